@@ -0,0 +1,19 @@
+CREATE TABLE Pet_Photos (
+  id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  pet_id BIGINT UNSIGNED NOT NULL,
+  storage_key VARCHAR(255) NOT NULL,
+  content_type VARCHAR(50) NOT NULL,
+  extension VARCHAR(10) NOT NULL,
+  size BIGINT NOT NULL DEFAULT 0,
+  width INT NOT NULL DEFAULT 0,
+  height INT NOT NULL DEFAULT 0,
+  position INT NOT NULL DEFAULT 0,
+  is_primary BOOLEAN NOT NULL DEFAULT FALSE,
+  crt_date DATETIME(3) NULL,
+  upt_date DATETIME(3) NULL,
+  INDEX idx_pet_photos_pet_id (pet_id),
+  CONSTRAINT fk_pet_photos_pet FOREIGN KEY (pet_id) REFERENCES Pets(id) ON DELETE CASCADE
+);
+
+-- Para ejecutar este archivo SQL con Go y una herramienta de migraciones como golang-migrate, usa el comando:
+-- migrate -path /root/adoption-system/backend/cmd/migrations -database "tu_cadena_de_conexion" up
\ No newline at end of file
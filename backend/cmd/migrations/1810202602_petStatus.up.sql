@@ -0,0 +1,12 @@
+ALTER TABLE Pets ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'available';
+
+UPDATE Pets SET status = 'adopted' WHERE is_adopted = TRUE;
+
+CREATE INDEX idx_pets_status ON Pets (status);
+CREATE INDEX idx_pets_species ON Pets (species);
+CREATE INDEX idx_pets_breed ON Pets (breed);
+CREATE INDEX idx_pets_birth_date ON Pets (birth_date);
+CREATE INDEX idx_pets_crt_date ON Pets (crt_date);
+
+-- Para ejecutar este archivo SQL con Go y una herramienta de migraciones como golang-migrate, usa el comando:
+-- migrate -path /root/adoption-system/backend/cmd/migrations -database "tu_cadena_de_conexion" up
\ No newline at end of file
@@ -0,0 +1,14 @@
+-- Índices FULLTEXT para la búsqueda de mascotas (GET /api/pets/search).
+-- InnoDB solo permite crear un índice FULLTEXT por sentencia.
+CREATE FULLTEXT INDEX ft_pets_search ON Pets (name, breed, description);
+CREATE FULLTEXT INDEX ft_pets_name_breed ON Pets (name, breed);
+
+-- En Postgres, el índice equivalente es:
+-- CREATE INDEX idx_pets_search ON Pets USING GIN ((
+--   setweight(to_tsvector('spanish', coalesce(name, '')), 'A') ||
+--   setweight(to_tsvector('spanish', coalesce(breed, '')), 'B') ||
+--   setweight(to_tsvector('spanish', coalesce(description, '')), 'C')
+-- ));
+
+-- Para ejecutar este archivo SQL con Go y una herramienta de migraciones como golang-migrate, usa el comando:
+-- migrate -path /root/adoption-system/backend/cmd/migrations -database "tu_cadena_de_conexion" up
\ No newline at end of file
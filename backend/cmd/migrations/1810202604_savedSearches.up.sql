@@ -0,0 +1,34 @@
+-- Búsquedas guardadas por los usuarios y su cola de alertas por email.
+CREATE TABLE Saved_Searches (
+  id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  user_id BIGINT UNSIGNED NOT NULL,
+  name VARCHAR(100) NOT NULL,
+  species VARCHAR(100) NOT NULL DEFAULT '',
+  breed VARCHAR(100) NOT NULL DEFAULT '',
+  age_min INT NULL,
+  age_max INT NULL,
+  keywords VARCHAR(200) NOT NULL DEFAULT '',
+  alerts_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+  crt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  upt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  INDEX idx_saved_searches_user (user_id),
+  INDEX idx_saved_searches_alerts (alerts_enabled, species),
+  CONSTRAINT fk_saved_searches_user FOREIGN KEY (user_id) REFERENCES Users(id) ON DELETE CASCADE
+);
+
+-- Mascotas que coinciden con una búsqueda guardada, pendientes (notified_at NULL) o ya enviadas en el resumen diario.
+CREATE TABLE Saved_Search_Matches (
+  id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  saved_search_id BIGINT UNSIGNED NOT NULL,
+  user_id BIGINT UNSIGNED NOT NULL,
+  pet_id BIGINT UNSIGNED NOT NULL,
+  notified_at DATETIME(3) NULL,
+  crt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  UNIQUE INDEX idx_search_pet (saved_search_id, pet_id),
+  INDEX idx_search_matches_user (user_id, notified_at),
+  CONSTRAINT fk_search_matches_search FOREIGN KEY (saved_search_id) REFERENCES Saved_Searches(id) ON DELETE CASCADE,
+  CONSTRAINT fk_search_matches_pet FOREIGN KEY (pet_id) REFERENCES Pets(id) ON DELETE CASCADE
+);
+
+-- Para ejecutar este archivo SQL con Go y una herramienta de migraciones como golang-migrate, usa el comando:
+-- migrate -path /root/adoption-system/backend/cmd/migrations -database "tu_cadena_de_conexion" up
\ No newline at end of file
@@ -0,0 +1,18 @@
+-- Rol de acceso de los usuarios (user, staff, admin). Los roles staff y admin se asignan manualmente:
+-- UPDATE Users SET role = 'staff' WHERE email = 'persona@refugio.org';
+ALTER TABLE Users ADD COLUMN role VARCHAR(20) NOT NULL DEFAULT 'user';
+
+-- Mascotas favoritas de cada usuario.
+CREATE TABLE Pet_Favorites (
+  id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  user_id BIGINT UNSIGNED NOT NULL,
+  pet_id BIGINT UNSIGNED NOT NULL,
+  crt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  UNIQUE INDEX idx_user_pet (user_id, pet_id),
+  INDEX idx_pet_favorites_pet (pet_id),
+  CONSTRAINT fk_pet_favorites_user FOREIGN KEY (user_id) REFERENCES Users(id) ON DELETE CASCADE,
+  CONSTRAINT fk_pet_favorites_pet FOREIGN KEY (pet_id) REFERENCES Pets(id) ON DELETE CASCADE
+);
+
+-- Para ejecutar este archivo SQL con Go y una herramienta de migraciones como golang-migrate, usa el comando:
+-- migrate -path /root/adoption-system/backend/cmd/migrations -database "tu_cadena_de_conexion" up
\ No newline at end of file
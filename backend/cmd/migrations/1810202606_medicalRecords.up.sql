@@ -0,0 +1,24 @@
+-- Historial médico de las mascotas: vacunas, tratamientos, visitas al veterinario,
+-- pesos, alergias y esterilización. Las notas (notes) son internas y solo las ve el personal.
+CREATE TABLE Pet_Medical_Records (
+  id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  pet_id BIGINT UNSIGNED NOT NULL,
+  type VARCHAR(20) NOT NULL,
+  name VARCHAR(150) NOT NULL DEFAULT '',
+  date DATE NOT NULL,
+  due_date DATE NULL,
+  end_date DATE NULL,
+  weight_kg DOUBLE NULL,
+  vet VARCHAR(150) NOT NULL DEFAULT '',
+  notes TEXT NULL,
+  created_by BIGINT UNSIGNED NULL,
+  crt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  upt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  INDEX idx_medical_records_pet (pet_id, date),
+  INDEX idx_medical_records_type (type),
+  INDEX idx_medical_records_due_date (due_date),
+  CONSTRAINT fk_medical_records_pet FOREIGN KEY (pet_id) REFERENCES Pets(id) ON DELETE CASCADE
+);
+
+-- Para ejecutar este archivo SQL con Go y una herramienta de migraciones como golang-migrate, usa el comando:
+-- migrate -path /root/adoption-system/backend/cmd/migrations -database "tu_cadena_de_conexion" up
\ No newline at end of file
@@ -0,0 +1,21 @@
+-- Registro de ejecuciones de las tareas programadas (recordatorios médicos, alertas de búsquedas...).
+-- La clave única (job, run_key) garantiza que cada periodo se procesa una sola vez aunque el backend se reinicie.
+-- Las ejecuciones de prueba (dry run) y las forzadas manualmente tienen run_key NULL.
+CREATE TABLE Job_Runs (
+  id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  job VARCHAR(50) NOT NULL,
+  run_key VARCHAR(50) NULL,
+  triggered_by VARCHAR(20) NOT NULL,
+  dry_run BOOLEAN NOT NULL DEFAULT FALSE,
+  status VARCHAR(20) NOT NULL,
+  items INT NOT NULL DEFAULT 0,
+  summary TEXT NULL,
+  error TEXT NULL,
+  started_at DATETIME(3) NOT NULL,
+  finished_at DATETIME(3) NULL,
+  UNIQUE INDEX idx_job_run_key (job, run_key),
+  INDEX idx_job_runs_started (job, started_at)
+);
+
+-- Para ejecutar este archivo SQL con Go y una herramienta de migraciones como golang-migrate, usa el comando:
+-- migrate -path /root/adoption-system/backend/cmd/migrations -database "tu_cadena_de_conexion" up
\ No newline at end of file
@@ -0,0 +1,8 @@
+-- Número de microchip (ISO 11784/11785, 15 dígitos) de las mascotas.
+-- Es opcional pero único: un mismo microchip no puede estar asignado a dos mascotas.
+ALTER TABLE Pets ADD COLUMN microchip VARCHAR(15) NULL;
+
+CREATE UNIQUE INDEX idx_pets_microchip ON Pets (microchip);
+
+-- Para ejecutar este archivo SQL con Go y una herramienta de migraciones como golang-migrate, usa el comando:
+-- migrate -path /root/adoption-system/backend/cmd/migrations -database "tu_cadena_de_conexion" up
\ No newline at end of file
@@ -0,0 +1,67 @@
+-- Color del pelaje de las mascotas, usado para buscar coincidencias con avisos de mascotas perdidas y encontradas.
+ALTER TABLE Pets ADD COLUMN color VARCHAR(50) NOT NULL DEFAULT '';
+
+-- Avisos de mascotas perdidas o encontradas enviados por la comunidad.
+-- Solo son públicos una vez aprobados (status = 'approved'); el email y teléfono del autor solo los ve el personal.
+CREATE TABLE Lost_Found_Reports (
+  id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  type VARCHAR(10) NOT NULL,
+  status VARCHAR(20) NOT NULL DEFAULT 'pending',
+  species VARCHAR(100) NOT NULL,
+  breed VARCHAR(100) NOT NULL DEFAULT '',
+  color VARCHAR(50) NOT NULL DEFAULT '',
+  microchip VARCHAR(15) NULL,
+  description TEXT NULL,
+  latitude DOUBLE NOT NULL,
+  longitude DOUBLE NOT NULL,
+  location VARCHAR(255) NOT NULL DEFAULT '',
+  seen_date DATE NOT NULL,
+  reporter_name VARCHAR(100) NOT NULL,
+  reporter_email VARCHAR(255) NOT NULL,
+  reporter_phone VARCHAR(30) NOT NULL DEFAULT '',
+  reporter_user_id BIGINT UNSIGNED NULL,
+  moderated_by BIGINT UNSIGNED NULL,
+  moderated_at DATETIME(3) NULL,
+  moderation_note VARCHAR(500) NOT NULL DEFAULT '',
+  crt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  upt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  INDEX idx_lost_found_status (status, crt_date),
+  INDEX idx_lost_found_type (type),
+  INDEX idx_lost_found_species (species),
+  INDEX idx_lost_found_microchip (microchip),
+  INDEX idx_lost_found_location (latitude, longitude),
+  CONSTRAINT fk_lost_found_reporter FOREIGN KEY (reporter_user_id) REFERENCES Users(id) ON DELETE SET NULL
+);
+
+-- Fotos de los avisos (mismo tratamiento que las fotos de mascotas: sin EXIF y con miniaturas).
+CREATE TABLE Lost_Found_Photos (
+  id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  report_id BIGINT UNSIGNED NOT NULL,
+  storage_key VARCHAR(255) NOT NULL,
+  content_type VARCHAR(50) NOT NULL,
+  extension VARCHAR(10) NOT NULL,
+  size BIGINT NOT NULL DEFAULT 0,
+  width INT NOT NULL DEFAULT 0,
+  height INT NOT NULL DEFAULT 0,
+  crt_date DATETIME(3) NULL,
+  INDEX idx_lost_found_photos_report (report_id),
+  CONSTRAINT fk_lost_found_photos_report FOREIGN KEY (report_id) REFERENCES Lost_Found_Reports(id) ON DELETE CASCADE
+);
+
+-- Mascotas del refugio candidatas a ser el animal de un aviso (por microchip, especie, raza y color).
+CREATE TABLE Lost_Found_Matches (
+  id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  report_id BIGINT UNSIGNED NOT NULL,
+  pet_id BIGINT UNSIGNED NOT NULL,
+  score INT NOT NULL,
+  reasons VARCHAR(100) NOT NULL,
+  notified_at DATETIME(3) NULL,
+  crt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  UNIQUE INDEX idx_report_pet (report_id, pet_id),
+  INDEX idx_lost_found_matches_pet (pet_id),
+  CONSTRAINT fk_lost_found_matches_report FOREIGN KEY (report_id) REFERENCES Lost_Found_Reports(id) ON DELETE CASCADE,
+  CONSTRAINT fk_lost_found_matches_pet FOREIGN KEY (pet_id) REFERENCES Pets(id) ON DELETE CASCADE
+);
+
+-- Para ejecutar este archivo SQL con Go y una herramienta de migraciones como golang-migrate, usa el comando:
+-- migrate -path /root/adoption-system/backend/cmd/migrations -database "tu_cadena_de_conexion" up
\ No newline at end of file
@@ -0,0 +1,55 @@
+-- Organizaciones (refugios) propietarias de mascotas, especies y personal.
+-- Cada organización tiene su cuestionario de adopción y su identidad de envío de emails.
+CREATE TABLE Organizations (
+  id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  name VARCHAR(150) NOT NULL,
+  slug VARCHAR(60) NOT NULL,
+  email VARCHAR(255) NOT NULL DEFAULT '',
+  phone VARCHAR(30) NOT NULL DEFAULT '',
+  address VARCHAR(255) NOT NULL DEFAULT '',
+  website VARCHAR(255) NOT NULL DEFAULT '',
+  sender_name VARCHAR(100) NOT NULL DEFAULT '',
+  sender_email VARCHAR(255) NOT NULL DEFAULT '',
+  reply_to VARCHAR(255) NOT NULL DEFAULT '',
+  adoption_questionnaire JSON NULL,
+  crt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  upt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  UNIQUE INDEX idx_organizations_slug (slug)
+);
+
+-- Organización por defecto: pasa a ser la propietaria de todos los datos existentes.
+INSERT INTO Organizations (id, name, slug, adoption_questionnaire) VALUES (1, 'Adoption System', 'adoption-system', JSON_ARRAY());
+
+-- Personal de cada organización. Los responsables (manager) gestionan la configuración y los miembros.
+CREATE TABLE Organization_Members (
+  id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  organization_id BIGINT UNSIGNED NOT NULL,
+  user_id BIGINT UNSIGNED NOT NULL,
+  role VARCHAR(20) NOT NULL DEFAULT 'staff',
+  crt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  UNIQUE INDEX idx_org_user (organization_id, user_id),
+  INDEX idx_organization_members_user (user_id),
+  CONSTRAINT fk_organization_members_org FOREIGN KEY (organization_id) REFERENCES Organizations(id) ON DELETE CASCADE,
+  CONSTRAINT fk_organization_members_user FOREIGN KEY (user_id) REFERENCES Users(id) ON DELETE CASCADE
+);
+
+-- El personal existente pasa a la organización por defecto; los administradores como responsables.
+INSERT INTO Organization_Members (organization_id, user_id, role)
+SELECT 1, id, CASE WHEN role = 'admin' THEN 'manager' ELSE 'staff' END FROM Users WHERE role IN ('staff', 'admin');
+
+-- Las mascotas existentes pertenecen a la organización por defecto.
+ALTER TABLE Pets
+  ADD COLUMN organization_id BIGINT UNSIGNED NOT NULL DEFAULT 1,
+  ADD INDEX idx_pets_organization (organization_id),
+  ADD CONSTRAINT fk_pets_organization FOREIGN KEY (organization_id) REFERENCES Organizations(id);
+
+-- Las especies son propias de cada organización: el nombre solo es único dentro de ella.
+-- "name" es el índice único creado por la restricción UNIQUE original de la columna.
+ALTER TABLE Species
+  ADD COLUMN organization_id BIGINT UNSIGNED NOT NULL DEFAULT 1,
+  DROP INDEX name,
+  ADD UNIQUE INDEX idx_species_org_name (organization_id, name),
+  ADD CONSTRAINT fk_species_organization FOREIGN KEY (organization_id) REFERENCES Organizations(id);
+
+-- Para ejecutar este archivo SQL con Go y una herramienta de migraciones como golang-migrate, usa el comando:
+-- migrate -path /root/adoption-system/backend/cmd/migrations -database "tu_cadena_de_conexion" up
\ No newline at end of file
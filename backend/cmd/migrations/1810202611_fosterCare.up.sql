@@ -0,0 +1,74 @@
+-- Casas de acogida: familias que alojan temporalmente mascotas de una organización.
+-- Cada casa pertenece a un usuario (el acogedor) y tiene una capacidad máxima de mascotas a la vez.
+-- accepted_species es una lista JSON de especies aceptadas; vacía acepta cualquier especie.
+CREATE TABLE Foster_Homes (
+  id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  organization_id BIGINT UNSIGNED NOT NULL,
+  user_id BIGINT UNSIGNED NOT NULL,
+  capacity INT NOT NULL DEFAULT 1,
+  accepted_species JSON NULL,
+  home_type VARCHAR(20) NOT NULL,
+  has_garden BOOLEAN NOT NULL DEFAULT FALSE,
+  has_children BOOLEAN NOT NULL DEFAULT FALSE,
+  has_other_pets BOOLEAN NOT NULL DEFAULT FALSE,
+  address VARCHAR(255) NOT NULL DEFAULT '',
+  phone VARCHAR(30) NOT NULL DEFAULT '',
+  notes TEXT NULL,
+  active BOOLEAN NOT NULL DEFAULT TRUE,
+  crt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  upt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  UNIQUE INDEX idx_foster_org_user (organization_id, user_id),
+  INDEX idx_foster_homes_user (user_id),
+  CONSTRAINT fk_foster_homes_organization FOREIGN KEY (organization_id) REFERENCES Organizations(id) ON DELETE CASCADE,
+  CONSTRAINT fk_foster_homes_user FOREIGN KEY (user_id) REFERENCES Users(id) ON DELETE CASCADE
+);
+
+-- Acogidas: estancias de una mascota en una casa de acogida. Una acogida está en curso mientras end_date es NULL;
+-- cada mascota tiene como máximo una acogida en curso (lo comprueba la aplicación).
+CREATE TABLE Foster_Placements (
+  id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  organization_id BIGINT UNSIGNED NOT NULL,
+  pet_id BIGINT UNSIGNED NOT NULL,
+  foster_home_id BIGINT UNSIGNED NOT NULL,
+  start_date DATE NOT NULL,
+  expected_end_date DATE NULL,
+  end_date DATE NULL,
+  notes TEXT NULL,
+  created_by BIGINT UNSIGNED NULL,
+  crt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  upt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  INDEX idx_foster_placements_organization (organization_id, start_date),
+  INDEX idx_foster_placements_pet (pet_id, end_date),
+  INDEX idx_foster_placements_home (foster_home_id, end_date),
+  CONSTRAINT fk_foster_placements_pet FOREIGN KEY (pet_id) REFERENCES Pets(id) ON DELETE CASCADE,
+  CONSTRAINT fk_foster_placements_home FOREIGN KEY (foster_home_id) REFERENCES Foster_Homes(id) ON DELETE CASCADE
+);
+
+-- Novedades que publican los acogedores sobre las mascotas que cuidan.
+CREATE TABLE Foster_Updates (
+  id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  placement_id BIGINT UNSIGNED NOT NULL,
+  author_user_id BIGINT UNSIGNED NOT NULL,
+  message TEXT NOT NULL,
+  crt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  INDEX idx_foster_updates_placement (placement_id, crt_date),
+  CONSTRAINT fk_foster_updates_placement FOREIGN KEY (placement_id) REFERENCES Foster_Placements(id) ON DELETE CASCADE
+);
+
+-- Fotos de las novedades (mismo tratamiento que las fotos de mascotas: sin EXIF y con miniaturas).
+CREATE TABLE Foster_Update_Photos (
+  id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  update_id BIGINT UNSIGNED NOT NULL,
+  storage_key VARCHAR(255) NOT NULL,
+  content_type VARCHAR(50) NOT NULL,
+  extension VARCHAR(10) NOT NULL,
+  size BIGINT NOT NULL DEFAULT 0,
+  width INT NOT NULL DEFAULT 0,
+  height INT NOT NULL DEFAULT 0,
+  crt_date DATETIME(3) NULL,
+  INDEX idx_foster_update_photos_update (update_id),
+  CONSTRAINT fk_foster_update_photos_update FOREIGN KEY (update_id) REFERENCES Foster_Updates(id) ON DELETE CASCADE
+);
+
+-- Para ejecutar este archivo SQL con Go y una herramienta de migraciones como golang-migrate, usa el comando:
+-- migrate -path /root/adoption-system/backend/cmd/migrations -database "tu_cadena_de_conexion" up
\ No newline at end of file
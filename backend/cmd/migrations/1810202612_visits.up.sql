@@ -0,0 +1,57 @@
+-- Franjas de disponibilidad para visitas de presentación (conocer a la mascota antes de adoptar).
+-- Cada franja admite una sola visita programada: booked se marca de forma atómica al reservar
+-- (UPDATE ... WHERE booked = FALSE), lo que impide las reservas dobles. pet_id NULL admite cualquier mascota.
+CREATE TABLE Visit_Slots (
+  id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  organization_id BIGINT UNSIGNED NOT NULL,
+  pet_id BIGINT UNSIGNED NULL,
+  start_time DATETIME(3) NOT NULL,
+  end_time DATETIME(3) NOT NULL,
+  location VARCHAR(255) NOT NULL DEFAULT '',
+  booked BOOLEAN NOT NULL DEFAULT FALSE,
+  created_by BIGINT UNSIGNED NULL,
+  crt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  upt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  INDEX idx_visit_slots_organization (organization_id, start_time),
+  INDEX idx_visit_slots_pet (pet_id, start_time),
+  CONSTRAINT fk_visit_slots_organization FOREIGN KEY (organization_id) REFERENCES Organizations(id) ON DELETE CASCADE,
+  CONSTRAINT fk_visit_slots_pet FOREIGN KEY (pet_id) REFERENCES Pets(id) ON DELETE CASCADE
+);
+
+-- Visitas reservadas. La hora y el lugar se copian de la franja, de modo que la visita los conserva
+-- si la franja se elimina (slot_id pasa a NULL). sequence es la revisión del evento de calendario (.ics).
+-- application_id enlaza la visita con una solicitud de adopción; es una referencia sin clave foránea.
+CREATE TABLE Visits (
+  id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  organization_id BIGINT UNSIGNED NOT NULL,
+  slot_id BIGINT UNSIGNED NULL,
+  pet_id BIGINT UNSIGNED NOT NULL,
+  start_time DATETIME(3) NOT NULL,
+  end_time DATETIME(3) NOT NULL,
+  location VARCHAR(255) NOT NULL DEFAULT '',
+  user_id BIGINT UNSIGNED NULL,
+  visitor_name VARCHAR(100) NOT NULL,
+  visitor_email VARCHAR(255) NOT NULL,
+  visitor_phone VARCHAR(30) NOT NULL DEFAULT '',
+  application_id BIGINT UNSIGNED NULL,
+  notes TEXT NULL,
+  status VARCHAR(20) NOT NULL DEFAULT 'scheduled',
+  sequence INT NOT NULL DEFAULT 0,
+  cancelled_at DATETIME(3) NULL,
+  cancel_reason VARCHAR(500) NOT NULL DEFAULT '',
+  reminder_sent_at DATETIME(3) NULL,
+  crt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  upt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  INDEX idx_visits_organization (organization_id, start_time),
+  INDEX idx_visits_pet (pet_id, status, start_time),
+  INDEX idx_visits_reminder (status, reminder_sent_at, start_time),
+  INDEX idx_visits_user (user_id),
+  INDEX idx_visits_application (application_id),
+  CONSTRAINT fk_visits_organization FOREIGN KEY (organization_id) REFERENCES Organizations(id) ON DELETE CASCADE,
+  CONSTRAINT fk_visits_slot FOREIGN KEY (slot_id) REFERENCES Visit_Slots(id) ON DELETE SET NULL,
+  CONSTRAINT fk_visits_pet FOREIGN KEY (pet_id) REFERENCES Pets(id) ON DELETE CASCADE,
+  CONSTRAINT fk_visits_user FOREIGN KEY (user_id) REFERENCES Users(id) ON DELETE SET NULL
+);
+
+-- Para ejecutar este archivo SQL con Go y una herramienta de migraciones como golang-migrate, usa el comando:
+-- migrate -path /root/adoption-system/backend/cmd/migrations -database "tu_cadena_de_conexion" up
\ No newline at end of file
@@ -0,0 +1,46 @@
+-- Adopciones finalizadas. Cada adopción guarda el contrato PDF generado en el almacenamiento
+-- (contract_key) junto con su huella SHA-256 (contract_hash), que permite detectar modificaciones posteriores.
+-- El contrato es un documento legal: no se puede eliminar la mascota ni el adoptante mientras exista la adopción.
+CREATE TABLE Adoptions (
+  id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  organization_id BIGINT UNSIGNED NOT NULL,
+  pet_id BIGINT UNSIGNED NOT NULL,
+  adopter_user_id BIGINT UNSIGNED NOT NULL,
+  adoption_date DATE NOT NULL,
+  fee_cents BIGINT NOT NULL DEFAULT 0,
+  currency VARCHAR(3) NOT NULL DEFAULT 'EUR',
+  clauses JSON NULL,
+  notes TEXT NULL,
+  contract_key VARCHAR(255) NOT NULL DEFAULT '',
+  contract_hash CHAR(64) NOT NULL DEFAULT '',
+  contract_generated_at DATETIME(3) NULL,
+  contract_sent_at DATETIME(3) NULL,
+  created_by BIGINT UNSIGNED NULL,
+  crt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  upt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  INDEX idx_adoptions_organization (organization_id, adoption_date),
+  INDEX idx_adoptions_pet (pet_id),
+  INDEX idx_adoptions_adopter (adopter_user_id),
+  CONSTRAINT fk_adoptions_organization FOREIGN KEY (organization_id) REFERENCES Organizations(id) ON DELETE CASCADE,
+  CONSTRAINT fk_adoptions_pet FOREIGN KEY (pet_id) REFERENCES Pets(id) ON DELETE RESTRICT,
+  CONSTRAINT fk_adoptions_adopter FOREIGN KEY (adopter_user_id) REFERENCES Users(id) ON DELETE RESTRICT
+);
+
+-- Plantilla del contrato de adopción de cada organización. Los textos son plantillas de Go
+-- (p. ej. {{.Pet.Name}}); las organizaciones sin plantilla usan la plantilla por defecto.
+CREATE TABLE Contract_Templates (
+  id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  organization_id BIGINT UNSIGNED NOT NULL,
+  title VARCHAR(200) NOT NULL,
+  intro TEXT NULL,
+  clauses JSON NULL,
+  closing TEXT NULL,
+  updated_by BIGINT UNSIGNED NULL,
+  crt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  upt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  UNIQUE INDEX idx_contract_templates_organization (organization_id),
+  CONSTRAINT fk_contract_templates_organization FOREIGN KEY (organization_id) REFERENCES Organizations(id) ON DELETE CASCADE
+);
+
+-- Para ejecutar este archivo SQL con Go y una herramienta de migraciones como golang-migrate, usa el comando:
+-- migrate -path /root/adoption-system/backend/cmd/migrations -database "tu_cadena_de_conexion" up
\ No newline at end of file
@@ -0,0 +1,71 @@
+-- Tarifas de adopción de cada organización por especie y rango de edad (en meses).
+-- Una especie vacía aplica a todas las especies y un max_age_months nulo no tiene límite superior.
+CREATE TABLE Fee_Schedules (
+  id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  organization_id BIGINT UNSIGNED NOT NULL,
+  name VARCHAR(100) NOT NULL,
+  species VARCHAR(100) NOT NULL DEFAULT '',
+  min_age_months INT NOT NULL DEFAULT 0,
+  max_age_months INT NULL,
+  amount_cents BIGINT NOT NULL,
+  currency VARCHAR(3) NOT NULL DEFAULT 'EUR',
+  crt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  upt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  INDEX idx_fee_schedules_organization (organization_id, species),
+  CONSTRAINT fk_fee_schedules_organization FOREIGN KEY (organization_id) REFERENCES Organizations(id) ON DELETE CASCADE
+);
+
+-- Pagos de tasas de adopción a través de la página de pago alojada del proveedor (checkout).
+-- El estado solo avanza con los webhooks firmados del proveedor; refunded_cents nunca supera amount_cents.
+CREATE TABLE Payments (
+  id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  organization_id BIGINT UNSIGNED NOT NULL,
+  adoption_id BIGINT UNSIGNED NOT NULL,
+  provider VARCHAR(30) NOT NULL,
+  checkout_id VARCHAR(100) NULL,
+  checkout_url VARCHAR(500) NOT NULL DEFAULT '',
+  amount_cents BIGINT NOT NULL,
+  currency VARCHAR(3) NOT NULL,
+  status VARCHAR(20) NOT NULL DEFAULT 'pending',
+  refunded_cents BIGINT NOT NULL DEFAULT 0,
+  failure_reason VARCHAR(255) NOT NULL DEFAULT '',
+  paid_at DATETIME(3) NULL,
+  created_by BIGINT UNSIGNED NULL,
+  crt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  upt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  INDEX idx_payments_organization (organization_id, crt_date),
+  INDEX idx_payments_adoption (adoption_id),
+  INDEX idx_payments_status (status),
+  UNIQUE INDEX idx_payments_checkout (provider, checkout_id),
+  CONSTRAINT fk_payments_organization FOREIGN KEY (organization_id) REFERENCES Organizations(id) ON DELETE CASCADE,
+  CONSTRAINT fk_payments_adoption FOREIGN KEY (adoption_id) REFERENCES Adoptions(id) ON DELETE CASCADE
+);
+
+-- Reembolsos totales o parciales de los pagos.
+CREATE TABLE Payment_Refunds (
+  id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  payment_id BIGINT UNSIGNED NOT NULL,
+  provider_refund_id VARCHAR(100) NOT NULL DEFAULT '',
+  amount_cents BIGINT NOT NULL,
+  reason VARCHAR(500) NOT NULL DEFAULT '',
+  created_by BIGINT UNSIGNED NULL,
+  crt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  INDEX idx_payment_refunds_payment (payment_id),
+  CONSTRAINT fk_payment_refunds_payment FOREIGN KEY (payment_id) REFERENCES Payments(id) ON DELETE CASCADE
+);
+
+-- Eventos de webhook ya procesados. El índice único (provider, event_id) hace que los webhooks
+-- duplicados (el proveedor reintenta los envíos) se ignoren en lugar de aplicarse dos veces.
+CREATE TABLE Payment_Events (
+  id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  provider VARCHAR(30) NOT NULL,
+  event_id VARCHAR(100) NOT NULL,
+  type VARCHAR(30) NOT NULL,
+  payment_id BIGINT UNSIGNED NULL,
+  received_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  UNIQUE INDEX idx_payment_event (provider, event_id),
+  CONSTRAINT fk_payment_events_payment FOREIGN KEY (payment_id) REFERENCES Payments(id) ON DELETE SET NULL
+);
+
+-- Para ejecutar este archivo SQL con Go y una herramienta de migraciones como golang-migrate, usa el comando:
+-- migrate -path /root/adoption-system/backend/cmd/migrations -database "tu_cadena_de_conexion" up
\ No newline at end of file
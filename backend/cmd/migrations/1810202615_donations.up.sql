@@ -0,0 +1,55 @@
+-- Donaciones puntuales o periódicas (mensuales o anuales) de los usuarios a una organización,
+-- opcionalmente destinadas a una mascota (apadrinamiento). Cada cobro es un pago en Payments.
+CREATE TABLE Donations (
+  id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  organization_id BIGINT UNSIGNED NOT NULL,
+  donor_user_id BIGINT UNSIGNED NOT NULL,
+  pet_id BIGINT UNSIGNED NULL,
+  frequency VARCHAR(10) NOT NULL,
+  amount_cents BIGINT NOT NULL,
+  currency VARCHAR(3) NOT NULL,
+  status VARCHAR(20) NOT NULL DEFAULT 'pending',
+  tax_id VARCHAR(20) NOT NULL DEFAULT '',
+  message VARCHAR(500) NOT NULL DEFAULT '',
+  next_charge_date DATE NULL,
+  cancelled_at DATETIME(3) NULL,
+  crt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  upt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  INDEX idx_donations_organization (organization_id, crt_date),
+  INDEX idx_donations_donor (donor_user_id),
+  INDEX idx_donations_pet (pet_id),
+  INDEX idx_donations_due (status, next_charge_date),
+  CONSTRAINT fk_donations_organization FOREIGN KEY (organization_id) REFERENCES Organizations(id) ON DELETE CASCADE,
+  CONSTRAINT fk_donations_donor FOREIGN KEY (donor_user_id) REFERENCES Users(id) ON DELETE RESTRICT,
+  CONSTRAINT fk_donations_pet FOREIGN KEY (pet_id) REFERENCES Pets(id) ON DELETE SET NULL
+);
+
+-- Cada pago pertenece a una adopción o a una donación.
+ALTER TABLE Payments
+  MODIFY adoption_id BIGINT UNSIGNED NULL,
+  ADD COLUMN donation_id BIGINT UNSIGNED NULL AFTER adoption_id,
+  ADD INDEX idx_payments_donation (donation_id),
+  ADD CONSTRAINT fk_payments_donation FOREIGN KEY (donation_id) REFERENCES Donations(id) ON DELETE CASCADE;
+
+-- Certificados anuales de donaciones. Se emite uno por organización, donante, año y moneda
+-- cuando el año ha terminado; el PDF se guarda en el almacenamiento junto con su SHA-256.
+CREATE TABLE Donation_Receipts (
+  id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  organization_id BIGINT UNSIGNED NOT NULL,
+  donor_user_id BIGINT UNSIGNED NOT NULL,
+  year INT NOT NULL,
+  currency VARCHAR(3) NOT NULL,
+  total_cents BIGINT NOT NULL,
+  number VARCHAR(30) NOT NULL DEFAULT '',
+  file_key VARCHAR(255) NOT NULL DEFAULT '',
+  file_hash CHAR(64) NOT NULL DEFAULT '',
+  sent_at DATETIME(3) NULL,
+  crt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  UNIQUE INDEX idx_donation_receipt (organization_id, donor_user_id, year, currency),
+  INDEX idx_donation_receipts_donor (donor_user_id),
+  CONSTRAINT fk_donation_receipts_organization FOREIGN KEY (organization_id) REFERENCES Organizations(id) ON DELETE CASCADE,
+  CONSTRAINT fk_donation_receipts_donor FOREIGN KEY (donor_user_id) REFERENCES Users(id) ON DELETE RESTRICT
+);
+
+-- Para ejecutar este archivo SQL con Go y una herramienta de migraciones como golang-migrate, usa el comando:
+-- migrate -path /root/adoption-system/backend/cmd/migrations -database "tu_cadena_de_conexion" up
\ No newline at end of file
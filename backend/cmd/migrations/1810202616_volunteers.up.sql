@@ -0,0 +1,83 @@
+-- Voluntarios de cada organización. Un usuario es voluntario de una organización como máximo una vez.
+-- skills es una lista JSON de habilidades declaradas (p. ej. "paseos", "limpieza"); notes es interna del personal.
+CREATE TABLE Volunteers (
+  id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  organization_id BIGINT UNSIGNED NOT NULL,
+  user_id BIGINT UNSIGNED NOT NULL,
+  phone VARCHAR(30) NOT NULL DEFAULT '',
+  bio TEXT NULL,
+  skills JSON NULL,
+  emergency_contact VARCHAR(100) NOT NULL DEFAULT '',
+  emergency_phone VARCHAR(30) NOT NULL DEFAULT '',
+  notes TEXT NULL,
+  active BOOLEAN NOT NULL DEFAULT TRUE,
+  crt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  upt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  UNIQUE INDEX idx_volunteer_org_user (organization_id, user_id),
+  INDEX idx_volunteers_user (user_id),
+  CONSTRAINT fk_volunteers_organization FOREIGN KEY (organization_id) REFERENCES Organizations(id) ON DELETE CASCADE,
+  CONSTRAINT fk_volunteers_user FOREIGN KEY (user_id) REFERENCES Users(id) ON DELETE CASCADE
+);
+
+-- Certificaciones y formaciones de los voluntarios. expires_on NULL indica que no caduca.
+CREATE TABLE Volunteer_Certifications (
+  id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  volunteer_id BIGINT UNSIGNED NOT NULL,
+  name VARCHAR(100) NOT NULL,
+  issued_by VARCHAR(150) NOT NULL DEFAULT '',
+  issued_on DATE NULL,
+  expires_on DATE NULL,
+  created_by BIGINT UNSIGNED NULL,
+  crt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  INDEX idx_volunteer_certifications_volunteer (volunteer_id),
+  CONSTRAINT fk_volunteer_certifications_volunteer FOREIGN KEY (volunteer_id) REFERENCES Volunteers(id) ON DELETE CASCADE
+);
+
+-- Turnos de voluntariado. signed_up cuenta las inscripciones que ocupan plaza y se actualiza de forma
+-- atómica (UPDATE ... WHERE signed_up < capacity), lo que impide superar la capacidad del turno.
+CREATE TABLE Volunteer_Shifts (
+  id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  organization_id BIGINT UNSIGNED NOT NULL,
+  title VARCHAR(150) NOT NULL,
+  description TEXT NULL,
+  required_skill VARCHAR(100) NOT NULL DEFAULT '',
+  required_certification VARCHAR(100) NOT NULL DEFAULT '',
+  start_time DATETIME(3) NOT NULL,
+  end_time DATETIME(3) NOT NULL,
+  location VARCHAR(255) NOT NULL DEFAULT '',
+  capacity INT NOT NULL,
+  signed_up INT NOT NULL DEFAULT 0,
+  created_by BIGINT UNSIGNED NULL,
+  crt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  upt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  INDEX idx_volunteer_shifts_organization (organization_id, start_time),
+  INDEX idx_volunteer_shifts_start (start_time),
+  CONSTRAINT fk_volunteer_shifts_organization FOREIGN KEY (organization_id) REFERENCES Organizations(id) ON DELETE CASCADE
+);
+
+-- Inscripciones de voluntarios en turnos, con su asistencia. Cada voluntario tiene una inscripción por turno;
+-- al volver a apuntarse tras cancelar se reutiliza. minutes_worked NULL cuenta la duración del turno.
+CREATE TABLE Volunteer_Signups (
+  id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  organization_id BIGINT UNSIGNED NOT NULL,
+  shift_id BIGINT UNSIGNED NOT NULL,
+  volunteer_id BIGINT UNSIGNED NOT NULL,
+  user_id BIGINT UNSIGNED NOT NULL,
+  status VARCHAR(20) NOT NULL DEFAULT 'signed_up',
+  minutes_worked INT NULL,
+  cancelled_at DATETIME(3) NULL,
+  attendance_by BIGINT UNSIGNED NULL,
+  reminder_sent_at DATETIME(3) NULL,
+  crt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  upt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  UNIQUE INDEX idx_signup_shift_volunteer (shift_id, volunteer_id),
+  INDEX idx_volunteer_signups_organization (organization_id, status),
+  INDEX idx_volunteer_signups_user (user_id),
+  INDEX idx_volunteer_signups_reminder (status, reminder_sent_at),
+  CONSTRAINT fk_volunteer_signups_shift FOREIGN KEY (shift_id) REFERENCES Volunteer_Shifts(id) ON DELETE CASCADE,
+  CONSTRAINT fk_volunteer_signups_volunteer FOREIGN KEY (volunteer_id) REFERENCES Volunteers(id) ON DELETE CASCADE,
+  CONSTRAINT fk_volunteer_signups_user FOREIGN KEY (user_id) REFERENCES Users(id) ON DELETE CASCADE
+);
+
+-- Para ejecutar este archivo SQL con Go y una herramienta de migraciones como golang-migrate, usa el comando:
+-- migrate -path /root/adoption-system/backend/cmd/migrations -database "tu_cadena_de_conexion" up
\ No newline at end of file
@@ -0,0 +1,45 @@
+-- Ubicaciones físicas de cada organización: edificios, salas y jaulas. Los edificios no tienen padre,
+-- las salas pertenecen a un edificio y las jaulas a una sala o a un edificio. Solo las jaulas tienen
+-- capacidad propia; la de edificios y salas se calcula sumando sus jaulas. accepted_species es una lista
+-- JSON de especies admitidas (vacía o NULL admite cualquiera) que se aplica también a las ubicaciones hijas.
+CREATE TABLE Locations (
+  id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  organization_id BIGINT UNSIGNED NOT NULL,
+  parent_id BIGINT UNSIGNED NULL,
+  kind VARCHAR(20) NOT NULL,
+  name VARCHAR(100) NOT NULL,
+  capacity INT NOT NULL DEFAULT 0,
+  accepted_species JSON NULL,
+  active BOOLEAN NOT NULL DEFAULT TRUE,
+  notes TEXT NULL,
+  crt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  upt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  INDEX idx_locations_organization (organization_id, kind),
+  INDEX idx_locations_parent (parent_id),
+  CONSTRAINT fk_locations_organization FOREIGN KEY (organization_id) REFERENCES Organizations(id) ON DELETE CASCADE,
+  CONSTRAINT fk_locations_parent FOREIGN KEY (parent_id) REFERENCES Locations(id)
+);
+
+-- Estancias de las mascotas en las jaulas. La estancia abierta (ended_at NULL) es donde vive la mascota;
+-- al trasladarla se cierra con end_reason 'traslado' y se abre otra, de modo que las cerradas forman el
+-- historial. Las estancias se cierran también al adoptar la mascota o al llevarla a una casa de acogida.
+CREATE TABLE Pet_Locations (
+  id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  organization_id BIGINT UNSIGNED NOT NULL,
+  pet_id BIGINT UNSIGNED NOT NULL,
+  location_id BIGINT UNSIGNED NOT NULL,
+  started_at DATETIME(3) NOT NULL,
+  ended_at DATETIME(3) NULL,
+  reason VARCHAR(255) NOT NULL DEFAULT '',
+  end_reason VARCHAR(255) NOT NULL DEFAULT '',
+  moved_by BIGINT UNSIGNED NULL,
+  crt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  INDEX idx_pet_locations_pet (pet_id, ended_at),
+  INDEX idx_pet_locations_location (location_id, ended_at),
+  INDEX idx_pet_locations_organization (organization_id, ended_at),
+  CONSTRAINT fk_pet_locations_pet FOREIGN KEY (pet_id) REFERENCES Pets(id) ON DELETE CASCADE,
+  CONSTRAINT fk_pet_locations_location FOREIGN KEY (location_id) REFERENCES Locations(id) ON DELETE CASCADE
+);
+
+-- Para ejecutar este archivo SQL con Go y una herramienta de migraciones como golang-migrate, usa el comando:
+-- migrate -path /root/adoption-system/backend/cmd/migrations -database "tu_cadena_de_conexion" up
\ No newline at end of file
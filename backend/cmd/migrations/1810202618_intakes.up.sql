@@ -0,0 +1,36 @@
+-- Ingresos de mascotas: cómo y cuándo llegó cada mascota al refugio. El ingreso se crea en la misma
+-- transacción que la mascota, por lo que cada mascota tiene como máximo un ingreso. Los datos de la persona
+-- que entrega el animal solo se rellenan en las entregas de propietarios (owner_surrender), transfer_from en
+-- los traslados y la ubicación del hallazgo en los animales callejeros (stray).
+CREATE TABLE Intakes (
+  id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  organization_id BIGINT UNSIGNED NOT NULL,
+  pet_id BIGINT UNSIGNED NOT NULL,
+  source VARCHAR(20) NOT NULL,
+  intake_date DATE NOT NULL,
+  `condition` VARCHAR(20) NOT NULL,
+  condition_notes TEXT NULL,
+  surrenderer_name VARCHAR(150) NOT NULL DEFAULT '',
+  surrenderer_email VARCHAR(255) NOT NULL DEFAULT '',
+  surrenderer_phone VARCHAR(30) NOT NULL DEFAULT '',
+  surrenderer_address VARCHAR(255) NOT NULL DEFAULT '',
+  surrender_reason TEXT NULL,
+  transfer_from VARCHAR(150) NOT NULL DEFAULT '',
+  found_location VARCHAR(255) NOT NULL DEFAULT '',
+  found_latitude DOUBLE NULL,
+  found_longitude DOUBLE NULL,
+  notes TEXT NULL,
+  created_by BIGINT UNSIGNED NULL,
+  crt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  upt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  UNIQUE INDEX idx_intakes_pet (pet_id),
+  INDEX idx_intakes_organization (organization_id, intake_date),
+  CONSTRAINT fk_intakes_organization FOREIGN KEY (organization_id) REFERENCES Organizations(id) ON DELETE CASCADE,
+  CONSTRAINT fk_intakes_pet FOREIGN KEY (pet_id) REFERENCES Pets(id) ON DELETE CASCADE
+);
+
+-- Las estadísticas de salidas cuentan las mascotas adoptadas por mes de adopción.
+CREATE INDEX idx_pets_adopt_date ON Pets (organization_id, is_adopted, adopt_date);
+
+-- Para ejecutar este archivo SQL con Go y una herramienta de migraciones como golang-migrate, usa el comando:
+-- migrate -path /root/adoption-system/backend/cmd/migrations -database "tu_cadena_de_conexion" up
\ No newline at end of file
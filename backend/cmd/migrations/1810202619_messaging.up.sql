@@ -0,0 +1,61 @@
+-- Mensajería entre usuarios y el personal de las protectoras. Cada conversación tiene un único usuario y la
+-- comparte todo el personal de la organización, que puede asignarla a un miembro y cerrarla; un mensaje nuevo
+-- la vuelve a abrir. application_id es una referencia a la solicitud de adopción, sin clave foránea.
+CREATE TABLE Conversations (
+  id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  organization_id BIGINT UNSIGNED NOT NULL,
+  user_id BIGINT UNSIGNED NOT NULL,
+  pet_id BIGINT UNSIGNED NULL,
+  application_id BIGINT UNSIGNED NULL,
+  subject VARCHAR(200) NOT NULL,
+  status VARCHAR(10) NOT NULL DEFAULT 'open',
+  assigned_to BIGINT UNSIGNED NULL,
+  last_message_at DATETIME(3) NOT NULL,
+  closed_at DATETIME(3) NULL,
+  created_by BIGINT UNSIGNED NULL,
+  crt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  upt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  INDEX idx_conversations_inbox (organization_id, status, last_message_at),
+  INDEX idx_conversations_user (user_id, last_message_at),
+  INDEX idx_conversations_pet (pet_id),
+  INDEX idx_conversations_application (application_id),
+  INDEX idx_conversations_assigned (assigned_to),
+  CONSTRAINT fk_conversations_organization FOREIGN KEY (organization_id) REFERENCES Organizations(id) ON DELETE CASCADE,
+  CONSTRAINT fk_conversations_user FOREIGN KEY (user_id) REFERENCES Users(id) ON DELETE CASCADE,
+  CONSTRAINT fk_conversations_pet FOREIGN KEY (pet_id) REFERENCES Pets(id) ON DELETE SET NULL,
+  CONSTRAINT fk_conversations_assigned FOREIGN KEY (assigned_to) REFERENCES Users(id) ON DELETE SET NULL
+);
+
+-- Mensajes de cada conversación. read_at es el acuse de lectura del otro lado y notified_at indica que el
+-- mensaje, aún sin leer pasado MESSAGE_NOTIFY_DELAY, ya se ha avisado por correo.
+CREATE TABLE Messages (
+  id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  conversation_id BIGINT UNSIGNED NOT NULL,
+  sender_id BIGINT UNSIGNED NOT NULL,
+  from_staff BOOLEAN NOT NULL DEFAULT FALSE,
+  body TEXT NOT NULL,
+  read_at DATETIME(3) NULL,
+  notified_at DATETIME(3) NULL,
+  crt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  INDEX idx_messages_conversation (conversation_id, from_staff, read_at),
+  INDEX idx_messages_pending_notification (read_at, notified_at, crt_date),
+  CONSTRAINT fk_messages_conversation FOREIGN KEY (conversation_id) REFERENCES Conversations(id) ON DELETE CASCADE,
+  CONSTRAINT fk_messages_sender FOREIGN KEY (sender_id) REFERENCES Users(id) ON DELETE CASCADE
+);
+
+-- Archivos adjuntos a los mensajes (imágenes y PDF). El contenido se guarda en el almacenamiento configurado
+-- con la clave storage_key y solo se sirve a los participantes de la conversación.
+CREATE TABLE Message_Attachments (
+  id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  message_id BIGINT UNSIGNED NOT NULL,
+  file_name VARCHAR(255) NOT NULL,
+  content_type VARCHAR(100) NOT NULL,
+  size BIGINT NOT NULL,
+  storage_key VARCHAR(255) NOT NULL,
+  crt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  INDEX idx_message_attachments_message (message_id),
+  CONSTRAINT fk_message_attachments_message FOREIGN KEY (message_id) REFERENCES Messages(id) ON DELETE CASCADE
+);
+
+-- Para ejecutar este archivo SQL con Go y una herramienta de migraciones como golang-migrate, usa el comando:
+-- migrate -path /root/adoption-system/backend/cmd/migrations -database "tu_cadena_de_conexion" up
\ No newline at end of file
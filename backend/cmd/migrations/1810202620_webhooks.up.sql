@@ -0,0 +1,61 @@
+-- Webhooks salientes para integraciones de socios. Los administradores registran la URL, los tipos de evento
+-- que recibe (event_types, array JSON) y opcionalmente una organización; sin organización recibe los eventos
+-- de todas. secret firma cada entrega con HMAC-SHA256 y solo se muestra al crearlo o rotarlo.
+CREATE TABLE Webhooks (
+  id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  url VARCHAR(500) NOT NULL,
+  description VARCHAR(255) NULL,
+  event_types JSON NOT NULL,
+  organization_id BIGINT UNSIGNED NULL,
+  secret VARCHAR(100) NOT NULL,
+  active BOOLEAN NOT NULL DEFAULT TRUE,
+  created_by BIGINT UNSIGNED NULL,
+  crt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  upt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  INDEX idx_webhooks_organization (organization_id),
+  CONSTRAINT fk_webhooks_organization FOREIGN KEY (organization_id) REFERENCES Organizations(id) ON DELETE CASCADE,
+  CONSTRAINT fk_webhooks_created_by FOREIGN KEY (created_by) REFERENCES Users(id) ON DELETE SET NULL
+);
+
+-- Cola persistente y registro de entregas. Las pendientes se reintentan con espera exponencial cuando vence
+-- next_attempt_at, hasta WEBHOOK_MAX_ATTEMPTS; una reentrega manual es una fila nueva con el mismo event_id
+-- y replay_of apuntando a la original.
+CREATE TABLE Webhook_Deliveries (
+  id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  webhook_id BIGINT UNSIGNED NOT NULL,
+  event_id VARCHAR(40) NOT NULL,
+  event_type VARCHAR(40) NOT NULL,
+  payload MEDIUMTEXT NOT NULL,
+  status VARCHAR(10) NOT NULL DEFAULT 'pending',
+  attempts INT NOT NULL DEFAULT 0,
+  next_attempt_at DATETIME(3) NULL,
+  last_attempt_at DATETIME(3) NULL,
+  response_status INT NOT NULL DEFAULT 0,
+  last_error VARCHAR(500) NULL,
+  delivered_at DATETIME(3) NULL,
+  replay_of BIGINT UNSIGNED NULL,
+  crt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  upt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  INDEX idx_webhook_deliveries_webhook (webhook_id, id),
+  INDEX idx_webhook_deliveries_due (status, next_attempt_at),
+  INDEX idx_webhook_deliveries_event (event_id),
+  CONSTRAINT fk_webhook_deliveries_webhook FOREIGN KEY (webhook_id) REFERENCES Webhooks(id) ON DELETE CASCADE,
+  CONSTRAINT fk_webhook_deliveries_replay FOREIGN KEY (replay_of) REFERENCES Webhook_Deliveries(id) ON DELETE SET NULL
+);
+
+-- Cada petición HTTP hecha para una entrega, con el estado y el comienzo de la respuesta del endpoint.
+CREATE TABLE Webhook_Attempts (
+  id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  delivery_id BIGINT UNSIGNED NOT NULL,
+  attempt INT NOT NULL,
+  response_status INT NOT NULL DEFAULT 0,
+  response_body TEXT NULL,
+  error VARCHAR(500) NULL,
+  duration_ms BIGINT NOT NULL DEFAULT 0,
+  crt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  INDEX idx_webhook_attempts_delivery (delivery_id),
+  CONSTRAINT fk_webhook_attempts_delivery FOREIGN KEY (delivery_id) REFERENCES Webhook_Deliveries(id) ON DELETE CASCADE
+);
+
+-- Para ejecutar este archivo SQL con Go y una herramienta de migraciones como golang-migrate, usa el comando:
+-- migrate -path /root/adoption-system/backend/cmd/migrations -database "tu_cadena_de_conexion" up
\ No newline at end of file
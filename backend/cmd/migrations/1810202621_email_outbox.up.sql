@@ -0,0 +1,24 @@
+-- Bandeja de salida de emails. Los servicios escriben aquí cada email (en la misma transacción que sus cambios
+-- cuando hace falta, como el código 2FA) y un proceso lo envía con reintentos y espera exponencial. Tras
+-- MAIL_MAX_ATTEMPTS intentos, o si el servidor lo rechaza de forma definitiva (5xx), queda como 'failed' y solo
+-- un administrador puede reenviarlo. message guarda el mensaje MIME completo y nunca se devuelve por la API.
+CREATE TABLE Email_Outbox (
+  id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  sender VARCHAR(255) NOT NULL,
+  recipients JSON NOT NULL,
+  subject VARCHAR(255) NOT NULL,
+  message MEDIUMBLOB NOT NULL,
+  status VARCHAR(10) NOT NULL DEFAULT 'pending',
+  attempts INT NOT NULL DEFAULT 0,
+  next_attempt_at DATETIME(3) NULL,
+  last_attempt_at DATETIME(3) NULL,
+  last_error VARCHAR(500) NULL,
+  sent_at DATETIME(3) NULL,
+  crt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  upt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  INDEX idx_email_outbox_due (status, next_attempt_at),
+  INDEX idx_email_outbox_crt_date (crt_date)
+);
+
+-- Para ejecutar este archivo SQL con Go y una herramienta de migraciones como golang-migrate, usa el comando:
+-- migrate -path /root/adoption-system/backend/cmd/migrations -database "tu_cadena_de_conexion" up
\ No newline at end of file
@@ -0,0 +1,7 @@
+-- Idioma de cada usuario (es, en, ca). Selecciona las plantillas y asuntos de los emails que recibe; los
+-- invitados (visitas y avisos sin cuenta) reciben los emails en el idioma por defecto, español.
+ALTER TABLE Users
+  ADD COLUMN locale VARCHAR(5) NOT NULL DEFAULT 'es' AFTER role;
+
+-- Para ejecutar este archivo SQL con Go y una herramienta de migraciones como golang-migrate, usa el comando:
+-- migrate -path /root/adoption-system/backend/cmd/migrations -database "tu_cadena_de_conexion" up
\ No newline at end of file
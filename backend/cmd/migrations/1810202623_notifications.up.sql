@@ -0,0 +1,31 @@
+-- Preferencias de notificación de cada usuario: por tipo (search_alerts, messages, application_updates,
+-- reminders) indica si se envía por email y si aparece en el centro de notificaciones. Los tipos sin fila
+-- tienen ambos canales activos. Los emails de cuenta (código 2FA, contraseñas) y los recibos de donación
+-- no son configurables.
+CREATE TABLE Notification_Preferences (
+  user_id BIGINT UNSIGNED NOT NULL,
+  type VARCHAR(30) NOT NULL,
+  email BOOLEAN NOT NULL DEFAULT TRUE,
+  in_app BOOLEAN NOT NULL DEFAULT TRUE,
+  upt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (user_id, type),
+  CONSTRAINT fk_notification_preferences_user FOREIGN KEY (user_id) REFERENCES Users(id) ON DELETE CASCADE
+);
+
+-- Centro de notificaciones. Cada notificación guarda el asunto y el resumen del email en el idioma del
+-- usuario; read_at queda a NULL hasta que el usuario la marca como leída.
+CREATE TABLE Notifications (
+  id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  user_id BIGINT UNSIGNED NOT NULL,
+  type VARCHAR(30) NOT NULL,
+  title VARCHAR(255) NOT NULL,
+  body TEXT NULL,
+  url VARCHAR(500) NULL,
+  read_at DATETIME(3) NULL,
+  crt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  INDEX idx_notifications_user_read (user_id, read_at),
+  CONSTRAINT fk_notifications_user FOREIGN KEY (user_id) REFERENCES Users(id) ON DELETE CASCADE
+);
+
+-- Para ejecutar este archivo SQL con Go y una herramienta de migraciones como golang-migrate, usa el comando:
+-- migrate -path /root/adoption-system/backend/cmd/migrations -database "tu_cadena_de_conexion" up
\ No newline at end of file
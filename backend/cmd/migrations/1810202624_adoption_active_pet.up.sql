@@ -0,0 +1,17 @@
+-- Adopción activa de cada mascota. active_pet_id repite pet_id mientras la mascota sigue adoptada bajo esa
+-- adopción y queda a NULL cuando la mascota vuelve a la protectora; el índice único impide registrar dos
+-- adopciones activas de la misma mascota aunque se finalicen a la vez.
+ALTER TABLE Adoptions
+  ADD COLUMN active_pet_id BIGINT UNSIGNED NULL AFTER pet_id;
+
+-- Las mascotas adoptadas conservan como activa su adopción más reciente
+UPDATE Adoptions a
+  JOIN Pets p ON p.id = a.pet_id AND p.status = 'adopted'
+  JOIN (SELECT pet_id, MAX(id) AS id FROM Adoptions GROUP BY pet_id) latest ON latest.id = a.id
+SET a.active_pet_id = a.pet_id;
+
+ALTER TABLE Adoptions
+  ADD UNIQUE INDEX idx_adoptions_active_pet (active_pet_id);
+
+-- Para ejecutar este archivo SQL con Go y una herramienta de migraciones como golang-migrate, usa el comando:
+-- migrate -path /root/adoption-system/backend/cmd/migrations -database "tu_cadena_de_conexion" up
\ No newline at end of file
@@ -0,0 +1,13 @@
+-- Resúmenes médicos diarios ya enviados a cada miembro del personal. Si la tarea falla y se reintenta,
+-- solo se envía el resumen a quienes aún no lo han recibido ese día. Las filas de días anteriores se
+-- borran en cada ejecución.
+CREATE TABLE Medical_Reminder_Deliveries (
+  digest_date DATE NOT NULL,
+  organization_id BIGINT UNSIGNED NOT NULL,
+  user_id BIGINT UNSIGNED NOT NULL,
+  crt_date DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (digest_date, organization_id, user_id)
+);
+
+-- Para ejecutar este archivo SQL con Go y una herramienta de migraciones como golang-migrate, usa el comando:
+-- migrate -path /root/adoption-system/backend/cmd/migrations -database "tu_cadena_de_conexion" up
\ No newline at end of file
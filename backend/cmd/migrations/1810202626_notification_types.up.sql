@@ -0,0 +1,12 @@
+-- Tipos de notificación propios para los cambios de estado de mascotas favoritas (favorites) y las
+-- coincidencias de avisos de perdidos y encontrados (lost_found), que antes se enviaban como search_alerts.
+-- Quien había configurado search_alerts conserva esa misma preferencia en los dos tipos nuevos; el resto
+-- de usuarios los reciben por email y en el centro de notificaciones.
+INSERT INTO Notification_Preferences (user_id, type, email, in_app)
+SELECT user_id, 'favorites', email, in_app FROM Notification_Preferences WHERE type = 'search_alerts';
+
+INSERT INTO Notification_Preferences (user_id, type, email, in_app)
+SELECT user_id, 'lost_found', email, in_app FROM Notification_Preferences WHERE type = 'search_alerts';
+
+-- Para ejecutar este archivo SQL con Go y una herramienta de migraciones como golang-migrate, usa el comando:
+-- migrate -path /root/adoption-system/backend/cmd/migrations -database "tu_cadena_de_conexion" up
\ No newline at end of file
@@ -0,0 +1,10 @@
+-- Los mensajes del outbox pueden contener códigos de verificación o contraseñas temporales.
+-- Se borran los de los emails ya enviados y los de los emails fallidos hace más de 72 horas
+-- (MAIL_FAILED_RETENTION por defecto); a partir de ahora el propio outbox los borra.
+UPDATE Email_Outbox SET message = '' WHERE status = 'sent' AND LENGTH(message) > 0;
+
+UPDATE Email_Outbox SET message = ''
+WHERE status = 'failed' AND last_attempt_at < NOW() - INTERVAL 72 HOUR AND LENGTH(message) > 0;
+
+-- Para ejecutar este archivo SQL con Go y una herramienta de migraciones como golang-migrate, usa el comando:
+-- migrate -path /root/adoption-system/backend/cmd/migrations -database "tu_cadena_de_conexion" up
\ No newline at end of file
@@ -3,8 +3,11 @@ module backend
 go 1.24.3
 
 require (
+	github.com/go-pdf/fpdf v0.9.0
 	github.com/lib/pq v1.10.9
 	golang.org/x/crypto v0.39.0
+	golang.org/x/image v0.28.0
+	google.golang.org/api v0.241.0
 	gorm.io/driver/mysql v1.6.0
 )
 
@@ -32,7 +35,6 @@ require (
 	golang.org/x/oauth2 v0.30.0 // indirect
 	golang.org/x/sys v0.33.0 // indirect
 	golang.org/x/time v0.12.0 // indirect
-	google.golang.org/api v0.241.0 // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20250603155806-513f23925822 // indirect
 	google.golang.org/grpc v1.73.0 // indirect
 	google.golang.org/protobuf v1.36.6 // indirect
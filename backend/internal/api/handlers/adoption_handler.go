@@ -0,0 +1,396 @@
+// Package handlers implements HTTP request handlers for the adoption API.
+// This layer is responsible for:
+// - Validating finalised adoptions and contract templates
+// - Calling appropriate service layer functions
+// - Converting service errors to HTTP responses
+package handlers
+
+import (
+	r_models "backend/internal/api/routes/models"
+	"backend/internal/db/query"
+	m "backend/internal/models"
+	s "backend/internal/services/backend_calls"
+	"backend/internal/services/contract"
+	response "backend/internal/utils/rest"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Limits of the texts of adoptions and contract templates.
+const (
+	maxAdoptionClauses    = 20
+	maxTemplateClauses    = 30
+	maxClauseLength       = 2000
+	maxContractTextLength = 5000
+)
+
+// currencyPattern matches ISO 4217 currency codes.
+var currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// ========================================
+// ADOPTION HANDLERS
+// ========================================
+
+// HandleListAdoptions processes staff requests to retrieve a page of adoptions.
+//
+// Validation:
+// - Validates pagination, sorting and filter parameters (400 on invalid input)
+//
+// Parameters:
+//   - path: Request path used to build next/prev links
+//   - values: Raw query parameters
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - *query.Page[m.Adoption]: Requested page of adoptions with pet and adopter summaries
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleListAdoptions(path string, values url.Values, orgID uint) (*query.Page[m.Adoption], response.HTTPError) {
+	params, err := s.NewAdoptionListQuery(path, values)
+	if err != nil {
+		return nil, response.Error(http.StatusBadRequest, err.Error())
+	}
+
+	adoptions, err := s.ListAdoptions(params, orgID)
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return adoptions, response.EmptyError
+}
+
+// HandleGetAdoption processes staff requests to retrieve an adoption.
+//
+// Parameters:
+//   - id: Adoption ID
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - *m.Adoption: Adoption with pet and adopter summaries
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleGetAdoption(id uint, orgID uint) (*m.Adoption, response.HTTPError) {
+	// Input validation
+	if id <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de adopción no válido")
+	}
+
+	adoption, err := s.GetAdoption(id, orgID)
+	if errors.Is(err, s.ErrAdoptionNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return adoption, response.EmptyError
+}
+
+// HandleListMyAdoptions processes requests to retrieve the adoptions of the current user.
+//
+// Parameters:
+//   - userID: Authenticated user ID
+//
+// Returns:
+//   - []m.Adoption: Adoptions of the user with contract download links
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleListMyAdoptions(userID uint) ([]m.Adoption, response.HTTPError) {
+	adoptions, err := s.ListUserAdoptions(userID)
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return adoptions, response.EmptyError
+}
+
+// HandleFinalizeAdoption processes staff requests to finalise an adoption.
+//
+// Validation:
+// - Ensures the pet and the adopter are given
+// - Ensures the date is valid and not in the future, and the fee is not negative
+// - Ensures the currency is an ISO 4217 code and the clauses and notes are within limits
+//
+// Parameters:
+//   - orgID: Organisation of the acting staff member
+//   - staffID: Authenticated staff user ID
+//   - req: AdoptionRequest with the pet, adopter, fee and clauses
+//
+// Returns:
+//   - *m.Adoption: Created adoption with contract metadata
+//   - response.HTTPError: HTTP error or EmptyError on success (409 when the pet is already adopted)
+func HandleFinalizeAdoption(orgID uint, staffID uint, req r_models.AdoptionRequest) (*m.Adoption, response.HTTPError) {
+	// Input validation
+	if req.PetID <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "pet_id es obligatorio")
+	}
+	if req.AdopterUserID <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "adopter_user_id es obligatorio")
+	}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	date, err := parseDate(req.AdoptionDate)
+	if err != nil {
+		return nil, response.Error(http.StatusBadRequest, "adoption_date debe tener formato YYYY-MM-DD")
+	}
+	if date == nil {
+		date = &today
+	}
+	if date.After(today) {
+		return nil, response.Error(http.StatusBadRequest, "adoption_date no puede ser una fecha futura")
+	}
+
+	currency := strings.ToUpper(strings.TrimSpace(req.Currency))
+
+	var fee int64
+	if req.FeeCents != nil {
+		fee = *req.FeeCents
+		if fee < 0 {
+			return nil, response.Error(http.StatusBadRequest, "fee_cents no puede ser negativo")
+		}
+	} else {
+		quote, err := s.QuoteAdoptionFee(req.PetID, orgID)
+		if errors.Is(err, s.ErrAdoptionPetNotFound) {
+			return nil, response.Error(http.StatusNotFound, err.Error())
+		}
+		if err != nil {
+			return nil, response.Error(http.StatusInternalServerError, err.Error())
+		}
+		fee = quote.AmountCents
+		if currency == "" {
+			currency = quote.Currency
+		}
+	}
+
+	if currency == "" {
+		currency = "EUR"
+	}
+	if !currencyPattern.MatchString(currency) {
+		return nil, response.Error(http.StatusBadRequest, "currency debe ser un código ISO 4217 de 3 letras")
+	}
+
+	clauses, msg := cleanClauses(req.Clauses, maxAdoptionClauses)
+	if msg != "" {
+		return nil, response.Error(http.StatusBadRequest, msg)
+	}
+
+	notes := strings.TrimSpace(req.Notes)
+	if utf8.RuneCountInString(notes) > 2000 {
+		return nil, response.Error(http.StatusBadRequest, "notes no puede superar 2000 caracteres")
+	}
+
+	adoption := &m.Adoption{
+		OrganizationID: orgID,
+		PetID:          req.PetID,
+		AdopterUserID:  req.AdopterUserID,
+		AdoptionDate:   *date,
+		FeeCents:       fee,
+		Currency:       currency,
+		Clauses:        clauses,
+		Notes:          notes,
+		CreatedBy:      staffID,
+	}
+
+	created, err := s.FinalizeAdoption(adoption)
+	if errors.Is(err, s.ErrAdoptionPetNotFound) || errors.Is(err, s.ErrAdoptionAdopterNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if errors.Is(err, s.ErrAdoptionPetAdopted) {
+		return nil, response.Error(http.StatusConflict, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return created, response.EmptyError
+}
+
+// ========================================
+// ADOPTION CONTRACT HANDLERS
+// ========================================
+
+// HandleGetAdoptionContract processes requests to download the contract of an adoption.
+//
+// Parameters:
+//   - id: Adoption ID
+//   - viewer: Authenticated user (the adopter or staff of the organisation)
+//
+// Returns:
+//   - io.ReadCloser: PDF content (caller must close it)
+//   - string: File name for the download
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleGetAdoptionContract(id uint, viewer *m.NonValidatedUser) (io.ReadCloser, string, response.HTTPError) {
+	// Input validation
+	if id <= 0 {
+		return nil, "", response.Error(http.StatusBadRequest, "ID de adopción no válido")
+	}
+
+	content, filename, err := s.OpenAdoptionContract(id, viewer)
+	if errors.Is(err, s.ErrAdoptionNotFound) || errors.Is(err, s.ErrAdoptionContractMissing) {
+		return nil, "", response.Error(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return nil, "", response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return content, filename, response.EmptyError
+}
+
+// HandleVerifyAdoptionContract processes staff requests to check a stored contract against its hash.
+//
+// Parameters:
+//   - id: Adoption ID
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - *s.AdoptionContractCheck: Recorded and computed hashes
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleVerifyAdoptionContract(id uint, orgID uint) (*s.AdoptionContractCheck, response.HTTPError) {
+	// Input validation
+	if id <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de adopción no válido")
+	}
+
+	check, err := s.VerifyAdoptionContract(id, orgID)
+	if errors.Is(err, s.ErrAdoptionNotFound) || errors.Is(err, s.ErrAdoptionContractMissing) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return check, response.EmptyError
+}
+
+// HandleResendAdoptionContract processes staff requests to email the contract to the adopter again.
+//
+// Parameters:
+//   - id: Adoption ID
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - *m.Adoption: Adoption with the updated sending time
+//   - response.HTTPError: HTTP error or EmptyError on success (409 when the stored contract was altered)
+func HandleResendAdoptionContract(id uint, orgID uint) (*m.Adoption, response.HTTPError) {
+	// Input validation
+	if id <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de adopción no válido")
+	}
+
+	adoption, err := s.ResendAdoptionContract(id, orgID)
+	if errors.Is(err, s.ErrAdoptionNotFound) || errors.Is(err, s.ErrAdoptionContractMissing) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if errors.Is(err, s.ErrAdoptionContractTampered) {
+		return nil, response.Error(http.StatusConflict, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return adoption, response.EmptyError
+}
+
+// ========================================
+// CONTRACT TEMPLATE HANDLERS
+// ========================================
+
+// HandleGetContractTemplate processes staff requests to retrieve the organisation's contract template.
+//
+// Parameters:
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - *m.ContractTemplate: Template in use (the default one if not configured)
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleGetContractTemplate(orgID uint) (*m.ContractTemplate, response.HTTPError) {
+	tmpl, err := s.GetContractTemplate(orgID)
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return tmpl, response.EmptyError
+}
+
+// HandleSaveContractTemplate processes manager requests to change the organisation's contract template.
+//
+// Validation:
+// - Ensures the title is given and every text is within limits
+// - Ensures every text is a valid template using only the contract data fields
+//
+// Parameters:
+//   - orgID: Organisation of the acting manager
+//   - staffID: Authenticated staff user ID
+//   - req: ContractTemplateRequest with the new texts
+//
+// Returns:
+//   - *m.ContractTemplate: Saved template
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleSaveContractTemplate(orgID uint, staffID uint, req r_models.ContractTemplateRequest) (*m.ContractTemplate, response.HTTPError) {
+	// Input validation
+	title := strings.TrimSpace(req.Title)
+	if title == "" || utf8.RuneCountInString(title) > 200 {
+		return nil, response.Error(http.StatusBadRequest, "title es obligatorio y no puede superar 200 caracteres")
+	}
+
+	intro := strings.TrimSpace(req.Intro)
+	closing := strings.TrimSpace(req.Closing)
+	if utf8.RuneCountInString(intro) > maxContractTextLength || utf8.RuneCountInString(closing) > maxContractTextLength {
+		return nil, response.Error(http.StatusBadRequest, fmt.Sprintf("intro y closing no pueden superar %d caracteres", maxContractTextLength))
+	}
+
+	clauses, msg := cleanClauses(req.Clauses, maxTemplateClauses)
+	if msg != "" {
+		return nil, response.Error(http.StatusBadRequest, msg)
+	}
+
+	tmpl := &m.ContractTemplate{
+		OrganizationID: orgID,
+		Title:          title,
+		Intro:          intro,
+		Clauses:        clauses,
+		Closing:        closing,
+		UpdatedBy:      staffID,
+	}
+
+	saved, err := s.SaveContractTemplate(tmpl)
+	if errors.Is(err, contract.ErrInvalidTemplate) {
+		return nil, response.Error(http.StatusBadRequest, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return saved, response.EmptyError
+}
+
+// ========================================
+// ADOPTION HELPERS
+// ========================================
+
+// cleanClauses trims the clauses, drops empty ones and checks the limits.
+// It returns an error message, or "" if valid.
+func cleanClauses(clauses []string, maxClauses int) ([]string, string) {
+	cleaned := make([]string, 0, len(clauses))
+	for _, clause := range clauses {
+		clause = strings.TrimSpace(clause)
+		if clause == "" {
+			continue
+		}
+		if utf8.RuneCountInString(clause) > maxClauseLength {
+			return nil, fmt.Sprintf("cada cláusula no puede superar %d caracteres", maxClauseLength)
+		}
+		cleaned = append(cleaned, clause)
+	}
+
+	if len(cleaned) > maxClauses {
+		return nil, fmt.Sprintf("no se permiten más de %d cláusulas", maxClauses)
+	}
+
+	return cleaned, ""
+}
@@ -0,0 +1,395 @@
+// Package handlers implements HTTP request handlers for the in-app messaging API.
+// This layer is responsible for:
+// - Validating conversations, messages and uploaded attachments
+// - Calling appropriate service layer functions
+// - Converting service errors to HTTP responses
+package handlers
+
+import (
+	r_models "backend/internal/api/routes/models"
+	"backend/internal/db/query"
+	m "backend/internal/models"
+	s "backend/internal/services/backend_calls"
+	"backend/internal/utils/env"
+	response "backend/internal/utils/rest"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxMessageAttachmentBytes is the maximum accepted size of a message attachment in bytes.
+// Configurable through the MESSAGE_ATTACHMENT_MAX_BYTES environment variable (default 10 MiB).
+var MaxMessageAttachmentBytes = env.GetInt("MESSAGE_ATTACHMENT_MAX_BYTES", 10<<20)
+
+// messageAttachmentTypes lists the accepted attachment types, as sniffed from the file content.
+var messageAttachmentTypes = []string{"image/jpeg", "image/png", "image/gif", "image/webp", "application/pdf"}
+
+// ========================================
+// CONVERSATION HANDLERS
+// ========================================
+
+// HandleListInbox processes staff requests to retrieve a page of the organisation's shared inbox.
+//
+// Validation:
+// - Validates pagination, sorting and filter parameters (400 on invalid input)
+//
+// Parameters:
+//   - path: Request path used to build next/prev links
+//   - values: Raw query parameters
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - *query.Page[m.Conversation]: Requested page of conversations with unread counts
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleListInbox(path string, values url.Values, orgID uint) (*query.Page[m.Conversation], response.HTTPError) {
+	params, err := s.NewConversationListQuery(path, values)
+	if err != nil {
+		return nil, response.Error(http.StatusBadRequest, err.Error())
+	}
+
+	conversations, err := s.ListInbox(params, orgID)
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return conversations, response.EmptyError
+}
+
+// HandleListUserConversations processes requests from a user to retrieve their conversations.
+//
+// Parameters:
+//   - userID: Authenticated user ID
+//
+// Returns:
+//   - []m.Conversation: Conversations of the user with unread counts
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleListUserConversations(userID uint) ([]m.Conversation, response.HTTPError) {
+	conversations, err := s.ListUserConversations(userID)
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return conversations, response.EmptyError
+}
+
+// HandleStartConversation processes requests from a user to write to an organisation.
+//
+// Validation:
+// - Ensures pet_id or organization_id is given, and valid subject and body (see toConversation)
+//
+// Parameters:
+//   - userID: Authenticated user ID
+//   - req: ConversationRequest with the subject and first message
+//
+// Returns:
+//   - *m.Conversation: Created conversation with its first message
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleStartConversation(userID uint, req r_models.ConversationRequest) (*m.Conversation, response.HTTPError) {
+	// Input validation
+	if req.PetID == nil && req.OrganizationID == nil {
+		return nil, response.Error(http.StatusBadRequest, "pet_id u organization_id es obligatorio")
+	}
+	if (req.PetID != nil && *req.PetID == 0) || (req.OrganizationID != nil && *req.OrganizationID == 0) {
+		return nil, response.Error(http.StatusBadRequest, "pet_id y organization_id deben ser IDs válidos")
+	}
+
+	conversation, body, msg := toConversation(req.Subject, req.Body, req.ApplicationID)
+	if msg != "" {
+		return nil, response.Error(http.StatusBadRequest, msg)
+	}
+
+	conversation.UserID = userID
+	conversation.PetID = req.PetID
+	if req.OrganizationID != nil {
+		conversation.OrganizationID = *req.OrganizationID
+	}
+
+	created, err := s.StartConversation(conversation, body)
+	if errors.Is(err, s.ErrConversationPetNotFound) || errors.Is(err, s.ErrOrganizationNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return created, response.EmptyError
+}
+
+// HandleStartStaffConversation processes staff requests to write to a user.
+//
+// Validation:
+// - Ensures user_id is given, and valid subject and body (see toConversation)
+//
+// Parameters:
+//   - orgID: Organisation of the acting staff member
+//   - staffID: Staff user writing the first message
+//   - req: StaffConversationRequest with the user, subject and first message
+//
+// Returns:
+//   - *m.Conversation: Created conversation with its first message
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleStartStaffConversation(orgID uint, staffID uint, req r_models.StaffConversationRequest) (*m.Conversation, response.HTTPError) {
+	// Input validation
+	if req.UserID == 0 {
+		return nil, response.Error(http.StatusBadRequest, "user_id es obligatorio")
+	}
+	if req.PetID != nil && *req.PetID == 0 {
+		return nil, response.Error(http.StatusBadRequest, "pet_id debe ser un ID válido")
+	}
+
+	conversation, body, msg := toConversation(req.Subject, req.Body, req.ApplicationID)
+	if msg != "" {
+		return nil, response.Error(http.StatusBadRequest, msg)
+	}
+
+	conversation.OrganizationID = orgID
+	conversation.UserID = req.UserID
+	conversation.PetID = req.PetID
+	conversation.CreatedBy = staffID
+
+	created, err := s.StartStaffConversation(conversation, body)
+	if errors.Is(err, s.ErrConversationUserNotFound) || errors.Is(err, s.ErrConversationPetNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return created, response.EmptyError
+}
+
+// HandleGetConversation processes requests to read a conversation, marking the other side's messages as read.
+//
+// Parameters:
+//   - id: Conversation ID
+//   - viewer: Current user (the user of the conversation or staff of its organisation)
+//
+// Returns:
+//   - *m.Conversation: Conversation with its messages
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleGetConversation(id uint, viewer *m.NonValidatedUser) (*m.Conversation, response.HTTPError) {
+	// Input validation
+	if id <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de conversación no válido")
+	}
+
+	conversation, err := s.GetConversation(id, viewer)
+	if errors.Is(err, s.ErrConversationNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return conversation, response.EmptyError
+}
+
+// HandleUpdateInboxConversation processes staff requests to change the status and assignee of a conversation.
+//
+// Validation:
+// - Ensures the status is open or closed and the assignee, when given, is a valid ID
+// - Rejects assignees outside the organisation (400)
+//
+// Parameters:
+//   - id: Conversation ID
+//   - orgID: Organisation of the acting staff member
+//   - req: InboxUpdateRequest with the new status and assignee
+//
+// Returns:
+//   - *m.Conversation: Updated conversation
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleUpdateInboxConversation(id uint, orgID uint, req r_models.InboxUpdateRequest) (*m.Conversation, response.HTTPError) {
+	// Input validation
+	if id <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de conversación no válido")
+	}
+
+	status := strings.TrimSpace(req.Status)
+	if !slices.Contains(m.ConversationStatuses, status) {
+		return nil, response.Error(http.StatusBadRequest, "status debe ser open o closed")
+	}
+	if req.AssignedTo != nil && *req.AssignedTo == 0 {
+		return nil, response.Error(http.StatusBadRequest, "assigned_to debe ser un ID válido o null")
+	}
+
+	updated, err := s.UpdateConversationInbox(&m.Conversation{
+		ID:             id,
+		OrganizationID: orgID,
+		Status:         status,
+		AssignedTo:     req.AssignedTo,
+	})
+	if errors.Is(err, s.ErrConversationNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if errors.Is(err, s.ErrConversationAssignee) {
+		return nil, response.Error(http.StatusBadRequest, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return updated, response.EmptyError
+}
+
+// ========================================
+// MESSAGE HANDLERS
+// ========================================
+
+// HandlePostMessage processes requests to write a message in a conversation.
+//
+// Validation:
+// - Ensures the body is given and does not exceed 5000 characters
+// - Accepts up to s.MaxMessageAttachments files, each validated by size (413) and sniffed type (415)
+//
+// Parameters:
+//   - conversationID: Conversation ID
+//   - req: MessageRequest with the form fields
+//   - files: Uploaded attachments
+//   - viewer: Current user (the user of the conversation or staff of its organisation)
+//
+// Returns:
+//   - *m.Message: Created message with attachment URLs
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandlePostMessage(conversationID uint, req r_models.MessageRequest, files []*multipart.FileHeader, viewer *m.NonValidatedUser) (*m.Message, response.HTTPError) {
+	// Input validation
+	if conversationID <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de conversación no válido")
+	}
+
+	body := strings.TrimSpace(req.Body)
+	if body == "" || utf8.RuneCountInString(body) > 5000 {
+		return nil, response.Error(http.StatusBadRequest, "body es obligatorio y no puede superar 5000 caracteres")
+	}
+
+	if len(files) > s.MaxMessageAttachments {
+		return nil, response.Error(http.StatusBadRequest, fmt.Sprintf("se permiten como máximo %d adjuntos", s.MaxMessageAttachments))
+	}
+
+	uploads := make([]m.MessageUpload, 0, len(files))
+	for _, file := range files {
+		upload, httpErr := readMessageAttachment(file)
+		if httpErr.Code != 0 {
+			return nil, httpErr
+		}
+		uploads = append(uploads, upload)
+	}
+
+	message := &m.Message{
+		ConversationID: conversationID,
+		SenderID:       viewer.ID,
+		Body:           body,
+	}
+
+	// Delegate creation and storage to service layer
+	err := s.PostMessage(message, uploads, viewer)
+	if errors.Is(err, s.ErrConversationNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return message, response.EmptyError
+}
+
+// HandleGetMessageAttachment processes requests to download an attachment of a conversation.
+//
+// Parameters:
+//   - conversationID: Conversation ID
+//   - attachmentID: Attachment ID
+//   - viewer: Current user (the user of the conversation or staff of its organisation)
+//
+// Returns:
+//   - io.ReadCloser: File content (caller must close it)
+//   - *m.MessageAttachment: Attachment metadata (file name and MIME type)
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleGetMessageAttachment(conversationID uint, attachmentID uint, viewer *m.NonValidatedUser) (io.ReadCloser, *m.MessageAttachment, response.HTTPError) {
+	// Input validation
+	if conversationID <= 0 || attachmentID <= 0 {
+		return nil, nil, response.Error(http.StatusBadRequest, "ID de conversación o adjunto no válido")
+	}
+
+	content, attachment, err := s.OpenMessageAttachment(conversationID, attachmentID, viewer)
+	if errors.Is(err, s.ErrConversationNotFound) || errors.Is(err, s.ErrMessageAttachmentNotFound) {
+		return nil, nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return nil, nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return content, attachment, response.EmptyError
+}
+
+// ========================================
+// CONVERSATION HELPERS
+// ========================================
+
+// toConversation validates the subject and first message of a new conversation.
+// Returns the conversation, the trimmed first message, and an error message when the request is invalid.
+func toConversation(subject string, body string, applicationID *uint) (*m.Conversation, string, string) {
+	conversation := &m.Conversation{
+		Subject:       strings.TrimSpace(subject),
+		ApplicationID: applicationID,
+	}
+	body = strings.TrimSpace(body)
+
+	if conversation.Subject == "" || utf8.RuneCountInString(conversation.Subject) > 200 {
+		return nil, "", "subject es obligatorio y no puede superar 200 caracteres"
+	}
+	if body == "" || utf8.RuneCountInString(body) > 5000 {
+		return nil, "", "body es obligatorio y no puede superar 5000 caracteres"
+	}
+	if applicationID != nil && *applicationID == 0 {
+		return nil, "", "application_id debe ser un ID válido"
+	}
+
+	return conversation, body, ""
+}
+
+// readMessageAttachment reads an uploaded attachment, enforcing MaxMessageAttachmentBytes (413) and
+// sniffing the real content type from the file bytes (415 if not an accepted image or PDF).
+func readMessageAttachment(file *multipart.FileHeader) (m.MessageUpload, response.HTTPError) {
+	tooLarge := response.Error(http.StatusRequestEntityTooLarge, fmt.Sprintf("el adjunto supera el tamaño máximo de %d bytes", MaxMessageAttachmentBytes))
+	if file.Size > MaxMessageAttachmentBytes {
+		return m.MessageUpload{}, tooLarge
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return m.MessageUpload{}, response.Error(http.StatusBadRequest, "no se pudo leer el adjunto")
+	}
+	defer src.Close()
+
+	// Read one extra byte to detect files larger than the declared size
+	data, err := io.ReadAll(io.LimitReader(src, MaxMessageAttachmentBytes+1))
+	if err != nil {
+		return m.MessageUpload{}, response.Error(http.StatusBadRequest, "no se pudo leer el adjunto")
+	}
+
+	if int64(len(data)) > MaxMessageAttachmentBytes {
+		return m.MessageUpload{}, tooLarge
+	}
+
+	contentType := http.DetectContentType(data)
+	if !slices.Contains(messageAttachmentTypes, contentType) {
+		return m.MessageUpload{}, response.Error(http.StatusUnsupportedMediaType, "solo se admiten imágenes JPEG, PNG, GIF o WebP y documentos PDF")
+	}
+
+	// Keep only the base name, as browsers may send the client path
+	name := strings.TrimSpace(filepath.Base(strings.ReplaceAll(file.Filename, "\\", "/")))
+	if name == "" || name == "." || name == "/" {
+		name = "adjunto"
+	}
+	if runes := []rune(name); len(runes) > 255 {
+		name = string(runes[len(runes)-255:])
+	}
+
+	return m.MessageUpload{FileName: name, ContentType: contentType, Data: data}, response.EmptyError
+}
@@ -0,0 +1,365 @@
+// Package handlers implements HTTP request handlers for the donation and sponsorship API.
+// This layer is responsible for:
+// - Validating donations and receipt requests
+// - Calling appropriate service layer functions
+// - Converting service errors to HTTP responses
+package handlers
+
+import (
+	r_models "backend/internal/api/routes/models"
+	"backend/internal/db/query"
+	m "backend/internal/models"
+	s "backend/internal/services/backend_calls"
+	response "backend/internal/utils/rest"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ========================================
+// DONATION HANDLERS
+// ========================================
+
+// HandleCreateDonation processes requests to make a donation or sponsor a pet.
+//
+// Parameters:
+//   - donor: Current user
+//   - req: DonationRequest with the organisation or pet, amount and frequency
+//
+// Returns:
+//   - *m.Donation: Pending donation with the checkout URL to redirect the donor to
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleCreateDonation(donor *m.NonValidatedUser, req r_models.DonationRequest) (*m.Donation, response.HTTPError) {
+	// Input validation
+	donation, msg := validateDonation(req)
+	if msg != "" {
+		return nil, response.Error(http.StatusBadRequest, msg)
+	}
+
+	created, err := s.CreateDonation(donation, donor)
+	if errors.Is(err, s.ErrDonationPetNotFound) || errors.Is(err, s.ErrDonationOrganizationNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if errors.Is(err, s.ErrPaymentsDisabled) {
+		return nil, response.Error(http.StatusServiceUnavailable, err.Error())
+	}
+	if errors.Is(err, s.ErrPaymentProvider) {
+		return nil, response.Error(http.StatusBadGateway, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return created, response.EmptyError
+}
+
+// HandleStartDonationCheckout processes donor requests to pay the pending charge of a donation.
+//
+// Parameters:
+//   - id: Donation ID
+//   - donor: Current user
+//
+// Returns:
+//   - *m.Donation: Donation with the checkout URL to redirect the donor to
+//   - response.HTTPError: HTTP error or EmptyError on success (409 when nothing is due)
+func HandleStartDonationCheckout(id uint, donor *m.NonValidatedUser) (*m.Donation, response.HTTPError) {
+	// Input validation
+	if id <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de donación no válido")
+	}
+
+	donation, err := s.StartDonationCheckout(id, donor)
+	if errors.Is(err, s.ErrDonationNotFound) || errors.Is(err, s.ErrDonationOrganizationNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if errors.Is(err, s.ErrDonationClosed) || errors.Is(err, s.ErrDonationNothingDue) {
+		return nil, response.Error(http.StatusConflict, err.Error())
+	}
+	if errors.Is(err, s.ErrPaymentsDisabled) {
+		return nil, response.Error(http.StatusServiceUnavailable, err.Error())
+	}
+	if errors.Is(err, s.ErrPaymentProvider) {
+		return nil, response.Error(http.StatusBadGateway, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return donation, response.EmptyError
+}
+
+// HandleCancelDonation processes requests to cancel a donation.
+//
+// Parameters:
+//   - id: Donation ID
+//   - viewer: Current user (the donor or staff of the organisation)
+//
+// Returns:
+//   - *m.Donation: Cancelled donation
+//   - response.HTTPError: HTTP error or EmptyError on success (409 when already finished)
+func HandleCancelDonation(id uint, viewer *m.NonValidatedUser) (*m.Donation, response.HTTPError) {
+	// Input validation
+	if id <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de donación no válido")
+	}
+
+	donation, err := s.CancelDonation(id, viewer)
+	if errors.Is(err, s.ErrDonationNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if errors.Is(err, s.ErrDonationClosed) {
+		return nil, response.Error(http.StatusConflict, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return donation, response.EmptyError
+}
+
+// HandleListMyDonations processes requests to retrieve the current user's donations.
+//
+// Parameters:
+//   - userID: Authenticated user ID
+//
+// Returns:
+//   - []m.Donation: Donations of the user
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleListMyDonations(userID uint) ([]m.Donation, response.HTTPError) {
+	donations, err := s.ListMyDonations(userID)
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return donations, response.EmptyError
+}
+
+// HandleListDonations processes staff requests to retrieve a page of donations.
+//
+// Validation:
+// - Validates pagination, sorting and filter parameters (400 on invalid input)
+//
+// Parameters:
+//   - path: Request path used to build next/prev links
+//   - values: Raw query parameters
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - *query.Page[m.Donation]: Requested page of donations
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleListDonations(path string, values url.Values, orgID uint) (*query.Page[m.Donation], response.HTTPError) {
+	params, err := s.NewDonationListQuery(path, values)
+	if err != nil {
+		return nil, response.Error(http.StatusBadRequest, err.Error())
+	}
+
+	donations, err := s.ListDonations(params, orgID)
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return donations, response.EmptyError
+}
+
+// HandleGetDonation processes staff requests to retrieve a donation.
+//
+// Parameters:
+//   - id: Donation ID
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - *m.Donation: Donation with donor and pet summaries
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleGetDonation(id uint, orgID uint) (*m.Donation, response.HTTPError) {
+	// Input validation
+	if id <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de donación no válido")
+	}
+
+	donation, err := s.GetDonation(id, orgID)
+	if errors.Is(err, s.ErrDonationNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return donation, response.EmptyError
+}
+
+// HandleListDonationPayments processes requests to retrieve the charges of a donation.
+//
+// Parameters:
+//   - id: Donation ID
+//   - viewer: Current user (the donor or staff of the organisation)
+//
+// Returns:
+//   - []m.Payment: Payments of the donation with their refunds
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleListDonationPayments(id uint, viewer *m.NonValidatedUser) ([]m.Payment, response.HTTPError) {
+	// Input validation
+	if id <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de donación no válido")
+	}
+
+	payments, err := s.ListDonationPayments(id, viewer)
+	if errors.Is(err, s.ErrDonationNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return payments, response.EmptyError
+}
+
+// ========================================
+// DONATION RECEIPT HANDLERS
+// ========================================
+
+// HandleListDonationReceipts processes staff requests to retrieve the receipts issued in a year.
+//
+// Parameters:
+//   - orgID: Organisation of the acting staff member
+//   - year: Year of the donations (defaults to the previous year)
+//
+// Returns:
+//   - []m.DonationReceipt: Receipts issued by the organisation
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleListDonationReceipts(orgID uint, year int) ([]m.DonationReceipt, response.HTTPError) {
+	// Input validation
+	if year == 0 {
+		year = time.Now().Year() - 1
+	}
+	if year < 2000 || year > time.Now().Year() {
+		return nil, response.Error(http.StatusBadRequest, "year no válido")
+	}
+
+	receipts, err := s.ListDonationReceipts(orgID, year)
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return receipts, response.EmptyError
+}
+
+// HandleGenerateDonationReceipts processes staff requests to issue the receipts of a year
+// to the donors that do not have one yet.
+//
+// Parameters:
+//   - orgID: Organisation of the acting staff member
+//   - req: DonationReceiptsRequest with the year
+//
+// Returns:
+//   - []m.DonationReceipt: Receipts issued by the request
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleGenerateDonationReceipts(orgID uint, req r_models.DonationReceiptsRequest) ([]m.DonationReceipt, response.HTTPError) {
+	// Input validation: receipts certify whole years
+	now := time.Now()
+	if req.Year < 2000 || req.Year >= now.Year() {
+		return nil, response.Error(http.StatusBadRequest, "year debe ser un año ya finalizado")
+	}
+
+	receipts, err := s.GenerateDonationReceipts(orgID, req.Year, now)
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return receipts, response.EmptyError
+}
+
+// HandleListMyDonationReceipts processes requests to retrieve the receipts issued to the current user.
+//
+// Parameters:
+//   - userID: Authenticated user ID
+//
+// Returns:
+//   - []m.DonationReceipt: Receipts of the user
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleListMyDonationReceipts(userID uint) ([]m.DonationReceipt, response.HTTPError) {
+	receipts, err := s.ListMyDonationReceipts(userID)
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return receipts, response.EmptyError
+}
+
+// HandleGetDonationReceipt processes requests to download a donation receipt.
+//
+// Parameters:
+//   - id: Receipt ID
+//   - viewer: Current user (the donor or staff of the organisation)
+//
+// Returns:
+//   - io.ReadCloser: PDF content (caller must close it)
+//   - string: File name for the download
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleGetDonationReceipt(id uint, viewer *m.NonValidatedUser) (io.ReadCloser, string, response.HTTPError) {
+	// Input validation
+	if id <= 0 {
+		return nil, "", response.Error(http.StatusBadRequest, "ID de certificado no válido")
+	}
+
+	content, filename, err := s.OpenDonationReceipt(id, viewer)
+	if errors.Is(err, s.ErrDonationReceiptNotFound) {
+		return nil, "", response.Error(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return nil, "", response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return content, filename, response.EmptyError
+}
+
+// validateDonation checks a donation request and returns the donation or an error message.
+func validateDonation(req r_models.DonationRequest) (*m.Donation, string) {
+	if req.OrganizationID == 0 && req.PetID == nil {
+		return nil, "organization_id o pet_id es obligatorio"
+	}
+
+	if req.AmountCents <= 0 {
+		return nil, "amount_cents debe ser positivo"
+	}
+
+	currency := strings.ToUpper(strings.TrimSpace(req.Currency))
+	if currency == "" {
+		currency = "EUR"
+	}
+	if !currencyPattern.MatchString(currency) {
+		return nil, "currency debe ser un código ISO 4217 de 3 letras"
+	}
+
+	frequency := strings.TrimSpace(req.Frequency)
+	if frequency == "" {
+		frequency = m.DonationOneOff
+	}
+	if !slices.Contains(m.DonationFrequencies, frequency) {
+		return nil, "frequency debe ser one_off, monthly o yearly"
+	}
+
+	taxID := strings.ToUpper(strings.TrimSpace(req.TaxID))
+	if utf8.RuneCountInString(taxID) > 20 {
+		return nil, "tax_id no puede superar 20 caracteres"
+	}
+
+	message := strings.TrimSpace(req.Message)
+	if utf8.RuneCountInString(message) > 500 {
+		return nil, "message no puede superar 500 caracteres"
+	}
+
+	return &m.Donation{
+		OrganizationID: req.OrganizationID,
+		PetID:          req.PetID,
+		Frequency:      frequency,
+		AmountCents:    req.AmountCents,
+		Currency:       currency,
+		TaxID:          taxID,
+		Message:        message,
+	}, ""
+}
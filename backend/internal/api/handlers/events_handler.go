@@ -0,0 +1,44 @@
+// Package handlers implements HTTP request handlers for the real-time events API.
+// This layer is responsible for:
+// - Registering clients of the event stream with the service layer
+// - Converting service errors to HTTP responses
+package handlers
+
+import (
+	m "backend/internal/models"
+	s "backend/internal/services/backend_calls"
+	"backend/internal/services/events"
+	response "backend/internal/utils/rest"
+	"net/http"
+)
+
+// ========================================
+// EVENT STREAM HANDLERS
+// ========================================
+
+// HandleSubscribeEvents processes requests to open the real-time event stream.
+//
+// Parameters:
+//   - viewer: Current user, or nil for anonymous clients (public events only)
+//   - lastEventID: ID of the last event the client received, empty for a new stream
+//
+// Returns:
+//   - *events.Subscription: Registered subscriber (release it with HandleUnsubscribeEvents)
+//   - []events.Event: Missed events to send first (or a resync event)
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleSubscribeEvents(viewer *m.NonValidatedUser, lastEventID string) (*events.Subscription, []events.Event, response.HTTPError) {
+	sub, missed, err := s.SubscribeEvents(viewer, lastEventID)
+	if err != nil {
+		return nil, nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return sub, missed, response.EmptyError
+}
+
+// HandleUnsubscribeEvents releases a client of the event stream once its connection ends.
+//
+// Parameters:
+//   - sub: Subscriber returned by HandleSubscribeEvents
+func HandleUnsubscribeEvents(sub *events.Subscription) {
+	s.UnsubscribeEvents(sub)
+}
@@ -0,0 +1,543 @@
+// Package handlers implements HTTP request handlers for the foster care API.
+// This layer is responsible for:
+// - Validating foster home profiles, placements and foster updates
+// - Calling appropriate service layer functions
+// - Converting service errors to HTTP responses
+package handlers
+
+import (
+	r_models "backend/internal/api/routes/models"
+	"backend/internal/db/query"
+	m "backend/internal/models"
+	s "backend/internal/services/backend_calls"
+	"backend/internal/services/imaging"
+	response "backend/internal/utils/rest"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ========================================
+// FOSTER HOME HANDLERS
+// ========================================
+
+// HandleListFosterHomes processes staff requests to retrieve a page of foster homes.
+//
+// Validation:
+// - Validates pagination, sorting and filter parameters (400 on invalid input)
+//
+// Parameters:
+//   - path: Request path used to build next/prev links
+//   - values: Raw query parameters
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - *query.Page[m.FosterHome]: Requested page of foster homes with their occupancy
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleListFosterHomes(path string, values url.Values, orgID uint) (*query.Page[m.FosterHome], response.HTTPError) {
+	params, err := s.NewFosterHomeListQuery(path, values)
+	if err != nil {
+		return nil, response.Error(http.StatusBadRequest, err.Error())
+	}
+
+	homes, err := s.ListFosterHomes(params, orgID)
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return homes, response.EmptyError
+}
+
+// HandleGetFosterHome processes staff requests to retrieve a foster home.
+//
+// Parameters:
+//   - id: Foster home ID
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - *m.FosterHome: Foster home with its current placements and free capacity
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleGetFosterHome(id uint, orgID uint) (*m.FosterHome, response.HTTPError) {
+	// Input validation
+	if id <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de casa de acogida no válido")
+	}
+
+	home, err := s.GetFosterHome(id, orgID)
+	if errors.Is(err, s.ErrFosterHomeNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return home, response.EmptyError
+}
+
+// HandleCreateFosterHome processes staff requests to register a foster home.
+//
+// Validation:
+// - Validates the profile fields (see toFosterHome)
+// - Ensures the foster user is given
+//
+// Parameters:
+//   - orgID: Organisation of the acting staff member
+//   - req: FosterHomeRequest with the profile
+//
+// Returns:
+//   - *m.FosterHome: Created foster home
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleCreateFosterHome(orgID uint, req r_models.FosterHomeRequest) (*m.FosterHome, response.HTTPError) {
+	// Input validation
+	if req.UserID == 0 {
+		return nil, response.Error(http.StatusBadRequest, "user_id es obligatorio")
+	}
+
+	home, msg := toFosterHome(req)
+	if msg != "" {
+		return nil, response.Error(http.StatusBadRequest, msg)
+	}
+
+	home.OrganizationID = orgID
+	home.UserID = req.UserID
+
+	created, err := s.CreateFosterHome(home)
+	if errors.Is(err, s.ErrFosterUserNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if errors.Is(err, s.ErrFosterHomeExists) {
+		return nil, response.Error(http.StatusConflict, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return created, response.EmptyError
+}
+
+// HandleUpdateFosterHome processes staff requests to replace the profile of a foster home.
+// The foster user cannot be changed and user_id is ignored.
+//
+// Parameters:
+//   - id: Foster home ID
+//   - orgID: Organisation of the acting staff member
+//   - req: FosterHomeRequest with the new profile
+//
+// Returns:
+//   - *m.FosterHome: Updated foster home
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleUpdateFosterHome(id uint, orgID uint, req r_models.FosterHomeRequest) (*m.FosterHome, response.HTTPError) {
+	// Input validation
+	if id <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de casa de acogida no válido")
+	}
+
+	home, msg := toFosterHome(req)
+	if msg != "" {
+		return nil, response.Error(http.StatusBadRequest, msg)
+	}
+
+	home.ID = id
+	home.OrganizationID = orgID
+
+	updated, err := s.UpdateFosterHome(home)
+	if errors.Is(err, s.ErrFosterHomeNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return updated, response.EmptyError
+}
+
+// HandleDeleteFosterHome processes staff requests to delete a foster home without placements.
+//
+// Parameters:
+//   - id: Foster home ID
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleDeleteFosterHome(id uint, orgID uint) response.HTTPError {
+	// Input validation
+	if id <= 0 {
+		return response.Error(http.StatusBadRequest, "ID de casa de acogida no válido")
+	}
+
+	err := s.DeleteFosterHome(id, orgID)
+	if errors.Is(err, s.ErrFosterHomeNotFound) {
+		return response.Error(http.StatusNotFound, err.Error())
+	}
+	if errors.Is(err, s.ErrFosterHomeInUse) {
+		return response.Error(http.StatusConflict, err.Error())
+	}
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return response.EmptyError
+}
+
+// ========================================
+// FOSTER PLACEMENT HANDLERS
+// ========================================
+
+// HandleListFosterPlacements processes staff requests to retrieve a page of placements.
+//
+// Validation:
+// - Validates pagination, sorting and filter parameters (400 on invalid input)
+//
+// Parameters:
+//   - path: Request path used to build next/prev links
+//   - values: Raw query parameters
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - *query.Page[m.FosterPlacement]: Requested page of placements with pet summaries
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleListFosterPlacements(path string, values url.Values, orgID uint) (*query.Page[m.FosterPlacement], response.HTTPError) {
+	params, err := s.NewFosterPlacementListQuery(path, values)
+	if err != nil {
+		return nil, response.Error(http.StatusBadRequest, err.Error())
+	}
+
+	placements, err := s.ListFosterPlacements(params, orgID)
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return placements, response.EmptyError
+}
+
+// HandleGetFosterPlacement processes staff requests to retrieve a placement.
+//
+// Parameters:
+//   - id: Placement ID
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - *m.FosterPlacement: Placement with pet summary
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleGetFosterPlacement(id uint, orgID uint) (*m.FosterPlacement, response.HTTPError) {
+	// Input validation
+	if id <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de acogida no válido")
+	}
+
+	placement, err := s.GetFosterPlacement(id, orgID)
+	if err != nil {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+
+	return placement, response.EmptyError
+}
+
+// HandleCreateFosterPlacement processes staff requests to place a pet in a foster home.
+//
+// Validation:
+// - Ensures the pet and the foster home are given
+// - Ensures the start date is valid and not in the future, and the expected end date is not before it
+//
+// Parameters:
+//   - orgID: Organisation of the acting staff member
+//   - staffID: Authenticated staff user ID
+//   - req: FosterPlacementRequest with the pet, home and dates
+//
+// Returns:
+//   - *m.FosterPlacement: Created placement
+//   - response.HTTPError: HTTP error or EmptyError on success (409 when the placement is not possible)
+func HandleCreateFosterPlacement(orgID uint, staffID uint, req r_models.FosterPlacementRequest) (*m.FosterPlacement, response.HTTPError) {
+	// Input validation
+	if req.PetID == 0 || req.FosterHomeID == 0 {
+		return nil, response.Error(http.StatusBadRequest, "pet_id y foster_home_id son obligatorios")
+	}
+
+	startDate, err := parseDate(req.StartDate)
+	if err != nil || startDate == nil {
+		return nil, response.Error(http.StatusBadRequest, "start_date es obligatoria (formato YYYY-MM-DD)")
+	}
+	if startDate.After(time.Now()) {
+		return nil, response.Error(http.StatusBadRequest, "start_date no puede ser una fecha futura")
+	}
+
+	expectedEndDate, err := parseDate(req.ExpectedEndDate)
+	if err != nil {
+		return nil, response.Error(http.StatusBadRequest, "expected_end_date inválida (formato YYYY-MM-DD)")
+	}
+	if expectedEndDate != nil && expectedEndDate.Before(*startDate) {
+		return nil, response.Error(http.StatusBadRequest, "expected_end_date no puede ser anterior a start_date")
+	}
+
+	placement := &m.FosterPlacement{
+		PetID:           req.PetID,
+		FosterHomeID:    req.FosterHomeID,
+		StartDate:       *startDate,
+		ExpectedEndDate: expectedEndDate,
+		Notes:           strings.TrimSpace(req.Notes),
+		CreatedBy:       staffID,
+	}
+
+	created, err := s.CreateFosterPlacement(placement, orgID)
+	if errors.Is(err, s.ErrFosterPetNotFound) || errors.Is(err, s.ErrFosterHomeNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if errors.Is(err, s.ErrFosterPlacementRejected) {
+		return nil, response.Error(http.StatusConflict, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return created, response.EmptyError
+}
+
+// HandleUpdateFosterPlacement processes staff requests to replace the dates and notes of a placement.
+// Setting end_date ends the placement.
+//
+// Validation:
+// - Ensures the dates are valid and the end date is not in the future
+// - Delegates checks against the start date to the service layer
+//
+// Parameters:
+//   - id: Placement ID
+//   - orgID: Organisation of the acting staff member
+//   - req: UpdateFosterPlacementRequest with the new dates and notes
+//
+// Returns:
+//   - *m.FosterPlacement: Updated placement
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleUpdateFosterPlacement(id uint, orgID uint, req r_models.UpdateFosterPlacementRequest) (*m.FosterPlacement, response.HTTPError) {
+	// Input validation
+	if id <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de acogida no válido")
+	}
+
+	expectedEndDate, err := parseDate(req.ExpectedEndDate)
+	if err != nil {
+		return nil, response.Error(http.StatusBadRequest, "expected_end_date inválida (formato YYYY-MM-DD)")
+	}
+
+	endDate, err := parseDate(req.EndDate)
+	if err != nil {
+		return nil, response.Error(http.StatusBadRequest, "end_date inválida (formato YYYY-MM-DD)")
+	}
+	if endDate != nil && endDate.After(time.Now()) {
+		return nil, response.Error(http.StatusBadRequest, "end_date no puede ser una fecha futura")
+	}
+
+	update := &m.FosterPlacement{
+		ID:              id,
+		ExpectedEndDate: expectedEndDate,
+		EndDate:         endDate,
+		Notes:           strings.TrimSpace(req.Notes),
+	}
+
+	placement, err := s.UpdateFosterPlacement(update, orgID)
+	if errors.Is(err, s.ErrFosterPlacementNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if errors.Is(err, s.ErrFosterPlacementRejected) {
+		return nil, response.Error(http.StatusBadRequest, err.Error())
+	}
+	if errors.Is(err, s.ErrFosterPlacementEnded) {
+		return nil, response.Error(http.StatusConflict, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return placement, response.EmptyError
+}
+
+// HandleListUserFosterPlacements processes requests from a foster to retrieve the pets in their care.
+//
+// Parameters:
+//   - userID: Authenticated user ID
+//
+// Returns:
+//   - []m.FosterPlacement: Placements of the user's foster homes, current ones first
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleListUserFosterPlacements(userID uint) ([]m.FosterPlacement, response.HTTPError) {
+	placements, err := s.ListUserFosterPlacements(userID)
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return placements, response.EmptyError
+}
+
+// ========================================
+// FOSTER UPDATE HANDLERS
+// ========================================
+
+// HandleListFosterUpdates processes requests to retrieve the updates of a placement.
+//
+// Parameters:
+//   - placementID: Placement ID
+//   - viewer: Authenticated user (the foster or staff of the organisation)
+//
+// Returns:
+//   - []m.FosterUpdate: Updates with photo URLs, newest first
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleListFosterUpdates(placementID uint, viewer *m.NonValidatedUser) ([]m.FosterUpdate, response.HTTPError) {
+	// Input validation
+	if placementID <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de acogida no válido")
+	}
+
+	updates, err := s.ListFosterUpdates(placementID, viewer)
+	if errors.Is(err, s.ErrFosterPlacementNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return updates, response.EmptyError
+}
+
+// HandleCreateFosterUpdate processes requests from a foster to post news about a pet in their care.
+//
+// Validation:
+// - Ensures the message is given and does not exceed 2000 characters
+// - Accepts up to s.MaxFosterUpdatePhotos photos, each validated like pet photos (413/415/400)
+//
+// Parameters:
+//   - placementID: Placement ID
+//   - req: FosterUpdateRequest with the form fields
+//   - files: Uploaded photos
+//   - authorID: Authenticated user ID (must be the foster of the placement)
+//
+// Returns:
+//   - *m.FosterUpdate: Created update with photo URLs
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleCreateFosterUpdate(placementID uint, req r_models.FosterUpdateRequest, files []*multipart.FileHeader, authorID uint) (*m.FosterUpdate, response.HTTPError) {
+	// Input validation
+	if placementID <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de acogida no válido")
+	}
+
+	message := strings.TrimSpace(req.Message)
+	if message == "" || utf8.RuneCountInString(message) > 2000 {
+		return nil, response.Error(http.StatusBadRequest, "message es obligatorio y no puede superar 2000 caracteres")
+	}
+
+	if len(files) > s.MaxFosterUpdatePhotos {
+		return nil, response.Error(http.StatusBadRequest, fmt.Sprintf("se permiten como máximo %d fotos", s.MaxFosterUpdatePhotos))
+	}
+
+	photos := make([][]byte, 0, len(files))
+	for _, file := range files {
+		data, httpErr := readUploadedPhoto(file)
+		if httpErr.Code != 0 {
+			return nil, httpErr
+		}
+		photos = append(photos, data)
+	}
+
+	update := &m.FosterUpdate{
+		PlacementID:  placementID,
+		AuthorUserID: authorID,
+		Message:      message,
+	}
+
+	// Delegate creation and photo processing to service layer
+	err := s.CreateFosterUpdate(update, photos)
+	if errors.Is(err, s.ErrFosterPlacementNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if errors.Is(err, s.ErrFosterPlacementEnded) {
+		return nil, response.Error(http.StatusConflict, err.Error())
+	}
+	if errors.Is(err, imaging.ErrInvalidImage) {
+		return nil, response.Error(http.StatusBadRequest, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return update, response.EmptyError
+}
+
+// HandleGetFosterUpdatePhotoContent processes requests to download a foster update photo variant.
+//
+// Parameters:
+//   - updateID: Update ID the photo belongs to
+//   - photoID: Photo ID to download
+//   - variant: "original" or a thumbnail size name
+//   - viewer: Authenticated user (the foster or staff of the organisation)
+//
+// Returns:
+//   - io.ReadCloser: Image content (caller must close it)
+//   - string: MIME type of the content
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleGetFosterUpdatePhotoContent(updateID uint, photoID uint, variant string, viewer *m.NonValidatedUser) (io.ReadCloser, string, response.HTTPError) {
+	// Input validation
+	if updateID <= 0 || photoID <= 0 {
+		return nil, "", response.Error(http.StatusBadRequest, "ID de novedad o foto no válido")
+	}
+
+	content, contentType, err := s.OpenFosterUpdatePhoto(updateID, photoID, variant, viewer)
+	if err != nil {
+		return nil, "", response.Error(http.StatusNotFound, err.Error())
+	}
+
+	return content, contentType, response.EmptyError
+}
+
+// ========================================
+// FOSTER HELPERS
+// ========================================
+
+// toFosterHome validates a foster home profile and converts it into a foster home.
+// It returns an error message, or "" if valid.
+func toFosterHome(req r_models.FosterHomeRequest) (*m.FosterHome, string) {
+	home := &m.FosterHome{
+		Capacity:     req.Capacity,
+		HomeType:     strings.TrimSpace(req.HomeType),
+		HasGarden:    req.HasGarden,
+		HasChildren:  req.HasChildren,
+		HasOtherPets: req.HasOtherPets,
+		Address:      strings.TrimSpace(req.Address),
+		Phone:        strings.TrimSpace(req.Phone),
+		Notes:        strings.TrimSpace(req.Notes),
+		Active:       req.Active == nil || *req.Active,
+	}
+
+	if home.Capacity < 1 || home.Capacity > 20 {
+		return nil, "capacity debe estar entre 1 y 20"
+	}
+
+	if !slices.Contains(m.FosterHomeTypes, home.HomeType) {
+		return nil, fmt.Sprintf("home_type inválido, debe ser uno de: %s", strings.Join(m.FosterHomeTypes, ", "))
+	}
+
+	if utf8.RuneCountInString(home.Address) > 255 || utf8.RuneCountInString(home.Phone) > 30 {
+		return nil, "address no puede superar 255 caracteres y phone 30"
+	}
+
+	if len(req.AcceptedSpecies) > 20 {
+		return nil, "accepted_species admite como máximo 20 especies"
+	}
+
+	home.AcceptedSpecies = []string{}
+	for _, species := range req.AcceptedSpecies {
+		species = strings.TrimSpace(species)
+		if species == "" || utf8.RuneCountInString(species) > 100 {
+			return nil, "las especies de accepted_species no pueden estar vacías ni superar 100 caracteres"
+		}
+		if !slices.Contains(home.AcceptedSpecies, species) {
+			home.AcceptedSpecies = append(home.AcceptedSpecies, species)
+		}
+	}
+
+	return home, ""
+}
@@ -0,0 +1,338 @@
+// Package handlers implements HTTP request handlers for the intake records API.
+// This layer is responsible for:
+// - Validating intakes and the pets created with them
+// - Calling appropriate service layer functions
+// - Converting service errors to HTTP responses
+package handlers
+
+import (
+	r_models "backend/internal/api/routes/models"
+	"backend/internal/db/query"
+	m "backend/internal/models"
+	s "backend/internal/services/backend_calls"
+	response "backend/internal/utils/rest"
+	"errors"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxIntakeStatsMonths is the longest range of the intake statistics.
+const maxIntakeStatsMonths = 36
+
+// ========================================
+// INTAKE HANDLERS
+// ========================================
+
+// HandleListIntakes processes staff requests to retrieve a page of intakes.
+//
+// Validation:
+// - Validates pagination, sorting and filter parameters (400 on invalid input)
+//
+// Parameters:
+//   - path: Request path used to build next/prev links
+//   - values: Raw query parameters
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - *query.Page[m.Intake]: Requested page of intakes with pet summaries
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleListIntakes(path string, values url.Values, orgID uint) (*query.Page[m.Intake], response.HTTPError) {
+	params, err := s.NewIntakeListQuery(path, values)
+	if err != nil {
+		return nil, response.Error(http.StatusBadRequest, err.Error())
+	}
+
+	intakes, err := s.ListIntakes(params, orgID)
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return intakes, response.EmptyError
+}
+
+// HandleGetIntake processes staff requests to retrieve an intake.
+//
+// Parameters:
+//   - id: Intake ID
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - *m.Intake: Intake with pet summary
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleGetIntake(id uint, orgID uint) (*m.Intake, response.HTTPError) {
+	// Input validation
+	if id <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de ingreso no válido")
+	}
+
+	intake, err := s.GetIntake(id, orgID)
+	if errors.Is(err, s.ErrIntakeNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return intake, response.EmptyError
+}
+
+// HandleCreateIntake processes staff requests to record the arrival of a new pet.
+//
+// Validation:
+// - Validates the intake fields (see toIntake) and the pet (see toIntakePet)
+// - Rejects invalid microchip numbers (400) and microchips assigned to another pet (409)
+//
+// Parameters:
+//   - orgID: Organisation of the acting staff member
+//   - staffID: Staff user recording the intake
+//   - req: IntakeRequest with the intake and the pet
+//
+// Returns:
+//   - *m.Intake: Created intake with the new pet's summary
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleCreateIntake(orgID uint, staffID uint, req r_models.IntakeRequest) (*m.Intake, response.HTTPError) {
+	// Input validation
+	if req.Pet == nil {
+		return nil, response.Error(http.StatusBadRequest, "pet es obligatorio")
+	}
+
+	pet, msg := toIntakePet(*req.Pet)
+	if msg != "" {
+		return nil, response.Error(http.StatusBadRequest, msg)
+	}
+
+	intake, msg := toIntake(req)
+	if msg != "" {
+		return nil, response.Error(http.StatusBadRequest, msg)
+	}
+
+	pet.OrganizationID = orgID
+	intake.OrganizationID = orgID
+	intake.CreatedBy = staffID
+
+	created, err := s.CreateIntake(intake, pet)
+	if errors.Is(err, s.ErrInvalidMicrochip) {
+		return nil, response.Error(http.StatusBadRequest, err.Error())
+	}
+	if errors.Is(err, s.ErrMicrochipInUse) {
+		return nil, response.Error(http.StatusConflict, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return created, response.EmptyError
+}
+
+// HandleUpdateIntake processes staff requests to replace the details of an intake.
+// The pet cannot be changed here and pet is ignored.
+//
+// Parameters:
+//   - id: Intake ID
+//   - orgID: Organisation of the acting staff member
+//   - req: IntakeRequest with the new details
+//
+// Returns:
+//   - *m.Intake: Updated intake
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleUpdateIntake(id uint, orgID uint, req r_models.IntakeRequest) (*m.Intake, response.HTTPError) {
+	// Input validation
+	if id <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de ingreso no válido")
+	}
+
+	intake, msg := toIntake(req)
+	if msg != "" {
+		return nil, response.Error(http.StatusBadRequest, msg)
+	}
+
+	intake.ID = id
+	intake.OrganizationID = orgID
+
+	updated, err := s.UpdateIntake(intake)
+	if errors.Is(err, s.ErrIntakeNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return updated, response.EmptyError
+}
+
+// HandleGetIntakeStats processes staff requests to compare intakes and outcomes month by month.
+//
+// Validation:
+// - Ensures the months are valid YYYY-MM values, in order and at most 36 months apart
+//
+// Parameters:
+//   - orgID: Organisation of the acting staff member
+//   - from: First month (YYYY-MM, default 11 months before to)
+//   - to: Last month, included (YYYY-MM, default current month)
+//
+// Returns:
+//   - *m.IntakeStats: Monthly intakes and outcomes with totals
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleGetIntakeStats(orgID uint, from string, to string) (*m.IntakeStats, response.HTTPError) {
+	// Input validation
+	start, err := parseMonth(from)
+	if err != nil {
+		return nil, response.Error(http.StatusBadRequest, "from inválido (formato YYYY-MM)")
+	}
+
+	end, err := parseMonth(to)
+	if err != nil {
+		return nil, response.Error(http.StatusBadRequest, "to inválido (formato YYYY-MM)")
+	}
+
+	if end == nil {
+		now := time.Now()
+		current := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
+		end = &current
+	}
+	if start == nil {
+		first := end.AddDate(0, -11, 0)
+		start = &first
+	}
+
+	if end.Before(*start) || !start.AddDate(0, maxIntakeStatsMonths, 0).After(*end) {
+		return nil, response.Error(http.StatusBadRequest, "to no puede ser anterior a from y el periodo no puede superar 36 meses")
+	}
+
+	stats, err := s.GetIntakeStats(orgID, *start, *end)
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return stats, response.EmptyError
+}
+
+// ========================================
+// INTAKE HELPERS
+// ========================================
+
+// toIntake validates an intake request and converts it to an intake.
+// Fields that do not apply to the source are discarded.
+// Returns an error message when the request is invalid.
+func toIntake(req r_models.IntakeRequest) (*m.Intake, string) {
+	intake := &m.Intake{
+		Source:         strings.TrimSpace(req.Source),
+		Condition:      strings.TrimSpace(req.Condition),
+		ConditionNotes: strings.TrimSpace(req.ConditionNotes),
+		Notes:          strings.TrimSpace(req.Notes),
+	}
+
+	if !slices.Contains(m.IntakeSources, intake.Source) {
+		return nil, "source debe ser stray, owner_surrender, transfer o born_in_care"
+	}
+	if !slices.Contains(m.IntakeConditions, intake.Condition) {
+		return nil, "condition debe ser healthy, treatable, injured, sick o critical"
+	}
+
+	intakeDate, err := parseDate(req.IntakeDate)
+	if err != nil {
+		return nil, "intake_date inválida (formato YYYY-MM-DD)"
+	}
+	if intakeDate == nil {
+		now := time.Now()
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+		intakeDate = &today
+	}
+	if intakeDate.After(time.Now()) {
+		return nil, "intake_date no puede ser una fecha futura"
+	}
+	intake.IntakeDate = *intakeDate
+
+	switch intake.Source {
+	case m.IntakeSourceOwnerSurrender:
+		intake.SurrendererName = strings.TrimSpace(req.SurrendererName)
+		intake.SurrendererEmail = strings.TrimSpace(req.SurrendererEmail)
+		intake.SurrendererPhone = strings.TrimSpace(req.SurrendererPhone)
+		intake.SurrendererAddress = strings.TrimSpace(req.SurrendererAddress)
+		intake.SurrenderReason = strings.TrimSpace(req.SurrenderReason)
+
+		if intake.SurrendererName == "" || utf8.RuneCountInString(intake.SurrendererName) > 150 {
+			return nil, "surrenderer_name es obligatorio en las entregas de propietarios y no puede superar 150 caracteres"
+		}
+		if intake.SurrendererEmail != "" && !isValidEmail(intake.SurrendererEmail) {
+			return nil, "surrenderer_email no es un email válido"
+		}
+		if utf8.RuneCountInString(intake.SurrendererPhone) > 30 || utf8.RuneCountInString(intake.SurrendererAddress) > 255 {
+			return nil, "surrenderer_phone no puede superar 30 caracteres y surrenderer_address 255"
+		}
+
+	case m.IntakeSourceTransfer:
+		intake.TransferFrom = strings.TrimSpace(req.TransferFrom)
+		if intake.TransferFrom == "" || utf8.RuneCountInString(intake.TransferFrom) > 150 {
+			return nil, "transfer_from es obligatorio en los traslados y no puede superar 150 caracteres"
+		}
+
+	case m.IntakeSourceStray:
+		intake.FoundLocation = strings.TrimSpace(req.FoundLocation)
+		if utf8.RuneCountInString(intake.FoundLocation) > 255 {
+			return nil, "found_location no puede superar 255 caracteres"
+		}
+
+		if (req.FoundLatitude == nil) != (req.FoundLongitude == nil) {
+			return nil, "found_latitude y found_longitude deben indicarse juntas"
+		}
+		if req.FoundLatitude != nil {
+			if *req.FoundLatitude < -90 || *req.FoundLatitude > 90 || *req.FoundLongitude < -180 || *req.FoundLongitude > 180 {
+				return nil, "found_latitude debe estar entre -90 y 90 y found_longitude entre -180 y 180"
+			}
+			intake.FoundLatitude = req.FoundLatitude
+			intake.FoundLongitude = req.FoundLongitude
+		}
+	}
+
+	return intake, ""
+}
+
+// toIntakePet validates the pet of an intake request and converts it to a pet.
+// Returns an error message when the request is invalid.
+func toIntakePet(req r_models.IntakePetRequest) (*m.Pet, string) {
+	pet := &m.Pet{
+		Name:        strings.TrimSpace(req.Name),
+		Species:     strings.TrimSpace(req.Species),
+		Breed:       strings.TrimSpace(req.Breed),
+		Color:       strings.TrimSpace(req.Color),
+		Description: strings.TrimSpace(req.Description),
+		Microchip:   req.Microchip,
+		Status:      m.PetStatusAvailable,
+	}
+
+	if pet.Name == "" || pet.Species == "" {
+		return nil, "nombre y especie de mascota son obligatorios"
+	}
+
+	birthDate, err := parseDate(req.BirthDate)
+	if err != nil {
+		return nil, "birth_date inválida (formato YYYY-MM-DD)"
+	}
+	if birthDate != nil {
+		if birthDate.After(time.Now()) {
+			return nil, "birth_date no puede ser una fecha futura"
+		}
+		pet.BirthDate = *birthDate
+	}
+
+	return pet, ""
+}
+
+// parseMonth parses an optional YYYY-MM month into its first day; empty values return nil.
+func parseMonth(value string) (*time.Time, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+
+	month, err := time.ParseInLocation("2006-01", strings.TrimSpace(value), time.Local)
+	if err != nil {
+		return nil, err
+	}
+
+	return &month, nil
+}
@@ -0,0 +1,93 @@
+// Package handlers implements HTTP request handlers for the background jobs admin API.
+// This layer is responsible for:
+// - Validating job names and run options
+// - Calling appropriate service layer functions
+// - Converting scheduler errors to HTTP responses
+package handlers
+
+import (
+	m "backend/internal/models"
+	s "backend/internal/services/backend_calls"
+	"backend/internal/services/scheduler"
+	response "backend/internal/utils/rest"
+	"errors"
+	"net/http"
+)
+
+// MaxJobRuns is the maximum number of runs returned by the run history.
+const MaxJobRuns = 100
+
+// ========================================
+// BACKGROUND JOB HANDLERS
+// ========================================
+
+// HandleListJobs processes requests to list the background jobs and their latest run.
+//
+// Returns:
+//   - []scheduler.JobStatus: Registered jobs
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleListJobs() ([]scheduler.JobStatus, response.HTTPError) {
+	jobs, err := s.ListJobs()
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return jobs, response.EmptyError
+}
+
+// HandleListJobRuns processes requests to retrieve the run history of a job.
+//
+// Validation:
+// - Ensures limit is between 1 and MaxJobRuns (defaults to 20 when 0)
+//
+// Parameters:
+//   - name: Job name
+//   - limit: Maximum number of runs
+//
+// Returns:
+//   - []m.JobRun: Latest runs, newest first
+//   - response.HTTPError: 404 for unknown jobs, HTTP error or EmptyError on success
+func HandleListJobRuns(name string, limit int) ([]m.JobRun, response.HTTPError) {
+	// Input validation
+	if limit == 0 {
+		limit = 20
+	}
+	if limit < 1 || limit > MaxJobRuns {
+		return nil, response.Error(http.StatusBadRequest, "limit debe estar entre 1 y 100")
+	}
+
+	runs, err := s.ListJobRuns(name, limit)
+	if errors.Is(err, scheduler.ErrUnknownJob) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return runs, response.EmptyError
+}
+
+// HandleTriggerJob processes requests to run a job immediately.
+//
+// Parameters:
+//   - name: Job name
+//   - dryRun: Whether to only preview the work
+//   - force: Whether to run even if the current period was already processed
+//
+// Returns:
+//   - *m.JobRun: Recorded run with its outcome
+//   - response.HTTPError: 404 unknown job, 409 period already processed, HTTP error or EmptyError on success
+func HandleTriggerJob(name string, dryRun bool, force bool) (*m.JobRun, response.HTTPError) {
+	run, err := s.TriggerJob(name, dryRun, force)
+	if errors.Is(err, scheduler.ErrUnknownJob) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if errors.Is(err, scheduler.ErrAlreadyRan) {
+		return nil, response.Error(http.StatusConflict, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return run, response.EmptyError
+}
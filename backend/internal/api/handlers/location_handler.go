@@ -0,0 +1,359 @@
+// Package handlers implements HTTP request handlers for the shelter locations API.
+// This layer is responsible for:
+// - Validating buildings, rooms and kennels, pet moves and occupancy queries
+// - Calling appropriate service layer functions
+// - Converting service errors to HTTP responses
+package handlers
+
+import (
+	r_models "backend/internal/api/routes/models"
+	"backend/internal/db/query"
+	m "backend/internal/models"
+	s "backend/internal/services/backend_calls"
+	response "backend/internal/utils/rest"
+	"errors"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// ========================================
+// LOCATION HANDLERS
+// ========================================
+
+// HandleListLocations processes staff requests to retrieve a page of locations.
+//
+// Validation:
+// - Validates pagination, sorting and filter parameters (400 on invalid input)
+//
+// Parameters:
+//   - path: Request path used to build next/prev links
+//   - values: Raw query parameters
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - *query.Page[m.Location]: Requested page of locations with their occupancy
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleListLocations(path string, values url.Values, orgID uint) (*query.Page[m.Location], response.HTTPError) {
+	params, err := s.NewLocationListQuery(path, values)
+	if err != nil {
+		return nil, response.Error(http.StatusBadRequest, err.Error())
+	}
+
+	locations, err := s.ListLocations(params, orgID)
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return locations, response.EmptyError
+}
+
+// HandleGetLocation processes staff requests to retrieve a location.
+//
+// Parameters:
+//   - id: Location ID
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - *m.Location: Location with its occupancy and, for kennels, its pets
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleGetLocation(id uint, orgID uint) (*m.Location, response.HTTPError) {
+	// Input validation
+	if id <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de ubicación no válido")
+	}
+
+	location, err := s.GetLocation(id, orgID)
+	if errors.Is(err, s.ErrLocationNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return location, response.EmptyError
+}
+
+// HandleCreateLocation processes staff requests to add a building, room or kennel.
+//
+// Validation:
+// - Validates the location fields (see toLocation)
+// - Ensures the kind is valid
+//
+// Parameters:
+//   - orgID: Organisation of the acting staff member
+//   - req: LocationRequest with the location
+//
+// Returns:
+//   - *m.Location: Created location
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleCreateLocation(orgID uint, req r_models.LocationRequest) (*m.Location, response.HTTPError) {
+	// Input validation
+	kind := strings.TrimSpace(req.Kind)
+	if !slices.Contains(m.LocationKinds, kind) {
+		return nil, response.Error(http.StatusBadRequest, "kind debe ser building, room o kennel")
+	}
+
+	location, msg := toLocation(req, kind)
+	if msg != "" {
+		return nil, response.Error(http.StatusBadRequest, msg)
+	}
+
+	location.OrganizationID = orgID
+	location.Kind = kind
+	location.ParentID = req.ParentID
+
+	created, err := s.CreateLocation(location)
+	if errors.Is(err, s.ErrLocationParent) {
+		return nil, response.Error(http.StatusBadRequest, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return created, response.EmptyError
+}
+
+// HandleUpdateLocation processes staff requests to replace the data of a location.
+// The kind and the parent cannot be changed; kind and parent_id are ignored.
+//
+// Parameters:
+//   - id: Location ID
+//   - orgID: Organisation of the acting staff member
+//   - req: LocationRequest with the new data
+//
+// Returns:
+//   - *m.Location: Updated location
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleUpdateLocation(id uint, orgID uint, req r_models.LocationRequest) (*m.Location, response.HTTPError) {
+	// Input validation
+	if id <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de ubicación no válido")
+	}
+
+	current, httpErr := HandleGetLocation(id, orgID)
+	if httpErr.Code != 0 {
+		return nil, httpErr
+	}
+
+	location, msg := toLocation(req, current.Kind)
+	if msg != "" {
+		return nil, response.Error(http.StatusBadRequest, msg)
+	}
+
+	location.ID = id
+	location.OrganizationID = orgID
+
+	updated, err := s.UpdateLocation(location)
+	if errors.Is(err, s.ErrLocationNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return updated, response.EmptyError
+}
+
+// HandleDeleteLocation processes staff requests to delete an empty location without history.
+//
+// Parameters:
+//   - id: Location ID
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleDeleteLocation(id uint, orgID uint) response.HTTPError {
+	// Input validation
+	if id <= 0 {
+		return response.Error(http.StatusBadRequest, "ID de ubicación no válido")
+	}
+
+	err := s.DeleteLocation(id, orgID)
+	if errors.Is(err, s.ErrLocationNotFound) {
+		return response.Error(http.StatusNotFound, err.Error())
+	}
+	if errors.Is(err, s.ErrLocationInUse) {
+		return response.Error(http.StatusConflict, err.Error())
+	}
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return response.EmptyError
+}
+
+// HandleGetOccupancy processes staff requests to check the free kennel places before an intake.
+//
+// Validation:
+// - Ensures incoming is between 0 and 1000
+//
+// Parameters:
+//   - orgID: Organisation of the acting staff member
+//   - species: Species of the intake (empty for any)
+//   - incoming: Number of pets about to arrive
+//
+// Returns:
+//   - *m.OccupancyReport: Free places and warning
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleGetOccupancy(orgID uint, species string, incoming int) (*m.OccupancyReport, response.HTTPError) {
+	// Input validation
+	if incoming < 0 || incoming > 1000 {
+		return nil, response.Error(http.StatusBadRequest, "incoming debe estar entre 0 y 1000")
+	}
+
+	report, err := s.GetOccupancy(orgID, strings.TrimSpace(species), incoming)
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return report, response.EmptyError
+}
+
+// ========================================
+// PET HOUSING HANDLERS
+// ========================================
+
+// HandleMovePet processes staff requests to house a pet in a kennel.
+//
+// Validation:
+// - Ensures the kennel is given and the reason is not too long
+//
+// Parameters:
+//   - petID: Pet ID
+//   - orgID: Organisation of the acting staff member
+//   - staffID: Staff user moving the pet
+//   - req: PetMoveRequest with the kennel
+//
+// Returns:
+//   - *m.PetLocation: New stay of the pet
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleMovePet(petID uint, orgID uint, staffID uint, req r_models.PetMoveRequest) (*m.PetLocation, response.HTTPError) {
+	// Input validation
+	if petID <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de mascota no válido")
+	}
+	if req.LocationID == 0 {
+		return nil, response.Error(http.StatusBadRequest, "location_id es obligatorio")
+	}
+
+	reason := strings.TrimSpace(req.Reason)
+	if utf8.RuneCountInString(reason) > 255 {
+		return nil, response.Error(http.StatusBadRequest, "reason no puede superar 255 caracteres")
+	}
+
+	stay, err := s.MovePet(petID, orgID, req.LocationID, reason, req.Force, staffID)
+	if errors.Is(err, s.ErrLocationPetNotFound) || errors.Is(err, s.ErrLocationNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if errors.Is(err, s.ErrLocationRejected) || errors.Is(err, s.ErrLocationFull) {
+		return nil, response.Error(http.StatusConflict, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return stay, response.EmptyError
+}
+
+// HandleReleasePetLocation processes staff requests to take a pet out of its kennel.
+//
+// Parameters:
+//   - petID: Pet ID
+//   - orgID: Organisation of the acting staff member
+//   - reason: Why the pet left (optional)
+//
+// Returns:
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleReleasePetLocation(petID uint, orgID uint, reason string) response.HTTPError {
+	// Input validation
+	if petID <= 0 {
+		return response.Error(http.StatusBadRequest, "ID de mascota no válido")
+	}
+
+	reason = strings.TrimSpace(reason)
+	if utf8.RuneCountInString(reason) > 255 {
+		return response.Error(http.StatusBadRequest, "reason no puede superar 255 caracteres")
+	}
+
+	err := s.ReleasePetLocation(petID, orgID, reason)
+	if errors.Is(err, s.ErrLocationPetNotFound) || errors.Is(err, s.ErrPetNotHoused) {
+		return response.Error(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return response.EmptyError
+}
+
+// HandleGetPetLocationHistory processes staff requests to retrieve where a pet has been housed.
+//
+// Parameters:
+//   - petID: Pet ID
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - []m.PetLocation: Stays of the pet, most recent first
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleGetPetLocationHistory(petID uint, orgID uint) ([]m.PetLocation, response.HTTPError) {
+	// Input validation
+	if petID <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de mascota no válido")
+	}
+
+	stays, err := s.GetPetLocationHistory(petID, orgID)
+	if errors.Is(err, s.ErrLocationPetNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return stays, response.EmptyError
+}
+
+// ========================================
+// LOCATION HELPERS
+// ========================================
+
+// toLocation validates a location request and converts it to a location.
+// The kind decides whether the capacity is required; kind and parent are set by the caller.
+// Returns an error message when the request is invalid.
+func toLocation(req r_models.LocationRequest, kind string) (*m.Location, string) {
+	location := &m.Location{
+		Name:   strings.TrimSpace(req.Name),
+		Notes:  strings.TrimSpace(req.Notes),
+		Active: req.Active == nil || *req.Active,
+	}
+
+	if location.Name == "" || utf8.RuneCountInString(location.Name) > 100 {
+		return nil, "name es obligatorio y no puede superar 100 caracteres"
+	}
+
+	if kind == m.LocationKindKennel {
+		if req.Capacity < 1 || req.Capacity > 100 {
+			return nil, "capacity debe estar entre 1 y 100 para las jaulas"
+		}
+		location.Capacity = req.Capacity
+	}
+
+	if len(req.AcceptedSpecies) > 20 {
+		return nil, "accepted_species admite como máximo 20 especies"
+	}
+
+	location.AcceptedSpecies = []string{}
+	for _, species := range req.AcceptedSpecies {
+		species = strings.TrimSpace(species)
+		if species == "" || utf8.RuneCountInString(species) > 100 {
+			return nil, "las especies de accepted_species no pueden estar vacías ni superar 100 caracteres"
+		}
+		if !slices.Contains(location.AcceptedSpecies, species) {
+			location.AcceptedSpecies = append(location.AcceptedSpecies, species)
+		}
+	}
+
+	return location, ""
+}
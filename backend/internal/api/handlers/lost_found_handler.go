@@ -0,0 +1,328 @@
+// Package handlers implements HTTP request handlers for the lost and found API.
+// This layer is responsible for:
+// - Validating public report submissions and their photos
+// - Validating staff moderation requests
+// - Calling appropriate service layer functions
+// - Converting service errors to HTTP responses
+package handlers
+
+import (
+	r_models "backend/internal/api/routes/models"
+	"backend/internal/db/query"
+	m "backend/internal/models"
+	s "backend/internal/services/backend_calls"
+	"backend/internal/services/imaging"
+	response "backend/internal/utils/rest"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ========================================
+// LOST AND FOUND HANDLERS
+// ========================================
+
+// HandleListLostFoundReports processes requests to retrieve a page of lost and found reports.
+//
+// Validation:
+// - Validates pagination, sorting and filter parameters (400 on invalid input)
+//
+// Parameters:
+//   - path: Request path used to build next/prev links
+//   - values: Raw query parameters
+//   - viewer: Current user, or nil for anonymous requests
+//
+// Returns:
+//   - *query.Page[m.LostFoundReport]: Requested page of reports (approved only for non-staff)
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleListLostFoundReports(path string, values url.Values, viewer *m.NonValidatedUser) (*query.Page[m.LostFoundReport], response.HTTPError) {
+	params, err := s.NewLostFoundListQuery(path, values)
+	if err != nil {
+		return nil, response.Error(http.StatusBadRequest, err.Error())
+	}
+
+	reports, err := s.ListLostFoundReports(params, viewer)
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return reports, response.EmptyError
+}
+
+// HandleGetLostFoundReport processes requests to retrieve a lost or found report.
+//
+// Parameters:
+//   - id: Report ID
+//   - viewer: Current user, or nil for anonymous requests
+//
+// Returns:
+//   - *m.LostFoundReport: Report data (404 for reports not visible to the viewer)
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleGetLostFoundReport(id uint, viewer *m.NonValidatedUser) (*m.LostFoundReport, response.HTTPError) {
+	// Input validation
+	if id <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de aviso no válido")
+	}
+
+	report, err := s.GetLostFoundReport(id, viewer)
+	if err != nil {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+
+	return report, response.EmptyError
+}
+
+// HandleCreateLostFoundReport processes public lost or found report submissions.
+//
+// Validation:
+// - Validates the report fields (see toLostFoundReport)
+// - Accepts up to s.MaxLostFoundPhotos photos, each validated like pet photos (413/415/400)
+// - Links the report to the session user, if any, whose email is used when none is given
+//
+// Parameters:
+//   - req: LostFoundReportRequest with the form fields
+//   - files: Uploaded photos
+//   - viewer: Current user, or nil for anonymous submissions
+//
+// Returns:
+//   - *m.LostFoundReport: Created report (pending moderation)
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleCreateLostFoundReport(req r_models.LostFoundReportRequest, files []*multipart.FileHeader, viewer *m.NonValidatedUser) (*m.LostFoundReport, response.HTTPError) {
+	if viewer != nil && strings.TrimSpace(req.ReporterEmail) == "" {
+		req.ReporterEmail = viewer.Email
+	}
+
+	// Input validation
+	report, msg := toLostFoundReport(req)
+	if msg != "" {
+		return nil, response.Error(http.StatusBadRequest, msg)
+	}
+
+	if viewer != nil {
+		report.ReporterUserID = &viewer.ID
+	}
+
+	if len(files) > s.MaxLostFoundPhotos {
+		return nil, response.Error(http.StatusBadRequest, fmt.Sprintf("se permiten como máximo %d fotos", s.MaxLostFoundPhotos))
+	}
+
+	photos := make([][]byte, 0, len(files))
+	for _, file := range files {
+		data, httpErr := readUploadedPhoto(file)
+		if httpErr.Code != 0 {
+			return nil, httpErr
+		}
+		photos = append(photos, data)
+	}
+
+	// Delegate creation and photo processing to service layer
+	err := s.CreateLostFoundReport(report, photos)
+	if errors.Is(err, imaging.ErrInvalidImage) {
+		return nil, response.Error(http.StatusBadRequest, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return report, response.EmptyError
+}
+
+// HandleModerateLostFoundReport processes staff requests to change the status of a report.
+//
+// Validation:
+// - Ensures the status is pending, approved, rejected or resolved
+// - Ensures the note does not exceed 500 characters
+//
+// Parameters:
+//   - id: Report ID
+//   - req: ModerateLostFoundRequest with the new status and note
+//   - moderatorID: Authenticated staff user ID
+//
+// Returns:
+//   - *m.LostFoundReport: Moderated report
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleModerateLostFoundReport(id uint, req r_models.ModerateLostFoundRequest, moderatorID uint) (*m.LostFoundReport, response.HTTPError) {
+	// Input validation
+	if id <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de aviso no válido")
+	}
+
+	if !slices.Contains(m.LostFoundStatuses, req.Status) {
+		return nil, response.Error(http.StatusBadRequest, fmt.Sprintf("estado inválido, debe ser uno de: %s", strings.Join(m.LostFoundStatuses, ", ")))
+	}
+
+	note := strings.TrimSpace(req.Note)
+	if utf8.RuneCountInString(note) > 500 {
+		return nil, response.Error(http.StatusBadRequest, "note no puede superar 500 caracteres")
+	}
+
+	report, err := s.ModerateLostFoundReport(id, req.Status, note, moderatorID)
+	if errors.Is(err, s.ErrLostFoundNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return report, response.EmptyError
+}
+
+// HandleDeleteLostFoundReport processes staff requests to delete a report.
+//
+// Parameters:
+//   - id: Report ID
+//
+// Returns:
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleDeleteLostFoundReport(id uint) response.HTTPError {
+	// Input validation
+	if id <= 0 {
+		return response.Error(http.StatusBadRequest, "ID de aviso no válido")
+	}
+
+	err := s.DeleteLostFoundReport(id)
+	if errors.Is(err, s.ErrLostFoundNotFound) {
+		return response.Error(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return response.EmptyError
+}
+
+// HandleListLostFoundMatches processes staff requests to retrieve the candidate pets of a report.
+//
+// Parameters:
+//   - id: Report ID
+//
+// Returns:
+//   - []m.LostFoundMatch: Candidate pets, strongest match first
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleListLostFoundMatches(id uint) ([]m.LostFoundMatch, response.HTTPError) {
+	// Input validation
+	if id <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de aviso no válido")
+	}
+
+	matches, err := s.ListLostFoundMatches(id)
+	if errors.Is(err, s.ErrLostFoundNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return matches, response.EmptyError
+}
+
+// HandleGetLostFoundPhotoContent processes requests to download a report photo variant.
+//
+// Parameters:
+//   - reportID: Report ID the photo belongs to
+//   - photoID: Photo ID to download
+//   - variant: "original" or a thumbnail size name
+//   - viewer: Current user, or nil for anonymous requests
+//
+// Returns:
+//   - io.ReadCloser: Image content (caller must close it)
+//   - string: MIME type of the content
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleGetLostFoundPhotoContent(reportID uint, photoID uint, variant string, viewer *m.NonValidatedUser) (io.ReadCloser, string, response.HTTPError) {
+	// Input validation
+	if reportID <= 0 || photoID <= 0 {
+		return nil, "", response.Error(http.StatusBadRequest, "ID de aviso o foto no válido")
+	}
+
+	content, contentType, err := s.OpenLostFoundPhoto(reportID, photoID, variant, viewer)
+	if err != nil {
+		return nil, "", response.Error(http.StatusNotFound, err.Error())
+	}
+
+	return content, contentType, response.EmptyError
+}
+
+// ========================================
+// LOST AND FOUND HELPERS
+// ========================================
+
+// toLostFoundReport validates a submission and converts it into a report.
+// It returns an error message, or "" if valid.
+func toLostFoundReport(req r_models.LostFoundReportRequest) (*m.LostFoundReport, string) {
+	report := &m.LostFoundReport{
+		Type:          strings.TrimSpace(req.Type),
+		Species:       strings.TrimSpace(req.Species),
+		Breed:         strings.TrimSpace(req.Breed),
+		Color:         strings.TrimSpace(req.Color),
+		Description:   strings.TrimSpace(req.Description),
+		Location:      strings.TrimSpace(req.Location),
+		ReporterName:  strings.TrimSpace(req.ReporterName),
+		ReporterEmail: strings.TrimSpace(req.ReporterEmail),
+		ReporterPhone: strings.TrimSpace(req.ReporterPhone),
+	}
+
+	if !slices.Contains(m.LostFoundTypes, report.Type) {
+		return nil, fmt.Sprintf("tipo inválido, debe ser uno de: %s", strings.Join(m.LostFoundTypes, ", "))
+	}
+
+	if report.Species == "" || report.ReporterName == "" {
+		return nil, "species y reporter_name son obligatorios"
+	}
+
+	if utf8.RuneCountInString(report.Species) > 100 || utf8.RuneCountInString(report.Breed) > 100 ||
+		utf8.RuneCountInString(report.ReporterName) > 100 {
+		return nil, "species, breed y reporter_name no pueden superar 100 caracteres"
+	}
+
+	if utf8.RuneCountInString(report.Color) > 50 || utf8.RuneCountInString(report.ReporterPhone) > 30 {
+		return nil, "color no puede superar 50 caracteres y reporter_phone 30"
+	}
+
+	if utf8.RuneCountInString(report.Location) > 255 || utf8.RuneCountInString(report.Description) > 2000 {
+		return nil, "location no puede superar 255 caracteres y description 2000"
+	}
+
+	address, err := mail.ParseAddress(report.ReporterEmail)
+	if err != nil || address.Address != report.ReporterEmail || len(report.ReporterEmail) > 255 {
+		return nil, "reporter_email es obligatorio y debe ser un email válido"
+	}
+
+	latitude, err := strconv.ParseFloat(strings.TrimSpace(req.Latitude), 64)
+	if err != nil || latitude < -90 || latitude > 90 {
+		return nil, "latitude es obligatoria y debe estar entre -90 y 90"
+	}
+	longitude, err := strconv.ParseFloat(strings.TrimSpace(req.Longitude), 64)
+	if err != nil || longitude < -180 || longitude > 180 {
+		return nil, "longitude es obligatoria y debe estar entre -180 y 180"
+	}
+	report.Latitude = latitude
+	report.Longitude = longitude
+
+	seenDate, err := parseDate(req.SeenDate)
+	if err != nil || seenDate == nil {
+		return nil, "seen_date es obligatoria (formato YYYY-MM-DD)"
+	}
+	if seenDate.After(time.Now()) {
+		return nil, "seen_date no puede ser una fecha futura"
+	}
+	report.SeenDate = *seenDate
+
+	if strings.TrimSpace(req.Microchip) != "" {
+		microchip, err := s.NormalizeMicrochip(req.Microchip)
+		if err != nil {
+			return nil, err.Error()
+		}
+		report.Microchip = &microchip
+	}
+
+	return report, ""
+}
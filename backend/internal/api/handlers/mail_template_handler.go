@@ -0,0 +1,50 @@
+// Package handlers implements HTTP request handlers for the email template admin API.
+// This layer is responsible for:
+// - Calling appropriate service layer functions
+// - Converting service errors to HTTP responses
+package handlers
+
+import (
+	s "backend/internal/services/backend_calls"
+	mailer "backend/internal/services/mail"
+	response "backend/internal/utils/rest"
+	"errors"
+	"net/http"
+)
+
+// ========================================
+// MAIL TEMPLATE HANDLERS
+// ========================================
+
+// HandleListMailTemplates processes admin requests to list the email templates.
+//
+// Returns:
+//   - []s.MailTemplate: Templates with their locales
+//   - response.HTTPError: EmptyError
+func HandleListMailTemplates() ([]s.MailTemplate, response.HTTPError) {
+	return s.ListMailTemplates(), response.EmptyError
+}
+
+// HandlePreviewMailTemplate processes admin requests to render an email template with sample data.
+//
+// Parameters:
+//   - name: Template name
+//   - locale: Locale to render (empty for the default locale)
+//
+// Returns:
+//   - *mailer.Rendered: Subject, in-app summary, plain text and HTML of the email
+//   - response.HTTPError: 404 unknown template, 400 unsupported locale, HTTP error or EmptyError on success
+func HandlePreviewMailTemplate(name string, locale string) (*mailer.Rendered, response.HTTPError) {
+	rendered, err := s.PreviewMailTemplate(name, locale)
+	if errors.Is(err, s.ErrMailTemplateNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if errors.Is(err, s.ErrUnsupportedLocale) {
+		return nil, response.Error(http.StatusBadRequest, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return rendered, response.EmptyError
+}
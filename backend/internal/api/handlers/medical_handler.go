@@ -0,0 +1,277 @@
+// Package handlers implements HTTP request handlers for the pet medical records API.
+// This layer is responsible for:
+// - Validating medical record data and dates
+// - Calling appropriate service layer functions
+// - Converting service errors to HTTP responses
+package handlers
+
+import (
+	r_models "backend/internal/api/routes/models"
+	m "backend/internal/models"
+	s "backend/internal/services/backend_calls"
+	response "backend/internal/utils/rest"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ========================================
+// MEDICAL RECORD HANDLERS
+// ========================================
+
+// HandleListMedicalRecords processes requests to retrieve the medical history of a pet.
+//
+// Validation:
+// - Ensures pet ID is valid (greater than 0)
+// - Ensures the type filter, if provided, is a valid record type
+//
+// Parameters:
+//   - petID: Pet whose records are retrieved
+//   - orgID: Organisation of the acting staff member
+//   - recordType: Record type to filter by, or "" for every type
+//
+// Returns:
+//   - []m.MedicalRecord: Medical records, most recent first
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleListMedicalRecords(petID uint, orgID uint, recordType string) ([]m.MedicalRecord, response.HTTPError) {
+	// Input validation
+	if petID <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de mascota no válido")
+	}
+
+	if recordType != "" && !s.IsValidMedicalRecordType(recordType) {
+		return nil, response.Error(http.StatusBadRequest, invalidMedicalTypeMessage())
+	}
+
+	records, err := s.ListMedicalRecords(petID, orgID, recordType)
+	if err != nil {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+
+	return records, response.EmptyError
+}
+
+// HandleGetMedicalRecord processes requests to retrieve a medical record.
+//
+// Parameters:
+//   - petID: Pet the record belongs to
+//   - orgID: Organisation of the acting staff member
+//   - id: Medical record ID
+//
+// Returns:
+//   - *m.MedicalRecord: Medical record data
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleGetMedicalRecord(petID uint, orgID uint, id uint) (*m.MedicalRecord, response.HTTPError) {
+	// Input validation
+	if petID <= 0 || id <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de mascota o registro no válido")
+	}
+
+	record, err := s.GetMedicalRecord(petID, orgID, id)
+	if err != nil {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+
+	return record, response.EmptyError
+}
+
+// HandleCreateMedicalRecord processes requests to add a medical record to a pet.
+//
+// Validation:
+// - Ensures the record type is valid
+// - Validates the fields required by the record type (see validateMedicalRecord)
+//
+// Parameters:
+//   - petID: Pet the record belongs to
+//   - orgID: Organisation of the acting staff member
+//   - staffID: Staff user registering the record
+//   - req: MedicalRecordRequest with the record data
+//
+// Returns:
+//   - *m.MedicalRecord: Created medical record
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleCreateMedicalRecord(petID uint, orgID uint, staffID uint, req r_models.MedicalRecordRequest) (*m.MedicalRecord, response.HTTPError) {
+	// Input validation
+	if petID <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de mascota no válido")
+	}
+
+	if !s.IsValidMedicalRecordType(req.Type) {
+		return nil, response.Error(http.StatusBadRequest, invalidMedicalTypeMessage())
+	}
+
+	record, msg := toMedicalRecord(req.Type, req)
+	if msg != "" {
+		return nil, response.Error(http.StatusBadRequest, msg)
+	}
+
+	record.PetID = petID
+	record.CreatedBy = staffID
+
+	if err := s.CreateMedicalRecord(record, orgID); err != nil {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+
+	return record, response.EmptyError
+}
+
+// HandleUpdateMedicalRecord processes requests to replace a medical record.
+// The record type cannot be changed and the type in the request is ignored.
+//
+// Parameters:
+//   - petID: Pet the record belongs to
+//   - orgID: Organisation of the acting staff member
+//   - id: Medical record ID
+//   - req: MedicalRecordRequest with the new data
+//
+// Returns:
+//   - *m.MedicalRecord: Updated medical record
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleUpdateMedicalRecord(petID uint, orgID uint, id uint, req r_models.MedicalRecordRequest) (*m.MedicalRecord, response.HTTPError) {
+	// Input validation
+	if petID <= 0 || id <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de mascota o registro no válido")
+	}
+
+	// Required fields depend on the stored type
+	current, err := s.GetMedicalRecord(petID, orgID, id)
+	if err != nil {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+
+	record, msg := toMedicalRecord(current.Type, req)
+	if msg != "" {
+		return nil, response.Error(http.StatusBadRequest, msg)
+	}
+
+	record.ID = id
+	record.PetID = petID
+
+	updated, err := s.UpdateMedicalRecord(record, orgID)
+	if err != nil {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+
+	return updated, response.EmptyError
+}
+
+// HandleDeleteMedicalRecord processes requests to delete a medical record.
+//
+// Parameters:
+//   - petID: Pet the record belongs to
+//   - orgID: Organisation of the acting staff member
+//   - id: Medical record ID
+//
+// Returns:
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleDeleteMedicalRecord(petID uint, orgID uint, id uint) response.HTTPError {
+	// Input validation
+	if petID <= 0 || id <= 0 {
+		return response.Error(http.StatusBadRequest, "ID de mascota o registro no válido")
+	}
+
+	if err := s.DeleteMedicalRecord(petID, orgID, id); err != nil {
+		return response.Error(http.StatusNotFound, err.Error())
+	}
+
+	return response.EmptyError
+}
+
+// HandleGetMedicalSummary processes requests for the adopter-facing medical summary of a pet.
+//
+// Parameters:
+//   - petID: Pet whose summary is built
+//
+// Returns:
+//   - *m.PetMedicalSummary: Medical summary without internal notes
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleGetMedicalSummary(petID uint) (*m.PetMedicalSummary, response.HTTPError) {
+	// Input validation
+	if petID <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de mascota no válido")
+	}
+
+	summary, err := s.GetMedicalSummary(petID, time.Now())
+	if err != nil {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+
+	return summary, response.EmptyError
+}
+
+// ========================================
+// MEDICAL RECORD HELPERS
+// ========================================
+
+// toMedicalRecord validates a request against the fields required by recordType
+// and converts it into a medical record. It returns an error message, or "" if valid.
+func toMedicalRecord(recordType string, req r_models.MedicalRecordRequest) (*m.MedicalRecord, string) {
+	record := &m.MedicalRecord{
+		Type:     recordType,
+		Name:     strings.TrimSpace(req.Name),
+		WeightKg: req.WeightKg,
+		Vet:      strings.TrimSpace(req.Vet),
+		Notes:    strings.TrimSpace(req.Notes),
+	}
+
+	if utf8.RuneCountInString(record.Name) > 150 || utf8.RuneCountInString(record.Vet) > 150 {
+		return nil, "name y vet no pueden superar 150 caracteres"
+	}
+
+	switch recordType {
+	case m.MedicalVaccination, m.MedicalTreatment, m.MedicalVetVisit, m.MedicalAllergy:
+		if record.Name == "" {
+			return nil, "name es obligatorio para este tipo de registro"
+		}
+	case m.MedicalWeight:
+		if req.WeightKg == nil || *req.WeightKg <= 0 || *req.WeightKg > 1000 {
+			return nil, "weight_kg es obligatorio y debe estar entre 0 y 1000"
+		}
+	}
+
+	date, err := parseDate(req.Date)
+	if err != nil || date == nil {
+		return nil, "date es obligatoria (formato YYYY-MM-DD)"
+	}
+	if date.After(time.Now()) {
+		return nil, "date no puede ser una fecha futura"
+	}
+	record.Date = *date
+
+	if record.DueDate, err = parseDate(req.DueDate); err != nil {
+		return nil, "due_date inválida (formato YYYY-MM-DD)"
+	}
+	if record.DueDate != nil && !record.DueDate.After(record.Date) {
+		return nil, "due_date debe ser posterior a date"
+	}
+
+	if record.EndDate, err = parseDate(req.EndDate); err != nil {
+		return nil, "end_date inválida (formato YYYY-MM-DD)"
+	}
+	if record.EndDate != nil && record.EndDate.Before(record.Date) {
+		return nil, "end_date no puede ser anterior a date"
+	}
+
+	return record, ""
+}
+
+// parseDate parses an optional YYYY-MM-DD date; empty values return nil.
+func parseDate(value string) (*time.Time, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+
+	date, err := time.ParseInLocation(time.DateOnly, strings.TrimSpace(value), time.Local)
+	if err != nil {
+		return nil, err
+	}
+
+	return &date, nil
+}
+
+// invalidMedicalTypeMessage lists the valid medical record types.
+func invalidMedicalTypeMessage() string {
+	return fmt.Sprintf("tipo de registro inválido, debe ser uno de: %s", strings.Join(m.MedicalRecordTypes, ", "))
+}
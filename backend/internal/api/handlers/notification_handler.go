@@ -0,0 +1,174 @@
+// Package handlers implements HTTP request handlers for notification preferences and the notification centre.
+// This layer is responsible for:
+// - Validating preference changes and notification centre queries
+// - Calling appropriate service layer functions on behalf of the current user
+// - Converting service errors to HTTP responses
+package handlers
+
+import (
+	r_models "backend/internal/api/routes/models"
+	"backend/internal/db/query"
+	m "backend/internal/models"
+	s "backend/internal/services/backend_calls"
+	response "backend/internal/utils/rest"
+	"errors"
+	"net/http"
+	"net/url"
+)
+
+// ========================================
+// NOTIFICATION PREFERENCE HANDLERS
+// ========================================
+
+// HandleListNotificationPreferences processes requests to retrieve the notification preferences of the current user.
+//
+// Parameters:
+//   - userID: Authenticated user ID
+//
+// Returns:
+//   - []m.NotificationPreference: One preference per notification type
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleListNotificationPreferences(userID uint) ([]m.NotificationPreference, response.HTTPError) {
+	preferences, err := s.ListNotificationPreferences(userID)
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return preferences, response.EmptyError
+}
+
+// HandleUpdateNotificationPreferences processes requests to change the notification preferences of the current user.
+//
+// Validation:
+// - Requires at least one change, each with a channel
+// - Returns 400 for unknown notification types
+//
+// Parameters:
+//   - userID: Authenticated user ID
+//   - req: Changes of the channels of some notification types
+//
+// Returns:
+//   - []m.NotificationPreference: Preferences for every type after the change
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleUpdateNotificationPreferences(userID uint, req []r_models.NotificationPreferenceRequest) ([]m.NotificationPreference, response.HTTPError) {
+	// Input validation
+	if len(req) == 0 {
+		return nil, response.Error(http.StatusBadRequest, "no se ha indicado ninguna preferencia")
+	}
+
+	changes := make([]s.NotificationPreferenceChange, len(req))
+	for i, change := range req {
+		if change.Email == nil && change.InApp == nil {
+			return nil, response.Error(http.StatusBadRequest, "cada preferencia debe indicar email o in_app")
+		}
+		changes[i] = s.NotificationPreferenceChange{Type: change.Type, Email: change.Email, InApp: change.InApp}
+	}
+
+	preferences, err := s.UpdateNotificationPreferences(userID, changes)
+	if errors.Is(err, s.ErrUnknownNotificationType) {
+		return nil, response.Error(http.StatusBadRequest, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return preferences, response.EmptyError
+}
+
+// ========================================
+// NOTIFICATION CENTRE HANDLERS
+// ========================================
+
+// HandleListNotifications processes requests to retrieve a page of the current user's notification centre.
+//
+// Validation:
+// - Validates pagination, sorting and filter parameters (400 on invalid input)
+//
+// Parameters:
+//   - userID: Authenticated user ID
+//   - path: Request path used to build next/prev links
+//   - values: Raw query parameters
+//
+// Returns:
+//   - *query.Page[m.Notification]: Requested page of notifications
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleListNotifications(userID uint, path string, values url.Values) (*query.Page[m.Notification], response.HTTPError) {
+	params, err := s.NewNotificationListQuery(path, values)
+	if err != nil {
+		return nil, response.Error(http.StatusBadRequest, err.Error())
+	}
+
+	notifications, err := s.ListNotifications(params, userID)
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return notifications, response.EmptyError
+}
+
+// HandleCountUnreadNotifications processes requests to count the unread notifications of the current user.
+//
+// Parameters:
+//   - userID: Authenticated user ID
+//
+// Returns:
+//   - *m.NotificationUnreadCount: Unread notifications, in total and by type
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleCountUnreadNotifications(userID uint) (*m.NotificationUnreadCount, response.HTTPError) {
+	unread, err := s.CountUnreadNotifications(userID)
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return unread, response.EmptyError
+}
+
+// HandleMarkNotificationRead processes requests to mark a notification of the current user as read.
+//
+// Validation:
+// - Ensures the notification ID is valid
+//
+// Parameters:
+//   - userID: Authenticated user ID
+//   - id: Notification ID
+//
+// Returns:
+//   - *m.Notification: Notification after the change
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleMarkNotificationRead(userID uint, id uint) (*m.Notification, response.HTTPError) {
+	// Input validation
+	if id <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de notificación no válido")
+	}
+
+	notification, err := s.MarkNotificationRead(userID, id)
+	if errors.Is(err, s.ErrNotificationNotFound) {
+		return nil, response.Error(http.StatusNotFound, s.ErrNotificationNotFound.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return notification, response.EmptyError
+}
+
+// HandleMarkAllNotificationsRead processes requests to mark every unread notification of the current user as read.
+//
+// Parameters:
+//   - userID: Authenticated user ID
+//   - notificationType: Only mark notifications of this type (optional)
+//
+// Returns:
+//   - *m.NotificationUnreadCount: Unread notifications after the change
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleMarkAllNotificationsRead(userID uint, notificationType string) (*m.NotificationUnreadCount, response.HTTPError) {
+	_, err := s.MarkAllNotificationsRead(userID, notificationType)
+	if errors.Is(err, s.ErrUnknownNotificationType) {
+		return nil, response.Error(http.StatusBadRequest, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return HandleCountUnreadNotifications(userID)
+}
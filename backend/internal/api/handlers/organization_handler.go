@@ -0,0 +1,417 @@
+// Package handlers implements HTTP request handlers for the organisation API.
+// This layer is responsible for:
+// - Validating organisation settings and adoption questionnaires
+// - Validating membership changes
+// - Resolving the organisation staff requests act on
+// - Converting service errors to HTTP responses
+package handlers
+
+import (
+	r_models "backend/internal/api/routes/models"
+	"backend/internal/db/dao"
+	"backend/internal/db/query"
+	m "backend/internal/models"
+	s "backend/internal/services/backend_calls"
+	response "backend/internal/utils/rest"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxQuestionnaireQuestions is the maximum number of questions of an adoption questionnaire.
+const MaxQuestionnaireQuestions = 50
+
+var (
+	// slugPattern matches organisation slugs: lowercase words separated by single dashes.
+	slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+	// questionKeyPattern matches questionnaire keys.
+	questionKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+)
+
+// ========================================
+// ORGANIZATION HANDLERS
+// ========================================
+
+// HandleListOrganizations processes requests to retrieve a page of organisations.
+//
+// Parameters:
+//   - path: Request path used to build next/prev links
+//   - values: Raw query parameters
+//
+// Returns:
+//   - *query.Page[m.Organization]: Requested page of organisations with total count and links
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleListOrganizations(path string, values url.Values) (*query.Page[m.Organization], response.HTTPError) {
+	params, err := s.NewOrganizationListQuery(path, values)
+	if err != nil {
+		return nil, response.Error(http.StatusBadRequest, err.Error())
+	}
+
+	organizations, err := s.ListOrganizations(params)
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return organizations, response.EmptyError
+}
+
+// HandleGetOrganization processes requests to retrieve an organisation with its settings.
+//
+// Parameters:
+//   - id: Organisation ID
+//
+// Returns:
+//   - *m.Organization: Organisation data, including its adoption questionnaire
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleGetOrganization(id uint) (*m.Organization, response.HTTPError) {
+	// Input validation
+	if id <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de organización no válido")
+	}
+
+	organization, err := s.GetOrganization(id)
+	if err != nil {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+
+	return organization, response.EmptyError
+}
+
+// HandleCreateOrganization processes requests to create an organisation.
+//
+// Validation:
+// - Validates the organisation data (see toOrganization)
+// - Rejects slugs already in use (409)
+//
+// Parameters:
+//   - req: OrganizationRequest with the organisation data
+//
+// Returns:
+//   - *m.Organization: Created organisation
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleCreateOrganization(req r_models.OrganizationRequest) (*m.Organization, response.HTTPError) {
+	// Input validation
+	organization, msg := toOrganization(req)
+	if msg != "" {
+		return nil, response.Error(http.StatusBadRequest, msg)
+	}
+
+	err := s.CreateOrganization(organization)
+	if errors.Is(err, s.ErrSlugInUse) {
+		return nil, response.Error(http.StatusConflict, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return organization, response.EmptyError
+}
+
+// HandleUpdateOrganization processes requests to replace the profile and settings of an organisation.
+//
+// Validation:
+// - Validates the organisation data (see toOrganization)
+// - Rejects slugs already in use by another organisation (409)
+//
+// Parameters:
+//   - orgID: Organisation of the acting manager
+//   - req: OrganizationRequest with the new data
+//
+// Returns:
+//   - *m.Organization: Updated organisation
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleUpdateOrganization(orgID uint, req r_models.OrganizationRequest) (*m.Organization, response.HTTPError) {
+	// Input validation
+	organization, msg := toOrganization(req)
+	if msg != "" {
+		return nil, response.Error(http.StatusBadRequest, msg)
+	}
+	organization.ID = orgID
+
+	updated, err := s.UpdateOrganization(organization)
+	if errors.Is(err, s.ErrSlugInUse) {
+		return nil, response.Error(http.StatusConflict, err.Error())
+	}
+	if errors.Is(err, s.ErrOrganizationNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return updated, response.EmptyError
+}
+
+// ========================================
+// ORGANIZATION MEMBERSHIP HANDLERS
+// ========================================
+
+// HandleResolveOrganization resolves the organisation a staff request acts on.
+//
+// Parameters:
+//   - user: Authenticated staff user
+//   - header: Value of the X-Organization-ID header, or "" when not sent
+//
+// Returns:
+//   - *m.OrganizationMember: Membership the request acts with (managers for admins)
+//   - response.HTTPError: 400 invalid or missing organisation, 403 not a member, 404 unknown organisation
+func HandleResolveOrganization(user *m.NonValidatedUser, header string) (*m.OrganizationMember, response.HTTPError) {
+	if user == nil {
+		return nil, response.Error(http.StatusUnauthorized, "sesión no válida")
+	}
+
+	orgID := dao.AllOrganizations
+	if header = strings.TrimSpace(header); header != "" {
+		id, err := strconv.ParseUint(header, 10, 64)
+		if err != nil || id == 0 {
+			return nil, response.Error(http.StatusBadRequest, "cabecera X-Organization-ID no válida")
+		}
+		orgID = uint(id)
+	}
+
+	membership, err := s.ResolveMembership(user, orgID)
+	if errors.Is(err, s.ErrOrganizationRequired) {
+		return nil, response.Error(http.StatusBadRequest, err.Error())
+	}
+	if errors.Is(err, s.ErrNotOrganizationMember) {
+		return nil, response.Error(http.StatusForbidden, err.Error())
+	}
+	if errors.Is(err, s.ErrOrganizationNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return membership, response.EmptyError
+}
+
+// HandleListUserMemberships processes requests to list the organisations of the current user.
+//
+// Parameters:
+//   - userID: Authenticated user ID
+//
+// Returns:
+//   - []m.OrganizationMember: Memberships with the organisation ID and role
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleListUserMemberships(userID uint) ([]m.OrganizationMember, response.HTTPError) {
+	members, err := s.ListUserMemberships(userID)
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return members, response.EmptyError
+}
+
+// HandleListOrganizationMembers processes requests to list the members of an organisation.
+//
+// Parameters:
+//   - orgID: Organisation of the acting manager
+//
+// Returns:
+//   - []m.OrganizationMember: Members with their user summary
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleListOrganizationMembers(orgID uint) ([]m.OrganizationMember, response.HTTPError) {
+	members, err := s.ListOrganizationMembers(orgID)
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return members, response.EmptyError
+}
+
+// HandleSaveOrganizationMember processes requests to add a member or change their role.
+//
+// Validation:
+// - Ensures the role is staff or manager
+// - Returns 404 for unknown users and 400 for users without a staff role
+//
+// Parameters:
+//   - orgID: Organisation of the acting manager
+//   - userID: User to add or update
+//   - req: OrganizationMemberRequest with the role
+//
+// Returns:
+//   - *m.OrganizationMember: Saved membership
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleSaveOrganizationMember(orgID uint, userID uint, req r_models.OrganizationMemberRequest) (*m.OrganizationMember, response.HTTPError) {
+	// Input validation
+	if userID <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de usuario no válido")
+	}
+
+	if !slices.Contains(m.OrgRoles, req.Role) {
+		return nil, response.Error(http.StatusBadRequest, fmt.Sprintf("rol inválido, debe ser uno de: %s", strings.Join(m.OrgRoles, ", ")))
+	}
+
+	member, err := s.SaveOrganizationMember(orgID, userID, req.Role)
+	if errors.Is(err, s.ErrMemberNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if errors.Is(err, s.ErrMemberNotStaff) {
+		return nil, response.Error(http.StatusBadRequest, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return member, response.EmptyError
+}
+
+// HandleRemoveOrganizationMember processes requests to remove a member from an organisation.
+//
+// Parameters:
+//   - orgID: Organisation of the acting manager
+//   - userID: User to remove
+//
+// Returns:
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleRemoveOrganizationMember(orgID uint, userID uint) response.HTTPError {
+	// Input validation
+	if userID <= 0 {
+		return response.Error(http.StatusBadRequest, "ID de usuario no válido")
+	}
+
+	if err := s.RemoveOrganizationMember(orgID, userID); err != nil {
+		return response.Error(http.StatusNotFound, err.Error())
+	}
+
+	return response.EmptyError
+}
+
+// ========================================
+// ORGANIZATION HELPERS
+// ========================================
+
+// toOrganization validates an organisation request and converts it into an organisation.
+// It returns an error message, or "" if valid.
+func toOrganization(req r_models.OrganizationRequest) (*m.Organization, string) {
+	organization := &m.Organization{
+		Name:                  strings.TrimSpace(req.Name),
+		Slug:                  strings.TrimSpace(req.Slug),
+		Email:                 strings.TrimSpace(req.Email),
+		Phone:                 strings.TrimSpace(req.Phone),
+		Address:               strings.TrimSpace(req.Address),
+		Website:               strings.TrimSpace(req.Website),
+		SenderName:            strings.TrimSpace(req.SenderName),
+		SenderEmail:           strings.TrimSpace(req.SenderEmail),
+		ReplyTo:               strings.TrimSpace(req.ReplyTo),
+		AdoptionQuestionnaire: []m.QuestionnaireQuestion{},
+	}
+
+	if organization.Name == "" || utf8.RuneCountInString(organization.Name) > 150 {
+		return nil, "name es obligatorio y no puede superar 150 caracteres"
+	}
+
+	if len(organization.Slug) > 60 || !slugPattern.MatchString(organization.Slug) {
+		return nil, "slug es obligatorio y solo admite minúsculas, dígitos y guiones (máximo 60 caracteres)"
+	}
+
+	if utf8.RuneCountInString(organization.Phone) > 30 || utf8.RuneCountInString(organization.SenderName) > 100 {
+		return nil, "phone no puede superar 30 caracteres y sender_name 100"
+	}
+
+	if utf8.RuneCountInString(organization.Address) > 255 || len(organization.Website) > 255 {
+		return nil, "address y website no pueden superar 255 caracteres"
+	}
+
+	if organization.Website != "" {
+		website, err := url.Parse(organization.Website)
+		if err != nil || (website.Scheme != "http" && website.Scheme != "https") || website.Host == "" {
+			return nil, "website debe ser una URL http o https"
+		}
+	}
+
+	emails := []struct{ field, value string }{
+		{"email", organization.Email},
+		{"sender_email", organization.SenderEmail},
+		{"reply_to", organization.ReplyTo},
+	}
+	for _, email := range emails {
+		if email.value != "" && !isValidEmail(email.value) {
+			return nil, fmt.Sprintf("%s debe ser un email válido", email.field)
+		}
+	}
+
+	if len(req.AdoptionQuestionnaire) > MaxQuestionnaireQuestions {
+		return nil, fmt.Sprintf("el cuestionario admite como máximo %d preguntas", MaxQuestionnaireQuestions)
+	}
+
+	keys := make(map[string]bool, len(req.AdoptionQuestionnaire))
+	for i, q := range req.AdoptionQuestionnaire {
+		question, msg := toQuestionnaireQuestion(q)
+		if msg != "" {
+			return nil, fmt.Sprintf("pregunta %d: %s", i+1, msg)
+		}
+
+		if keys[question.Key] {
+			return nil, fmt.Sprintf("pregunta %d: la clave %q está repetida", i+1, question.Key)
+		}
+		keys[question.Key] = true
+
+		organization.AdoptionQuestionnaire = append(organization.AdoptionQuestionnaire, question)
+	}
+
+	return organization, ""
+}
+
+// toQuestionnaireQuestion validates a questionnaire question.
+// It returns an error message, or "" if valid.
+func toQuestionnaireQuestion(req r_models.QuestionRequest) (m.QuestionnaireQuestion, string) {
+	question := m.QuestionnaireQuestion{
+		Key:      strings.TrimSpace(req.Key),
+		Label:    strings.TrimSpace(req.Label),
+		Type:     strings.TrimSpace(req.Type),
+		Required: req.Required,
+	}
+
+	if len(question.Key) > 50 || !questionKeyPattern.MatchString(question.Key) {
+		return question, "key es obligatoria y solo admite minúsculas, dígitos y guiones bajos, empezando por una letra (máximo 50 caracteres)"
+	}
+
+	if question.Label == "" || utf8.RuneCountInString(question.Label) > 255 {
+		return question, "label es obligatoria y no puede superar 255 caracteres"
+	}
+
+	if !slices.Contains(m.QuestionTypes, question.Type) {
+		return question, fmt.Sprintf("tipo inválido, debe ser uno de: %s", strings.Join(m.QuestionTypes, ", "))
+	}
+
+	if question.Type != m.QuestionChoice {
+		if len(req.Options) > 0 {
+			return question, "options solo se admite en preguntas de tipo choice"
+		}
+		return question, ""
+	}
+
+	if len(req.Options) < 2 || len(req.Options) > 20 {
+		return question, "las preguntas de tipo choice necesitan entre 2 y 20 opciones"
+	}
+
+	for _, option := range req.Options {
+		option = strings.TrimSpace(option)
+		if option == "" || utf8.RuneCountInString(option) > 100 {
+			return question, "las opciones no pueden estar vacías ni superar 100 caracteres"
+		}
+		if slices.Contains(question.Options, option) {
+			return question, fmt.Sprintf("la opción %q está repetida", option)
+		}
+		question.Options = append(question.Options, option)
+	}
+
+	return question, ""
+}
+
+// isValidEmail reports whether value is a bare email address of at most 255 bytes.
+func isValidEmail(value string) bool {
+	address, err := mail.ParseAddress(value)
+	return err == nil && address.Address == value && len(value) <= 255
+}
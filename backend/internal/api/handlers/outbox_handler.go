@@ -0,0 +1,102 @@
+// Package handlers implements HTTP request handlers for the email outbox admin API.
+// This layer is responsible for:
+// - Validating list queries and email IDs
+// - Calling appropriate service layer functions
+// - Converting service errors to HTTP responses
+package handlers
+
+import (
+	"backend/internal/db/query"
+	m "backend/internal/models"
+	s "backend/internal/services/backend_calls"
+	response "backend/internal/utils/rest"
+	"errors"
+	"net/http"
+	"net/url"
+)
+
+// ========================================
+// OUTBOX HANDLERS
+// ========================================
+
+// HandleListOutboxEmails processes admin requests to retrieve a page of the email outbox.
+//
+// Validation:
+// - Validates pagination, sorting and filter parameters (400 on invalid input)
+//
+// Parameters:
+//   - path: Request path used to build next/prev links
+//   - values: Raw query parameters
+//
+// Returns:
+//   - *query.Page[m.OutboxEmail]: Requested page of emails
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleListOutboxEmails(path string, values url.Values) (*query.Page[m.OutboxEmail], response.HTTPError) {
+	params, err := s.NewOutboxListQuery(path, values)
+	if err != nil {
+		return nil, response.Error(http.StatusBadRequest, err.Error())
+	}
+
+	emails, err := s.ListOutboxEmails(params)
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return emails, response.EmptyError
+}
+
+// HandleGetOutboxEmail processes admin requests to retrieve an email of the outbox.
+//
+// Parameters:
+//   - id: Email ID
+//
+// Returns:
+//   - *m.OutboxEmail: Email with its delivery state
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleGetOutboxEmail(id uint) (*m.OutboxEmail, response.HTTPError) {
+	// Input validation
+	if id <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de email no válido")
+	}
+
+	email, err := s.GetOutboxEmail(id)
+	if errors.Is(err, s.ErrOutboxEmailNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return email, response.EmptyError
+}
+
+// HandleResendOutboxEmail processes admin requests to resend a failed email.
+//
+// Parameters:
+//   - id: Email ID
+//
+// Returns:
+//   - *m.OutboxEmail: Email with the outcome of the new attempt
+//   - response.HTTPError: 404 unknown email, 409 email not failed, 410 message cleared, HTTP error or EmptyError on success
+func HandleResendOutboxEmail(id uint) (*m.OutboxEmail, response.HTTPError) {
+	// Input validation
+	if id <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de email no válido")
+	}
+
+	email, err := s.ResendOutboxEmail(id)
+	if errors.Is(err, s.ErrOutboxEmailNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if errors.Is(err, s.ErrOutboxEmailNotFailed) {
+		return nil, response.Error(http.StatusConflict, err.Error())
+	}
+	if errors.Is(err, s.ErrOutboxEmailCleared) {
+		return nil, response.Error(http.StatusGone, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return email, response.EmptyError
+}
@@ -0,0 +1,442 @@
+// Package handlers implements HTTP request handlers for the adoption fee and payment API.
+// This layer is responsible for:
+// - Validating fee schedules and refunds
+// - Calling appropriate service layer functions
+// - Converting service errors to HTTP responses
+package handlers
+
+import (
+	r_models "backend/internal/api/routes/models"
+	"backend/internal/db/query"
+	m "backend/internal/models"
+	s "backend/internal/services/backend_calls"
+	"backend/internal/services/payments"
+	response "backend/internal/utils/rest"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+)
+
+// ========================================
+// FEE SCHEDULE HANDLERS
+// ========================================
+
+// HandleListFeeSchedules processes staff requests to retrieve the organisation's fee schedules.
+//
+// Parameters:
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - []m.FeeSchedule: Fee schedules of the organisation
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleListFeeSchedules(orgID uint) ([]m.FeeSchedule, response.HTTPError) {
+	schedules, err := s.ListFeeSchedules(orgID)
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return schedules, response.EmptyError
+}
+
+// HandleCreateFeeSchedule processes staff requests to add a fee schedule.
+//
+// Parameters:
+//   - orgID: Organisation of the acting staff member
+//   - req: FeeScheduleRequest with the species, age range and amount
+//
+// Returns:
+//   - *m.FeeSchedule: Created schedule
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleCreateFeeSchedule(orgID uint, req r_models.FeeScheduleRequest) (*m.FeeSchedule, response.HTTPError) {
+	// Input validation
+	schedule, msg := validateFeeSchedule(req)
+	if msg != "" {
+		return nil, response.Error(http.StatusBadRequest, msg)
+	}
+	schedule.OrganizationID = orgID
+
+	created, err := s.CreateFeeSchedule(schedule)
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return created, response.EmptyError
+}
+
+// HandleUpdateFeeSchedule processes staff requests to change a fee schedule.
+//
+// Parameters:
+//   - id: Fee schedule ID
+//   - orgID: Organisation of the acting staff member
+//   - req: FeeScheduleRequest with the new species, age range and amount
+//
+// Returns:
+//   - *m.FeeSchedule: Updated schedule
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleUpdateFeeSchedule(id uint, orgID uint, req r_models.FeeScheduleRequest) (*m.FeeSchedule, response.HTTPError) {
+	// Input validation
+	if id <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de tarifa no válido")
+	}
+
+	schedule, msg := validateFeeSchedule(req)
+	if msg != "" {
+		return nil, response.Error(http.StatusBadRequest, msg)
+	}
+	schedule.ID = id
+	schedule.OrganizationID = orgID
+
+	updated, err := s.UpdateFeeSchedule(schedule)
+	if errors.Is(err, s.ErrFeeScheduleNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return updated, response.EmptyError
+}
+
+// HandleDeleteFeeSchedule processes staff requests to remove a fee schedule.
+//
+// Parameters:
+//   - id: Fee schedule ID
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleDeleteFeeSchedule(id uint, orgID uint) response.HTTPError {
+	// Input validation
+	if id <= 0 {
+		return response.Error(http.StatusBadRequest, "ID de tarifa no válido")
+	}
+
+	err := s.DeleteFeeSchedule(id, orgID)
+	if errors.Is(err, s.ErrFeeScheduleNotFound) {
+		return response.Error(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return response.EmptyError
+}
+
+// HandleQuoteAdoptionFee processes staff requests to compute the adoption fee of a pet.
+//
+// Parameters:
+//   - petID: Pet ID
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - *m.FeeQuote: Fee of the pet and the schedule it comes from
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleQuoteAdoptionFee(petID uint, orgID uint) (*m.FeeQuote, response.HTTPError) {
+	// Input validation
+	if petID <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de mascota no válido")
+	}
+
+	quote, err := s.QuoteAdoptionFee(petID, orgID)
+	if errors.Is(err, s.ErrAdoptionPetNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return quote, response.EmptyError
+}
+
+// ========================================
+// PAYMENT HANDLERS
+// ========================================
+
+// HandleStartAdoptionCheckout processes requests to pay the outstanding fee of an adoption.
+//
+// Parameters:
+//   - adoptionID: Adoption ID
+//   - viewer: Current user (the adopter or staff of the organisation)
+//
+// Returns:
+//   - *m.Payment: Pending payment with the checkout URL to redirect the payer to
+//   - response.HTTPError: HTTP error or EmptyError on success (409 when nothing is left to pay)
+func HandleStartAdoptionCheckout(adoptionID uint, viewer *m.NonValidatedUser) (*m.Payment, response.HTTPError) {
+	// Input validation
+	if adoptionID <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de adopción no válido")
+	}
+
+	payment, err := s.StartAdoptionCheckout(adoptionID, viewer)
+	if errors.Is(err, s.ErrAdoptionNotFound) || errors.Is(err, s.ErrAdoptionPetNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if errors.Is(err, s.ErrAdoptionFeePaid) {
+		return nil, response.Error(http.StatusConflict, err.Error())
+	}
+	if errors.Is(err, s.ErrPaymentsDisabled) {
+		return nil, response.Error(http.StatusServiceUnavailable, err.Error())
+	}
+	if errors.Is(err, s.ErrPaymentProvider) {
+		return nil, response.Error(http.StatusBadGateway, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return payment, response.EmptyError
+}
+
+// HandleListAdoptionPayments processes requests to retrieve the payments of an adoption.
+//
+// Parameters:
+//   - adoptionID: Adoption ID
+//   - viewer: Current user (the adopter or staff of the organisation)
+//
+// Returns:
+//   - []m.Payment: Payments of the adoption with their refunds
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleListAdoptionPayments(adoptionID uint, viewer *m.NonValidatedUser) ([]m.Payment, response.HTTPError) {
+	// Input validation
+	if adoptionID <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de adopción no válido")
+	}
+
+	payments, err := s.ListAdoptionPayments(adoptionID, viewer)
+	if errors.Is(err, s.ErrAdoptionNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return payments, response.EmptyError
+}
+
+// HandleListPayments processes staff requests to retrieve a page of payments.
+//
+// Validation:
+// - Validates pagination, sorting and filter parameters (400 on invalid input)
+//
+// Parameters:
+//   - path: Request path used to build next/prev links
+//   - values: Raw query parameters
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - *query.Page[m.Payment]: Requested page of payments
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleListPayments(path string, values url.Values, orgID uint) (*query.Page[m.Payment], response.HTTPError) {
+	params, err := s.NewPaymentListQuery(path, values)
+	if err != nil {
+		return nil, response.Error(http.StatusBadRequest, err.Error())
+	}
+
+	payments, err := s.ListPayments(params, orgID)
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return payments, response.EmptyError
+}
+
+// HandleGetPayment processes staff requests to retrieve a payment.
+//
+// Parameters:
+//   - id: Payment ID
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - *m.Payment: Payment with its refunds
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleGetPayment(id uint, orgID uint) (*m.Payment, response.HTTPError) {
+	// Input validation
+	if id <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de pago no válido")
+	}
+
+	payment, err := s.GetPayment(id, orgID)
+	if err != nil {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+
+	return payment, response.EmptyError
+}
+
+// HandleRefundPayment processes staff requests to refund a payment.
+//
+// Parameters:
+//   - id: Payment ID
+//   - orgID: Organisation of the acting staff member
+//   - staffID: Authenticated staff user ID
+//   - req: RefundRequest with the amount and reason
+//
+// Returns:
+//   - *m.Payment: Payment with its refunds
+//   - response.HTTPError: HTTP error or EmptyError on success (409 when the payment cannot be refunded)
+func HandleRefundPayment(id uint, orgID uint, staffID uint, req r_models.RefundRequest) (*m.Payment, response.HTTPError) {
+	// Input validation
+	if id <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de pago no válido")
+	}
+
+	var amount int64
+	if req.AmountCents != nil {
+		amount = *req.AmountCents
+		if amount <= 0 {
+			return nil, response.Error(http.StatusBadRequest, "amount_cents debe ser positivo")
+		}
+	}
+
+	reason := strings.TrimSpace(req.Reason)
+	if utf8.RuneCountInString(reason) > 500 {
+		return nil, response.Error(http.StatusBadRequest, "reason no puede superar 500 caracteres")
+	}
+
+	payment, err := s.RefundPayment(id, orgID, amount, reason, staffID)
+	if errors.Is(err, s.ErrPaymentNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if errors.Is(err, s.ErrPaymentNotRefundable) {
+		return nil, response.Error(http.StatusConflict, err.Error())
+	}
+	if errors.Is(err, s.ErrPaymentsDisabled) {
+		return nil, response.Error(http.StatusServiceUnavailable, err.Error())
+	}
+	if errors.Is(err, s.ErrPaymentProvider) {
+		return nil, response.Error(http.StatusBadGateway, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return payment, response.EmptyError
+}
+
+// ========================================
+// PAYMENT PROVIDER HANDLERS
+// ========================================
+
+// HandlePaymentWebhook processes webhook callbacks sent by a payment provider.
+//
+// Parameters:
+//   - provider: Provider name from the webhook URL
+//   - header: Request headers (carrying the signature)
+//   - body: Raw request body
+//
+// Returns:
+//   - response.HTTPError: HTTP error or EmptyError on success (also for duplicated events)
+func HandlePaymentWebhook(provider string, header http.Header, body []byte) response.HTTPError {
+	err := s.ProcessPaymentWebhook(provider, header, body)
+	if errors.Is(err, s.ErrPaymentProviderNotFound) {
+		return response.Error(http.StatusNotFound, err.Error())
+	}
+	if errors.Is(err, payments.ErrInvalidSignature) {
+		return response.Error(http.StatusUnauthorized, err.Error())
+	}
+	if errors.Is(err, payments.ErrInvalidEvent) {
+		return response.Error(http.StatusBadRequest, err.Error())
+	}
+	if err != nil {
+		// The provider retries failed deliveries
+		return response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return response.EmptyError
+}
+
+// FakePaymentsEnabled reports whether the fake payment provider is in use, so its checkout routes are registered.
+func FakePaymentsEnabled() bool {
+	return s.FakePaymentsEnabled()
+}
+
+// HandleFakeCheckoutPage processes requests for the checkout page of the fake payment provider.
+//
+// Parameters:
+//   - checkoutID: Checkout identifier
+//
+// Returns:
+//   - []byte: HTML page
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleFakeCheckoutPage(checkoutID string) ([]byte, response.HTTPError) {
+	page, err := s.FakeCheckoutPage(checkoutID)
+	if errors.Is(err, s.ErrPaymentProviderNotFound) || errors.Is(err, payments.ErrCheckoutNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return page, response.EmptyError
+}
+
+// HandleCompleteFakeCheckout processes the outcome chosen on the fake checkout page.
+//
+// Parameters:
+//   - checkoutID: Checkout identifier
+//   - outcome: "paid", "failed" or "expired"
+//
+// Returns:
+//   - string: URL to redirect the payer to
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleCompleteFakeCheckout(checkoutID string, outcome string) (string, response.HTTPError) {
+	// Input validation
+	switch outcome {
+	case payments.EventPaid, payments.EventFailed, payments.EventExpired:
+	default:
+		return "", response.Error(http.StatusBadRequest, "outcome debe ser paid, failed o expired")
+	}
+
+	returnURL, err := s.CompleteFakeCheckout(checkoutID, outcome)
+	if errors.Is(err, s.ErrPaymentProviderNotFound) || errors.Is(err, payments.ErrCheckoutNotFound) {
+		return "", response.Error(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return "", response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return returnURL, response.EmptyError
+}
+
+// validateFeeSchedule checks a fee schedule request and returns the schedule or an error message.
+func validateFeeSchedule(req r_models.FeeScheduleRequest) (*m.FeeSchedule, string) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" || utf8.RuneCountInString(name) > 100 {
+		return nil, "name es obligatorio y no puede superar 100 caracteres"
+	}
+
+	species := strings.TrimSpace(req.Species)
+	if utf8.RuneCountInString(species) > 100 {
+		return nil, "species no puede superar 100 caracteres"
+	}
+
+	if req.MinAgeMonths < 0 {
+		return nil, "min_age_months no puede ser negativo"
+	}
+	if req.MaxAgeMonths != nil && *req.MaxAgeMonths <= req.MinAgeMonths {
+		return nil, "max_age_months debe ser mayor que min_age_months"
+	}
+
+	if req.AmountCents < 0 {
+		return nil, "amount_cents no puede ser negativo"
+	}
+
+	currency := strings.ToUpper(strings.TrimSpace(req.Currency))
+	if currency == "" {
+		currency = "EUR"
+	}
+	if !currencyPattern.MatchString(currency) {
+		return nil, "currency debe ser un código ISO 4217 de 3 letras"
+	}
+
+	return &m.FeeSchedule{
+		Name:         name,
+		Species:      species,
+		MinAgeMonths: req.MinAgeMonths,
+		MaxAgeMonths: req.MaxAgeMonths,
+		AmountCents:  req.AmountCents,
+		Currency:     currency,
+	}, ""
+}
@@ -0,0 +1,93 @@
+// Package handlers implements HTTP request handlers for the pet favourites API.
+// This layer is responsible for:
+// - Validating pet IDs and list parameters
+// - Calling appropriate service layer functions on behalf of the current user
+// - Converting service errors to HTTP responses
+package handlers
+
+import (
+	"backend/internal/db/query"
+	m "backend/internal/models"
+	s "backend/internal/services/backend_calls"
+	response "backend/internal/utils/rest"
+	"net/http"
+	"net/url"
+)
+
+// ========================================
+// PET FAVORITE HANDLERS
+// ========================================
+
+// HandleAddFavorite processes requests to favourite a pet.
+//
+// Validation:
+// - Ensures pet ID is valid (greater than 0)
+// - Returns 404 when the pet does not exist
+//
+// Parameters:
+//   - userID: Authenticated user ID
+//   - petID: Pet to favourite
+//
+// Returns:
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleAddFavorite(userID uint, petID uint) response.HTTPError {
+	// Input validation
+	if petID <= 0 {
+		return response.Error(http.StatusBadRequest, "ID de mascota no válido")
+	}
+
+	if err := s.AddFavorite(userID, petID); err != nil {
+		return response.Error(http.StatusNotFound, err.Error())
+	}
+
+	return response.EmptyError
+}
+
+// HandleRemoveFavorite processes requests to remove a pet from the user's favourites.
+//
+// Parameters:
+//   - userID: Authenticated user ID
+//   - petID: Pet to remove
+//
+// Returns:
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleRemoveFavorite(userID uint, petID uint) response.HTTPError {
+	// Input validation
+	if petID <= 0 {
+		return response.Error(http.StatusBadRequest, "ID de mascota no válido")
+	}
+
+	if err := s.RemoveFavorite(userID, petID); err != nil {
+		return response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return response.EmptyError
+}
+
+// HandleListFavorites processes requests to retrieve a page of the user's favourite pets.
+//
+// Validation:
+// - Validates pagination, sorting and filter parameters like pet lists (400 on invalid input)
+//
+// Parameters:
+//   - user: Authenticated user
+//   - path: Request path used to build next/prev links
+//   - values: Raw query parameters
+//
+// Returns:
+//   - *query.Page[m.SimplifiedPet]: Requested page of favourite pets
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleListFavorites(user *m.NonValidatedUser, path string, values url.Values) (*query.Page[m.SimplifiedPet], response.HTTPError) {
+	// Input validation
+	params, err := s.NewPetListQuery(path, values)
+	if err != nil {
+		return nil, response.Error(http.StatusBadRequest, err.Error())
+	}
+
+	pets, err := s.ListFavorites(user, params)
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return pets, response.EmptyError
+}
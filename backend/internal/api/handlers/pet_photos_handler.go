@@ -0,0 +1,225 @@
+// Package handlers implements HTTP request handlers for the pet photo API.
+// This layer is responsible for:
+// - Validating uploaded files (size limit and sniffed content type)
+// - Calling appropriate service layer functions
+// - Converting service errors to HTTP responses
+package handlers
+
+import (
+	r_models "backend/internal/api/routes/models"
+	m "backend/internal/models"
+	s "backend/internal/services/backend_calls"
+	"backend/internal/services/imaging"
+	"backend/internal/utils/env"
+	response "backend/internal/utils/rest"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+)
+
+// MaxPhotoBytes is the maximum accepted size of an uploaded photo in bytes.
+// Configurable through the PHOTO_MAX_BYTES environment variable (default 10 MiB).
+var MaxPhotoBytes = env.GetInt("PHOTO_MAX_BYTES", 10<<20)
+
+// ========================================
+// PET PHOTO HANDLERS
+// ========================================
+
+// HandleListPetPhotos processes requests to retrieve the photos of a pet.
+//
+// Parameters:
+//   - petID: Pet ID whose photos are listed
+//
+// Returns:
+//   - []m.PetPhoto: Photos ordered by position with download URLs
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleListPetPhotos(petID uint) ([]m.PetPhoto, response.HTTPError) {
+	// Input validation
+	if petID <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de mascota no válido")
+	}
+
+	photos, err := s.ListPetPhotos(petID)
+	if err != nil {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+
+	return photos, response.EmptyError
+}
+
+// HandleUploadPetPhoto processes photo upload requests.
+//
+// Validation:
+// - Ensures the file does not exceed MaxPhotoBytes (413 otherwise)
+// - Sniffs the real content type from the file bytes, ignoring the client header (415 if unsupported)
+// - Rejects files that cannot be decoded as images (400)
+//
+// Parameters:
+//   - petID: Pet ID the photo belongs to
+//   - orgID: Organisation of the acting staff member
+//   - file: Uploaded multipart file
+//
+// Returns:
+//   - *m.PetPhoto: Created photo with download URLs
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleUploadPetPhoto(petID uint, orgID uint, file *multipart.FileHeader) (*m.PetPhoto, response.HTTPError) {
+	// Input validation
+	if petID <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de mascota no válido")
+	}
+
+	data, httpErr := readUploadedPhoto(file)
+	if httpErr.Code != 0 {
+		return nil, httpErr
+	}
+
+	// Delegate processing and storage to service layer
+	photo, err := s.UploadPetPhoto(petID, orgID, data)
+	if errors.Is(err, imaging.ErrInvalidImage) {
+		return nil, response.Error(http.StatusBadRequest, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return photo, response.EmptyError
+}
+
+// HandleReorderPetPhotos processes requests to change the display order of a pet's photos.
+//
+// Validation:
+// - Ensures at least one photo ID is provided
+// - Delegates completeness checks to the service layer
+//
+// Parameters:
+//   - petID: Pet ID whose photos are reordered
+//   - orgID: Organisation of the acting staff member
+//   - req: ReorderPhotosRequest with the new order
+//
+// Returns:
+//   - []m.PetPhoto: Photos in their new order
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleReorderPetPhotos(petID uint, orgID uint, req r_models.ReorderPhotosRequest) ([]m.PetPhoto, response.HTTPError) {
+	// Input validation
+	if petID <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de mascota no válido")
+	}
+
+	if len(req.PhotoIDs) == 0 {
+		return nil, response.Error(http.StatusBadRequest, "photo_ids es obligatorio")
+	}
+
+	photos, err := s.ReorderPetPhotos(petID, orgID, req.PhotoIDs)
+	if err != nil {
+		return nil, response.Error(http.StatusBadRequest, err.Error())
+	}
+
+	return photos, response.EmptyError
+}
+
+// HandleSetPrimaryPetPhoto processes requests to mark a photo as the pet's main photo.
+//
+// Parameters:
+//   - petID: Pet ID the photo belongs to
+//   - orgID: Organisation of the acting staff member
+//   - photoID: Photo ID to promote
+//
+// Returns:
+//   - []m.PetPhoto: Photos of the pet after the change
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleSetPrimaryPetPhoto(petID uint, orgID uint, photoID uint) ([]m.PetPhoto, response.HTTPError) {
+	// Input validation
+	if petID <= 0 || photoID <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de mascota o foto no válido")
+	}
+
+	photos, err := s.SetPrimaryPetPhoto(petID, orgID, photoID)
+	if err != nil {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+
+	return photos, response.EmptyError
+}
+
+// HandleDeletePetPhoto processes photo deletion requests.
+//
+// Parameters:
+//   - petID: Pet ID the photo belongs to
+//   - orgID: Organisation of the acting staff member
+//   - photoID: Photo ID to delete
+//
+// Returns:
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleDeletePetPhoto(petID uint, orgID uint, photoID uint) response.HTTPError {
+	// Input validation
+	if petID <= 0 || photoID <= 0 {
+		return response.Error(http.StatusBadRequest, "ID de mascota o foto no válido")
+	}
+
+	if err := s.DeletePetPhoto(petID, orgID, photoID); err != nil {
+		return response.Error(http.StatusNotFound, err.Error())
+	}
+
+	return response.EmptyError
+}
+
+// HandleGetPetPhotoContent processes requests to download a photo variant.
+//
+// Parameters:
+//   - petID: Pet ID the photo belongs to
+//   - photoID: Photo ID to download
+//   - variant: "original" or a thumbnail size name
+//
+// Returns:
+//   - io.ReadCloser: Image content (caller must close it)
+//   - string: MIME type of the content
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleGetPetPhotoContent(petID uint, photoID uint, variant string) (io.ReadCloser, string, response.HTTPError) {
+	// Input validation
+	if petID <= 0 || photoID <= 0 {
+		return nil, "", response.Error(http.StatusBadRequest, "ID de mascota o foto no válido")
+	}
+
+	content, contentType, err := s.OpenPetPhoto(petID, photoID, variant)
+	if err != nil {
+		return nil, "", response.Error(http.StatusNotFound, err.Error())
+	}
+
+	return content, contentType, response.EmptyError
+}
+
+// ========================================
+// PET PHOTO HELPERS
+// ========================================
+
+// readUploadedPhoto reads an uploaded photo, enforcing MaxPhotoBytes (413) and
+// sniffing the real content type from the file bytes (415 if unsupported).
+func readUploadedPhoto(file *multipart.FileHeader) ([]byte, response.HTTPError) {
+	if file.Size > MaxPhotoBytes {
+		return nil, response.Error(http.StatusRequestEntityTooLarge, fmt.Sprintf("la foto supera el tamaño máximo de %d bytes", MaxPhotoBytes))
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return nil, response.Error(http.StatusBadRequest, "no se pudo leer la foto")
+	}
+	defer src.Close()
+
+	// Read one extra byte to detect files larger than the declared size
+	data, err := io.ReadAll(io.LimitReader(src, MaxPhotoBytes+1))
+	if err != nil {
+		return nil, response.Error(http.StatusBadRequest, "no se pudo leer la foto")
+	}
+
+	if int64(len(data)) > MaxPhotoBytes {
+		return nil, response.Error(http.StatusRequestEntityTooLarge, fmt.Sprintf("la foto supera el tamaño máximo de %d bytes", MaxPhotoBytes))
+	}
+
+	if _, err := imaging.Sniff(data); err != nil {
+		return nil, response.Error(http.StatusUnsupportedMediaType, err.Error())
+	}
+
+	return data, response.EmptyError
+}
@@ -8,25 +8,45 @@
 package handlers
 
 import (
+	"backend/internal/db/query"
 	m "backend/internal/models"
 	s "backend/internal/services/backend_calls"
+	"backend/internal/services/search"
 	response "backend/internal/utils/rest"
+	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
 )
 
 // ========================================
 // PET MANAGEMENT HANDLERS
 // ========================================
 
-// HandleListPets processes requests to retrieve all pets in the system.
+// HandleListPets processes requests to retrieve a page of pets.
 // Returns a simplified view of pets suitable for listing purposes.
 //
+// Validation:
+// - Validates pagination, sorting and filter parameters (400 on invalid input)
+//
+// Parameters:
+//   - path: Request path used to build next/prev links
+//   - values: Raw query parameters
+//   - viewer: Current user, or nil for anonymous requests
+//
 // Returns:
-//   - *[]m.SimplifiedPet: List of all pets with essential information
+//   - *query.Page[m.SimplifiedPet]: Requested page of pets with total count and links
 //   - response.HTTPError: HTTP error or EmptyError on success
-func HandleListPets() (*[]m.SimplifiedPet, response.HTTPError) {
+func HandleListPets(path string, values url.Values, viewer *m.NonValidatedUser) (*query.Page[m.SimplifiedPet], response.HTTPError) {
+	// Input validation
+	params, err := s.NewPetListQuery(path, values)
+	if err != nil {
+		return nil, response.Error(http.StatusBadRequest, err.Error())
+	}
+
 	// Delegate pet listing to service layer
-	pets, err := s.ListAllPets()
+	pets, err := s.ListAllPets(params, viewer)
 	if err != nil {
 		return nil, response.Error(http.StatusInternalServerError, err.Error())
 	}
@@ -43,18 +63,19 @@ func HandleListPets() (*[]m.SimplifiedPet, response.HTTPError) {
 //
 // Parameters:
 //   - id: Pet ID to retrieve
+//   - viewer: Current user, or nil for anonymous requests
 //
 // Returns:
 //   - *m.Pet: Complete pet data with all information
 //   - response.HTTPError: HTTP error or EmptyError on success
-func HandleGetPetByID(id uint) (*m.Pet, response.HTTPError) {
+func HandleGetPetByID(id uint, viewer *m.NonValidatedUser) (*m.Pet, response.HTTPError) {
 	// Input validation
 	if id <= 0 {
 		return nil, response.Error(http.StatusBadRequest, "ID de mascota no válido")
 	}
 
 	// Delegate pet retrieval to service layer
-	pet, err := s.GetPetByID(id)
+	pet, err := s.GetPetByID(id, viewer)
 	if err != nil {
 		return nil, response.Error(http.StatusNotFound, err.Error())
 	}
@@ -67,22 +88,38 @@ func HandleGetPetByID(id uint) (*m.Pet, response.HTTPError) {
 //
 // Validation:
 // - Ensures required fields are provided (name and species are mandatory)
+// - Ensures status, if provided, is available, reserved or adopted
+// - Rejects invalid microchip numbers (400) and microchips assigned to another pet (409)
+// - Assigns the pet to the organisation of the acting staff member
 // - Delegates creation logic and business rules to service layer
 //
 // Parameters:
 //   - pet: Pet data for the new pet to be created
+//   - orgID: Organisation of the acting staff member
 //
 // Returns:
 //   - *m.Pet: Created pet data with assigned ID and timestamps
 //   - response.HTTPError: HTTP error or EmptyError on success
-func HandleCreatePet(pet *m.Pet) (*m.Pet, response.HTTPError) {
+func HandleCreatePet(pet *m.Pet, orgID uint) (*m.Pet, response.HTTPError) {
 	// Input validation
 	if pet.Name == "" || pet.Species == "" {
 		return nil, response.Error(http.StatusBadRequest, "nombre y especie de mascota son obligatorios")
 	}
 
+	if !s.IsValidPetStatus(pet.Status) {
+		return nil, response.Error(http.StatusBadRequest, "estado de mascota no válido")
+	}
+
+	pet.OrganizationID = orgID
+
 	// Delegate pet creation to service layer
 	err := s.CreatePet(pet)
+	if errors.Is(err, s.ErrInvalidMicrochip) {
+		return nil, response.Error(http.StatusBadRequest, err.Error())
+	}
+	if errors.Is(err, s.ErrMicrochipInUse) {
+		return nil, response.Error(http.StatusConflict, err.Error())
+	}
 	if err != nil {
 		return nil, response.Error(http.StatusInternalServerError, err.Error())
 	}
@@ -96,15 +133,19 @@ func HandleCreatePet(pet *m.Pet) (*m.Pet, response.HTTPError) {
 // Validation:
 // - Ensures pet ID is valid (greater than 0)
 // - Ensures required fields are provided (name and species are mandatory)
+// - Ensures status, if provided, is available, reserved or adopted
+// - Rejects invalid microchip numbers (400) and microchips assigned to another pet (409)
+// - Only updates pets of the organisation of the acting staff member
 // - Delegates update logic and business rules to service layer
 //
 // Parameters:
 //   - pet: Pet data with updated information (must include valid ID)
+//   - orgID: Organisation of the acting staff member
 //
 // Returns:
 //   - *m.Pet: Updated pet data
 //   - response.HTTPError: HTTP error or EmptyError on success
-func HandleUpdatePet(pet *m.Pet) (*m.Pet, response.HTTPError) {
+func HandleUpdatePet(pet *m.Pet, orgID uint) (*m.Pet, response.HTTPError) {
 	// Input validation
 	if pet.ID <= 0 {
 		return nil, response.Error(http.StatusBadRequest, "ID de mascota no válido")
@@ -114,8 +155,20 @@ func HandleUpdatePet(pet *m.Pet) (*m.Pet, response.HTTPError) {
 		return nil, response.Error(http.StatusBadRequest, "nombre y especie de mascota son obligatorios")
 	}
 
+	if !s.IsValidPetStatus(pet.Status) {
+		return nil, response.Error(http.StatusBadRequest, "estado de mascota no válido")
+	}
+
+	pet.OrganizationID = orgID
+
 	// Delegate pet update to service layer
 	err := s.UpdatePet(pet)
+	if errors.Is(err, s.ErrInvalidMicrochip) {
+		return nil, response.Error(http.StatusBadRequest, err.Error())
+	}
+	if errors.Is(err, s.ErrMicrochipInUse) {
+		return nil, response.Error(http.StatusConflict, err.Error())
+	}
 	if err != nil {
 		return nil, response.Error(http.StatusInternalServerError, err.Error())
 	}
@@ -132,20 +185,95 @@ func HandleUpdatePet(pet *m.Pet) (*m.Pet, response.HTTPError) {
 //
 // Parameters:
 //   - id: Pet ID to delete
+//   - orgID: Organisation of the acting staff member
 //
 // Returns:
 //   - response.HTTPError: HTTP error or EmptyError on success
-func HandleDeletePet(id uint) response.HTTPError {
+func HandleDeletePet(id uint, orgID uint) response.HTTPError {
 	// Input validation
 	if id <= 0 {
 		return response.Error(http.StatusBadRequest, "ID de mascota no válido")
 	}
 
 	// Delegate pet deletion to service layer
-	err := s.DeletePet(id)
+	err := s.DeletePet(id, orgID)
 	if err != nil {
 		return response.Error(http.StatusInternalServerError, err.Error())
 	}
 
 	return response.EmptyError
 }
+
+// HandleSearchPets processes full-text pet search requests.
+// Returns ranked results with highlighted snippets.
+//
+// Validation:
+// - Ensures q is present and at most search.MaxQueryLength bytes long
+// - Validates pagination, sorting and filter parameters (400 on invalid input)
+// - Rejects queries made only of stopwords (400)
+//
+// Parameters:
+//   - path: Request path used to build next/prev links
+//   - values: Raw query parameters, including q
+//   - viewer: Current user, or nil for anonymous requests
+//
+// Returns:
+//   - *query.Page[m.PetSearchResult]: Requested page of results with total count and links
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleSearchPets(path string, values url.Values, viewer *m.NonValidatedUser) (*query.Page[m.PetSearchResult], response.HTTPError) {
+	// Input validation
+	q := strings.TrimSpace(values.Get("q"))
+	if q == "" {
+		return nil, response.Error(http.StatusBadRequest, "el parámetro q es obligatorio")
+	}
+
+	if len(q) > search.MaxQueryLength {
+		return nil, response.Error(http.StatusBadRequest, fmt.Sprintf("la búsqueda no puede superar %d caracteres", search.MaxQueryLength))
+	}
+
+	params, err := s.NewPetSearchQuery(path, values)
+	if err != nil {
+		return nil, response.Error(http.StatusBadRequest, err.Error())
+	}
+
+	// Delegate search to service layer
+	results, err := s.SearchPets(q, params, viewer)
+	if errors.Is(err, s.ErrNoSearchTerms) {
+		return nil, response.Error(http.StatusBadRequest, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return results, response.EmptyError
+}
+
+// HandleLookupMicrochip processes microchip lookup requests.
+// Identifies the pet carrying a microchip; adopter contact details are only included for staff.
+//
+// Validation:
+// - Ensures the number is a valid ISO 11784/11785 code (400)
+// - Returns 404 when no pet has the microchip
+//
+// Parameters:
+//   - number: Microchip number (spaces, dashes and dots are ignored)
+//   - viewer: Current user, or nil for anonymous requests
+//
+// Returns:
+//   - *m.MicrochipLookup: Identified pet
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleLookupMicrochip(number string, viewer *m.NonValidatedUser) (*m.MicrochipLookup, response.HTTPError) {
+	// Delegate lookup to service layer
+	lookup, err := s.LookupMicrochip(number, viewer)
+	if errors.Is(err, s.ErrInvalidMicrochip) {
+		return nil, response.Error(http.StatusBadRequest, err.Error())
+	}
+	if errors.Is(err, s.ErrMicrochipNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return lookup, response.EmptyError
+}
@@ -0,0 +1,204 @@
+// Package handlers implements HTTP request handlers for the saved search API.
+// This layer is responsible for:
+// - Validating saved search criteria
+// - Calling appropriate service layer functions on behalf of the current user
+// - Converting service errors to HTTP responses
+package handlers
+
+import (
+	r_models "backend/internal/api/routes/models"
+	m "backend/internal/models"
+	s "backend/internal/services/backend_calls"
+	"backend/internal/services/security"
+	response "backend/internal/utils/rest"
+	"errors"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+// ========================================
+// SAVED SEARCH HANDLERS
+// ========================================
+
+// HandleListSavedSearches processes requests to retrieve the saved searches of the current user.
+//
+// Parameters:
+//   - userID: Authenticated user ID
+//
+// Returns:
+//   - []m.SavedSearch: Saved searches, newest first
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleListSavedSearches(userID uint) ([]m.SavedSearch, response.HTTPError) {
+	searches, err := s.ListSavedSearches(userID)
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return searches, response.EmptyError
+}
+
+// HandleCreateSavedSearch processes requests to save a new search for the current user.
+//
+// Validation:
+// - Validates the criteria (see validateSavedSearch)
+// - Returns 409 when the user already has the maximum number of saved searches
+//
+// Parameters:
+//   - userID: Authenticated user ID
+//   - req: SavedSearchRequest with the search criteria
+//
+// Returns:
+//   - *m.SavedSearch: Created saved search
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleCreateSavedSearch(userID uint, req r_models.SavedSearchRequest) (*m.SavedSearch, response.HTTPError) {
+	// Input validation
+	if msg := validateSavedSearch(req); msg != "" {
+		return nil, response.Error(http.StatusBadRequest, msg)
+	}
+
+	search := toSavedSearch(userID, req)
+
+	// Delegate creation to service layer
+	err := s.CreateSavedSearch(search)
+	if errors.Is(err, s.ErrTooManySavedSearches) {
+		return nil, response.Error(http.StatusConflict, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return search, response.EmptyError
+}
+
+// HandleUpdateSavedSearch processes requests to replace the criteria of a saved search.
+//
+// Validation:
+// - Ensures the saved search ID is valid
+// - Validates the criteria (see validateSavedSearch)
+//
+// Parameters:
+//   - userID: Authenticated user ID
+//   - id: Saved search ID
+//   - req: SavedSearchRequest with the new criteria
+//
+// Returns:
+//   - *m.SavedSearch: Updated saved search
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleUpdateSavedSearch(userID uint, id uint, req r_models.SavedSearchRequest) (*m.SavedSearch, response.HTTPError) {
+	// Input validation
+	if id <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de búsqueda no válido")
+	}
+
+	if msg := validateSavedSearch(req); msg != "" {
+		return nil, response.Error(http.StatusBadRequest, msg)
+	}
+
+	search := toSavedSearch(userID, req)
+	search.ID = id
+
+	// Delegate update to service layer
+	updated, err := s.UpdateSavedSearch(search)
+	if err != nil {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+
+	return updated, response.EmptyError
+}
+
+// HandleDeleteSavedSearch processes requests to delete a saved search of the current user.
+//
+// Parameters:
+//   - userID: Authenticated user ID
+//   - id: Saved search ID
+//
+// Returns:
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleDeleteSavedSearch(userID uint, id uint) response.HTTPError {
+	// Input validation
+	if id <= 0 {
+		return response.Error(http.StatusBadRequest, "ID de búsqueda no válido")
+	}
+
+	if err := s.DeleteSavedSearch(userID, id); err != nil {
+		return response.Error(http.StatusNotFound, err.Error())
+	}
+
+	return response.EmptyError
+}
+
+// HandleUnsubscribeSearchAlerts processes unsubscribe links from alert emails.
+//
+// Parameters:
+//   - token: Signed token from the link
+//
+// Returns:
+//   - response.HTTPError: 400 for invalid tokens, 500 for database errors, EmptyError on success
+func HandleUnsubscribeSearchAlerts(token string) response.HTTPError {
+	// Input validation
+	if token == "" {
+		return response.Error(http.StatusBadRequest, "token es obligatorio")
+	}
+
+	err := s.UnsubscribeSearchAlerts(token)
+	if errors.Is(err, security.ErrInvalidToken) {
+		return response.Error(http.StatusBadRequest, "enlace de baja no válido")
+	}
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return response.EmptyError
+}
+
+// ========================================
+// SAVED SEARCH HELPERS
+// ========================================
+
+// validateSavedSearch checks the saved search criteria and returns an error message, or "" if valid.
+func validateSavedSearch(req r_models.SavedSearchRequest) string {
+	if strings.TrimSpace(req.Species) == "" && strings.TrimSpace(req.Breed) == "" &&
+		strings.TrimSpace(req.Keywords) == "" && req.AgeMin == nil && req.AgeMax == nil {
+		return "la búsqueda debe tener al menos un criterio"
+	}
+
+	if utf8.RuneCountInString(req.Name) > 100 {
+		return "el nombre no puede superar 100 caracteres"
+	}
+
+	if utf8.RuneCountInString(req.Keywords) > 200 {
+		return "las palabras clave no pueden superar 200 caracteres"
+	}
+
+	for _, age := range []*int{req.AgeMin, req.AgeMax} {
+		if age != nil && (*age < 0 || *age > 100) {
+			return "la edad debe estar entre 0 y 100 años"
+		}
+	}
+
+	if req.AgeMin != nil && req.AgeMax != nil && *req.AgeMin > *req.AgeMax {
+		return "age_min no puede ser mayor que age_max"
+	}
+
+	return ""
+}
+
+// toSavedSearch converts a request into a saved search owned by userID.
+func toSavedSearch(userID uint, req r_models.SavedSearchRequest) *m.SavedSearch {
+	alerts := true
+	if req.AlertsEnabled != nil {
+		alerts = *req.AlertsEnabled
+	}
+
+	return &m.SavedSearch{
+		UserID:        userID,
+		Name:          req.Name,
+		Species:       req.Species,
+		Breed:         req.Breed,
+		AgeMin:        req.AgeMin,
+		AgeMax:        req.AgeMax,
+		Keywords:      req.Keywords,
+		AlertsEnabled: alerts,
+	}
+}
@@ -8,25 +8,40 @@
 package handlers
 
 import (
+	"backend/internal/db/query"
 	m "backend/internal/models"
 	s "backend/internal/services/backend_calls"
 	response "backend/internal/utils/rest"
 	"net/http"
+	"net/url"
 )
 
 // ========================================
 // SPECIES MANAGEMENT HANDLERS
 // ========================================
 
-// HandleListSpecies processes requests to retrieve all species in the system.
-// Returns all available species for use in pet registration and filtering.
+// HandleListSpecies processes requests to retrieve a page of species.
+// Returns available species for use in pet registration and filtering.
+//
+// Validation:
+// - Validates pagination, sorting and filter parameters (400 on invalid input)
+//
+// Parameters:
+//   - path: Request path used to build next/prev links
+//   - values: Raw query parameters
 //
 // Returns:
-//   - []m.Species: List of all species with their information
+//   - *query.Page[m.Species]: Requested page of species with total count and links
 //   - response.HTTPError: HTTP error or EmptyError on success
-func HandleListSpecies() ([]m.Species, response.HTTPError) {
+func HandleListSpecies(path string, values url.Values) (*query.Page[m.Species], response.HTTPError) {
+	// Input validation
+	params, err := s.NewSpeciesListQuery(path, values)
+	if err != nil {
+		return nil, response.Error(http.StatusBadRequest, err.Error())
+	}
+
 	// Delegate species listing to service layer
-	species, err := s.ListAllSpecies()
+	species, err := s.ListAllSpecies(params)
 	if err != nil {
 		return nil, response.Error(http.StatusInternalServerError, err.Error())
 	}
@@ -67,20 +82,24 @@ func HandleGetSpeciesByID(id uint) (*m.Species, response.HTTPError) {
 //
 // Validation:
 // - Ensures required fields are provided (name is mandatory)
+// - Assigns the species to the organisation of the acting staff member
 // - Delegates creation logic and business rules to service layer
 //
 // Parameters:
 //   - species: Species data for the new species to be created
+//   - orgID: Organisation of the acting staff member
 //
 // Returns:
 //   - *m.Species: Created species data with assigned ID
 //   - response.HTTPError: HTTP error or EmptyError on success
-func HandleCreateSpecies(species *m.Species) (*m.Species, response.HTTPError) {
+func HandleCreateSpecies(species *m.Species, orgID uint) (*m.Species, response.HTTPError) {
 	// Input validation
 	if species.Name == "" {
 		return nil, response.Error(http.StatusBadRequest, "nombre de especie es obligatorio")
 	}
 
+	species.OrganizationID = orgID
+
 	// Delegate species creation to service layer
 	err := s.CreateSpecies(species)
 	if err != nil {
@@ -102,17 +121,18 @@ func HandleCreateSpecies(species *m.Species) (*m.Species, response.HTTPError) {
 //
 // Parameters:
 //   - id: Species ID to delete
+//   - orgID: Organisation of the acting staff member
 //
 // Returns:
 //   - response.HTTPError: HTTP error or EmptyError on success
-func HandleDeleteSpecies(id uint) response.HTTPError {
+func HandleDeleteSpecies(id uint, orgID uint) response.HTTPError {
 	// Input validation
 	if id <= 0 {
 		return response.Error(http.StatusBadRequest, "ID de especie no válido")
 	}
 
 	// Delegate species deletion to service layer
-	err := s.DeleteSpecies(id)
+	err := s.DeleteSpecies(id, orgID)
 	if err != nil {
 		return response.Error(http.StatusInternalServerError, err.Error())
 	}
@@ -17,11 +17,14 @@ package handlers
 
 import (
 	r_models "backend/internal/api/routes/models"
+	"backend/internal/db/query"
 	"backend/internal/models"
 	s "backend/internal/services/backend_calls"
 	"backend/internal/services/security"
 	response "backend/internal/utils/rest"
+	"errors"
 	"net/http"
+	"net/url"
 	"time"
 )
 
@@ -40,16 +43,16 @@ import (
 //   - req: LoginRequest containing user credentials
 //
 // Returns:
-//   - *models.User: Authenticated user data with session information
+//   - *models.AuthenticatedUser: Authenticated user data with session information
 //   - response.HTTPError: HTTP error or EmptyError on success
-func HandleManualLogin(req r_models.LoginRequest) (*models.User, response.HTTPError) {
+func HandleManualLogin(req r_models.LoginRequest) (*models.AuthenticatedUser, response.HTTPError) {
 	// Delegate authentication to service layer
 	user, err := s.AuthenticateUser(req)
 	if err != nil {
 		return nil, response.Error(http.StatusUnauthorized, err.Error())
 	}
 
-	return user, response.EmptyError
+	return toAuthenticatedUser(user), response.EmptyError
 }
 
 // Handle2FAAuth processes two-factor authentication verification requests.
@@ -119,9 +122,9 @@ func HandleRefresh2FAToken(req r_models.RefreshTokenRequest) (*string, response.
 //   - req: GoogleLoginRequest containing Google authentication data
 //
 // Returns:
-//   - *models.User: Authenticated user data
+//   - *models.AuthenticatedUser: Authenticated user data with session information
 //   - response.HTTPError: HTTP error or EmptyError on success
-func HandleGoogleLogin(req r_models.GoogleLoginRequest) (*models.User, response.HTTPError) {
+func HandleGoogleLogin(req r_models.GoogleLoginRequest) (*models.AuthenticatedUser, response.HTTPError) {
 	// Input validation
 	if req.Email == "" || req.IDToken == "" {
 		return nil, response.Error(http.StatusBadRequest, "email y ID Token son obligatorios")
@@ -133,7 +136,30 @@ func HandleGoogleLogin(req r_models.GoogleLoginRequest) (*models.User, response.
 		return nil, response.Error(http.StatusUnauthorized, err.Error())
 	}
 
-	return user, response.EmptyError
+	return toAuthenticatedUser(user), response.EmptyError
+}
+
+// toAuthenticatedUser maps a logged-in user to the login response, which carries
+// the new session identifier but none of the credentials of the account.
+func toAuthenticatedUser(user *models.User) *models.AuthenticatedUser {
+	return &models.AuthenticatedUser{
+		NonValidatedUser: models.NonValidatedUser{
+			ID:           user.ID,
+			Name:         user.Name,
+			Surname:      user.Surname,
+			Email:        user.Email,
+			Address:      user.Address,
+			FailedLogins: user.FailedLogins,
+			Provider:     user.Provider,
+			IsBlocked:    user.IsBlocked,
+			Role:         user.Role,
+			Locale:       user.Locale,
+			CrtDate:      user.CrtDate,
+			UptDate:      user.UptDate,
+		},
+		ChangePass: user.ChangePass,
+		SessionID:  user.SessionID,
+	}
 }
 
 // HandleResetPassword processes password reset requests for local authentication users.
@@ -201,15 +227,28 @@ func HandleForgotPassword(req r_models.ResetPasswordRequest) (*string, response.
 // USER MANAGEMENT HANDLERS
 // ========================================
 
-// HandleListUsers processes requests to retrieve all users.
+// HandleListUsers processes requests to retrieve a page of users.
 // Returns non-sensitive user data for administrative purposes.
 //
+// Validation:
+// - Validates pagination, sorting and filter parameters (400 on invalid input)
+//
+// Parameters:
+//   - path: Request path used to build next/prev links
+//   - values: Raw query parameters
+//
 // Returns:
-//   - *[]models.NonValidatedUser: List of all users without sensitive data
+//   - *query.Page[models.NonValidatedUser]: Requested page of users without sensitive data
 //   - response.HTTPError: HTTP error or EmptyError on success
-func HandleListUsers() (*[]models.NonValidatedUser, response.HTTPError) {
+func HandleListUsers(path string, values url.Values) (*query.Page[models.NonValidatedUser], response.HTTPError) {
+	// Input validation
+	params, err := s.NewUserListQuery(path, values)
+	if err != nil {
+		return nil, response.Error(http.StatusBadRequest, err.Error())
+	}
+
 	// Delegate user listing to service layer
-	users, err := s.ListAllUsers()
+	users, err := s.ListAllUsers(params)
 	if err != nil {
 		return nil, response.Error(http.StatusInternalServerError, err.Error())
 	}
@@ -245,11 +284,43 @@ func HandleGetUserByID(id uint) (*models.NonValidatedUser, response.HTTPError) {
 	return user, response.EmptyError
 }
 
+// HandleAuthenticateSession resolves the user owning a session identifier.
+// Used by the session middleware to authenticate requests.
+//
+// Validation:
+// - Ensures the session ID is provided (401 otherwise)
+// - Unknown sessions return 401, blocked users return 403
+//
+// Parameters:
+//   - sessionID: Session identifier sent by the client
+//
+// Returns:
+//   - *models.NonValidatedUser: Authenticated user
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleAuthenticateSession(sessionID string) (*models.NonValidatedUser, response.HTTPError) {
+	// Input validation
+	if sessionID == "" {
+		return nil, response.Error(http.StatusUnauthorized, "sesión requerida")
+	}
+
+	// Delegate session lookup to service layer
+	user, err := s.GetSessionUser(sessionID)
+	if errors.Is(err, s.ErrUserBlocked) {
+		return nil, response.Error(http.StatusForbidden, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusUnauthorized, "sesión no válida")
+	}
+
+	return user, response.EmptyError
+}
+
 // HandleCreateUser processes user registration requests.
 // Creates new user accounts with proper data transformation and validation.
 //
 // Validation:
 // - Ensures required fields are provided (name is mandatory)
+// - Rejects unsupported locales (empty uses the default locale)
 // - Transforms request data to internal user model
 // - Sets creation and update timestamps
 //
@@ -263,6 +334,9 @@ func HandleCreateUser(user *r_models.CreateUserRequest) response.HTTPError {
 	if user.Name == "" {
 		return response.Error(http.StatusBadRequest, "nombre es obligatorio")
 	}
+	if !s.IsValidLocale(user.Locale) {
+		return response.Error(http.StatusBadRequest, "idioma no soportado")
+	}
 
 	// Transform request data to internal model
 	fullUser := &models.FullUser{
@@ -273,6 +347,7 @@ func HandleCreateUser(user *r_models.CreateUserRequest) response.HTTPError {
 		Address:    user.Address,
 		Provider:   user.Provider,
 		ProviderID: user.ProviderID,
+		Locale:     user.Locale,
 		CrtDate:    time.Now(),
 		UptDate:    time.Now(),
 	}
@@ -290,6 +365,7 @@ func HandleCreateUser(user *r_models.CreateUserRequest) response.HTTPError {
 // Updates existing user information with proper validation.
 //
 // Validation:
+// - Rejects unsupported locales (empty keeps the current locale)
 // - Delegates validation and update logic to service layer
 // - Ensures data integrity during updates
 //
@@ -299,6 +375,10 @@ func HandleCreateUser(user *r_models.CreateUserRequest) response.HTTPError {
 // Returns:
 //   - response.HTTPError: HTTP error or EmptyError on success
 func HandleUpdateUser(user *models.User) response.HTTPError {
+	if !s.IsValidLocale(user.Locale) {
+		return response.Error(http.StatusBadRequest, "idioma no soportado")
+	}
+
 	// Hash password if provided
 	if user.Password != "" {
 		hashedPassword, err := security.HashPassword(user.Password)
@@ -0,0 +1,475 @@
+// Package handlers implements HTTP request handlers for the meet-and-greet visit API.
+// This layer is responsible for:
+// - Validating availability slots, bookings and visit status changes
+// - Calling appropriate service layer functions
+// - Converting service errors to HTTP responses
+package handlers
+
+import (
+	r_models "backend/internal/api/routes/models"
+	"backend/internal/db/query"
+	m "backend/internal/models"
+	s "backend/internal/services/backend_calls"
+	"backend/internal/services/security"
+	response "backend/internal/utils/rest"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxVisitSlotDuration is the longest slot staff can publish.
+const maxVisitSlotDuration = 8 * time.Hour
+
+// ========================================
+// VISIT SLOT HANDLERS
+// ========================================
+
+// HandleListVisitSlots processes staff requests to retrieve a page of availability slots.
+//
+// Validation:
+// - Validates pagination, sorting and filter parameters (400 on invalid input)
+//
+// Parameters:
+//   - path: Request path used to build next/prev links
+//   - values: Raw query parameters
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - *query.Page[m.VisitSlot]: Requested page of slots
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleListVisitSlots(path string, values url.Values, orgID uint) (*query.Page[m.VisitSlot], response.HTTPError) {
+	params, err := s.NewVisitSlotListQuery(path, values)
+	if err != nil {
+		return nil, response.Error(http.StatusBadRequest, err.Error())
+	}
+
+	slots, err := s.ListVisitSlots(params, orgID)
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return slots, response.EmptyError
+}
+
+// HandleCreateVisitSlot processes staff requests to publish an availability slot.
+//
+// Validation:
+// - Ensures the times are valid RFC 3339 values, in the future and at most 8 hours apart
+// - Ensures the location does not exceed 255 characters
+//
+// Parameters:
+//   - orgID: Organisation of the acting staff member
+//   - staffID: Authenticated staff user ID
+//   - req: VisitSlotRequest with the time window
+//
+// Returns:
+//   - *m.VisitSlot: Created slot
+//   - response.HTTPError: HTTP error or EmptyError on success (409 when it overlaps a slot of the same pet)
+func HandleCreateVisitSlot(orgID uint, staffID uint, req r_models.VisitSlotRequest) (*m.VisitSlot, response.HTTPError) {
+	// Input validation
+	start, err := time.Parse(time.RFC3339, strings.TrimSpace(req.StartTime))
+	if err != nil {
+		return nil, response.Error(http.StatusBadRequest, "start_time es obligatoria (formato RFC 3339)")
+	}
+
+	end, err := time.Parse(time.RFC3339, strings.TrimSpace(req.EndTime))
+	if err != nil {
+		return nil, response.Error(http.StatusBadRequest, "end_time es obligatoria (formato RFC 3339)")
+	}
+
+	if !start.After(time.Now()) {
+		return nil, response.Error(http.StatusBadRequest, "start_time debe ser una fecha futura")
+	}
+	if !end.After(start) || end.Sub(start) > maxVisitSlotDuration {
+		return nil, response.Error(http.StatusBadRequest, "end_time debe ser posterior a start_time y la franja no puede superar 8 horas")
+	}
+
+	location := strings.TrimSpace(req.Location)
+	if utf8.RuneCountInString(location) > 255 {
+		return nil, response.Error(http.StatusBadRequest, "location no puede superar 255 caracteres")
+	}
+
+	slot := &m.VisitSlot{
+		OrganizationID: orgID,
+		PetID:          req.PetID,
+		StartTime:      start,
+		EndTime:        end,
+		Location:       location,
+		CreatedBy:      staffID,
+	}
+
+	created, err := s.CreateVisitSlot(slot)
+	if errors.Is(err, s.ErrVisitPetNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if errors.Is(err, s.ErrVisitSlotOverlap) {
+		return nil, response.Error(http.StatusConflict, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return created, response.EmptyError
+}
+
+// HandleDeleteVisitSlot processes staff requests to delete a free slot.
+//
+// Parameters:
+//   - id: Slot ID
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - response.HTTPError: HTTP error or EmptyError on success (409 when a visit holds the slot)
+func HandleDeleteVisitSlot(id uint, orgID uint) response.HTTPError {
+	// Input validation
+	if id <= 0 {
+		return response.Error(http.StatusBadRequest, "ID de franja no válido")
+	}
+
+	err := s.DeleteVisitSlot(id, orgID)
+	if errors.Is(err, s.ErrVisitSlotNotFound) {
+		return response.Error(http.StatusNotFound, err.Error())
+	}
+	if errors.Is(err, s.ErrVisitSlotBooked) {
+		return response.Error(http.StatusConflict, err.Error())
+	}
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return response.EmptyError
+}
+
+// HandleListAvailableVisitSlots processes public requests to retrieve the free slots to visit a pet.
+//
+// Parameters:
+//   - petID: Pet ID
+//
+// Returns:
+//   - []m.VisitSlot: Free future slots, earliest first
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleListAvailableVisitSlots(petID uint) ([]m.VisitSlot, response.HTTPError) {
+	// Input validation
+	if petID <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de mascota no válido")
+	}
+
+	slots, err := s.ListAvailableVisitSlots(petID)
+	if errors.Is(err, s.ErrVisitPetNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return slots, response.EmptyError
+}
+
+// ========================================
+// VISIT HANDLERS
+// ========================================
+
+// HandleListVisits processes staff requests to retrieve a page of visits.
+//
+// Validation:
+// - Validates pagination, sorting and filter parameters (400 on invalid input)
+//
+// Parameters:
+//   - path: Request path used to build next/prev links
+//   - values: Raw query parameters
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - *query.Page[m.Visit]: Requested page of visits with pet summaries
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleListVisits(path string, values url.Values, orgID uint) (*query.Page[m.Visit], response.HTTPError) {
+	params, err := s.NewVisitListQuery(path, values)
+	if err != nil {
+		return nil, response.Error(http.StatusBadRequest, err.Error())
+	}
+
+	visits, err := s.ListVisits(params, orgID)
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return visits, response.EmptyError
+}
+
+// HandleGetVisit processes staff requests to retrieve a visit.
+//
+// Parameters:
+//   - id: Visit ID
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - *m.Visit: Visit with pet summary
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleGetVisit(id uint, orgID uint) (*m.Visit, response.HTTPError) {
+	// Input validation
+	if id <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de visita no válido")
+	}
+
+	visit, err := s.GetVisit(id, orgID)
+	if errors.Is(err, s.ErrVisitNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return visit, response.EmptyError
+}
+
+// HandleBookVisit processes public requests to book a meet-and-greet visit.
+//
+// Validation:
+// - Ensures the slot is given and the visitor data is valid (see toVisit)
+// - Signed-in visitors get the visit linked to their account and their email as default
+//
+// Parameters:
+//   - req: BookVisitRequest with the slot, pet and visitor data
+//   - viewer: Current user, or nil for anonymous visitors
+//
+// Returns:
+//   - *m.Visit: Booked visit
+//   - response.HTTPError: HTTP error or EmptyError on success (409 when the slot or the pet is not available)
+func HandleBookVisit(req r_models.BookVisitRequest, viewer *m.NonValidatedUser) (*m.Visit, response.HTTPError) {
+	if viewer != nil && strings.TrimSpace(req.VisitorEmail) == "" {
+		req.VisitorEmail = viewer.Email
+	}
+
+	// Input validation
+	visit, msg := toVisit(req)
+	if msg != "" {
+		return nil, response.Error(http.StatusBadRequest, msg)
+	}
+
+	if viewer != nil {
+		visit.UserID = &viewer.ID
+	}
+
+	booked, err := s.BookVisit(visit)
+	if errors.Is(err, s.ErrVisitSlotNotFound) || errors.Is(err, s.ErrVisitPetNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if errors.Is(err, s.ErrVisitSlotUnavailable) || errors.Is(err, s.ErrVisitPetUnavailable) {
+		return nil, response.Error(http.StatusConflict, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return booked, response.EmptyError
+}
+
+// HandleUpdateVisitStatus processes staff requests to record the outcome of a visit or cancel it.
+//
+// Validation:
+// - Ensures the status is cancelled, completed or no_show
+// - Ensures the reason does not exceed 500 characters
+//
+// Parameters:
+//   - id: Visit ID
+//   - orgID: Organisation of the acting staff member
+//   - req: UpdateVisitStatusRequest with the new status
+//
+// Returns:
+//   - *m.Visit: Updated visit
+//   - response.HTTPError: HTTP error or EmptyError on success (409 when the visit is no longer scheduled)
+func HandleUpdateVisitStatus(id uint, orgID uint, req r_models.UpdateVisitStatusRequest) (*m.Visit, response.HTTPError) {
+	// Input validation
+	if id <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de visita no válido")
+	}
+
+	if req.Status != m.VisitStatusCancelled && req.Status != m.VisitStatusCompleted && req.Status != m.VisitStatusNoShow {
+		return nil, response.Error(http.StatusBadRequest, "estado inválido, debe ser uno de: cancelled, completed, no_show")
+	}
+
+	reason, msg := visitCancelReason(req.Reason)
+	if msg != "" {
+		return nil, response.Error(http.StatusBadRequest, msg)
+	}
+
+	visit, err := s.UpdateVisitStatus(id, orgID, req.Status, reason)
+	return visitChangeResult(visit, err)
+}
+
+// HandleLinkVisitApplication processes staff requests to link a visit to an adoption application.
+//
+// Parameters:
+//   - id: Visit ID
+//   - orgID: Organisation of the acting staff member
+//   - req: VisitApplicationRequest with the application ID (null to unlink)
+//
+// Returns:
+//   - *m.Visit: Updated visit
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleLinkVisitApplication(id uint, orgID uint, req r_models.VisitApplicationRequest) (*m.Visit, response.HTTPError) {
+	// Input validation
+	if id <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de visita no válido")
+	}
+	if req.ApplicationID != nil && *req.ApplicationID == 0 {
+		return nil, response.Error(http.StatusBadRequest, "application_id no válido")
+	}
+
+	visit, err := s.LinkVisitApplication(id, orgID, req.ApplicationID)
+	if errors.Is(err, s.ErrVisitNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return visit, response.EmptyError
+}
+
+// ========================================
+// VISIT MANAGEMENT LINK HANDLERS
+// ========================================
+
+// HandleGetManagedVisit processes requests from the signed link sent to the visitor.
+//
+// Parameters:
+//   - token: Signed token from the link
+//
+// Returns:
+//   - *m.Visit: Visit with pet summary
+//   - response.HTTPError: 400 for invalid tokens, 404 unknown visit, EmptyError on success
+func HandleGetManagedVisit(token string) (*m.Visit, response.HTTPError) {
+	// Input validation
+	if token == "" {
+		return nil, response.Error(http.StatusBadRequest, "token es obligatorio")
+	}
+
+	visit, err := s.GetManagedVisit(token)
+	return visitChangeResult(visit, err)
+}
+
+// HandleCancelManagedVisit processes cancellations from the signed link sent to the visitor.
+//
+// Parameters:
+//   - token: Signed token from the link
+//   - req: CancelVisitRequest with the optional reason
+//
+// Returns:
+//   - *m.Visit: Cancelled visit
+//   - response.HTTPError: HTTP error or EmptyError on success (409 when the visit is no longer scheduled)
+func HandleCancelManagedVisit(token string, req r_models.CancelVisitRequest) (*m.Visit, response.HTTPError) {
+	// Input validation
+	if token == "" {
+		return nil, response.Error(http.StatusBadRequest, "token es obligatorio")
+	}
+
+	reason, msg := visitCancelReason(req.Reason)
+	if msg != "" {
+		return nil, response.Error(http.StatusBadRequest, msg)
+	}
+
+	visit, err := s.CancelManagedVisit(token, reason)
+	return visitChangeResult(visit, err)
+}
+
+// HandleRescheduleManagedVisit processes reschedules from the signed link sent to the visitor.
+//
+// Parameters:
+//   - token: Signed token from the link
+//   - req: RescheduleVisitRequest with the new slot
+//
+// Returns:
+//   - *m.Visit: Rescheduled visit
+//   - response.HTTPError: HTTP error or EmptyError on success (409 when the slot or the pet is not available)
+func HandleRescheduleManagedVisit(token string, req r_models.RescheduleVisitRequest) (*m.Visit, response.HTTPError) {
+	// Input validation
+	if token == "" {
+		return nil, response.Error(http.StatusBadRequest, "token es obligatorio")
+	}
+	if req.SlotID == 0 {
+		return nil, response.Error(http.StatusBadRequest, "slot_id es obligatorio")
+	}
+
+	visit, err := s.RescheduleManagedVisit(token, req.SlotID)
+	return visitChangeResult(visit, err)
+}
+
+// ========================================
+// VISIT HELPERS
+// ========================================
+
+// toVisit validates a booking request and maps it to a visit.
+// It returns an error message, or "" if the request is valid.
+func toVisit(req r_models.BookVisitRequest) (*m.Visit, string) {
+	if req.SlotID == 0 {
+		return nil, "slot_id es obligatorio"
+	}
+
+	name := strings.TrimSpace(req.VisitorName)
+	if name == "" || utf8.RuneCountInString(name) > 100 {
+		return nil, "visitor_name es obligatorio y no puede superar 100 caracteres"
+	}
+
+	email := strings.TrimSpace(req.VisitorEmail)
+	if !isValidEmail(email) {
+		return nil, "visitor_email es obligatorio y debe ser un email válido"
+	}
+
+	phone := strings.TrimSpace(req.VisitorPhone)
+	if utf8.RuneCountInString(phone) > 30 {
+		return nil, "visitor_phone no puede superar 30 caracteres"
+	}
+
+	notes := strings.TrimSpace(req.Notes)
+	if utf8.RuneCountInString(notes) > 2000 {
+		return nil, "notes no puede superar 2000 caracteres"
+	}
+
+	if req.ApplicationID != nil && *req.ApplicationID == 0 {
+		return nil, "application_id no válido"
+	}
+
+	slotID := req.SlotID
+	return &m.Visit{
+		SlotID:        &slotID,
+		PetID:         req.PetID,
+		VisitorName:   name,
+		VisitorEmail:  email,
+		VisitorPhone:  phone,
+		ApplicationID: req.ApplicationID,
+		Notes:         notes,
+	}, ""
+}
+
+// visitCancelReason trims a cancellation reason and checks its length.
+// It returns an error message, or "" if the reason is valid.
+func visitCancelReason(reason string) (string, string) {
+	reason = strings.TrimSpace(reason)
+	if utf8.RuneCountInString(reason) > 500 {
+		return "", "reason no puede superar 500 caracteres"
+	}
+
+	return reason, ""
+}
+
+// visitChangeResult converts the outcome of a visit operation to the handler result.
+func visitChangeResult(visit *m.Visit, err error) (*m.Visit, response.HTTPError) {
+	switch {
+	case err == nil:
+		return visit, response.EmptyError
+	case errors.Is(err, security.ErrInvalidToken):
+		return nil, response.Error(http.StatusBadRequest, "enlace de visita no válido")
+	case errors.Is(err, s.ErrVisitLinkExpired):
+		return nil, response.Error(http.StatusGone, err.Error())
+	case errors.Is(err, s.ErrVisitNotFound), errors.Is(err, s.ErrVisitSlotNotFound), errors.Is(err, s.ErrVisitPetNotFound):
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	case errors.Is(err, s.ErrVisitNotScheduled), errors.Is(err, s.ErrVisitSlotUnavailable), errors.Is(err, s.ErrVisitPetUnavailable):
+		return nil, response.Error(http.StatusConflict, err.Error())
+	default:
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+}
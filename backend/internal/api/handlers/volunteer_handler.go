@@ -0,0 +1,814 @@
+// Package handlers implements HTTP request handlers for the volunteer API.
+// This layer is responsible for:
+// - Validating volunteer profiles, certifications, shifts and attendance
+// - Calling appropriate service layer functions
+// - Converting service errors to HTTP responses
+package handlers
+
+import (
+	r_models "backend/internal/api/routes/models"
+	"backend/internal/db/query"
+	m "backend/internal/models"
+	s "backend/internal/services/backend_calls"
+	response "backend/internal/utils/rest"
+	"errors"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxVolunteerShiftDuration is the longest shift staff can publish.
+const maxVolunteerShiftDuration = 12 * time.Hour
+
+// maxVolunteerHoursRange is the longest date range of the hours report.
+const maxVolunteerHoursRange = 366 * 24 * time.Hour
+
+// ========================================
+// VOLUNTEER HANDLERS
+// ========================================
+
+// HandleListVolunteers processes staff requests to retrieve a page of volunteers.
+//
+// Validation:
+// - Validates pagination, sorting and filter parameters (400 on invalid input)
+//
+// Parameters:
+//   - path: Request path used to build next/prev links
+//   - values: Raw query parameters
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - *query.Page[m.Volunteer]: Requested page of volunteers
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleListVolunteers(path string, values url.Values, orgID uint) (*query.Page[m.Volunteer], response.HTTPError) {
+	params, err := s.NewVolunteerListQuery(path, values)
+	if err != nil {
+		return nil, response.Error(http.StatusBadRequest, err.Error())
+	}
+
+	volunteers, err := s.ListVolunteers(params, orgID)
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return volunteers, response.EmptyError
+}
+
+// HandleGetVolunteer processes staff requests to retrieve a volunteer.
+//
+// Parameters:
+//   - id: Volunteer ID
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - *m.Volunteer: Volunteer with their user and certifications
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleGetVolunteer(id uint, orgID uint) (*m.Volunteer, response.HTTPError) {
+	// Input validation
+	if id <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de voluntario no válido")
+	}
+
+	volunteer, err := s.GetVolunteer(id, orgID)
+	if errors.Is(err, s.ErrVolunteerNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return volunteer, response.EmptyError
+}
+
+// HandleCreateVolunteer processes staff requests to register a user as a volunteer.
+//
+// Validation:
+// - Validates the profile fields (see toVolunteer)
+// - Ensures the volunteer user is given
+//
+// Parameters:
+//   - orgID: Organisation of the acting staff member
+//   - req: VolunteerRequest with the profile
+//
+// Returns:
+//   - *m.Volunteer: Created volunteer
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleCreateVolunteer(orgID uint, req r_models.VolunteerRequest) (*m.Volunteer, response.HTTPError) {
+	// Input validation
+	if req.UserID == 0 {
+		return nil, response.Error(http.StatusBadRequest, "user_id es obligatorio")
+	}
+
+	volunteer, msg := toVolunteer(r_models.VolunteerProfileRequest{
+		Phone:            req.Phone,
+		Bio:              req.Bio,
+		Skills:           req.Skills,
+		EmergencyContact: req.EmergencyContact,
+		EmergencyPhone:   req.EmergencyPhone,
+	})
+	if msg != "" {
+		return nil, response.Error(http.StatusBadRequest, msg)
+	}
+
+	volunteer.OrganizationID = orgID
+	volunteer.UserID = req.UserID
+	volunteer.Notes = strings.TrimSpace(req.Notes)
+	volunteer.Active = req.Active == nil || *req.Active
+
+	created, err := s.CreateVolunteer(volunteer)
+	if errors.Is(err, s.ErrVolunteerUserNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if errors.Is(err, s.ErrVolunteerExists) {
+		return nil, response.Error(http.StatusConflict, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return created, response.EmptyError
+}
+
+// HandleUpdateVolunteer processes staff requests to replace the profile of a volunteer.
+// The volunteer user cannot be changed and user_id is ignored.
+//
+// Parameters:
+//   - id: Volunteer ID
+//   - orgID: Organisation of the acting staff member
+//   - req: VolunteerRequest with the new profile
+//
+// Returns:
+//   - *m.Volunteer: Updated volunteer
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleUpdateVolunteer(id uint, orgID uint, req r_models.VolunteerRequest) (*m.Volunteer, response.HTTPError) {
+	// Input validation
+	if id <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de voluntario no válido")
+	}
+
+	volunteer, msg := toVolunteer(r_models.VolunteerProfileRequest{
+		Phone:            req.Phone,
+		Bio:              req.Bio,
+		Skills:           req.Skills,
+		EmergencyContact: req.EmergencyContact,
+		EmergencyPhone:   req.EmergencyPhone,
+	})
+	if msg != "" {
+		return nil, response.Error(http.StatusBadRequest, msg)
+	}
+
+	volunteer.ID = id
+	volunteer.OrganizationID = orgID
+	volunteer.Notes = strings.TrimSpace(req.Notes)
+	volunteer.Active = req.Active == nil || *req.Active
+
+	updated, err := s.UpdateVolunteer(volunteer)
+	if errors.Is(err, s.ErrVolunteerNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return updated, response.EmptyError
+}
+
+// HandleDeleteVolunteer processes staff requests to delete a volunteer without signups.
+//
+// Parameters:
+//   - id: Volunteer ID
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleDeleteVolunteer(id uint, orgID uint) response.HTTPError {
+	// Input validation
+	if id <= 0 {
+		return response.Error(http.StatusBadRequest, "ID de voluntario no válido")
+	}
+
+	err := s.DeleteVolunteer(id, orgID)
+	if errors.Is(err, s.ErrVolunteerNotFound) {
+		return response.Error(http.StatusNotFound, err.Error())
+	}
+	if errors.Is(err, s.ErrVolunteerInUse) {
+		return response.Error(http.StatusConflict, err.Error())
+	}
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return response.EmptyError
+}
+
+// HandleAddVolunteerCertification processes staff requests to record a certification of a volunteer.
+//
+// Validation:
+// - Ensures the name is given and the texts do not exceed their limits
+// - Ensures the dates are valid YYYY-MM-DD values and the expiry is not before the issue date
+//
+// Parameters:
+//   - volunteerID: Volunteer ID
+//   - orgID: Organisation of the acting staff member
+//   - staffID: Authenticated staff user ID
+//   - req: VolunteerCertificationRequest with the certification
+//
+// Returns:
+//   - *m.Volunteer: Volunteer with the new certification
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleAddVolunteerCertification(volunteerID uint, orgID uint, staffID uint, req r_models.VolunteerCertificationRequest) (*m.Volunteer, response.HTTPError) {
+	// Input validation
+	if volunteerID <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de voluntario no válido")
+	}
+
+	certification := &m.VolunteerCertification{
+		VolunteerID: volunteerID,
+		Name:        strings.TrimSpace(req.Name),
+		IssuedBy:    strings.TrimSpace(req.IssuedBy),
+		CreatedBy:   staffID,
+	}
+
+	if certification.Name == "" || utf8.RuneCountInString(certification.Name) > 100 {
+		return nil, response.Error(http.StatusBadRequest, "name es obligatorio y no puede superar 100 caracteres")
+	}
+	if utf8.RuneCountInString(certification.IssuedBy) > 150 {
+		return nil, response.Error(http.StatusBadRequest, "issued_by no puede superar 150 caracteres")
+	}
+
+	var err error
+	if certification.IssuedOn, err = parseDate(req.IssuedOn); err != nil {
+		return nil, response.Error(http.StatusBadRequest, "issued_on inválida (formato YYYY-MM-DD)")
+	}
+	if certification.ExpiresOn, err = parseDate(req.ExpiresOn); err != nil {
+		return nil, response.Error(http.StatusBadRequest, "expires_on inválida (formato YYYY-MM-DD)")
+	}
+	if certification.IssuedOn != nil && certification.ExpiresOn != nil && certification.ExpiresOn.Before(*certification.IssuedOn) {
+		return nil, response.Error(http.StatusBadRequest, "expires_on no puede ser anterior a issued_on")
+	}
+
+	volunteer, err := s.AddVolunteerCertification(certification, orgID)
+	if errors.Is(err, s.ErrVolunteerNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return volunteer, response.EmptyError
+}
+
+// HandleDeleteVolunteerCertification processes staff requests to delete a certification of a volunteer.
+//
+// Parameters:
+//   - id: Certification ID
+//   - volunteerID: Volunteer ID
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleDeleteVolunteerCertification(id uint, volunteerID uint, orgID uint) response.HTTPError {
+	// Input validation
+	if id <= 0 || volunteerID <= 0 {
+		return response.Error(http.StatusBadRequest, "ID de certificación no válido")
+	}
+
+	err := s.DeleteVolunteerCertification(id, volunteerID, orgID)
+	if errors.Is(err, s.ErrVolunteerNotFound) || errors.Is(err, s.ErrVolunteerCertificationNotFound) {
+		return response.Error(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return response.EmptyError
+}
+
+// HandleListMyVolunteerProfiles processes requests to retrieve the current user's volunteer profiles.
+//
+// Parameters:
+//   - userID: Authenticated user ID
+//
+// Returns:
+//   - []m.Volunteer: Volunteer profiles of the user in every organisation
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleListMyVolunteerProfiles(userID uint) ([]m.Volunteer, response.HTTPError) {
+	volunteers, err := s.ListMyVolunteerProfiles(userID)
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return volunteers, response.EmptyError
+}
+
+// HandleUpdateMyVolunteerProfile processes volunteer requests to update their own profile.
+//
+// Parameters:
+//   - id: Volunteer profile ID
+//   - userID: Authenticated user ID
+//   - req: VolunteerProfileRequest with the contact details and skills
+//
+// Returns:
+//   - *m.Volunteer: Updated volunteer profile
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleUpdateMyVolunteerProfile(id uint, userID uint, req r_models.VolunteerProfileRequest) (*m.Volunteer, response.HTTPError) {
+	// Input validation
+	if id <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de voluntario no válido")
+	}
+
+	profile, msg := toVolunteer(req)
+	if msg != "" {
+		return nil, response.Error(http.StatusBadRequest, msg)
+	}
+	profile.ID = id
+
+	updated, err := s.UpdateMyVolunteerProfile(profile, userID)
+	if errors.Is(err, s.ErrVolunteerNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return updated, response.EmptyError
+}
+
+// ========================================
+// VOLUNTEER SHIFT HANDLERS
+// ========================================
+
+// HandleListVolunteerShifts processes staff requests to retrieve a page of shifts.
+//
+// Validation:
+// - Validates pagination, sorting and filter parameters (400 on invalid input)
+//
+// Parameters:
+//   - path: Request path used to build next/prev links
+//   - values: Raw query parameters
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - *query.Page[m.VolunteerShift]: Requested page of shifts
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleListVolunteerShifts(path string, values url.Values, orgID uint) (*query.Page[m.VolunteerShift], response.HTTPError) {
+	params, err := s.NewVolunteerShiftListQuery(path, values)
+	if err != nil {
+		return nil, response.Error(http.StatusBadRequest, err.Error())
+	}
+
+	shifts, err := s.ListVolunteerShifts(params, orgID)
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return shifts, response.EmptyError
+}
+
+// HandleGetVolunteerShift processes staff requests to retrieve a shift with its signups.
+//
+// Parameters:
+//   - id: Shift ID
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - *m.VolunteerShift: Shift with its signups
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleGetVolunteerShift(id uint, orgID uint) (*m.VolunteerShift, response.HTTPError) {
+	// Input validation
+	if id <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de turno no válido")
+	}
+
+	shift, err := s.GetVolunteerShift(id, orgID)
+	if errors.Is(err, s.ErrVolunteerShiftNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return shift, response.EmptyError
+}
+
+// HandleCreateVolunteerShift processes staff requests to publish a shift.
+//
+// Validation:
+// - Validates the shift fields (see toVolunteerShift)
+//
+// Parameters:
+//   - orgID: Organisation of the acting staff member
+//   - staffID: Authenticated staff user ID
+//   - req: VolunteerShiftRequest with the shift
+//
+// Returns:
+//   - *m.VolunteerShift: Created shift
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleCreateVolunteerShift(orgID uint, staffID uint, req r_models.VolunteerShiftRequest) (*m.VolunteerShift, response.HTTPError) {
+	// Input validation
+	shift, msg := toVolunteerShift(req)
+	if msg != "" {
+		return nil, response.Error(http.StatusBadRequest, msg)
+	}
+
+	shift.OrganizationID = orgID
+	shift.CreatedBy = staffID
+
+	created, err := s.CreateVolunteerShift(shift)
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return created, response.EmptyError
+}
+
+// HandleUpdateVolunteerShift processes staff requests to replace the details of a shift.
+//
+// Parameters:
+//   - id: Shift ID
+//   - orgID: Organisation of the acting staff member
+//   - req: VolunteerShiftRequest with the new details
+//
+// Returns:
+//   - *m.VolunteerShift: Updated shift
+//   - response.HTTPError: HTTP error or EmptyError on success (409 when the capacity is below the signups)
+func HandleUpdateVolunteerShift(id uint, orgID uint, req r_models.VolunteerShiftRequest) (*m.VolunteerShift, response.HTTPError) {
+	// Input validation
+	if id <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de turno no válido")
+	}
+
+	shift, msg := toVolunteerShift(req)
+	if msg != "" {
+		return nil, response.Error(http.StatusBadRequest, msg)
+	}
+
+	shift.ID = id
+	shift.OrganizationID = orgID
+
+	updated, err := s.UpdateVolunteerShift(shift)
+	if errors.Is(err, s.ErrVolunteerShiftNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if errors.Is(err, s.ErrVolunteerShiftCapacity) {
+		return nil, response.Error(http.StatusConflict, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return updated, response.EmptyError
+}
+
+// HandleDeleteVolunteerShift processes staff requests to delete a shift nobody is signed up for.
+//
+// Parameters:
+//   - id: Shift ID
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleDeleteVolunteerShift(id uint, orgID uint) response.HTTPError {
+	// Input validation
+	if id <= 0 {
+		return response.Error(http.StatusBadRequest, "ID de turno no válido")
+	}
+
+	err := s.DeleteVolunteerShift(id, orgID)
+	if errors.Is(err, s.ErrVolunteerShiftNotFound) {
+		return response.Error(http.StatusNotFound, err.Error())
+	}
+	if errors.Is(err, s.ErrVolunteerShiftInUse) {
+		return response.Error(http.StatusConflict, err.Error())
+	}
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return response.EmptyError
+}
+
+// ========================================
+// VOLUNTEER SIGNUP HANDLERS
+// ========================================
+
+// HandleListOpenVolunteerShifts processes requests to retrieve the shifts the current user can sign up for.
+//
+// Parameters:
+//   - userID: Authenticated user ID
+//
+// Returns:
+//   - []m.VolunteerShift: Upcoming shifts with free places or joined by the user
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleListOpenVolunteerShifts(userID uint) ([]m.VolunteerShift, response.HTTPError) {
+	shifts, err := s.ListOpenVolunteerShifts(userID, time.Now())
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return shifts, response.EmptyError
+}
+
+// HandleSignUpForShift processes volunteer requests to sign up for a shift.
+//
+// Parameters:
+//   - id: Shift ID
+//   - userID: Authenticated user ID
+//
+// Returns:
+//   - *m.VolunteerSignup: Active signup with the shift
+//   - response.HTTPError: HTTP error or EmptyError on success (403 when not eligible, 409 when full or already signed up)
+func HandleSignUpForShift(id uint, userID uint) (*m.VolunteerSignup, response.HTTPError) {
+	// Input validation
+	if id <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de turno no válido")
+	}
+
+	signup, err := s.SignUpForShift(id, userID, time.Now())
+	if errors.Is(err, s.ErrVolunteerShiftNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if errors.Is(err, s.ErrVolunteerNotEligible) {
+		return nil, response.Error(http.StatusForbidden, err.Error())
+	}
+	if errors.Is(err, s.ErrVolunteerShiftFull) || errors.Is(err, s.ErrVolunteerAlreadySignedUp) {
+		return nil, response.Error(http.StatusConflict, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return signup, response.EmptyError
+}
+
+// HandleCancelShiftSignup processes volunteer requests to cancel their signup in a shift.
+//
+// Parameters:
+//   - id: Shift ID
+//   - userID: Authenticated user ID
+//
+// Returns:
+//   - *m.VolunteerSignup: Cancelled signup with the shift
+//   - response.HTTPError: HTTP error or EmptyError on success (409 after the cancellation deadline)
+func HandleCancelShiftSignup(id uint, userID uint) (*m.VolunteerSignup, response.HTTPError) {
+	// Input validation
+	if id <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de turno no válido")
+	}
+
+	signup, err := s.CancelShiftSignup(id, userID, time.Now())
+	if errors.Is(err, s.ErrVolunteerShiftNotFound) || errors.Is(err, s.ErrVolunteerSignupNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if errors.Is(err, s.ErrVolunteerCancelDeadline) {
+		return nil, response.Error(http.StatusConflict, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return signup, response.EmptyError
+}
+
+// HandleListMyVolunteerSignups processes requests to retrieve the current user's shift signups.
+//
+// Parameters:
+//   - userID: Authenticated user ID
+//   - from: Earliest shift date (YYYY-MM-DD, default today)
+//
+// Returns:
+//   - []m.VolunteerSignup: Signups with their shift, earliest first
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleListMyVolunteerSignups(userID uint, from string) ([]m.VolunteerSignup, response.HTTPError) {
+	// Input validation
+	start, err := parseDate(from)
+	if err != nil {
+		return nil, response.Error(http.StatusBadRequest, "from inválida (formato YYYY-MM-DD)")
+	}
+	if start == nil {
+		now := time.Now()
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+		start = &today
+	}
+
+	signups, err := s.ListMyVolunteerSignups(userID, *start)
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return signups, response.EmptyError
+}
+
+// ========================================
+// VOLUNTEER ATTENDANCE HANDLERS
+// ========================================
+
+// HandleRecordVolunteerAttendance processes staff requests to record the attendance of a shift.
+//
+// Validation:
+// - Ensures 1 to 100 entries, each signup listed once
+// - Ensures each status is attended or no_show and minutes are only given for attended signups
+//
+// Parameters:
+//   - id: Shift ID
+//   - orgID: Organisation of the acting staff member
+//   - staffID: Authenticated staff user ID
+//   - req: AttendanceRequest with the attendance of each signup
+//
+// Returns:
+//   - *m.VolunteerShift: Shift with its updated signups
+//   - response.HTTPError: HTTP error or EmptyError on success (409 before the shift starts)
+func HandleRecordVolunteerAttendance(id uint, orgID uint, staffID uint, req r_models.AttendanceRequest) (*m.VolunteerShift, response.HTTPError) {
+	// Input validation
+	if id <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de turno no válido")
+	}
+
+	if len(req.Entries) == 0 || len(req.Entries) > 100 {
+		return nil, response.Error(http.StatusBadRequest, "entries debe contener entre 1 y 100 inscripciones")
+	}
+
+	seen := make(map[uint]bool, len(req.Entries))
+	entries := make([]m.VolunteerSignup, 0, len(req.Entries))
+	for _, entry := range req.Entries {
+		if entry.SignupID == 0 || seen[entry.SignupID] {
+			return nil, response.Error(http.StatusBadRequest, "cada entrada necesita un signup_id distinto")
+		}
+		seen[entry.SignupID] = true
+
+		status := strings.TrimSpace(entry.Status)
+		if status != m.SignupStatusAttended && status != m.SignupStatusNoShow {
+			return nil, response.Error(http.StatusBadRequest, "status debe ser attended o no_show")
+		}
+
+		if entry.MinutesWorked != nil {
+			if status != m.SignupStatusAttended {
+				return nil, response.Error(http.StatusBadRequest, "minutes_worked solo se admite con status attended")
+			}
+			if *entry.MinutesWorked < 1 || *entry.MinutesWorked > 24*60 {
+				return nil, response.Error(http.StatusBadRequest, "minutes_worked debe estar entre 1 y 1440")
+			}
+		}
+
+		entries = append(entries, m.VolunteerSignup{ID: entry.SignupID, Status: status, MinutesWorked: entry.MinutesWorked})
+	}
+
+	shift, err := s.RecordVolunteerAttendance(id, orgID, entries, staffID, time.Now())
+	if errors.Is(err, s.ErrVolunteerShiftNotFound) || errors.Is(err, s.ErrVolunteerSignupNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if errors.Is(err, s.ErrVolunteerShiftNotStarted) {
+		return nil, response.Error(http.StatusConflict, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return shift, response.EmptyError
+}
+
+// HandleGetVolunteerHours processes staff requests to retrieve the hours worked by each volunteer.
+//
+// Validation:
+// - Ensures the dates are valid YYYY-MM-DD values, in order and at most a year apart
+//
+// Parameters:
+//   - orgID: Organisation of the acting staff member
+//   - from: First day of the report (YYYY-MM-DD, default first day of the current month)
+//   - to: Last day of the report, included (YYYY-MM-DD, default today)
+//
+// Returns:
+//   - []m.VolunteerHours: Hours of each volunteer, most hours first
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleGetVolunteerHours(orgID uint, from string, to string) ([]m.VolunteerHours, response.HTTPError) {
+	// Input validation
+	start, err := parseDate(from)
+	if err != nil {
+		return nil, response.Error(http.StatusBadRequest, "from inválida (formato YYYY-MM-DD)")
+	}
+
+	end, err := parseDate(to)
+	if err != nil {
+		return nil, response.Error(http.StatusBadRequest, "to inválida (formato YYYY-MM-DD)")
+	}
+
+	now := time.Now()
+	if end == nil {
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+		end = &today
+	}
+	if start == nil {
+		first := time.Date(end.Year(), end.Month(), 1, 0, 0, 0, 0, time.Local)
+		start = &first
+	}
+
+	// The last day is included in the report
+	until := end.AddDate(0, 0, 1)
+	if !until.After(*start) || until.Sub(*start) > maxVolunteerHoursRange {
+		return nil, response.Error(http.StatusBadRequest, "to no puede ser anterior a from y el periodo no puede superar un año")
+	}
+
+	hours, err := s.GetVolunteerHoursReport(orgID, *start, until)
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return hours, response.EmptyError
+}
+
+// ========================================
+// VOLUNTEER HELPERS
+// ========================================
+
+// toVolunteer validates the fields of a volunteer profile editable by the volunteer.
+// It returns an error message, or "" if valid.
+func toVolunteer(req r_models.VolunteerProfileRequest) (*m.Volunteer, string) {
+	volunteer := &m.Volunteer{
+		Phone:            strings.TrimSpace(req.Phone),
+		Bio:              strings.TrimSpace(req.Bio),
+		EmergencyContact: strings.TrimSpace(req.EmergencyContact),
+		EmergencyPhone:   strings.TrimSpace(req.EmergencyPhone),
+	}
+
+	if utf8.RuneCountInString(volunteer.Phone) > 30 || utf8.RuneCountInString(volunteer.EmergencyPhone) > 30 {
+		return nil, "phone y emergency_phone no pueden superar 30 caracteres"
+	}
+
+	if utf8.RuneCountInString(volunteer.EmergencyContact) > 100 {
+		return nil, "emergency_contact no puede superar 100 caracteres"
+	}
+
+	if utf8.RuneCountInString(volunteer.Bio) > 2000 {
+		return nil, "bio no puede superar 2000 caracteres"
+	}
+
+	if len(req.Skills) > 20 {
+		return nil, "skills admite como máximo 20 habilidades"
+	}
+
+	volunteer.Skills = []string{}
+	for _, skill := range req.Skills {
+		skill = strings.TrimSpace(skill)
+		if skill == "" || utf8.RuneCountInString(skill) > 100 {
+			return nil, "las habilidades de skills no pueden estar vacías ni superar 100 caracteres"
+		}
+		if !slices.Contains(volunteer.Skills, skill) {
+			volunteer.Skills = append(volunteer.Skills, skill)
+		}
+	}
+
+	return volunteer, ""
+}
+
+// toVolunteerShift validates a shift request and converts it into a shift.
+// It returns an error message, or "" if valid.
+func toVolunteerShift(req r_models.VolunteerShiftRequest) (*m.VolunteerShift, string) {
+	start, err := time.Parse(time.RFC3339, strings.TrimSpace(req.StartTime))
+	if err != nil {
+		return nil, "start_time es obligatoria (formato RFC 3339)"
+	}
+
+	end, err := time.Parse(time.RFC3339, strings.TrimSpace(req.EndTime))
+	if err != nil {
+		return nil, "end_time es obligatoria (formato RFC 3339)"
+	}
+
+	if !start.After(time.Now()) {
+		return nil, "start_time debe ser una fecha futura"
+	}
+	if !end.After(start) || end.Sub(start) > maxVolunteerShiftDuration {
+		return nil, "end_time debe ser posterior a start_time y el turno no puede superar 12 horas"
+	}
+
+	shift := &m.VolunteerShift{
+		Title:                 strings.TrimSpace(req.Title),
+		Description:           strings.TrimSpace(req.Description),
+		RequiredSkill:         strings.TrimSpace(req.RequiredSkill),
+		RequiredCertification: strings.TrimSpace(req.RequiredCertification),
+		StartTime:             start,
+		EndTime:               end,
+		Location:              strings.TrimSpace(req.Location),
+		Capacity:              req.Capacity,
+	}
+
+	if shift.Title == "" || utf8.RuneCountInString(shift.Title) > 150 {
+		return nil, "title es obligatorio y no puede superar 150 caracteres"
+	}
+
+	if utf8.RuneCountInString(shift.RequiredSkill) > 100 || utf8.RuneCountInString(shift.RequiredCertification) > 100 {
+		return nil, "required_skill y required_certification no pueden superar 100 caracteres"
+	}
+
+	if utf8.RuneCountInString(shift.Location) > 255 {
+		return nil, "location no puede superar 255 caracteres"
+	}
+
+	if shift.Capacity < 1 || shift.Capacity > 100 {
+		return nil, "capacity debe estar entre 1 y 100"
+	}
+
+	return shift, ""
+}
@@ -0,0 +1,336 @@
+// Package handlers implements HTTP request handlers for the webhooks admin API.
+// This layer is responsible for:
+// - Validating webhook endpoints and event filters
+// - Calling appropriate service layer functions
+// - Converting service errors to HTTP responses
+package handlers
+
+import (
+	r_models "backend/internal/api/routes/models"
+	"backend/internal/db/query"
+	m "backend/internal/models"
+	s "backend/internal/services/backend_calls"
+	"backend/internal/utils/env"
+	response "backend/internal/utils/rest"
+	"errors"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// webhookAllowHTTP accepts plain http endpoints, for local testing (WEBHOOK_ALLOW_HTTP, default false).
+var webhookAllowHTTP = env.GetBool("WEBHOOK_ALLOW_HTTP", false)
+
+// ========================================
+// WEBHOOK HANDLERS
+// ========================================
+
+// HandleListWebhooks processes admin requests to list the registered webhooks.
+//
+// Returns:
+//   - []m.Webhook: Webhooks without their secrets
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleListWebhooks() ([]m.Webhook, response.HTTPError) {
+	webhooks, err := s.ListWebhooks()
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return webhooks, response.EmptyError
+}
+
+// HandleGetWebhook processes admin requests to retrieve a webhook.
+//
+// Parameters:
+//   - id: Webhook ID
+//
+// Returns:
+//   - *m.Webhook: Webhook without its secret
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleGetWebhook(id uint) (*m.Webhook, response.HTTPError) {
+	// Input validation
+	if id <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de webhook no válido")
+	}
+
+	webhook, err := s.GetWebhook(id)
+	if errors.Is(err, s.ErrWebhookNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return webhook, response.EmptyError
+}
+
+// HandleCreateWebhook processes admin requests to register a webhook.
+//
+// Validation:
+// - Validates the endpoint, event filter and organisation (see toWebhook)
+//
+// Parameters:
+//   - adminID: Authenticated admin user ID
+//   - req: WebhookRequest with the webhook settings
+//
+// Returns:
+//   - *m.Webhook: Created webhook with its signing secret
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleCreateWebhook(adminID uint, req r_models.WebhookRequest) (*m.Webhook, response.HTTPError) {
+	webhook, msg := toWebhook(req)
+	if msg != "" {
+		return nil, response.Error(http.StatusBadRequest, msg)
+	}
+
+	webhook.CreatedBy = adminID
+
+	created, err := s.CreateWebhook(webhook)
+	if errors.Is(err, s.ErrOrganizationNotFound) {
+		return nil, response.Error(http.StatusNotFound, s.ErrOrganizationNotFound.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return created, response.EmptyError
+}
+
+// HandleUpdateWebhook processes admin requests to replace the settings of a webhook.
+// The signing secret is kept; use HandleRotateWebhookSecret to replace it.
+//
+// Parameters:
+//   - id: Webhook ID
+//   - req: WebhookRequest with the new settings
+//
+// Returns:
+//   - *m.Webhook: Updated webhook
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleUpdateWebhook(id uint, req r_models.WebhookRequest) (*m.Webhook, response.HTTPError) {
+	// Input validation
+	if id <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de webhook no válido")
+	}
+
+	webhook, msg := toWebhook(req)
+	if msg != "" {
+		return nil, response.Error(http.StatusBadRequest, msg)
+	}
+
+	webhook.ID = id
+
+	updated, err := s.UpdateWebhook(webhook)
+	if errors.Is(err, s.ErrWebhookNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if errors.Is(err, s.ErrOrganizationNotFound) {
+		return nil, response.Error(http.StatusNotFound, s.ErrOrganizationNotFound.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return updated, response.EmptyError
+}
+
+// HandleRotateWebhookSecret processes admin requests to replace the signing secret of a webhook.
+//
+// Parameters:
+//   - id: Webhook ID
+//
+// Returns:
+//   - *m.Webhook: Webhook with its new signing secret
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleRotateWebhookSecret(id uint) (*m.Webhook, response.HTTPError) {
+	// Input validation
+	if id <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de webhook no válido")
+	}
+
+	webhook, err := s.RotateWebhookSecret(id)
+	if errors.Is(err, s.ErrWebhookNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return webhook, response.EmptyError
+}
+
+// HandleDeleteWebhook processes admin requests to delete a webhook and its delivery log.
+//
+// Parameters:
+//   - id: Webhook ID
+//
+// Returns:
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleDeleteWebhook(id uint) response.HTTPError {
+	// Input validation
+	if id <= 0 {
+		return response.Error(http.StatusBadRequest, "ID de webhook no válido")
+	}
+
+	err := s.DeleteWebhook(id)
+	if errors.Is(err, s.ErrWebhookNotFound) {
+		return response.Error(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return response.EmptyError
+}
+
+// ========================================
+// WEBHOOK DELIVERY HANDLERS
+// ========================================
+
+// HandleListWebhookDeliveries processes admin requests to retrieve a page of the delivery log of a webhook.
+//
+// Validation:
+// - Validates pagination, sorting and filter parameters (400 on invalid input)
+//
+// Parameters:
+//   - path: Request path used to build next/prev links
+//   - values: Raw query parameters
+//   - webhookID: Webhook ID
+//
+// Returns:
+//   - *query.Page[m.WebhookDelivery]: Requested page of deliveries
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleListWebhookDeliveries(path string, values url.Values, webhookID uint) (*query.Page[m.WebhookDelivery], response.HTTPError) {
+	params, err := s.NewWebhookDeliveryListQuery(path, values)
+	if err != nil {
+		return nil, response.Error(http.StatusBadRequest, err.Error())
+	}
+
+	deliveries, err := s.ListWebhookDeliveries(params, webhookID)
+	if errors.Is(err, s.ErrWebhookNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return deliveries, response.EmptyError
+}
+
+// HandleGetWebhookDelivery processes admin requests to retrieve a delivery with its attempts.
+//
+// Parameters:
+//   - webhookID: Webhook ID
+//   - id: Delivery ID
+//
+// Returns:
+//   - *m.WebhookDelivery: Delivery with its attempt log
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleGetWebhookDelivery(webhookID uint, id uint) (*m.WebhookDelivery, response.HTTPError) {
+	// Input validation
+	if id <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de entrega no válido")
+	}
+
+	delivery, err := s.GetWebhookDelivery(webhookID, id)
+	if errors.Is(err, s.ErrWebhookDeliveryNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return delivery, response.EmptyError
+}
+
+// HandleReplayWebhookDelivery processes admin requests to send a delivered or failed event again.
+//
+// Parameters:
+//   - webhookID: Webhook ID
+//   - id: Delivery ID to replay
+//
+// Returns:
+//   - *m.WebhookDelivery: New delivery with the outcome of its first attempt
+//   - response.HTTPError: HTTP error or EmptyError on success
+func HandleReplayWebhookDelivery(webhookID uint, id uint) (*m.WebhookDelivery, response.HTTPError) {
+	// Input validation
+	if id <= 0 {
+		return nil, response.Error(http.StatusBadRequest, "ID de entrega no válido")
+	}
+
+	delivery, err := s.ReplayWebhookDelivery(webhookID, id)
+	if errors.Is(err, s.ErrWebhookNotFound) || errors.Is(err, s.ErrWebhookDeliveryNotFound) {
+		return nil, response.Error(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return nil, response.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	return delivery, response.EmptyError
+}
+
+// ========================================
+// WEBHOOK HELPERS
+// ========================================
+
+// toWebhook validates a webhook request and converts it into a webhook.
+// It returns an error message, or "" if valid.
+func toWebhook(req r_models.WebhookRequest) (*m.Webhook, string) {
+	webhook := &m.Webhook{
+		URL:            strings.TrimSpace(req.URL),
+		Description:    strings.TrimSpace(req.Description),
+		OrganizationID: req.OrganizationID,
+		Active:         req.Active == nil || *req.Active,
+	}
+
+	if msg := validateWebhookURL(webhook.URL); msg != "" {
+		return nil, msg
+	}
+
+	if utf8.RuneCountInString(webhook.Description) > 255 {
+		return nil, "description no puede superar 255 caracteres"
+	}
+
+	if webhook.OrganizationID != nil && *webhook.OrganizationID == 0 {
+		return nil, "organization_id no válido"
+	}
+
+	if len(req.EventTypes) == 0 {
+		return nil, "event_types debe incluir al menos un tipo de evento"
+	}
+
+	webhook.EventTypes = []string{}
+	for _, eventType := range req.EventTypes {
+		eventType = strings.TrimSpace(eventType)
+		if !slices.Contains(m.WebhookEventTypes, eventType) {
+			return nil, "tipo de evento no válido: " + eventType + " (admitidos: " + strings.Join(m.WebhookEventTypes, ", ") + ")"
+		}
+		if !slices.Contains(webhook.EventTypes, eventType) {
+			webhook.EventTypes = append(webhook.EventTypes, eventType)
+		}
+	}
+
+	return webhook, ""
+}
+
+// validateWebhookURL checks that a webhook endpoint is an absolute https URL.
+// It returns an error message, or "" if valid.
+func validateWebhookURL(raw string) string {
+	if raw == "" {
+		return "url es obligatoria"
+	}
+	if len(raw) > 500 {
+		return "url no puede superar 500 caracteres"
+	}
+
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Host == "" || parsed.User != nil {
+		return "url debe ser una URL absoluta sin credenciales"
+	}
+
+	if parsed.Scheme != "https" && (parsed.Scheme != "http" || !webhookAllowHTTP) {
+		return "url debe usar https"
+	}
+
+	return ""
+}
@@ -0,0 +1,265 @@
+// Package api implements HTTP route handlers and endpoint registration for finalised adoptions.
+// This layer is responsible for:
+// - HTTP endpoint registration and routing for adoptions and their contracts
+// - Restricting adoption records and the contract template to the organisation's staff
+// - Streaming contract PDFs to the adopter and the organisation's staff
+package api
+
+import (
+	"backend/internal/api/handlers"
+	r_models "backend/internal/api/routes/models"
+	"backend/internal/services/contract"
+	response "backend/internal/utils/rest"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ========================================
+// ROUTE REGISTRATION
+// ========================================
+
+// RegisterAdoptionRoutes registers all adoption HTTP endpoints with the Echo router.
+//
+// Endpoint Organization:
+// - GET /api/adoptions: List adoptions (staff)
+// - POST /api/adoptions: Finalise an adoption and generate its contract (staff)
+// - GET /api/adoptions/:id: Get an adoption (staff)
+// - GET /api/adoptions/:id/contract: Download the contract PDF (adopter or staff)
+// - GET /api/adoptions/:id/contract/verify: Check the stored contract against its hash (staff)
+// - POST /api/adoptions/:id/contract/send: Email the contract to the adopter again (staff)
+// - GET /api/adoptions/contract-template: Get the contract template (staff)
+// - PUT /api/adoptions/contract-template: Change the contract template (managers)
+// - GET /api/users/me/adoptions: Adoptions of the current user
+//
+// Staff endpoints act on the organisation selected by requireOrganization.
+//
+// Parameters:
+//   - e: Echo router instance for endpoint registration
+func RegisterAdoptionRoutes(e *echo.Echo) {
+	e.GET("/api/adoptions", handleListAdoptions, requireSession, requireStaff, requireOrganization)
+	e.POST("/api/adoptions", handleFinalizeAdoption, requireSession, requireStaff, requireOrganization)
+	e.GET("/api/adoptions/:id", handleGetAdoption, requireSession, requireStaff, requireOrganization)
+	e.GET("/api/adoptions/:id/contract", handleGetAdoptionContract, requireSession)
+	e.GET("/api/adoptions/:id/contract/verify", handleVerifyAdoptionContract, requireSession, requireStaff, requireOrganization)
+	e.POST("/api/adoptions/:id/contract/send", handleResendAdoptionContract, requireSession, requireStaff, requireOrganization)
+
+	e.GET("/api/adoptions/contract-template", handleGetContractTemplate, requireSession, requireStaff, requireOrganization)
+	e.PUT("/api/adoptions/contract-template", handleSaveContractTemplate, requireSession, requireStaff, requireOrganization, requireOrgManager)
+
+	e.GET("/api/users/me/adoptions", handleListMyAdoptions, requireSession)
+}
+
+// ========================================
+// ADOPTION ROUTE HANDLERS
+// ========================================
+
+// handleListAdoptions processes staff requests to list adoptions.
+//
+// HTTP Method: GET
+// Endpoint: /api/adoptions
+//
+// Query Parameters:
+//   - page, page_size, cursor, sort: Pagination and sorting (see package query)
+//   - pet, adopter, from, to: Filters
+//
+// Response:
+//   - Success: Page of adoptions with pet and adopter summaries, most recent first by default
+//   - Error: HTTP error with appropriate status code
+func handleListAdoptions(c echo.Context) error {
+	adoptions, httpErr := handlers.HandleListAdoptions(c.Path(), c.QueryParams(), currentOrganizationID(c))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, adoptions)
+}
+
+// handleFinalizeAdoption processes staff requests to finalise an adoption.
+//
+// HTTP Method: POST
+// Endpoint: /api/adoptions
+// Content-Type: application/json
+//
+// Request Body:
+//   - See r_models.AdoptionRequest
+//
+// Response:
+//   - Success: Created adoption (the pet is marked adopted and the adopter is emailed the contract)
+//   - Error: 400 invalid data or template, 404 unknown pet or adopter, 409 pet already adopted
+func handleFinalizeAdoption(c echo.Context) error {
+	var req r_models.AdoptionRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "datos de adopción inválidos")
+	}
+
+	adoption, httpErr := handlers.HandleFinalizeAdoption(currentOrganizationID(c), currentUser(c).ID, req)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, adoption)
+}
+
+// handleGetAdoption processes staff requests to retrieve an adoption.
+//
+// HTTP Method: GET
+// Endpoint: /api/adoptions/:id
+//
+// Response:
+//   - Success: Adoption with pet and adopter summaries
+//   - Error: 404 unknown adoption
+func handleGetAdoption(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de adopción inválido")
+	}
+
+	adoption, httpErr := handlers.HandleGetAdoption(uint(id), currentOrganizationID(c))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, adoption)
+}
+
+// handleListMyAdoptions processes requests to list the adoptions of the current user.
+//
+// HTTP Method: GET
+// Endpoint: /api/users/me/adoptions
+//
+// Response:
+//   - Success: Adoptions with pet summaries and contract download links
+//   - Error: HTTP error with appropriate status code
+func handleListMyAdoptions(c echo.Context) error {
+	adoptions, httpErr := handlers.HandleListMyAdoptions(currentUser(c).ID)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, adoptions)
+}
+
+// ========================================
+// ADOPTION CONTRACT ROUTE HANDLERS
+// ========================================
+
+// handleGetAdoptionContract streams the contract PDF of an adoption to the client.
+//
+// HTTP Method: GET
+// Endpoint: /api/adoptions/:id/contract
+//
+// Response:
+//   - Success: PDF document as an attachment
+//   - Error: 404 unknown adoption, not visible to the user or without contract
+func handleGetAdoptionContract(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de adopción inválido")
+	}
+
+	content, filename, httpErr := handlers.HandleGetAdoptionContract(uint(id), currentUser(c))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+	defer content.Close()
+
+	// Contracts hold personal data of the adopter, so shared caches must not keep them
+	c.Response().Header().Set("Cache-Control", "private, no-store")
+	c.Response().Header().Set("X-Content-Type-Options", "nosniff")
+	c.Response().Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+
+	return c.Stream(http.StatusOK, contract.ContentType, content)
+}
+
+// handleVerifyAdoptionContract processes staff requests to check a stored contract against its hash.
+//
+// HTTP Method: GET
+// Endpoint: /api/adoptions/:id/contract/verify
+//
+// Response:
+//   - Success: Recorded and computed SHA-256 and whether they match
+//   - Error: 404 unknown adoption or without contract
+func handleVerifyAdoptionContract(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de adopción inválido")
+	}
+
+	check, httpErr := handlers.HandleVerifyAdoptionContract(uint(id), currentOrganizationID(c))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, check)
+}
+
+// handleResendAdoptionContract processes staff requests to email the contract to the adopter again.
+//
+// HTTP Method: POST
+// Endpoint: /api/adoptions/:id/contract/send
+//
+// Response:
+//   - Success: Adoption with the updated contract_sent_at
+//   - Error: 404 unknown adoption or without contract, 409 stored contract altered
+func handleResendAdoptionContract(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de adopción inválido")
+	}
+
+	adoption, httpErr := handlers.HandleResendAdoptionContract(uint(id), currentOrganizationID(c))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, adoption)
+}
+
+// ========================================
+// CONTRACT TEMPLATE ROUTE HANDLERS
+// ========================================
+
+// handleGetContractTemplate processes staff requests to retrieve the contract template.
+//
+// HTTP Method: GET
+// Endpoint: /api/adoptions/contract-template
+//
+// Response:
+//   - Success: Template in use (the default one, with id 0, if not configured)
+//   - Error: HTTP error with appropriate status code
+func handleGetContractTemplate(c echo.Context) error {
+	tmpl, httpErr := handlers.HandleGetContractTemplate(currentOrganizationID(c))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, tmpl)
+}
+
+// handleSaveContractTemplate processes manager requests to change the contract template.
+//
+// HTTP Method: PUT
+// Endpoint: /api/adoptions/contract-template
+// Content-Type: application/json
+//
+// Request Body:
+//   - See r_models.ContractTemplateRequest
+//
+// Response:
+//   - Success: Saved template (contracts already generated are not changed)
+//   - Error: 400 invalid text or template placeholder
+func handleSaveContractTemplate(c echo.Context) error {
+	var req r_models.ContractTemplateRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "datos de plantilla inválidos")
+	}
+
+	tmpl, httpErr := handlers.HandleSaveContractTemplate(currentOrganizationID(c), currentUser(c).ID, req)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, tmpl)
+}
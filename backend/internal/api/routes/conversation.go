@@ -0,0 +1,287 @@
+// Package api implements HTTP route handlers and endpoint registration for in-app messaging.
+// This layer is responsible for:
+// - HTTP endpoint registration and routing for conversations, messages and the staff inbox
+// - Restricting the shared inbox to the organisation's staff
+// - Multipart request parsing and request size limiting for message attachments
+// - Streaming attachments back to the participants of a conversation
+package api
+
+import (
+	"backend/internal/api/handlers"
+	r_models "backend/internal/api/routes/models"
+	s "backend/internal/services/backend_calls"
+	response "backend/internal/utils/rest"
+	"errors"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ========================================
+// ROUTE REGISTRATION
+// ========================================
+
+// RegisterConversationRoutes registers all messaging HTTP endpoints with the Echo router.
+//
+// Endpoint Organization:
+// - POST /api/conversations: Write to an organisation, about a pet or not (user)
+// - GET /api/users/me/conversations: Conversations of the current user with unread counts
+// - GET /api/conversations/:id: Read a conversation, marking the other side's messages as read (participants)
+// - POST /api/conversations/:id/messages: Write a message with attachments (multipart, participants)
+// - GET /api/conversations/:id/attachments/:attachmentId: Download an attachment (participants)
+// - GET /api/inbox: Shared inbox of the organisation (staff)
+// - POST /api/inbox: Write to a user (staff)
+// - PUT /api/inbox/:id: Change the status and assignee of a conversation (staff)
+//
+// Participants are the user of the conversation and the staff of its organisation.
+// Inbox endpoints act on the organisation selected by requireOrganization.
+//
+// Parameters:
+//   - e: Echo router instance for endpoint registration
+func RegisterConversationRoutes(e *echo.Echo) {
+	e.POST("/api/conversations", handleStartConversation, requireSession)
+	e.GET("/api/users/me/conversations", handleListMyConversations, requireSession)
+	e.GET("/api/conversations/:id", handleGetConversation, requireSession)
+	e.POST("/api/conversations/:id/messages", handlePostMessage, requireSession)
+	e.GET("/api/conversations/:id/attachments/:attachmentId", handleGetMessageAttachment, requireSession)
+
+	e.GET("/api/inbox", handleListInbox, requireSession, requireStaff, requireOrganization)
+	e.POST("/api/inbox", handleStartStaffConversation, requireSession, requireStaff, requireOrganization)
+	e.PUT("/api/inbox/:id", handleUpdateInboxConversation, requireSession, requireStaff, requireOrganization)
+}
+
+// ========================================
+// CONVERSATION ROUTE HANDLERS
+// ========================================
+
+// handleStartConversation processes requests from a user to write to an organisation.
+//
+// HTTP Method: POST
+// Endpoint: /api/conversations
+// Content-Type: application/json
+//
+// Request Body:
+//   - See r_models.ConversationRequest
+//
+// Response:
+//   - Success: Created conversation with its first message
+//   - Error: 400 invalid data, 404 unknown pet or organisation
+func handleStartConversation(c echo.Context) error {
+	var req r_models.ConversationRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "datos de conversación inválidos")
+	}
+
+	conversation, httpErr := handlers.HandleStartConversation(currentUser(c).ID, req)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, conversation)
+}
+
+// handleListMyConversations processes requests from a user to list their conversations.
+//
+// HTTP Method: GET
+// Endpoint: /api/users/me/conversations
+//
+// Response:
+//   - Success: Conversations with pet summaries and messages unread by the user, most recent activity first
+//   - Error: HTTP error with appropriate status code
+func handleListMyConversations(c echo.Context) error {
+	conversations, httpErr := handlers.HandleListUserConversations(currentUser(c).ID)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, conversations)
+}
+
+// handleGetConversation processes requests to read a conversation.
+//
+// HTTP Method: GET
+// Endpoint: /api/conversations/:id
+//
+// Response:
+//   - Success: Conversation with its messages, read receipts and attachment URLs
+//   - Error: 404 when the conversation does not exist or the caller does not take part
+func handleGetConversation(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de conversación inválido")
+	}
+
+	conversation, httpErr := handlers.HandleGetConversation(uint(id), currentUser(c))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, conversation)
+}
+
+// ========================================
+// MESSAGE ROUTE HANDLERS
+// ========================================
+
+// handlePostMessage processes requests to write a message in a conversation.
+//
+// HTTP Method: POST
+// Endpoint: /api/conversations/:id/messages
+// Content-Type: multipart/form-data
+//
+// Form Fields:
+//   - body: Message text
+//   - attachments: Up to 5 files (images or PDF)
+//
+// Response:
+//   - Success: Created message with attachment URLs
+//   - Error: 400 invalid data, 404 unknown conversation, 413 too large, 415 unsupported file type
+func handlePostMessage(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de conversación inválido")
+	}
+
+	// Limit the whole request body, leaving room for the form fields and multipart overhead
+	req := c.Request()
+	req.Body = http.MaxBytesReader(c.Response(), req.Body, handlers.MaxMessageAttachmentBytes*s.MaxMessageAttachments+1<<20)
+
+	form, err := c.MultipartForm()
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return response.ErrorResponse(c, http.StatusRequestEntityTooLarge, "los adjuntos superan el tamaño máximo permitido")
+		}
+		return response.ErrorResponse(c, http.StatusBadRequest, "se esperaba un formulario multipart")
+	}
+
+	var message r_models.MessageRequest
+	if err := c.Bind(&message); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "datos de mensaje inválidos")
+	}
+
+	created, httpErr := handlers.HandlePostMessage(uint(id), message, form.File["attachments"], currentUser(c))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, created)
+}
+
+// handleGetMessageAttachment streams an attachment of a conversation to the client.
+//
+// HTTP Method: GET
+// Endpoint: /api/conversations/:id/attachments/:attachmentId
+//
+// Response:
+//   - Success: Raw file bytes with the matching Content-Type, as a download
+//   - Error: 404 when the attachment does not exist or the caller does not take part
+func handleGetMessageAttachment(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de conversación inválido")
+	}
+
+	attachmentID, err := strconv.Atoi(c.Param("attachmentId"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de adjunto inválido")
+	}
+
+	content, attachment, httpErr := handlers.HandleGetMessageAttachment(uint(id), uint(attachmentID), currentUser(c))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+	defer content.Close()
+
+	// Attachments are private to the participants, so shared caches must not keep them;
+	// the file name comes from the uploader and is encoded by mime
+	c.Response().Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}))
+	c.Response().Header().Set("Cache-Control", "private, max-age=3600")
+	c.Response().Header().Set("X-Content-Type-Options", "nosniff")
+
+	return c.Stream(http.StatusOK, attachment.ContentType, content)
+}
+
+// ========================================
+// INBOX ROUTE HANDLERS
+// ========================================
+
+// handleListInbox processes staff requests to list the organisation's conversations.
+//
+// HTTP Method: GET
+// Endpoint: /api/inbox
+//
+// Query Parameters:
+//   - page, page_size, cursor, sort: Pagination and sorting (see package query)
+//   - status, assigned, unassigned, pet, user, application: Filters
+//
+// Response:
+//   - Success: Page of conversations with messages unread by staff
+//   - Error: HTTP error with appropriate status code
+func handleListInbox(c echo.Context) error {
+	conversations, httpErr := handlers.HandleListInbox(c.Path(), c.QueryParams(), currentOrganizationID(c))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, conversations)
+}
+
+// handleStartStaffConversation processes staff requests to write to a user.
+//
+// HTTP Method: POST
+// Endpoint: /api/inbox
+// Content-Type: application/json
+//
+// Request Body:
+//   - See r_models.StaffConversationRequest
+//
+// Response:
+//   - Success: Created conversation with its first message
+//   - Error: 400 invalid data, 404 unknown user or pet
+func handleStartStaffConversation(c echo.Context) error {
+	var req r_models.StaffConversationRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "datos de conversación inválidos")
+	}
+
+	conversation, httpErr := handlers.HandleStartStaffConversation(currentOrganizationID(c), currentUser(c).ID, req)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, conversation)
+}
+
+// handleUpdateInboxConversation processes staff requests to change the status and assignee of a conversation.
+//
+// HTTP Method: PUT
+// Endpoint: /api/inbox/:id
+// Content-Type: application/json
+//
+// Request Body:
+//   - See r_models.InboxUpdateRequest
+//
+// Response:
+//   - Success: Updated conversation
+//   - Error: 400 invalid data or assignee, 404 unknown conversation
+func handleUpdateInboxConversation(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de conversación inválido")
+	}
+
+	var req r_models.InboxUpdateRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "datos de conversación inválidos")
+	}
+
+	conversation, httpErr := handlers.HandleUpdateInboxConversation(uint(id), currentOrganizationID(c), req)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, conversation)
+}
@@ -0,0 +1,318 @@
+// Package api implements HTTP route handlers and endpoint registration for donations and pet sponsorships.
+// This layer is responsible for:
+// - HTTP endpoint registration and routing for donations, their charges and cancellation
+// - Restricting the organisation's donation records and receipt issuing to its staff
+// - Streaming donation receipt PDFs to the donor and the organisation's staff
+package api
+
+import (
+	"backend/internal/api/handlers"
+	r_models "backend/internal/api/routes/models"
+	"backend/internal/services/receipt"
+	response "backend/internal/utils/rest"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ========================================
+// ROUTE REGISTRATION
+// ========================================
+
+// RegisterDonationRoutes registers all donation and sponsorship HTTP endpoints with the Echo router.
+//
+// Endpoint Organization:
+// - POST /api/donations: Make a donation or sponsor a pet (any user)
+// - POST /api/donations/:id/checkout: Pay the pending charge of a donation (donor)
+// - POST /api/donations/:id/cancel: Cancel a donation (donor or staff)
+// - GET /api/donations/:id/payments: List the charges of a donation (donor or staff)
+// - GET /api/users/me/donations: Donations of the current user
+// - GET /api/donations: List donations (staff)
+// - GET /api/donations/:id: Get a donation (staff)
+// - GET /api/donations/receipts: List the receipts issued in a year (staff)
+// - POST /api/donations/receipts: Issue the receipts of a year (managers)
+// - GET /api/users/me/donation-receipts: Receipts of the current user
+// - GET /api/donation-receipts/:id: Download a receipt PDF (donor or staff)
+//
+// The public sponsorship total of a pet is part of GET /api/pets/:id.
+// Staff endpoints act on the organisation selected by requireOrganization.
+//
+// Parameters:
+//   - e: Echo router instance for endpoint registration
+func RegisterDonationRoutes(e *echo.Echo) {
+	e.POST("/api/donations", handleCreateDonation, requireSession)
+	e.POST("/api/donations/:id/checkout", handleStartDonationCheckout, requireSession)
+	e.POST("/api/donations/:id/cancel", handleCancelDonation, requireSession)
+	e.GET("/api/donations/:id/payments", handleListDonationPayments, requireSession)
+	e.GET("/api/users/me/donations", handleListMyDonations, requireSession)
+
+	e.GET("/api/donations", handleListDonations, requireSession, requireStaff, requireOrganization)
+	e.GET("/api/donations/:id", handleGetDonation, requireSession, requireStaff, requireOrganization)
+
+	e.GET("/api/donations/receipts", handleListDonationReceipts, requireSession, requireStaff, requireOrganization)
+	e.POST("/api/donations/receipts", handleGenerateDonationReceipts, requireSession, requireStaff, requireOrganization, requireOrgManager)
+	e.GET("/api/users/me/donation-receipts", handleListMyDonationReceipts, requireSession)
+	e.GET("/api/donation-receipts/:id", handleGetDonationReceipt, requireSession)
+}
+
+// ========================================
+// DONATION ROUTE HANDLERS
+// ========================================
+
+// handleCreateDonation processes requests to make a donation or sponsor a pet.
+//
+// HTTP Method: POST
+// Endpoint: /api/donations
+// Content-Type: application/json
+//
+// Request Body:
+//   - See r_models.DonationRequest
+//
+// Response:
+//   - Success: Pending donation; the client redirects the donor to its checkout_url
+//   - Error: 400 invalid data, 404 unknown organisation or pet, 502 provider error
+func handleCreateDonation(c echo.Context) error {
+	var req r_models.DonationRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "datos de donación inválidos")
+	}
+
+	donation, httpErr := handlers.HandleCreateDonation(currentUser(c), req)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, donation)
+}
+
+// handleStartDonationCheckout processes donor requests to pay the pending charge of a donation.
+//
+// HTTP Method: POST
+// Endpoint: /api/donations/:id/checkout
+//
+// Response:
+//   - Success: Donation; the client redirects the donor to its checkout_url
+//   - Error: 404 unknown donation or not the donor's, 409 finished or nothing due, 502 provider error
+func handleStartDonationCheckout(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de donación inválido")
+	}
+
+	donation, httpErr := handlers.HandleStartDonationCheckout(uint(id), currentUser(c))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, donation)
+}
+
+// handleCancelDonation processes requests to cancel a donation.
+//
+// HTTP Method: POST
+// Endpoint: /api/donations/:id/cancel
+//
+// Response:
+//   - Success: Cancelled donation (charges already paid are kept)
+//   - Error: 404 unknown donation or not visible to the user, 409 already finished
+func handleCancelDonation(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de donación inválido")
+	}
+
+	donation, httpErr := handlers.HandleCancelDonation(uint(id), currentUser(c))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, donation)
+}
+
+// handleListDonationPayments processes requests to list the charges of a donation.
+//
+// HTTP Method: GET
+// Endpoint: /api/donations/:id/payments
+//
+// Response:
+//   - Success: Payments of the donation with their refunds
+//   - Error: 404 unknown donation or not visible to the user
+func handleListDonationPayments(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de donación inválido")
+	}
+
+	payments, httpErr := handlers.HandleListDonationPayments(uint(id), currentUser(c))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, payments)
+}
+
+// handleListMyDonations processes requests to list the current user's donations.
+//
+// HTTP Method: GET
+// Endpoint: /api/users/me/donations
+//
+// Response:
+//   - Success: Donations with sponsored pet, paid amount and pending checkout
+//   - Error: HTTP error with appropriate status code
+func handleListMyDonations(c echo.Context) error {
+	donations, httpErr := handlers.HandleListMyDonations(currentUser(c).ID)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, donations)
+}
+
+// handleListDonations processes staff requests to list donations.
+//
+// HTTP Method: GET
+// Endpoint: /api/donations
+//
+// Query Parameters:
+//   - page, page_size, cursor, sort: Pagination and sorting (see package query)
+//   - status, frequency, pet, donor, from, to: Filters
+//
+// Response:
+//   - Success: Page of donations, most recent first by default
+//   - Error: HTTP error with appropriate status code
+func handleListDonations(c echo.Context) error {
+	donations, httpErr := handlers.HandleListDonations(c.Path(), c.QueryParams(), currentOrganizationID(c))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, donations)
+}
+
+// handleGetDonation processes staff requests to retrieve a donation.
+//
+// HTTP Method: GET
+// Endpoint: /api/donations/:id
+//
+// Response:
+//   - Success: Donation with donor and pet summaries
+//   - Error: 404 unknown donation
+func handleGetDonation(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de donación inválido")
+	}
+
+	donation, httpErr := handlers.HandleGetDonation(uint(id), currentOrganizationID(c))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, donation)
+}
+
+// ========================================
+// DONATION RECEIPT ROUTE HANDLERS
+// ========================================
+
+// handleListDonationReceipts processes staff requests to list the receipts issued in a year.
+//
+// HTTP Method: GET
+// Endpoint: /api/donations/receipts
+//
+// Query Parameters:
+//   - year: Year of the donations (default previous year)
+//
+// Response:
+//   - Success: Receipts issued by the organisation
+//   - Error: 400 invalid year
+func handleListDonationReceipts(c echo.Context) error {
+	year := 0
+	if value := c.QueryParam("year"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return response.ErrorResponse(c, http.StatusBadRequest, "year inválido")
+		}
+		year = parsed
+	}
+
+	receipts, httpErr := handlers.HandleListDonationReceipts(currentOrganizationID(c), year)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, receipts)
+}
+
+// handleGenerateDonationReceipts processes manager requests to issue the receipts of a year.
+// Donors that already have a receipt for the year are skipped.
+//
+// HTTP Method: POST
+// Endpoint: /api/donations/receipts
+// Content-Type: application/json
+//
+// Request Body:
+//   - See r_models.DonationReceiptsRequest
+//
+// Response:
+//   - Success: Receipts issued by the request (each one is emailed to its donor)
+//   - Error: 400 invalid year
+func handleGenerateDonationReceipts(c echo.Context) error {
+	var req r_models.DonationReceiptsRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "datos de certificados inválidos")
+	}
+
+	receipts, httpErr := handlers.HandleGenerateDonationReceipts(currentOrganizationID(c), req)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, receipts)
+}
+
+// handleListMyDonationReceipts processes requests to list the receipts issued to the current user.
+//
+// HTTP Method: GET
+// Endpoint: /api/users/me/donation-receipts
+//
+// Response:
+//   - Success: Receipts with their download URL, most recent year first
+//   - Error: HTTP error with appropriate status code
+func handleListMyDonationReceipts(c echo.Context) error {
+	receipts, httpErr := handlers.HandleListMyDonationReceipts(currentUser(c).ID)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, receipts)
+}
+
+// handleGetDonationReceipt streams the PDF of a donation receipt to the client.
+//
+// HTTP Method: GET
+// Endpoint: /api/donation-receipts/:id
+//
+// Response:
+//   - Success: application/pdf attachment
+//   - Error: 404 unknown receipt or not visible to the user
+func handleGetDonationReceipt(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de certificado inválido")
+	}
+
+	content, filename, httpErr := handlers.HandleGetDonationReceipt(uint(id), currentUser(c))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+	defer content.Close()
+
+	// Receipts hold personal and tax data of the donor, so shared caches must not keep them
+	c.Response().Header().Set("Cache-Control", "private, no-store")
+	c.Response().Header().Set("X-Content-Type-Options", "nosniff")
+	c.Response().Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+
+	return c.Stream(http.StatusOK, receipt.ContentType, content)
+}
@@ -0,0 +1,139 @@
+// Package api implements HTTP route handlers and endpoint registration for real-time updates.
+// This layer is responsible for:
+// - HTTP endpoint registration for the Server-Sent Events stream
+// - Writing events, heartbeats and reconnection hints in the text/event-stream format
+package api
+
+import (
+	"backend/internal/api/handlers"
+	"backend/internal/services/events"
+	response "backend/internal/utils/rest"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// eventRetryMillis is the reconnection delay suggested to clients, in milliseconds.
+const eventRetryMillis = 3000
+
+// ========================================
+// ROUTE REGISTRATION
+// ========================================
+
+// RegisterEventRoutes registers the real-time events HTTP endpoint with the Echo router.
+//
+// Endpoint Organization:
+// - GET /api/events: Server-Sent Events stream of the events the caller may see (optional session)
+//
+// Parameters:
+//   - e: Echo router instance for endpoint registration
+func RegisterEventRoutes(e *echo.Echo) {
+	e.GET("/api/events", handleEventStream, optionalSession)
+}
+
+// ========================================
+// EVENT ROUTE HANDLERS
+// ========================================
+
+// handleEventStream streams real-time events to the client until it disconnects.
+//
+// HTTP Method: GET
+// Endpoint: /api/events
+//
+// Request Headers:
+//   - Last-Event-ID: ID of the last event received, sent by EventSource when reconnecting
+//
+// Query Parameters:
+//   - last_event_id: Same as Last-Event-ID, for clients that cannot set headers
+//
+// Events:
+//   - pet-created, pet-status-changed: Public pet changes
+//   - application-updated: Adoption steps (staff of the organisation and the adopter)
+//   - new-message: Messages in conversations (staff of the organisation and the user)
+//   - notification: New entries of the notification centre (the user)
+//   - resync: Missed events are no longer available; the client must reload its data
+//
+// Behaviour:
+//   - Idle streams send a heartbeat comment every SSE_HEARTBEAT_INTERVAL
+//   - Streams end after SSE_MAX_DURATION, or when the client does not keep up; clients reconnect and resume
+//
+// Response:
+//   - Success: text/event-stream with one JSON payload per event
+//   - Error: HTTP error with appropriate status code
+func handleEventStream(c echo.Context) error {
+	lastEventID := c.Request().Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.QueryParam("last_event_id")
+	}
+
+	sub, missed, httpErr := handlers.HandleSubscribeEvents(currentUser(c), lastEventID)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+	defer handlers.HandleUnsubscribeEvents(sub)
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set("Cache-Control", "no-cache")
+	res.Header().Set("Connection", "keep-alive")
+	// Reverse proxies must not buffer the stream
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(http.StatusOK)
+
+	if _, err := fmt.Fprintf(res, "retry: %d\n\n", eventRetryMillis); err != nil {
+		return nil
+	}
+	for _, event := range missed {
+		if err := writeEvent(res, event); err != nil {
+			return nil
+		}
+	}
+	res.Flush()
+
+	heartbeat := time.NewTicker(events.HeartbeatInterval)
+	defer heartbeat.Stop()
+	deadline := time.NewTimer(events.MaxStreamDuration)
+	defer deadline.Stop()
+
+	ctx := c.Request().Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case <-deadline.C:
+			return nil
+
+		case event, ok := <-sub.Events():
+			if !ok {
+				// Dropped for not keeping up: the client reconnects with its Last-Event-ID
+				return nil
+			}
+			if err := writeEvent(res, event); err != nil {
+				return nil
+			}
+			res.Flush()
+
+		case <-heartbeat.C:
+			if _, err := io.WriteString(res, ": heartbeat\n\n"); err != nil {
+				return nil
+			}
+			res.Flush()
+		}
+	}
+}
+
+// writeEvent writes an event in the text/event-stream format.
+func writeEvent(w io.Writer, event events.Event) error {
+	data, err := json.Marshal(event.Data)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+	return err
+}
@@ -0,0 +1,410 @@
+// Package api implements HTTP route handlers and endpoint registration for the foster care programme.
+// This layer is responsible for:
+// - HTTP endpoint registration and routing for foster homes, placements and updates
+// - Restricting foster home and placement management to the organisation's staff
+// - Multipart request parsing and request size limiting for foster updates
+// - Streaming foster update photos back to clients
+package api
+
+import (
+	"backend/internal/api/handlers"
+	r_models "backend/internal/api/routes/models"
+	s "backend/internal/services/backend_calls"
+	response "backend/internal/utils/rest"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ========================================
+// ROUTE REGISTRATION
+// ========================================
+
+// RegisterFosterRoutes registers all foster care HTTP endpoints with the Echo router.
+//
+// Endpoint Organization:
+// - GET /api/foster/homes: List foster homes with their pets and free capacity (staff)
+// - POST /api/foster/homes: Register a user as a foster home (staff)
+// - GET /api/foster/homes/:id: Get a foster home (staff)
+// - PUT /api/foster/homes/:id: Replace the profile of a foster home (staff)
+// - DELETE /api/foster/homes/:id: Delete a foster home without placements (staff)
+// - GET /api/foster/placements: List placements, i.e. which pets live where (staff)
+// - POST /api/foster/placements: Place a pet in a foster home (staff)
+// - GET /api/foster/placements/:id: Get a placement (staff)
+// - PUT /api/foster/placements/:id: Replace the dates and notes of a placement, or end it (staff)
+// - GET /api/users/me/foster-placements: Pets in the care of the current user
+// - GET /api/foster/placements/:id/updates: Updates of a placement (foster or staff)
+// - POST /api/foster/placements/:id/updates: Post an update with photos (multipart, foster)
+// - GET /api/foster/updates/:id/photos/:photoId/:variant: Download an update photo (foster or staff)
+//
+// Staff endpoints act on the organisation selected by requireOrganization.
+//
+// Parameters:
+//   - e: Echo router instance for endpoint registration
+func RegisterFosterRoutes(e *echo.Echo) {
+	e.GET("/api/foster/homes", handleListFosterHomes, requireSession, requireStaff, requireOrganization)
+	e.POST("/api/foster/homes", handleCreateFosterHome, requireSession, requireStaff, requireOrganization)
+	e.GET("/api/foster/homes/:id", handleGetFosterHome, requireSession, requireStaff, requireOrganization)
+	e.PUT("/api/foster/homes/:id", handleUpdateFosterHome, requireSession, requireStaff, requireOrganization)
+	e.DELETE("/api/foster/homes/:id", handleDeleteFosterHome, requireSession, requireStaff, requireOrganization)
+
+	e.GET("/api/foster/placements", handleListFosterPlacements, requireSession, requireStaff, requireOrganization)
+	e.POST("/api/foster/placements", handleCreateFosterPlacement, requireSession, requireStaff, requireOrganization)
+	e.GET("/api/foster/placements/:id", handleGetFosterPlacement, requireSession, requireStaff, requireOrganization)
+	e.PUT("/api/foster/placements/:id", handleUpdateFosterPlacement, requireSession, requireStaff, requireOrganization)
+
+	e.GET("/api/users/me/foster-placements", handleListMyFosterPlacements, requireSession)
+	e.GET("/api/foster/placements/:id/updates", handleListFosterUpdates, requireSession)
+	e.POST("/api/foster/placements/:id/updates", handleCreateFosterUpdate, requireSession)
+	e.GET("/api/foster/updates/:id/photos/:photoId/:variant", handleGetFosterUpdatePhotoContent, requireSession)
+}
+
+// ========================================
+// FOSTER HOME ROUTE HANDLERS
+// ========================================
+
+// handleListFosterHomes processes staff requests to list foster homes.
+//
+// HTTP Method: GET
+// Endpoint: /api/foster/homes
+//
+// Query Parameters:
+//   - page, page_size, cursor, sort: Pagination and sorting (see package query)
+//   - species, home_type, active, has_capacity, user: Filters
+//
+// Response:
+//   - Success: Page of foster homes with their foster, current placements, occupancy and free capacity
+//   - Error: HTTP error with appropriate status code
+func handleListFosterHomes(c echo.Context) error {
+	homes, httpErr := handlers.HandleListFosterHomes(c.Path(), c.QueryParams(), currentOrganizationID(c))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, homes)
+}
+
+// handleCreateFosterHome processes staff requests to register a foster home.
+//
+// HTTP Method: POST
+// Endpoint: /api/foster/homes
+// Content-Type: application/json
+//
+// Request Body:
+//   - See r_models.FosterHomeRequest
+//
+// Response:
+//   - Success: Created foster home
+//   - Error: 400 invalid data, 404 unknown user, 409 user already fosters for the organisation
+func handleCreateFosterHome(c echo.Context) error {
+	var req r_models.FosterHomeRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "datos de casa de acogida inválidos")
+	}
+
+	home, httpErr := handlers.HandleCreateFosterHome(currentOrganizationID(c), req)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, home)
+}
+
+// handleGetFosterHome processes staff requests to retrieve a foster home.
+//
+// HTTP Method: GET
+// Endpoint: /api/foster/homes/:id
+//
+// Response:
+//   - Success: Foster home with its current placements and free capacity
+//   - Error: 404 when the home does not exist in the organisation
+func handleGetFosterHome(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de casa de acogida inválido")
+	}
+
+	home, httpErr := handlers.HandleGetFosterHome(uint(id), currentOrganizationID(c))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, home)
+}
+
+// handleUpdateFosterHome processes staff requests to replace the profile of a foster home.
+//
+// HTTP Method: PUT
+// Endpoint: /api/foster/homes/:id
+// Content-Type: application/json
+//
+// Request Body:
+//   - See r_models.FosterHomeRequest (user_id is ignored)
+//
+// Response:
+//   - Success: Updated foster home
+//   - Error: 400 invalid data, 404 unknown home
+func handleUpdateFosterHome(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de casa de acogida inválido")
+	}
+
+	var req r_models.FosterHomeRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "datos de casa de acogida inválidos")
+	}
+
+	home, httpErr := handlers.HandleUpdateFosterHome(uint(id), currentOrganizationID(c), req)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, home)
+}
+
+// handleDeleteFosterHome processes staff requests to delete a foster home.
+//
+// HTTP Method: DELETE
+// Endpoint: /api/foster/homes/:id
+//
+// Response:
+//   - Success: Deletion confirmation message
+//   - Error: 404 unknown home, 409 home with placements (deactivate it instead)
+func handleDeleteFosterHome(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de casa de acogida inválido")
+	}
+
+	httpErr := handlers.HandleDeleteFosterHome(uint(id), currentOrganizationID(c))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, map[string]string{"status": "deleted"})
+}
+
+// ========================================
+// FOSTER PLACEMENT ROUTE HANDLERS
+// ========================================
+
+// handleListFosterPlacements processes staff requests to list placements.
+//
+// HTTP Method: GET
+// Endpoint: /api/foster/placements
+//
+// Query Parameters:
+//   - page, page_size, cursor, sort: Pagination and sorting (see package query)
+//   - current, pet, foster_home, start_from, start_to: Filters
+//
+// Response:
+//   - Success: Page of placements with pet summaries
+//   - Error: HTTP error with appropriate status code
+func handleListFosterPlacements(c echo.Context) error {
+	placements, httpErr := handlers.HandleListFosterPlacements(c.Path(), c.QueryParams(), currentOrganizationID(c))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, placements)
+}
+
+// handleCreateFosterPlacement processes staff requests to place a pet in a foster home.
+//
+// HTTP Method: POST
+// Endpoint: /api/foster/placements
+// Content-Type: application/json
+//
+// Request Body:
+//   - See r_models.FosterPlacementRequest
+//
+// Response:
+//   - Success: Created placement
+//   - Error: 400 invalid data, 404 unknown pet or home, 409 placement not possible
+func handleCreateFosterPlacement(c echo.Context) error {
+	var req r_models.FosterPlacementRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "datos de acogida inválidos")
+	}
+
+	placement, httpErr := handlers.HandleCreateFosterPlacement(currentOrganizationID(c), currentUser(c).ID, req)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, placement)
+}
+
+// handleGetFosterPlacement processes staff requests to retrieve a placement.
+//
+// HTTP Method: GET
+// Endpoint: /api/foster/placements/:id
+//
+// Response:
+//   - Success: Placement with pet summary
+//   - Error: 404 when the placement does not exist in the organisation
+func handleGetFosterPlacement(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de acogida inválido")
+	}
+
+	placement, httpErr := handlers.HandleGetFosterPlacement(uint(id), currentOrganizationID(c))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, placement)
+}
+
+// handleUpdateFosterPlacement processes staff requests to replace the dates and notes of a placement.
+//
+// HTTP Method: PUT
+// Endpoint: /api/foster/placements/:id
+// Content-Type: application/json
+//
+// Request Body:
+//   - See r_models.UpdateFosterPlacementRequest (end_date ends the placement)
+//
+// Response:
+//   - Success: Updated placement
+//   - Error: 400 invalid dates, 404 unknown placement, 409 reopening a finished placement
+func handleUpdateFosterPlacement(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de acogida inválido")
+	}
+
+	var req r_models.UpdateFosterPlacementRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "datos de acogida inválidos")
+	}
+
+	placement, httpErr := handlers.HandleUpdateFosterPlacement(uint(id), currentOrganizationID(c), req)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, placement)
+}
+
+// handleListMyFosterPlacements processes requests from a foster to list the pets in their care.
+//
+// HTTP Method: GET
+// Endpoint: /api/users/me/foster-placements
+//
+// Response:
+//   - Success: Placements with pet summaries, current ones first (without internal notes)
+//   - Error: HTTP error with appropriate status code
+func handleListMyFosterPlacements(c echo.Context) error {
+	placements, httpErr := handlers.HandleListUserFosterPlacements(currentUser(c).ID)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, placements)
+}
+
+// ========================================
+// FOSTER UPDATE ROUTE HANDLERS
+// ========================================
+
+// handleListFosterUpdates processes requests to list the updates of a placement.
+//
+// HTTP Method: GET
+// Endpoint: /api/foster/placements/:id/updates
+//
+// Response:
+//   - Success: Updates with photo URLs, newest first
+//   - Error: 404 when the placement does not exist or the user is neither its foster nor staff of its organisation
+func handleListFosterUpdates(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de acogida inválido")
+	}
+
+	updates, httpErr := handlers.HandleListFosterUpdates(uint(id), currentUser(c))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, updates)
+}
+
+// handleCreateFosterUpdate processes requests from a foster to post news about a pet in their care.
+//
+// HTTP Method: POST
+// Endpoint: /api/foster/placements/:id/updates
+// Content-Type: multipart/form-data
+//
+// Form Fields:
+//   - message: News about the pet
+//   - photos: Up to 5 image files (JPEG, PNG, GIF or WebP)
+//
+// Response:
+//   - Success: Created update with photo URLs
+//   - Error: 400 invalid data, 404 not the foster of the placement, 409 finished placement,
+//     413 photo too large, 415 unsupported photo type
+func handleCreateFosterUpdate(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de acogida inválido")
+	}
+
+	// Limit the whole request body, leaving room for the form fields and multipart overhead
+	req := c.Request()
+	req.Body = http.MaxBytesReader(c.Response(), req.Body, handlers.MaxPhotoBytes*s.MaxFosterUpdatePhotos+1<<20)
+
+	form, err := c.MultipartForm()
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return response.ErrorResponse(c, http.StatusRequestEntityTooLarge, "las fotos superan el tamaño máximo permitido")
+		}
+		return response.ErrorResponse(c, http.StatusBadRequest, "se esperaba un formulario multipart")
+	}
+
+	var update r_models.FosterUpdateRequest
+	if err := c.Bind(&update); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "datos de novedad inválidos")
+	}
+
+	created, httpErr := handlers.HandleCreateFosterUpdate(uint(id), update, form.File["photos"], currentUser(c).ID)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, created)
+}
+
+// handleGetFosterUpdatePhotoContent streams a stored foster update photo variant to the client.
+//
+// HTTP Method: GET
+// Endpoint: /api/foster/updates/:id/photos/:photoId/:variant
+// Path Parameters:
+//   - variant: "original", "small", "medium" or "large"
+//
+// Response:
+//   - Success: Raw image bytes with the matching Content-Type
+//   - Error: HTTP error with appropriate status code
+func handleGetFosterUpdatePhotoContent(c echo.Context) error {
+	updateID, photoID, err := photoPathParams(c)
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de novedad o foto inválido")
+	}
+
+	content, contentType, httpErr := handlers.HandleGetFosterUpdatePhotoContent(updateID, photoID, c.Param("variant"), currentUser(c))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+	defer content.Close()
+
+	// Foster photos are only visible to the foster and staff, so shared caches must not keep them
+	c.Response().Header().Set("Cache-Control", "private, max-age=3600")
+	c.Response().Header().Set("X-Content-Type-Options", "nosniff")
+
+	return c.Stream(http.StatusOK, contentType, content)
+}
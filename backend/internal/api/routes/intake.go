@@ -0,0 +1,165 @@
+// Package api implements HTTP route handlers and endpoint registration for intake records.
+// This layer is responsible for:
+// - HTTP endpoint registration and routing for intakes and their statistics
+// - Restricting every endpoint to the organisation's staff
+package api
+
+import (
+	"backend/internal/api/handlers"
+	r_models "backend/internal/api/routes/models"
+	response "backend/internal/utils/rest"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ========================================
+// ROUTE REGISTRATION
+// ========================================
+
+// RegisterIntakeRoutes registers all intake HTTP endpoints with the Echo router.
+//
+// Endpoint Organization:
+// - GET /api/intakes: List intakes (staff)
+// - POST /api/intakes: Record the arrival of a pet, creating the pet (staff)
+// - GET /api/intakes/stats: Intakes and outcomes month by month (staff)
+// - GET /api/intakes/:id: Get an intake (staff)
+// - PUT /api/intakes/:id: Replace the details of an intake (staff)
+//
+// Every endpoint acts on the organisation selected by requireOrganization.
+//
+// Parameters:
+//   - e: Echo router instance for endpoint registration
+func RegisterIntakeRoutes(e *echo.Echo) {
+	e.GET("/api/intakes", handleListIntakes, requireSession, requireStaff, requireOrganization)
+	e.POST("/api/intakes", handleCreateIntake, requireSession, requireStaff, requireOrganization)
+	e.GET("/api/intakes/stats", handleGetIntakeStats, requireSession, requireStaff, requireOrganization)
+	e.GET("/api/intakes/:id", handleGetIntake, requireSession, requireStaff, requireOrganization)
+	e.PUT("/api/intakes/:id", handleUpdateIntake, requireSession, requireStaff, requireOrganization)
+}
+
+// ========================================
+// INTAKE ROUTE HANDLERS
+// ========================================
+
+// handleListIntakes processes staff requests to list intakes.
+//
+// HTTP Method: GET
+// Endpoint: /api/intakes
+//
+// Query Parameters:
+//   - page, page_size, cursor, sort: Pagination and sorting (see package query)
+//   - source, condition, pet, from, to: Filters
+//
+// Response:
+//   - Success: Page of intakes with pet summaries
+//   - Error: HTTP error with appropriate status code
+func handleListIntakes(c echo.Context) error {
+	intakes, httpErr := handlers.HandleListIntakes(c.Path(), c.QueryParams(), currentOrganizationID(c))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, intakes)
+}
+
+// handleCreateIntake processes staff requests to record the arrival of a pet.
+//
+// HTTP Method: POST
+// Endpoint: /api/intakes
+// Content-Type: application/json
+//
+// Request Body:
+//   - See r_models.IntakeRequest (pet is required)
+//
+// Response:
+//   - Success: Created intake with the new pet's summary
+//   - Error: 400 invalid data or microchip, 409 microchip assigned to another pet
+func handleCreateIntake(c echo.Context) error {
+	var req r_models.IntakeRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "datos de ingreso inválidos")
+	}
+
+	intake, httpErr := handlers.HandleCreateIntake(currentOrganizationID(c), currentUser(c).ID, req)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, intake)
+}
+
+// handleGetIntakeStats processes staff requests to compare intakes and outcomes.
+//
+// HTTP Method: GET
+// Endpoint: /api/intakes/stats
+//
+// Query Parameters:
+//   - from: First month (YYYY-MM, default 11 months before to)
+//   - to: Last month, included (YYYY-MM, default current month)
+//
+// Response:
+//   - Success: Intakes by source and outcomes by type for each month, with totals
+//   - Error: 400 invalid months or range longer than 36 months
+func handleGetIntakeStats(c echo.Context) error {
+	stats, httpErr := handlers.HandleGetIntakeStats(currentOrganizationID(c), c.QueryParam("from"), c.QueryParam("to"))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, stats)
+}
+
+// handleGetIntake processes staff requests to retrieve an intake.
+//
+// HTTP Method: GET
+// Endpoint: /api/intakes/:id
+//
+// Response:
+//   - Success: Intake with pet summary
+//   - Error: 404 when the intake does not exist in the organisation
+func handleGetIntake(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de ingreso inválido")
+	}
+
+	intake, httpErr := handlers.HandleGetIntake(uint(id), currentOrganizationID(c))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, intake)
+}
+
+// handleUpdateIntake processes staff requests to replace the details of an intake.
+//
+// HTTP Method: PUT
+// Endpoint: /api/intakes/:id
+// Content-Type: application/json
+//
+// Request Body:
+//   - See r_models.IntakeRequest (pet is ignored)
+//
+// Response:
+//   - Success: Updated intake
+//   - Error: 400 invalid data, 404 unknown intake
+func handleUpdateIntake(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de ingreso inválido")
+	}
+
+	var req r_models.IntakeRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "datos de ingreso inválidos")
+	}
+
+	intake, httpErr := handlers.HandleUpdateIntake(uint(id), currentOrganizationID(c), req)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, intake)
+}
@@ -0,0 +1,125 @@
+// Package api implements HTTP route handlers and endpoint registration for background jobs.
+// This layer is responsible for:
+// - HTTP endpoint registration and routing for the jobs admin API
+// - Restricting every endpoint to admins
+// - Parsing run options from the query string
+package api
+
+import (
+	"backend/internal/api/handlers"
+	response "backend/internal/utils/rest"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ========================================
+// ROUTE REGISTRATION
+// ========================================
+
+// RegisterJobRoutes registers all background job HTTP endpoints with the Echo router.
+// Every endpoint requires an admin session.
+//
+// Endpoint Organization:
+// - GET /api/admin/jobs: List jobs with their schedule and latest run
+// - GET /api/admin/jobs/:name/runs: Run history of a job
+// - POST /api/admin/jobs/:name/run: Run a job now (?dry_run=true to preview, ?force=true to repeat a period)
+//
+// Parameters:
+//   - e: Echo router instance for endpoint registration
+func RegisterJobRoutes(e *echo.Echo) {
+	e.GET("/api/admin/jobs", handleListJobs, requireSession, requireAdmin)
+	e.GET("/api/admin/jobs/:name/runs", handleListJobRuns, requireSession, requireAdmin)
+	e.POST("/api/admin/jobs/:name/run", handleTriggerJob, requireSession, requireAdmin)
+}
+
+// ========================================
+// BACKGROUND JOB ROUTE HANDLERS
+// ========================================
+
+// handleListJobs processes requests to list the background jobs.
+//
+// HTTP Method: GET
+// Endpoint: /api/admin/jobs
+//
+// Response:
+//   - Success: Array of jobs with name, schedule and latest run
+//   - Error: HTTP error with appropriate status code
+func handleListJobs(c echo.Context) error {
+	jobs, httpErr := handlers.HandleListJobs()
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, jobs)
+}
+
+// handleListJobRuns processes requests to retrieve the run history of a job.
+//
+// HTTP Method: GET
+// Endpoint: /api/admin/jobs/:name/runs
+//
+// Query Parameters:
+//   - limit: Maximum number of runs (default 20, max 100)
+//
+// Response:
+//   - Success: Array of runs, newest first
+//   - Error: HTTP error with appropriate status code
+func handleListJobRuns(c echo.Context) error {
+	limit := 0
+	if value := c.QueryParam("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return response.ErrorResponse(c, http.StatusBadRequest, "limit inválido")
+		}
+		limit = parsed
+	}
+
+	runs, httpErr := handlers.HandleListJobRuns(c.Param("name"), limit)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, runs)
+}
+
+// handleTriggerJob processes requests to run a job immediately.
+//
+// HTTP Method: POST
+// Endpoint: /api/admin/jobs/:name/run
+//
+// Query Parameters:
+//   - dry_run: true to compute the work without sending anything
+//   - force: true to run even if the current period was already processed
+//
+// Response:
+//   - Success: Recorded run with status, items, summary and, for dry runs, the preview
+//   - Error: 404 unknown job, 409 period already processed
+func handleTriggerJob(c echo.Context) error {
+	dryRun, err := parseOptionalBool(c.QueryParam("dry_run"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "dry_run debe ser true o false")
+	}
+
+	force, err := parseOptionalBool(c.QueryParam("force"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "force debe ser true o false")
+	}
+
+	run, httpErr := handlers.HandleTriggerJob(c.Param("name"), dryRun, force)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, run)
+}
+
+// parseOptionalBool parses a boolean query parameter; empty values are false.
+func parseOptionalBool(value string) (bool, error) {
+	if value == "" {
+		return false, nil
+	}
+
+	return strconv.ParseBool(value)
+}
@@ -0,0 +1,288 @@
+// Package api implements HTTP route handlers and endpoint registration for shelter locations.
+// This layer is responsible for:
+// - HTTP endpoint registration and routing for buildings, rooms and kennels
+// - Moving pets between kennels and reading their housing history
+// - Restricting every endpoint to the organisation's staff
+package api
+
+import (
+	"backend/internal/api/handlers"
+	r_models "backend/internal/api/routes/models"
+	response "backend/internal/utils/rest"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ========================================
+// ROUTE REGISTRATION
+// ========================================
+
+// RegisterLocationRoutes registers all shelter location HTTP endpoints with the Echo router.
+//
+// Endpoint Organization:
+// - GET /api/locations: List buildings, rooms and kennels with their occupancy (staff)
+// - POST /api/locations: Add a building, room or kennel (staff)
+// - GET /api/locations/occupancy: Free places and capacity warning for an intake (staff)
+// - GET /api/locations/:id: Get a location, with its pets for kennels (staff)
+// - PUT /api/locations/:id: Replace the data of a location (staff)
+// - DELETE /api/locations/:id: Delete an empty location without history (staff)
+// - PUT /api/pets/:id/location: House a pet in a kennel (staff)
+// - DELETE /api/pets/:id/location: Take a pet out of its kennel (staff)
+// - GET /api/pets/:id/location-history: Kennels where a pet has been housed (staff)
+//
+// Every endpoint acts on the organisation selected by requireOrganization.
+//
+// Parameters:
+//   - e: Echo router instance for endpoint registration
+func RegisterLocationRoutes(e *echo.Echo) {
+	e.GET("/api/locations", handleListLocations, requireSession, requireStaff, requireOrganization)
+	e.POST("/api/locations", handleCreateLocation, requireSession, requireStaff, requireOrganization)
+	e.GET("/api/locations/occupancy", handleGetOccupancy, requireSession, requireStaff, requireOrganization)
+	e.GET("/api/locations/:id", handleGetLocation, requireSession, requireStaff, requireOrganization)
+	e.PUT("/api/locations/:id", handleUpdateLocation, requireSession, requireStaff, requireOrganization)
+	e.DELETE("/api/locations/:id", handleDeleteLocation, requireSession, requireStaff, requireOrganization)
+
+	e.PUT("/api/pets/:id/location", handleMovePet, requireSession, requireStaff, requireOrganization)
+	e.DELETE("/api/pets/:id/location", handleReleasePetLocation, requireSession, requireStaff, requireOrganization)
+	e.GET("/api/pets/:id/location-history", handleGetPetLocationHistory, requireSession, requireStaff, requireOrganization)
+}
+
+// ========================================
+// LOCATION ROUTE HANDLERS
+// ========================================
+
+// handleListLocations processes staff requests to list locations.
+//
+// HTTP Method: GET
+// Endpoint: /api/locations
+//
+// Query Parameters:
+//   - page, page_size, cursor, sort: Pagination and sorting (see package query)
+//   - kind, parent, active, species: Filters
+//
+// Response:
+//   - Success: Page of locations with their path, capacity, occupancy and free places
+//   - Error: HTTP error with appropriate status code
+func handleListLocations(c echo.Context) error {
+	locations, httpErr := handlers.HandleListLocations(c.Path(), c.QueryParams(), currentOrganizationID(c))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, locations)
+}
+
+// handleCreateLocation processes staff requests to add a location.
+//
+// HTTP Method: POST
+// Endpoint: /api/locations
+// Content-Type: application/json
+//
+// Request Body:
+//   - See r_models.LocationRequest
+//
+// Response:
+//   - Success: Created location
+//   - Error: 400 invalid data or parent
+func handleCreateLocation(c echo.Context) error {
+	var req r_models.LocationRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "datos de ubicación inválidos")
+	}
+
+	location, httpErr := handlers.HandleCreateLocation(currentOrganizationID(c), req)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, location)
+}
+
+// handleGetOccupancy processes staff requests to check the free kennel places before an intake.
+//
+// HTTP Method: GET
+// Endpoint: /api/locations/occupancy
+//
+// Query Parameters:
+//   - species: Species of the intake (optional)
+//   - incoming: Number of pets about to arrive (default 0)
+//
+// Response:
+//   - Success: Capacity, occupancy and free places, with a warning when the intake does not fit
+//   - Error: 400 invalid incoming
+func handleGetOccupancy(c echo.Context) error {
+	incoming := 0
+	if value := c.QueryParam("incoming"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return response.ErrorResponse(c, http.StatusBadRequest, "incoming inválido")
+		}
+		incoming = parsed
+	}
+
+	report, httpErr := handlers.HandleGetOccupancy(currentOrganizationID(c), c.QueryParam("species"), incoming)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, report)
+}
+
+// handleGetLocation processes staff requests to retrieve a location.
+//
+// HTTP Method: GET
+// Endpoint: /api/locations/:id
+//
+// Response:
+//   - Success: Location with its occupancy and, for kennels, the pets housed
+//   - Error: 404 when the location does not exist in the organisation
+func handleGetLocation(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de ubicación inválido")
+	}
+
+	location, httpErr := handlers.HandleGetLocation(uint(id), currentOrganizationID(c))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, location)
+}
+
+// handleUpdateLocation processes staff requests to replace the data of a location.
+//
+// HTTP Method: PUT
+// Endpoint: /api/locations/:id
+// Content-Type: application/json
+//
+// Request Body:
+//   - See r_models.LocationRequest (kind and parent_id are ignored)
+//
+// Response:
+//   - Success: Updated location
+//   - Error: 400 invalid data, 404 unknown location
+func handleUpdateLocation(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de ubicación inválido")
+	}
+
+	var req r_models.LocationRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "datos de ubicación inválidos")
+	}
+
+	location, httpErr := handlers.HandleUpdateLocation(uint(id), currentOrganizationID(c), req)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, location)
+}
+
+// handleDeleteLocation processes staff requests to delete a location.
+//
+// HTTP Method: DELETE
+// Endpoint: /api/locations/:id
+//
+// Response:
+//   - Success: Deletion confirmation message
+//   - Error: 404 unknown location, 409 location with child locations or history (deactivate it instead)
+func handleDeleteLocation(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de ubicación inválido")
+	}
+
+	httpErr := handlers.HandleDeleteLocation(uint(id), currentOrganizationID(c))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, map[string]string{"status": "deleted"})
+}
+
+// ========================================
+// PET HOUSING ROUTE HANDLERS
+// ========================================
+
+// handleMovePet processes staff requests to house a pet in a kennel.
+//
+// HTTP Method: PUT
+// Endpoint: /api/pets/:id/location
+// Content-Type: application/json
+//
+// Request Body:
+//   - See r_models.PetMoveRequest
+//
+// Response:
+//   - Success: New stay of the pet with the kennel path
+//   - Error: 400 invalid data, 404 unknown pet or kennel, 409 kennel full, inactive or not accepting the species
+func handleMovePet(c echo.Context) error {
+	petID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de mascota inválido")
+	}
+
+	var req r_models.PetMoveRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "datos de traslado inválidos")
+	}
+
+	stay, httpErr := handlers.HandleMovePet(uint(petID), currentOrganizationID(c), currentUser(c).ID, req)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, stay)
+}
+
+// handleReleasePetLocation processes staff requests to take a pet out of its kennel.
+//
+// HTTP Method: DELETE
+// Endpoint: /api/pets/:id/location
+//
+// Query Parameters:
+//   - reason: Why the pet left the kennel (optional, default "salida")
+//
+// Response:
+//   - Success: Confirmation message
+//   - Error: 404 unknown pet or pet not housed
+func handleReleasePetLocation(c echo.Context) error {
+	petID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de mascota inválido")
+	}
+
+	httpErr := handlers.HandleReleasePetLocation(uint(petID), currentOrganizationID(c), c.QueryParam("reason"))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, map[string]string{"status": "released"})
+}
+
+// handleGetPetLocationHistory processes staff requests to retrieve the housing history of a pet.
+//
+// HTTP Method: GET
+// Endpoint: /api/pets/:id/location-history
+//
+// Response:
+//   - Success: Stays of the pet with the kennel path, most recent first
+//   - Error: 404 unknown pet
+func handleGetPetLocationHistory(c echo.Context) error {
+	petID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de mascota inválido")
+	}
+
+	stays, httpErr := handlers.HandleGetPetLocationHistory(uint(petID), currentOrganizationID(c))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, stays)
+}
@@ -0,0 +1,242 @@
+// Package api implements HTTP route handlers and endpoint registration for lost and found reports.
+// This layer is responsible for:
+// - HTTP endpoint registration and routing for lost and found operations
+// - Multipart request parsing and request size limiting for submissions
+// - Restricting moderation and candidate matches to staff
+// - Streaming report photos back to clients
+package api
+
+import (
+	"backend/internal/api/handlers"
+	r_models "backend/internal/api/routes/models"
+	s "backend/internal/services/backend_calls"
+	response "backend/internal/utils/rest"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ========================================
+// ROUTE REGISTRATION
+// ========================================
+
+// RegisterLostFoundRoutes registers all lost and found HTTP endpoints with the Echo router.
+//
+// Endpoint Organization:
+// - GET /api/lost-found/reports: List reports (public: approved only)
+// - POST /api/lost-found/reports: Submit a report (multipart, public)
+// - GET /api/lost-found/reports/:id: Get a report (public: approved only)
+// - PUT /api/lost-found/reports/:id/moderation: Approve, reject or resolve a report (staff)
+// - DELETE /api/lost-found/reports/:id: Delete a report (staff)
+// - GET /api/lost-found/reports/:id/matches: Candidate shelter pets (staff)
+// - GET /api/lost-found/reports/:id/photos/:photoId/:variant: Download a report photo
+//
+// Public endpoints accept an optional session: staff see every report with contact
+// details, and signed-in reporters get their report linked to their account.
+//
+// Parameters:
+//   - e: Echo router instance for endpoint registration
+func RegisterLostFoundRoutes(e *echo.Echo) {
+	e.GET("/api/lost-found/reports", handleListLostFoundReports, optionalSession)
+	e.POST("/api/lost-found/reports", handleCreateLostFoundReport, optionalSession)
+	e.GET("/api/lost-found/reports/:id", handleGetLostFoundReport, optionalSession)
+	e.PUT("/api/lost-found/reports/:id/moderation", handleModerateLostFoundReport, requireSession, requireStaff)
+	e.DELETE("/api/lost-found/reports/:id", handleDeleteLostFoundReport, requireSession, requireStaff)
+	e.GET("/api/lost-found/reports/:id/matches", handleListLostFoundMatches, requireSession, requireStaff)
+	e.GET("/api/lost-found/reports/:id/photos/:photoId/:variant", handleGetLostFoundPhotoContent, optionalSession)
+}
+
+// ========================================
+// LOST AND FOUND ROUTE HANDLERS
+// ========================================
+
+// handleListLostFoundReports processes requests to list lost and found reports.
+//
+// HTTP Method: GET
+// Endpoint: /api/lost-found/reports
+//
+// Query Parameters:
+//   - page, page_size, cursor, sort: Pagination and sorting (see package query)
+//   - type, species, near (lat,lng,radius_km), seen_from, seen_to: Filters
+//   - status: Moderation status filter (staff only)
+//
+// Response:
+//   - Success: Page of reports with total count and next/prev links
+//   - Error: HTTP error with appropriate status code
+func handleListLostFoundReports(c echo.Context) error {
+	reports, httpErr := handlers.HandleListLostFoundReports(c.Path(), c.QueryParams(), currentUser(c))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, reports)
+}
+
+// handleCreateLostFoundReport processes public lost or found report submissions.
+//
+// HTTP Method: POST
+// Endpoint: /api/lost-found/reports
+// Content-Type: multipart/form-data
+//
+// Form Fields:
+//   - See r_models.LostFoundReportRequest
+//   - photos: Up to 5 image files (JPEG, PNG, GIF or WebP)
+//
+// Response:
+//   - Success: Created report, pending moderation
+//   - Error: 400 invalid data, 413 photo too large, 415 unsupported photo type
+func handleCreateLostFoundReport(c echo.Context) error {
+	// Limit the whole request body, leaving room for the form fields and multipart overhead
+	req := c.Request()
+	req.Body = http.MaxBytesReader(c.Response(), req.Body, handlers.MaxPhotoBytes*s.MaxLostFoundPhotos+1<<20)
+
+	form, err := c.MultipartForm()
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return response.ErrorResponse(c, http.StatusRequestEntityTooLarge, "las fotos superan el tamaño máximo permitido")
+		}
+		return response.ErrorResponse(c, http.StatusBadRequest, "se esperaba un formulario multipart")
+	}
+
+	var report r_models.LostFoundReportRequest
+	if err := c.Bind(&report); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "datos de aviso inválidos")
+	}
+
+	created, httpErr := handlers.HandleCreateLostFoundReport(report, form.File["photos"], currentUser(c))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, created)
+}
+
+// handleGetLostFoundReport processes requests to retrieve a lost or found report.
+//
+// HTTP Method: GET
+// Endpoint: /api/lost-found/reports/:id
+//
+// Response:
+//   - Success: Report with photo URLs (contact details for staff only)
+//   - Error: 404 when the report does not exist or is not public
+func handleGetLostFoundReport(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de aviso inválido")
+	}
+
+	report, httpErr := handlers.HandleGetLostFoundReport(uint(id), currentUser(c))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, report)
+}
+
+// handleModerateLostFoundReport processes staff moderation requests.
+//
+// HTTP Method: PUT
+// Endpoint: /api/lost-found/reports/:id/moderation
+// Content-Type: application/json
+//
+// Request Body:
+//   - status: pending, approved, rejected or resolved
+//   - note: Internal moderation note (optional)
+//
+// Response:
+//   - Success: Moderated report (approving starts matching against shelter pets)
+//   - Error: HTTP error with appropriate status code
+func handleModerateLostFoundReport(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de aviso inválido")
+	}
+
+	var req r_models.ModerateLostFoundRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "datos de moderación inválidos")
+	}
+
+	report, httpErr := handlers.HandleModerateLostFoundReport(uint(id), req, currentUser(c).ID)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, report)
+}
+
+// handleDeleteLostFoundReport processes staff requests to delete a report.
+//
+// HTTP Method: DELETE
+// Endpoint: /api/lost-found/reports/:id
+//
+// Response:
+//   - Success: Deletion confirmation message
+//   - Error: HTTP error with appropriate status code
+func handleDeleteLostFoundReport(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de aviso inválido")
+	}
+
+	httpErr := handlers.HandleDeleteLostFoundReport(uint(id))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, map[string]string{"status": "deleted"})
+}
+
+// handleListLostFoundMatches processes staff requests to list the candidate pets of a report.
+//
+// HTTP Method: GET
+// Endpoint: /api/lost-found/reports/:id/matches
+//
+// Response:
+//   - Success: Matches with score, matching criteria and pet summary
+//   - Error: HTTP error with appropriate status code
+func handleListLostFoundMatches(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de aviso inválido")
+	}
+
+	matches, httpErr := handlers.HandleListLostFoundMatches(uint(id))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, matches)
+}
+
+// handleGetLostFoundPhotoContent streams a stored report photo variant to the client.
+//
+// HTTP Method: GET
+// Endpoint: /api/lost-found/reports/:id/photos/:photoId/:variant
+// Path Parameters:
+//   - variant: "original", "small", "medium" or "large"
+//
+// Response:
+//   - Success: Raw image bytes with the matching Content-Type
+//   - Error: HTTP error with appropriate status code
+func handleGetLostFoundPhotoContent(c echo.Context) error {
+	reportID, photoID, err := photoPathParams(c)
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de aviso o foto inválido")
+	}
+
+	content, contentType, httpErr := handlers.HandleGetLostFoundPhotoContent(reportID, photoID, c.Param("variant"), currentUser(c))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+	defer content.Close()
+
+	// Photos of reports pending moderation must not be kept by shared caches
+	c.Response().Header().Set("Cache-Control", "private, max-age=3600")
+	c.Response().Header().Set("X-Content-Type-Options", "nosniff")
+
+	return c.Stream(http.StatusOK, contentType, content)
+}
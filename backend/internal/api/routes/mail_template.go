@@ -0,0 +1,77 @@
+// Package api implements HTTP route handlers and endpoint registration for email templates.
+// This layer is responsible for:
+// - HTTP endpoint registration and routing for the email template admin API
+// - Restricting every endpoint to admins
+// - Request parameter extraction
+package api
+
+import (
+	"backend/internal/api/handlers"
+	response "backend/internal/utils/rest"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ========================================
+// ROUTE REGISTRATION
+// ========================================
+
+// RegisterMailTemplateRoutes registers all email template HTTP endpoints with the Echo router.
+// Every endpoint requires an admin session.
+//
+// Endpoint Organization:
+// - GET /api/admin/mail/templates: List the email templates and their locales
+// - GET /api/admin/mail/templates/:name/preview: Render a template with sample data
+//
+// Parameters:
+//   - e: Echo router instance for endpoint registration
+func RegisterMailTemplateRoutes(e *echo.Echo) {
+	e.GET("/api/admin/mail/templates", handleListMailTemplates, requireSession, requireAdmin)
+	e.GET("/api/admin/mail/templates/:name/preview", handlePreviewMailTemplate, requireSession, requireAdmin)
+}
+
+// ========================================
+// MAIL TEMPLATE ROUTE HANDLERS
+// ========================================
+
+// handleListMailTemplates processes admin requests to list the email templates.
+//
+// HTTP Method: GET
+// Endpoint: /api/admin/mail/templates
+//
+// Response:
+//   - Success: Templates with the locales they are available in
+func handleListMailTemplates(c echo.Context) error {
+	templates, httpErr := handlers.HandleListMailTemplates()
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, templates)
+}
+
+// handlePreviewMailTemplate processes admin requests to render an email template with sample data.
+//
+// HTTP Method: GET
+// Endpoint: /api/admin/mail/templates/:name/preview
+//
+// Query Parameters:
+//   - locale: Locale to render (es, en, ca; default es)
+//   - format: "html" returns the HTML body as a page to open in the browser (default: JSON with subject, text and html)
+//
+// Response:
+//   - Success: Rendered subject and bodies, or the HTML page
+//   - Error: 404 unknown template, 400 unsupported locale
+func handlePreviewMailTemplate(c echo.Context) error {
+	rendered, httpErr := handlers.HandlePreviewMailTemplate(c.Param("name"), c.QueryParam("locale"))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	if c.QueryParam("format") == "html" {
+		return c.HTML(http.StatusOK, rendered.HTML)
+	}
+
+	return response.MarshalResponse(c, rendered)
+}
@@ -0,0 +1,216 @@
+// Package api implements HTTP route handlers and endpoint registration for pet medical records.
+// This layer is responsible for:
+// - HTTP endpoint registration and routing for medical record operations
+// - Restricting full medical records to staff
+// - Calling appropriate handler functions for medical record management
+package api
+
+import (
+	"backend/internal/api/handlers"
+	r_models "backend/internal/api/routes/models"
+	response "backend/internal/utils/rest"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ========================================
+// ROUTE REGISTRATION
+// ========================================
+
+// RegisterMedicalRoutes registers all medical record HTTP endpoints with the Echo router.
+// Every endpoint except the summary requires a staff session and only
+// accepts pets of the staff member's organisation (see requireOrganization).
+//
+// Endpoint Organization:
+// - GET /api/pets/:id/medical/summary: Adopter-facing medical summary (public, no internal notes)
+// - GET /api/pets/:id/medical: List medical records (optional ?type= filter)
+// - POST /api/pets/:id/medical: Add a medical record
+// - GET /api/pets/:id/medical/:recordId: Get a medical record
+// - PUT /api/pets/:id/medical/:recordId: Replace a medical record
+// - DELETE /api/pets/:id/medical/:recordId: Delete a medical record
+//
+// Parameters:
+//   - e: Echo router instance for endpoint registration
+func RegisterMedicalRoutes(e *echo.Echo) {
+	e.GET("/api/pets/:id/medical/summary", handleGetMedicalSummary)
+
+	e.GET("/api/pets/:id/medical", handleListMedicalRecords, requireSession, requireStaff, requireOrganization)
+	e.POST("/api/pets/:id/medical", handleCreateMedicalRecord, requireSession, requireStaff, requireOrganization)
+	e.GET("/api/pets/:id/medical/:recordId", handleGetMedicalRecord, requireSession, requireStaff, requireOrganization)
+	e.PUT("/api/pets/:id/medical/:recordId", handleUpdateMedicalRecord, requireSession, requireStaff, requireOrganization)
+	e.DELETE("/api/pets/:id/medical/:recordId", handleDeleteMedicalRecord, requireSession, requireStaff, requireOrganization)
+}
+
+// ========================================
+// MEDICAL RECORD ROUTE HANDLERS
+// ========================================
+
+// handleGetMedicalSummary processes requests for the adopter-facing medical summary.
+//
+// HTTP Method: GET
+// Endpoint: /api/pets/:id/medical/summary
+//
+// Response:
+//   - Success: Vaccination status, spay/neuter status, allergies, treatments, latest weight and vet visit
+//   - Error: HTTP error with appropriate status code
+func handleGetMedicalSummary(c echo.Context) error {
+	petID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de mascota inválido")
+	}
+
+	summary, httpErr := handlers.HandleGetMedicalSummary(uint(petID))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, summary)
+}
+
+// handleListMedicalRecords processes requests to list the medical records of a pet.
+//
+// HTTP Method: GET
+// Endpoint: /api/pets/:id/medical
+//
+// Query Parameters:
+//   - type: Record type filter (optional)
+//
+// Response:
+//   - Success: Array of medical records, most recent first
+//   - Error: HTTP error with appropriate status code
+func handleListMedicalRecords(c echo.Context) error {
+	petID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de mascota inválido")
+	}
+
+	records, httpErr := handlers.HandleListMedicalRecords(uint(petID), currentOrganizationID(c), c.QueryParam("type"))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, records)
+}
+
+// handleCreateMedicalRecord processes requests to add a medical record.
+//
+// HTTP Method: POST
+// Endpoint: /api/pets/:id/medical
+// Content-Type: application/json
+//
+// Request Body:
+//   - MedicalRecordRequest: type, name, date, due_date, end_date, weight_kg, vet, notes
+//
+// Response:
+//   - Success: Created medical record
+//   - Error: HTTP error with appropriate status code
+func handleCreateMedicalRecord(c echo.Context) error {
+	petID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de mascota inválido")
+	}
+
+	var req r_models.MedicalRecordRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "datos de registro médico inválidos")
+	}
+
+	record, httpErr := handlers.HandleCreateMedicalRecord(uint(petID), currentOrganizationID(c), currentUser(c).ID, req)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, record)
+}
+
+// handleGetMedicalRecord processes requests to retrieve a medical record.
+//
+// HTTP Method: GET
+// Endpoint: /api/pets/:id/medical/:recordId
+//
+// Response:
+//   - Success: Medical record data, including internal notes
+//   - Error: HTTP error with appropriate status code
+func handleGetMedicalRecord(c echo.Context) error {
+	petID, recordID, err := medicalPathParams(c)
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de mascota o registro inválido")
+	}
+
+	record, httpErr := handlers.HandleGetMedicalRecord(petID, currentOrganizationID(c), recordID)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, record)
+}
+
+// handleUpdateMedicalRecord processes requests to replace a medical record.
+//
+// HTTP Method: PUT
+// Endpoint: /api/pets/:id/medical/:recordId
+// Content-Type: application/json
+//
+// Request Body:
+//   - MedicalRecordRequest: name, date, due_date, end_date, weight_kg, vet, notes (type is ignored)
+//
+// Response:
+//   - Success: Updated medical record
+//   - Error: HTTP error with appropriate status code
+func handleUpdateMedicalRecord(c echo.Context) error {
+	petID, recordID, err := medicalPathParams(c)
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de mascota o registro inválido")
+	}
+
+	var req r_models.MedicalRecordRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "datos de registro médico inválidos")
+	}
+
+	record, httpErr := handlers.HandleUpdateMedicalRecord(petID, currentOrganizationID(c), recordID, req)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, record)
+}
+
+// handleDeleteMedicalRecord processes requests to delete a medical record.
+//
+// HTTP Method: DELETE
+// Endpoint: /api/pets/:id/medical/:recordId
+//
+// Response:
+//   - Success: {"status": "deleted"}
+//   - Error: HTTP error with appropriate status code
+func handleDeleteMedicalRecord(c echo.Context) error {
+	petID, recordID, err := medicalPathParams(c)
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de mascota o registro inválido")
+	}
+
+	httpErr := handlers.HandleDeleteMedicalRecord(petID, currentOrganizationID(c), recordID)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, map[string]string{"status": "deleted"})
+}
+
+// medicalPathParams extracts the pet and medical record IDs from the request path.
+func medicalPathParams(c echo.Context) (uint, uint, error) {
+	petID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	recordID, err := strconv.Atoi(c.Param("recordId"))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return uint(petID), uint(recordID), nil
+}
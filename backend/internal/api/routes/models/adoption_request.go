@@ -0,0 +1,42 @@
+// Package r_models contains request models for API endpoints.
+// These models define the structure of data expected in HTTP request bodies.
+package r_models
+
+// AdoptionRequest represents the request payload for finalising an adoption.
+//
+// Validation Requirements:
+//   - PetID: Required, pet of the organisation not adopted yet
+//   - AdopterUserID: Required, existing user
+//   - AdoptionDate: Optional, YYYY-MM-DD not in the future (defaults to today)
+//   - FeeCents: Optional, zero or positive (defaults to the pet's fee schedule)
+//   - Currency: Optional, 3-letter ISO 4217 code (defaults to the schedule's currency, or EUR)
+//   - Clauses: Optional, up to 20 clauses of up to 2000 characters each
+//   - Notes: Optional, up to 2000 characters
+//
+// Business Rules:
+//   - The contract PDF is generated from the organisation's template and emailed to the adopter
+//   - The pet is marked as adopted by the adopter
+type AdoptionRequest struct {
+	PetID         uint     `json:"pet_id"`          // Adopted pet
+	AdopterUserID uint     `json:"adopter_user_id"` // User adopting the pet
+	AdoptionDate  string   `json:"adoption_date"`   // Date of the handover (YYYY-MM-DD)
+	FeeCents      *int64   `json:"fee_cents"`       // Adoption fee in cents (nil to use the fee schedule)
+	Currency      string   `json:"currency"`        // Currency of the fee
+	Clauses       []string `json:"clauses"`         // Clauses added to the template clauses
+	Notes         string   `json:"notes"`           // Internal notes (not included in the contract)
+}
+
+// ContractTemplateRequest represents the request payload for changing the organisation's contract template.
+// Texts are Go templates filled with the contract data, e.g. {{.Pet.Name}}, {{.Adopter.FullName}} or {{date .Date}}.
+//
+// Validation Requirements:
+//   - Title: Required, up to 200 characters
+//   - Intro, Closing: Optional, up to 5000 characters each
+//   - Clauses: Optional, up to 30 clauses of up to 2000 characters each
+//   - Every text must be a valid template using only the contract data fields
+type ContractTemplateRequest struct {
+	Title   string   `json:"title"`   // Contract title
+	Intro   string   `json:"intro"`   // Opening paragraph
+	Clauses []string `json:"clauses"` // Standard clauses
+	Closing string   `json:"closing"` // Closing paragraph
+}
@@ -71,6 +71,7 @@ type RefreshTokenRequest struct {
 //   - Email: Must be valid format and unique in the system
 //   - Password: Must meet security requirements (if provider is 'local')
 //   - Provider: Must be a supported authentication provider
+//   - Locale: Empty or one of the supported locales (es, en, ca)
 //
 // Business Rules:
 //   - Email addresses must be unique across all users
@@ -86,6 +87,8 @@ type CreateUserRequest struct {
 	Password   string `json:"password"`    // User's password (required for 'local' provider)
 	Provider   string `json:"provider"`    // Authentication provider ('local', 'google', etc.)
 	ProviderID string `json:"provider_id"` // Provider-specific user identifier (for external providers)
+
+	Locale string `json:"locale"` // Language of the user's emails (optional, defaults to es)
 }
 
 // ResetPasswordRequest represents the request payload for initiating a password reset process.
@@ -0,0 +1,55 @@
+// Package r_models contains request models for API endpoints.
+// These models define the structure of data expected in HTTP request bodies.
+package r_models
+
+// ConversationRequest represents the request payload for a user starting a conversation with an organisation.
+//
+// Validation Requirements:
+//   - PetID or OrganizationID: Required; the pet's organisation takes precedence
+//   - Subject: Required, up to 200 characters
+//   - Body: Required, up to 5000 characters
+//
+// Business Rules:
+//   - ApplicationID links the conversation to an adoption application (optional)
+type ConversationRequest struct {
+	PetID          *uint  `json:"pet_id"`          // Pet the conversation is about (optional)
+	OrganizationID *uint  `json:"organization_id"` // Organisation to write to (required without pet_id)
+	ApplicationID  *uint  `json:"application_id"`  // Adoption application the conversation is about (optional)
+	Subject        string `json:"subject"`         // Subject of the thread
+	Body           string `json:"body"`            // First message
+}
+
+// StaffConversationRequest represents the request payload for staff starting a conversation with a user.
+//
+// Validation Requirements:
+//   - UserID: Required, existing user
+//   - PetID: Optional, pet of the organisation
+//   - Subject: Required, up to 200 characters
+//   - Body: Required, up to 5000 characters
+type StaffConversationRequest struct {
+	UserID        uint   `json:"user_id"`        // User to write to
+	PetID         *uint  `json:"pet_id"`         // Pet the conversation is about (optional)
+	ApplicationID *uint  `json:"application_id"` // Adoption application the conversation is about (optional)
+	Subject       string `json:"subject"`        // Subject of the thread
+	Body          string `json:"body"`           // First message
+}
+
+// InboxUpdateRequest represents the request payload for staff changing the status and assignee of a conversation.
+//
+// Validation Requirements:
+//   - Status: open or closed
+//   - AssignedTo: Optional, member of the organisation (null unassigns the conversation)
+type InboxUpdateRequest struct {
+	Status     string `json:"status"`      // open or closed
+	AssignedTo *uint  `json:"assigned_to"` // Staff member handling the conversation (null for nobody)
+}
+
+// MessageRequest represents the multipart form fields of a message posted in a conversation.
+// Files are sent in the same form as repeated "attachments" file fields.
+//
+// Validation Requirements:
+//   - Body: Required, up to 5000 characters
+//   - Up to 5 attachments: JPEG, PNG, GIF or WebP images, or PDF documents
+type MessageRequest struct {
+	Body string `form:"body"` // Message text
+}
@@ -0,0 +1,31 @@
+// Package r_models contains request models for API endpoints.
+// These models define the structure of data expected in HTTP request bodies.
+package r_models
+
+// DonationRequest represents the request payload for making a donation or sponsoring a pet.
+//
+// Validation Requirements:
+//   - OrganizationID: Required unless PetID is given (the pet's organisation receives the donation)
+//   - PetID: Optional, pet to sponsor
+//   - AmountCents: Required, positive
+//   - Currency: Optional, 3-letter ISO 4217 code (defaults to EUR)
+//   - Frequency: Optional, one_off, monthly or yearly (defaults to one_off)
+//   - TaxID: Optional, up to 20 characters
+//   - Message: Optional, up to 500 characters
+type DonationRequest struct {
+	OrganizationID uint   `json:"organization_id"` // Organisation receiving the donation
+	PetID          *uint  `json:"pet_id"`          // Pet to sponsor
+	AmountCents    int64  `json:"amount_cents"`    // Amount of each charge in cents
+	Currency       string `json:"currency"`        // ISO 4217 currency code
+	Frequency      string `json:"frequency"`       // one_off, monthly or yearly
+	TaxID          string `json:"tax_id"`          // Donor tax ID shown on receipts
+	Message        string `json:"message"`         // Message to the organisation
+}
+
+// DonationReceiptsRequest represents the request payload for issuing the donation receipts of a year.
+//
+// Validation Requirements:
+//   - Year: Required, a year already over
+type DonationReceiptsRequest struct {
+	Year int `json:"year"` // Year of the donations
+}
@@ -0,0 +1,75 @@
+// Package r_models contains request models for API endpoints.
+// These models define the structure of data expected in HTTP request bodies.
+package r_models
+
+// FosterHomeRequest represents the request payload for registering a foster home or replacing its profile.
+//
+// Validation Requirements:
+//   - UserID: Required on creation, existing user (ignored on update)
+//   - Capacity: 1 to 20 pets
+//   - AcceptedSpecies: Up to 20 species of up to 100 characters (empty accepts any)
+//   - HomeType: house, apartment or rural
+//   - Address: Up to 255 characters; Phone: up to 30 characters
+//   - Active: Defaults to true when omitted
+//
+// Business Rules:
+//   - A user has at most one foster home per organisation
+//   - Notes are internal and never shown to the foster
+type FosterHomeRequest struct {
+	UserID          uint     `json:"user_id"`          // User account of the foster
+	Capacity        int      `json:"capacity"`         // Maximum number of pets at once
+	AcceptedSpecies []string `json:"accepted_species"` // Species the home accepts (empty for any)
+	HomeType        string   `json:"home_type"`        // house, apartment or rural
+	HasGarden       bool     `json:"has_garden"`       // Whether the home has private outdoor space
+	HasChildren     bool     `json:"has_children"`     // Whether children live in the home
+	HasOtherPets    bool     `json:"has_other_pets"`   // Whether the family has pets of their own
+	Address         string   `json:"address"`          // Postal address of the home (optional)
+	Phone           string   `json:"phone"`            // Contact phone (optional)
+	Notes           string   `json:"notes"`            // Internal notes (optional)
+	Active          *bool    `json:"active"`           // Whether the home accepts new placements (default true)
+}
+
+// FosterPlacementRequest represents the request payload for placing a pet in a foster home.
+// Dates use the YYYY-MM-DD format.
+//
+// Validation Requirements:
+//   - PetID, FosterHomeID: Required
+//   - StartDate: Required, not in the future
+//   - ExpectedEndDate: Optional, not before StartDate
+//
+// Business Rules:
+//   - The home must be active, accept the pet's species and have free capacity
+//   - The pet must not be adopted nor already live in a foster home
+type FosterPlacementRequest struct {
+	PetID           uint   `json:"pet_id"`            // Pet to place
+	FosterHomeID    uint   `json:"foster_home_id"`    // Foster home receiving the pet
+	StartDate       string `json:"start_date"`        // Date the pet moves to the home (YYYY-MM-DD)
+	ExpectedEndDate string `json:"expected_end_date"` // Planned end of the placement (YYYY-MM-DD, optional)
+	Notes           string `json:"notes"`             // Internal notes (optional)
+}
+
+// UpdateFosterPlacementRequest represents the request payload for replacing the dates and notes of a placement.
+// Dates use the YYYY-MM-DD format.
+//
+// Validation Requirements:
+//   - ExpectedEndDate: Optional, not before the start date
+//   - EndDate: Optional, not before the start date nor in the future
+//
+// Business Rules:
+//   - Setting EndDate ends the placement and frees its place in the foster home
+//   - Finished placements cannot be reopened
+type UpdateFosterPlacementRequest struct {
+	ExpectedEndDate string `json:"expected_end_date"` // Planned end of the placement (YYYY-MM-DD, optional)
+	EndDate         string `json:"end_date"`          // Date the pet left the home (YYYY-MM-DD, optional)
+	Notes           string `json:"notes"`             // Internal notes (optional)
+}
+
+// FosterUpdateRequest represents the multipart form fields of an update posted by a foster.
+// Photos are sent in the same form as repeated "photos" file fields.
+//
+// Validation Requirements:
+//   - Message: Required, up to 2000 characters
+//   - Up to 5 photos
+type FosterUpdateRequest struct {
+	Message string `form:"message"` // News about the pet
+}
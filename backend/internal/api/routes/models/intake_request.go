@@ -0,0 +1,52 @@
+// Package r_models contains request models for API endpoints.
+// These models define the structure of data expected in HTTP request bodies.
+package r_models
+
+// IntakeRequest represents the request payload for recording the arrival of a pet, or replacing its details.
+// Dates use the YYYY-MM-DD format.
+//
+// Validation Requirements:
+//   - Pet: Required on creation (see IntakePetRequest), ignored on update
+//   - Source: stray, owner_surrender, transfer or born_in_care
+//   - IntakeDate: Optional, not in the future (defaults to today)
+//   - Condition: healthy, treatable, injured, sick or critical
+//   - SurrendererName: Required for owner surrenders, up to 150 characters; SurrendererEmail must be a valid address
+//   - TransferFrom: Required for transfers, up to 150 characters
+//   - FoundLatitude, FoundLongitude: Optional for strays, both or neither, within valid ranges
+//
+// Business Rules:
+//   - Fields that do not apply to the source are discarded (e.g. surrenderer details of a stray)
+//   - Creating an intake creates its pet in the same step
+type IntakeRequest struct {
+	Pet                *IntakePetRequest `json:"pet"`                 // Pet that arrived (creation only)
+	Source             string            `json:"source"`              // stray, owner_surrender, transfer or born_in_care
+	IntakeDate         string            `json:"intake_date"`         // Date the pet arrived (YYYY-MM-DD)
+	Condition          string            `json:"condition"`           // healthy, treatable, injured, sick or critical
+	ConditionNotes     string            `json:"condition_notes"`     // Description of the initial condition (optional)
+	SurrendererName    string            `json:"surrenderer_name"`    // Person surrendering the pet (owner surrenders)
+	SurrendererEmail   string            `json:"surrenderer_email"`   // Email of the person surrendering the pet (optional)
+	SurrendererPhone   string            `json:"surrenderer_phone"`   // Phone of the person surrendering the pet (optional)
+	SurrendererAddress string            `json:"surrenderer_address"` // Address of the person surrendering the pet (optional)
+	SurrenderReason    string            `json:"surrender_reason"`    // Why the owner gave the pet up (optional)
+	TransferFrom       string            `json:"transfer_from"`       // Shelter or organisation the pet came from (transfers)
+	FoundLocation      string            `json:"found_location"`      // Where the stray was found (optional)
+	FoundLatitude      *float64          `json:"found_latitude"`      // Latitude of the found location (optional)
+	FoundLongitude     *float64          `json:"found_longitude"`     // Longitude of the found location (optional)
+	Notes              string            `json:"notes"`               // Internal notes (optional)
+}
+
+// IntakePetRequest represents the pet created together with an intake.
+//
+// Validation Requirements:
+//   - Name, Species: Required
+//   - BirthDate: Optional, YYYY-MM-DD not in the future (estimated for strays)
+//   - Microchip: Optional, valid ISO 11784/11785 number not assigned to another pet
+type IntakePetRequest struct {
+	Name        string  `json:"name"`        // Pet's name
+	Species     string  `json:"species"`     // Pet's species
+	Breed       string  `json:"breed"`       // Pet's breed (optional)
+	BirthDate   string  `json:"birth_date"`  // Date of birth, exact or estimated (YYYY-MM-DD, optional)
+	Color       string  `json:"color"`       // Coat colour(s) (optional)
+	Microchip   *string `json:"microchip"`   // Microchip number read at intake (optional)
+	Description string  `json:"description"` // Description of the pet (optional)
+}
@@ -0,0 +1,41 @@
+// Package r_models contains request models for API endpoints.
+// These models define the structure of data expected in HTTP request bodies.
+package r_models
+
+// LocationRequest represents the request payload for creating a location or replacing its data.
+//
+// Validation Requirements:
+//   - Kind: building, room or kennel (required on creation, ignored on update)
+//   - ParentID: Required for rooms (a building) and kennels (a room or building), absent for buildings; ignored on update
+//   - Name: Required, up to 100 characters
+//   - Capacity: 1 to 100 pets for kennels (ignored for buildings and rooms)
+//   - AcceptedSpecies: Up to 20 species of up to 100 characters (empty accepts any)
+//   - Active: Defaults to true when omitted
+//
+// Business Rules:
+//   - The species restrictions of a building or room apply to every kennel inside it
+//   - Inactive locations receive no new pets
+type LocationRequest struct {
+	ParentID        *uint    `json:"parent_id"`        // Building or room containing the location
+	Kind            string   `json:"kind"`             // building, room or kennel
+	Name            string   `json:"name"`             // Name, e.g. "Nave A", "Jaula 12"
+	Capacity        int      `json:"capacity"`         // Pets the kennel holds
+	AcceptedSpecies []string `json:"accepted_species"` // Species allowed (empty for any)
+	Active          *bool    `json:"active"`           // Whether the location receives new pets (default true)
+	Notes           string   `json:"notes"`            // Internal notes (optional)
+}
+
+// PetMoveRequest represents the request payload for housing a pet in a kennel.
+//
+// Validation Requirements:
+//   - LocationID: Required, an active kennel accepting the pet's species
+//   - Reason: Optional, up to 255 characters
+//
+// Business Rules:
+//   - Full kennels are rejected unless Force is set
+//   - The previous stay of the pet ends and is kept in its history
+type PetMoveRequest struct {
+	LocationID uint   `json:"location_id"` // Kennel receiving the pet
+	Reason     string `json:"reason"`      // Reason of the move, e.g. "ingreso", "cuarentena" (optional)
+	Force      bool   `json:"force"`       // Whether to house the pet even if the kennel is full
+}
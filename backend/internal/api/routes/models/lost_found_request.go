@@ -0,0 +1,44 @@
+// Package r_models contains request models for API endpoints.
+// These models define the structure of data expected in HTTP request bodies.
+package r_models
+
+// LostFoundReportRequest represents the multipart form fields of a lost or found report submission.
+// Photos are sent in the same form as repeated "photos" file fields.
+//
+// Validation Requirements:
+//   - Type: lost or found
+//   - Species, ReporterName: Required
+//   - Latitude (-90 to 90) and Longitude (-180 to 180): Required, decimal degrees
+//   - SeenDate: Required (YYYY-MM-DD), not in the future
+//   - ReporterEmail: Required, valid address (defaults to the session user's email)
+//   - Microchip: Optional, valid ISO 11784/11785 number
+//   - Up to 5 photos
+//
+// Business Rules:
+//   - Reports are not public until approved by staff
+//   - The reporter's email and phone are only visible to staff
+type LostFoundReportRequest struct {
+	Type          string `form:"type"`           // lost or found
+	Species       string `form:"species"`        // Species of the animal
+	Breed         string `form:"breed"`          // Breed, if known (optional)
+	Color         string `form:"color"`          // Coat colour(s) (optional)
+	Microchip     string `form:"microchip"`      // Microchip number, if known (optional)
+	Description   string `form:"description"`    // Distinguishing features and circumstances (optional)
+	Latitude      string `form:"latitude"`       // Last-seen or found location latitude
+	Longitude     string `form:"longitude"`      // Last-seen or found location longitude
+	Location      string `form:"location"`       // Human readable location (optional)
+	SeenDate      string `form:"seen_date"`      // Date the animal was lost or found (YYYY-MM-DD)
+	ReporterName  string `form:"reporter_name"`  // Name of the reporter
+	ReporterEmail string `form:"reporter_email"` // Contact email of the reporter
+	ReporterPhone string `form:"reporter_phone"` // Contact phone of the reporter (optional)
+}
+
+// ModerateLostFoundRequest represents the request payload for moderating a lost or found report.
+//
+// Validation Requirements:
+//   - Status: pending, approved, rejected or resolved
+//   - Note: Optional, up to 500 characters
+type ModerateLostFoundRequest struct {
+	Status string `json:"status"` // New moderation status
+	Note   string `json:"note"`   // Internal moderation note (optional)
+}
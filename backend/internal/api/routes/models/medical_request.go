@@ -0,0 +1,28 @@
+// Package r_models contains request models for API endpoints.
+// These models define the structure of data expected in HTTP request bodies.
+package r_models
+
+// MedicalRecordRequest represents the request payload for creating or replacing a medical record.
+// Dates use the YYYY-MM-DD format.
+//
+// Validation Requirements:
+//   - Type: vaccination, treatment, vet_visit, weight, allergy or spay_neuter (ignored on update)
+//   - Name: Required for vaccinations, treatments, vet visits and allergies (max 150 characters)
+//   - Date: Required, not in the future
+//   - DueDate: Optional, vaccinations only, after Date
+//   - EndDate: Optional, treatments only, not before Date
+//   - WeightKg: Required for weight records, greater than 0
+//
+// Business Rules:
+//   - Fields that do not apply to the record type are ignored
+//   - Notes are internal and never shown to adopters
+type MedicalRecordRequest struct {
+	Type     string   `json:"type"`      // Record type
+	Name     string   `json:"name"`      // Vaccine, treatment, visit reason or allergen
+	Date     string   `json:"date"`      // Date of the event (YYYY-MM-DD)
+	DueDate  string   `json:"due_date"`  // Next vaccination dose (YYYY-MM-DD, optional)
+	EndDate  string   `json:"end_date"`  // End of treatment (YYYY-MM-DD, optional)
+	WeightKg *float64 `json:"weight_kg"` // Weight in kilograms (weight records)
+	Vet      string   `json:"vet"`       // Veterinarian or clinic (optional)
+	Notes    string   `json:"notes"`     // Internal notes (optional)
+}
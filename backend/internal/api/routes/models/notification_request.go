@@ -0,0 +1,15 @@
+// Package r_models contains request models for API endpoints.
+// These models define the structure of data expected in HTTP request bodies.
+package r_models
+
+// NotificationPreferenceRequest represents a change of the channels of one notification type.
+// The update endpoint receives a list of them; types not in the list keep their preferences.
+//
+// Validation Requirements:
+//   - Type must be one of search_alerts, favorites, lost_found, messages, application_updates or reminders
+//   - At least one of Email or InApp must be provided
+type NotificationPreferenceRequest struct {
+	Type  string `json:"type"`   // Notification type
+	Email *bool  `json:"email"`  // Whether the type is emailed (optional, unchanged when missing)
+	InApp *bool  `json:"in_app"` // Whether the type appears in the notification centre (optional, unchanged when missing)
+}
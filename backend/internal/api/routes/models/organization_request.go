@@ -0,0 +1,51 @@
+// Package r_models contains request models for API endpoints.
+// These models define the structure of data expected in HTTP request bodies.
+package r_models
+
+// OrganizationRequest represents the request payload for creating an organisation or replacing its settings.
+//
+// Validation Requirements:
+//   - Name: Required, up to 150 characters
+//   - Slug: Required, lowercase letters, digits and single dashes, up to 60 characters
+//   - Email, SenderEmail, ReplyTo: Optional, valid addresses
+//   - Website: Optional, http or https URL
+//   - AdoptionQuestionnaire: Up to 50 questions (see QuestionRequest)
+//
+// Business Rules:
+//   - Slugs are unique across organisations
+//   - Emails about the organisation's pets use SenderName, SenderEmail and ReplyTo when set
+type OrganizationRequest struct {
+	Name                  string            `json:"name"`                   // Display name
+	Slug                  string            `json:"slug"`                   // URL identifier
+	Email                 string            `json:"email"`                  // Public contact email (optional)
+	Phone                 string            `json:"phone"`                  // Public contact phone (optional)
+	Address               string            `json:"address"`                // Postal address (optional)
+	Website               string            `json:"website"`                // Website URL (optional)
+	SenderName            string            `json:"sender_name"`            // Email sender display name (optional)
+	SenderEmail           string            `json:"sender_email"`           // Email sender address (optional)
+	ReplyTo               string            `json:"reply_to"`               // Reply-To address for emails (optional)
+	AdoptionQuestionnaire []QuestionRequest `json:"adoption_questionnaire"` // Questions asked to adopters
+}
+
+// QuestionRequest represents one question of an adoption questionnaire.
+//
+// Validation Requirements:
+//   - Key: Required, unique, lowercase letters, digits and underscores starting with a letter, up to 50 characters
+//   - Label: Required, up to 255 characters
+//   - Type: text, textarea, yes_no, number or choice
+//   - Options: 2 to 20 non-empty answers for choice questions, none otherwise
+type QuestionRequest struct {
+	Key      string   `json:"key"`      // Stable identifier of the question
+	Label    string   `json:"label"`    // Question text shown to adopters
+	Type     string   `json:"type"`     // Answer type
+	Options  []string `json:"options"`  // Allowed answers of choice questions
+	Required bool     `json:"required"` // Whether an answer is mandatory
+}
+
+// OrganizationMemberRequest represents the request payload for adding a member or changing their role.
+//
+// Validation Requirements:
+//   - Role: staff or manager
+type OrganizationMemberRequest struct {
+	Role string `json:"role"` // Membership role
+}
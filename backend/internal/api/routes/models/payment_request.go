@@ -0,0 +1,31 @@
+// Package r_models contains request models for API endpoints.
+// These models define the structure of data expected in HTTP request bodies.
+package r_models
+
+// FeeScheduleRequest represents the request payload for creating or changing an adoption fee schedule.
+//
+// Validation Requirements:
+//   - Name: Required, up to 100 characters
+//   - Species: Optional, up to 100 characters (empty for any species)
+//   - MinAgeMonths: Optional, zero or positive
+//   - MaxAgeMonths: Optional, greater than MinAgeMonths (nil for no upper limit)
+//   - AmountCents: Required, zero or positive
+//   - Currency: Optional, 3-letter ISO 4217 code (defaults to EUR)
+type FeeScheduleRequest struct {
+	Name         string `json:"name"`           // Label shown to staff
+	Species      string `json:"species"`        // Species the fee applies to
+	MinAgeMonths int    `json:"min_age_months"` // Minimum age in months (inclusive)
+	MaxAgeMonths *int   `json:"max_age_months"` // Maximum age in months (exclusive)
+	AmountCents  int64  `json:"amount_cents"`   // Fee in cents
+	Currency     string `json:"currency"`       // ISO 4217 currency code
+}
+
+// RefundRequest represents the request payload for refunding a payment.
+//
+// Validation Requirements:
+//   - AmountCents: Optional, positive (nil refunds everything not refunded yet)
+//   - Reason: Optional, up to 500 characters
+type RefundRequest struct {
+	AmountCents *int64 `json:"amount_cents"` // Amount to refund in cents
+	Reason      string `json:"reason"`       // Why the payment is refunded
+}
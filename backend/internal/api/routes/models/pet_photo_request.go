@@ -0,0 +1,14 @@
+// Package r_models contains request models for API endpoints.
+// These models define the structure of data expected in HTTP request bodies.
+package r_models
+
+// ReorderPhotosRequest represents the request payload for changing the display order of pet photos.
+//
+// Validation Requirements:
+//   - PhotoIDs: Must contain every photo ID of the pet exactly once
+//
+// Business Rules:
+//   - The position of each photo becomes its index in PhotoIDs
+type ReorderPhotosRequest struct {
+	PhotoIDs []uint `json:"photo_ids"` // Photo IDs in the desired display order
+}
@@ -0,0 +1,23 @@
+// Package r_models contains request models for API endpoints.
+// These models define the structure of data expected in HTTP request bodies.
+package r_models
+
+// SavedSearchRequest represents the request payload for creating or replacing a saved search.
+//
+// Validation Requirements:
+//   - At least one of Species, Breed, AgeMin, AgeMax or Keywords must be provided
+//   - AgeMin and AgeMax must be between 0 and 100, and AgeMin <= AgeMax
+//   - Name up to 100 characters, Keywords up to 200 characters
+//
+// Business Rules:
+//   - Name defaults to a summary of the criteria
+//   - AlertsEnabled defaults to true
+type SavedSearchRequest struct {
+	Name          string `json:"name"`           // Display name (optional)
+	Species       string `json:"species"`        // Exact species (optional)
+	Breed         string `json:"breed"`          // Breed contains this value (optional)
+	AgeMin        *int   `json:"age_min"`        // Minimum age in whole years (optional)
+	AgeMax        *int   `json:"age_max"`        // Maximum age in whole years (optional)
+	Keywords      string `json:"keywords"`       // Free-text keywords (optional)
+	AlertsEnabled *bool  `json:"alerts_enabled"` // Whether new matches are emailed (default true)
+}
@@ -0,0 +1,75 @@
+// Package r_models contains request models for API endpoints.
+// These models define the structure of data expected in HTTP request bodies.
+package r_models
+
+// VisitSlotRequest represents the request payload for publishing an availability slot.
+// Times use the RFC 3339 format (e.g. 2026-10-20T17:00:00+02:00).
+//
+// Validation Requirements:
+//   - StartTime: Required, in the future
+//   - EndTime: Required, after StartTime and at most 8 hours later
+//   - PetID: Optional, pet of the organisation (omit to accept visits to any pet)
+//   - Location: Optional, up to 255 characters
+//
+// Business Rules:
+//   - Slots of the same pet cannot overlap
+type VisitSlotRequest struct {
+	PetID     *uint  `json:"pet_id"`     // Pet the slot is reserved for (optional)
+	StartTime string `json:"start_time"` // Start of the visit (RFC 3339)
+	EndTime   string `json:"end_time"`   // End of the visit (RFC 3339)
+	Location  string `json:"location"`   // Where the visitor is received (optional)
+}
+
+// BookVisitRequest represents the request payload for booking a meet-and-greet visit.
+//
+// Validation Requirements:
+//   - SlotID: Required, free future slot
+//   - PetID: Required for slots open to any pet; defaults to the pet of the slot otherwise
+//   - VisitorName: Required, up to 100 characters
+//   - VisitorEmail: Required, valid address (defaults to the session user's email)
+//   - VisitorPhone: Optional, up to 30 characters
+//   - Notes: Optional, up to 2000 characters
+//
+// Business Rules:
+//   - The confirmation email carries the calendar invite and the signed link to cancel or reschedule
+type BookVisitRequest struct {
+	SlotID        uint   `json:"slot_id"`        // Slot to book
+	PetID         uint   `json:"pet_id"`         // Pet to meet (optional for pet slots)
+	VisitorName   string `json:"visitor_name"`   // Name of the visitor
+	VisitorEmail  string `json:"visitor_email"`  // Email the confirmation and reminder are sent to
+	VisitorPhone  string `json:"visitor_phone"`  // Contact phone (optional)
+	ApplicationID *uint  `json:"application_id"` // Adoption application the visit belongs to (optional)
+	Notes         string `json:"notes"`          // Message for the organisation (optional)
+}
+
+// UpdateVisitStatusRequest represents the request payload for recording the outcome of a visit.
+//
+// Validation Requirements:
+//   - Status: cancelled, completed or no_show
+//   - Reason: Optional, up to 500 characters (shown to the visitor when cancelling)
+type UpdateVisitStatusRequest struct {
+	Status string `json:"status"` // New visit status
+	Reason string `json:"reason"` // Cancellation reason (optional)
+}
+
+// VisitApplicationRequest represents the request payload for linking a visit to an adoption application.
+// A null application_id unlinks the visit.
+type VisitApplicationRequest struct {
+	ApplicationID *uint `json:"application_id"` // Adoption application ID, or null to unlink
+}
+
+// CancelVisitRequest represents the request payload for cancelling a visit from its signed link.
+//
+// Validation Requirements:
+//   - Reason: Optional, up to 500 characters
+type CancelVisitRequest struct {
+	Reason string `json:"reason"` // Why the visitor cancels (optional)
+}
+
+// RescheduleVisitRequest represents the request payload for moving a visit to another slot from its signed link.
+//
+// Validation Requirements:
+//   - SlotID: Required, free future slot of the same organisation open to the visit's pet
+type RescheduleVisitRequest struct {
+	SlotID uint `json:"slot_id"` // New slot
+}
@@ -0,0 +1,98 @@
+// Package r_models contains request models for API endpoints.
+// These models define the structure of data expected in HTTP request bodies.
+package r_models
+
+// VolunteerRequest represents the request payload for registering a volunteer or replacing their profile.
+//
+// Validation Requirements:
+//   - UserID: Required on creation, existing user (ignored on update)
+//   - Skills: Up to 20 skills of up to 100 characters
+//   - Phone, EmergencyPhone: Up to 30 characters; EmergencyContact: up to 100 characters
+//   - Active: Defaults to true when omitted
+//
+// Business Rules:
+//   - A user is a volunteer of each organisation at most once
+//   - Notes are internal and never shown to the volunteer
+type VolunteerRequest struct {
+	UserID           uint     `json:"user_id"`           // User account of the volunteer
+	Phone            string   `json:"phone"`             // Contact phone (optional)
+	Bio              string   `json:"bio"`               // Presentation of the volunteer (optional)
+	Skills           []string `json:"skills"`            // Skills, e.g. "paseos", "limpieza"
+	EmergencyContact string   `json:"emergency_contact"` // Name of the emergency contact (optional)
+	EmergencyPhone   string   `json:"emergency_phone"`   // Phone of the emergency contact (optional)
+	Notes            string   `json:"notes"`             // Internal notes (optional)
+	Active           *bool    `json:"active"`            // Whether the volunteer can sign up for shifts (default true)
+}
+
+// VolunteerProfileRequest represents the request payload for a volunteer updating their own profile.
+//
+// Validation Requirements:
+//   - Same limits as VolunteerRequest
+//
+// Business Rules:
+//   - Notes and the active flag are managed by staff and cannot be changed here
+type VolunteerProfileRequest struct {
+	Phone            string   `json:"phone"`             // Contact phone (optional)
+	Bio              string   `json:"bio"`               // Presentation of the volunteer (optional)
+	Skills           []string `json:"skills"`            // Skills, e.g. "paseos", "limpieza"
+	EmergencyContact string   `json:"emergency_contact"` // Name of the emergency contact (optional)
+	EmergencyPhone   string   `json:"emergency_phone"`   // Phone of the emergency contact (optional)
+}
+
+// VolunteerCertificationRequest represents the request payload for recording a certification of a volunteer.
+// Dates use the YYYY-MM-DD format.
+//
+// Validation Requirements:
+//   - Name: Required, up to 100 characters
+//   - IssuedBy: Up to 150 characters
+//   - ExpiresOn: Optional, not before IssuedOn
+type VolunteerCertificationRequest struct {
+	Name      string `json:"name"`       // Certification name, e.g. "Primeros auxilios"
+	IssuedBy  string `json:"issued_by"`  // Issuing body (optional)
+	IssuedOn  string `json:"issued_on"`  // Issue date (YYYY-MM-DD, optional)
+	ExpiresOn string `json:"expires_on"` // Expiry date (YYYY-MM-DD, optional; never expires when empty)
+}
+
+// VolunteerShiftRequest represents the request payload for publishing a shift or replacing its details.
+// Times use the RFC 3339 format.
+//
+// Validation Requirements:
+//   - Title: Required, up to 150 characters
+//   - StartTime: Required, in the future
+//   - EndTime: Required, after StartTime and at most 12 hours later
+//   - Capacity: 1 to 100 volunteers
+//   - RequiredSkill, RequiredCertification: Up to 100 characters
+//   - Location: Up to 255 characters
+//
+// Business Rules:
+//   - The capacity cannot be lowered below the volunteers already signed up
+type VolunteerShiftRequest struct {
+	Title                 string `json:"title"`                  // Short description of the shift
+	Description           string `json:"description"`            // Details of the tasks (optional)
+	RequiredSkill         string `json:"required_skill"`         // Skill volunteers must have (optional)
+	RequiredCertification string `json:"required_certification"` // Certification volunteers must hold (optional)
+	StartTime             string `json:"start_time"`             // Start of the shift (RFC 3339)
+	EndTime               string `json:"end_time"`               // End of the shift (RFC 3339)
+	Location              string `json:"location"`               // Where volunteers meet (optional)
+	Capacity              int    `json:"capacity"`               // Maximum number of volunteers
+}
+
+// AttendanceRequest represents the request payload for recording the attendance of a shift.
+//
+// Validation Requirements:
+//   - Entries: 1 to 100 signups of the shift, each listed once
+type AttendanceRequest struct {
+	Entries []AttendanceEntry `json:"entries"` // Attendance of each signup
+}
+
+// AttendanceEntry is the attendance of one volunteer in a shift.
+//
+// Validation Requirements:
+//   - SignupID: Required, signup of the shift that was not cancelled
+//   - Status: attended or no_show
+//   - MinutesWorked: Optional, 1 to 1440 and only for attended signups (the shift length when omitted)
+type AttendanceEntry struct {
+	SignupID      uint   `json:"signup_id"`      // Signup of the volunteer
+	Status        string `json:"status"`         // attended or no_show
+	MinutesWorked *int   `json:"minutes_worked"` // Time worked, when different from the shift length
+}
@@ -0,0 +1,23 @@
+// Package r_models contains request models for API endpoints.
+// These models define the structure of data expected in HTTP request bodies.
+package r_models
+
+// WebhookRequest represents the request payload for registering a webhook or replacing its settings.
+//
+// Validation Requirements:
+//   - URL: Required absolute https URL (http only when WEBHOOK_ALLOW_HTTP is enabled), up to 500 characters
+//   - EventTypes: At least one known event type (pet.created, pet.updated, pet.adopted, pet.deleted,
+//     species.created, species.deleted, adoption.finalized)
+//   - Description: Up to 255 characters
+//   - OrganizationID: Existing organisation when given
+//   - Active: Defaults to true when omitted
+//
+// Business Rules:
+//   - The signing secret is generated by the server and only returned on creation and rotation
+type WebhookRequest struct {
+	URL            string   `json:"url"`             // Endpoint receiving the events
+	Description    string   `json:"description"`     // Who the endpoint belongs to (optional)
+	EventTypes     []string `json:"event_types"`     // Subscribed event types
+	OrganizationID *uint    `json:"organization_id"` // Only events of this organisation (optional, every organisation when omitted)
+	Active         *bool    `json:"active"`          // Whether the webhook receives new events (default true)
+}
@@ -0,0 +1,173 @@
+// Package api implements HTTP route handlers and endpoint registration for notifications.
+// This layer is responsible for:
+// - HTTP endpoint registration and routing for notification preferences and the notification centre
+// - Request parsing and user resolution through the session middleware
+// - Calling appropriate handler functions on behalf of the current user
+package api
+
+import (
+	"backend/internal/api/handlers"
+	r_models "backend/internal/api/routes/models"
+	response "backend/internal/utils/rest"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ========================================
+// ROUTE REGISTRATION
+// ========================================
+
+// RegisterNotificationRoutes registers all notification HTTP endpoints with the Echo router.
+//
+// Endpoint Organization:
+// - GET /api/users/me/notification-preferences: Channels of every notification type
+// - PUT /api/users/me/notification-preferences: Change the channels of some notification types
+// - GET /api/users/me/notifications: Notification centre of the current user (paginated)
+// - GET /api/users/me/notifications/unread-count: Unread notifications, in total and by type
+// - POST /api/users/me/notifications/read-all: Mark every unread notification as read
+// - POST /api/users/me/notifications/:id/read: Mark a notification as read
+//
+// Parameters:
+//   - e: Echo router instance for endpoint registration
+func RegisterNotificationRoutes(e *echo.Echo) {
+	e.GET("/api/users/me/notification-preferences", handleListNotificationPreferences, requireSession)
+	e.PUT("/api/users/me/notification-preferences", handleUpdateNotificationPreferences, requireSession)
+	e.GET("/api/users/me/notifications", handleListNotifications, requireSession)
+	e.GET("/api/users/me/notifications/unread-count", handleCountUnreadNotifications, requireSession)
+	e.POST("/api/users/me/notifications/read-all", handleMarkAllNotificationsRead, requireSession)
+	e.POST("/api/users/me/notifications/:id/read", handleMarkNotificationRead, requireSession)
+}
+
+// ========================================
+// NOTIFICATION PREFERENCE ROUTE HANDLERS
+// ========================================
+
+// handleListNotificationPreferences processes requests to list the notification preferences of the current user.
+//
+// HTTP Method: GET
+// Endpoint: /api/users/me/notification-preferences
+//
+// Response:
+//   - Success: Array with the email and in_app channels of every notification type
+//   - Error: HTTP error with appropriate status code
+func handleListNotificationPreferences(c echo.Context) error {
+	preferences, httpErr := handlers.HandleListNotificationPreferences(currentUser(c).ID)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, preferences)
+}
+
+// handleUpdateNotificationPreferences processes requests to change the notification preferences of the current user.
+//
+// HTTP Method: PUT
+// Endpoint: /api/users/me/notification-preferences
+// Content-Type: application/json
+//
+// Request Body:
+//   - Array of NotificationPreferenceRequest: type, email, in_app
+//
+// Response:
+//   - Success: Array with the preferences of every notification type
+//   - Error: 400 empty list, missing channels or unknown type
+func handleUpdateNotificationPreferences(c echo.Context) error {
+	var req []r_models.NotificationPreferenceRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "datos de preferencias inválidos")
+	}
+
+	preferences, httpErr := handlers.HandleUpdateNotificationPreferences(currentUser(c).ID, req)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, preferences)
+}
+
+// ========================================
+// NOTIFICATION CENTRE ROUTE HANDLERS
+// ========================================
+
+// handleListNotifications processes requests to list the notification centre of the current user.
+//
+// HTTP Method: GET
+// Endpoint: /api/users/me/notifications
+//
+// Query Parameters:
+//   - type: Notification type (search_alerts, favorites, lost_found, messages, application_updates, reminders)
+//   - unread: true for unread notifications only, false for read ones
+//   - page, page_size, cursor, sort: Pagination and sorting (see package query, default -id)
+//
+// Response:
+//   - Success: Page of notifications, newest first
+//   - Error: 400 invalid query parameters
+func handleListNotifications(c echo.Context) error {
+	notifications, httpErr := handlers.HandleListNotifications(currentUser(c).ID, c.Path(), c.QueryParams())
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, notifications)
+}
+
+// handleCountUnreadNotifications processes requests to count the unread notifications of the current user.
+//
+// HTTP Method: GET
+// Endpoint: /api/users/me/notifications/unread-count
+//
+// Response:
+//   - Success: {"total": n, "by_type": {"search_alerts": n, ...}}
+//   - Error: HTTP error with appropriate status code
+func handleCountUnreadNotifications(c echo.Context) error {
+	unread, httpErr := handlers.HandleCountUnreadNotifications(currentUser(c).ID)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, unread)
+}
+
+// handleMarkNotificationRead processes requests to mark a notification as read.
+//
+// HTTP Method: POST
+// Endpoint: /api/users/me/notifications/:id/read
+//
+// Response:
+//   - Success: Notification with its read date
+//   - Error: 400 invalid ID, 404 not found
+func handleMarkNotificationRead(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de notificación inválido")
+	}
+
+	notification, httpErr := handlers.HandleMarkNotificationRead(currentUser(c).ID, uint(id))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, notification)
+}
+
+// handleMarkAllNotificationsRead processes requests to mark every unread notification as read.
+//
+// HTTP Method: POST
+// Endpoint: /api/users/me/notifications/read-all?type=
+//
+// Query Parameters:
+//   - type: Only mark notifications of this type (optional)
+//
+// Response:
+//   - Success: Unread notifications after the change, in total and by type
+//   - Error: 400 unknown type
+func handleMarkAllNotificationsRead(c echo.Context) error {
+	unread, httpErr := handlers.HandleMarkAllNotificationsRead(currentUser(c).ID, c.QueryParam("type"))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, unread)
+}
@@ -0,0 +1,234 @@
+// Package api implements HTTP route handlers and endpoint registration for shelter organisations.
+// This layer is responsible for:
+// - HTTP endpoint registration and routing for organisation operations
+// - Restricting organisation creation to admins and settings to organisation managers
+// - Calling appropriate handler functions for organisations and their members
+package api
+
+import (
+	"backend/internal/api/handlers"
+	r_models "backend/internal/api/routes/models"
+	response "backend/internal/utils/rest"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ========================================
+// ROUTE REGISTRATION
+// ========================================
+
+// RegisterOrganizationRoutes registers all organisation HTTP endpoints with the Echo router.
+//
+// Endpoint Organization:
+// - GET /api/organizations: List organisations (public)
+// - GET /api/organizations/:id: Get an organisation with its adoption questionnaire (public)
+// - POST /api/organizations: Create an organisation (admin)
+// - GET /api/users/me/organizations: Organisations of the current staff member
+// - PUT /api/organizations/current: Replace the profile and settings of the current organisation (manager)
+// - GET /api/organizations/current/members: List members of the current organisation (manager)
+// - PUT /api/organizations/current/members/:userId: Add a member or change their role (manager)
+// - DELETE /api/organizations/current/members/:userId: Remove a member (manager)
+//
+// The current organisation is the one selected with the X-Organization-ID header,
+// or the staff member's only organisation (see requireOrganization).
+//
+// Parameters:
+//   - e: Echo router instance for endpoint registration
+func RegisterOrganizationRoutes(e *echo.Echo) {
+	e.GET("/api/organizations", handleListOrganizations)
+	e.GET("/api/organizations/:id", handleGetOrganization)
+	e.POST("/api/organizations", handleCreateOrganization, requireSession, requireAdmin)
+	e.GET("/api/users/me/organizations", handleListMyOrganizations, requireSession, requireStaff)
+	e.PUT("/api/organizations/current", handleUpdateOrganization, requireSession, requireStaff, requireOrganization, requireOrgManager)
+	e.GET("/api/organizations/current/members", handleListOrganizationMembers, requireSession, requireStaff, requireOrganization, requireOrgManager)
+	e.PUT("/api/organizations/current/members/:userId", handleSaveOrganizationMember, requireSession, requireStaff, requireOrganization, requireOrgManager)
+	e.DELETE("/api/organizations/current/members/:userId", handleRemoveOrganizationMember, requireSession, requireStaff, requireOrganization, requireOrgManager)
+}
+
+// ========================================
+// ORGANIZATION ROUTE HANDLERS
+// ========================================
+
+// handleListOrganizations processes requests to list organisations.
+//
+// HTTP Method: GET
+// Endpoint: /api/organizations
+//
+// Query Parameters:
+//   - page, page_size, cursor, sort: Pagination and sorting (see package query)
+//   - name: Filter by name
+//
+// Response:
+//   - Success: Page of organisations with total count and next/prev links
+//   - Error: HTTP error with appropriate status code
+func handleListOrganizations(c echo.Context) error {
+	organizations, httpErr := handlers.HandleListOrganizations(c.Path(), c.QueryParams())
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, organizations)
+}
+
+// handleGetOrganization processes requests to retrieve an organisation.
+//
+// HTTP Method: GET
+// Endpoint: /api/organizations/:id
+//
+// Response:
+//   - Success: Organisation with its contact details and adoption questionnaire
+//   - Error: 404 when the organisation does not exist
+func handleGetOrganization(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de organización inválido")
+	}
+
+	organization, httpErr := handlers.HandleGetOrganization(uint(id))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, organization)
+}
+
+// handleCreateOrganization processes admin requests to create an organisation.
+//
+// HTTP Method: POST
+// Endpoint: /api/organizations
+// Content-Type: application/json
+//
+// Request Body:
+//   - See r_models.OrganizationRequest
+//
+// Response:
+//   - Success: Created organisation
+//   - Error: 400 invalid data, 409 slug in use
+func handleCreateOrganization(c echo.Context) error {
+	var req r_models.OrganizationRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "datos de organización inválidos")
+	}
+
+	organization, httpErr := handlers.HandleCreateOrganization(req)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, organization)
+}
+
+// handleListMyOrganizations processes requests to list the organisations of the current staff member.
+//
+// HTTP Method: GET
+// Endpoint: /api/users/me/organizations
+//
+// Response:
+//   - Success: Memberships with the organisation ID and role
+//   - Error: HTTP error with appropriate status code
+func handleListMyOrganizations(c echo.Context) error {
+	members, httpErr := handlers.HandleListUserMemberships(currentUser(c).ID)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, members)
+}
+
+// handleUpdateOrganization processes manager requests to replace the current organisation's settings.
+//
+// HTTP Method: PUT
+// Endpoint: /api/organizations/current
+// Content-Type: application/json
+//
+// Request Body:
+//   - See r_models.OrganizationRequest
+//
+// Response:
+//   - Success: Updated organisation
+//   - Error: 400 invalid data, 409 slug in use
+func handleUpdateOrganization(c echo.Context) error {
+	var req r_models.OrganizationRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "datos de organización inválidos")
+	}
+
+	organization, httpErr := handlers.HandleUpdateOrganization(currentOrganizationID(c), req)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, organization)
+}
+
+// handleListOrganizationMembers processes manager requests to list the current organisation's members.
+//
+// HTTP Method: GET
+// Endpoint: /api/organizations/current/members
+//
+// Response:
+//   - Success: Members with their user summary and role
+//   - Error: HTTP error with appropriate status code
+func handleListOrganizationMembers(c echo.Context) error {
+	members, httpErr := handlers.HandleListOrganizationMembers(currentOrganizationID(c))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, members)
+}
+
+// handleSaveOrganizationMember processes manager requests to add a member or change their role.
+//
+// HTTP Method: PUT
+// Endpoint: /api/organizations/current/members/:userId
+// Content-Type: application/json
+//
+// Request Body:
+//   - See r_models.OrganizationMemberRequest
+//
+// Response:
+//   - Success: Saved membership
+//   - Error: 400 invalid role or user without a staff role, 404 unknown user
+func handleSaveOrganizationMember(c echo.Context) error {
+	userID, err := strconv.Atoi(c.Param("userId"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de usuario inválido")
+	}
+
+	var req r_models.OrganizationMemberRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "datos de miembro inválidos")
+	}
+
+	member, httpErr := handlers.HandleSaveOrganizationMember(currentOrganizationID(c), uint(userID), req)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, member)
+}
+
+// handleRemoveOrganizationMember processes manager requests to remove a member.
+//
+// HTTP Method: DELETE
+// Endpoint: /api/organizations/current/members/:userId
+//
+// Response:
+//   - Success: Deletion confirmation message
+//   - Error: 404 when the user is not a member
+func handleRemoveOrganizationMember(c echo.Context) error {
+	userID, err := strconv.Atoi(c.Param("userId"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de usuario inválido")
+	}
+
+	httpErr := handlers.HandleRemoveOrganizationMember(currentOrganizationID(c), uint(userID))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, map[string]string{"status": "deleted"})
+}
@@ -0,0 +1,104 @@
+// Package api implements HTTP route handlers and endpoint registration for the email outbox.
+// This layer is responsible for:
+// - HTTP endpoint registration and routing for the outbox admin API
+// - Restricting every endpoint to admins
+// - Request parameter extraction and validation
+package api
+
+import (
+	"backend/internal/api/handlers"
+	response "backend/internal/utils/rest"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ========================================
+// ROUTE REGISTRATION
+// ========================================
+
+// RegisterOutboxRoutes registers all email outbox HTTP endpoints with the Echo router.
+// Every endpoint requires an admin session.
+//
+// Endpoint Organization:
+// - GET /api/admin/mail/outbox: List queued, sent and failed emails
+// - GET /api/admin/mail/outbox/:id: Get an email with its delivery state
+// - POST /api/admin/mail/outbox/:id/resend: Resend a failed email
+//
+// Parameters:
+//   - e: Echo router instance for endpoint registration
+func RegisterOutboxRoutes(e *echo.Echo) {
+	e.GET("/api/admin/mail/outbox", handleListOutboxEmails, requireSession, requireAdmin)
+	e.GET("/api/admin/mail/outbox/:id", handleGetOutboxEmail, requireSession, requireAdmin)
+	e.POST("/api/admin/mail/outbox/:id/resend", handleResendOutboxEmail, requireSession, requireAdmin)
+}
+
+// ========================================
+// OUTBOX ROUTE HANDLERS
+// ========================================
+
+// handleListOutboxEmails processes admin requests to list the email outbox.
+//
+// HTTP Method: GET
+// Endpoint: /api/admin/mail/outbox
+//
+// Query Parameters:
+//   - page, page_size, cursor, sort: Pagination and sorting (see package query)
+//   - status, recipient, from, to: Filters
+//
+// Response:
+//   - Success: Page of emails (without their messages), newest first by default
+//   - Error: HTTP error with appropriate status code
+func handleListOutboxEmails(c echo.Context) error {
+	emails, httpErr := handlers.HandleListOutboxEmails(c.Path(), c.QueryParams())
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, emails)
+}
+
+// handleGetOutboxEmail processes admin requests to retrieve an email of the outbox.
+//
+// HTTP Method: GET
+// Endpoint: /api/admin/mail/outbox/:id
+//
+// Response:
+//   - Success: Email with status, attempts and last error
+//   - Error: 404 unknown email
+func handleGetOutboxEmail(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de email inválido")
+	}
+
+	email, httpErr := handlers.HandleGetOutboxEmail(uint(id))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, email)
+}
+
+// handleResendOutboxEmail processes admin requests to resend a failed email.
+//
+// HTTP Method: POST
+// Endpoint: /api/admin/mail/outbox/:id/resend
+//
+// Response:
+//   - Success: Email with the outcome of the new attempt
+//   - Error: 404 unknown email, 409 email not failed, 410 message cleared after MAIL_FAILED_RETENTION
+func handleResendOutboxEmail(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de email inválido")
+	}
+
+	email, httpErr := handlers.HandleResendOutboxEmail(uint(id))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, email)
+}
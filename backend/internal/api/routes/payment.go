@@ -0,0 +1,378 @@
+// Package api implements HTTP route handlers and endpoint registration for adoption fees and payments.
+// This layer is responsible for:
+// - HTTP endpoint registration and routing for fee schedules, checkouts, payments and refunds
+// - Receiving the signed webhook callbacks of the payment provider
+// - Serving the checkout page of the fake provider used in development
+package api
+
+import (
+	"backend/internal/api/handlers"
+	r_models "backend/internal/api/routes/models"
+	response "backend/internal/utils/rest"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// maxWebhookBytes is the maximum size of a payment webhook body.
+const maxWebhookBytes = 1 << 20
+
+// ========================================
+// ROUTE REGISTRATION
+// ========================================
+
+// RegisterPaymentRoutes registers all adoption fee and payment HTTP endpoints with the Echo router.
+//
+// Endpoint Organization:
+// - GET /api/adoptions/fees: List fee schedules (staff)
+// - POST /api/adoptions/fees: Create a fee schedule (managers)
+// - PUT /api/adoptions/fees/:id: Update a fee schedule (managers)
+// - DELETE /api/adoptions/fees/:id: Delete a fee schedule (managers)
+// - GET /api/pets/:id/adoption-fee: Compute the adoption fee of a pet (staff)
+// - POST /api/adoptions/:id/checkout: Start paying the outstanding fee (adopter or staff)
+// - GET /api/adoptions/:id/payments: List the payments of an adoption (adopter or staff)
+// - GET /api/payments: List payments (staff)
+// - GET /api/payments/:id: Get a payment (staff)
+// - POST /api/payments/:id/refund: Refund a payment (managers)
+// - POST /api/payments/webhook/:provider: Webhook callback of the payment provider (signed)
+// - GET, POST /api/payments/fake/checkout/:id: Checkout page of the fake provider (only registered when it is in use)
+//
+// Staff endpoints act on the organisation selected by requireOrganization.
+//
+// Parameters:
+//   - e: Echo router instance for endpoint registration
+func RegisterPaymentRoutes(e *echo.Echo) {
+	e.GET("/api/adoptions/fees", handleListFeeSchedules, requireSession, requireStaff, requireOrganization)
+	e.POST("/api/adoptions/fees", handleCreateFeeSchedule, requireSession, requireStaff, requireOrganization, requireOrgManager)
+	e.PUT("/api/adoptions/fees/:id", handleUpdateFeeSchedule, requireSession, requireStaff, requireOrganization, requireOrgManager)
+	e.DELETE("/api/adoptions/fees/:id", handleDeleteFeeSchedule, requireSession, requireStaff, requireOrganization, requireOrgManager)
+	e.GET("/api/pets/:id/adoption-fee", handleQuoteAdoptionFee, requireSession, requireStaff, requireOrganization)
+
+	e.POST("/api/adoptions/:id/checkout", handleStartAdoptionCheckout, requireSession)
+	e.GET("/api/adoptions/:id/payments", handleListAdoptionPayments, requireSession)
+
+	e.GET("/api/payments", handleListPayments, requireSession, requireStaff, requireOrganization)
+	e.GET("/api/payments/:id", handleGetPayment, requireSession, requireStaff, requireOrganization)
+	e.POST("/api/payments/:id/refund", handleRefundPayment, requireSession, requireStaff, requireOrganization, requireOrgManager)
+
+	// Called by the provider: authenticated by the webhook signature, not by a session
+	e.POST("/api/payments/webhook/:provider", handlePaymentWebhook)
+
+	// Anyone can mark a fake checkout as paid, so these only exist in development
+	if handlers.FakePaymentsEnabled() {
+		e.GET("/api/payments/fake/checkout/:id", handleFakeCheckoutPage)
+		e.POST("/api/payments/fake/checkout/:id", handleCompleteFakeCheckout)
+	}
+}
+
+// ========================================
+// FEE SCHEDULE ROUTE HANDLERS
+// ========================================
+
+// handleListFeeSchedules processes staff requests to list the fee schedules.
+//
+// HTTP Method: GET
+// Endpoint: /api/adoptions/fees
+//
+// Response:
+//   - Success: Fee schedules of the organisation
+//   - Error: HTTP error with appropriate status code
+func handleListFeeSchedules(c echo.Context) error {
+	schedules, httpErr := handlers.HandleListFeeSchedules(currentOrganizationID(c))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, schedules)
+}
+
+// handleCreateFeeSchedule processes manager requests to add a fee schedule.
+//
+// HTTP Method: POST
+// Endpoint: /api/adoptions/fees
+// Content-Type: application/json
+//
+// Request Body:
+//   - See r_models.FeeScheduleRequest
+//
+// Response:
+//   - Success: Created fee schedule
+//   - Error: 400 invalid data
+func handleCreateFeeSchedule(c echo.Context) error {
+	var req r_models.FeeScheduleRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "datos de tarifa inválidos")
+	}
+
+	schedule, httpErr := handlers.HandleCreateFeeSchedule(currentOrganizationID(c), req)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, schedule)
+}
+
+// handleUpdateFeeSchedule processes manager requests to change a fee schedule.
+//
+// HTTP Method: PUT
+// Endpoint: /api/adoptions/fees/:id
+// Content-Type: application/json
+//
+// Request Body:
+//   - See r_models.FeeScheduleRequest
+//
+// Response:
+//   - Success: Updated fee schedule (adoptions already finalised keep their fee)
+//   - Error: 400 invalid data, 404 unknown schedule
+func handleUpdateFeeSchedule(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de tarifa inválido")
+	}
+
+	var req r_models.FeeScheduleRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "datos de tarifa inválidos")
+	}
+
+	schedule, httpErr := handlers.HandleUpdateFeeSchedule(uint(id), currentOrganizationID(c), req)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, schedule)
+}
+
+// handleDeleteFeeSchedule processes manager requests to remove a fee schedule.
+//
+// HTTP Method: DELETE
+// Endpoint: /api/adoptions/fees/:id
+//
+// Response:
+//   - Success: {"status": "deleted"}
+//   - Error: 404 unknown schedule
+func handleDeleteFeeSchedule(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de tarifa inválido")
+	}
+
+	httpErr := handlers.HandleDeleteFeeSchedule(uint(id), currentOrganizationID(c))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, map[string]string{"status": "deleted"})
+}
+
+// handleQuoteAdoptionFee processes staff requests to compute the adoption fee of a pet.
+//
+// HTTP Method: GET
+// Endpoint: /api/pets/:id/adoption-fee
+//
+// Response:
+//   - Success: Fee of the pet and the schedule it comes from (0 when no schedule applies)
+//   - Error: 404 unknown pet
+func handleQuoteAdoptionFee(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de mascota inválido")
+	}
+
+	quote, httpErr := handlers.HandleQuoteAdoptionFee(uint(id), currentOrganizationID(c))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, quote)
+}
+
+// ========================================
+// PAYMENT ROUTE HANDLERS
+// ========================================
+
+// handleStartAdoptionCheckout processes requests to pay the outstanding fee of an adoption.
+//
+// HTTP Method: POST
+// Endpoint: /api/adoptions/:id/checkout
+//
+// Response:
+//   - Success: Pending payment; the client redirects the payer to its checkout_url
+//   - Error: 404 unknown adoption or not visible to the user, 409 nothing left to pay, 502 provider error
+func handleStartAdoptionCheckout(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de adopción inválido")
+	}
+
+	payment, httpErr := handlers.HandleStartAdoptionCheckout(uint(id), currentUser(c))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, payment)
+}
+
+// handleListAdoptionPayments processes requests to list the payments of an adoption.
+//
+// HTTP Method: GET
+// Endpoint: /api/adoptions/:id/payments
+//
+// Response:
+//   - Success: Payments of the adoption with their refunds
+//   - Error: 404 unknown adoption or not visible to the user
+func handleListAdoptionPayments(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de adopción inválido")
+	}
+
+	payments, httpErr := handlers.HandleListAdoptionPayments(uint(id), currentUser(c))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, payments)
+}
+
+// handleListPayments processes staff requests to list payments.
+//
+// HTTP Method: GET
+// Endpoint: /api/payments
+//
+// Query Parameters:
+//   - page, page_size, cursor, sort: Pagination and sorting (see package query)
+//   - status, adoption, donation, from, to: Filters
+//
+// Response:
+//   - Success: Page of payments, most recent first by default
+//   - Error: HTTP error with appropriate status code
+func handleListPayments(c echo.Context) error {
+	payments, httpErr := handlers.HandleListPayments(c.Path(), c.QueryParams(), currentOrganizationID(c))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, payments)
+}
+
+// handleGetPayment processes staff requests to retrieve a payment.
+//
+// HTTP Method: GET
+// Endpoint: /api/payments/:id
+//
+// Response:
+//   - Success: Payment with its refunds
+//   - Error: 404 unknown payment
+func handleGetPayment(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de pago inválido")
+	}
+
+	payment, httpErr := handlers.HandleGetPayment(uint(id), currentOrganizationID(c))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, payment)
+}
+
+// handleRefundPayment processes manager requests to refund a payment.
+//
+// HTTP Method: POST
+// Endpoint: /api/payments/:id/refund
+// Content-Type: application/json
+//
+// Request Body:
+//   - See r_models.RefundRequest
+//
+// Response:
+//   - Success: Payment with its refunds
+//   - Error: 404 unknown payment, 409 not paid or amount above what is left, 502 provider error
+func handleRefundPayment(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de pago inválido")
+	}
+
+	var req r_models.RefundRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "datos de reembolso inválidos")
+	}
+
+	payment, httpErr := handlers.HandleRefundPayment(uint(id), currentOrganizationID(c), currentUser(c).ID, req)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, payment)
+}
+
+// ========================================
+// PAYMENT PROVIDER ROUTE HANDLERS
+// ========================================
+
+// handlePaymentWebhook processes the webhook callbacks of the payment provider.
+// The raw body is passed on untouched, since the signature is computed over it.
+//
+// HTTP Method: POST
+// Endpoint: /api/payments/webhook/:provider
+//
+// Response:
+//   - Success: {"status": "received"} (also for duplicated events, which are ignored)
+//   - Error: 400 invalid event, 401 invalid signature, 404 unknown provider
+func handlePaymentWebhook(c echo.Context) error {
+	body, err := io.ReadAll(io.LimitReader(c.Request().Body, maxWebhookBytes))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "no se pudo leer el webhook")
+	}
+
+	httpErr := handlers.HandlePaymentWebhook(c.Param("provider"), c.Request().Header, body)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, map[string]string{"status": "received"})
+}
+
+// handleFakeCheckoutPage serves the checkout page of the fake payment provider.
+//
+// HTTP Method: GET
+// Endpoint: /api/payments/fake/checkout/:id
+//
+// Response:
+//   - Success: HTML page with one button per outcome
+//   - Error: 404 unknown checkout or fake provider not in use
+func handleFakeCheckoutPage(c echo.Context) error {
+	page, httpErr := handlers.HandleFakeCheckoutPage(c.Param("id"))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return c.HTMLBlob(http.StatusOK, page)
+}
+
+// handleCompleteFakeCheckout processes the outcome chosen on the fake checkout page:
+// the provider's signed webhook is processed and the payer is sent back to the frontend.
+//
+// HTTP Method: POST
+// Endpoint: /api/payments/fake/checkout/:id
+// Content-Type: application/x-www-form-urlencoded
+//
+// Form Fields:
+//   - outcome: "paid", "failed" or "expired"
+//
+// Response:
+//   - Success: 303 redirect to the success or cancel URL of the checkout
+//   - Error: 400 invalid outcome, 404 unknown checkout or fake provider not in use
+func handleCompleteFakeCheckout(c echo.Context) error {
+	returnURL, httpErr := handlers.HandleCompleteFakeCheckout(c.Param("id"), c.FormValue("outcome"))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return c.Redirect(http.StatusSeeOther, returnURL)
+}
@@ -26,19 +26,30 @@ import (
 //
 // Endpoint Organization:
 // - GET /api/pets: List all pets
+// - GET /api/pets/search: Full-text search over name, breed and description
+// - GET /api/pets/chip/:number: Identify a pet by its microchip number
 // - GET /api/pets/:id: Get specific pet by ID
 // - POST /api/pets: Create new pet
 // - PUT /api/pets/:id: Update existing pet
 // - DELETE /api/pets/:id: Delete pet by ID
 //
+// List, search and detail endpoints accept an optional session to flag the
+// caller's favourites (and, for staff, include favourite counts). The microchip
+// lookup only includes the adopter's contact details for staff.
+//
+// Write endpoints require a staff session and act on the staff member's organisation
+// (see requireOrganization); public endpoints span every organisation.
+//
 // Parameters:
 //   - e: Echo router instance for endpoint registration
 func RegisterPetRoutes(e *echo.Echo) {
-	e.GET("/api/pets", handleListPets)
-	e.GET("/api/pets/:id", handleGetPetByID)
-	e.POST("/api/pets", handleCreatePet)
-	e.PUT("/api/pets/:id", handleUpdatePet)
-	e.DELETE("/api/pets/:id", handleDeletePet)
+	e.GET("/api/pets", handleListPets, optionalSession)
+	e.GET("/api/pets/search", handleSearchPets, optionalSession)
+	e.GET("/api/pets/chip/:number", handleLookupMicrochip, optionalSession)
+	e.GET("/api/pets/:id", handleGetPetByID, optionalSession)
+	e.POST("/api/pets", handleCreatePet, requireSession, requireStaff, requireOrganization)
+	e.PUT("/api/pets/:id", handleUpdatePet, requireSession, requireStaff, requireOrganization)
+	e.DELETE("/api/pets/:id", handleDeletePet, requireSession, requireStaff, requireOrganization)
 }
 
 // ========================================
@@ -51,12 +62,16 @@ func RegisterPetRoutes(e *echo.Echo) {
 // HTTP Method: GET
 // Endpoint: /api/pets
 //
+// Query Parameters:
+//   - page, page_size, cursor, sort: Pagination and sorting (see package query)
+//   - species, breed, status, age_min, age_max, adopted, organization, in_foster: Filters
+//
 // Response:
-//   - Success: Array of simplified pet data with adoption status
+//   - Success: Page of simplified pet data with total count and next/prev links
 //   - Error: HTTP error with appropriate status code
 func handleListPets(c echo.Context) error {
 	// Delegate pet listing to handler layer
-	pets, httpErr := handlers.HandleListPets()
+	pets, httpErr := handlers.HandleListPets(c.Path(), c.QueryParams(), currentUser(c))
 	if httpErr.Code != 0 {
 		return response.ConvertToErrorResponse(c, httpErr)
 	}
@@ -64,6 +79,51 @@ func handleListPets(c echo.Context) error {
 	return response.MarshalResponse(c, pets)
 }
 
+// handleSearchPets processes full-text pet search requests.
+// Results are ranked by relevance and include highlighted snippets.
+//
+// HTTP Method: GET
+// Endpoint: /api/pets/search
+//
+// Query Parameters:
+//   - q: Search text (required), e.g. "perro pequeño bueno con niños"
+//   - page, page_size, sort: Pagination and sorting; sort defaults to -relevance
+//   - species, breed, status, age_min, age_max, adopted, organization, in_foster: Same filters as /api/pets
+//
+// Response:
+//   - Success: Page of results with relevance and highlights (matches wrapped in <mark>)
+//   - Error: HTTP error with appropriate status code
+func handleSearchPets(c echo.Context) error {
+	// Delegate pet search to handler layer
+	results, httpErr := handlers.HandleSearchPets(c.Path(), c.QueryParams(), currentUser(c))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, results)
+}
+
+// handleLookupMicrochip processes requests to identify a pet by its microchip number.
+// Used by the shelter desk and finders to reunite lost pets.
+//
+// HTTP Method: GET
+// Endpoint: /api/pets/chip/:number
+// Path Parameters:
+//   - number: 15-digit ISO 11784/11785 microchip number
+//
+// Response:
+//   - Success: Identified pet, with adopter contact details for staff only
+//   - Error: 400 for invalid numbers, 404 when no pet has the microchip
+func handleLookupMicrochip(c echo.Context) error {
+	// Delegate lookup to handler layer
+	lookup, httpErr := handlers.HandleLookupMicrochip(c.Param("number"), currentUser(c))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, lookup)
+}
+
 // handleGetPetByID processes requests to retrieve a specific pet by its ID.
 // Returns complete pet information including all details and relationships.
 //
@@ -81,13 +141,13 @@ func handleGetPetByID(c echo.Context) error {
 		return response.ErrorResponse(c, http.StatusBadRequest, "ID de mascota inválido")
 	}
 
-	_, httpErr := handlers.HandleGetPetByID(uint(id))
+	_, httpErr := handlers.HandleGetPetByID(uint(id), currentUser(c))
 	if httpErr.Code != 0 {
 		return response.ConvertToErrorResponse(c, httpErr)
 	}
 
 	// Delegate pet retrieval to handler layer
-	pet, httpErr := handlers.HandleGetPetByID(uint(id))
+	pet, httpErr := handlers.HandleGetPetByID(uint(id), currentUser(c))
 	if httpErr.Code != 0 {
 		return response.ConvertToErrorResponse(c, httpErr)
 	}
@@ -117,7 +177,7 @@ func handleCreatePet(c echo.Context) error {
 	}
 
 	// Delegate pet creation to handler layer
-	created, httpErr := handlers.HandleCreatePet(&pet)
+	created, httpErr := handlers.HandleCreatePet(&pet, currentOrganizationID(c))
 	if httpErr.Code != 0 {
 		return response.ConvertToErrorResponse(c, httpErr)
 	}
@@ -159,7 +219,7 @@ func handleUpdatePet(c echo.Context) error {
 	pet.ID = uint(id)
 
 	// Delegate pet update to handler layer
-	updated, httpErr := handlers.HandleUpdatePet(&pet)
+	updated, httpErr := handlers.HandleUpdatePet(&pet, currentOrganizationID(c))
 	if httpErr.Code != 0 {
 		return response.ConvertToErrorResponse(c, httpErr)
 	}
@@ -186,7 +246,7 @@ func handleDeletePet(c echo.Context) error {
 	}
 
 	// Delegate pet deletion to handler layer
-	httpErr := handlers.HandleDeletePet(uint(id))
+	httpErr := handlers.HandleDeletePet(uint(id), currentOrganizationID(c))
 	if httpErr.Code != 0 {
 		return response.ConvertToErrorResponse(c, httpErr)
 	}
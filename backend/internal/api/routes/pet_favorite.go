@@ -0,0 +1,105 @@
+// Package api implements HTTP route handlers and endpoint registration for pet favourites.
+// This layer is responsible for:
+// - HTTP endpoint registration and routing for favourite operations
+// - Resolving the current user through the session middleware
+// - Calling appropriate handler functions for favourite management
+package api
+
+import (
+	"backend/internal/api/handlers"
+	response "backend/internal/utils/rest"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ========================================
+// ROUTE REGISTRATION
+// ========================================
+
+// RegisterPetFavoriteRoutes registers all pet favourite HTTP endpoints with the Echo router.
+// Every endpoint requires a session.
+//
+// Endpoint Organization:
+// - POST /api/pets/:id/favorite: Add a pet to the current user's favourites
+// - DELETE /api/pets/:id/favorite: Remove a pet from the current user's favourites
+// - GET /api/users/me/favorites: List the current user's favourite pets
+//
+// Parameters:
+//   - e: Echo router instance for endpoint registration
+func RegisterPetFavoriteRoutes(e *echo.Echo) {
+	e.POST("/api/pets/:id/favorite", handleAddFavorite, requireSession)
+	e.DELETE("/api/pets/:id/favorite", handleRemoveFavorite, requireSession)
+	e.GET("/api/users/me/favorites", handleListFavorites, requireSession)
+}
+
+// ========================================
+// PET FAVORITE ROUTE HANDLERS
+// ========================================
+
+// handleAddFavorite processes requests to favourite a pet.
+// Favouriting a pet twice has no effect.
+//
+// HTTP Method: POST
+// Endpoint: /api/pets/:id/favorite
+//
+// Response:
+//   - Success: {"status": "favorited"}
+//   - Error: 404 when the pet does not exist
+func handleAddFavorite(c echo.Context) error {
+	petID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de mascota inválido")
+	}
+
+	httpErr := handlers.HandleAddFavorite(currentUser(c).ID, uint(petID))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, map[string]string{"status": "favorited"})
+}
+
+// handleRemoveFavorite processes requests to remove a pet from the favourites.
+// Removing a pet that is not a favourite has no effect.
+//
+// HTTP Method: DELETE
+// Endpoint: /api/pets/:id/favorite
+//
+// Response:
+//   - Success: {"status": "deleted"}
+//   - Error: HTTP error with appropriate status code
+func handleRemoveFavorite(c echo.Context) error {
+	petID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de mascota inválido")
+	}
+
+	httpErr := handlers.HandleRemoveFavorite(currentUser(c).ID, uint(petID))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, map[string]string{"status": "deleted"})
+}
+
+// handleListFavorites processes requests to list the current user's favourite pets.
+//
+// HTTP Method: GET
+// Endpoint: /api/users/me/favorites
+//
+// Query Parameters:
+//   - Same pagination, sorting and filters as /api/pets
+//
+// Response:
+//   - Success: Page of simplified pet data (favorited is always true)
+//   - Error: HTTP error with appropriate status code
+func handleListFavorites(c echo.Context) error {
+	pets, httpErr := handlers.HandleListFavorites(currentUser(c), c.Path(), c.QueryParams())
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, pets)
+}
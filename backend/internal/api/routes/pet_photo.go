@@ -0,0 +1,230 @@
+// Package api implements HTTP route handlers and endpoint registration for pet photos.
+// This layer is responsible for:
+// - HTTP endpoint registration and routing for pet photo operations
+// - Multipart request parsing and request size limiting
+// - Calling appropriate handler functions for pet photo management
+// - Streaming stored images back to clients
+package api
+
+import (
+	"backend/internal/api/handlers"
+	r_models "backend/internal/api/routes/models"
+	response "backend/internal/utils/rest"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ========================================
+// ROUTE REGISTRATION
+// ========================================
+
+// RegisterPetPhotoRoutes registers all pet photo HTTP endpoints with the Echo router.
+//
+// Endpoint Organization:
+// - GET /api/pets/:id/photos: List photos of a pet
+// - POST /api/pets/:id/photos: Upload a photo (multipart field "photo")
+// - PUT /api/pets/:id/photos/order: Change photo display order
+// - PUT /api/pets/:id/photos/:photoId/primary: Mark photo as primary
+// - DELETE /api/pets/:id/photos/:photoId: Delete a photo
+// - GET /api/pets/:id/photos/:photoId/:variant: Download original or thumbnail
+//
+// Upload, order, primary and delete endpoints require a staff session and only
+// accept pets of the staff member's organisation (see requireOrganization).
+//
+// Parameters:
+//   - e: Echo router instance for endpoint registration
+func RegisterPetPhotoRoutes(e *echo.Echo) {
+	e.GET("/api/pets/:id/photos", handleListPetPhotos)
+	e.POST("/api/pets/:id/photos", handleUploadPetPhoto, requireSession, requireStaff, requireOrganization)
+	e.PUT("/api/pets/:id/photos/order", handleReorderPetPhotos, requireSession, requireStaff, requireOrganization)
+	e.PUT("/api/pets/:id/photos/:photoId/primary", handleSetPrimaryPetPhoto, requireSession, requireStaff, requireOrganization)
+	e.DELETE("/api/pets/:id/photos/:photoId", handleDeletePetPhoto, requireSession, requireStaff, requireOrganization)
+	e.GET("/api/pets/:id/photos/:photoId/:variant", handleGetPetPhotoContent)
+}
+
+// ========================================
+// PET PHOTO ROUTE HANDLERS
+// ========================================
+
+// handleListPetPhotos processes requests to list the photos of a pet.
+//
+// HTTP Method: GET
+// Endpoint: /api/pets/:id/photos
+//
+// Response:
+//   - Success: Array of photos ordered by position, with original and thumbnail URLs
+//   - Error: HTTP error with appropriate status code
+func handleListPetPhotos(c echo.Context) error {
+	petID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de mascota inválido")
+	}
+
+	photos, httpErr := handlers.HandleListPetPhotos(uint(petID))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, photos)
+}
+
+// handleUploadPetPhoto processes photo upload requests.
+//
+// HTTP Method: POST
+// Endpoint: /api/pets/:id/photos
+// Content-Type: multipart/form-data
+//
+// Form Fields:
+//   - photo: Image file (JPEG, PNG, GIF or WebP)
+//
+// Response:
+//   - Success: Created photo with original and thumbnail URLs
+//   - Error: 400 invalid image, 413 file too large, 415 unsupported type
+func handleUploadPetPhoto(c echo.Context) error {
+	petID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de mascota inválido")
+	}
+
+	// Limit the whole request body, leaving room for multipart overhead
+	req := c.Request()
+	req.Body = http.MaxBytesReader(c.Response(), req.Body, handlers.MaxPhotoBytes+1<<20)
+
+	file, err := c.FormFile("photo")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return response.ErrorResponse(c, http.StatusRequestEntityTooLarge, "la foto supera el tamaño máximo permitido")
+		}
+		return response.ErrorResponse(c, http.StatusBadRequest, "el campo 'photo' es obligatorio")
+	}
+
+	photo, httpErr := handlers.HandleUploadPetPhoto(uint(petID), currentOrganizationID(c), file)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, photo)
+}
+
+// handleReorderPetPhotos processes requests to change the display order of a pet's photos.
+//
+// HTTP Method: PUT
+// Endpoint: /api/pets/:id/photos/order
+// Content-Type: application/json
+//
+// Request Body:
+//   - photo_ids: Every photo ID of the pet in the desired order
+//
+// Response:
+//   - Success: Photos in their new order
+//   - Error: HTTP error with appropriate status code
+func handleReorderPetPhotos(c echo.Context) error {
+	petID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de mascota inválido")
+	}
+
+	var req r_models.ReorderPhotosRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "datos de orden inválidos")
+	}
+
+	photos, httpErr := handlers.HandleReorderPetPhotos(uint(petID), currentOrganizationID(c), req)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, photos)
+}
+
+// handleSetPrimaryPetPhoto processes requests to mark a photo as the pet's main photo.
+//
+// HTTP Method: PUT
+// Endpoint: /api/pets/:id/photos/:photoId/primary
+//
+// Response:
+//   - Success: Photos of the pet after the change
+//   - Error: HTTP error with appropriate status code
+func handleSetPrimaryPetPhoto(c echo.Context) error {
+	petID, photoID, err := photoPathParams(c)
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de mascota o foto inválido")
+	}
+
+	photos, httpErr := handlers.HandleSetPrimaryPetPhoto(petID, currentOrganizationID(c), photoID)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, photos)
+}
+
+// handleDeletePetPhoto processes photo deletion requests.
+//
+// HTTP Method: DELETE
+// Endpoint: /api/pets/:id/photos/:photoId
+//
+// Response:
+//   - Success: Deletion confirmation message
+//   - Error: HTTP error with appropriate status code
+func handleDeletePetPhoto(c echo.Context) error {
+	petID, photoID, err := photoPathParams(c)
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de mascota o foto inválido")
+	}
+
+	httpErr := handlers.HandleDeletePetPhoto(petID, currentOrganizationID(c), photoID)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, map[string]string{"status": "deleted"})
+}
+
+// handleGetPetPhotoContent streams a stored photo variant to the client.
+//
+// HTTP Method: GET
+// Endpoint: /api/pets/:id/photos/:photoId/:variant
+// Path Parameters:
+//   - variant: "original", "small", "medium" or "large"
+//
+// Response:
+//   - Success: Raw image bytes with the matching Content-Type
+//   - Error: HTTP error with appropriate status code
+func handleGetPetPhotoContent(c echo.Context) error {
+	petID, photoID, err := photoPathParams(c)
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de mascota o foto inválido")
+	}
+
+	content, contentType, httpErr := handlers.HandleGetPetPhotoContent(petID, photoID, c.Param("variant"))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+	defer content.Close()
+
+	// Stored objects are immutable: a new upload always gets a new key
+	c.Response().Header().Set("Cache-Control", "public, max-age=86400")
+	c.Response().Header().Set("X-Content-Type-Options", "nosniff")
+
+	return c.Stream(http.StatusOK, contentType, content)
+}
+
+// photoPathParams extracts the pet and photo IDs from the request path.
+func photoPathParams(c echo.Context) (uint, uint, error) {
+	petID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	photoID, err := strconv.Atoi(c.Param("photoId"))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return uint(petID), uint(photoID), nil
+}
@@ -0,0 +1,185 @@
+// Package api implements HTTP route handlers and endpoint registration for saved searches.
+// This layer is responsible for:
+// - HTTP endpoint registration and routing for saved search operations
+// - Request parsing and user resolution through the session middleware
+// - Calling appropriate handler functions for saved search management
+// - Serving unsubscribe links from alert emails
+package api
+
+import (
+	"backend/internal/api/handlers"
+	r_models "backend/internal/api/routes/models"
+	response "backend/internal/utils/rest"
+	"html"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ========================================
+// ROUTE REGISTRATION
+// ========================================
+
+// RegisterSavedSearchRoutes registers all saved search HTTP endpoints with the Echo router.
+//
+// Endpoint Organization:
+// - GET /api/users/me/saved-searches: List saved searches of the current user
+// - POST /api/users/me/saved-searches: Save a new search
+// - PUT /api/users/me/saved-searches/:id: Replace a saved search
+// - DELETE /api/users/me/saved-searches/:id: Delete a saved search
+// - GET/POST /api/saved-searches/unsubscribe: Disable alerts from an email link (public)
+//
+// Parameters:
+//   - e: Echo router instance for endpoint registration
+func RegisterSavedSearchRoutes(e *echo.Echo) {
+	e.GET("/api/users/me/saved-searches", handleListSavedSearches, requireSession)
+	e.POST("/api/users/me/saved-searches", handleCreateSavedSearch, requireSession)
+	e.PUT("/api/users/me/saved-searches/:id", handleUpdateSavedSearch, requireSession)
+	e.DELETE("/api/users/me/saved-searches/:id", handleDeleteSavedSearch, requireSession)
+	e.GET("/api/saved-searches/unsubscribe", handleUnsubscribeSearchAlerts)
+	e.POST("/api/saved-searches/unsubscribe", handleUnsubscribeSearchAlertsOneClick)
+}
+
+// ========================================
+// SAVED SEARCH ROUTE HANDLERS
+// ========================================
+
+// handleListSavedSearches processes requests to list the saved searches of the current user.
+//
+// HTTP Method: GET
+// Endpoint: /api/users/me/saved-searches
+//
+// Response:
+//   - Success: Array of saved searches, newest first
+//   - Error: HTTP error with appropriate status code
+func handleListSavedSearches(c echo.Context) error {
+	searches, httpErr := handlers.HandleListSavedSearches(currentUser(c).ID)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, searches)
+}
+
+// handleCreateSavedSearch processes requests to save a new search.
+//
+// HTTP Method: POST
+// Endpoint: /api/users/me/saved-searches
+// Content-Type: application/json
+//
+// Request Body:
+//   - SavedSearchRequest: name, species, breed, age_min, age_max, keywords, alerts_enabled
+//
+// Response:
+//   - Success: Created saved search
+//   - Error: 400 invalid criteria, 409 too many saved searches
+func handleCreateSavedSearch(c echo.Context) error {
+	var req r_models.SavedSearchRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "datos de búsqueda inválidos")
+	}
+
+	search, httpErr := handlers.HandleCreateSavedSearch(currentUser(c).ID, req)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, search)
+}
+
+// handleUpdateSavedSearch processes requests to replace a saved search.
+//
+// HTTP Method: PUT
+// Endpoint: /api/users/me/saved-searches/:id
+// Content-Type: application/json
+//
+// Request Body:
+//   - SavedSearchRequest: name, species, breed, age_min, age_max, keywords, alerts_enabled
+//
+// Response:
+//   - Success: Updated saved search
+//   - Error: 400 invalid criteria, 404 not found
+func handleUpdateSavedSearch(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de búsqueda inválido")
+	}
+
+	var req r_models.SavedSearchRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "datos de búsqueda inválidos")
+	}
+
+	search, httpErr := handlers.HandleUpdateSavedSearch(currentUser(c).ID, uint(id), req)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, search)
+}
+
+// handleDeleteSavedSearch processes requests to delete a saved search.
+//
+// HTTP Method: DELETE
+// Endpoint: /api/users/me/saved-searches/:id
+//
+// Response:
+//   - Success: {"status": "deleted"}
+//   - Error: HTTP error with appropriate status code
+func handleDeleteSavedSearch(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de búsqueda inválido")
+	}
+
+	httpErr := handlers.HandleDeleteSavedSearch(currentUser(c).ID, uint(id))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, map[string]string{"status": "deleted"})
+}
+
+// handleUnsubscribeSearchAlerts processes unsubscribe links opened from alert emails.
+//
+// HTTP Method: GET
+// Endpoint: /api/saved-searches/unsubscribe?token=...
+//
+// Response:
+//   - Success: Short HTML confirmation page
+//   - Error: HTML page with the error message
+func handleUnsubscribeSearchAlerts(c echo.Context) error {
+	httpErr := handlers.HandleUnsubscribeSearchAlerts(c.QueryParam("token"))
+	if httpErr.Code != 0 {
+		return c.HTML(httpErr.Code, unsubscribePage("No se ha podido completar la baja", httpErr.Message))
+	}
+
+	return c.HTML(http.StatusOK, unsubscribePage("Baja completada", "Ya no recibirás alertas de esta búsqueda."))
+}
+
+// handleUnsubscribeSearchAlertsOneClick processes one-click unsubscribe requests
+// sent by mail clients (RFC 8058 List-Unsubscribe-Post).
+//
+// HTTP Method: POST
+// Endpoint: /api/saved-searches/unsubscribe?token=...
+//
+// Response:
+//   - Success: {"status": "unsubscribed"}
+//   - Error: HTTP error with appropriate status code
+func handleUnsubscribeSearchAlertsOneClick(c echo.Context) error {
+	httpErr := handlers.HandleUnsubscribeSearchAlerts(c.QueryParam("token"))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, map[string]string{"status": "unsubscribed"})
+}
+
+// unsubscribePage renders the minimal page shown after following an unsubscribe link.
+func unsubscribePage(title string, message string) string {
+	title, message = html.EscapeString(title), html.EscapeString(message)
+	return `<!DOCTYPE html><html lang="es"><head><meta charset="UTF-8"><title>` + title +
+		`</title></head><body style="font-family: Arial, sans-serif; text-align: center; padding: 40px;"><h1>` + title +
+		`</h1><p>` + message + `</p></body></html>`
+}
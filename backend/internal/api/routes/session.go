@@ -0,0 +1,192 @@
+// Package api implements the session middleware used by authenticated endpoints.
+// This layer is responsible for:
+// - Reading the session identifier from the request
+// - Resolving the current user through the handler layer
+// - Making the current user available to route handlers
+// - Restricting staff-only and admin-only endpoints
+// - Resolving the organisation staff requests act on
+package api
+
+import (
+	"backend/internal/api/handlers"
+	m "backend/internal/models"
+	response "backend/internal/utils/rest"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// sessionCookieName is the cookie where the web application stores the session identifier.
+const sessionCookieName = "sessionID"
+
+// currentUserKey is the echo context key holding the authenticated user.
+const currentUserKey = "currentUser"
+
+// currentMembershipKey is the echo context key holding the organisation membership of a staff request.
+const currentMembershipKey = "currentMembership"
+
+// organizationHeader is the request header selecting the organisation a staff request acts on.
+const organizationHeader = "X-Organization-ID"
+
+// requireSession is an Echo middleware that rejects requests without a valid session.
+//
+// Session Lookup:
+// - Authorization: Bearer <session_id> header
+// - sessionID cookie, as stored by the web application
+//
+// Response:
+//   - 401 when the session is missing or unknown
+//   - 403 when the user is blocked
+func requireSession(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		user, httpErr := handlers.HandleAuthenticateSession(sessionID(c))
+		if httpErr.Code != 0 {
+			return response.ConvertToErrorResponse(c, httpErr)
+		}
+
+		c.Set(currentUserKey, user)
+		return next(c)
+	}
+}
+
+// optionalSession is an Echo middleware for public endpoints whose response depends on the caller.
+// It resolves the current user like requireSession but lets anonymous requests
+// (or requests with an invalid session) through without a user.
+func optionalSession(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if id := sessionID(c); id != "" {
+			if user, httpErr := handlers.HandleAuthenticateSession(id); httpErr.Code == 0 {
+				c.Set(currentUserKey, user)
+			}
+		}
+
+		return next(c)
+	}
+}
+
+// requireStaff is an Echo middleware that rejects requests from users without a staff or admin role.
+// It must run after requireSession.
+//
+// Response:
+//   - 403 when the current user is not staff
+func requireStaff(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if user := currentUser(c); user == nil || !user.IsStaff() {
+			return response.ErrorResponse(c, http.StatusForbidden, "acceso restringido al personal del refugio")
+		}
+
+		return next(c)
+	}
+}
+
+// requireAdmin is an Echo middleware that rejects requests from users without the admin role.
+// It must run after requireSession.
+//
+// Response:
+//   - 403 when the current user is not an admin
+func requireAdmin(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if user := currentUser(c); user == nil || user.Role != m.UserRoleAdmin {
+			return response.ErrorResponse(c, http.StatusForbidden, "acceso restringido a administradores")
+		}
+
+		return next(c)
+	}
+}
+
+// requireSelfOrAdmin is an Echo middleware for endpoints acting on the user given by the :id parameter.
+// It must run after requireSession.
+//
+// Response:
+//   - 400 when the user ID is invalid
+//   - 403 when the current user is neither that user nor an admin
+func requireSelfOrAdmin(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			return response.ErrorResponse(c, http.StatusBadRequest, "ID de usuario inválido")
+		}
+
+		user := currentUser(c)
+		if user == nil || (user.ID != uint(id) && user.Role != m.UserRoleAdmin) {
+			return response.ErrorResponse(c, http.StatusForbidden, "solo puedes modificar tu propia cuenta")
+		}
+
+		return next(c)
+	}
+}
+
+// requireOrganization is an Echo middleware that resolves the organisation a staff request acts on.
+// It must run after requireSession and requireStaff.
+//
+// Organisation Lookup:
+// - X-Organization-ID header; the user must be a member (admins may act on any organisation)
+// - Otherwise the user's only membership
+//
+// Response:
+//   - 400 when the header is invalid, or missing for members of several organisations
+//   - 403 when the user is not a member of the organisation
+//   - 404 when the organisation does not exist
+func requireOrganization(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		membership, httpErr := handlers.HandleResolveOrganization(currentUser(c), c.Request().Header.Get(organizationHeader))
+		if httpErr.Code != 0 {
+			return response.ConvertToErrorResponse(c, httpErr)
+		}
+
+		c.Set(currentMembershipKey, membership)
+		return next(c)
+	}
+}
+
+// requireOrgManager is an Echo middleware that rejects requests from members who cannot manage
+// the organisation's settings and members. It must run after requireOrganization.
+//
+// Response:
+//   - 403 when the membership role is not manager
+func requireOrgManager(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if membership := currentMembership(c); membership == nil || !membership.IsManager() {
+			return response.ErrorResponse(c, http.StatusForbidden, "acceso restringido a los responsables de la organización")
+		}
+
+		return next(c)
+	}
+}
+
+// currentUser returns the user authenticated by requireSession or optionalSession,
+// or nil for anonymous requests.
+func currentUser(c echo.Context) *m.NonValidatedUser {
+	user, _ := c.Get(currentUserKey).(*m.NonValidatedUser)
+	return user
+}
+
+// currentMembership returns the organisation membership resolved by requireOrganization,
+// or nil outside organisation-scoped endpoints.
+func currentMembership(c echo.Context) *m.OrganizationMember {
+	membership, _ := c.Get(currentMembershipKey).(*m.OrganizationMember)
+	return membership
+}
+
+// currentOrganizationID returns the organisation a staff request acts on.
+// It must only be used in handlers behind requireOrganization.
+func currentOrganizationID(c echo.Context) uint {
+	return currentMembership(c).OrganizationID
+}
+
+// sessionID extracts the session identifier from the Authorization header or the session cookie.
+func sessionID(c echo.Context) string {
+	if auth := c.Request().Header.Get(echo.HeaderAuthorization); auth != "" {
+		if token, ok := strings.CutPrefix(auth, "Bearer "); ok {
+			return strings.TrimSpace(token)
+		}
+	}
+
+	if cookie, err := c.Cookie(sessionCookieName); err == nil {
+		return cookie.Value
+	}
+
+	return ""
+}
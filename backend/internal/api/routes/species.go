@@ -33,13 +33,16 @@ import (
 // Note: PUT endpoint not implemented as species updates are typically restricted
 // to maintain data integrity with existing pet records.
 //
+// Write endpoints require a staff session and act on the staff member's organisation
+// (see requireOrganization); public endpoints span every organisation.
+//
 // Parameters:
 //   - e: Echo router instance for endpoint registration
 func RegisterSpeciesRoutes(e *echo.Echo) {
 	e.GET("/api/species", handleListSpecies)
 	e.GET("/api/species/:id", handleGetSpeciesByID)
-	e.POST("/api/species", handleCreateSpecies)
-	e.DELETE("/api/species/:id", handleDeleteSpecies)
+	e.POST("/api/species", handleCreateSpecies, requireSession, requireStaff, requireOrganization)
+	e.DELETE("/api/species/:id", handleDeleteSpecies, requireSession, requireStaff, requireOrganization)
 }
 
 // ========================================
@@ -52,12 +55,16 @@ func RegisterSpeciesRoutes(e *echo.Echo) {
 // HTTP Method: GET
 // Endpoint: /api/species
 //
+// Query Parameters:
+//   - page, page_size, cursor, sort: Pagination and sorting (see package query)
+//   - name, organization: Filters
+//
 // Response:
-//   - Success: Array of all species with complete information
+//   - Success: Page of species with total count and next/prev links
 //   - Error: HTTP error with appropriate status code
 func handleListSpecies(c echo.Context) error {
 	// Delegate species listing to handler layer
-	species, httpErr := handlers.HandleListSpecies()
+	species, httpErr := handlers.HandleListSpecies(c.Path(), c.QueryParams())
 	if httpErr.Code != 0 {
 		return response.ConvertToErrorResponse(c, httpErr)
 	}
@@ -113,7 +120,7 @@ func handleCreateSpecies(c echo.Context) error {
 	}
 
 	// Delegate species creation to handler layer
-	created, httpErr := handlers.HandleCreateSpecies(&species)
+	created, httpErr := handlers.HandleCreateSpecies(&species, currentOrganizationID(c))
 	if httpErr.Code != 0 {
 		return response.ConvertToErrorResponse(c, httpErr)
 	}
@@ -143,13 +150,13 @@ func handleDeleteSpecies(c echo.Context) error {
 		return response.ErrorResponse(c, http.StatusBadRequest, "ID de especie inválido")
 	}
 
-	httpErr := handlers.HandleDeleteSpecies(uint(id))
+	httpErr := handlers.HandleDeleteSpecies(uint(id), currentOrganizationID(c))
 	if httpErr.Code != 0 {
 		return response.ConvertToErrorResponse(c, httpErr)
 	}
 
 	// Delegate species deletion to handler layer
-	httpErr = handlers.HandleDeleteSpecies(uint(id))
+	httpErr = handlers.HandleDeleteSpecies(uint(id), currentOrganizationID(c))
 	if httpErr.Code != 0 {
 		return response.ConvertToErrorResponse(c, httpErr)
 	}
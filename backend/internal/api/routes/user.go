@@ -27,17 +27,18 @@ import (
 //
 // Endpoint Organization:
 // - User CRUD operations: Standard REST endpoints for user management
+// - Listing users requires an admin; updating and deleting require the user themselves or an admin
 // - Authentication endpoints: Login and 2FA verification endpoints
 //
 // Parameters:
 //   - e: Echo router instance for endpoint registration
 func RegisterUserRoutes(e *echo.Echo) {
 	// User CRUD operations
-	e.GET("/api/users", handleListUsers)
+	e.GET("/api/users", handleListUsers, requireSession, requireAdmin)
 	e.GET("/api/users/:id", handleGetUserByID)
 	e.POST("/api/register", handleCreateUser)
-	e.PUT("/api/users/:id", handleUpdateUser)
-	e.DELETE("/api/users/:id", handleDeleteUser)
+	e.PUT("/api/users/:id", handleUpdateUser, requireSession, requireSelfOrAdmin)
+	e.DELETE("/api/users/:id", handleDeleteUser, requireSession, requireSelfOrAdmin)
 
 	// Authentication endpoints
 	e.POST("/api/auth/login", handleLoginUser)
@@ -225,19 +226,21 @@ func handleForgotPassword(c echo.Context) error {
 // Endpoint: /api/users
 //
 // Business Rules:
-//   - Returns all user records in the system
-//   - May require administrative privileges (implement authorization middleware)
-//   - Should paginate results for large datasets (implement pagination middleware)
+//   - Returns one page of user records (page, page_size, cursor, sort)
+//   - Supports filters: name, surname, email, provider, blocked
+//   - Requires an admin session
 //
 // Parameters:
 //   - c: Echo context containing the HTTP request and response
 //
 // Returns:
-//   - HTTP 200 with array of user objects on success
+//   - HTTP 200 with a page of user objects, total count and next/prev links on success
+//   - HTTP 400 if pagination, sorting or filter parameters are invalid
+//   - HTTP 401/403 without an admin session
 //   - HTTP 500 on internal server error
 //   - Error response with appropriate status code on failure
 func handleListUsers(c echo.Context) error {
-	users, httpErr := handlers.HandleListUsers()
+	users, httpErr := handlers.HandleListUsers(c.Path(), c.QueryParams())
 	if httpErr.Code != 0 {
 		return response.ConvertToErrorResponse(c, httpErr)
 	}
@@ -321,6 +324,7 @@ func handleCreateUser(c echo.Context) error {
 //
 // Business Rules:
 //   - Requires valid user ID as URL parameter
+//   - Requires a session of that user or of an admin
 //   - Validates updated fields according to business rules
 //   - Maintains data integrity and referential constraints
 //   - Updates only provided fields (partial updates supported)
@@ -333,7 +337,8 @@ func handleCreateUser(c echo.Context) error {
 //
 // Returns:
 //   - HTTP 200 with updated user object on success
-//   - HTTP 400 if user ID is invalid or request data is malformed
+//   - HTTP 400 if user ID is invalid, request data is malformed or the locale is not supported
+//   - HTTP 401 without a session, 403 for another user's account
 //   - HTTP 404 if user not found
 //   - HTTP 409 if update would violate unique constraints
 //   - HTTP 500 on internal server error
@@ -381,7 +386,7 @@ func handleUpdateUserPassword(c echo.Context) error {
 //   - Validates that user can be safely deleted (no critical dependencies)
 //   - Maintains referential integrity with related entities
 //   - Logs deletion for audit trail and compliance
-//   - May require administrative privileges for execution
+//   - Requires a session of that user or of an admin
 //
 // Parameters:
 //   - c: Echo context containing the HTTP request and response
@@ -390,6 +395,7 @@ func handleUpdateUserPassword(c echo.Context) error {
 // Returns:
 //   - HTTP 200 with deletion confirmation on success
 //   - HTTP 400 if user ID is invalid or non-numeric
+//   - HTTP 401 without a session, 403 for another user's account
 //   - HTTP 404 if user not found or already deleted
 //   - HTTP 409 if user cannot be deleted due to dependencies
 //   - HTTP 500 on internal server error
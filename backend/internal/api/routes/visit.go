@@ -0,0 +1,361 @@
+// Package api implements HTTP route handlers and endpoint registration for meet-and-greet visits.
+// This layer is responsible for:
+// - HTTP endpoint registration and routing for availability slots and visits
+// - Restricting slot management and the visit agenda to the organisation's staff
+// - Exposing public booking and the signed management links sent to visitors
+package api
+
+import (
+	"backend/internal/api/handlers"
+	r_models "backend/internal/api/routes/models"
+	response "backend/internal/utils/rest"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ========================================
+// ROUTE REGISTRATION
+// ========================================
+
+// RegisterVisitRoutes registers all meet-and-greet visit HTTP endpoints with the Echo router.
+//
+// Endpoint Organization:
+// - GET /api/visits/slots: List availability slots (staff)
+// - POST /api/visits/slots: Publish an availability slot (staff)
+// - DELETE /api/visits/slots/:id: Delete a free slot (staff)
+// - GET /api/pets/:id/visit-slots: Free slots to visit a pet (public)
+// - GET /api/visits: List visits (staff)
+// - POST /api/visits: Book a visit (public)
+// - GET /api/visits/:id: Get a visit (staff)
+// - PUT /api/visits/:id/status: Cancel a visit or record its outcome (staff)
+// - PUT /api/visits/:id/application: Link a visit to an adoption application (staff)
+// - GET /api/visits/manage?token=...: Get the visit of a signed link (visitor)
+// - POST /api/visits/manage/cancel?token=...: Cancel the visit of a signed link (visitor)
+// - POST /api/visits/manage/reschedule?token=...: Move the visit of a signed link to another slot (visitor)
+//
+// Staff endpoints act on the organisation selected by requireOrganization. Booking accepts an
+// optional session: signed-in visitors get the visit linked to their account.
+//
+// Parameters:
+//   - e: Echo router instance for endpoint registration
+func RegisterVisitRoutes(e *echo.Echo) {
+	e.GET("/api/visits/slots", handleListVisitSlots, requireSession, requireStaff, requireOrganization)
+	e.POST("/api/visits/slots", handleCreateVisitSlot, requireSession, requireStaff, requireOrganization)
+	e.DELETE("/api/visits/slots/:id", handleDeleteVisitSlot, requireSession, requireStaff, requireOrganization)
+	e.GET("/api/pets/:id/visit-slots", handleListAvailableVisitSlots)
+
+	e.GET("/api/visits", handleListVisits, requireSession, requireStaff, requireOrganization)
+	e.POST("/api/visits", handleBookVisit, optionalSession)
+	e.GET("/api/visits/:id", handleGetVisit, requireSession, requireStaff, requireOrganization)
+	e.PUT("/api/visits/:id/status", handleUpdateVisitStatus, requireSession, requireStaff, requireOrganization)
+	e.PUT("/api/visits/:id/application", handleLinkVisitApplication, requireSession, requireStaff, requireOrganization)
+
+	e.GET("/api/visits/manage", handleGetManagedVisit)
+	e.POST("/api/visits/manage/cancel", handleCancelManagedVisit)
+	e.POST("/api/visits/manage/reschedule", handleRescheduleManagedVisit)
+}
+
+// ========================================
+// VISIT SLOT ROUTE HANDLERS
+// ========================================
+
+// handleListVisitSlots processes staff requests to list availability slots.
+//
+// HTTP Method: GET
+// Endpoint: /api/visits/slots
+//
+// Query Parameters:
+//   - page, page_size, cursor, sort: Pagination and sorting (see package query)
+//   - pet, booked, from, to: Filters
+//
+// Response:
+//   - Success: Page of slots, earliest first by default
+//   - Error: HTTP error with appropriate status code
+func handleListVisitSlots(c echo.Context) error {
+	slots, httpErr := handlers.HandleListVisitSlots(c.Path(), c.QueryParams(), currentOrganizationID(c))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, slots)
+}
+
+// handleCreateVisitSlot processes staff requests to publish an availability slot.
+//
+// HTTP Method: POST
+// Endpoint: /api/visits/slots
+// Content-Type: application/json
+//
+// Request Body:
+//   - See r_models.VisitSlotRequest
+//
+// Response:
+//   - Success: Created slot
+//   - Error: 400 invalid data, 404 unknown pet, 409 overlaps another slot of the pet
+func handleCreateVisitSlot(c echo.Context) error {
+	var req r_models.VisitSlotRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "datos de franja inválidos")
+	}
+
+	slot, httpErr := handlers.HandleCreateVisitSlot(currentOrganizationID(c), currentUser(c).ID, req)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, slot)
+}
+
+// handleDeleteVisitSlot processes staff requests to delete a free slot.
+//
+// HTTP Method: DELETE
+// Endpoint: /api/visits/slots/:id
+//
+// Response:
+//   - Success: Deletion confirmation message
+//   - Error: 404 unknown slot, 409 slot with a scheduled visit (cancel it first)
+func handleDeleteVisitSlot(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de franja inválido")
+	}
+
+	httpErr := handlers.HandleDeleteVisitSlot(uint(id), currentOrganizationID(c))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, map[string]string{"status": "deleted"})
+}
+
+// handleListAvailableVisitSlots processes public requests to list the free slots to visit a pet.
+//
+// HTTP Method: GET
+// Endpoint: /api/pets/:id/visit-slots
+//
+// Response:
+//   - Success: Free future slots reserved for the pet or open to any pet (empty for adopted pets)
+//   - Error: 404 unknown pet
+func handleListAvailableVisitSlots(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de mascota inválido")
+	}
+
+	slots, httpErr := handlers.HandleListAvailableVisitSlots(uint(id))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, slots)
+}
+
+// ========================================
+// VISIT ROUTE HANDLERS
+// ========================================
+
+// handleListVisits processes staff requests to list visits.
+//
+// HTTP Method: GET
+// Endpoint: /api/visits
+//
+// Query Parameters:
+//   - page, page_size, cursor, sort: Pagination and sorting (see package query)
+//   - status, pet, application, from, to: Filters
+//
+// Response:
+//   - Success: Page of visits with pet summaries, earliest first by default
+//   - Error: HTTP error with appropriate status code
+func handleListVisits(c echo.Context) error {
+	visits, httpErr := handlers.HandleListVisits(c.Path(), c.QueryParams(), currentOrganizationID(c))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, visits)
+}
+
+// handleBookVisit processes public requests to book a visit.
+//
+// HTTP Method: POST
+// Endpoint: /api/visits
+// Content-Type: application/json
+//
+// Request Body:
+//   - See r_models.BookVisitRequest
+//
+// Response:
+//   - Success: Booked visit (the visitor is emailed the calendar invite and the management link)
+//   - Error: 400 invalid data, 404 unknown slot or pet, 409 slot already booked or pet not available
+func handleBookVisit(c echo.Context) error {
+	var req r_models.BookVisitRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "datos de visita inválidos")
+	}
+
+	visit, httpErr := handlers.HandleBookVisit(req, currentUser(c))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, visit)
+}
+
+// handleGetVisit processes staff requests to retrieve a visit.
+//
+// HTTP Method: GET
+// Endpoint: /api/visits/:id
+//
+// Response:
+//   - Success: Visit with pet summary
+//   - Error: 404 when the visit does not exist in the organisation
+func handleGetVisit(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de visita inválido")
+	}
+
+	visit, httpErr := handlers.HandleGetVisit(uint(id), currentOrganizationID(c))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, visit)
+}
+
+// handleUpdateVisitStatus processes staff requests to cancel a visit or record its outcome.
+//
+// HTTP Method: PUT
+// Endpoint: /api/visits/:id/status
+// Content-Type: application/json
+//
+// Request Body:
+//   - See r_models.UpdateVisitStatusRequest
+//
+// Response:
+//   - Success: Updated visit (cancelling releases the slot and emails the visitor)
+//   - Error: 400 invalid status, 404 unknown visit, 409 visit no longer scheduled
+func handleUpdateVisitStatus(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de visita inválido")
+	}
+
+	var req r_models.UpdateVisitStatusRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "datos de visita inválidos")
+	}
+
+	visit, httpErr := handlers.HandleUpdateVisitStatus(uint(id), currentOrganizationID(c), req)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, visit)
+}
+
+// handleLinkVisitApplication processes staff requests to link a visit to an adoption application.
+//
+// HTTP Method: PUT
+// Endpoint: /api/visits/:id/application
+// Content-Type: application/json
+//
+// Request Body:
+//   - application_id: Adoption application ID, or null to unlink
+//
+// Response:
+//   - Success: Updated visit
+//   - Error: 400 invalid ID, 404 unknown visit
+func handleLinkVisitApplication(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de visita inválido")
+	}
+
+	var req r_models.VisitApplicationRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "datos de solicitud inválidos")
+	}
+
+	visit, httpErr := handlers.HandleLinkVisitApplication(uint(id), currentOrganizationID(c), req)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, visit)
+}
+
+// ========================================
+// VISIT MANAGEMENT LINK ROUTE HANDLERS
+// ========================================
+
+// handleGetManagedVisit processes requests from the management link sent to the visitor.
+//
+// HTTP Method: GET
+// Endpoint: /api/visits/manage?token=...
+//
+// Response:
+//   - Success: Visit with pet summary
+//   - Error: 400 invalid link, 410 expired link, 404 unknown visit
+func handleGetManagedVisit(c echo.Context) error {
+	visit, httpErr := handlers.HandleGetManagedVisit(c.QueryParam("token"))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, visit)
+}
+
+// handleCancelManagedVisit processes cancellations from the management link sent to the visitor.
+//
+// HTTP Method: POST
+// Endpoint: /api/visits/manage/cancel?token=...
+// Content-Type: application/json
+//
+// Request Body:
+//   - reason: Why the visitor cancels (optional)
+//
+// Response:
+//   - Success: Cancelled visit (the slot is released and the calendar cancellation emailed)
+//   - Error: 400 invalid link, 410 expired link, 404 unknown visit, 409 visit no longer scheduled
+func handleCancelManagedVisit(c echo.Context) error {
+	var req r_models.CancelVisitRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "datos de cancelación inválidos")
+	}
+
+	visit, httpErr := handlers.HandleCancelManagedVisit(c.QueryParam("token"), req)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, visit)
+}
+
+// handleRescheduleManagedVisit processes reschedules from the management link sent to the visitor.
+//
+// HTTP Method: POST
+// Endpoint: /api/visits/manage/reschedule?token=...
+// Content-Type: application/json
+//
+// Request Body:
+//   - slot_id: New slot (see GET /api/pets/:id/visit-slots)
+//
+// Response:
+//   - Success: Rescheduled visit (the visitor is emailed the updated calendar invite)
+//   - Error: 400 invalid link, 410 expired link, 404 unknown visit or slot, 409 visit no longer scheduled or slot not available
+func handleRescheduleManagedVisit(c echo.Context) error {
+	var req r_models.RescheduleVisitRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "datos de cambio de visita inválidos")
+	}
+
+	visit, httpErr := handlers.HandleRescheduleManagedVisit(c.QueryParam("token"), req)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, visit)
+}
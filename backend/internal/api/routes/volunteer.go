@@ -0,0 +1,568 @@
+// Package api implements HTTP route handlers and endpoint registration for the volunteer programme.
+// This layer is responsible for:
+// - HTTP endpoint registration and routing for volunteers, shifts, signups and attendance
+// - Restricting volunteer and shift management and the hours report to the organisation's staff
+// - Letting volunteers sign up for shifts, cancel and keep their profile up to date
+package api
+
+import (
+	"backend/internal/api/handlers"
+	r_models "backend/internal/api/routes/models"
+	response "backend/internal/utils/rest"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ========================================
+// ROUTE REGISTRATION
+// ========================================
+
+// RegisterVolunteerRoutes registers all volunteer programme HTTP endpoints with the Echo router.
+//
+// Endpoint Organization:
+// - GET /api/volunteering/volunteers: List volunteers (staff)
+// - POST /api/volunteering/volunteers: Register a user as a volunteer (staff)
+// - GET /api/volunteering/volunteers/:id: Get a volunteer (staff)
+// - PUT /api/volunteering/volunteers/:id: Replace the profile of a volunteer (staff)
+// - DELETE /api/volunteering/volunteers/:id: Delete a volunteer without signups (staff)
+// - POST /api/volunteering/volunteers/:id/certifications: Record a certification (staff)
+// - DELETE /api/volunteering/volunteers/:id/certifications/:certId: Delete a certification (staff)
+// - GET /api/volunteering/shifts: List shifts (staff)
+// - POST /api/volunteering/shifts: Publish a shift (staff)
+// - GET /api/volunteering/shifts/:id: Get a shift with its signups (staff)
+// - PUT /api/volunteering/shifts/:id: Replace the details of a shift (staff)
+// - DELETE /api/volunteering/shifts/:id: Delete a shift nobody is signed up for (staff)
+// - PUT /api/volunteering/shifts/:id/attendance: Record who worked a shift (staff)
+// - GET /api/volunteering/hours: Hours worked by each volunteer in a date range (staff)
+// - GET /api/volunteering/shifts/open: Shifts the current user can sign up for
+// - POST /api/volunteering/shifts/:id/signup: Sign up for a shift (volunteer)
+// - POST /api/volunteering/shifts/:id/cancel: Cancel a signup before the deadline (volunteer)
+// - GET /api/users/me/volunteer-profiles: Volunteer profiles of the current user
+// - PUT /api/users/me/volunteer-profiles/:id: Update the current user's volunteer profile
+// - GET /api/users/me/volunteer-shifts: Shift signups of the current user
+//
+// Staff endpoints act on the organisation selected by requireOrganization.
+//
+// Parameters:
+//   - e: Echo router instance for endpoint registration
+func RegisterVolunteerRoutes(e *echo.Echo) {
+	e.GET("/api/volunteering/volunteers", handleListVolunteers, requireSession, requireStaff, requireOrganization)
+	e.POST("/api/volunteering/volunteers", handleCreateVolunteer, requireSession, requireStaff, requireOrganization)
+	e.GET("/api/volunteering/volunteers/:id", handleGetVolunteer, requireSession, requireStaff, requireOrganization)
+	e.PUT("/api/volunteering/volunteers/:id", handleUpdateVolunteer, requireSession, requireStaff, requireOrganization)
+	e.DELETE("/api/volunteering/volunteers/:id", handleDeleteVolunteer, requireSession, requireStaff, requireOrganization)
+	e.POST("/api/volunteering/volunteers/:id/certifications", handleAddVolunteerCertification, requireSession, requireStaff, requireOrganization)
+	e.DELETE("/api/volunteering/volunteers/:id/certifications/:certId", handleDeleteVolunteerCertification, requireSession, requireStaff, requireOrganization)
+
+	e.GET("/api/volunteering/shifts", handleListVolunteerShifts, requireSession, requireStaff, requireOrganization)
+	e.POST("/api/volunteering/shifts", handleCreateVolunteerShift, requireSession, requireStaff, requireOrganization)
+	e.GET("/api/volunteering/shifts/:id", handleGetVolunteerShift, requireSession, requireStaff, requireOrganization)
+	e.PUT("/api/volunteering/shifts/:id", handleUpdateVolunteerShift, requireSession, requireStaff, requireOrganization)
+	e.DELETE("/api/volunteering/shifts/:id", handleDeleteVolunteerShift, requireSession, requireStaff, requireOrganization)
+	e.PUT("/api/volunteering/shifts/:id/attendance", handleRecordVolunteerAttendance, requireSession, requireStaff, requireOrganization)
+	e.GET("/api/volunteering/hours", handleGetVolunteerHours, requireSession, requireStaff, requireOrganization)
+
+	e.GET("/api/volunteering/shifts/open", handleListOpenVolunteerShifts, requireSession)
+	e.POST("/api/volunteering/shifts/:id/signup", handleSignUpForShift, requireSession)
+	e.POST("/api/volunteering/shifts/:id/cancel", handleCancelShiftSignup, requireSession)
+	e.GET("/api/users/me/volunteer-profiles", handleListMyVolunteerProfiles, requireSession)
+	e.PUT("/api/users/me/volunteer-profiles/:id", handleUpdateMyVolunteerProfile, requireSession)
+	e.GET("/api/users/me/volunteer-shifts", handleListMyVolunteerSignups, requireSession)
+}
+
+// ========================================
+// VOLUNTEER ROUTE HANDLERS
+// ========================================
+
+// handleListVolunteers processes staff requests to list volunteers.
+//
+// HTTP Method: GET
+// Endpoint: /api/volunteering/volunteers
+//
+// Query Parameters:
+//   - page, page_size, cursor, sort: Pagination and sorting (see package query)
+//   - skill, active, user: Filters
+//
+// Response:
+//   - Success: Page of volunteers with their user and certifications
+//   - Error: HTTP error with appropriate status code
+func handleListVolunteers(c echo.Context) error {
+	volunteers, httpErr := handlers.HandleListVolunteers(c.Path(), c.QueryParams(), currentOrganizationID(c))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, volunteers)
+}
+
+// handleCreateVolunteer processes staff requests to register a volunteer.
+//
+// HTTP Method: POST
+// Endpoint: /api/volunteering/volunteers
+// Content-Type: application/json
+//
+// Request Body:
+//   - See r_models.VolunteerRequest
+//
+// Response:
+//   - Success: Created volunteer
+//   - Error: 400 invalid data, 404 unknown user, 409 user already volunteers for the organisation
+func handleCreateVolunteer(c echo.Context) error {
+	var req r_models.VolunteerRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "datos de voluntario inválidos")
+	}
+
+	volunteer, httpErr := handlers.HandleCreateVolunteer(currentOrganizationID(c), req)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, volunteer)
+}
+
+// handleGetVolunteer processes staff requests to retrieve a volunteer.
+//
+// HTTP Method: GET
+// Endpoint: /api/volunteering/volunteers/:id
+//
+// Response:
+//   - Success: Volunteer with their user and certifications
+//   - Error: 404 unknown volunteer
+func handleGetVolunteer(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de voluntario inválido")
+	}
+
+	volunteer, httpErr := handlers.HandleGetVolunteer(uint(id), currentOrganizationID(c))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, volunteer)
+}
+
+// handleUpdateVolunteer processes staff requests to replace the profile of a volunteer.
+//
+// HTTP Method: PUT
+// Endpoint: /api/volunteering/volunteers/:id
+// Content-Type: application/json
+//
+// Request Body:
+//   - See r_models.VolunteerRequest (user_id is ignored)
+//
+// Response:
+//   - Success: Updated volunteer
+//   - Error: 400 invalid data, 404 unknown volunteer
+func handleUpdateVolunteer(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de voluntario inválido")
+	}
+
+	var req r_models.VolunteerRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "datos de voluntario inválidos")
+	}
+
+	volunteer, httpErr := handlers.HandleUpdateVolunteer(uint(id), currentOrganizationID(c), req)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, volunteer)
+}
+
+// handleDeleteVolunteer processes staff requests to delete a volunteer.
+//
+// HTTP Method: DELETE
+// Endpoint: /api/volunteering/volunteers/:id
+//
+// Response:
+//   - Success: Deletion confirmation message
+//   - Error: 404 unknown volunteer, 409 volunteer with signups (deactivate them instead)
+func handleDeleteVolunteer(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de voluntario inválido")
+	}
+
+	httpErr := handlers.HandleDeleteVolunteer(uint(id), currentOrganizationID(c))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, map[string]string{"status": "deleted"})
+}
+
+// handleAddVolunteerCertification processes staff requests to record a certification of a volunteer.
+//
+// HTTP Method: POST
+// Endpoint: /api/volunteering/volunteers/:id/certifications
+// Content-Type: application/json
+//
+// Request Body:
+//   - See r_models.VolunteerCertificationRequest
+//
+// Response:
+//   - Success: Volunteer with the new certification
+//   - Error: 400 invalid data, 404 unknown volunteer
+func handleAddVolunteerCertification(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de voluntario inválido")
+	}
+
+	var req r_models.VolunteerCertificationRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "datos de certificación inválidos")
+	}
+
+	volunteer, httpErr := handlers.HandleAddVolunteerCertification(uint(id), currentOrganizationID(c), currentUser(c).ID, req)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, volunteer)
+}
+
+// handleDeleteVolunteerCertification processes staff requests to delete a certification of a volunteer.
+//
+// HTTP Method: DELETE
+// Endpoint: /api/volunteering/volunteers/:id/certifications/:certId
+//
+// Response:
+//   - Success: Deletion confirmation message
+//   - Error: 404 unknown volunteer or certification
+func handleDeleteVolunteerCertification(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de voluntario inválido")
+	}
+
+	certID, err := strconv.Atoi(c.Param("certId"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de certificación inválido")
+	}
+
+	httpErr := handlers.HandleDeleteVolunteerCertification(uint(certID), uint(id), currentOrganizationID(c))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, map[string]string{"status": "deleted"})
+}
+
+// handleListMyVolunteerProfiles processes requests to list the current user's volunteer profiles.
+//
+// HTTP Method: GET
+// Endpoint: /api/users/me/volunteer-profiles
+//
+// Response:
+//   - Success: Volunteer profiles with their certifications, one per organisation
+//   - Error: HTTP error with appropriate status code
+func handleListMyVolunteerProfiles(c echo.Context) error {
+	volunteers, httpErr := handlers.HandleListMyVolunteerProfiles(currentUser(c).ID)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, volunteers)
+}
+
+// handleUpdateMyVolunteerProfile processes requests to update the current user's volunteer profile.
+//
+// HTTP Method: PUT
+// Endpoint: /api/users/me/volunteer-profiles/:id
+// Content-Type: application/json
+//
+// Request Body:
+//   - See r_models.VolunteerProfileRequest
+//
+// Response:
+//   - Success: Updated volunteer profile
+//   - Error: 400 invalid data, 404 unknown profile or not the user's
+func handleUpdateMyVolunteerProfile(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de voluntario inválido")
+	}
+
+	var req r_models.VolunteerProfileRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "datos de voluntario inválidos")
+	}
+
+	volunteer, httpErr := handlers.HandleUpdateMyVolunteerProfile(uint(id), currentUser(c).ID, req)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, volunteer)
+}
+
+// ========================================
+// VOLUNTEER SHIFT ROUTE HANDLERS
+// ========================================
+
+// handleListVolunteerShifts processes staff requests to list shifts.
+//
+// HTTP Method: GET
+// Endpoint: /api/volunteering/shifts
+//
+// Query Parameters:
+//   - page, page_size, cursor, sort: Pagination and sorting (see package query)
+//   - from, to, has_places, skill: Filters
+//
+// Response:
+//   - Success: Page of shifts with their free places, earliest first by default
+//   - Error: HTTP error with appropriate status code
+func handleListVolunteerShifts(c echo.Context) error {
+	shifts, httpErr := handlers.HandleListVolunteerShifts(c.Path(), c.QueryParams(), currentOrganizationID(c))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, shifts)
+}
+
+// handleCreateVolunteerShift processes staff requests to publish a shift.
+//
+// HTTP Method: POST
+// Endpoint: /api/volunteering/shifts
+// Content-Type: application/json
+//
+// Request Body:
+//   - See r_models.VolunteerShiftRequest
+//
+// Response:
+//   - Success: Created shift
+//   - Error: 400 invalid data
+func handleCreateVolunteerShift(c echo.Context) error {
+	var req r_models.VolunteerShiftRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "datos de turno inválidos")
+	}
+
+	shift, httpErr := handlers.HandleCreateVolunteerShift(currentOrganizationID(c), currentUser(c).ID, req)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, shift)
+}
+
+// handleGetVolunteerShift processes staff requests to retrieve a shift.
+//
+// HTTP Method: GET
+// Endpoint: /api/volunteering/shifts/:id
+//
+// Response:
+//   - Success: Shift with its signups and their volunteer
+//   - Error: 404 unknown shift
+func handleGetVolunteerShift(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de turno inválido")
+	}
+
+	shift, httpErr := handlers.HandleGetVolunteerShift(uint(id), currentOrganizationID(c))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, shift)
+}
+
+// handleUpdateVolunteerShift processes staff requests to replace the details of a shift.
+//
+// HTTP Method: PUT
+// Endpoint: /api/volunteering/shifts/:id
+// Content-Type: application/json
+//
+// Request Body:
+//   - See r_models.VolunteerShiftRequest
+//
+// Response:
+//   - Success: Updated shift
+//   - Error: 400 invalid data, 404 unknown shift, 409 capacity below the signups
+func handleUpdateVolunteerShift(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de turno inválido")
+	}
+
+	var req r_models.VolunteerShiftRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "datos de turno inválidos")
+	}
+
+	shift, httpErr := handlers.HandleUpdateVolunteerShift(uint(id), currentOrganizationID(c), req)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, shift)
+}
+
+// handleDeleteVolunteerShift processes staff requests to delete a shift.
+//
+// HTTP Method: DELETE
+// Endpoint: /api/volunteering/shifts/:id
+//
+// Response:
+//   - Success: Deletion confirmation message
+//   - Error: 404 unknown shift, 409 volunteers signed up
+func handleDeleteVolunteerShift(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de turno inválido")
+	}
+
+	httpErr := handlers.HandleDeleteVolunteerShift(uint(id), currentOrganizationID(c))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, map[string]string{"status": "deleted"})
+}
+
+// handleRecordVolunteerAttendance processes staff requests to record the attendance of a shift.
+//
+// HTTP Method: PUT
+// Endpoint: /api/volunteering/shifts/:id/attendance
+// Content-Type: application/json
+//
+// Request Body:
+//   - See r_models.AttendanceRequest
+//
+// Response:
+//   - Success: Shift with its updated signups
+//   - Error: 400 invalid data, 404 unknown shift or signup, 409 shift not started yet
+func handleRecordVolunteerAttendance(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de turno inválido")
+	}
+
+	var req r_models.AttendanceRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "datos de asistencia inválidos")
+	}
+
+	shift, httpErr := handlers.HandleRecordVolunteerAttendance(uint(id), currentOrganizationID(c), currentUser(c).ID, req)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, shift)
+}
+
+// handleGetVolunteerHours processes staff requests for the hours worked by each volunteer.
+//
+// HTTP Method: GET
+// Endpoint: /api/volunteering/hours
+//
+// Query Parameters:
+//   - from: First day (YYYY-MM-DD, default first day of the month of to)
+//   - to: Last day, included (YYYY-MM-DD, default today)
+//
+// Response:
+//   - Success: Shifts, missed shifts and hours of each volunteer, most hours first
+//   - Error: 400 invalid dates
+func handleGetVolunteerHours(c echo.Context) error {
+	hours, httpErr := handlers.HandleGetVolunteerHours(currentOrganizationID(c), c.QueryParam("from"), c.QueryParam("to"))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, hours)
+}
+
+// ========================================
+// VOLUNTEER SIGNUP ROUTE HANDLERS
+// ========================================
+
+// handleListOpenVolunteerShifts processes requests to list the shifts the current user can sign up for.
+//
+// HTTP Method: GET
+// Endpoint: /api/volunteering/shifts/open
+//
+// Response:
+//   - Success: Upcoming shifts of the user's organisations with free places or already joined
+//   - Error: HTTP error with appropriate status code
+func handleListOpenVolunteerShifts(c echo.Context) error {
+	shifts, httpErr := handlers.HandleListOpenVolunteerShifts(currentUser(c).ID)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, shifts)
+}
+
+// handleSignUpForShift processes volunteer requests to sign up for a shift.
+//
+// HTTP Method: POST
+// Endpoint: /api/volunteering/shifts/:id/signup
+//
+// Response:
+//   - Success: Signup with the shift
+//   - Error: 404 unknown shift, 403 missing skill or certification, 409 shift full or already signed up
+func handleSignUpForShift(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de turno inválido")
+	}
+
+	signup, httpErr := handlers.HandleSignUpForShift(uint(id), currentUser(c).ID)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, signup)
+}
+
+// handleCancelShiftSignup processes volunteer requests to cancel their signup in a shift.
+//
+// HTTP Method: POST
+// Endpoint: /api/volunteering/shifts/:id/cancel
+//
+// Response:
+//   - Success: Cancelled signup with the shift
+//   - Error: 404 unknown shift or no active signup, 409 cancellation deadline passed
+func handleCancelShiftSignup(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de turno inválido")
+	}
+
+	signup, httpErr := handlers.HandleCancelShiftSignup(uint(id), currentUser(c).ID)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, signup)
+}
+
+// handleListMyVolunteerSignups processes requests to list the current user's shift signups.
+//
+// HTTP Method: GET
+// Endpoint: /api/users/me/volunteer-shifts
+//
+// Query Parameters:
+//   - from: Earliest shift date (YYYY-MM-DD, default today)
+//
+// Response:
+//   - Success: Signups with their shift, earliest first
+//   - Error: 400 invalid date
+func handleListMyVolunteerSignups(c echo.Context) error {
+	signups, httpErr := handlers.HandleListMyVolunteerSignups(currentUser(c).ID, c.QueryParam("from"))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, signups)
+}
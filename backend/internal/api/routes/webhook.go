@@ -0,0 +1,277 @@
+// Package api implements HTTP route handlers and endpoint registration for outbound webhooks.
+// This layer is responsible for:
+// - HTTP endpoint registration and routing for the webhooks admin API
+// - Restricting every endpoint to admins
+// - Request parameter extraction and validation
+package api
+
+import (
+	"backend/internal/api/handlers"
+	r_models "backend/internal/api/routes/models"
+	response "backend/internal/utils/rest"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ========================================
+// ROUTE REGISTRATION
+// ========================================
+
+// RegisterWebhookRoutes registers all webhook HTTP endpoints with the Echo router.
+// Every endpoint requires an admin session.
+//
+// Endpoint Organization:
+// - GET /api/admin/webhooks: List webhooks
+// - POST /api/admin/webhooks: Register a webhook (returns its signing secret)
+// - GET /api/admin/webhooks/:id: Get a webhook
+// - PUT /api/admin/webhooks/:id: Replace the endpoint, event filter and state of a webhook
+// - DELETE /api/admin/webhooks/:id: Delete a webhook and its delivery log
+// - POST /api/admin/webhooks/:id/secret: Rotate the signing secret (returns the new one)
+// - GET /api/admin/webhooks/:id/deliveries: Delivery log of a webhook
+// - GET /api/admin/webhooks/:id/deliveries/:deliveryId: Delivery with every attempt
+// - POST /api/admin/webhooks/:id/deliveries/:deliveryId/replay: Send the event of a delivery again
+//
+// Parameters:
+//   - e: Echo router instance for endpoint registration
+func RegisterWebhookRoutes(e *echo.Echo) {
+	e.GET("/api/admin/webhooks", handleListWebhooks, requireSession, requireAdmin)
+	e.POST("/api/admin/webhooks", handleCreateWebhook, requireSession, requireAdmin)
+	e.GET("/api/admin/webhooks/:id", handleGetWebhook, requireSession, requireAdmin)
+	e.PUT("/api/admin/webhooks/:id", handleUpdateWebhook, requireSession, requireAdmin)
+	e.DELETE("/api/admin/webhooks/:id", handleDeleteWebhook, requireSession, requireAdmin)
+	e.POST("/api/admin/webhooks/:id/secret", handleRotateWebhookSecret, requireSession, requireAdmin)
+	e.GET("/api/admin/webhooks/:id/deliveries", handleListWebhookDeliveries, requireSession, requireAdmin)
+	e.GET("/api/admin/webhooks/:id/deliveries/:deliveryId", handleGetWebhookDelivery, requireSession, requireAdmin)
+	e.POST("/api/admin/webhooks/:id/deliveries/:deliveryId/replay", handleReplayWebhookDelivery, requireSession, requireAdmin)
+}
+
+// ========================================
+// WEBHOOK ROUTE HANDLERS
+// ========================================
+
+// handleListWebhooks processes admin requests to list the registered webhooks.
+//
+// HTTP Method: GET
+// Endpoint: /api/admin/webhooks
+//
+// Response:
+//   - Success: Array of webhooks (without secrets)
+//   - Error: HTTP error with appropriate status code
+func handleListWebhooks(c echo.Context) error {
+	webhooks, httpErr := handlers.HandleListWebhooks()
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, webhooks)
+}
+
+// handleCreateWebhook processes admin requests to register a webhook.
+//
+// HTTP Method: POST
+// Endpoint: /api/admin/webhooks
+// Content-Type: application/json
+//
+// Request Body:
+//   - See r_models.WebhookRequest
+//
+// Response:
+//   - Success: Created webhook with signing_secret (only shown now)
+//   - Error: 400 invalid data, 404 unknown organisation
+func handleCreateWebhook(c echo.Context) error {
+	var req r_models.WebhookRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "datos de webhook inválidos")
+	}
+
+	webhook, httpErr := handlers.HandleCreateWebhook(currentUser(c).ID, req)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, webhook)
+}
+
+// handleGetWebhook processes admin requests to retrieve a webhook.
+//
+// HTTP Method: GET
+// Endpoint: /api/admin/webhooks/:id
+//
+// Response:
+//   - Success: Webhook (without its secret)
+//   - Error: 404 unknown webhook
+func handleGetWebhook(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de webhook inválido")
+	}
+
+	webhook, httpErr := handlers.HandleGetWebhook(uint(id))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, webhook)
+}
+
+// handleUpdateWebhook processes admin requests to replace the settings of a webhook.
+//
+// HTTP Method: PUT
+// Endpoint: /api/admin/webhooks/:id
+// Content-Type: application/json
+//
+// Request Body:
+//   - See r_models.WebhookRequest
+//
+// Response:
+//   - Success: Updated webhook
+//   - Error: 400 invalid data, 404 unknown webhook or organisation
+func handleUpdateWebhook(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de webhook inválido")
+	}
+
+	var req r_models.WebhookRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "datos de webhook inválidos")
+	}
+
+	webhook, httpErr := handlers.HandleUpdateWebhook(uint(id), req)
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, webhook)
+}
+
+// handleDeleteWebhook processes admin requests to delete a webhook.
+//
+// HTTP Method: DELETE
+// Endpoint: /api/admin/webhooks/:id
+//
+// Response:
+//   - Success: Deletion confirmation message
+//   - Error: 404 unknown webhook
+func handleDeleteWebhook(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de webhook inválido")
+	}
+
+	httpErr := handlers.HandleDeleteWebhook(uint(id))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, map[string]string{"status": "deleted"})
+}
+
+// handleRotateWebhookSecret processes admin requests to replace the signing secret of a webhook.
+//
+// HTTP Method: POST
+// Endpoint: /api/admin/webhooks/:id/secret
+//
+// Response:
+//   - Success: Webhook with the new signing_secret (only shown now)
+//   - Error: 404 unknown webhook
+func handleRotateWebhookSecret(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de webhook inválido")
+	}
+
+	webhook, httpErr := handlers.HandleRotateWebhookSecret(uint(id))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, webhook)
+}
+
+// ========================================
+// WEBHOOK DELIVERY ROUTE HANDLERS
+// ========================================
+
+// handleListWebhookDeliveries processes admin requests to list the deliveries of a webhook.
+//
+// HTTP Method: GET
+// Endpoint: /api/admin/webhooks/:id/deliveries
+//
+// Query Parameters:
+//   - page, page_size, cursor, sort: Pagination and sorting (see package query)
+//   - status, event, event_id, from, to: Filters
+//
+// Response:
+//   - Success: Page of deliveries, newest first by default
+//   - Error: 400 invalid parameters, 404 unknown webhook
+func handleListWebhookDeliveries(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de webhook inválido")
+	}
+
+	// The request path keeps the webhook ID in the next/prev links
+	deliveries, httpErr := handlers.HandleListWebhookDeliveries(c.Request().URL.Path, c.QueryParams(), uint(id))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, deliveries)
+}
+
+// handleGetWebhookDelivery processes admin requests to retrieve a delivery with its attempts.
+//
+// HTTP Method: GET
+// Endpoint: /api/admin/webhooks/:id/deliveries/:deliveryId
+//
+// Response:
+//   - Success: Delivery with payload and attempt_log
+//   - Error: 404 unknown delivery
+func handleGetWebhookDelivery(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de webhook inválido")
+	}
+
+	deliveryID, err := strconv.Atoi(c.Param("deliveryId"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de entrega inválido")
+	}
+
+	delivery, httpErr := handlers.HandleGetWebhookDelivery(uint(id), uint(deliveryID))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, delivery)
+}
+
+// handleReplayWebhookDelivery processes admin requests to send the event of a delivery again.
+//
+// HTTP Method: POST
+// Endpoint: /api/admin/webhooks/:id/deliveries/:deliveryId/replay
+//
+// Response:
+//   - Success: New delivery with the outcome of its first attempt
+//   - Error: 404 unknown webhook or delivery
+func handleReplayWebhookDelivery(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de webhook inválido")
+	}
+
+	deliveryID, err := strconv.Atoi(c.Param("deliveryId"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "ID de entrega inválido")
+	}
+
+	delivery, httpErr := handlers.HandleReplayWebhookDelivery(uint(id), uint(deliveryID))
+	if httpErr.Code != 0 {
+		return response.ConvertToErrorResponse(c, httpErr)
+	}
+
+	return response.MarshalResponse(c, delivery)
+}
@@ -0,0 +1,377 @@
+// Package dao implements data access objects for finalised adoptions.
+// This layer is responsible for:
+// - CRUD operations on adoption records and their contract metadata
+// - Reading and saving the contract template of each organisation
+package dao
+
+import (
+	"backend/internal/db"
+	"backend/internal/db/query"
+	m "backend/internal/models"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AdoptionListSchema is the allowlist of sort fields and filters accepted by adoption list queries.
+//
+// Filters:
+//   - pet: Pet ID
+//   - adopter: Adopter user ID
+//   - from, to: Range of the adoption date (YYYY-MM-DD)
+//
+// Sort fields: adoption_date, crt_date, id
+var AdoptionListSchema = query.Schema{
+	Sorts: map[string]query.SortColumn{
+		"id":            {Column: "id"},
+		"adoption_date": {Column: "adoption_date"},
+		"crt_date":      {Column: "crt_date"},
+	},
+	Filters: map[string]query.FilterFunc{
+		"pet":     query.Uint("pet_id"),
+		"adopter": query.Uint("adopter_user_id"),
+		"from":    query.DateFrom("adoption_date"),
+		"to":      query.DateTo("adoption_date"),
+	},
+	DefaultSort: "-adoption_date",
+}
+
+// ========================================
+// ADOPTION RETRIEVAL OPERATIONS
+// ========================================
+
+// GetAdoptions retrieves one page of an organisation's adoptions matching the list query.
+//
+// Parameters:
+//   - params: Parsed list query (see AdoptionListSchema)
+//   - orgID: Organisation whose adoptions are listed
+//
+// Returns:
+//   - *query.Page[m.Adoption]: Requested page of adoptions with total count and links
+//   - error: Database error or nil on success
+func GetAdoptions(params *query.Params, orgID uint) (*query.Page[m.Adoption], error) {
+	gormDB := db.ORMOpen()
+
+	page, err := query.Find[m.Adoption](gormDB.Model(&m.Adoption{}).Scopes(inOrganization(orgID)), params)
+	if err != nil {
+		return nil, fmt.Errorf("error al leer adopciones: %v", err)
+	}
+
+	return page, nil
+}
+
+// GetUserAdoptions retrieves the adoptions of an adopter in every organisation.
+//
+// Parameters:
+//   - userID: Unique identifier of the adopter
+//
+// Returns:
+//   - []m.Adoption: Adoptions of the user, most recent first
+//   - error: Database error or nil on success
+func GetUserAdoptions(userID uint) ([]m.Adoption, error) {
+	gormDB := db.ORMOpen()
+
+	var adoptions []m.Adoption
+	result := gormDB.Where("adopter_user_id = ?", userID).Order("adoption_date DESC, id DESC").Find(&adoptions)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer adopciones del usuario %d: %v", userID, result.Error)
+	}
+
+	return adoptions, nil
+}
+
+// GetAdoption retrieves an adoption of an organisation.
+//
+// Parameters:
+//   - id: Unique identifier of the adoption
+//   - orgID: Organisation the adoption must belong to, or AllOrganizations
+//
+// Returns:
+//   - *m.Adoption: Adoption data
+//   - error: Database error or record not found error
+func GetAdoption(id uint, orgID uint) (*m.Adoption, error) {
+	gormDB := db.ORMOpen()
+
+	var adoption m.Adoption
+	result := gormDB.Scopes(inOrganization(orgID)).First(&adoption, id)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer adopción %d: %v", id, result.Error)
+	}
+
+	return &adoption, nil
+}
+
+// GetAdoptionPets retrieves the summaries of the pets of the given adoptions.
+//
+// Parameters:
+//   - petIDs: Unique identifiers of the pets
+//
+// Returns:
+//   - map[uint]*m.SimplifiedPet: Pet summaries with primary photo by ID
+//   - error: Database error or nil on success
+func GetAdoptionPets(petIDs []uint) (map[uint]*m.SimplifiedPet, error) {
+	byID := make(map[uint]*m.SimplifiedPet, len(petIDs))
+	if len(petIDs) == 0 {
+		return byID, nil
+	}
+
+	gormDB := db.ORMOpen()
+
+	var pets []m.Pet
+	result := gormDB.Preload("Photos", "is_primary = ?", true).Where("id IN ?", petIDs).Find(&pets)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer mascotas de las adopciones: %v", result.Error)
+	}
+
+	for _, pet := range pets {
+		summary := toSimplifiedPet(pet)
+		byID[pet.ID] = &summary
+	}
+
+	return byID, nil
+}
+
+// GetAdoptionAdopters retrieves the user summaries of the given adopters.
+//
+// Parameters:
+//   - userIDs: Unique identifiers of the adopters
+//
+// Returns:
+//   - map[uint]*m.SimplifiedUser: User summaries by ID
+//   - error: Database error or nil on success
+func GetAdoptionAdopters(userIDs []uint) (map[uint]*m.SimplifiedUser, error) {
+	byID := make(map[uint]*m.SimplifiedUser, len(userIDs))
+	if len(userIDs) == 0 {
+		return byID, nil
+	}
+
+	gormDB := db.ORMOpen()
+
+	var users []m.SimplifiedUser
+	if err := gormDB.Model(&m.User{}).Where("id IN ?", userIDs).Find(&users).Error; err != nil {
+		return nil, fmt.Errorf("error al leer adoptantes: %v", err)
+	}
+
+	for i := range users {
+		byID[users[i].ID] = &users[i]
+	}
+
+	return byID, nil
+}
+
+// ========================================
+// ADOPTION WRITE OPERATIONS
+// ========================================
+
+// CreateAdoption claims a pet, inserts its adoption record and marks the pet as adopted, in one transaction.
+// The pet is locked while its status and active adoption are checked, so two adoptions of
+// the same pet finalised at the same time cannot both be recorded.
+//
+// Database Operations:
+// - SELECT ... FROM Pets WHERE id = ? AND organization_id = ? FOR UPDATE
+// - Checks the pet is not adopted and has no active adoption (unique active_pet_id)
+// - INSERT INTO Adoptions with active_pet_id = pet_id
+// - UPDATE Pets SET status = 'adopted', is_adopted, adopt_user_id, adopt_date WHERE id = ?
+//
+// Parameters:
+//   - adoption: Adoption to insert (will be updated with ID, ActivePetID and timestamps)
+//
+// Returns:
+//   - *m.Pet: Adoption fields of the pet before the adoption (ID, Status, IsAdopted, AdoptUserID, AdoptDate),
+//     nil if the pet is already adopted or has an active adoption
+//   - error: Database error or nil on success
+func CreateAdoption(adoption *m.Adoption) (*m.Pet, error) {
+	gormDB := db.ORMOpen()
+
+	var previous *m.Pet
+	err := gormDB.Transaction(func(tx *gorm.DB) error {
+		var pet m.Pet
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id", "status", "is_adopted", "adopt_user_id", "adopt_date").
+			Where("id = ? AND organization_id = ?", adoption.PetID, adoption.OrganizationID).
+			First(&pet).Error; err != nil {
+			return err
+		}
+		if pet.Status == m.PetStatusAdopted {
+			return nil
+		}
+
+		var active int64
+		if err := tx.Model(&m.Adoption{}).Where("active_pet_id = ?", adoption.PetID).Count(&active).Error; err != nil {
+			return err
+		}
+		if active > 0 {
+			return nil
+		}
+
+		adoption.ActivePetID = &adoption.PetID
+		if err := tx.Omit("Pet", "Adopter").Create(adoption).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&m.Pet{}).Where("id = ?", pet.ID).Updates(map[string]interface{}{
+			"status":        m.PetStatusAdopted,
+			"is_adopted":    true,
+			"adopt_user_id": adoption.AdopterUserID,
+			"adopt_date":    adoption.AdoptionDate,
+			"upt_date":      time.Now(),
+		}).Error; err != nil {
+			return err
+		}
+
+		previous = &pet
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error al crear adopción: %v", err)
+	}
+
+	return previous, nil
+}
+
+// EndActiveAdoption ends the active adoption of a pet, when the pet leaves the adopted status
+// (e.g. it is returned to the shelter), so it can be adopted again.
+//
+// Parameters:
+//   - petID: Unique identifier of the pet
+//
+// Returns:
+//   - error: Database error or nil on success
+func EndActiveAdoption(petID uint) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Model(&m.Adoption{}).Where("active_pet_id = ?", petID).Update("active_pet_id", nil)
+	if result.Error != nil {
+		return fmt.Errorf("error al cerrar la adopción activa de la mascota %d: %v", petID, result.Error)
+	}
+
+	return nil
+}
+
+// SetAdoptionContract records the generated contract of an adoption.
+//
+// Parameters:
+//   - adoption: Adoption with ContractKey, ContractHash and ContractGeneratedAt set
+//
+// Returns:
+//   - error: Database error or nil on success
+func SetAdoptionContract(adoption *m.Adoption) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Model(&m.Adoption{}).Where("id = ?", adoption.ID).Updates(map[string]interface{}{
+		"contract_key":          adoption.ContractKey,
+		"contract_hash":         adoption.ContractHash,
+		"contract_generated_at": adoption.ContractGeneratedAt,
+	})
+	if result.Error != nil {
+		return fmt.Errorf("error al guardar contrato de la adopción %d: %v", adoption.ID, result.Error)
+	}
+
+	return nil
+}
+
+// MarkAdoptionContractSent records when the contract was emailed to the adopter.
+//
+// Parameters:
+//   - id: Unique identifier of the adoption
+//   - sentAt: Time the email was sent
+//
+// Returns:
+//   - error: Database error or nil on success
+func MarkAdoptionContractSent(id uint, sentAt time.Time) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Model(&m.Adoption{}).Where("id = ?", id).Update("contract_sent_at", sentAt)
+	if result.Error != nil {
+		return fmt.Errorf("error al marcar contrato enviado de la adopción %d: %v", id, result.Error)
+	}
+
+	return nil
+}
+
+// UndoAdoption removes an adoption record and restores the adoption fields of its pet, in one transaction.
+// Only used to undo an adoption whose finalisation failed; callers remove the stored contract.
+//
+// Database Operations:
+// - DELETE FROM Adoptions WHERE id = ?
+// - UPDATE Pets SET status, is_adopted, adopt_user_id, adopt_date WHERE id = ? AND status = 'adopted'
+//
+// Parameters:
+//   - id: Unique identifier of the adoption
+//   - previous: Adoption fields of the pet before the adoption, as returned by CreateAdoption
+//
+// Returns:
+//   - error: Database error or nil on success
+func UndoAdoption(id uint, previous *m.Pet) error {
+	gormDB := db.ORMOpen()
+
+	err := gormDB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&m.Adoption{}, id).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&m.Pet{}).Where("id = ? AND status = ?", previous.ID, m.PetStatusAdopted).Updates(map[string]interface{}{
+			"status":        previous.Status,
+			"is_adopted":    previous.IsAdopted,
+			"adopt_user_id": previous.AdoptUserID,
+			"adopt_date":    previous.AdoptDate,
+			"upt_date":      time.Now(),
+		}).Error
+	})
+	if err != nil {
+		return fmt.Errorf("error al eliminar adopción %d: %v", id, err)
+	}
+
+	return nil
+}
+
+// ========================================
+// CONTRACT TEMPLATE OPERATIONS
+// ========================================
+
+// GetContractTemplate retrieves the contract template of an organisation.
+//
+// Parameters:
+//   - orgID: Unique identifier of the organisation
+//
+// Returns:
+//   - *m.ContractTemplate: Template, or nil if the organisation uses the default one
+//   - error: Database error or nil on success
+func GetContractTemplate(orgID uint) (*m.ContractTemplate, error) {
+	gormDB := db.ORMOpen()
+
+	var tmpl m.ContractTemplate
+	result := gormDB.Where("organization_id = ?", orgID).First(&tmpl)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer plantilla de contrato de la organización %d: %v", orgID, result.Error)
+	}
+
+	return &tmpl, nil
+}
+
+// SaveContractTemplate creates or replaces the contract template of an organisation.
+//
+// Parameters:
+//   - tmpl: Template to save (OrganizationID, texts and UpdatedBy)
+//
+// Returns:
+//   - error: Database error or nil on success
+func SaveContractTemplate(tmpl *m.ContractTemplate) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "organization_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"title", "intro", "clauses", "closing", "updated_by", "upt_date"}),
+	}).Create(tmpl)
+	if result.Error != nil {
+		return fmt.Errorf("error al guardar plantilla de contrato de la organización %d: %v", tmpl.OrganizationID, result.Error)
+	}
+
+	return nil
+}
@@ -0,0 +1,489 @@
+// Package dao implements data access objects for conversations between users and shelter staff.
+// This layer is responsible for:
+// - CRUD operations on conversations, their messages and attachments
+// - Read receipts and unread counts for each side of a conversation
+// - Finding unread messages due for an email notification
+package dao
+
+import (
+	"backend/internal/db"
+	"backend/internal/db/query"
+	m "backend/internal/models"
+	"fmt"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ConversationListSchema is the allowlist of sort fields and filters accepted by staff inbox queries.
+//
+// Filters:
+//   - status: open or closed
+//   - assigned: Assigned staff user ID
+//   - unassigned: true for conversations nobody is handling
+//   - pet: Pet ID
+//   - user: User ID of the user side
+//   - application: Adoption application ID
+//
+// Sort fields: last_message_at, crt_date, id
+var ConversationListSchema = query.Schema{
+	Sorts: map[string]query.SortColumn{
+		"id":              {Column: "id"},
+		"last_message_at": {Column: "last_message_at"},
+		"crt_date":        {Column: "crt_date"},
+	},
+	Filters: map[string]query.FilterFunc{
+		"status":      query.OneOf("status", m.ConversationStatuses...),
+		"assigned":    query.Uint("assigned_to"),
+		"unassigned":  unassignedConversationFilter,
+		"pet":         query.Uint("pet_id"),
+		"user":        query.Uint("user_id"),
+		"application": query.Uint("application_id"),
+	},
+	DefaultSort: "-last_message_at",
+}
+
+// unassignedConversationFilter keeps conversations without (true) or with (false) an assigned staff member.
+func unassignedConversationFilter(value string) (query.Scope, error) {
+	unassigned, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, fmt.Errorf("unassigned debe ser true o false")
+	}
+
+	return func(tx *gorm.DB) *gorm.DB {
+		if unassigned {
+			return tx.Where("assigned_to IS NULL")
+		}
+		return tx.Where("assigned_to IS NOT NULL")
+	}, nil
+}
+
+// ========================================
+// CONVERSATION RETRIEVAL OPERATIONS
+// ========================================
+
+// GetConversations retrieves one page of an organisation's conversations matching the inbox query.
+//
+// Parameters:
+//   - params: Parsed list query (see ConversationListSchema)
+//   - orgID: Organisation whose conversations are listed
+//
+// Returns:
+//   - *query.Page[m.Conversation]: Requested page of conversations with total count and links
+//   - error: Database error or nil on success
+func GetConversations(params *query.Params, orgID uint) (*query.Page[m.Conversation], error) {
+	gormDB := db.ORMOpen()
+
+	page, err := query.Find[m.Conversation](gormDB.Model(&m.Conversation{}).Scopes(inOrganization(orgID)), params)
+	if err != nil {
+		return nil, fmt.Errorf("error al leer conversaciones: %v", err)
+	}
+
+	return page, nil
+}
+
+// GetUserConversations retrieves the conversations of a user with every organisation.
+//
+// Parameters:
+//   - userID: Unique identifier of the user
+//
+// Returns:
+//   - []m.Conversation: Conversations of the user, most recent activity first
+//   - error: Database error or nil on success
+func GetUserConversations(userID uint) ([]m.Conversation, error) {
+	gormDB := db.ORMOpen()
+
+	var conversations []m.Conversation
+	result := gormDB.Where("user_id = ?", userID).Order("last_message_at DESC, id DESC").Find(&conversations)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer conversaciones del usuario %d: %v", userID, result.Error)
+	}
+
+	return conversations, nil
+}
+
+// GetConversation retrieves a conversation of any organisation.
+//
+// Parameters:
+//   - id: Unique identifier of the conversation
+//
+// Returns:
+//   - *m.Conversation: Conversation data
+//   - error: Database error or record not found error
+func GetConversation(id uint) (*m.Conversation, error) {
+	gormDB := db.ORMOpen()
+
+	var conversation m.Conversation
+	result := gormDB.First(&conversation, id)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer conversación %d: %v", id, result.Error)
+	}
+
+	return &conversation, nil
+}
+
+// GetConversationsByID retrieves conversations by ID.
+//
+// Parameters:
+//   - ids: Unique identifiers of the conversations
+//
+// Returns:
+//   - map[uint]*m.Conversation: Conversations by ID
+//   - error: Database error or nil on success
+func GetConversationsByID(ids []uint) (map[uint]*m.Conversation, error) {
+	byID := make(map[uint]*m.Conversation, len(ids))
+	if len(ids) == 0 {
+		return byID, nil
+	}
+
+	gormDB := db.ORMOpen()
+
+	var conversations []m.Conversation
+	if err := gormDB.Where("id IN ?", ids).Find(&conversations).Error; err != nil {
+		return nil, fmt.Errorf("error al leer conversaciones: %v", err)
+	}
+
+	for i := range conversations {
+		byID[conversations[i].ID] = &conversations[i]
+	}
+
+	return byID, nil
+}
+
+// GetConversationUsers retrieves the user summaries of the participants, assignees and senders of conversations.
+//
+// Parameters:
+//   - userIDs: Unique identifiers of the users
+//
+// Returns:
+//   - map[uint]*m.SimplifiedUser: User summaries by ID
+//   - error: Database error or nil on success
+func GetConversationUsers(userIDs []uint) (map[uint]*m.SimplifiedUser, error) {
+	byID := make(map[uint]*m.SimplifiedUser, len(userIDs))
+	if len(userIDs) == 0 {
+		return byID, nil
+	}
+
+	gormDB := db.ORMOpen()
+
+	var users []m.SimplifiedUser
+	if err := gormDB.Model(&m.User{}).Where("id IN ?", userIDs).Find(&users).Error; err != nil {
+		return nil, fmt.Errorf("error al leer usuarios de las conversaciones: %v", err)
+	}
+
+	for i := range users {
+		byID[users[i].ID] = &users[i]
+	}
+
+	return byID, nil
+}
+
+// GetConversationPets retrieves the summaries of the pets conversations are about.
+//
+// Parameters:
+//   - petIDs: Unique identifiers of the pets
+//
+// Returns:
+//   - map[uint]*m.SimplifiedPet: Pet summaries with primary photo by ID
+//   - error: Database error or nil on success
+func GetConversationPets(petIDs []uint) (map[uint]*m.SimplifiedPet, error) {
+	byID := make(map[uint]*m.SimplifiedPet, len(petIDs))
+	if len(petIDs) == 0 {
+		return byID, nil
+	}
+
+	gormDB := db.ORMOpen()
+
+	var pets []m.Pet
+	result := gormDB.Preload("Photos", "is_primary = ?", true).Where("id IN ?", petIDs).Find(&pets)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer mascotas de las conversaciones: %v", result.Error)
+	}
+
+	for _, pet := range pets {
+		summary := toSimplifiedPet(pet)
+		byID[pet.ID] = &summary
+	}
+
+	return byID, nil
+}
+
+// CountUnreadMessages counts the unread messages written by one side of each conversation.
+//
+// Parameters:
+//   - conversationIDs: Unique identifiers of the conversations
+//   - fromStaff: true to count messages from staff (unread by the user), false for messages from the user
+//
+// Returns:
+//   - map[uint]int64: Unread messages by conversation ID (conversations without unread messages are absent)
+//   - error: Database error or nil on success
+func CountUnreadMessages(conversationIDs []uint, fromStaff bool) (map[uint]int64, error) {
+	counts := make(map[uint]int64, len(conversationIDs))
+	if len(conversationIDs) == 0 {
+		return counts, nil
+	}
+
+	gormDB := db.ORMOpen()
+
+	var rows []struct {
+		ConversationID uint
+		Unread         int64
+	}
+	result := gormDB.Model(&m.Message{}).
+		Select("conversation_id, COUNT(*) AS unread").
+		Where("conversation_id IN ? AND from_staff = ? AND read_at IS NULL", conversationIDs, fromStaff).
+		Group("conversation_id").
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al contar mensajes sin leer: %v", result.Error)
+	}
+
+	for _, row := range rows {
+		counts[row.ConversationID] = row.Unread
+	}
+
+	return counts, nil
+}
+
+// ========================================
+// CONVERSATION CRUD OPERATIONS
+// ========================================
+
+// CreateConversation inserts a conversation and its first message in one transaction.
+//
+// Parameters:
+//   - conversation: Conversation to insert (will be updated with ID and timestamps)
+//   - message: First message (will be updated with ID, ConversationID and timestamps)
+//
+// Returns:
+//   - error: Database error or nil on success (nothing is saved on error)
+func CreateConversation(conversation *m.Conversation, message *m.Message) error {
+	gormDB := db.ORMOpen()
+
+	err := gormDB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(conversation).Error; err != nil {
+			return err
+		}
+
+		message.ConversationID = conversation.ID
+		return tx.Omit("Attachments").Create(message).Error
+	})
+	if err != nil {
+		return fmt.Errorf("error al crear conversación: %v", err)
+	}
+
+	return nil
+}
+
+// UpdateConversationInbox updates the inbox status and assignee of a conversation of an organisation.
+//
+// Parameters:
+//   - conversation: Conversation with the new Status, ClosedAt and AssignedTo (must include ID and OrganizationID)
+//
+// Returns:
+//   - error: Database error, record not found error or nil on success
+func UpdateConversationInbox(conversation *m.Conversation) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Model(&m.Conversation{}).
+		Where("id = ? AND organization_id = ?", conversation.ID, conversation.OrganizationID).
+		Select("status", "closed_at", "assigned_to").
+		Updates(conversation)
+	if result.Error != nil {
+		return fmt.Errorf("error al actualizar conversación %d: %v", conversation.ID, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("conversación con id %d no encontrada", conversation.ID)
+	}
+
+	return nil
+}
+
+// ========================================
+// MESSAGE OPERATIONS
+// ========================================
+
+// GetConversationMessages retrieves the messages of a conversation with their attachments.
+//
+// Parameters:
+//   - conversationID: Unique identifier of the conversation
+//
+// Returns:
+//   - []m.Message: Messages, oldest first
+//   - error: Database error or nil on success
+func GetConversationMessages(conversationID uint) ([]m.Message, error) {
+	gormDB := db.ORMOpen()
+
+	var messages []m.Message
+	result := gormDB.Preload("Attachments", func(tx *gorm.DB) *gorm.DB {
+		return tx.Order("id")
+	}).Where("conversation_id = ?", conversationID).Order("crt_date, id").Find(&messages)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer mensajes de la conversación %d: %v", conversationID, result.Error)
+	}
+
+	return messages, nil
+}
+
+// CreateMessage inserts a message and reopens its conversation, in one transaction.
+//
+// Parameters:
+//   - message: Message to insert (must include ConversationID; will be updated with ID and timestamps)
+//
+// Returns:
+//   - error: Database error or nil on success
+func CreateMessage(message *m.Message) error {
+	gormDB := db.ORMOpen()
+
+	err := gormDB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Attachments").Create(message).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&m.Conversation{}).
+			Where("id = ?", message.ConversationID).
+			Updates(map[string]any{
+				"last_message_at": message.CrtDate,
+				"status":          m.ConversationStatusOpen,
+				"closed_at":       nil,
+			}).Error
+	})
+	if err != nil {
+		return fmt.Errorf("error al crear mensaje: %v", err)
+	}
+
+	return nil
+}
+
+// DeleteMessage removes a message. Used to undo a message whose attachments could not be stored.
+//
+// Parameters:
+//   - id: Unique identifier of the message
+//
+// Returns:
+//   - error: Database error or nil on success
+func DeleteMessage(id uint) error {
+	gormDB := db.ORMOpen()
+
+	err := gormDB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("message_id = ?", id).Delete(&m.MessageAttachment{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&m.Message{}, id).Error
+	})
+	if err != nil {
+		return fmt.Errorf("error al eliminar mensaje %d: %v", id, err)
+	}
+
+	return nil
+}
+
+// CreateMessageAttachment inserts the metadata of a stored attachment.
+//
+// Parameters:
+//   - attachment: Attachment to insert (will be updated with ID and timestamps)
+//
+// Returns:
+//   - error: Database error or nil on success
+func CreateMessageAttachment(attachment *m.MessageAttachment) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Create(attachment)
+	if result.Error != nil {
+		return fmt.Errorf("error al registrar adjunto: %v", result.Error)
+	}
+
+	return nil
+}
+
+// GetMessageAttachment retrieves an attachment of a conversation.
+//
+// Parameters:
+//   - conversationID: Unique identifier of the conversation
+//   - attachmentID: Unique identifier of the attachment
+//
+// Returns:
+//   - *m.MessageAttachment: Attachment metadata
+//   - error: Database error or record not found error
+func GetMessageAttachment(conversationID uint, attachmentID uint) (*m.MessageAttachment, error) {
+	gormDB := db.ORMOpen()
+
+	var attachment m.MessageAttachment
+	result := gormDB.Joins("JOIN Messages ON Messages.id = Message_Attachments.message_id").
+		Where("Messages.conversation_id = ?", conversationID).
+		First(&attachment, "Message_Attachments.id = ?", attachmentID)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer adjunto %d: %v", attachmentID, result.Error)
+	}
+
+	return &attachment, nil
+}
+
+// MarkMessagesRead sets the read receipt of the unread messages written by one side of a conversation.
+//
+// Parameters:
+//   - conversationID: Unique identifier of the conversation
+//   - fromStaff: true to mark the messages from staff (read by the user), false for messages from the user
+//   - readAt: When the messages were read
+//
+// Returns:
+//   - error: Database error or nil on success
+func MarkMessagesRead(conversationID uint, fromStaff bool, readAt time.Time) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Model(&m.Message{}).
+		Where("conversation_id = ? AND from_staff = ? AND read_at IS NULL", conversationID, fromStaff).
+		Update("read_at", readAt)
+	if result.Error != nil {
+		return fmt.Errorf("error al marcar mensajes como leídos: %v", result.Error)
+	}
+
+	return nil
+}
+
+// GetMessagesDueForNotification retrieves the unread messages not notified yet that were sent before a time.
+//
+// Parameters:
+//   - sentBefore: Latest creation time of the messages
+//
+// Returns:
+//   - []m.Message: Messages, oldest first
+//   - error: Database error or nil on success
+func GetMessagesDueForNotification(sentBefore time.Time) ([]m.Message, error) {
+	gormDB := db.ORMOpen()
+
+	var messages []m.Message
+	result := gormDB.Where("read_at IS NULL AND notified_at IS NULL AND crt_date <= ?", sentBefore).
+		Order("crt_date, id").
+		Find(&messages)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al buscar mensajes pendientes de aviso: %v", result.Error)
+	}
+
+	return messages, nil
+}
+
+// MarkMessagesNotified records that unread messages were notified by email.
+//
+// Parameters:
+//   - ids: Unique identifiers of the messages
+//   - notifiedAt: When the notification was sent
+//
+// Returns:
+//   - error: Database error or nil on success
+func MarkMessagesNotified(ids []uint, notifiedAt time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Model(&m.Message{}).Where("id IN ?", ids).Update("notified_at", notifiedAt)
+	if result.Error != nil {
+		return fmt.Errorf("error al registrar aviso de mensajes: %v", result.Error)
+	}
+
+	return nil
+}
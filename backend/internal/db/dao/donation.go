@@ -0,0 +1,681 @@
+// Package dao implements data access objects for donations.
+// This layer is responsible for:
+// - CRUD operations on donations and the scheduling of recurring charges
+// - Aggregating paid donations into pet sponsorship totals and yearly donor totals
+// - Storing the yearly donation receipts
+package dao
+
+import (
+	"backend/internal/db"
+	"backend/internal/db/query"
+	m "backend/internal/models"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DonationListSchema is the allowlist of sort fields and filters accepted by donation list queries.
+//
+// Filters:
+//   - status: pending, active, completed or cancelled (comma-separated for several)
+//   - frequency: one_off, monthly or yearly (comma-separated for several)
+//   - pet: Sponsored pet ID
+//   - donor: Donor user ID
+//   - from, to: Range of the creation date (YYYY-MM-DD)
+//
+// Sort fields: crt_date, amount_cents, id
+var DonationListSchema = query.Schema{
+	Sorts: map[string]query.SortColumn{
+		"id":           {Column: "id"},
+		"crt_date":     {Column: "crt_date"},
+		"amount_cents": {Column: "amount_cents"},
+	},
+	Filters: map[string]query.FilterFunc{
+		"status":    query.OneOf("status", m.DonationStatuses...),
+		"frequency": query.OneOf("frequency", m.DonationFrequencies...),
+		"pet":       query.Uint("pet_id"),
+		"donor":     query.Uint("donor_user_id"),
+		"from":      query.DateFrom("crt_date"),
+		"to":        query.DateTo("crt_date"),
+	},
+	DefaultSort: "-crt_date",
+}
+
+// ========================================
+// DONATION RETRIEVAL OPERATIONS
+// ========================================
+
+// GetDonations retrieves one page of an organisation's donations matching the list query.
+//
+// Parameters:
+//   - params: Parsed list query (see DonationListSchema)
+//   - orgID: Organisation whose donations are listed
+//
+// Returns:
+//   - *query.Page[m.Donation]: Requested page of donations with total count and links
+//   - error: Database error or nil on success
+func GetDonations(params *query.Params, orgID uint) (*query.Page[m.Donation], error) {
+	gormDB := db.ORMOpen()
+
+	page, err := query.Find[m.Donation](gormDB.Model(&m.Donation{}).Scopes(inOrganization(orgID)), params)
+	if err != nil {
+		return nil, fmt.Errorf("error al leer donaciones: %v", err)
+	}
+
+	return page, nil
+}
+
+// GetUserDonations retrieves the donations of a donor in every organisation.
+//
+// Parameters:
+//   - userID: Unique identifier of the donor
+//
+// Returns:
+//   - []m.Donation: Donations of the user, most recent first
+//   - error: Database error or nil on success
+func GetUserDonations(userID uint) ([]m.Donation, error) {
+	gormDB := db.ORMOpen()
+
+	var donations []m.Donation
+	result := gormDB.Where("donor_user_id = ?", userID).Order("crt_date DESC, id DESC").Find(&donations)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer donaciones del usuario %d: %v", userID, result.Error)
+	}
+
+	return donations, nil
+}
+
+// GetDonation retrieves a donation of an organisation.
+//
+// Parameters:
+//   - id: Unique identifier of the donation
+//   - orgID: Organisation the donation must belong to, or AllOrganizations
+//
+// Returns:
+//   - *m.Donation: Donation data
+//   - error: Database error or record not found error
+func GetDonation(id uint, orgID uint) (*m.Donation, error) {
+	gormDB := db.ORMOpen()
+
+	var donation m.Donation
+	result := gormDB.Scopes(inOrganization(orgID)).First(&donation, id)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer donación %d: %v", id, result.Error)
+	}
+
+	return &donation, nil
+}
+
+// GetDonationsByID retrieves the given donations, whatever their organisation.
+//
+// Parameters:
+//   - ids: Unique identifiers of the donations
+//
+// Returns:
+//   - map[uint]*m.Donation: Donations by ID
+//   - error: Database error or nil on success
+func GetDonationsByID(ids []uint) (map[uint]*m.Donation, error) {
+	byID := make(map[uint]*m.Donation, len(ids))
+	if len(ids) == 0 {
+		return byID, nil
+	}
+
+	gormDB := db.ORMOpen()
+
+	var donations []m.Donation
+	if err := gormDB.Where("id IN ?", ids).Find(&donations).Error; err != nil {
+		return nil, fmt.Errorf("error al leer donaciones: %v", err)
+	}
+
+	for i := range donations {
+		byID[donations[i].ID] = &donations[i]
+	}
+
+	return byID, nil
+}
+
+// GetDonationPets retrieves the summaries of the sponsored pets of the given donations.
+//
+// Parameters:
+//   - petIDs: Unique identifiers of the pets
+//
+// Returns:
+//   - map[uint]*m.SimplifiedPet: Pet summaries with primary photo by ID
+//   - error: Database error or nil on success
+func GetDonationPets(petIDs []uint) (map[uint]*m.SimplifiedPet, error) {
+	byID := make(map[uint]*m.SimplifiedPet, len(petIDs))
+	if len(petIDs) == 0 {
+		return byID, nil
+	}
+
+	gormDB := db.ORMOpen()
+
+	var pets []m.Pet
+	result := gormDB.Preload("Photos", "is_primary = ?", true).Where("id IN ?", petIDs).Find(&pets)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer mascotas apadrinadas: %v", result.Error)
+	}
+
+	for _, pet := range pets {
+		summary := toSimplifiedPet(pet)
+		byID[pet.ID] = &summary
+	}
+
+	return byID, nil
+}
+
+// GetDonationDonors retrieves the user summaries of the given donors.
+//
+// Parameters:
+//   - userIDs: Unique identifiers of the donors
+//
+// Returns:
+//   - map[uint]*m.SimplifiedUser: Donor summaries by ID
+//   - error: Database error or nil on success
+func GetDonationDonors(userIDs []uint) (map[uint]*m.SimplifiedUser, error) {
+	byID := make(map[uint]*m.SimplifiedUser, len(userIDs))
+	if len(userIDs) == 0 {
+		return byID, nil
+	}
+
+	gormDB := db.ORMOpen()
+
+	var users []m.SimplifiedUser
+	if err := gormDB.Model(&m.User{}).Where("id IN ?", userIDs).Find(&users).Error; err != nil {
+		return nil, fmt.Errorf("error al leer donantes: %v", err)
+	}
+
+	for i := range users {
+		byID[users[i].ID] = &users[i]
+	}
+
+	return byID, nil
+}
+
+// GetDonationPayments retrieves every payment of a donation with its refunds.
+//
+// Parameters:
+//   - donationID: Unique identifier of the donation
+//
+// Returns:
+//   - []m.Payment: Payments, most recent first
+//   - error: Database error or nil on success
+func GetDonationPayments(donationID uint) ([]m.Payment, error) {
+	gormDB := db.ORMOpen()
+
+	var payments []m.Payment
+	result := gormDB.Preload("Refunds", func(tx *gorm.DB) *gorm.DB {
+		return tx.Order("crt_date, id")
+	}).Where("donation_id = ?", donationID).Order("crt_date DESC, id DESC").Find(&payments)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer pagos de la donación %d: %v", donationID, result.Error)
+	}
+
+	return payments, nil
+}
+
+// GetDonationPaidCents sums the amount paid and not refunded of each donation.
+//
+// Parameters:
+//   - donationIDs: Unique identifiers of the donations
+//
+// Returns:
+//   - map[uint]int64: Net paid amount in cents by donation ID (donations without payments are missing)
+//   - error: Database error or nil on success
+func GetDonationPaidCents(donationIDs []uint) (map[uint]int64, error) {
+	byID := make(map[uint]int64, len(donationIDs))
+	if len(donationIDs) == 0 {
+		return byID, nil
+	}
+
+	gormDB := db.ORMOpen()
+
+	var rows []struct {
+		DonationID uint
+		PaidCents  int64
+	}
+	result := gormDB.Model(&m.Payment{}).
+		Select("donation_id, SUM(amount_cents - refunded_cents) AS paid_cents").
+		Where("donation_id IN ? AND paid_at IS NOT NULL", donationIDs).
+		Group("donation_id").
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al sumar pagos de las donaciones: %v", result.Error)
+	}
+
+	for _, row := range rows {
+		byID[row.DonationID] = row.PaidCents
+	}
+
+	return byID, nil
+}
+
+// GetPendingDonationCheckouts retrieves the checkout URL of the latest pending payment of each donation.
+//
+// Parameters:
+//   - donationIDs: Unique identifiers of the donations
+//
+// Returns:
+//   - map[uint]string: Checkout URL by donation ID (donations without a pending payment are missing)
+//   - error: Database error or nil on success
+func GetPendingDonationCheckouts(donationIDs []uint) (map[uint]string, error) {
+	byID := make(map[uint]string, len(donationIDs))
+	if len(donationIDs) == 0 {
+		return byID, nil
+	}
+
+	gormDB := db.ORMOpen()
+
+	var payments []m.Payment
+	result := gormDB.Select("id, donation_id, checkout_url").
+		Where("donation_id IN ? AND status = ? AND checkout_url <> ''", donationIDs, m.PaymentStatusPending).
+		Order("id").
+		Find(&payments)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer pagos pendientes de las donaciones: %v", result.Error)
+	}
+
+	// Ordered by ID, so the latest pending payment of each donation wins
+	for _, payment := range payments {
+		byID[*payment.DonationID] = payment.CheckoutURL
+	}
+
+	return byID, nil
+}
+
+// GetDonationsDue retrieves the active recurring donations whose next charge is due.
+//
+// Parameters:
+//   - today: Current date
+//
+// Returns:
+//   - []m.Donation: Donations due, oldest charge first
+//   - error: Database error or nil on success
+func GetDonationsDue(today time.Time) ([]m.Donation, error) {
+	gormDB := db.ORMOpen()
+
+	var donations []m.Donation
+	result := gormDB.Where("status = ? AND next_charge_date <= ?", m.DonationStatusActive, today.Format(time.DateOnly)).
+		Order("next_charge_date, id").
+		Find(&donations)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer donaciones pendientes de cobro: %v", result.Error)
+	}
+
+	return donations, nil
+}
+
+// GetPetSponsorship sums the paid donations designated to a pet.
+//
+// Parameters:
+//   - petID: Unique identifier of the pet
+//
+// Returns:
+//   - *m.PetSponsorship: Number of sponsors and net amount donated by currency
+//   - error: Database error or nil on success
+func GetPetSponsorship(petID uint) (*m.PetSponsorship, error) {
+	gormDB := db.ORMOpen()
+
+	var totals []m.MoneyAmount
+	result := gormDB.Table("Payments AS p").
+		Select("p.currency, SUM(p.amount_cents - p.refunded_cents) AS amount_cents").
+		Joins("JOIN Donations AS d ON d.id = p.donation_id").
+		Where("d.pet_id = ? AND p.paid_at IS NOT NULL", petID).
+		Group("p.currency").
+		Having("SUM(p.amount_cents - p.refunded_cents) > 0").
+		Order("p.currency").
+		Scan(&totals)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al sumar apadrinamientos de la mascota %d: %v", petID, result.Error)
+	}
+
+	var sponsors int64
+	result = gormDB.Table("Payments AS p").
+		Joins("JOIN Donations AS d ON d.id = p.donation_id").
+		Where("d.pet_id = ? AND p.paid_at IS NOT NULL AND p.amount_cents > p.refunded_cents", petID).
+		Distinct("d.donor_user_id").
+		Count(&sponsors)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al contar padrinos de la mascota %d: %v", petID, result.Error)
+	}
+
+	if totals == nil {
+		totals = []m.MoneyAmount{}
+	}
+
+	return &m.PetSponsorship{Sponsors: sponsors, Totals: totals}, nil
+}
+
+// ========================================
+// DONATION WRITE OPERATIONS
+// ========================================
+
+// CreateDonation inserts a new donation.
+//
+// Parameters:
+//   - donation: Donation to insert (will be updated with ID and timestamps)
+//
+// Returns:
+//   - error: Database error or nil on success
+func CreateDonation(donation *m.Donation) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Omit("Donor", "Pet").Create(donation)
+	if result.Error != nil {
+		return fmt.Errorf("error al crear donación: %v", result.Error)
+	}
+
+	return nil
+}
+
+// ActivateDonation records the first payment of a pending donation: one-off donations are
+// completed and recurring ones become active with their next charge date.
+// Donations that are no longer pending are left untouched, so repeated calls have no effect.
+//
+// Parameters:
+//   - id: Unique identifier of the donation
+//   - nextCharge: Next charge date of recurring donations
+//
+// Returns:
+//   - error: Database error or nil on success
+func ActivateDonation(id uint, nextCharge time.Time) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Exec(
+		"UPDATE Donations SET status = CASE WHEN frequency = ? THEN ? ELSE ? END, "+
+			"next_charge_date = CASE WHEN frequency = ? THEN NULL ELSE ? END WHERE id = ? AND status = ?",
+		m.DonationOneOff, m.DonationStatusCompleted, m.DonationStatusActive,
+		m.DonationOneOff, nextCharge.Format(time.DateOnly), id, m.DonationStatusPending,
+	)
+	if result.Error != nil {
+		return fmt.Errorf("error al activar donación %d: %v", id, result.Error)
+	}
+
+	return nil
+}
+
+// ClaimDonationCharge moves the next charge date of an active donation forward, only if it is
+// still the expected one. It guarantees that each period is charged once even if the
+// renewal job runs twice at the same time.
+//
+// Parameters:
+//   - id: Unique identifier of the donation
+//   - due: Charge date being claimed
+//   - next: Following charge date
+//
+// Returns:
+//   - bool: true if the charge was claimed by this call
+//   - error: Database error or nil on success
+func ClaimDonationCharge(id uint, due time.Time, next time.Time) (bool, error) {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Model(&m.Donation{}).
+		Where("id = ? AND status = ? AND next_charge_date = ?", id, m.DonationStatusActive, due.Format(time.DateOnly)).
+		Update("next_charge_date", next.Format(time.DateOnly))
+	if result.Error != nil {
+		return false, fmt.Errorf("error al programar el siguiente cobro de la donación %d: %v", id, result.Error)
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// CancelDonation cancels a pending or active donation.
+//
+// Parameters:
+//   - id: Unique identifier of the donation
+//   - at: Cancellation time
+//
+// Returns:
+//   - bool: false if the donation was already completed or cancelled
+//   - error: Database error or nil on success
+func CancelDonation(id uint, at time.Time) (bool, error) {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Model(&m.Donation{}).
+		Where("id = ? AND status IN ?", id, []string{m.DonationStatusPending, m.DonationStatusActive}).
+		Updates(map[string]interface{}{
+			"status":           m.DonationStatusCancelled,
+			"cancelled_at":     at,
+			"next_charge_date": nil,
+		})
+	if result.Error != nil {
+		return false, fmt.Errorf("error al cancelar donación %d: %v", id, result.Error)
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// ExpirePendingDonationPayments marks the pending payments of a donation as expired,
+// so cancelled donations cannot be paid through checkouts sent earlier.
+//
+// Parameters:
+//   - donationID: Unique identifier of the donation
+//
+// Returns:
+//   - error: Database error or nil on success
+func ExpirePendingDonationPayments(donationID uint) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Model(&m.Payment{}).
+		Where("donation_id = ? AND status = ?", donationID, m.PaymentStatusPending).
+		Update("status", m.PaymentStatusExpired)
+	if result.Error != nil {
+		return fmt.Errorf("error al expirar pagos de la donación %d: %v", donationID, result.Error)
+	}
+
+	return nil
+}
+
+// ========================================
+// DONATION RECEIPT OPERATIONS
+// ========================================
+
+// GetDonationYearTotals sums the net donations of each donor in a year, for donors without a receipt yet.
+//
+// Parameters:
+//   - orgID: Organisation issuing the receipts, or AllOrganizations
+//   - year: Year of the payments
+//
+// Returns:
+//   - []m.DonationYearTotal: Totals by organisation, donor and currency
+//   - error: Database error or nil on success
+func GetDonationYearTotals(orgID uint, year int) ([]m.DonationYearTotal, error) {
+	gormDB := db.ORMOpen()
+
+	from := time.Date(year, time.January, 1, 0, 0, 0, 0, time.Local)
+	to := from.AddDate(1, 0, 0)
+
+	tx := gormDB.Table("Payments AS p").
+		Select("p.organization_id, d.donor_user_id, p.currency, SUM(p.amount_cents - p.refunded_cents) AS total_cents").
+		Joins("JOIN Donations AS d ON d.id = p.donation_id").
+		Where("p.paid_at >= ? AND p.paid_at < ?", from, to).
+		Where("NOT EXISTS (SELECT 1 FROM Donation_Receipts AS r WHERE r.organization_id = p.organization_id "+
+			"AND r.donor_user_id = d.donor_user_id AND r.year = ? AND r.currency = p.currency)", year)
+	if orgID != AllOrganizations {
+		tx = tx.Where("p.organization_id = ?", orgID)
+	}
+
+	var totals []m.DonationYearTotal
+	result := tx.Group("p.organization_id, d.donor_user_id, p.currency").
+		Having("SUM(p.amount_cents - p.refunded_cents) > 0").
+		Order("p.organization_id, d.donor_user_id, p.currency").
+		Scan(&totals)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al sumar donaciones de %d: %v", year, result.Error)
+	}
+
+	return totals, nil
+}
+
+// GetDonorYearPayments retrieves the paid donation payments of a donor to an organisation in a year.
+//
+// Parameters:
+//   - total: Organisation, donor and currency of the receipt
+//   - year: Year of the payments
+//
+// Returns:
+//   - []m.Payment: Payments in chronological order
+//   - error: Database error or nil on success
+func GetDonorYearPayments(total m.DonationYearTotal, year int) ([]m.Payment, error) {
+	gormDB := db.ORMOpen()
+
+	from := time.Date(year, time.January, 1, 0, 0, 0, 0, time.Local)
+	to := from.AddDate(1, 0, 0)
+
+	var payments []m.Payment
+	result := gormDB.Model(&m.Payment{}).
+		Select("Payments.*").
+		Joins("JOIN Donations ON Donations.id = Payments.donation_id").
+		Where("Payments.organization_id = ? AND Donations.donor_user_id = ? AND Payments.currency = ?",
+			total.OrganizationID, total.DonorUserID, total.Currency).
+		Where("Payments.paid_at >= ? AND Payments.paid_at < ? AND Payments.amount_cents > Payments.refunded_cents", from, to).
+		Order("Payments.paid_at, Payments.id").
+		Find(&payments)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer donaciones de %d del usuario %d: %v", year, total.DonorUserID, result.Error)
+	}
+
+	return payments, nil
+}
+
+// GetDonationReceipts retrieves the receipts issued by an organisation in a year.
+//
+// Parameters:
+//   - orgID: Organisation issuing the receipts
+//   - year: Year of the donations
+//
+// Returns:
+//   - []m.DonationReceipt: Receipts ordered by number
+//   - error: Database error or nil on success
+func GetDonationReceipts(orgID uint, year int) ([]m.DonationReceipt, error) {
+	gormDB := db.ORMOpen()
+
+	var receipts []m.DonationReceipt
+	result := gormDB.Where("organization_id = ? AND year = ?", orgID, year).Order("id").Find(&receipts)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer certificados de donación de %d: %v", year, result.Error)
+	}
+
+	return receipts, nil
+}
+
+// GetUserDonationReceipts retrieves the receipts issued to a donor by every organisation.
+//
+// Parameters:
+//   - userID: Unique identifier of the donor
+//
+// Returns:
+//   - []m.DonationReceipt: Receipts, most recent year first
+//   - error: Database error or nil on success
+func GetUserDonationReceipts(userID uint) ([]m.DonationReceipt, error) {
+	gormDB := db.ORMOpen()
+
+	var receipts []m.DonationReceipt
+	result := gormDB.Where("donor_user_id = ? AND file_key <> ''", userID).Order("year DESC, id DESC").Find(&receipts)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer certificados de donación del usuario %d: %v", userID, result.Error)
+	}
+
+	return receipts, nil
+}
+
+// GetDonationReceipt retrieves a receipt by ID.
+//
+// Parameters:
+//   - id: Unique identifier of the receipt
+//
+// Returns:
+//   - *m.DonationReceipt: Receipt data
+//   - error: Database error or record not found error
+func GetDonationReceipt(id uint) (*m.DonationReceipt, error) {
+	gormDB := db.ORMOpen()
+
+	var receipt m.DonationReceipt
+	result := gormDB.First(&receipt, id)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer certificado de donación %d: %v", id, result.Error)
+	}
+
+	return &receipt, nil
+}
+
+// CreateDonationReceipt inserts a receipt, unless the donor already has one for that year and currency.
+//
+// Parameters:
+//   - receipt: Receipt to insert (will be updated with ID)
+//
+// Returns:
+//   - bool: false if the receipt already existed
+//   - error: Database error or nil on success
+func CreateDonationReceipt(receipt *m.DonationReceipt) (bool, error) {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Clauses(clause.OnConflict{DoNothing: true}).Create(receipt)
+	if result.Error != nil {
+		return false, fmt.Errorf("error al crear certificado de donación: %v", result.Error)
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// SetDonationReceiptFile records the number and generated PDF of a receipt.
+//
+// Parameters:
+//   - receipt: Receipt with Number, FileKey and FileHash set
+//
+// Returns:
+//   - error: Database error or nil on success
+func SetDonationReceiptFile(receipt *m.DonationReceipt) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Model(&m.DonationReceipt{}).Where("id = ?", receipt.ID).Updates(map[string]interface{}{
+		"number":    receipt.Number,
+		"file_key":  receipt.FileKey,
+		"file_hash": receipt.FileHash,
+	})
+	if result.Error != nil {
+		return fmt.Errorf("error al guardar certificado de donación %d: %v", receipt.ID, result.Error)
+	}
+
+	return nil
+}
+
+// MarkDonationReceiptSent records when a receipt was emailed to the donor.
+//
+// Parameters:
+//   - id: Unique identifier of the receipt
+//   - sentAt: Time the email was sent
+//
+// Returns:
+//   - error: Database error or nil on success
+func MarkDonationReceiptSent(id uint, sentAt time.Time) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Model(&m.DonationReceipt{}).Where("id = ?", id).Update("sent_at", sentAt)
+	if result.Error != nil {
+		return fmt.Errorf("error al marcar certificado de donación %d enviado: %v", id, result.Error)
+	}
+
+	return nil
+}
+
+// DeleteDonationReceipt removes a receipt.
+// Only used to undo a receipt whose generation failed, so it is generated again on the next run.
+//
+// Parameters:
+//   - id: Unique identifier of the receipt
+//
+// Returns:
+//   - error: Database error or nil on success
+func DeleteDonationReceipt(id uint) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Delete(&m.DonationReceipt{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("error al eliminar certificado de donación %d: %v", id, result.Error)
+	}
+
+	return nil
+}
@@ -0,0 +1,621 @@
+// Package dao implements data access objects for the foster care programme.
+// This layer is responsible for:
+// - CRUD operations on foster homes and their placements
+// - Computing the occupancy of foster homes from their current placements
+// - Storing the updates and photos fosters post about the pets they care for
+package dao
+
+import (
+	"backend/internal/db"
+	"backend/internal/db/query"
+	m "backend/internal/models"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"gorm.io/gorm"
+)
+
+// FosterHomeListSchema is the allowlist of sort fields and filters accepted by foster home list queries.
+//
+// Filters:
+//   - species: Homes accepting the species (homes without a species list accept any)
+//   - home_type: house, apartment or rural (comma-separated for several)
+//   - active: true/false
+//   - has_capacity: true for homes that can take another pet, false for full homes
+//   - user: Foster user ID
+//
+// Sort fields: capacity, crt_date, id
+var FosterHomeListSchema = query.Schema{
+	Sorts: map[string]query.SortColumn{
+		"id":       {Column: "id"},
+		"capacity": {Column: "capacity"},
+		"crt_date": {Column: "crt_date"},
+	},
+	Filters: map[string]query.FilterFunc{
+		"species":      acceptsSpeciesFilter,
+		"home_type":    query.OneOf("home_type", m.FosterHomeTypes...),
+		"active":       query.Bool("active"),
+		"has_capacity": hasCapacityFilter,
+		"user":         query.Uint("user_id"),
+	},
+	DefaultSort: "-crt_date",
+}
+
+// FosterPlacementListSchema is the allowlist of sort fields and filters accepted by placement list queries.
+//
+// Filters:
+//   - current: true for pets still in the foster home, false for finished placements
+//   - pet: Pet ID
+//   - foster_home: Foster home ID
+//   - start_from, start_to: Range of the start date (YYYY-MM-DD)
+//
+// Sort fields: start_date, end_date, crt_date, id
+var FosterPlacementListSchema = query.Schema{
+	Sorts: map[string]query.SortColumn{
+		"id":         {Column: "id"},
+		"start_date": {Column: "start_date"},
+		"end_date":   {Column: "end_date", Nullable: true},
+		"crt_date":   {Column: "crt_date"},
+	},
+	Filters: map[string]query.FilterFunc{
+		"current":     currentPlacementFilter,
+		"pet":         query.Uint("pet_id"),
+		"foster_home": query.Uint("foster_home_id"),
+		"start_from":  query.DateFrom("start_date"),
+		"start_to":    query.DateTo("start_date"),
+	},
+	DefaultSort: "-start_date",
+}
+
+// currentPlacementsOf is the number of current placements of the foster home in the outer query.
+const currentPlacementsOf = "(SELECT COUNT(*) FROM Foster_Placements fp WHERE fp.foster_home_id = Foster_Homes.id AND fp.end_date IS NULL)"
+
+// acceptsSpeciesFilter keeps foster homes accepting a species; homes without a species list accept any.
+func acceptsSpeciesFilter(value string) (query.Scope, error) {
+	return func(tx *gorm.DB) *gorm.DB {
+		return tx.Where("(accepted_species IS NULL OR JSON_LENGTH(accepted_species) = 0 OR JSON_CONTAINS(accepted_species, JSON_QUOTE(?)))", value)
+	}, nil
+}
+
+// hasCapacityFilter keeps foster homes with (true) or without (false) room for another pet.
+func hasCapacityFilter(value string) (query.Scope, error) {
+	free, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, fmt.Errorf("valor booleano inválido para has_capacity: %s", value)
+	}
+
+	return func(tx *gorm.DB) *gorm.DB {
+		if free {
+			return tx.Where("capacity > " + currentPlacementsOf)
+		}
+		return tx.Where("capacity <= " + currentPlacementsOf)
+	}, nil
+}
+
+// currentPlacementFilter keeps current (true) or finished (false) placements.
+func currentPlacementFilter(value string) (query.Scope, error) {
+	current, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, fmt.Errorf("valor booleano inválido para current: %s", value)
+	}
+
+	return func(tx *gorm.DB) *gorm.DB {
+		if current {
+			return tx.Where("end_date IS NULL")
+		}
+		return tx.Where("end_date IS NOT NULL")
+	}, nil
+}
+
+// inFosterFilter keeps pets living (true) or not living (false) in a foster home.
+func inFosterFilter(value string) (query.Scope, error) {
+	fostered, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, fmt.Errorf("valor booleano inválido para in_foster: %s", value)
+	}
+
+	return func(tx *gorm.DB) *gorm.DB {
+		condition := "EXISTS (SELECT 1 FROM Foster_Placements fp WHERE fp.pet_id = Pets.id AND fp.end_date IS NULL)"
+		if !fostered {
+			condition = "NOT " + condition
+		}
+		return tx.Where(condition)
+	}, nil
+}
+
+// withPlacementPet preloads the pet of a placement with the data of its summary.
+func withPlacementPet(tx *gorm.DB) *gorm.DB {
+	return tx.Preload("Pet").
+		Preload("Pet.Photos", "is_primary = ?", true)
+}
+
+// fillPlacementPets sets the pet summary of loaded placements.
+func fillPlacementPets(placements []m.FosterPlacement) {
+	for i := range placements {
+		summary := toSimplifiedPet(placements[i].Pet)
+		placements[i].PetSummary = &summary
+	}
+}
+
+// ========================================
+// FOSTER HOME RETRIEVAL OPERATIONS
+// ========================================
+
+// GetFosterHomes retrieves one page of an organisation's foster homes matching the list query.
+//
+// Parameters:
+//   - params: Parsed list query (see FosterHomeListSchema)
+//   - orgID: Organisation whose foster homes are listed
+//
+// Returns:
+//   - *query.Page[m.FosterHome]: Requested page of foster homes with total count and links
+//   - error: Database error or nil on success
+func GetFosterHomes(params *query.Params, orgID uint) (*query.Page[m.FosterHome], error) {
+	gormDB := db.ORMOpen()
+
+	page, err := query.Find[m.FosterHome](gormDB.Model(&m.FosterHome{}).Scopes(inOrganization(orgID)), params)
+	if err != nil {
+		return nil, fmt.Errorf("error al leer casas de acogida: %v", err)
+	}
+
+	return page, nil
+}
+
+// GetFosterHome retrieves a foster home of an organisation.
+//
+// Parameters:
+//   - id: Unique identifier of the foster home
+//   - orgID: Organisation the home must belong to, or AllOrganizations
+//
+// Returns:
+//   - *m.FosterHome: Foster home data
+//   - error: Database error or record not found error
+func GetFosterHome(id uint, orgID uint) (*m.FosterHome, error) {
+	gormDB := db.ORMOpen()
+
+	var home m.FosterHome
+	result := gormDB.Scopes(inOrganization(orgID)).First(&home, id)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer casa de acogida %d: %v", id, result.Error)
+	}
+
+	return &home, nil
+}
+
+// GetFosterHomeByUser retrieves the foster home of a user in an organisation.
+//
+// Parameters:
+//   - orgID: Unique identifier of the organisation
+//   - userID: Unique identifier of the foster user
+//
+// Returns:
+//   - *m.FosterHome: Foster home, or nil if the user has none in the organisation
+//   - error: Database error or nil on success
+func GetFosterHomeByUser(orgID uint, userID uint) (*m.FosterHome, error) {
+	gormDB := db.ORMOpen()
+
+	var home m.FosterHome
+	result := gormDB.Where("organization_id = ? AND user_id = ?", orgID, userID).First(&home)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al buscar casa de acogida del usuario %d: %v", userID, result.Error)
+	}
+
+	return &home, nil
+}
+
+// GetFosterHomeUsers retrieves the user summaries of the given foster users.
+//
+// Parameters:
+//   - userIDs: Unique identifiers of the users
+//
+// Returns:
+//   - map[uint]*m.SimplifiedUser: User summaries by ID
+//   - error: Database error or nil on success
+func GetFosterHomeUsers(userIDs []uint) (map[uint]*m.SimplifiedUser, error) {
+	byID := make(map[uint]*m.SimplifiedUser, len(userIDs))
+	if len(userIDs) == 0 {
+		return byID, nil
+	}
+
+	gormDB := db.ORMOpen()
+
+	var users []m.SimplifiedUser
+	if err := gormDB.Model(&m.User{}).Where("id IN ?", userIDs).Find(&users).Error; err != nil {
+		return nil, fmt.Errorf("error al leer usuarios de las casas de acogida: %v", err)
+	}
+
+	for i := range users {
+		byID[users[i].ID] = &users[i]
+	}
+
+	return byID, nil
+}
+
+// ========================================
+// FOSTER HOME CRUD OPERATIONS
+// ========================================
+
+// CreateFosterHome inserts a new foster home.
+//
+// Parameters:
+//   - home: Foster home to insert (will be updated with ID and timestamps)
+//
+// Returns:
+//   - error: Database error or nil on success
+func CreateFosterHome(home *m.FosterHome) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Create(home)
+	if result.Error != nil {
+		return fmt.Errorf("error al crear casa de acogida: %v", result.Error)
+	}
+
+	return nil
+}
+
+// UpdateFosterHome updates the profile of a foster home of an organisation.
+// The organisation and the foster user cannot be changed.
+//
+// Parameters:
+//   - home: Foster home with updated data (must include ID and OrganizationID)
+//
+// Returns:
+//   - error: Database error, record not found error or nil on success
+func UpdateFosterHome(home *m.FosterHome) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Model(&m.FosterHome{}).
+		Where("id = ? AND organization_id = ?", home.ID, home.OrganizationID).
+		Select("capacity", "accepted_species", "home_type", "has_garden", "has_children",
+			"has_other_pets", "address", "phone", "notes", "active").
+		Updates(home)
+	if result.Error != nil {
+		return fmt.Errorf("error al actualizar casa de acogida %d: %v", home.ID, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("casa de acogida con id %d no encontrada", home.ID)
+	}
+
+	return nil
+}
+
+// DeleteFosterHome removes a foster home of an organisation.
+// Callers must ensure the home has no placements, whose update photos would stay in storage.
+//
+// Parameters:
+//   - id: Unique identifier of the foster home
+//   - orgID: Organisation the home must belong to
+//
+// Returns:
+//   - error: Database error, record not found error or nil on success
+func DeleteFosterHome(id uint, orgID uint) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Where("organization_id = ?", orgID).Delete(&m.FosterHome{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("error al eliminar casa de acogida %d: %v", id, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("casa de acogida con id %d no encontrada", id)
+	}
+
+	return nil
+}
+
+// ========================================
+// FOSTER PLACEMENT OPERATIONS
+// ========================================
+
+// GetFosterPlacements retrieves one page of an organisation's placements matching the list query.
+//
+// Parameters:
+//   - params: Parsed list query (see FosterPlacementListSchema)
+//   - orgID: Organisation whose placements are listed
+//
+// Returns:
+//   - *query.Page[m.FosterPlacement]: Requested page of placements with pet summaries
+//   - error: Database error or nil on success
+func GetFosterPlacements(params *query.Params, orgID uint) (*query.Page[m.FosterPlacement], error) {
+	gormDB := db.ORMOpen()
+
+	page, err := query.Find[m.FosterPlacement](gormDB.Model(&m.FosterPlacement{}).Scopes(inOrganization(orgID)), params, withPlacementPet)
+	if err != nil {
+		return nil, fmt.Errorf("error al leer acogidas: %v", err)
+	}
+
+	fillPlacementPets(page.Items)
+
+	return page, nil
+}
+
+// GetFosterPlacement retrieves a placement with its pet summary.
+//
+// Parameters:
+//   - id: Unique identifier of the placement
+//
+// Returns:
+//   - *m.FosterPlacement: Placement data
+//   - error: Database error or record not found error
+func GetFosterPlacement(id uint) (*m.FosterPlacement, error) {
+	gormDB := db.ORMOpen()
+
+	var placement m.FosterPlacement
+	result := gormDB.Scopes(withPlacementPet).First(&placement, id)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer acogida %d: %v", id, result.Error)
+	}
+
+	summary := toSimplifiedPet(placement.Pet)
+	placement.PetSummary = &summary
+
+	return &placement, nil
+}
+
+// CountFosterPlacements counts the placements, current or finished, of a foster home.
+//
+// Parameters:
+//   - homeID: Unique identifier of the foster home
+//
+// Returns:
+//   - int64: Number of placements
+//   - error: Database error or nil on success
+func CountFosterPlacements(homeID uint) (int64, error) {
+	gormDB := db.ORMOpen()
+
+	var count int64
+	result := gormDB.Model(&m.FosterPlacement{}).Where("foster_home_id = ?", homeID).Count(&count)
+	if result.Error != nil {
+		return 0, fmt.Errorf("error al contar acogidas de la casa %d: %v", homeID, result.Error)
+	}
+
+	return count, nil
+}
+
+// GetCurrentPlacements retrieves the current placements of the given foster homes.
+//
+// Parameters:
+//   - homeIDs: Unique identifiers of the foster homes
+//
+// Returns:
+//   - []m.FosterPlacement: Current placements with pet summaries, oldest first
+//   - error: Database error or nil on success
+func GetCurrentPlacements(homeIDs []uint) ([]m.FosterPlacement, error) {
+	if len(homeIDs) == 0 {
+		return nil, nil
+	}
+
+	gormDB := db.ORMOpen()
+
+	var placements []m.FosterPlacement
+	result := gormDB.Scopes(withPlacementPet).
+		Where("foster_home_id IN ? AND end_date IS NULL", homeIDs).
+		Order("start_date, id").
+		Find(&placements)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer acogidas en curso: %v", result.Error)
+	}
+
+	fillPlacementPets(placements)
+
+	return placements, nil
+}
+
+// GetCurrentPlacementForPet retrieves the current placement of a pet.
+//
+// Parameters:
+//   - petID: Unique identifier of the pet
+//
+// Returns:
+//   - *m.FosterPlacement: Current placement, or nil if the pet is not in a foster home
+//   - error: Database error or nil on success
+func GetCurrentPlacementForPet(petID uint) (*m.FosterPlacement, error) {
+	gormDB := db.ORMOpen()
+
+	var placement m.FosterPlacement
+	result := gormDB.Where("pet_id = ? AND end_date IS NULL", petID).First(&placement)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer acogida en curso de la mascota %d: %v", petID, result.Error)
+	}
+
+	return &placement, nil
+}
+
+// GetUserFosterPlacements retrieves the placements of every foster home of a user.
+//
+// Parameters:
+//   - userID: Unique identifier of the foster user
+//
+// Returns:
+//   - []m.FosterPlacement: Placements with pet summaries, current ones first
+//   - error: Database error or nil on success
+func GetUserFosterPlacements(userID uint) ([]m.FosterPlacement, error) {
+	gormDB := db.ORMOpen()
+
+	var placements []m.FosterPlacement
+	result := gormDB.Scopes(withPlacementPet).
+		Where("foster_home_id IN (?)", gormDB.Model(&m.FosterHome{}).Select("id").Where("user_id = ?", userID)).
+		Order("end_date IS NOT NULL, start_date DESC, id DESC").
+		Find(&placements)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer acogidas del usuario %d: %v", userID, result.Error)
+	}
+
+	fillPlacementPets(placements)
+
+	return placements, nil
+}
+
+// CreateFosterPlacement inserts a new placement.
+//
+// Parameters:
+//   - placement: Placement to insert (will be updated with ID and timestamps)
+//
+// Returns:
+//   - error: Database error or nil on success
+func CreateFosterPlacement(placement *m.FosterPlacement) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Omit("Pet").Create(placement)
+	if result.Error != nil {
+		return fmt.Errorf("error al crear acogida: %v", result.Error)
+	}
+
+	return nil
+}
+
+// UpdateFosterPlacement stores the dates and notes of a placement.
+//
+// Parameters:
+//   - placement: Placement with updated data (must include ID)
+//
+// Returns:
+//   - error: Database error, record not found error or nil on success
+func UpdateFosterPlacement(placement *m.FosterPlacement) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Model(&m.FosterPlacement{}).
+		Where("id = ?", placement.ID).
+		Select("expected_end_date", "end_date", "notes").
+		Updates(placement)
+	if result.Error != nil {
+		return fmt.Errorf("error al actualizar acogida %d: %v", placement.ID, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("acogida con id %d no encontrada", placement.ID)
+	}
+
+	return nil
+}
+
+// ========================================
+// FOSTER UPDATE OPERATIONS
+// ========================================
+
+// GetFosterUpdates retrieves the updates posted about a placement, newest first.
+//
+// Parameters:
+//   - placementID: Unique identifier of the placement
+//
+// Returns:
+//   - []m.FosterUpdate: Updates with their photos
+//   - error: Database error or nil on success
+func GetFosterUpdates(placementID uint) ([]m.FosterUpdate, error) {
+	gormDB := db.ORMOpen()
+
+	var updates []m.FosterUpdate
+	result := gormDB.Preload("Photos", func(tx *gorm.DB) *gorm.DB { return tx.Order("id") }).
+		Where("placement_id = ?", placementID).
+		Order("crt_date DESC, id DESC").
+		Find(&updates)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer novedades de la acogida %d: %v", placementID, result.Error)
+	}
+
+	return updates, nil
+}
+
+// GetFosterUpdate retrieves an update.
+//
+// Parameters:
+//   - id: Unique identifier of the update
+//
+// Returns:
+//   - *m.FosterUpdate: Update data (without photos)
+//   - error: Database error or record not found error
+func GetFosterUpdate(id uint) (*m.FosterUpdate, error) {
+	gormDB := db.ORMOpen()
+
+	var update m.FosterUpdate
+	result := gormDB.First(&update, id)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer novedad %d: %v", id, result.Error)
+	}
+
+	return &update, nil
+}
+
+// GetFosterUpdatePhoto retrieves a photo, ensuring it belongs to the given update.
+//
+// Parameters:
+//   - updateID: Unique identifier of the update
+//   - photoID: Unique identifier of the photo
+//
+// Returns:
+//   - *m.FosterUpdatePhoto: Photo metadata
+//   - error: Database error or record not found error
+func GetFosterUpdatePhoto(updateID uint, photoID uint) (*m.FosterUpdatePhoto, error) {
+	gormDB := db.ORMOpen()
+
+	var photo m.FosterUpdatePhoto
+	result := gormDB.Where("id = ? AND update_id = ?", photoID, updateID).First(&photo)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer foto %d de la novedad %d: %v", photoID, updateID, result.Error)
+	}
+
+	return &photo, nil
+}
+
+// CreateFosterUpdate inserts a new update (without photos).
+//
+// Parameters:
+//   - update: Update to insert (will be updated with ID and timestamps)
+//
+// Returns:
+//   - error: Database error or nil on success
+func CreateFosterUpdate(update *m.FosterUpdate) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Omit("Photos").Create(update)
+	if result.Error != nil {
+		return fmt.Errorf("error al crear novedad: %v", result.Error)
+	}
+
+	return nil
+}
+
+// CreateFosterUpdatePhoto inserts the metadata of a stored update photo.
+//
+// Parameters:
+//   - photo: Photo to insert (will be updated with ID and timestamps)
+//
+// Returns:
+//   - error: Database error or nil on success
+func CreateFosterUpdatePhoto(photo *m.FosterUpdatePhoto) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Create(photo)
+	if result.Error != nil {
+		return fmt.Errorf("error al registrar foto de la novedad %d: %v", photo.UpdateID, result.Error)
+	}
+
+	return nil
+}
+
+// DeleteFosterUpdate removes an update.
+// Its photos are removed by the ON DELETE CASCADE constraint.
+//
+// Parameters:
+//   - id: Unique identifier of the update
+//
+// Returns:
+//   - error: Database error or nil on success
+func DeleteFosterUpdate(id uint) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Delete(&m.FosterUpdate{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("error al eliminar novedad %d: %v", id, result.Error)
+	}
+
+	return nil
+}
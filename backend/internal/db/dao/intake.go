@@ -0,0 +1,258 @@
+// Package dao implements data access objects for intake records.
+// This layer is responsible for:
+// - Creating a pet together with the record of its arrival
+// - Reading and updating intake records
+// - Counting intakes and outcomes month by month
+package dao
+
+import (
+	"backend/internal/db"
+	"backend/internal/db/query"
+	m "backend/internal/models"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// IntakeListSchema is the allowlist of sort fields and filters accepted by intake list queries.
+//
+// Filters:
+//   - source: stray, owner_surrender, transfer or born_in_care (comma separated)
+//   - condition: healthy, treatable, injured, sick or critical (comma separated)
+//   - pet: Pet ID
+//   - from, to: Range of the intake date (YYYY-MM-DD)
+//
+// Sort fields: intake_date, crt_date, id
+var IntakeListSchema = query.Schema{
+	Sorts: map[string]query.SortColumn{
+		"id":          {Column: "id"},
+		"intake_date": {Column: "intake_date"},
+		"crt_date":    {Column: "crt_date"},
+	},
+	Filters: map[string]query.FilterFunc{
+		"source":    query.OneOf("source", m.IntakeSources...),
+		"condition": query.OneOf("condition", m.IntakeConditions...),
+		"pet":       query.Uint("pet_id"),
+		"from":      query.DateFrom("intake_date"),
+		"to":        query.DateTo("intake_date"),
+	},
+	DefaultSort: "-intake_date",
+}
+
+// ========================================
+// INTAKE RETRIEVAL OPERATIONS
+// ========================================
+
+// GetIntakes retrieves one page of an organisation's intakes matching the list query.
+//
+// Parameters:
+//   - params: Parsed list query (see IntakeListSchema)
+//   - orgID: Organisation whose intakes are listed
+//
+// Returns:
+//   - *query.Page[m.Intake]: Requested page of intakes with total count and links
+//   - error: Database error or nil on success
+func GetIntakes(params *query.Params, orgID uint) (*query.Page[m.Intake], error) {
+	gormDB := db.ORMOpen()
+
+	page, err := query.Find[m.Intake](gormDB.Model(&m.Intake{}).Scopes(inOrganization(orgID)), params)
+	if err != nil {
+		return nil, fmt.Errorf("error al leer ingresos: %v", err)
+	}
+
+	return page, nil
+}
+
+// GetIntake retrieves an intake of an organisation.
+//
+// Parameters:
+//   - id: Unique identifier of the intake
+//   - orgID: Organisation the intake must belong to
+//
+// Returns:
+//   - *m.Intake: Intake data
+//   - error: Database error or record not found error
+func GetIntake(id uint, orgID uint) (*m.Intake, error) {
+	gormDB := db.ORMOpen()
+
+	var intake m.Intake
+	result := gormDB.Scopes(inOrganization(orgID)).First(&intake, id)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer ingreso %d: %v", id, result.Error)
+	}
+
+	return &intake, nil
+}
+
+// GetIntakePets retrieves the summaries of the pets of the given intakes.
+//
+// Parameters:
+//   - petIDs: Unique identifiers of the pets
+//
+// Returns:
+//   - map[uint]*m.SimplifiedPet: Pet summaries with primary photo by ID
+//   - error: Database error or nil on success
+func GetIntakePets(petIDs []uint) (map[uint]*m.SimplifiedPet, error) {
+	byID := make(map[uint]*m.SimplifiedPet, len(petIDs))
+	if len(petIDs) == 0 {
+		return byID, nil
+	}
+
+	gormDB := db.ORMOpen()
+
+	var pets []m.Pet
+	result := gormDB.Preload("Photos", "is_primary = ?", true).Where("id IN ?", petIDs).Find(&pets)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer mascotas de los ingresos: %v", result.Error)
+	}
+
+	for _, pet := range pets {
+		summary := toSimplifiedPet(pet)
+		byID[pet.ID] = &summary
+	}
+
+	return byID, nil
+}
+
+// ========================================
+// INTAKE CRUD OPERATIONS
+// ========================================
+
+// CreatePetWithIntake inserts a pet and the record of its arrival in one transaction.
+//
+// Parameters:
+//   - pet: Pet to insert, including its organisation (will be updated with ID and timestamps)
+//   - intake: Intake to insert (will be updated with ID, PetID and timestamps)
+//
+// Returns:
+//   - error: Database error or nil on success (nothing is saved on error)
+func CreatePetWithIntake(pet *m.Pet, intake *m.Intake) error {
+	if pet.OrganizationID == AllOrganizations {
+		return fmt.Errorf("error al crear ingreso: falta la organización")
+	}
+
+	gormDB := db.ORMOpen()
+
+	now := time.Now()
+	pet.CrtDate = now
+	pet.UptDate = now
+
+	err := gormDB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Photos").Create(pet).Error; err != nil {
+			return err
+		}
+
+		intake.PetID = pet.ID
+		intake.OrganizationID = pet.OrganizationID
+		return tx.Create(intake).Error
+	})
+	if err != nil {
+		return fmt.Errorf("error al crear ingreso: %v", err)
+	}
+
+	return nil
+}
+
+// UpdateIntake updates the details of an intake of an organisation.
+// The pet and the staff user who recorded the intake cannot be changed.
+//
+// Parameters:
+//   - intake: Intake with updated data (must include ID and OrganizationID)
+//
+// Returns:
+//   - error: Database error, record not found error or nil on success
+func UpdateIntake(intake *m.Intake) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Model(&m.Intake{}).
+		Where("id = ? AND organization_id = ?", intake.ID, intake.OrganizationID).
+		Select("source", "intake_date", "condition", "condition_notes",
+			"surrenderer_name", "surrenderer_email", "surrenderer_phone", "surrenderer_address", "surrender_reason",
+			"transfer_from", "found_location", "found_latitude", "found_longitude", "notes").
+		Updates(intake)
+	if result.Error != nil {
+		return fmt.Errorf("error al actualizar ingreso %d: %v", intake.ID, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("ingreso con id %d no encontrado", intake.ID)
+	}
+
+	return nil
+}
+
+// ========================================
+// INTAKE STATISTICS OPERATIONS
+// ========================================
+
+// monthCount is one row of a monthly count grouped by a key (intake source or outcome type).
+type monthCount struct {
+	Month string
+	Key   string
+	Total int
+}
+
+// CountIntakesByMonth counts the intakes of an organisation by month and source.
+//
+// Parameters:
+//   - orgID: Organisation whose intakes are counted
+//   - from, to: Range of the intake date (to excluded)
+//
+// Returns:
+//   - map[string]map[string]int: Intakes by month ("2026-03") and source
+//   - error: Database error or nil on success
+func CountIntakesByMonth(orgID uint, from time.Time, to time.Time) (map[string]map[string]int, error) {
+	gormDB := db.ORMOpen()
+
+	var rows []monthCount
+	result := gormDB.Model(&m.Intake{}).
+		Select("DATE_FORMAT(intake_date, '%Y-%m') AS month, source AS `key`, COUNT(*) AS total").
+		Where("organization_id = ? AND intake_date >= ? AND intake_date < ?", orgID, from, to).
+		Group("month, source").
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al contar ingresos: %v", result.Error)
+	}
+
+	return groupMonthCounts(rows), nil
+}
+
+// CountOutcomesByMonth counts the pets of an organisation that left the shelter by month and outcome type.
+// Adopted pets are counted in the month of their adoption date.
+//
+// Parameters:
+//   - orgID: Organisation whose pets are counted
+//   - from, to: Range of the outcome date (to excluded)
+//
+// Returns:
+//   - map[string]map[string]int: Outcomes by month ("2026-03") and type
+//   - error: Database error or nil on success
+func CountOutcomesByMonth(orgID uint, from time.Time, to time.Time) (map[string]map[string]int, error) {
+	gormDB := db.ORMOpen()
+
+	var rows []monthCount
+	result := gormDB.Model(&m.Pet{}).
+		Select("DATE_FORMAT(adopt_date, '%Y-%m') AS month, ? AS `key`, COUNT(*) AS total", m.OutcomeAdopted).
+		Where("organization_id = ? AND is_adopted = ? AND adopt_date >= ? AND adopt_date < ?", orgID, true, from, to).
+		Group("month").
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al contar salidas: %v", result.Error)
+	}
+
+	return groupMonthCounts(rows), nil
+}
+
+// groupMonthCounts turns monthly count rows into a map by month and key.
+func groupMonthCounts(rows []monthCount) map[string]map[string]int {
+	counts := make(map[string]map[string]int)
+	for _, row := range rows {
+		if counts[row.Month] == nil {
+			counts[row.Month] = make(map[string]int)
+		}
+		counts[row.Month][row.Key] += row.Total
+	}
+
+	return counts
+}
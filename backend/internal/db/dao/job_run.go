@@ -0,0 +1,184 @@
+// Package dao implements data access objects for background job runs.
+// This layer is responsible for:
+// - Claiming job periods so each one is processed only once
+// - Recording the outcome of every run
+// - Listing the run history of a job
+package dao
+
+import (
+	"backend/internal/db"
+	m "backend/internal/models"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ========================================
+// JOB RUN OPERATIONS
+// ========================================
+
+// ClaimJobRun records the start of a run for run.Job and *run.RunKey.
+// The claim succeeds when the period has no run yet, when its previous run failed, or when
+// its previous run is still marked as running after the lease (the process running it died).
+//
+// Database Operations:
+// - INSERT INTO Job_Runs ... ON CONFLICT DO NOTHING (unique job + run_key)
+// - On conflict, UPDATE Job_Runs SET status = 'running' ... WHERE job = ? AND run_key = ? AND (status = 'failed' OR (status = 'running' AND started_at < ?))
+// - When the claim fails, SELECT of the existing run
+//
+// Parameters:
+//   - run: Run to record (Job, RunKey, Trigger and StartedAt must be set; updated with ID,
+//     or with the existing run of the period when the claim fails)
+//   - lease: How long a run may stay as running before its period can be claimed again
+//
+// Returns:
+//   - bool: true if this caller owns the period and must run the job
+//   - error: Database error or nil on success
+func ClaimJobRun(run *m.JobRun, lease time.Duration) (bool, error) {
+	gormDB := db.ORMOpen()
+
+	run.Status = m.JobRunRunning
+	result := gormDB.Clauses(clause.OnConflict{DoNothing: true}).Create(run)
+	if result.Error != nil {
+		return false, fmt.Errorf("error al registrar ejecución de %s: %v", run.Job, result.Error)
+	}
+
+	if result.RowsAffected == 1 {
+		return true, nil
+	}
+
+	// The period already has a run: only a failed or abandoned one can be retried
+	result = gormDB.Model(&m.JobRun{}).
+		Where("job = ? AND run_key = ?", run.Job, run.RunKey).
+		Where(gormDB.Where("status = ?", m.JobRunFailed).
+			Or("status = ? AND started_at < ?", m.JobRunRunning, run.StartedAt.Add(-lease))).
+		Updates(map[string]any{
+			"status":       m.JobRunRunning,
+			"triggered_by": run.Trigger,
+			"items":        0,
+			"summary":      "",
+			"error":        "",
+			"started_at":   run.StartedAt,
+			"finished_at":  nil,
+		})
+	if result.Error != nil {
+		return false, fmt.Errorf("error al reintentar ejecución de %s: %v", run.Job, result.Error)
+	}
+
+	// Reload the claimed row (the ID reported by the ignored insert is not reliable), or the run holding the period
+	claimed := result.RowsAffected > 0
+	job, key := run.Job, run.RunKey
+	*run = m.JobRun{}
+	if err := gormDB.Where("job = ? AND run_key = ?", job, key).First(run).Error; err != nil {
+		return false, fmt.Errorf("error al leer ejecución de %s: %v", job, err)
+	}
+
+	return claimed, nil
+}
+
+// CreateJobRun records the start of a run that does not claim a period (dry or forced runs).
+//
+// Parameters:
+//   - run: Run to record (RunKey must be nil; updated with ID)
+//
+// Returns:
+//   - error: Database error or nil on success
+func CreateJobRun(run *m.JobRun) error {
+	gormDB := db.ORMOpen()
+
+	run.Status = m.JobRunRunning
+	if err := gormDB.Create(run).Error; err != nil {
+		return fmt.Errorf("error al registrar ejecución de %s: %v", run.Job, err)
+	}
+
+	return nil
+}
+
+// FinishJobRun stores the outcome of a run.
+//
+// Parameters:
+//   - run: Finished run (ID, Status, Items, Summary, Error and FinishedAt must be set)
+//
+// Returns:
+//   - error: Database error or nil on success
+func FinishJobRun(run *m.JobRun) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Model(&m.JobRun{}).
+		Where("id = ?", run.ID).
+		Select("status", "items", "summary", "error", "finished_at").
+		Updates(run)
+	if result.Error != nil {
+		return fmt.Errorf("error al finalizar ejecución %d: %v", run.ID, result.Error)
+	}
+
+	return nil
+}
+
+// DeleteJobRunsBefore deletes the finished runs of a job started before a date.
+// Used to keep only the latest runs of frequent jobs.
+//
+// Parameters:
+//   - job: Job name
+//   - before: Runs started before this moment are deleted
+//
+// Returns:
+//   - int64: Number of runs deleted
+//   - error: Database error or nil on success
+func DeleteJobRunsBefore(job string, before time.Time) (int64, error) {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Where("job = ? AND started_at < ? AND status <> ?", job, before, m.JobRunRunning).Delete(&m.JobRun{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("error al borrar ejecuciones de %s: %v", job, result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
+
+// GetJobRuns retrieves the latest runs of a job, newest first.
+//
+// Parameters:
+//   - job: Job name
+//   - limit: Maximum number of runs
+//
+// Returns:
+//   - []m.JobRun: Latest runs
+//   - error: Database error or nil on success
+func GetJobRuns(job string, limit int) ([]m.JobRun, error) {
+	gormDB := db.ORMOpen()
+
+	var runs []m.JobRun
+	result := gormDB.Where("job = ?", job).Order("started_at DESC, id DESC").Limit(limit).Find(&runs)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer ejecuciones de %s: %v", job, result.Error)
+	}
+
+	return runs, nil
+}
+
+// GetLastJobRun retrieves the latest run of a job.
+//
+// Parameters:
+//   - job: Job name
+//
+// Returns:
+//   - *m.JobRun: Latest run, or nil if the job never ran
+//   - error: Database error or nil on success
+func GetLastJobRun(job string) (*m.JobRun, error) {
+	gormDB := db.ORMOpen()
+
+	var run m.JobRun
+	result := gormDB.Where("job = ?", job).Order("started_at DESC, id DESC").First(&run)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer última ejecución de %s: %v", job, result.Error)
+	}
+
+	return &run, nil
+}
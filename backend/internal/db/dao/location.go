@@ -0,0 +1,443 @@
+// Package dao implements data access objects for shelter locations and pet housing.
+// This layer is responsible for:
+// - CRUD operations on buildings, rooms and kennels
+// - Counting the pets housed in each kennel
+// - Moving pets between kennels without exceeding their capacity and keeping the history of moves
+package dao
+
+import (
+	"backend/internal/db"
+	"backend/internal/db/query"
+	m "backend/internal/models"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// LocationListSchema is the allowlist of sort fields and filters accepted by location list queries.
+//
+// Filters:
+//   - kind: building, room or kennel
+//   - parent: Parent location ID
+//   - active: true/false
+//   - species: Locations accepting the species
+//
+// Sort fields: name, crt_date, id
+var LocationListSchema = query.Schema{
+	Sorts: map[string]query.SortColumn{
+		"id":       {Column: "id"},
+		"name":     {Column: "name"},
+		"crt_date": {Column: "crt_date"},
+	},
+	Filters: map[string]query.FilterFunc{
+		"kind":    query.OneOf("kind", m.LocationKinds...),
+		"parent":  query.Uint("parent_id"),
+		"active":  query.Bool("active"),
+		"species": acceptsLocationSpeciesFilter,
+	},
+	DefaultSort: "name",
+}
+
+// acceptsLocationSpeciesFilter keeps locations accepting a species (or any species).
+func acceptsLocationSpeciesFilter(value string) (query.Scope, error) {
+	return func(tx *gorm.DB) *gorm.DB {
+		return tx.Where("(accepted_species IS NULL OR JSON_LENGTH(accepted_species) = 0 OR JSON_CONTAINS(accepted_species, JSON_QUOTE(?)))", value)
+	}, nil
+}
+
+// ========================================
+// LOCATION RETRIEVAL OPERATIONS
+// ========================================
+
+// GetLocations retrieves one page of an organisation's locations matching the list query.
+//
+// Parameters:
+//   - params: Parsed list query (see LocationListSchema)
+//   - orgID: Organisation whose locations are listed
+//
+// Returns:
+//   - *query.Page[m.Location]: Requested page of locations with total count and links
+//   - error: Database error or nil on success
+func GetLocations(params *query.Params, orgID uint) (*query.Page[m.Location], error) {
+	gormDB := db.ORMOpen()
+
+	page, err := query.Find[m.Location](gormDB.Model(&m.Location{}).Scopes(inOrganization(orgID)), params)
+	if err != nil {
+		return nil, fmt.Errorf("error al leer ubicaciones: %v", err)
+	}
+
+	return page, nil
+}
+
+// GetOrganizationLocations retrieves every location of an organisation.
+// Used to build location paths and to add up the occupancy of buildings and rooms.
+//
+// Parameters:
+//   - orgID: Organisation whose locations are listed
+//
+// Returns:
+//   - []m.Location: Locations ordered by name
+//   - error: Database error or nil on success
+func GetOrganizationLocations(orgID uint) ([]m.Location, error) {
+	gormDB := db.ORMOpen()
+
+	var locations []m.Location
+	result := gormDB.Where("organization_id = ?", orgID).Order("name, id").Find(&locations)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer ubicaciones de la organización %d: %v", orgID, result.Error)
+	}
+
+	return locations, nil
+}
+
+// GetLocation retrieves a location of an organisation.
+//
+// Parameters:
+//   - id: Unique identifier of the location
+//   - orgID: Organisation the location must belong to
+//
+// Returns:
+//   - *m.Location: Location data
+//   - error: Database error or record not found error
+func GetLocation(id uint, orgID uint) (*m.Location, error) {
+	gormDB := db.ORMOpen()
+
+	var location m.Location
+	result := gormDB.Scopes(inOrganization(orgID)).First(&location, id)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer ubicación %d: %v", id, result.Error)
+	}
+
+	return &location, nil
+}
+
+// ========================================
+// LOCATION CRUD OPERATIONS
+// ========================================
+
+// CreateLocation inserts a new location.
+//
+// Parameters:
+//   - location: Location to insert (will be updated with ID and timestamps)
+//
+// Returns:
+//   - error: Database error or nil on success
+func CreateLocation(location *m.Location) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Create(location)
+	if result.Error != nil {
+		return fmt.Errorf("error al crear ubicación: %v", result.Error)
+	}
+
+	return nil
+}
+
+// UpdateLocation updates a location of an organisation.
+// The kind and the parent cannot be changed.
+//
+// Parameters:
+//   - location: Location with updated data (must include ID and OrganizationID)
+//
+// Returns:
+//   - error: Database error, record not found error or nil on success
+func UpdateLocation(location *m.Location) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Model(&m.Location{}).
+		Where("id = ? AND organization_id = ?", location.ID, location.OrganizationID).
+		Select("name", "capacity", "accepted_species", "active", "notes").
+		Updates(location)
+	if result.Error != nil {
+		return fmt.Errorf("error al actualizar ubicación %d: %v", location.ID, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("ubicación con id %d no encontrada", location.ID)
+	}
+
+	return nil
+}
+
+// DeleteLocation removes a location of an organisation.
+// Callers must ensure the location has no children nor housing history.
+//
+// Parameters:
+//   - id: Unique identifier of the location
+//   - orgID: Organisation the location must belong to
+//
+// Returns:
+//   - error: Database error, record not found error or nil on success
+func DeleteLocation(id uint, orgID uint) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Where("organization_id = ?", orgID).Delete(&m.Location{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("error al eliminar ubicación %d: %v", id, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("ubicación con id %d no encontrada", id)
+	}
+
+	return nil
+}
+
+// CountChildLocations counts the rooms and kennels inside a location.
+//
+// Parameters:
+//   - id: Unique identifier of the location
+//
+// Returns:
+//   - int64: Number of child locations
+//   - error: Database error or nil on success
+func CountChildLocations(id uint) (int64, error) {
+	gormDB := db.ORMOpen()
+
+	var count int64
+	result := gormDB.Model(&m.Location{}).Where("parent_id = ?", id).Count(&count)
+	if result.Error != nil {
+		return 0, fmt.Errorf("error al contar ubicaciones dentro de %d: %v", id, result.Error)
+	}
+
+	return count, nil
+}
+
+// CountLocationStays counts the stays, current or finished, of pets in a kennel.
+//
+// Parameters:
+//   - id: Unique identifier of the kennel
+//
+// Returns:
+//   - int64: Number of stays
+//   - error: Database error or nil on success
+func CountLocationStays(id uint) (int64, error) {
+	gormDB := db.ORMOpen()
+
+	var count int64
+	result := gormDB.Model(&m.PetLocation{}).Where("location_id = ?", id).Count(&count)
+	if result.Error != nil {
+		return 0, fmt.Errorf("error al contar estancias de la ubicación %d: %v", id, result.Error)
+	}
+
+	return count, nil
+}
+
+// ========================================
+// PET HOUSING OPERATIONS
+// ========================================
+
+// CountHousedPets counts the pets currently housed in each kennel of an organisation.
+//
+// Parameters:
+//   - orgID: Organisation whose kennels are counted
+//
+// Returns:
+//   - map[uint]int: Pets housed by kennel ID (kennels without pets are absent)
+//   - error: Database error or nil on success
+func CountHousedPets(orgID uint) (map[uint]int, error) {
+	gormDB := db.ORMOpen()
+
+	var rows []struct {
+		LocationID uint
+		Pets       int
+	}
+	result := gormDB.Model(&m.PetLocation{}).
+		Select("location_id, COUNT(*) AS pets").
+		Where("organization_id = ? AND ended_at IS NULL", orgID).
+		Group("location_id").
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al contar mascotas alojadas: %v", result.Error)
+	}
+
+	counts := make(map[uint]int, len(rows))
+	for _, row := range rows {
+		counts[row.LocationID] = row.Pets
+	}
+
+	return counts, nil
+}
+
+// GetHousedPets retrieves the current stays of a kennel.
+//
+// Parameters:
+//   - locationID: Unique identifier of the kennel
+//
+// Returns:
+//   - []m.PetLocation: Current stays, earliest arrival first
+//   - error: Database error or nil on success
+func GetHousedPets(locationID uint) ([]m.PetLocation, error) {
+	gormDB := db.ORMOpen()
+
+	var stays []m.PetLocation
+	result := gormDB.Where("location_id = ? AND ended_at IS NULL", locationID).
+		Order("started_at, id").
+		Find(&stays)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer mascotas de la ubicación %d: %v", locationID, result.Error)
+	}
+
+	return stays, nil
+}
+
+// GetCurrentPetLocation retrieves the open stay of a pet.
+//
+// Parameters:
+//   - petID: Unique identifier of the pet
+//
+// Returns:
+//   - *m.PetLocation: Current stay, or nil if the pet is not housed in a kennel
+//   - error: Database error or nil on success
+func GetCurrentPetLocation(petID uint) (*m.PetLocation, error) {
+	gormDB := db.ORMOpen()
+
+	var stay m.PetLocation
+	result := gormDB.Where("pet_id = ? AND ended_at IS NULL", petID).First(&stay)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al buscar ubicación de la mascota %d: %v", petID, result.Error)
+	}
+
+	return &stay, nil
+}
+
+// GetPetLocationHistory retrieves every stay of a pet.
+//
+// Parameters:
+//   - petID: Unique identifier of the pet
+//
+// Returns:
+//   - []m.PetLocation: Stays, most recent first
+//   - error: Database error or nil on success
+func GetPetLocationHistory(petID uint) ([]m.PetLocation, error) {
+	gormDB := db.ORMOpen()
+
+	var stays []m.PetLocation
+	result := gormDB.Where("pet_id = ?", petID).Order("started_at DESC, id DESC").Find(&stays)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer historial de ubicaciones de la mascota %d: %v", petID, result.Error)
+	}
+
+	return stays, nil
+}
+
+// GetLocationPets retrieves the summaries of the pets housed in a kennel.
+//
+// Parameters:
+//   - petIDs: Unique identifiers of the pets
+//
+// Returns:
+//   - map[uint]*m.SimplifiedPet: Pet summaries with primary photo by ID
+//   - error: Database error or nil on success
+func GetLocationPets(petIDs []uint) (map[uint]*m.SimplifiedPet, error) {
+	byID := make(map[uint]*m.SimplifiedPet, len(petIDs))
+	if len(petIDs) == 0 {
+		return byID, nil
+	}
+
+	gormDB := db.ORMOpen()
+
+	var pets []m.Pet
+	result := gormDB.Preload("Photos", "is_primary = ?", true).
+		Where("id IN ?", petIDs).
+		Find(&pets)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer mascotas de la ubicación: %v", result.Error)
+	}
+
+	for _, pet := range pets {
+		summary := toSimplifiedPet(pet)
+		byID[pet.ID] = &summary
+	}
+
+	return byID, nil
+}
+
+// MovePet ends the open stay of a pet, if any, and opens a new one in a kennel, in one transaction.
+// The pet and the kennel are locked while the kennel's occupancy is checked, so concurrent moves
+// never exceed its capacity.
+//
+// Parameters:
+//   - stay: New stay (OrganizationID, PetID, LocationID, StartedAt, Reason and MovedBy set; updated with ID)
+//   - capacity: Capacity of the kennel
+//   - force: Whether to house the pet even if the kennel is full
+//
+// Returns:
+//   - bool: false if the kennel is full (and force is false)
+//   - error: Database error or nil on success
+func MovePet(stay *m.PetLocation, capacity int, force bool) (bool, error) {
+	gormDB := db.ORMOpen()
+
+	moved := false
+	err := gormDB.Transaction(func(tx *gorm.DB) error {
+		// Lock the pet first, so two moves of the same pet cannot both leave an open stay
+		var pet m.Pet
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&pet, stay.PetID).Error; err != nil {
+			return err
+		}
+
+		var location m.Location
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&location, stay.LocationID).Error; err != nil {
+			return err
+		}
+
+		var occupied int64
+		if err := tx.Model(&m.PetLocation{}).
+			Where("location_id = ? AND ended_at IS NULL AND pet_id <> ?", stay.LocationID, stay.PetID).
+			Count(&occupied).Error; err != nil {
+			return err
+		}
+		if !force && occupied >= int64(capacity) {
+			return nil
+		}
+
+		result := tx.Model(&m.PetLocation{}).
+			Where("pet_id = ? AND ended_at IS NULL", stay.PetID).
+			Updates(map[string]any{"ended_at": stay.StartedAt, "end_reason": "traslado"})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		stay.EndedAt = nil
+		if err := tx.Create(stay).Error; err != nil {
+			return err
+		}
+
+		moved = true
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("error al trasladar la mascota %d: %v", stay.PetID, err)
+	}
+
+	return moved, nil
+}
+
+// EndPetLocation ends the open stay of a pet, when it leaves the shelter's kennels.
+//
+// Parameters:
+//   - petID: Unique identifier of the pet
+//   - endedAt: When the pet left
+//   - reason: Why the pet left, e.g. "adoptada", "acogida"
+//
+// Returns:
+//   - bool: false if the pet was not housed in a kennel
+//   - error: Database error or nil on success
+func EndPetLocation(petID uint, endedAt time.Time, reason string) (bool, error) {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Model(&m.PetLocation{}).
+		Where("pet_id = ? AND ended_at IS NULL", petID).
+		Updates(map[string]any{"ended_at": endedAt, "end_reason": reason})
+	if result.Error != nil {
+		return false, fmt.Errorf("error al finalizar la estancia de la mascota %d: %v", petID, result.Error)
+	}
+
+	return result.RowsAffected > 0, nil
+}
@@ -0,0 +1,345 @@
+// Package dao implements data access objects for lost and found reports.
+// This layer is responsible for:
+// - CRUD and moderation operations on lost and found reports and their photos
+// - Loading candidate pets and reports for automatic matching
+// - Persisting matches and tracking which ones were notified
+package dao
+
+import (
+	"backend/internal/db"
+	"backend/internal/db/query"
+	m "backend/internal/models"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// LostFoundListSchema is the allowlist of sort fields and filters accepted by lost and found list queries.
+//
+// Filters:
+//   - type: lost or found
+//   - species: Exact species (comma-separated for several)
+//   - status: pending, approved, rejected or resolved (staff only, public lists are always approved)
+//   - near: lat,lng,radius_km around the last-seen location
+//   - seen_from, seen_to: Range of the date the animal was lost or found (YYYY-MM-DD)
+//
+// Sort fields: seen_date, crt_date, id
+var LostFoundListSchema = query.Schema{
+	Sorts: map[string]query.SortColumn{
+		"id":        {Column: "id"},
+		"seen_date": {Column: "seen_date"},
+		"crt_date":  {Column: "crt_date"},
+	},
+	Filters: map[string]query.FilterFunc{
+		"type":      query.OneOf("type", m.LostFoundTypes...),
+		"species":   query.Equals("species"),
+		"status":    query.OneOf("status", m.LostFoundStatuses...),
+		"near":      query.Near("latitude", "longitude"),
+		"seen_from": query.DateFrom("seen_date"),
+		"seen_to":   query.DateTo("seen_date"),
+	},
+	DefaultSort: "-crt_date",
+}
+
+// ========================================
+// LOST AND FOUND RETRIEVAL OPERATIONS
+// ========================================
+
+// GetLostFoundReports retrieves one page of lost and found reports matching the list query.
+//
+// Database Operations:
+// - Performs SELECT COUNT(*) and SELECT * FROM Lost_Found_Reports with the filters from LostFoundListSchema
+// - Restricts the query to approved reports when approvedOnly is set
+// - Preloads report photos
+//
+// Parameters:
+//   - params: Parsed list query (see LostFoundListSchema)
+//   - approvedOnly: Whether only approved (public) reports are returned
+//
+// Returns:
+//   - *query.Page[m.LostFoundReport]: Requested page of reports with total count and links
+//   - error: Database error or nil on success
+func GetLostFoundReports(params *query.Params, approvedOnly bool) (*query.Page[m.LostFoundReport], error) {
+	gormDB := db.ORMOpen()
+
+	base := gormDB.Model(&m.LostFoundReport{})
+	if approvedOnly {
+		base = base.Where("status = ?", m.LostFoundStatusApproved)
+	}
+
+	page, err := query.Find[m.LostFoundReport](base, params, func(tx *gorm.DB) *gorm.DB {
+		return tx.Preload("Photos", func(tx *gorm.DB) *gorm.DB { return tx.Order("id") })
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error al leer avisos de mascotas perdidas y encontradas: %v", err)
+	}
+
+	return page, nil
+}
+
+// GetLostFoundReport retrieves a lost or found report with its photos.
+//
+// Parameters:
+//   - id: Unique identifier of the report
+//
+// Returns:
+//   - *m.LostFoundReport: Report data with photos
+//   - error: Database error or record not found error
+func GetLostFoundReport(id uint) (*m.LostFoundReport, error) {
+	gormDB := db.ORMOpen()
+
+	var report m.LostFoundReport
+	result := gormDB.Preload("Photos", func(tx *gorm.DB) *gorm.DB { return tx.Order("id") }).First(&report, id)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer aviso %d: %v", id, result.Error)
+	}
+
+	return &report, nil
+}
+
+// GetLostFoundPhoto retrieves a photo, ensuring it belongs to the given report.
+//
+// Parameters:
+//   - reportID: Unique identifier of the report
+//   - photoID: Unique identifier of the photo
+//
+// Returns:
+//   - *m.LostFoundPhoto: Photo metadata
+//   - error: Database error or record not found error
+func GetLostFoundPhoto(reportID uint, photoID uint) (*m.LostFoundPhoto, error) {
+	gormDB := db.ORMOpen()
+
+	var photo m.LostFoundPhoto
+	result := gormDB.Where("id = ? AND report_id = ?", photoID, reportID).First(&photo)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer foto %d del aviso %d: %v", photoID, reportID, result.Error)
+	}
+
+	return &photo, nil
+}
+
+// ========================================
+// LOST AND FOUND CRUD OPERATIONS
+// ========================================
+
+// CreateLostFoundReport inserts a new lost or found report (without photos).
+//
+// Parameters:
+//   - report: Report to insert (will be updated with ID and timestamps)
+//
+// Returns:
+//   - error: Database error or nil on success
+func CreateLostFoundReport(report *m.LostFoundReport) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Omit("Photos").Create(report)
+	if result.Error != nil {
+		return fmt.Errorf("error al crear aviso: %v", result.Error)
+	}
+
+	return nil
+}
+
+// CreateLostFoundPhoto inserts the metadata of a stored report photo.
+//
+// Parameters:
+//   - photo: Photo to insert (will be updated with ID and timestamps)
+//
+// Returns:
+//   - error: Database error or nil on success
+func CreateLostFoundPhoto(photo *m.LostFoundPhoto) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Create(photo)
+	if result.Error != nil {
+		return fmt.Errorf("error al registrar foto del aviso %d: %v", photo.ReportID, result.Error)
+	}
+
+	return nil
+}
+
+// UpdateLostFoundModeration stores the moderation status, note and moderator of a report.
+//
+// Parameters:
+//   - report: Report with updated moderation fields (must include ID)
+//
+// Returns:
+//   - error: Database error, record not found error or nil on success
+func UpdateLostFoundModeration(report *m.LostFoundReport) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Model(&m.LostFoundReport{}).
+		Where("id = ?", report.ID).
+		Select("status", "moderated_by", "moderated_at", "moderation_note").
+		Updates(report)
+	if result.Error != nil {
+		return fmt.Errorf("error al moderar aviso %d: %v", report.ID, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("aviso con id %d no encontrado", report.ID)
+	}
+
+	return nil
+}
+
+// DeleteLostFoundReport removes a report.
+// Its photos and matches are removed by the ON DELETE CASCADE constraints.
+//
+// Parameters:
+//   - id: Unique identifier of the report
+//
+// Returns:
+//   - error: Database error, record not found error or nil on success
+func DeleteLostFoundReport(id uint) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Delete(&m.LostFoundReport{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("error al eliminar aviso %d: %v", id, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("aviso con id %d no encontrado", id)
+	}
+
+	return nil
+}
+
+// ========================================
+// LOST AND FOUND MATCHING OPERATIONS
+// ========================================
+
+// GetMatchCandidatePets retrieves the pets that may match a report: pets of the same
+// species and, when the report has a microchip, the pet carrying it.
+//
+// Parameters:
+//   - species: Species of the reported animal
+//   - microchip: Normalised microchip number of the reported animal, or nil
+//   - excludeAdopted: Whether adopted pets are left out (lost reports only match shelter pets)
+//
+// Returns:
+//   - []m.Pet: Candidate pets with the primary photo preloaded
+//   - error: Database error or nil on success
+func GetMatchCandidatePets(species string, microchip *string, excludeAdopted bool) ([]m.Pet, error) {
+	gormDB := db.ORMOpen()
+
+	tx := gormDB.Preload("Photos", "is_primary = ?", true)
+	if microchip != nil {
+		tx = tx.Where("species = ? OR microchip = ?", species, *microchip)
+	} else {
+		tx = tx.Where("species = ?", species)
+	}
+	if excludeAdopted {
+		tx = tx.Where("status <> ?", m.PetStatusAdopted)
+	}
+
+	var pets []m.Pet
+	if err := tx.Find(&pets).Error; err != nil {
+		return nil, fmt.Errorf("error al leer mascotas candidatas: %v", err)
+	}
+
+	return pets, nil
+}
+
+// GetApprovedReportsForPet retrieves the approved reports that may match a pet:
+// reports of the same species and, when the pet has a microchip, reports with it.
+//
+// Parameters:
+//   - species: Species of the pet
+//   - microchip: Microchip number of the pet, or nil
+//
+// Returns:
+//   - []m.LostFoundReport: Candidate reports
+//   - error: Database error or nil on success
+func GetApprovedReportsForPet(species string, microchip *string) ([]m.LostFoundReport, error) {
+	gormDB := db.ORMOpen()
+
+	tx := gormDB.Where("status = ?", m.LostFoundStatusApproved)
+	if microchip != nil {
+		tx = tx.Where("species = ? OR microchip = ?", species, *microchip)
+	} else {
+		tx = tx.Where("species = ?", species)
+	}
+
+	var reports []m.LostFoundReport
+	if err := tx.Find(&reports).Error; err != nil {
+		return nil, fmt.Errorf("error al leer avisos aprobados: %v", err)
+	}
+
+	return reports, nil
+}
+
+// GetLostFoundMatches retrieves the candidate pets of a report, strongest first.
+//
+// Parameters:
+//   - reportID: Unique identifier of the report
+//
+// Returns:
+//   - []m.LostFoundMatch: Matches with the candidate pet summary filled in
+//   - error: Database error or nil on success
+func GetLostFoundMatches(reportID uint) ([]m.LostFoundMatch, error) {
+	gormDB := db.ORMOpen()
+
+	var matches []m.LostFoundMatch
+	result := gormDB.Preload("Pet").
+		Preload("Pet.Photos", "is_primary = ?", true).
+		Where("report_id = ?", reportID).
+		Order("score DESC, id").
+		Find(&matches)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer coincidencias del aviso %d: %v", reportID, result.Error)
+	}
+
+	for i := range matches {
+		candidate := toSimplifiedPet(matches[i].Pet)
+		matches[i].Candidate = &candidate
+	}
+
+	return matches, nil
+}
+
+// CreateLostFoundMatch inserts a new match.
+// Inserting a match that already exists for the same report and pet has no effect.
+//
+// Parameters:
+//   - match: Match to insert
+//
+// Returns:
+//   - bool: Whether the match was inserted (false if it already existed)
+//   - error: Database error or nil on success
+func CreateLostFoundMatch(match *m.LostFoundMatch) (bool, error) {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Clauses(clause.OnConflict{DoNothing: true}).Omit("Pet").Create(match)
+	if result.Error != nil {
+		return false, fmt.Errorf("error al registrar coincidencia del aviso %d: %v", match.ReportID, result.Error)
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// MarkLostFoundMatchesNotified sets the notification time of the given matches.
+//
+// Parameters:
+//   - ids: Matches included in an email
+//   - at: Time the email was sent
+//
+// Returns:
+//   - error: Database error or nil on success
+func MarkLostFoundMatchesNotified(ids []uint, at time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Model(&m.LostFoundMatch{}).Where("id IN ?", ids).Update("notified_at", at)
+	if result.Error != nil {
+		return fmt.Errorf("error al marcar coincidencias notificadas: %v", result.Error)
+	}
+
+	return nil
+}
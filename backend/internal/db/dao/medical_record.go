@@ -0,0 +1,276 @@
+// Package dao implements data access objects for pet medical records.
+// This layer is responsible for:
+// - CRUD operations on medical records, always scoped to their pet
+// - Listing the medical history of a pet
+// - Finding vaccinations and treatments due for staff reminders
+// - Recording which staff members already got the reminder digest of the day
+package dao
+
+import (
+	"backend/internal/db"
+	m "backend/internal/models"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm/clause"
+)
+
+// ========================================
+// MEDICAL RECORD RETRIEVAL OPERATIONS
+// ========================================
+
+// GetMedicalRecords retrieves the medical records of a pet, most recent first.
+//
+// Database Operations:
+// - Performs SELECT * FROM Pet_Medical_Records WHERE pet_id = ? [AND type = ?] ORDER BY date DESC, id DESC
+//
+// Parameters:
+//   - petID: Pet whose records are retrieved
+//   - recordType: Record type to filter by, or "" for every type
+//
+// Returns:
+//   - []m.MedicalRecord: Medical records of the pet
+//   - error: Database error or nil on success
+func GetMedicalRecords(petID uint, recordType string) ([]m.MedicalRecord, error) {
+	gormDB := db.ORMOpen()
+
+	tx := gormDB.Where("pet_id = ?", petID)
+	if recordType != "" {
+		tx = tx.Where("type = ?", recordType)
+	}
+
+	var records []m.MedicalRecord
+	result := tx.Order("date DESC, id DESC").Find(&records)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer historial médico de la mascota %d: %v", petID, result.Error)
+	}
+
+	return records, nil
+}
+
+// GetMedicalRecord retrieves a medical record, ensuring it belongs to the given pet.
+//
+// Parameters:
+//   - petID: Pet the record belongs to
+//   - id: Unique identifier of the record
+//
+// Returns:
+//   - *m.MedicalRecord: Medical record data
+//   - error: Database error or record not found error
+func GetMedicalRecord(petID uint, id uint) (*m.MedicalRecord, error) {
+	gormDB := db.ORMOpen()
+
+	var record m.MedicalRecord
+	result := gormDB.Where("id = ? AND pet_id = ?", id, petID).First(&record)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer registro médico %d: %v", id, result.Error)
+	}
+
+	return &record, nil
+}
+
+// ========================================
+// MEDICAL RECORD CRUD OPERATIONS
+// ========================================
+
+// CreateMedicalRecord inserts a new medical record.
+//
+// Parameters:
+//   - record: Medical record to insert (will be updated with ID and timestamps)
+//
+// Returns:
+//   - error: Database error or nil on success
+func CreateMedicalRecord(record *m.MedicalRecord) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Create(record)
+	if result.Error != nil {
+		return fmt.Errorf("error al crear registro médico: %v", result.Error)
+	}
+
+	return nil
+}
+
+// UpdateMedicalRecord updates every editable field of a medical record of record.PetID.
+// The pet, type and author of a record cannot be changed.
+//
+// Parameters:
+//   - record: Medical record with updated data (must include ID and PetID)
+//
+// Returns:
+//   - error: Database error, record not found error or nil on success
+func UpdateMedicalRecord(record *m.MedicalRecord) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Model(&m.MedicalRecord{}).
+		Where("id = ? AND pet_id = ?", record.ID, record.PetID).
+		Select("name", "date", "due_date", "end_date", "weight_kg", "vet", "notes").
+		Updates(record)
+	if result.Error != nil {
+		return fmt.Errorf("error al actualizar registro médico %d: %v", record.ID, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("registro médico con id %d no encontrado", record.ID)
+	}
+
+	return nil
+}
+
+// DeleteMedicalRecord removes a medical record of the given pet.
+//
+// Parameters:
+//   - petID: Pet the record belongs to
+//   - id: Unique identifier of the record
+//
+// Returns:
+//   - error: Database error, record not found error or nil on success
+func DeleteMedicalRecord(petID uint, id uint) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Where("id = ? AND pet_id = ?", id, petID).Delete(&m.MedicalRecord{})
+	if result.Error != nil {
+		return fmt.Errorf("error al eliminar registro médico %d: %v", id, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("registro médico con id %d no encontrado", id)
+	}
+
+	return nil
+}
+
+// ========================================
+// MEDICAL REMINDER OPERATIONS
+// ========================================
+
+// GetDueVaccinations retrieves the latest dose of each vaccine whose next dose is
+// due on or before until, including overdue ones. Adopted pets are excluded.
+//
+// Database Operations:
+// - Joins Pet_Medical_Records with Pets to get the pet name, organisation and status
+// - Skips doses superseded by a later dose of the same vaccine for the same pet
+//
+// Parameters:
+//   - until: Last due date included
+//
+// Returns:
+//   - []m.MedicalReminder: Due vaccinations ordered by organisation and due date
+//   - error: Database error or nil on success
+func GetDueVaccinations(until time.Time) ([]m.MedicalReminder, error) {
+	gormDB := db.ORMOpen()
+
+	newer := gormDB.Table("Pet_Medical_Records AS n").
+		Select("1").
+		Where("n.pet_id = r.pet_id AND n.type = r.type AND n.name = r.name").
+		Where("(n.date > r.date OR (n.date = r.date AND n.id > r.id))")
+
+	var reminders []m.MedicalReminder
+	result := gormDB.Table("Pet_Medical_Records AS r").
+		Select("r.*, p.name AS pet_name, p.organization_id AS organization_id").
+		Joins("JOIN Pets p ON p.id = r.pet_id").
+		Where("r.type = ? AND r.due_date IS NOT NULL AND r.due_date <= ?", m.MedicalVaccination, until).
+		Where("p.status <> ?", m.PetStatusAdopted).
+		Where("NOT EXISTS (?)", newer).
+		Order("p.organization_id, r.due_date, p.name").
+		Scan(&reminders)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer vacunas pendientes: %v", result.Error)
+	}
+
+	return reminders, nil
+}
+
+// GetEndingTreatments retrieves the treatments ending between from and until (inclusive).
+// Adopted pets are excluded.
+//
+// Parameters:
+//   - from: First end date included
+//   - until: Last end date included
+//
+// Returns:
+//   - []m.MedicalReminder: Ending treatments ordered by organisation and end date
+//   - error: Database error or nil on success
+func GetEndingTreatments(from time.Time, until time.Time) ([]m.MedicalReminder, error) {
+	gormDB := db.ORMOpen()
+
+	var reminders []m.MedicalReminder
+	result := gormDB.Table("Pet_Medical_Records AS r").
+		Select("r.*, p.name AS pet_name, p.organization_id AS organization_id").
+		Joins("JOIN Pets p ON p.id = r.pet_id").
+		Where("r.type = ? AND r.end_date BETWEEN ? AND ?", m.MedicalTreatment, from, until).
+		Where("p.status <> ?", m.PetStatusAdopted).
+		Order("p.organization_id, r.end_date, p.name").
+		Scan(&reminders)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer tratamientos que finalizan: %v", result.Error)
+	}
+
+	return reminders, nil
+}
+
+// ========================================
+// MEDICAL REMINDER DELIVERY OPERATIONS
+// ========================================
+
+// ClaimMedicalReminderDelivery records that a staff member is getting the digest of a day.
+// The claim fails when the staff member already got it, so retried runs skip them.
+//
+// Database Operations:
+// - INSERT INTO Medical_Reminder_Deliveries ... ON CONFLICT DO NOTHING (primary key day + organisation + user)
+//
+// Parameters:
+//   - delivery: Delivery to record (DigestDate, OrganizationID and UserID)
+//
+// Returns:
+//   - bool: true if the caller must send the digest
+//   - error: Database error or nil on success
+func ClaimMedicalReminderDelivery(delivery *m.MedicalReminderDelivery) (bool, error) {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Clauses(clause.OnConflict{DoNothing: true}).Create(delivery)
+	if result.Error != nil {
+		return false, fmt.Errorf("error al registrar envío del resumen médico al usuario %d: %v", delivery.UserID, result.Error)
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// ReleaseMedicalReminderDelivery removes a claimed delivery whose digest could not be sent,
+// so the next run sends it again.
+//
+// Parameters:
+//   - delivery: Claimed delivery
+//
+// Returns:
+//   - error: Database error or nil on success
+func ReleaseMedicalReminderDelivery(delivery *m.MedicalReminderDelivery) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Where("digest_date = ? AND organization_id = ? AND user_id = ?",
+		delivery.DigestDate, delivery.OrganizationID, delivery.UserID).
+		Delete(&m.MedicalReminderDelivery{})
+	if result.Error != nil {
+		return fmt.Errorf("error al liberar envío del resumen médico al usuario %d: %v", delivery.UserID, result.Error)
+	}
+
+	return nil
+}
+
+// DeleteMedicalReminderDeliveriesBefore deletes the deliveries of the days before a date.
+//
+// Parameters:
+//   - day: First day whose deliveries are kept
+//
+// Returns:
+//   - error: Database error or nil on success
+func DeleteMedicalReminderDeliveriesBefore(day time.Time) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Where("digest_date < ?", day).Delete(&m.MedicalReminderDelivery{})
+	if result.Error != nil {
+		return fmt.Errorf("error al borrar envíos de resúmenes médicos: %v", result.Error)
+	}
+
+	return nil
+}
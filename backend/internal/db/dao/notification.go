@@ -0,0 +1,251 @@
+// Package dao implements data access objects for notification preferences and the notification centre.
+// This layer is responsible for:
+// - Reading and saving the notification preferences of users
+// - Storing in-app notifications and listing them, always scoped to their user
+// - Counting unread notifications and marking them as read
+package dao
+
+import (
+	"backend/internal/db"
+	"backend/internal/db/query"
+	m "backend/internal/models"
+	"fmt"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// NotificationListSchema is the allowlist of sort fields and filters accepted by notification centre queries.
+//
+// Filters:
+//   - type: Notification type (see m.NotificationTypes)
+//   - unread: true keeps unread notifications, false read ones
+//
+// Sort fields: id, crt_date
+var NotificationListSchema = query.Schema{
+	Sorts: map[string]query.SortColumn{
+		"id":       {Column: "id"},
+		"crt_date": {Column: "crt_date"},
+	},
+	Filters: map[string]query.FilterFunc{
+		"type":   query.OneOf("type", m.NotificationTypes...),
+		"unread": unreadNotificationFilter,
+	},
+	DefaultSort: "-id",
+}
+
+// unreadNotificationFilter keeps unread (true) or read (false) notifications.
+func unreadNotificationFilter(value string) (query.Scope, error) {
+	unread, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, fmt.Errorf("unread debe ser true o false")
+	}
+
+	return func(tx *gorm.DB) *gorm.DB {
+		if unread {
+			return tx.Where("read_at IS NULL")
+		}
+		return tx.Where("read_at IS NOT NULL")
+	}, nil
+}
+
+// ========================================
+// NOTIFICATION PREFERENCE OPERATIONS
+// ========================================
+
+// GetNotificationPreferences retrieves the stored notification preferences of a user.
+// Types without a stored preference are not returned (every channel enabled).
+//
+// Parameters:
+//   - userID: Owner of the preferences
+//
+// Returns:
+//   - []m.NotificationPreference: Stored preferences of the user
+//   - error: Database error or nil on success
+func GetNotificationPreferences(userID uint) ([]m.NotificationPreference, error) {
+	gormDB := db.ORMOpen()
+
+	var preferences []m.NotificationPreference
+	result := gormDB.Where("user_id = ?", userID).Order("type").Find(&preferences)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer preferencias de notificación del usuario %d: %v", userID, result.Error)
+	}
+
+	return preferences, nil
+}
+
+// SaveNotificationPreferences creates or replaces notification preferences of a user.
+//
+// Database Operations:
+// - Performs INSERT ... ON DUPLICATE KEY UPDATE email, in_app for every preference
+//
+// Parameters:
+//   - preferences: Preferences to save (UserID, Type and channels)
+//
+// Returns:
+//   - error: Database error or nil on success
+func SaveNotificationPreferences(preferences []m.NotificationPreference) error {
+	if len(preferences) == 0 {
+		return nil
+	}
+
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "type"}},
+		DoUpdates: clause.AssignmentColumns([]string{"email", "in_app", "upt_date"}),
+	}).Create(&preferences)
+	if result.Error != nil {
+		return fmt.Errorf("error al guardar preferencias de notificación del usuario %d: %v", preferences[0].UserID, result.Error)
+	}
+
+	return nil
+}
+
+// ========================================
+// NOTIFICATION CENTRE OPERATIONS
+// ========================================
+
+// CreateNotification stores an in-app notification.
+//
+// Parameters:
+//   - notification: Notification to store (UserID, Type, Title, Body and URL)
+//
+// Returns:
+//   - error: Database error or nil on success
+func CreateNotification(notification *m.Notification) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Create(notification)
+	if result.Error != nil {
+		return fmt.Errorf("error al crear notificación del usuario %d: %v", notification.UserID, result.Error)
+	}
+
+	return nil
+}
+
+// GetNotifications retrieves one page of a user's notifications matching the notification centre query.
+//
+// Parameters:
+//   - params: Parsed list query (see NotificationListSchema)
+//   - userID: Recipient of the notifications
+//
+// Returns:
+//   - *query.Page[m.Notification]: Requested page of notifications with total count and links
+//   - error: Database error or nil on success
+func GetNotifications(params *query.Params, userID uint) (*query.Page[m.Notification], error) {
+	gormDB := db.ORMOpen()
+
+	page, err := query.Find[m.Notification](gormDB.Model(&m.Notification{}).Where("user_id = ?", userID), params)
+	if err != nil {
+		return nil, fmt.Errorf("error al leer notificaciones del usuario %d: %v", userID, err)
+	}
+
+	return page, nil
+}
+
+// GetNotification retrieves a notification, ensuring it belongs to the given user.
+//
+// Parameters:
+//   - userID: Recipient of the notification
+//   - id: Unique identifier of the notification
+//
+// Returns:
+//   - *m.Notification: Notification data
+//   - error: Database error or record not found error
+func GetNotification(userID uint, id uint) (*m.Notification, error) {
+	gormDB := db.ORMOpen()
+
+	var notification m.Notification
+	result := gormDB.Where("id = ? AND user_id = ?", id, userID).First(&notification)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer notificación %d: %v", id, result.Error)
+	}
+
+	return &notification, nil
+}
+
+// CountUnreadNotifications counts the unread notifications of a user by type.
+//
+// Database Operations:
+// - Performs SELECT type, COUNT(*) FROM Notifications WHERE user_id = ? AND read_at IS NULL GROUP BY type
+//
+// Parameters:
+//   - userID: Recipient of the notifications
+//
+// Returns:
+//   - map[string]int64: Unread notifications per type (types without any are missing)
+//   - error: Database error or nil on success
+func CountUnreadNotifications(userID uint) (map[string]int64, error) {
+	gormDB := db.ORMOpen()
+
+	var rows []struct {
+		Type  string
+		Count int64
+	}
+	result := gormDB.Model(&m.Notification{}).
+		Select("type, COUNT(*) AS count").
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Group("type").
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al contar notificaciones del usuario %d: %v", userID, result.Error)
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Type] = row.Count
+	}
+
+	return counts, nil
+}
+
+// MarkNotificationsRead marks the unread notifications of a user as read.
+//
+// Parameters:
+//   - userID: Recipient of the notifications
+//   - id: Notification to mark, or 0 to mark every unread notification
+//   - notificationType: Only mark notifications of this type (optional, ignored with an id)
+//   - readAt: Read timestamp
+//
+// Returns:
+//   - int64: Number of notifications marked
+//   - error: Database error or nil on success
+func MarkNotificationsRead(userID uint, id uint, notificationType string, readAt time.Time) (int64, error) {
+	gormDB := db.ORMOpen()
+
+	tx := gormDB.Model(&m.Notification{}).Where("user_id = ? AND read_at IS NULL", userID)
+	if id != 0 {
+		tx = tx.Where("id = ?", id)
+	} else if notificationType != "" {
+		tx = tx.Where("type = ?", notificationType)
+	}
+
+	result := tx.Update("read_at", readAt)
+	if result.Error != nil {
+		return 0, fmt.Errorf("error al marcar notificaciones del usuario %d como leídas: %v", userID, result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
+
+// DeleteReadNotificationsBefore deletes the notifications read before a date.
+//
+// Parameters:
+//   - before: Notifications read before this moment are deleted
+//
+// Returns:
+//   - int64: Number of notifications deleted
+//   - error: Database error or nil on success
+func DeleteReadNotificationsBefore(before time.Time) (int64, error) {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Where("read_at IS NOT NULL AND read_at < ?", before).Delete(&m.Notification{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("error al borrar notificaciones leídas: %v", result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
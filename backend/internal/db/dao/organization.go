@@ -0,0 +1,299 @@
+// Package dao implements data access objects for shelter organisations.
+// This layer is responsible for:
+// - CRUD operations on organisations and their settings
+// - Managing staff memberships of organisations
+// - Scoping pet and species queries to an organisation
+package dao
+
+import (
+	"backend/internal/db"
+	"backend/internal/db/query"
+	m "backend/internal/models"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AllOrganizations is passed to pet and species queries that span every organisation:
+// public browsing (which narrows results with the organization filter instead) and background jobs.
+const AllOrganizations uint = 0
+
+// OrganizationListSchema is the allowlist of sort fields and filters accepted by organisation list queries.
+//
+// Filters:
+//   - name: Name contains the value
+//
+// Sort fields: id, name
+var OrganizationListSchema = query.Schema{
+	Sorts: map[string]query.SortColumn{
+		"id":   {Column: "id"},
+		"name": {Column: "name"},
+	},
+	Filters: map[string]query.FilterFunc{
+		"name": query.Contains("name"),
+	},
+	DefaultSort: "name",
+}
+
+// inOrganization returns a scope restricting a pet or species query to an organisation.
+// AllOrganizations leaves the query unrestricted.
+func inOrganization(orgID uint) func(*gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		if orgID == AllOrganizations {
+			return tx
+		}
+
+		return tx.Where("organization_id = ?", orgID)
+	}
+}
+
+// ========================================
+// ORGANIZATION RETRIEVAL OPERATIONS
+// ========================================
+
+// GetOrganizations retrieves one page of organisations matching the list query.
+//
+// Parameters:
+//   - params: Parsed list query (see OrganizationListSchema)
+//
+// Returns:
+//   - *query.Page[m.Organization]: Requested page of organisations with total count and links
+//   - error: Database error or nil on success
+func GetOrganizations(params *query.Params) (*query.Page[m.Organization], error) {
+	gormDB := db.ORMOpen()
+
+	page, err := query.Find[m.Organization](gormDB.Model(&m.Organization{}), params)
+	if err != nil {
+		return nil, fmt.Errorf("error al leer organizaciones: %v", err)
+	}
+
+	return page, nil
+}
+
+// GetOrganizationByID retrieves an organisation with its settings.
+//
+// Parameters:
+//   - id: Unique identifier of the organisation
+//
+// Returns:
+//   - *m.Organization: Organisation data
+//   - error: Database error or record not found error
+func GetOrganizationByID(id uint) (*m.Organization, error) {
+	gormDB := db.ORMOpen()
+
+	var organization m.Organization
+	result := gormDB.First(&organization, id)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer organización %d: %v", id, result.Error)
+	}
+
+	return &organization, nil
+}
+
+// GetOrganizationBySlug retrieves the organisation with the given slug.
+//
+// Parameters:
+//   - slug: URL identifier of the organisation
+//
+// Returns:
+//   - *m.Organization: Organisation data, or nil if no organisation has the slug
+//   - error: Database error or nil on success
+func GetOrganizationBySlug(slug string) (*m.Organization, error) {
+	gormDB := db.ORMOpen()
+
+	var organization m.Organization
+	result := gormDB.Where("slug = ?", slug).First(&organization)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al buscar organización por slug: %v", result.Error)
+	}
+
+	return &organization, nil
+}
+
+// ========================================
+// ORGANIZATION CRUD OPERATIONS
+// ========================================
+
+// CreateOrganization inserts a new organisation.
+//
+// Parameters:
+//   - organization: Organisation to insert (will be updated with ID and timestamps)
+//
+// Returns:
+//   - error: Database error (including duplicate slug) or nil on success
+func CreateOrganization(organization *m.Organization) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Create(organization)
+	if result.Error != nil {
+		return fmt.Errorf("error al crear organización: %v", result.Error)
+	}
+
+	return nil
+}
+
+// UpdateOrganization updates the profile and settings of an organisation.
+//
+// Parameters:
+//   - organization: Organisation with updated data (must include ID)
+//
+// Returns:
+//   - error: Database error, record not found error or nil on success
+func UpdateOrganization(organization *m.Organization) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Model(&m.Organization{}).
+		Where("id = ?", organization.ID).
+		Select("name", "slug", "email", "phone", "address", "website",
+			"sender_name", "sender_email", "reply_to", "adoption_questionnaire").
+		Updates(organization)
+	if result.Error != nil {
+		return fmt.Errorf("error al actualizar organización %d: %v", organization.ID, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("organización con id %d no encontrada", organization.ID)
+	}
+
+	return nil
+}
+
+// ========================================
+// ORGANIZATION MEMBERSHIP OPERATIONS
+// ========================================
+
+// GetMembership retrieves the membership of a user in an organisation.
+//
+// Parameters:
+//   - orgID: Unique identifier of the organisation
+//   - userID: Unique identifier of the user
+//
+// Returns:
+//   - *m.OrganizationMember: Membership, or nil if the user is not a member
+//   - error: Database error or nil on success
+func GetMembership(orgID uint, userID uint) (*m.OrganizationMember, error) {
+	gormDB := db.ORMOpen()
+
+	var member m.OrganizationMember
+	result := gormDB.Where("organization_id = ? AND user_id = ?", orgID, userID).First(&member)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer pertenencia a la organización %d: %v", orgID, result.Error)
+	}
+
+	return &member, nil
+}
+
+// GetUserMemberships retrieves every organisation membership of a user.
+//
+// Parameters:
+//   - userID: Unique identifier of the user
+//
+// Returns:
+//   - []m.OrganizationMember: Memberships ordered by organisation
+//   - error: Database error or nil on success
+func GetUserMemberships(userID uint) ([]m.OrganizationMember, error) {
+	gormDB := db.ORMOpen()
+
+	var members []m.OrganizationMember
+	result := gormDB.Where("user_id = ?", userID).Order("organization_id").Find(&members)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer organizaciones del usuario %d: %v", userID, result.Error)
+	}
+
+	return members, nil
+}
+
+// GetOrganizationMembers retrieves the members of an organisation with their user summary.
+//
+// Parameters:
+//   - orgID: Unique identifier of the organisation
+//
+// Returns:
+//   - []m.OrganizationMember: Members ordered by join date
+//   - error: Database error or nil on success
+func GetOrganizationMembers(orgID uint) ([]m.OrganizationMember, error) {
+	gormDB := db.ORMOpen()
+
+	var members []m.OrganizationMember
+	result := gormDB.Where("organization_id = ?", orgID).Order("crt_date, id").Find(&members)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer miembros de la organización %d: %v", orgID, result.Error)
+	}
+
+	if len(members) == 0 {
+		return members, nil
+	}
+
+	userIDs := make([]uint, len(members))
+	for i, member := range members {
+		userIDs[i] = member.UserID
+	}
+
+	// Users are loaded as SimplifiedUser so passwords and sessions are never exposed
+	var users []m.SimplifiedUser
+	if err := gormDB.Model(&m.User{}).Where("id IN ?", userIDs).Find(&users).Error; err != nil {
+		return nil, fmt.Errorf("error al leer usuarios de la organización %d: %v", orgID, err)
+	}
+
+	byID := make(map[uint]*m.SimplifiedUser, len(users))
+	for i := range users {
+		byID[users[i].ID] = &users[i]
+	}
+	for i := range members {
+		members[i].User = byID[members[i].UserID]
+	}
+
+	return members, nil
+}
+
+// SaveOrganizationMember adds a user to an organisation, or changes their role if already a member.
+//
+// Parameters:
+//   - member: Membership to save (OrganizationID, UserID and Role)
+//
+// Returns:
+//   - error: Database error or nil on success
+func SaveOrganizationMember(member *m.OrganizationMember) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "organization_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"role"}),
+	}).Create(member)
+	if result.Error != nil {
+		return fmt.Errorf("error al guardar miembro de la organización %d: %v", member.OrganizationID, result.Error)
+	}
+
+	return nil
+}
+
+// RemoveOrganizationMember removes a user from an organisation.
+//
+// Parameters:
+//   - orgID: Unique identifier of the organisation
+//   - userID: Unique identifier of the user
+//
+// Returns:
+//   - error: Database error, record not found error or nil on success
+func RemoveOrganizationMember(orgID uint, userID uint) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Where("organization_id = ? AND user_id = ?", orgID, userID).Delete(&m.OrganizationMember{})
+	if result.Error != nil {
+		return fmt.Errorf("error al eliminar miembro de la organización %d: %v", orgID, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("el usuario %d no es miembro de la organización %d", userID, orgID)
+	}
+
+	return nil
+}
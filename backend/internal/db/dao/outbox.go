@@ -0,0 +1,247 @@
+// Package dao implements data access objects for the email outbox.
+// This layer is responsible for:
+// - Writing emails to the outbox, alone or inside the transaction of other changes
+// - Claiming due emails before each attempt and recording the outcome
+// - Clearing the messages of sent and dead-lettered emails
+// - Listing and requeueing emails for the admin API
+package dao
+
+import (
+	"backend/internal/db"
+	"backend/internal/db/query"
+	m "backend/internal/models"
+	"fmt"
+	"net/mail"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// OutboxEmailListSchema is the allowlist of sort fields and filters accepted by outbox queries.
+//
+// Filters:
+//   - status: pending, sent or failed
+//   - recipient: Recipient address
+//   - from, to: Queue date range (YYYY-MM-DD)
+//
+// Sort fields: id, crt_date, next_attempt_at
+var OutboxEmailListSchema = query.Schema{
+	Sorts: map[string]query.SortColumn{
+		"id":              {Column: "id"},
+		"crt_date":        {Column: "crt_date"},
+		"next_attempt_at": {Column: "next_attempt_at", Nullable: true},
+	},
+	Filters: map[string]query.FilterFunc{
+		"status":    query.OneOf("status", m.OutboxEmailStatuses...),
+		"recipient": outboxRecipientFilter,
+		"from":      query.DateFrom("crt_date"),
+		"to":        query.DateTo("crt_date"),
+	},
+	DefaultSort: "-id",
+}
+
+// outboxRecipientFilter keeps the emails sent to an address.
+func outboxRecipientFilter(value string) (query.Scope, error) {
+	address, err := mail.ParseAddress(value)
+	if err != nil {
+		return nil, fmt.Errorf("recipient debe ser una dirección de email")
+	}
+
+	return func(tx *gorm.DB) *gorm.DB {
+		return tx.Where("JSON_CONTAINS(recipients, JSON_QUOTE(?))", address.Address)
+	}, nil
+}
+
+// ========================================
+// OUTBOX WRITE OPERATIONS
+// ========================================
+
+// CreateOutboxEmail queues an email.
+//
+// Parameters:
+//   - email: Pending email (will be updated with ID and timestamps)
+//
+// Returns:
+//   - error: Database error or nil on success
+func CreateOutboxEmail(email *m.OutboxEmail) error {
+	return createOutboxEmail(db.ORMOpen(), email)
+}
+
+// createOutboxEmail queues an email with the given connection or transaction.
+func createOutboxEmail(tx *gorm.DB, email *m.OutboxEmail) error {
+	if err := tx.Create(email).Error; err != nil {
+		return fmt.Errorf("error al encolar email: %v", err)
+	}
+
+	return nil
+}
+
+// ========================================
+// OUTBOX QUEUE OPERATIONS
+// ========================================
+
+// GetDueOutboxEmails retrieves the pending emails whose attempt is due.
+//
+// Parameters:
+//   - now: Reference time
+//   - limit: Maximum number of emails
+//
+// Returns:
+//   - []m.OutboxEmail: Due emails, longest waiting first
+//   - error: Database error or nil on success
+func GetDueOutboxEmails(now time.Time, limit int) ([]m.OutboxEmail, error) {
+	gormDB := db.ORMOpen()
+
+	var emails []m.OutboxEmail
+	result := gormDB.Where("status = ? AND next_attempt_at <= ?", m.OutboxEmailPending, now).
+		Order("next_attempt_at, id").
+		Limit(limit).
+		Find(&emails)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al buscar emails pendientes: %v", result.Error)
+	}
+
+	return emails, nil
+}
+
+// ClaimOutboxEmail reserves a due email for one attempt, so concurrent workers never send it twice.
+// The claim moves the next attempt to leaseUntil; recording the attempt replaces it.
+//
+// Parameters:
+//   - id: Unique identifier of the email
+//   - now: Reference time (the attempt must be due)
+//   - leaseUntil: When the email becomes due again if the worker stops before recording the attempt
+//
+// Returns:
+//   - bool: Whether the email was claimed (false if another worker claimed it or it is no longer pending)
+//   - error: Database error or nil on success
+func ClaimOutboxEmail(id uint, now time.Time, leaseUntil time.Time) (bool, error) {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Model(&m.OutboxEmail{}).
+		Where("id = ? AND status = ? AND next_attempt_at <= ?", id, m.OutboxEmailPending, now).
+		Update("next_attempt_at", leaseUntil)
+	if result.Error != nil {
+		return false, fmt.Errorf("error al reservar email %d: %v", id, result.Error)
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// RecordOutboxAttempt stores the outcome of an attempt to send an email.
+// The message of a sent email is cleared, since it may hold codes or passwords.
+//
+// Parameters:
+//   - email: Email with its new Status, Attempts, NextAttemptAt, LastAttemptAt, LastError and SentAt
+//
+// Returns:
+//   - error: Database error or nil on success
+func RecordOutboxAttempt(email *m.OutboxEmail) error {
+	gormDB := db.ORMOpen()
+
+	columns := []string{"status", "attempts", "next_attempt_at", "last_attempt_at", "last_error", "sent_at"}
+	if email.Status == m.OutboxEmailSent {
+		email.Message = []byte{}
+		columns = append(columns, "message")
+	}
+
+	err := gormDB.Model(email).Select(columns).Updates(email).Error
+	if err != nil {
+		return fmt.Errorf("error al registrar intento del email %d: %v", email.ID, err)
+	}
+
+	return nil
+}
+
+// RequeueOutboxEmail moves a failed email back to the queue with its attempts reset.
+//
+// Parameters:
+//   - id: Unique identifier of the email
+//   - now: When the email becomes due
+//
+// Returns:
+//   - bool: Whether the email was requeued (false if it is not failed or its message was cleared)
+//   - error: Database error or nil on success
+func RequeueOutboxEmail(id uint, now time.Time) (bool, error) {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Model(&m.OutboxEmail{}).
+		Where("id = ? AND status = ? AND LENGTH(message) > 0", id, m.OutboxEmailFailed).
+		Updates(map[string]any{
+			"status":          m.OutboxEmailPending,
+			"attempts":        0,
+			"next_attempt_at": now,
+		})
+	if result.Error != nil {
+		return false, fmt.Errorf("error al reencolar email %d: %v", id, result.Error)
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// ClearFailedOutboxMessages clears the messages of the emails dead-lettered before a time.
+// The emails stay in the outbox for the admin log, but can no longer be resent.
+//
+// Parameters:
+//   - before: Emails whose latest attempt is older are cleared
+//
+// Returns:
+//   - int64: Number of messages cleared
+//   - error: Database error or nil on success
+func ClearFailedOutboxMessages(before time.Time) (int64, error) {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Model(&m.OutboxEmail{}).
+		Where("status = ? AND last_attempt_at < ? AND LENGTH(message) > 0", m.OutboxEmailFailed, before).
+		Update("message", []byte{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("error al borrar mensajes de emails fallidos: %v", result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
+
+// ========================================
+// OUTBOX READ OPERATIONS
+// ========================================
+
+// GetOutboxEmails retrieves one page of the outbox.
+//
+// Parameters:
+//   - params: Validated list query (see OutboxEmailListSchema)
+//
+// Returns:
+//   - *query.Page[m.OutboxEmail]: Requested page of emails
+//   - error: Database error or nil on success
+func GetOutboxEmails(params *query.Params) (*query.Page[m.OutboxEmail], error) {
+	gormDB := db.ORMOpen()
+
+	// The messages are never returned and can be large
+	page, err := query.Find[m.OutboxEmail](gormDB.Model(&m.OutboxEmail{}), params, func(tx *gorm.DB) *gorm.DB {
+		return tx.Omit("message")
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error al leer emails: %v", err)
+	}
+
+	return page, nil
+}
+
+// GetOutboxEmail retrieves an email of the outbox, with its message.
+//
+// Parameters:
+//   - id: Unique identifier of the email
+//
+// Returns:
+//   - *m.OutboxEmail: Email
+//   - error: Database error or not found error
+func GetOutboxEmail(id uint) (*m.OutboxEmail, error) {
+	gormDB := db.ORMOpen()
+
+	var email m.OutboxEmail
+	if err := gormDB.First(&email, id).Error; err != nil {
+		return nil, fmt.Errorf("email %d no encontrado: %v", id, err)
+	}
+
+	return &email, nil
+}
@@ -0,0 +1,473 @@
+// Package dao implements data access objects for adoption fees and payments.
+// This layer is responsible for:
+// - CRUD operations on fee schedules
+// - Recording payments, their refunds and the webhook events received from providers
+// - Applying webhook events exactly once, even when providers deliver them several times
+package dao
+
+import (
+	"backend/internal/db"
+	"backend/internal/db/query"
+	m "backend/internal/models"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PaymentListSchema is the allowlist of sort fields and filters accepted by payment list queries.
+//
+// Filters:
+//   - status: pending, paid, failed, expired, partially_refunded, refunded or review (comma-separated for several)
+//   - adoption: Adoption ID
+//   - donation: Donation ID
+//   - from, to: Range of the creation date (YYYY-MM-DD)
+//
+// Sort fields: crt_date, amount_cents, id
+var PaymentListSchema = query.Schema{
+	Sorts: map[string]query.SortColumn{
+		"id":           {Column: "id"},
+		"crt_date":     {Column: "crt_date"},
+		"amount_cents": {Column: "amount_cents"},
+	},
+	Filters: map[string]query.FilterFunc{
+		"status":   query.OneOf("status", m.PaymentStatuses...),
+		"adoption": query.Uint("adoption_id"),
+		"donation": query.Uint("donation_id"),
+		"from":     query.DateFrom("crt_date"),
+		"to":       query.DateTo("crt_date"),
+	},
+	DefaultSort: "-crt_date",
+}
+
+// ========================================
+// FEE SCHEDULE OPERATIONS
+// ========================================
+
+// GetFeeSchedules retrieves every fee schedule of an organisation.
+//
+// Parameters:
+//   - orgID: Unique identifier of the organisation
+//
+// Returns:
+//   - []m.FeeSchedule: Schedules ordered by species and minimum age
+//   - error: Database error or nil on success
+func GetFeeSchedules(orgID uint) ([]m.FeeSchedule, error) {
+	gormDB := db.ORMOpen()
+
+	var schedules []m.FeeSchedule
+	result := gormDB.Where("organization_id = ?", orgID).Order("species, min_age_months, id").Find(&schedules)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer tarifas de adopción: %v", result.Error)
+	}
+
+	return schedules, nil
+}
+
+// GetMatchingFeeSchedules retrieves the fee schedules of an organisation that apply to a species:
+// the ones for that species and the ones for any species.
+//
+// Parameters:
+//   - orgID: Unique identifier of the organisation
+//   - species: Species of the pet
+//
+// Returns:
+//   - []m.FeeSchedule: Candidate schedules (age ranges are checked by the caller)
+//   - error: Database error or nil on success
+func GetMatchingFeeSchedules(orgID uint, species string) ([]m.FeeSchedule, error) {
+	gormDB := db.ORMOpen()
+
+	var schedules []m.FeeSchedule
+	result := gormDB.Where("organization_id = ? AND (species = '' OR species = ?)", orgID, species).
+		Order("id").
+		Find(&schedules)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer tarifas de adopción para %s: %v", species, result.Error)
+	}
+
+	return schedules, nil
+}
+
+// GetFeeSchedule retrieves a fee schedule of an organisation.
+//
+// Parameters:
+//   - id: Unique identifier of the schedule
+//   - orgID: Organisation the schedule must belong to
+//
+// Returns:
+//   - *m.FeeSchedule: Schedule data
+//   - error: Database error or record not found error
+func GetFeeSchedule(id uint, orgID uint) (*m.FeeSchedule, error) {
+	gormDB := db.ORMOpen()
+
+	var schedule m.FeeSchedule
+	result := gormDB.Where("organization_id = ?", orgID).First(&schedule, id)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer tarifa de adopción %d: %v", id, result.Error)
+	}
+
+	return &schedule, nil
+}
+
+// CreateFeeSchedule inserts a new fee schedule.
+//
+// Parameters:
+//   - schedule: Schedule to insert (will be updated with ID and timestamps)
+//
+// Returns:
+//   - error: Database error or nil on success
+func CreateFeeSchedule(schedule *m.FeeSchedule) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Create(schedule)
+	if result.Error != nil {
+		return fmt.Errorf("error al crear tarifa de adopción: %v", result.Error)
+	}
+
+	return nil
+}
+
+// UpdateFeeSchedule updates a fee schedule of an organisation.
+//
+// Parameters:
+//   - schedule: Schedule with updated data (must include ID and OrganizationID)
+//
+// Returns:
+//   - error: Database error or nil on success
+func UpdateFeeSchedule(schedule *m.FeeSchedule) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Model(&m.FeeSchedule{}).
+		Where("id = ? AND organization_id = ?", schedule.ID, schedule.OrganizationID).
+		Select("name", "species", "min_age_months", "max_age_months", "amount_cents", "currency").
+		Updates(schedule)
+	if result.Error != nil {
+		return fmt.Errorf("error al actualizar tarifa de adopción %d: %v", schedule.ID, result.Error)
+	}
+
+	return nil
+}
+
+// DeleteFeeSchedule removes a fee schedule of an organisation.
+// Adoptions keep the fee they were finalised with.
+//
+// Parameters:
+//   - id: Unique identifier of the schedule
+//   - orgID: Organisation the schedule must belong to
+//
+// Returns:
+//   - error: Database error, record not found error or nil on success
+func DeleteFeeSchedule(id uint, orgID uint) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Where("organization_id = ?", orgID).Delete(&m.FeeSchedule{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("error al eliminar tarifa de adopción %d: %v", id, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("tarifa de adopción con id %d no encontrada", id)
+	}
+
+	return nil
+}
+
+// ========================================
+// PAYMENT RETRIEVAL OPERATIONS
+// ========================================
+
+// GetPayments retrieves one page of an organisation's payments matching the list query.
+//
+// Parameters:
+//   - params: Parsed list query (see PaymentListSchema)
+//   - orgID: Organisation whose payments are listed
+//
+// Returns:
+//   - *query.Page[m.Payment]: Requested page of payments with total count and links
+//   - error: Database error or nil on success
+func GetPayments(params *query.Params, orgID uint) (*query.Page[m.Payment], error) {
+	gormDB := db.ORMOpen()
+
+	page, err := query.Find[m.Payment](gormDB.Model(&m.Payment{}).Scopes(inOrganization(orgID)), params)
+	if err != nil {
+		return nil, fmt.Errorf("error al leer pagos: %v", err)
+	}
+
+	return page, nil
+}
+
+// GetPayment retrieves a payment of an organisation with its refunds.
+//
+// Parameters:
+//   - id: Unique identifier of the payment
+//   - orgID: Organisation the payment must belong to, or AllOrganizations
+//
+// Returns:
+//   - *m.Payment: Payment data
+//   - error: Database error or record not found error
+func GetPayment(id uint, orgID uint) (*m.Payment, error) {
+	gormDB := db.ORMOpen()
+
+	var payment m.Payment
+	result := gormDB.Preload("Refunds", func(tx *gorm.DB) *gorm.DB {
+		return tx.Order("crt_date, id")
+	}).Scopes(inOrganization(orgID)).First(&payment, id)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer pago %d: %v", id, result.Error)
+	}
+
+	return &payment, nil
+}
+
+// GetPaymentByCheckout retrieves the payment of a provider checkout.
+//
+// Parameters:
+//   - provider: Payment provider name
+//   - checkoutID: Provider's checkout identifier
+//
+// Returns:
+//   - *m.Payment: Payment, or nil if no payment has that checkout
+//   - error: Database error or nil on success
+func GetPaymentByCheckout(provider string, checkoutID string) (*m.Payment, error) {
+	gormDB := db.ORMOpen()
+
+	var payment m.Payment
+	result := gormDB.Where("provider = ? AND checkout_id = ?", provider, checkoutID).First(&payment)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al buscar pago del checkout %s: %v", checkoutID, result.Error)
+	}
+
+	return &payment, nil
+}
+
+// GetAdoptionPayments retrieves every payment of an adoption with its refunds.
+//
+// Parameters:
+//   - adoptionID: Unique identifier of the adoption
+//
+// Returns:
+//   - []m.Payment: Payments, most recent first
+//   - error: Database error or nil on success
+func GetAdoptionPayments(adoptionID uint) ([]m.Payment, error) {
+	gormDB := db.ORMOpen()
+
+	var payments []m.Payment
+	result := gormDB.Preload("Refunds", func(tx *gorm.DB) *gorm.DB {
+		return tx.Order("crt_date, id")
+	}).Where("adoption_id = ?", adoptionID).Order("crt_date DESC, id DESC").Find(&payments)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer pagos de la adopción %d: %v", adoptionID, result.Error)
+	}
+
+	return payments, nil
+}
+
+// GetAdoptionPaidCents sums the amount paid and not refunded of each adoption.
+//
+// Parameters:
+//   - adoptionIDs: Unique identifiers of the adoptions
+//
+// Returns:
+//   - map[uint]int64: Net paid amount in cents by adoption ID (adoptions without payments are missing)
+//   - error: Database error or nil on success
+func GetAdoptionPaidCents(adoptionIDs []uint) (map[uint]int64, error) {
+	byID := make(map[uint]int64, len(adoptionIDs))
+	if len(adoptionIDs) == 0 {
+		return byID, nil
+	}
+
+	gormDB := db.ORMOpen()
+
+	var rows []struct {
+		AdoptionID uint
+		PaidCents  int64
+	}
+	result := gormDB.Model(&m.Payment{}).
+		Select("adoption_id, SUM(amount_cents - refunded_cents) AS paid_cents").
+		Where("adoption_id IN ? AND paid_at IS NOT NULL", adoptionIDs).
+		Group("adoption_id").
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al sumar pagos de las adopciones: %v", result.Error)
+	}
+
+	for _, row := range rows {
+		byID[row.AdoptionID] = row.PaidCents
+	}
+
+	return byID, nil
+}
+
+// ========================================
+// PAYMENT WRITE OPERATIONS
+// ========================================
+
+// CreatePayment inserts a new payment.
+//
+// Parameters:
+//   - payment: Payment to insert (will be updated with ID and timestamps)
+//
+// Returns:
+//   - error: Database error or nil on success
+func CreatePayment(payment *m.Payment) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Omit("Refunds").Create(payment)
+	if result.Error != nil {
+		return fmt.Errorf("error al crear pago: %v", result.Error)
+	}
+
+	return nil
+}
+
+// SetPaymentCheckout records the provider checkout of a payment.
+//
+// Parameters:
+//   - payment: Payment with CheckoutID and CheckoutURL set
+//
+// Returns:
+//   - error: Database error or nil on success
+func SetPaymentCheckout(payment *m.Payment) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Model(&m.Payment{}).Where("id = ?", payment.ID).Updates(map[string]interface{}{
+		"checkout_id":  payment.CheckoutID,
+		"checkout_url": payment.CheckoutURL,
+	})
+	if result.Error != nil {
+		return fmt.Errorf("error al guardar checkout del pago %d: %v", payment.ID, result.Error)
+	}
+
+	return nil
+}
+
+// MarkPaymentFailed marks a pending payment as failed.
+//
+// Parameters:
+//   - id: Unique identifier of the payment
+//   - reason: Why the payment failed
+//
+// Returns:
+//   - error: Database error or nil on success
+func MarkPaymentFailed(id uint, reason string) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Model(&m.Payment{}).
+		Where("id = ? AND status = ?", id, m.PaymentStatusPending).
+		Updates(map[string]interface{}{"status": m.PaymentStatusFailed, "failure_reason": reason})
+	if result.Error != nil {
+		return fmt.Errorf("error al marcar pago %d como fallido: %v", id, result.Error)
+	}
+
+	return nil
+}
+
+// ProcessPaymentEvent records a webhook event and applies its changes to the payment, in one transaction.
+// Events already recorded are duplicates and are not applied again.
+//
+// Parameters:
+//   - event: Event to record (Provider, EventID, Type and PaymentID)
+//   - fromStatuses: Statuses the payment must have for the changes to apply
+//   - changes: Columns to update on the payment (nil to only record the event)
+//
+// Returns:
+//   - bool: false if the event had already been processed
+//   - error: Database error or nil on success
+func ProcessPaymentEvent(event *m.PaymentEvent, fromStatuses []string, changes map[string]interface{}) (bool, error) {
+	gormDB := db.ORMOpen()
+
+	processed := false
+	err := gormDB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(event)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		processed = true
+
+		if event.PaymentID == nil || changes == nil {
+			return nil
+		}
+
+		return tx.Model(&m.Payment{}).
+			Where("id = ? AND status IN ?", *event.PaymentID, fromStatuses).
+			Updates(changes).Error
+	})
+	if err != nil {
+		return false, fmt.Errorf("error al procesar evento de pago %s: %v", event.EventID, err)
+	}
+
+	return processed, nil
+}
+
+// ReservePaymentRefund adds a refund amount to a paid payment, if it does not exceed the paid amount.
+// The check and the update are a single statement, so concurrent refunds never exceed the payment.
+//
+// Parameters:
+//   - id: Unique identifier of the payment
+//   - amount: Amount to refund in cents
+//
+// Returns:
+//   - bool: false if the payment is not paid or the amount exceeds what is left to refund
+//   - error: Database error or nil on success
+func ReservePaymentRefund(id uint, amount int64) (bool, error) {
+	gormDB := db.ORMOpen()
+
+	// MySQL evaluates assignments left to right, so status is computed before refunded_cents changes
+	result := gormDB.Exec(
+		"UPDATE Payments SET status = CASE WHEN refunded_cents + ? >= amount_cents THEN ? ELSE ? END, "+
+			"refunded_cents = refunded_cents + ? WHERE id = ? AND status IN ? AND refunded_cents + ? <= amount_cents",
+		amount, m.PaymentStatusRefunded, m.PaymentStatusPartiallyRefunded,
+		amount, id, []string{m.PaymentStatusPaid, m.PaymentStatusPartiallyRefunded}, amount)
+	if result.Error != nil {
+		return false, fmt.Errorf("error al reservar reembolso del pago %d: %v", id, result.Error)
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// ReleasePaymentRefund undoes ReservePaymentRefund when the provider rejects the refund.
+//
+// Parameters:
+//   - id: Unique identifier of the payment
+//   - amount: Reserved amount in cents
+//
+// Returns:
+//   - error: Database error or nil on success
+func ReleasePaymentRefund(id uint, amount int64) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Exec(
+		"UPDATE Payments SET status = CASE WHEN refunded_cents - ? <= 0 THEN ? ELSE ? END, "+
+			"refunded_cents = refunded_cents - ? WHERE id = ? AND refunded_cents >= ?",
+		amount, m.PaymentStatusPaid, m.PaymentStatusPartiallyRefunded, amount, id, amount)
+	if result.Error != nil {
+		return fmt.Errorf("error al anular reembolso del pago %d: %v", id, result.Error)
+	}
+
+	return nil
+}
+
+// CreatePaymentRefund inserts a refund accepted by the provider.
+//
+// Parameters:
+//   - refund: Refund to insert (will be updated with ID and timestamp)
+//
+// Returns:
+//   - error: Database error or nil on success
+func CreatePaymentRefund(refund *m.PaymentRefund) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Create(refund)
+	if result.Error != nil {
+		return fmt.Errorf("error al registrar reembolso del pago %d: %v", refund.PaymentID, result.Error)
+	}
+
+	return nil
+}
@@ -9,43 +9,90 @@ package dao
 
 import (
 	"backend/internal/db"
+	"backend/internal/db/query"
 	m "backend/internal/models"
+	"errors"
 	"fmt"
 	"time"
+
+	"gorm.io/gorm"
 )
 
 // ========================================
 // PET RETRIEVAL OPERATIONS
 // ========================================
 
-// GetAllPets retrieves all pet records from the database.
+// PetListSchema is the allowlist of sort fields and filters accepted by pet list queries.
+//
+// Filters:
+//   - species: Exact species (comma-separated for several)
+//   - breed: Breed contains the value
+//   - status: available, reserved or adopted (comma-separated for several)
+//   - age_min, age_max: Age range in whole years, computed from birth_date
+//   - adopted: true/false
+//   - organization: Owning organisation ID
+//   - in_foster: true for pets living in a foster home, false for the rest
+//
+// Sort fields: name, species, breed, status, age, birth_date, crt_date, id
+var PetListSchema = query.Schema{
+	Sorts: map[string]query.SortColumn{
+		"id":         {Column: "id"},
+		"name":       {Column: "name"},
+		"species":    {Column: "species"},
+		"breed":      {Column: "breed"},
+		"status":     {Column: "status"},
+		"age":        {Column: "birth_date", Invert: true},
+		"birth_date": {Column: "birth_date"},
+		"crt_date":   {Column: "crt_date"},
+	},
+	Filters: map[string]query.FilterFunc{
+		"species":      query.Equals("species"),
+		"breed":        query.Contains("breed"),
+		"status":       query.OneOf("status", m.PetStatuses...),
+		"age_min":      query.MinAge("birth_date"),
+		"age_max":      query.MaxAge("birth_date"),
+		"adopted":      query.Bool("is_adopted"),
+		"organization": query.Uint("organization_id"),
+		"in_foster":    inFosterFilter,
+	},
+	DefaultSort: "-crt_date",
+}
+
+// GetAllPets retrieves one page of pet records matching the list query.
 // Returns simplified pet data suitable for listing and overview purposes.
 //
 // Database Operations:
-// - Performs SELECT * FROM pets with user relationship preloading
-// - Uses GORM's Preload to fetch associated AdoptUser data
+// - Performs SELECT COUNT(*) and SELECT * FROM pets with the filters from PetListSchema
+// - Restricts the query to the given organisation (AllOrganizations for public browsing)
+// - Applies sorting and offset or keyset pagination
+// - Uses GORM's Preload to fetch the primary photo
 // - Returns SimplifiedPet models optimized for list views
 //
 // Relationship Loading:
-// - Preloads AdoptUser relationship to show adoption status
-// - Optimizes queries by loading related data in single operation
-// - Reduces N+1 query problems
+// - Preloads the primary photo for card thumbnails
+// - Only loads relationships for the rows of the requested page
+//
+// Parameters:
+//   - params: Parsed list query (see PetListSchema)
+//   - orgID: Organisation whose pets are listed, or AllOrganizations
 //
 // Returns:
-//   - []m.SimplifiedPet: Slice of all pets with essential information and adoption status
+//   - *query.Page[m.SimplifiedPet]: Requested page of pets with total count and links
 //   - error: Database error or nil on success
-func GetAllPets() ([]m.SimplifiedPet, error) {
+func GetAllPets(params *query.Params, orgID uint) (*query.Page[m.SimplifiedPet], error) {
 	// Open database connection
 	gormDB := db.ORMOpen()
 
-	// Retrieve all pets with user relationship preloaded
-	var pets []m.SimplifiedPet
-	result := gormDB.Preload("AdoptUser").Find(&pets)
-	if result.Error != nil {
-		return nil, fmt.Errorf("error al leer mascotas: %v", result.Error)
+	// Retrieve requested page with primary photo preloaded
+	base := gormDB.Model(&m.Pet{}).Scopes(inOrganization(orgID))
+	page, err := query.Find[m.Pet](base, params, func(tx *gorm.DB) *gorm.DB {
+		return tx.Preload("Photos", "is_primary = ?", true)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error al leer mascotas: %v", err)
 	}
 
-	return pets, nil
+	return query.MapPage(page, toSimplifiedPet), nil
 }
 
 // GetPetByID retrieves a specific pet by its unique identifier.
@@ -53,27 +100,33 @@ func GetAllPets() ([]m.SimplifiedPet, error) {
 //
 // Database Operations:
 // - Performs SELECT * FROM pets WHERE id = ? with relationship preloading
-// - Uses GORM's Preload to fetch associated AdoptUser data
+// - Restricts the query to the given organisation (AllOrganizations for public views)
+// - Uses GORM's Preload to fetch the pet photos
 // - Returns complete Pet model with all details
 //
 // Relationship Loading:
-// - Preloads AdoptUser relationship for adoption information
+// - Does not load the adopter; only AdoptUserID is returned
+// - Preloads Photos ordered by display position
 // - Provides complete pet profile data
 // - Used for detailed pet views and management
 //
 // Parameters:
 //   - id: Unique identifier of the pet to retrieve
+//   - orgID: Organisation the pet must belong to, or AllOrganizations
 //
 // Returns:
 //   - *m.Pet: Complete pet data with all relationships
 //   - error: Database error or record not found error
-func GetPetByID(id uint) (*m.Pet, error) {
+func GetPetByID(id uint, orgID uint) (*m.Pet, error) {
 	// Open database connection
 	gormDB := db.ORMOpen()
 
 	// Retrieve specific pet by ID with relationships
 	var pet m.Pet
-	result := gormDB.Preload("AdoptUser").Where("id = ?", id).First(&pet)
+	result := gormDB.Preload("Photos", func(db *gorm.DB) *gorm.DB { return db.Order("position, id") }).
+		Scopes(inOrganization(orgID)).
+		Where("id = ?", id).
+		First(&pet)
 	if result.Error != nil {
 		return nil, fmt.Errorf("error al leer mascota con id %d: %v", id, result.Error)
 	}
@@ -81,6 +134,37 @@ func GetPetByID(id uint) (*m.Pet, error) {
 	return &pet, nil
 }
 
+// GetPetByMicrochip retrieves the pet with the given (normalised) microchip number.
+//
+// Database Operations:
+// - Performs SELECT * FROM pets WHERE microchip = ? using the unique microchip index
+// - Searches every organisation, as microchip numbers are unique worldwide
+// - Preloads the primary photo; the adopter is loaded by the caller when it may see it
+//
+// Parameters:
+//   - microchip: Normalised microchip number (15 digits)
+//
+// Returns:
+//   - *m.Pet: Pet with the microchip, or nil if no pet has it
+//   - error: Database error or nil on success
+func GetPetByMicrochip(microchip string) (*m.Pet, error) {
+	// Open database connection
+	gormDB := db.ORMOpen()
+
+	var pet m.Pet
+	result := gormDB.Preload("Photos", "is_primary = ?", true).
+		Where("microchip = ?", microchip).
+		First(&pet)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al buscar mascota por microchip: %v", result.Error)
+	}
+
+	return &pet, nil
+}
+
 // ========================================
 // PET CRUD OPERATIONS
 // ========================================
@@ -92,6 +176,7 @@ func GetPetByID(id uint) (*m.Pet, error) {
 // - Performs INSERT INTO pets with all pet data
 // - Sets creation and update timestamps automatically
 // - Validates referential integrity with species
+// - Requires the owning organisation to be set
 //
 // Business Logic:
 // - Assigns creation timestamp (CrtDate) to current time
@@ -111,6 +196,10 @@ func GetPetByID(id uint) (*m.Pet, error) {
 //   - *m.Pet: Created pet data with assigned ID and timestamps
 //   - error: Database error or validation error
 func CreatePet(pet *m.Pet) (*m.Pet, error) {
+	if pet.OrganizationID == AllOrganizations {
+		return nil, fmt.Errorf("error al crear mascota: falta la organización")
+	}
+
 	// Open database connection
 	gormDB := db.ORMOpen()
 
@@ -120,7 +209,7 @@ func CreatePet(pet *m.Pet) (*m.Pet, error) {
 	pet.UptDate = now
 
 	// Create new pet record in database
-	result := gormDB.Create(pet)
+	result := gormDB.Omit("Photos").Create(pet)
 	if result.Error != nil {
 		return nil, fmt.Errorf("error al crear mascota: %v", result.Error)
 	}
@@ -132,9 +221,10 @@ func CreatePet(pet *m.Pet) (*m.Pet, error) {
 // Handles pet data modification with automatic timestamp management.
 //
 // Database Operations:
-// - Performs UPDATE pets SET ... WHERE id = ?
+// - Performs UPDATE pets SET ... WHERE id = ? AND organization_id = ?
 // - Updates modification timestamp automatically
 // - Uses selective field updates with Select("*")
+// - Never moves the pet to another organisation
 //
 // Business Logic:
 // - Updates UptDate timestamp automatically to current time
@@ -148,10 +238,10 @@ func CreatePet(pet *m.Pet) (*m.Pet, error) {
 // - Supports change tracking and auditing
 //
 // Parameters:
-//   - pet: Pet data with updated information (must include valid ID)
+//   - pet: Pet data with updated information (must include valid ID and OrganizationID)
 //
 // Returns:
-//   - error: Database error or validation error, nil on success
+//   - error: Database error, record not found error or validation error, nil on success
 func UpdatePet(pet *m.Pet) error {
 	// Open database connection
 	gormDB := db.ORMOpen()
@@ -161,14 +251,19 @@ func UpdatePet(pet *m.Pet) error {
 
 	// Update pet record with all fields
 	result := gormDB.Model(&m.Pet{}).
-		Where("id = ?", pet.ID).
+		Where("id = ? AND organization_id = ?", pet.ID, pet.OrganizationID).
 		Select("*").
+		Omit("Photos", "organization_id").
 		Updates(pet)
 
 	if result.Error != nil {
 		return fmt.Errorf("error al actualizar mascota con id %d: %v", pet.ID, result.Error)
 	}
 
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("mascota con id %d no encontrada", pet.ID)
+	}
+
 	return nil
 }
 
@@ -176,7 +271,7 @@ func UpdatePet(pet *m.Pet) error {
 // Handles pet deletion with proper data integrity management.
 //
 // Database Operations:
-// - Performs DELETE FROM pets WHERE id = ?
+// - Performs DELETE FROM pets WHERE id = ? AND organization_id = ?
 // - Handles soft deletion if configured in GORM model
 // - Maintains referential integrity with adoption records
 //
@@ -192,18 +287,47 @@ func UpdatePet(pet *m.Pet) error {
 //
 // Parameters:
 //   - id: Unique identifier of the pet to delete
+//   - orgID: Organisation the pet must belong to
 //
 // Returns:
-//   - error: Database error, constraint violation, or nil on success
-func DeletePetByID(id uint) error {
+//   - error: Database error, record not found error, constraint violation, or nil on success
+func DeletePetByID(id uint, orgID uint) error {
 	// Open database connection
 	gormDB := db.ORMOpen()
 
 	// Delete pet record by ID
-	result := gormDB.Delete(&m.Pet{}, id)
+	result := gormDB.Scopes(inOrganization(orgID)).Delete(&m.Pet{}, id)
 	if result.Error != nil {
 		return fmt.Errorf("error al eliminar mascota con id %d: %v", id, result.Error)
 	}
 
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("mascota con id %d no encontrada", id)
+	}
+
 	return nil
 }
+
+// ========================================
+// PET MAPPING HELPERS
+// ========================================
+
+// toSimplifiedPet maps a complete Pet entity to its SimplifiedPet list representation.
+// The first preloaded photo, if any, is exposed as the primary photo.
+func toSimplifiedPet(pet m.Pet) m.SimplifiedPet {
+	simplified := m.SimplifiedPet{
+		ID:             pet.ID,
+		OrganizationID: pet.OrganizationID,
+		Name:           pet.Name,
+		Species:        pet.Species,
+		Breed:          pet.Breed,
+		Status:         pet.Status,
+		IsAdopted:      pet.IsAdopted,
+	}
+
+	if len(pet.Photos) > 0 {
+		simplified.PrimaryPhoto = &pet.Photos[0]
+	}
+
+	return simplified
+}
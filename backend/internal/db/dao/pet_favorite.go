@@ -0,0 +1,190 @@
+// Package dao implements data access objects for pet favourites.
+// This layer is responsible for:
+// - Adding and removing favourites, scoped to their owner
+// - Listing the favourite pets of a user
+// - Resolving favourite flags, counts and followers for pets
+package dao
+
+import (
+	"backend/internal/db"
+	"backend/internal/db/query"
+	m "backend/internal/models"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ========================================
+// PET FAVORITE RETRIEVAL OPERATIONS
+// ========================================
+
+// GetFavoritePets retrieves one page of the pets favourited by a user.
+// Accepts the same sorting and filters as pet lists (see PetListSchema).
+//
+// Database Operations:
+// - Performs SELECT * FROM Pets WHERE id IN (SELECT pet_id FROM Pet_Favorites WHERE user_id = ?)
+// - Preloads the primary photo like GetAllPets
+//
+// Parameters:
+//   - userID: Owner of the favourites
+//   - params: Parsed list query (see PetListSchema)
+//
+// Returns:
+//   - *query.Page[m.SimplifiedPet]: Requested page of favourite pets
+//   - error: Database error or nil on success
+func GetFavoritePets(userID uint, params *query.Params) (*query.Page[m.SimplifiedPet], error) {
+	gormDB := db.ORMOpen()
+
+	favorites := gormDB.Model(&m.PetFavorite{}).Select("pet_id").Where("user_id = ?", userID)
+
+	page, err := query.Find[m.Pet](gormDB.Model(&m.Pet{}).Where("id IN (?)", favorites), params, func(tx *gorm.DB) *gorm.DB {
+		return tx.Preload("Photos", "is_primary = ?", true)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error al leer mascotas favoritas del usuario %d: %v", userID, err)
+	}
+
+	return query.MapPage(page, toSimplifiedPet), nil
+}
+
+// GetFavoritedPetIDs reports which of the given pets a user has favourited.
+//
+// Parameters:
+//   - userID: User whose favourites are checked
+//   - petIDs: Pets to check
+//
+// Returns:
+//   - map[uint]bool: Set of favourited pet IDs
+//   - error: Database error or nil on success
+func GetFavoritedPetIDs(userID uint, petIDs []uint) (map[uint]bool, error) {
+	favorited := make(map[uint]bool)
+	if len(petIDs) == 0 {
+		return favorited, nil
+	}
+
+	gormDB := db.ORMOpen()
+
+	var ids []uint
+	result := gormDB.Model(&m.PetFavorite{}).
+		Where("user_id = ? AND pet_id IN ?", userID, petIDs).
+		Pluck("pet_id", &ids)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer favoritos del usuario %d: %v", userID, result.Error)
+	}
+
+	for _, id := range ids {
+		favorited[id] = true
+	}
+
+	return favorited, nil
+}
+
+// CountFavorites returns the number of users who favourited each of the given pets.
+// Pets without favourites are missing from the map.
+//
+// Database Operations:
+// - Performs SELECT pet_id, COUNT(*) FROM Pet_Favorites WHERE pet_id IN ? GROUP BY pet_id
+//
+// Parameters:
+//   - petIDs: Pets to count
+//
+// Returns:
+//   - map[uint]int64: Favourite count per pet ID
+//   - error: Database error or nil on success
+func CountFavorites(petIDs []uint) (map[uint]int64, error) {
+	counts := make(map[uint]int64)
+	if len(petIDs) == 0 {
+		return counts, nil
+	}
+
+	gormDB := db.ORMOpen()
+
+	var rows []struct {
+		PetID uint
+		Total int64
+	}
+	result := gormDB.Model(&m.PetFavorite{}).
+		Select("pet_id, COUNT(*) AS total").
+		Where("pet_id IN ?", petIDs).
+		Group("pet_id").
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al contar favoritos: %v", result.Error)
+	}
+
+	for _, row := range rows {
+		counts[row.PetID] = row.Total
+	}
+
+	return counts, nil
+}
+
+// GetPetFollowers retrieves the active (not blocked) users who favourited a pet.
+//
+// Parameters:
+//   - petID: Favourited pet
+//
+// Returns:
+//   - []m.SimplifiedUser: Users following the pet
+//   - error: Database error or nil on success
+func GetPetFollowers(petID uint) ([]m.SimplifiedUser, error) {
+	gormDB := db.ORMOpen()
+
+	followers := gormDB.Model(&m.PetFavorite{}).Select("user_id").Where("pet_id = ?", petID)
+
+	var users []m.SimplifiedUser
+	result := gormDB.Model(&m.User{}).
+		Where("id IN (?) AND Is_Blocked = ?", followers, false).
+		Find(&users)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer seguidores de la mascota %d: %v", petID, result.Error)
+	}
+
+	return users, nil
+}
+
+// ========================================
+// PET FAVORITE CRUD OPERATIONS
+// ========================================
+
+// AddFavorite adds a pet to a user's favourites.
+// Adding a pet that is already a favourite does nothing.
+//
+// Parameters:
+//   - userID: User adding the favourite
+//   - petID: Pet to favourite
+//
+// Returns:
+//   - error: Database error or nil on success
+func AddFavorite(userID uint, petID uint) error {
+	gormDB := db.ORMOpen()
+
+	favorite := m.PetFavorite{UserID: userID, PetID: petID}
+	result := gormDB.Clauses(clause.OnConflict{DoNothing: true}).Create(&favorite)
+	if result.Error != nil {
+		return fmt.Errorf("error al añadir favorito: %v", result.Error)
+	}
+
+	return nil
+}
+
+// RemoveFavorite removes a pet from a user's favourites.
+// Removing a pet that is not a favourite does nothing.
+//
+// Parameters:
+//   - userID: User removing the favourite
+//   - petID: Pet to remove
+//
+// Returns:
+//   - error: Database error or nil on success
+func RemoveFavorite(userID uint, petID uint) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Where("user_id = ? AND pet_id = ?", userID, petID).Delete(&m.PetFavorite{})
+	if result.Error != nil {
+		return fmt.Errorf("error al eliminar favorito: %v", result.Error)
+	}
+
+	return nil
+}
@@ -0,0 +1,244 @@
+// Package dao implements data access objects for pet photo management.
+// This layer is responsible for:
+// - Direct database operations and queries for pet photo metadata
+// - Ordering and primary photo bookkeeping
+// - Transaction management so a pet never ends up with two primary photos
+package dao
+
+import (
+	"backend/internal/db"
+	m "backend/internal/models"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// ========================================
+// PET PHOTO RETRIEVAL OPERATIONS
+// ========================================
+
+// GetPetPhotos retrieves all photos of a pet in display order.
+//
+// Database Operations:
+// - Performs SELECT * FROM Pet_Photos WHERE pet_id = ? ORDER BY position, id
+//
+// Parameters:
+//   - petID: Unique identifier of the pet
+//
+// Returns:
+//   - []m.PetPhoto: Photos of the pet ordered by position
+//   - error: Database error or nil on success
+func GetPetPhotos(petID uint) ([]m.PetPhoto, error) {
+	gormDB := db.ORMOpen()
+
+	var photos []m.PetPhoto
+	result := gormDB.Where("pet_id = ?", petID).Order("position, id").Find(&photos)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer fotos de mascota con id %d: %v", petID, result.Error)
+	}
+
+	return photos, nil
+}
+
+// GetPetPhoto retrieves a single photo, ensuring it belongs to the given pet.
+//
+// Parameters:
+//   - petID: Unique identifier of the pet
+//   - photoID: Unique identifier of the photo
+//
+// Returns:
+//   - *m.PetPhoto: Photo metadata
+//   - error: Database error or record not found error
+func GetPetPhoto(petID uint, photoID uint) (*m.PetPhoto, error) {
+	gormDB := db.ORMOpen()
+
+	var photo m.PetPhoto
+	result := gormDB.Where("id = ? AND pet_id = ?", photoID, petID).First(&photo)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer foto %d de mascota con id %d: %v", photoID, petID, result.Error)
+	}
+
+	return &photo, nil
+}
+
+// ========================================
+// PET PHOTO CRUD OPERATIONS
+// ========================================
+
+// CreatePetPhoto inserts a new photo at the end of the pet's photo list.
+//
+// Business Logic:
+// - Position is set to the current highest position plus one
+// - The first photo uploaded for a pet becomes its primary photo
+//
+// Parameters:
+//   - photo: Photo metadata to insert (will be updated with ID, position and primary flag)
+//
+// Returns:
+//   - error: Database error or nil on success
+func CreatePetPhoto(photo *m.PetPhoto) error {
+	gormDB := db.ORMOpen()
+
+	err := gormDB.Transaction(func(tx *gorm.DB) error {
+		var stats struct {
+			Count       int64
+			MaxPosition int
+		}
+		if err := tx.Model(&m.PetPhoto{}).
+			Select("COUNT(*) AS count, COALESCE(MAX(position), -1) AS max_position").
+			Where("pet_id = ?", photo.PetID).
+			Scan(&stats).Error; err != nil {
+			return err
+		}
+
+		photo.Position = stats.MaxPosition + 1
+		photo.IsPrimary = stats.Count == 0
+
+		return tx.Create(photo).Error
+	})
+
+	if err != nil {
+		return fmt.Errorf("error al crear foto de mascota con id %d: %v", photo.PetID, err)
+	}
+
+	return nil
+}
+
+// DeletePetPhoto removes a photo record.
+// If the deleted photo was the primary one, the next photo in order is promoted.
+//
+// Parameters:
+//   - petID: Unique identifier of the pet
+//   - photoID: Unique identifier of the photo to delete
+//
+// Returns:
+//   - error: Database error or nil on success
+func DeletePetPhoto(petID uint, photoID uint) error {
+	gormDB := db.ORMOpen()
+
+	err := gormDB.Transaction(func(tx *gorm.DB) error {
+		var photo m.PetPhoto
+		if err := tx.Where("id = ? AND pet_id = ?", photoID, petID).First(&photo).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&photo).Error; err != nil {
+			return err
+		}
+
+		if !photo.IsPrimary {
+			return nil
+		}
+
+		var next m.PetPhoto
+		result := tx.Where("pet_id = ?", petID).Order("position, id").Limit(1).Find(&next)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+
+		return tx.Model(&next).Update("is_primary", true).Error
+	})
+
+	if err != nil {
+		return fmt.Errorf("error al eliminar foto %d de mascota con id %d: %v", photoID, petID, err)
+	}
+
+	return nil
+}
+
+// ReorderPetPhotos sets the display order of a pet's photos.
+//
+// Business Logic:
+// - photoIDs must contain every photo of the pet exactly once
+// - The position of each photo becomes its index in photoIDs
+//
+// Parameters:
+//   - petID: Unique identifier of the pet
+//   - photoIDs: Photo IDs in the desired display order
+//
+// Returns:
+//   - error: Validation error, database error or nil on success
+func ReorderPetPhotos(petID uint, photoIDs []uint) error {
+	gormDB := db.ORMOpen()
+
+	err := gormDB.Transaction(func(tx *gorm.DB) error {
+		var existing []uint
+		if err := tx.Model(&m.PetPhoto{}).Where("pet_id = ?", petID).Pluck("id", &existing).Error; err != nil {
+			return err
+		}
+
+		if !sameIDs(existing, photoIDs) {
+			return fmt.Errorf("la lista debe contener todas las fotos de la mascota exactamente una vez")
+		}
+
+		for position, id := range photoIDs {
+			if err := tx.Model(&m.PetPhoto{}).
+				Where("id = ? AND pet_id = ?", id, petID).
+				Update("position", position).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("error al ordenar fotos de mascota con id %d: %v", petID, err)
+	}
+
+	return nil
+}
+
+// SetPrimaryPetPhoto marks a photo as the pet's primary photo and clears the flag on the others.
+//
+// Parameters:
+//   - petID: Unique identifier of the pet
+//   - photoID: Unique identifier of the photo to promote
+//
+// Returns:
+//   - error: Database error, photo not found error or nil on success
+func SetPrimaryPetPhoto(petID uint, photoID uint) error {
+	gormDB := db.ORMOpen()
+
+	err := gormDB.Transaction(func(tx *gorm.DB) error {
+		var photo m.PetPhoto
+		if err := tx.Where("id = ? AND pet_id = ?", photoID, petID).First(&photo).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&m.PetPhoto{}).
+			Where("pet_id = ? AND id <> ?", petID, photoID).
+			Update("is_primary", false).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&photo).Update("is_primary", true).Error
+	})
+
+	if err != nil {
+		return fmt.Errorf("error al marcar foto principal %d de mascota con id %d: %v", photoID, petID, err)
+	}
+
+	return nil
+}
+
+// sameIDs reports whether got contains exactly the IDs in want, each once.
+func sameIDs(want []uint, got []uint) bool {
+	if len(want) != len(got) {
+		return false
+	}
+
+	seen := make(map[uint]bool, len(want))
+	for _, id := range want {
+		seen[id] = true
+	}
+
+	for _, id := range got {
+		if !seen[id] {
+			return false
+		}
+		delete(seen, id)
+	}
+
+	return true
+}
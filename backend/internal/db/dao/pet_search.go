@@ -0,0 +1,194 @@
+// Package dao implements data access objects for full-text pet search.
+// This layer is responsible for:
+// - Building the dialect-specific full-text match and ranking expressions
+// - Combining the full-text match with the structured pet list filters
+// - Loading the matched pets in relevance order
+package dao
+
+import (
+	"backend/internal/db"
+	"backend/internal/db/query"
+	m "backend/internal/models"
+	"backend/internal/services/search"
+	"fmt"
+	"slices"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PetSearchSchema is the allowlist of sort fields and filters accepted by pet search.
+// It accepts every filter and sort field of PetListSchema plus "relevance",
+// which is the default (most relevant first). The q parameter holds the search text.
+var PetSearchSchema = query.Schema{
+	Sorts:       petSearchSorts(),
+	Filters:     PetListSchema.Filters,
+	DefaultSort: "-relevance",
+	Extra:       []string{"q"},
+}
+
+// postgresPetVector is the weighted document searched on Postgres: name weighs
+// more than breed, and breed more than description. An expression index with
+// the same definition (USING GIN) keeps the search fast.
+const postgresPetVector = "setweight(to_tsvector('spanish', coalesce(name, '')), 'A') || " +
+	"setweight(to_tsvector('spanish', coalesce(breed, '')), 'B') || " +
+	"setweight(to_tsvector('spanish', coalesce(description, '')), 'C')"
+
+// PetSearchHit is a pet matched by full-text search with its ranking score.
+// Description is kept apart from the simplified pet to build result snippets.
+type PetSearchHit struct {
+	Pet         m.SimplifiedPet
+	Description string
+	Relevance   float64
+}
+
+// ========================================
+// PET SEARCH OPERATIONS
+// ========================================
+
+// SearchPets retrieves one page of pets matching the search terms and list filters.
+//
+// Database Operations:
+// - MySQL: MATCH(name, breed, description) AGAINST (? IN BOOLEAN MODE), name and breed matches count double
+// - Postgres: weighted tsvector @@ to_tsquery('spanish', ?) ranked with ts_rank
+// - Performs SELECT COUNT(*) and a paged SELECT id, relevance with the filters from PetListSchema
+// - Loads the matched pets with the primary photo preloaded
+//
+// Parameters:
+//   - terms: Analysed search terms (with typo variants)
+//   - params: Parsed list query (see PetSearchSchema); offset pagination only
+//
+// Returns:
+//   - *query.Page[PetSearchHit]: Requested page of matches in the requested order
+//   - error: Database error or nil on success
+func SearchPets(terms []search.Term, params *query.Params) (*query.Page[PetSearchHit], error) {
+	// Open database connection
+	gormDB := db.ORMOpen()
+
+	match, rank := petSearchClauses(gormDB.Dialector.Name(), terms)
+
+	// Retrieve requested page of matching IDs with their score
+	type row struct {
+		ID        uint
+		Relevance float64
+	}
+	base := gormDB.Model(&m.Pet{}).Where(match.SQL, match.Vars...)
+	page, err := query.Find[row](base, params, func(tx *gorm.DB) *gorm.DB {
+		return tx.Select("id, "+rank.SQL+" AS relevance", rank.Vars...)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error al buscar mascotas: %v", err)
+	}
+
+	ids := make([]uint, 0, len(page.Items))
+	for _, item := range page.Items {
+		ids = append(ids, item.ID)
+	}
+
+	// Load the matched pets with primary photo preloaded
+	var pets []m.Pet
+	if len(ids) > 0 {
+		result := gormDB.Preload("Photos", "is_primary = ?", true).
+			Where("id IN ?", ids).
+			Find(&pets)
+		if result.Error != nil {
+			return nil, fmt.Errorf("error al leer mascotas encontradas: %v", result.Error)
+		}
+	}
+
+	byID := make(map[uint]m.Pet, len(pets))
+	for _, pet := range pets {
+		byID[pet.ID] = pet
+	}
+
+	// Keep the page order; pets deleted in between are skipped
+	result := query.MapPage(page, func(item row) PetSearchHit {
+		pet := byID[item.ID]
+		return PetSearchHit{Pet: toSimplifiedPet(pet), Description: pet.Description, Relevance: item.Relevance}
+	})
+	result.Items = slices.DeleteFunc(result.Items, func(hit PetSearchHit) bool { return hit.Pet.ID == 0 })
+
+	return result, nil
+}
+
+// GetPetSearchTexts retrieves the searchable text of every pet.
+// Used to build the vocabulary for typo tolerance.
+//
+// Database Operations:
+// - Performs SELECT name, breed, description FROM Pets
+//
+// Returns:
+//   - []string: Name, breed and description of every pet
+//   - error: Database error or nil on success
+func GetPetSearchTexts() ([]string, error) {
+	// Open database connection
+	gormDB := db.ORMOpen()
+
+	var rows []struct {
+		Name        string
+		Breed       string
+		Description string
+	}
+	result := gormDB.Model(&m.Pet{}).Select("name, breed, description").Find(&rows)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer textos de mascotas: %v", result.Error)
+	}
+
+	texts := make([]string, 0, len(rows)*3)
+	for _, r := range rows {
+		texts = append(texts, r.Name, r.Breed, r.Description)
+	}
+
+	return texts, nil
+}
+
+// ========================================
+// PET SEARCH HELPERS
+// ========================================
+
+// petSearchSorts returns the PetListSchema sort fields plus relevance.
+func petSearchSorts() map[string]query.SortColumn {
+	sorts := map[string]query.SortColumn{"relevance": {Column: "relevance"}}
+	for name, column := range PetListSchema.Sorts {
+		sorts[name] = column
+	}
+
+	return sorts
+}
+
+// petSearchClauses builds the full-text match condition and ranking expression
+// for the given SQL dialect. Terms only contain letters and digits, so they can
+// be safely combined into the full-text query syntax; the result is still bound
+// as a parameter.
+func petSearchClauses(dialect string, terms []search.Term) (clause.Expr, clause.Expr) {
+	if dialect == "postgres" {
+		// Postgres stems the words itself with the spanish configuration
+		var words []string
+		for _, term := range terms {
+			words = append(words, term.Word+":*")
+			for _, variant := range term.Variants {
+				words = append(words, variant+":*")
+			}
+		}
+		tsquery := strings.Join(words, " | ")
+
+		return clause.Expr{SQL: "(" + postgresPetVector + ") @@ to_tsquery('spanish', ?)", Vars: []any{tsquery}},
+			clause.Expr{SQL: "ts_rank(" + postgresPetVector + ", to_tsquery('spanish', ?))", Vars: []any{tsquery}}
+	}
+
+	// MySQL boolean mode: every stem is a prefix search, any of them may match
+	var prefixes []string
+	for _, term := range terms {
+		for _, prefix := range term.Prefixes() {
+			prefixes = append(prefixes, prefix+"*")
+		}
+	}
+	against := strings.Join(prefixes, " ")
+
+	return clause.Expr{SQL: "MATCH(name, breed, description) AGAINST (? IN BOOLEAN MODE)", Vars: []any{against}},
+		clause.Expr{
+			SQL:  "MATCH(name, breed) AGAINST (? IN BOOLEAN MODE) * 2 + MATCH(name, breed, description) AGAINST (? IN BOOLEAN MODE)",
+			Vars: []any{against, against},
+		}
+}
@@ -0,0 +1,328 @@
+// Package dao implements data access objects for saved searches and their alerts.
+// This layer is responsible for:
+// - CRUD operations on saved searches, always scoped to their owner
+// - Queueing pets that match saved searches
+// - Selecting and marking the matches included in daily digest emails
+package dao
+
+import (
+	"backend/internal/db"
+	m "backend/internal/models"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm/clause"
+)
+
+// ========================================
+// SAVED SEARCH RETRIEVAL OPERATIONS
+// ========================================
+
+// GetSavedSearchesByUser retrieves every saved search of a user, newest first.
+//
+// Database Operations:
+// - Performs SELECT * FROM Saved_Searches WHERE user_id = ? ORDER BY crt_date DESC, id DESC
+//
+// Parameters:
+//   - userID: Owner of the saved searches
+//
+// Returns:
+//   - []m.SavedSearch: Saved searches of the user
+//   - error: Database error or nil on success
+func GetSavedSearchesByUser(userID uint) ([]m.SavedSearch, error) {
+	gormDB := db.ORMOpen()
+
+	var searches []m.SavedSearch
+	result := gormDB.Where("user_id = ?", userID).Order("crt_date DESC, id DESC").Find(&searches)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer búsquedas guardadas del usuario %d: %v", userID, result.Error)
+	}
+
+	return searches, nil
+}
+
+// GetSavedSearch retrieves a saved search, ensuring it belongs to the given user.
+//
+// Parameters:
+//   - userID: Owner of the saved search
+//   - id: Unique identifier of the saved search
+//
+// Returns:
+//   - *m.SavedSearch: Saved search data
+//   - error: Database error or record not found error
+func GetSavedSearch(userID uint, id uint) (*m.SavedSearch, error) {
+	gormDB := db.ORMOpen()
+
+	var search m.SavedSearch
+	result := gormDB.Where("id = ? AND user_id = ?", id, userID).First(&search)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer búsqueda guardada %d: %v", id, result.Error)
+	}
+
+	return &search, nil
+}
+
+// CountSavedSearches returns the number of saved searches of a user.
+//
+// Parameters:
+//   - userID: Owner of the saved searches
+//
+// Returns:
+//   - int64: Number of saved searches
+//   - error: Database error or nil on success
+func CountSavedSearches(userID uint) (int64, error) {
+	gormDB := db.ORMOpen()
+
+	var count int64
+	result := gormDB.Model(&m.SavedSearch{}).Where("user_id = ?", userID).Count(&count)
+	if result.Error != nil {
+		return 0, fmt.Errorf("error al contar búsquedas guardadas del usuario %d: %v", userID, result.Error)
+	}
+
+	return count, nil
+}
+
+// GetAlertSearchesForSpecies retrieves the saved searches with alerts enabled that
+// may match a pet of the given species (same species or no species criterion).
+// Remaining criteria are checked by the caller.
+//
+// Parameters:
+//   - species: Species of the pet being matched
+//
+// Returns:
+//   - []m.SavedSearch: Candidate saved searches
+//   - error: Database error or nil on success
+func GetAlertSearchesForSpecies(species string) ([]m.SavedSearch, error) {
+	gormDB := db.ORMOpen()
+
+	var searches []m.SavedSearch
+	result := gormDB.Where("alerts_enabled = ? AND (species = '' OR species IS NULL OR species = ?)", true, species).
+		Find(&searches)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer búsquedas con alertas: %v", result.Error)
+	}
+
+	return searches, nil
+}
+
+// ========================================
+// SAVED SEARCH CRUD OPERATIONS
+// ========================================
+
+// CreateSavedSearch inserts a new saved search.
+//
+// Parameters:
+//   - search: Saved search to insert (will be updated with ID and timestamps)
+//
+// Returns:
+//   - error: Database error or nil on success
+func CreateSavedSearch(search *m.SavedSearch) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Create(search)
+	if result.Error != nil {
+		return fmt.Errorf("error al crear búsqueda guardada: %v", result.Error)
+	}
+
+	return nil
+}
+
+// UpdateSavedSearch updates every editable field of a saved search owned by search.UserID.
+//
+// Parameters:
+//   - search: Saved search with updated data (must include ID and UserID)
+//
+// Returns:
+//   - error: Database error, record not found error or nil on success
+func UpdateSavedSearch(search *m.SavedSearch) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Model(&m.SavedSearch{}).
+		Where("id = ? AND user_id = ?", search.ID, search.UserID).
+		Select("name", "species", "breed", "age_min", "age_max", "keywords", "alerts_enabled").
+		Updates(search)
+	if result.Error != nil {
+		return fmt.Errorf("error al actualizar búsqueda guardada %d: %v", search.ID, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("búsqueda guardada con id %d no encontrada", search.ID)
+	}
+
+	return nil
+}
+
+// DeleteSavedSearch removes a saved search owned by the given user.
+// Its queued matches are removed by the ON DELETE CASCADE constraint.
+//
+// Parameters:
+//   - userID: Owner of the saved search
+//   - id: Unique identifier of the saved search
+//
+// Returns:
+//   - error: Database error, record not found error or nil on success
+func DeleteSavedSearch(userID uint, id uint) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Where("id = ? AND user_id = ?", id, userID).Delete(&m.SavedSearch{})
+	if result.Error != nil {
+		return fmt.Errorf("error al eliminar búsqueda guardada %d: %v", id, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("búsqueda guardada con id %d no encontrada", id)
+	}
+
+	return nil
+}
+
+// DisableSearchAlerts turns off email alerts of one or every saved search of a user.
+//
+// Parameters:
+//   - userID: Owner of the saved searches
+//   - id: Saved search to disable, or 0 to disable every saved search of the user
+//
+// Returns:
+//   - error: Database error or nil on success
+func DisableSearchAlerts(userID uint, id uint) error {
+	gormDB := db.ORMOpen()
+
+	tx := gormDB.Model(&m.SavedSearch{}).Where("user_id = ?", userID)
+	if id != 0 {
+		tx = tx.Where("id = ?", id)
+	}
+
+	if err := tx.Update("alerts_enabled", false).Error; err != nil {
+		return fmt.Errorf("error al desactivar alertas del usuario %d: %v", userID, err)
+	}
+
+	return nil
+}
+
+// ========================================
+// SAVED SEARCH MATCH OPERATIONS
+// ========================================
+
+// QueueSearchMatches inserts pending matches for the next digest.
+// Matches already queued for the same saved search and pet are ignored.
+//
+// Parameters:
+//   - matches: Matches to queue
+//
+// Returns:
+//   - error: Database error or nil on success
+func QueueSearchMatches(matches []m.SavedSearchMatch) error {
+	if len(matches) == 0 {
+		return nil
+	}
+
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Clauses(clause.OnConflict{DoNothing: true}).Omit("SavedSearch", "Pet").Create(&matches)
+	if result.Error != nil {
+		return fmt.Errorf("error al registrar coincidencias de búsquedas: %v", result.Error)
+	}
+
+	return nil
+}
+
+// GetUsersWithPendingMatches retrieves the users that have pending matches and
+// have not received a digest since the given time.
+//
+// Database Operations:
+// - Performs SELECT DISTINCT user_id FROM Saved_Search_Matches WHERE notified_at IS NULL
+// - Excludes users with any match notified after since
+//
+// Parameters:
+//   - since: Users notified after this time are skipped
+//
+// Returns:
+//   - []uint: User IDs due for a digest
+//   - error: Database error or nil on success
+func GetUsersWithPendingMatches(since time.Time) ([]uint, error) {
+	gormDB := db.ORMOpen()
+
+	recent := gormDB.Model(&m.SavedSearchMatch{}).Select("user_id").Where("notified_at > ?", since)
+
+	var userIDs []uint
+	result := gormDB.Model(&m.SavedSearchMatch{}).
+		Distinct("user_id").
+		Where("notified_at IS NULL AND user_id NOT IN (?)", recent).
+		Pluck("user_id", &userIDs)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer usuarios con coincidencias pendientes: %v", result.Error)
+	}
+
+	return userIDs, nil
+}
+
+// GetPendingMatches retrieves the pending matches of a user with their saved search
+// and pet preloaded, oldest first.
+//
+// Parameters:
+//   - userID: Owner of the matches
+//
+// Returns:
+//   - []m.SavedSearchMatch: Pending matches
+//   - error: Database error or nil on success
+func GetPendingMatches(userID uint) ([]m.SavedSearchMatch, error) {
+	gormDB := db.ORMOpen()
+
+	var matches []m.SavedSearchMatch
+	result := gormDB.Preload("SavedSearch").
+		Preload("Pet").
+		Where("user_id = ? AND notified_at IS NULL", userID).
+		Order("crt_date, id").
+		Find(&matches)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer coincidencias pendientes del usuario %d: %v", userID, result.Error)
+	}
+
+	return matches, nil
+}
+
+// MarkMatchesNotified sets the notification time of the given matches.
+//
+// Parameters:
+//   - ids: Matches included in a digest
+//   - at: Time the digest was sent
+//
+// Returns:
+//   - error: Database error or nil on success
+func MarkMatchesNotified(ids []uint, at time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Model(&m.SavedSearchMatch{}).Where("id IN ?", ids).Update("notified_at", at)
+	if result.Error != nil {
+		return fmt.Errorf("error al marcar coincidencias notificadas: %v", result.Error)
+	}
+
+	return nil
+}
+
+// DeleteSearchMatches removes queued matches that are no longer relevant
+// (alerts disabled or pet no longer available).
+//
+// Parameters:
+//   - ids: Matches to remove
+//
+// Returns:
+//   - error: Database error or nil on success
+func DeleteSearchMatches(ids []uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Where("id IN ?", ids).Delete(&m.SavedSearchMatch{})
+	if result.Error != nil {
+		return fmt.Errorf("error al eliminar coincidencias: %v", result.Error)
+	}
+
+	return nil
+}
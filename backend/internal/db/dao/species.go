@@ -9,6 +9,7 @@ package dao
 
 import (
 	"backend/internal/db"
+	"backend/internal/db/query"
 	m "backend/internal/models"
 	"fmt"
 )
@@ -17,29 +18,52 @@ import (
 // SPECIES RETRIEVAL OPERATIONS
 // ========================================
 
-// GetAllSpecies retrieves all species records from the database.
+// SpeciesListSchema is the allowlist of sort fields and filters accepted by species list queries.
+//
+// Filters:
+//   - name: Name contains the value
+//   - organization: Owning organisation ID
+//
+// Sort fields: id, name
+var SpeciesListSchema = query.Schema{
+	Sorts: map[string]query.SortColumn{
+		"id":   {Column: "id"},
+		"name": {Column: "name"},
+	},
+	Filters: map[string]query.FilterFunc{
+		"name":         query.Contains("name"),
+		"organization": query.Uint("organization_id"),
+	},
+	DefaultSort: "name",
+}
+
+// GetAllSpecies retrieves one page of species records matching the list query.
 // Returns complete species information for use in pet registration and filtering.
 //
 // Database Operations:
-// - Performs SELECT * FROM species
-// - Returns all species without pagination
+// - Performs SELECT COUNT(*) and SELECT * FROM species with the filters from SpeciesListSchema
+// - Restricts the query to the given organisation (AllOrganizations for public browsing)
+// - Applies sorting and offset or keyset pagination
 // - Used for dropdown menus and reference data
 //
+// Parameters:
+//   - params: Parsed list query (see SpeciesListSchema)
+//   - orgID: Organisation whose species are listed, or AllOrganizations
+//
 // Returns:
-//   - []m.Species: Slice of all species with complete information
+//   - *query.Page[m.Species]: Requested page of species with total count and links
 //   - error: Database error or nil on success
-func GetAllSpecies() ([]m.Species, error) {
+func GetAllSpecies(params *query.Params, orgID uint) (*query.Page[m.Species], error) {
 	// Open database connection
 	gormDB := db.ORMOpen()
 
-	// Retrieve all species from database
-	var species []m.Species
-	result := gormDB.Find(&species)
-	if result.Error != nil {
-		return nil, fmt.Errorf("error al leer especies: %v", result.Error)
+	// Retrieve requested page of species
+	page, err := query.Find[m.Species](gormDB.Model(&m.Species{}).Scopes(inOrganization(orgID)), params)
+	if err != nil {
+		return nil, fmt.Errorf("error al leer especies: %v", err)
 	}
 
-	return species, nil
+	return page, nil
 }
 
 // GetSpeciesByID retrieves a specific species by its unique identifier.
@@ -47,22 +71,24 @@ func GetAllSpecies() ([]m.Species, error) {
 //
 // Database Operations:
 // - Performs SELECT * FROM species WHERE id = ?
+// - Restricts the query to the given organisation (AllOrganizations for public views)
 // - Uses GORM's First method for single record retrieval
 // - Handles record not found scenarios
 //
 // Parameters:
 //   - id: Unique identifier of the species to retrieve
+//   - orgID: Organisation the species must belong to, or AllOrganizations
 //
 // Returns:
 //   - *m.Species: Complete species data
 //   - error: Database error or record not found error
-func GetSpeciesByID(id uint) (*m.Species, error) {
+func GetSpeciesByID(id uint, orgID uint) (*m.Species, error) {
 	// Open database connection
 	gormDB := db.ORMOpen()
 
 	// Retrieve specific species by ID
 	var s m.Species
-	result := gormDB.First(&s, id)
+	result := gormDB.Scopes(inOrganization(orgID)).First(&s, id)
 	if result.Error != nil {
 		return nil, fmt.Errorf("error al leer especie con id %d: %v", id, result.Error)
 	}
@@ -83,16 +109,20 @@ func GetSpeciesByID(id uint) (*m.Species, error) {
 // - Validates data integrity and constraints
 //
 // Business Logic:
-// - Ensures species name uniqueness (handled by database constraints)
+// - Ensures species name uniqueness within the organisation (handled by database constraints)
 // - Updates the input species object with generated ID
 // - Maintains referential integrity for future pet associations
 //
 // Parameters:
-//   - s: Species data to be created (will be updated with generated ID)
+//   - s: Species data to be created, including its organisation (will be updated with generated ID)
 //
 // Returns:
 //   - error: Database error or validation error, nil on success
 func CreateSpecies(s *m.Species) error {
+	if s.OrganizationID == AllOrganizations {
+		return fmt.Errorf("error al crear especie: falta la organización")
+	}
+
 	// Open database connection
 	gormDB := db.ORMOpen()
 
@@ -109,7 +139,7 @@ func CreateSpecies(s *m.Species) error {
 // Handles species deletion with proper constraint checking.
 //
 // Database Operations:
-// - Performs DELETE FROM species WHERE id = ?
+// - Performs DELETE FROM species WHERE id = ? AND organization_id = ?
 // - Handles foreign key constraints with pet records
 // - May prevent deletion if pets are associated with the species
 //
@@ -125,18 +155,23 @@ func CreateSpecies(s *m.Species) error {
 //
 // Parameters:
 //   - id: Unique identifier of the species to delete
+//   - orgID: Organisation the species must belong to
 //
 // Returns:
-//   - error: Database error, constraint violation, or nil on success
-func DeleteSpeciesByID(id uint) error {
+//   - error: Database error, record not found error, constraint violation, or nil on success
+func DeleteSpeciesByID(id uint, orgID uint) error {
 	// Open database connection
 	gormDB := db.ORMOpen()
 
 	// Delete species record by ID
-	result := gormDB.Delete(&m.Species{}, id)
+	result := gormDB.Scopes(inOrganization(orgID)).Delete(&m.Species{}, id)
 	if result.Error != nil {
 		return fmt.Errorf("error al eliminar especie con id %d: %v", id, result.Error)
 	}
 
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("especie con id %d no encontrada", id)
+	}
+
 	return nil
 }
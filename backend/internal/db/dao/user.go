@@ -9,6 +9,7 @@ package dao
 
 import (
 	"backend/internal/db"
+	"backend/internal/db/query"
 	m "backend/internal/models"
 	"backend/internal/services/security"
 	"errors"
@@ -22,33 +23,62 @@ import (
 // USER RETRIEVAL OPERATIONS
 // ========================================
 
-// GetAllUsers retrieves all user records from the database.
+// UserListSchema is the allowlist of sort fields and filters accepted by user list queries.
+//
+// Filters:
+//   - name, surname, email: Field contains the value
+//   - provider: Exact authentication provider (local, google)
+//   - blocked: true/false
+//   - role: user, staff or admin (comma-separated for several)
+//
+// Sort fields: id, name, surname, email, crt_date
+var UserListSchema = query.Schema{
+	Sorts: map[string]query.SortColumn{
+		"id":       {Column: "id"},
+		"name":     {Column: "name"},
+		"surname":  {Column: "surname"},
+		"email":    {Column: "email"},
+		"crt_date": {Column: "crt_date"},
+	},
+	Filters: map[string]query.FilterFunc{
+		"name":     query.Contains("name"),
+		"surname":  query.Contains("surname"),
+		"email":    query.Contains("email"),
+		"provider": query.Equals("Provider"),
+		"blocked":  query.Bool("Is_Blocked"),
+		"role":     query.OneOf("role", m.UserRoleUser, m.UserRoleStaff, m.UserRoleAdmin),
+	},
+	DefaultSort: "surname,name",
+}
+
+// GetAllUsers retrieves one page of user records matching the list query.
 // Returns non-validated user data (excluding sensitive information like passwords).
 //
 // Database Operations:
-// - Performs SELECT * FROM users
+// - Performs SELECT COUNT(*) and SELECT * FROM users with the filters from UserListSchema
+// - Applies sorting and offset or keyset pagination
 // - Maps User entities to NonValidatedUser DTOs
 // - Excludes sensitive fields for security
 //
+// Parameters:
+//   - params: Parsed list query (see UserListSchema)
+//
 // Returns:
-//   - []m.NonValidatedUser: Slice of all users without sensitive data
+//   - *query.Page[m.NonValidatedUser]: Requested page of users without sensitive data
 //   - error: Database error or nil on success
-func GetAllUsers() ([]m.NonValidatedUser, error) {
+func GetAllUsers(params *query.Params) (*query.Page[m.NonValidatedUser], error) {
 	// Open database connection
 	gormDB := db.ORMOpen()
 
-	// Retrieve all users from database
-	var users []m.User
-	result := gormDB.Find(&users)
-
-	if result.Error != nil {
-		return nil, fmt.Errorf("error al leer usuarios: %v", result.Error)
+	// Retrieve requested page of users
+	page, err := query.Find[m.User](gormDB.Model(&m.User{}), params)
+	if err != nil {
+		return nil, fmt.Errorf("error al leer usuarios: %v", err)
 	}
 
 	// Map to non-validated user DTOs (exclude sensitive data)
-	var nonValidatedUsers []m.NonValidatedUser
-	for _, user := range users {
-		nonValidatedUser := m.NonValidatedUser{
+	return query.MapPage(page, func(user m.User) m.NonValidatedUser {
+		return m.NonValidatedUser{
 			ID:           user.ID,
 			Name:         user.Name,
 			Surname:      user.Surname,
@@ -56,11 +86,10 @@ func GetAllUsers() ([]m.NonValidatedUser, error) {
 			Address:      user.Address,
 			FailedLogins: user.FailedLogins,
 			IsBlocked:    user.IsBlocked,
+			Role:         user.Role,
+			Locale:       user.Locale,
 		}
-		nonValidatedUsers = append(nonValidatedUsers, nonValidatedUser)
-	}
-
-	return nonValidatedUsers, nil
+	}), nil
 }
 
 // GetUserByID retrieves a specific user by their unique identifier.
@@ -97,6 +126,8 @@ func GetUserByID(id uint) (*m.NonValidatedUser, error) {
 		Address:      user.Address,
 		FailedLogins: user.FailedLogins,
 		IsBlocked:    user.IsBlocked,
+		Role:         user.Role,
+		Locale:       user.Locale,
 	}
 
 	return nonValidatedUser, nil
@@ -134,6 +165,8 @@ func GetUserByEmail(email string) (*m.NonValidatedUser, error) {
 		Address:      user.Address,
 		FailedLogins: user.FailedLogins,
 		IsBlocked:    user.IsBlocked,
+		Role:         user.Role,
+		Locale:       user.Locale,
 		Provider:     user.Provider,
 	}
 
@@ -175,6 +208,8 @@ func GetUserBySessionID(sessionID string) (*m.NonValidatedUser, error) {
 		Address:      user.Address,
 		FailedLogins: user.FailedLogins,
 		IsBlocked:    user.IsBlocked,
+		Role:         user.Role,
+		Locale:       user.Locale,
 	}
 
 	return nonValidatedUser, nil
@@ -316,6 +351,7 @@ func DeleteUserByID(id uint) (*m.SimplifiedUser, error) {
 // - Assigns creation timestamp (CrtDate)
 // - Assigns update timestamp (UptDate)
 // - Validates user data integrity
+// - Always registers the account with the user role
 //
 // Parameters:
 //   - user: Complete user data for registration
@@ -329,6 +365,13 @@ func CreateUser(user *m.FullUser) error {
 	user.CrtDate = now
 	user.UptDate = now
 
+	// Roles are never taken from registration data
+	user.Role = m.UserRoleUser
+
+	if user.Locale == "" {
+		user.Locale = m.DefaultLocale
+	}
+
 	result := gormDB.Create(user)
 	if result.Error != nil {
 		return fmt.Errorf("error al crear usuario: %v", result.Error)
@@ -349,6 +392,7 @@ func CreateUser(user *m.FullUser) error {
 // - Updates UptDate timestamp automatically
 // - Preserves data integrity during updates
 // - Validates user existence before update
+// - Never changes the role; roles are managed by administrators
 //
 // Parameters:
 //   - user: User data with updated information (must include valid ID)
@@ -361,6 +405,7 @@ func UpdateUser(user *m.User) error {
 	user.UptDate = time.Now()
 	result := gormDB.Model(&m.User{}).
 		Where("id = ?", user.ID).
+		Omit("role").
 		Updates(user)
 
 	if result.Error != nil {
@@ -384,7 +429,7 @@ func UpdatePassword(email string, newPassword string) error {
 		Update("password", hashedPassword)
 
 	// Change the change_password flag to false
-	SetChangePasswordFlag(email, false)
+	SetChangePasswordFlag(email, false, nil)
 
 	if result.Error != nil {
 		return fmt.Errorf("error al actualizar contraseña para usuario %s: %v", email, result.Error)
@@ -649,45 +694,57 @@ func UnblockUser(email string) error {
 // TWO-FACTOR AUTHENTICATION OPERATIONS
 // ========================================
 
-// UpdateTwoFactorCode generates and updates a new 2FA token for a user.
+// UpdateTwoFactorCode generates and updates a new 2FA token for a user, and queues the email that delivers it.
 // Used for two-factor authentication setup and token refresh.
 //
 // Database Operations:
 // - Generates a new 6-digit 2FA code using security.Generate2FA
 // - Performs UPDATE users SET two_factor_auth WHERE email = ?
-// - Validates user existence after update
+// - Inserts the email composed with the code into the outbox, in the same transaction
 //
 // Security Features:
 // - Generates cryptographically secure 2FA codes
-// - Updates 2FA token atomically
+// - Updates 2FA token atomically: the code is only stored if its email is queued
 // - Validates operation success
 //
 // Parameters:
 //   - email: User's email address
+//   - compose: Builds the outbox email delivering the code
 //
 // Returns:
 //   - string: Generated 2FA token
-//   - error: Database error or user not found error
-func UpdateTwoFactorCode(email string) (string, error) {
+//   - *m.OutboxEmail: Queued email
+//   - error: Database error, compose error or user not found error
+func UpdateTwoFactorCode(email string, compose func(code string) (*m.OutboxEmail, error)) (string, *m.OutboxEmail, error) {
 	gormDB := db.ORMOpen()
 	_2fa := security.Generate2FA(6)
 
-	result := gormDB.Model(&m.User{}).
-		Where("email = ?", email).
-		Updates(map[string]any{
-			"two_factor_auth": _2fa,
-		})
-
-	_, err := GetUserByEmail(email)
-	if result.Error != nil || err != nil {
-		return "", fmt.Errorf("error al actualizar TwoFactorAuth para usuario %s: %v", email, result.Error)
+	notification, err := compose(_2fa)
+	if err != nil {
+		return "", nil, err
 	}
 
-	if result.RowsAffected == 0 {
-		return "", fmt.Errorf("usuario con email %s no encontrado", email)
+	err = gormDB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&m.User{}).
+			Where("email = ?", email).
+			Updates(map[string]any{
+				"two_factor_auth": _2fa,
+			})
+		if result.Error != nil {
+			return fmt.Errorf("error al actualizar TwoFactorAuth para usuario %s: %v", email, result.Error)
+		}
+
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("usuario con email %s no encontrado", email)
+		}
+
+		return createOutboxEmail(tx, notification)
+	})
+	if err != nil {
+		return "", nil, err
 	}
 
-	return _2fa, nil
+	return _2fa, notification, nil
 }
 
 // GenerateSessionID creates and updates a new session identifier for a user.
@@ -731,7 +788,17 @@ func GenerateSessionID(email string) (string, error) {
 	return sessionID, nil
 }
 
-func SetChangePasswordFlag(email string, flag bool) error {
+// SetChangePasswordFlag sets whether a user must change their password at the next login,
+// optionally queueing an email in the same transaction.
+//
+// Parameters:
+//   - email: User's email address
+//   - flag: Whether the password must be changed
+//   - notification: Email to queue with the change (optional)
+//
+// Returns:
+//   - error: Database error or user not found error
+func SetChangePasswordFlag(email string, flag bool, notification *m.OutboxEmail) error {
 	gormDB := db.ORMOpen()
 
 	// Verificar si el usuario existe
@@ -740,14 +807,47 @@ func SetChangePasswordFlag(email string, flag bool) error {
 		return fmt.Errorf("usuario no encontrado %s: %v", email, err)
 	}
 
-	// Actualizar la bandera de cambio de contraseña
-	result := gormDB.Model(&m.User{}).
-		Where("email = ?", email).
-		Update("change_password", flag)
+	return gormDB.Transaction(func(tx *gorm.DB) error {
+		// Actualizar la bandera de cambio de contraseña
+		result := tx.Model(&m.User{}).
+			Where("email = ?", email).
+			Update("change_password", flag)
 
+		if result.Error != nil {
+			return fmt.Errorf("error al establecer la bandera de cambio de contraseña para usuario %s: %v", email, result.Error)
+		}
+
+		if notification == nil {
+			return nil
+		}
+
+		return createOutboxEmail(tx, notification)
+	})
+}
+
+// GetStaffUsers retrieves every active (not blocked) user with the staff or admin role
+// who is a member of an organisation.
+// Used to address staff notifications such as medical reminders.
+//
+// Parameters:
+//   - orgID: Organisation whose staff is retrieved
+//
+// Returns:
+//   - []m.SimplifiedUser: Staff users
+//   - error: Database error or nil on success
+func GetStaffUsers(orgID uint) ([]m.SimplifiedUser, error) {
+	gormDB := db.ORMOpen()
+
+	members := gormDB.Model(&m.OrganizationMember{}).Select("user_id").Where("organization_id = ?", orgID)
+
+	var users []m.SimplifiedUser
+	result := gormDB.Model(&m.User{}).
+		Where("role IN ? AND Is_Blocked = ?", []string{m.UserRoleStaff, m.UserRoleAdmin}, false).
+		Where("id IN (?)", members).
+		Find(&users)
 	if result.Error != nil {
-		return fmt.Errorf("error al establecer la bandera de cambio de contraseña para usuario %s: %v", email, result.Error)
+		return nil, fmt.Errorf("error al leer usuarios del personal: %v", result.Error)
 	}
 
-	return nil
+	return users, nil
 }
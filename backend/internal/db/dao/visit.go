@@ -0,0 +1,532 @@
+// Package dao implements data access objects for meet-and-greet visits.
+// This layer is responsible for:
+// - CRUD operations on availability slots
+// - Booking, rescheduling and cancelling visits while keeping slots consistent
+// - Finding the visits due for a reminder email
+package dao
+
+import (
+	"backend/internal/db"
+	"backend/internal/db/query"
+	m "backend/internal/models"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// VisitSlotListSchema is the allowlist of sort fields and filters accepted by slot list queries.
+//
+// Filters:
+//   - pet: Pet ID the slot is reserved for
+//   - booked: true/false
+//   - from, to: Range of the start date (YYYY-MM-DD)
+//
+// Sort fields: start_time, crt_date, id
+var VisitSlotListSchema = query.Schema{
+	Sorts: map[string]query.SortColumn{
+		"id":         {Column: "id"},
+		"start_time": {Column: "start_time"},
+		"crt_date":   {Column: "crt_date"},
+	},
+	Filters: map[string]query.FilterFunc{
+		"pet":    query.Uint("pet_id"),
+		"booked": query.Bool("booked"),
+		"from":   query.DateFrom("start_time"),
+		"to":     query.DateTo("start_time"),
+	},
+	DefaultSort: "start_time",
+}
+
+// VisitListSchema is the allowlist of sort fields and filters accepted by visit list queries.
+//
+// Filters:
+//   - status: scheduled, cancelled, completed or no_show (comma-separated for several)
+//   - pet: Pet ID
+//   - application: Adoption application ID
+//   - from, to: Range of the visit date (YYYY-MM-DD)
+//
+// Sort fields: start_time, crt_date, id
+var VisitListSchema = query.Schema{
+	Sorts: map[string]query.SortColumn{
+		"id":         {Column: "id"},
+		"start_time": {Column: "start_time"},
+		"crt_date":   {Column: "crt_date"},
+	},
+	Filters: map[string]query.FilterFunc{
+		"status":      query.OneOf("status", m.VisitStatuses...),
+		"pet":         query.Uint("pet_id"),
+		"application": query.Uint("application_id"),
+		"from":        query.DateFrom("start_time"),
+		"to":          query.DateTo("start_time"),
+	},
+	DefaultSort: "start_time",
+}
+
+// claimVisitSlot marks a future free slot as booked.
+// The conditional update is atomic, so two concurrent bookings never claim the same slot.
+func claimVisitSlot(tx *gorm.DB, slotID uint, now time.Time) (bool, error) {
+	result := tx.Model(&m.VisitSlot{}).
+		Where("id = ? AND booked = ? AND start_time > ?", slotID, false, now).
+		Update("booked", true)
+
+	return result.RowsAffected == 1, result.Error
+}
+
+// releaseVisitSlot marks a slot as free again.
+func releaseVisitSlot(tx *gorm.DB, slotID *uint) error {
+	if slotID == nil {
+		return nil
+	}
+
+	return tx.Model(&m.VisitSlot{}).Where("id = ?", *slotID).Update("booked", false).Error
+}
+
+// ========================================
+// VISIT SLOT OPERATIONS
+// ========================================
+
+// GetVisitSlots retrieves one page of an organisation's slots matching the list query.
+//
+// Parameters:
+//   - params: Parsed list query (see VisitSlotListSchema)
+//   - orgID: Organisation whose slots are listed
+//
+// Returns:
+//   - *query.Page[m.VisitSlot]: Requested page of slots with total count and links
+//   - error: Database error or nil on success
+func GetVisitSlots(params *query.Params, orgID uint) (*query.Page[m.VisitSlot], error) {
+	gormDB := db.ORMOpen()
+
+	page, err := query.Find[m.VisitSlot](gormDB.Model(&m.VisitSlot{}).Scopes(inOrganization(orgID)), params)
+	if err != nil {
+		return nil, fmt.Errorf("error al leer franjas de visita: %v", err)
+	}
+
+	return page, nil
+}
+
+// GetAvailableVisitSlots retrieves the free future slots in which a pet can be visited.
+//
+// Parameters:
+//   - orgID: Organisation that owns the pet
+//   - petID: Unique identifier of the pet
+//   - from: Only slots starting after this time are returned
+//
+// Returns:
+//   - []m.VisitSlot: Free slots reserved for the pet or open to any pet, earliest first
+//   - error: Database error or nil on success
+func GetAvailableVisitSlots(orgID uint, petID uint, from time.Time) ([]m.VisitSlot, error) {
+	gormDB := db.ORMOpen()
+
+	var slots []m.VisitSlot
+	result := gormDB.Where("organization_id = ? AND booked = ? AND start_time > ?", orgID, false, from).
+		Where("(pet_id IS NULL OR pet_id = ?)", petID).
+		Order("start_time, id").
+		Find(&slots)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer franjas libres de la mascota %d: %v", petID, result.Error)
+	}
+
+	return slots, nil
+}
+
+// GetVisitSlot retrieves a slot of an organisation.
+//
+// Parameters:
+//   - id: Unique identifier of the slot
+//   - orgID: Organisation the slot must belong to, or AllOrganizations
+//
+// Returns:
+//   - *m.VisitSlot: Slot data
+//   - error: Database error or record not found error
+func GetVisitSlot(id uint, orgID uint) (*m.VisitSlot, error) {
+	gormDB := db.ORMOpen()
+
+	var slot m.VisitSlot
+	result := gormDB.Scopes(inOrganization(orgID)).First(&slot, id)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer franja de visita %d: %v", id, result.Error)
+	}
+
+	return &slot, nil
+}
+
+// CountOverlappingVisitSlots counts the slots reserved for a pet that overlap a time range.
+//
+// Parameters:
+//   - petID: Unique identifier of the pet
+//   - start, end: Time range to check
+//
+// Returns:
+//   - int64: Number of overlapping slots
+//   - error: Database error or nil on success
+func CountOverlappingVisitSlots(petID uint, start time.Time, end time.Time) (int64, error) {
+	gormDB := db.ORMOpen()
+
+	var count int64
+	result := gormDB.Model(&m.VisitSlot{}).
+		Where("pet_id = ? AND start_time < ? AND end_time > ?", petID, end, start).
+		Count(&count)
+	if result.Error != nil {
+		return 0, fmt.Errorf("error al comprobar franjas de la mascota %d: %v", petID, result.Error)
+	}
+
+	return count, nil
+}
+
+// CreateVisitSlot inserts a new slot.
+//
+// Parameters:
+//   - slot: Slot to insert (will be updated with ID and timestamps)
+//
+// Returns:
+//   - error: Database error or nil on success
+func CreateVisitSlot(slot *m.VisitSlot) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Create(slot)
+	if result.Error != nil {
+		return fmt.Errorf("error al crear franja de visita: %v", result.Error)
+	}
+
+	return nil
+}
+
+// DeleteFreeVisitSlot removes a slot of an organisation unless a visit holds it.
+// The check and the deletion are a single statement, so a concurrent booking is never lost.
+//
+// Parameters:
+//   - id: Unique identifier of the slot
+//   - orgID: Organisation the slot must belong to
+//
+// Returns:
+//   - bool: false if the slot does not exist or is booked
+//   - error: Database error or nil on success
+func DeleteFreeVisitSlot(id uint, orgID uint) (bool, error) {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Where("organization_id = ? AND booked = ?", orgID, false).Delete(&m.VisitSlot{}, id)
+	if result.Error != nil {
+		return false, fmt.Errorf("error al eliminar franja de visita %d: %v", id, result.Error)
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// ========================================
+// VISIT RETRIEVAL OPERATIONS
+// ========================================
+
+// GetVisits retrieves one page of an organisation's visits matching the list query.
+//
+// Parameters:
+//   - params: Parsed list query (see VisitListSchema)
+//   - orgID: Organisation whose visits are listed
+//
+// Returns:
+//   - *query.Page[m.Visit]: Requested page of visits with total count and links
+//   - error: Database error or nil on success
+func GetVisits(params *query.Params, orgID uint) (*query.Page[m.Visit], error) {
+	gormDB := db.ORMOpen()
+
+	page, err := query.Find[m.Visit](gormDB.Model(&m.Visit{}).Scopes(inOrganization(orgID)), params)
+	if err != nil {
+		return nil, fmt.Errorf("error al leer visitas: %v", err)
+	}
+
+	return page, nil
+}
+
+// GetVisit retrieves a visit of an organisation.
+//
+// Parameters:
+//   - id: Unique identifier of the visit
+//   - orgID: Organisation the visit must belong to, or AllOrganizations
+//
+// Returns:
+//   - *m.Visit: Visit data
+//   - error: Database error or record not found error
+func GetVisit(id uint, orgID uint) (*m.Visit, error) {
+	gormDB := db.ORMOpen()
+
+	var visit m.Visit
+	result := gormDB.Scopes(inOrganization(orgID)).First(&visit, id)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer visita %d: %v", id, result.Error)
+	}
+
+	return &visit, nil
+}
+
+// CountOverlappingVisits counts the scheduled visits of a pet that overlap a time range.
+//
+// Parameters:
+//   - petID: Unique identifier of the pet
+//   - start, end: Time range to check
+//   - excludeID: Visit to ignore (0 for none), e.g. the visit being rescheduled
+//
+// Returns:
+//   - int64: Number of overlapping visits
+//   - error: Database error or nil on success
+func CountOverlappingVisits(petID uint, start time.Time, end time.Time, excludeID uint) (int64, error) {
+	gormDB := db.ORMOpen()
+
+	var count int64
+	result := gormDB.Model(&m.Visit{}).
+		Where("pet_id = ? AND status = ? AND start_time < ? AND end_time > ? AND id <> ?",
+			petID, m.VisitStatusScheduled, end, start, excludeID).
+		Count(&count)
+	if result.Error != nil {
+		return 0, fmt.Errorf("error al comprobar visitas de la mascota %d: %v", petID, result.Error)
+	}
+
+	return count, nil
+}
+
+// GetVisitsDueForReminder retrieves the scheduled visits starting within a time range
+// whose reminder has not been sent yet.
+//
+// Parameters:
+//   - from, to: Range of the visit start time
+//
+// Returns:
+//   - []m.Visit: Visits due for a reminder, earliest first
+//   - error: Database error or nil on success
+func GetVisitsDueForReminder(from time.Time, to time.Time) ([]m.Visit, error) {
+	gormDB := db.ORMOpen()
+
+	var visits []m.Visit
+	result := gormDB.Where("status = ? AND reminder_sent_at IS NULL AND start_time > ? AND start_time <= ?",
+		m.VisitStatusScheduled, from, to).
+		Order("start_time, id").
+		Find(&visits)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer visitas pendientes de recordatorio: %v", result.Error)
+	}
+
+	return visits, nil
+}
+
+// GetVisitPets retrieves the summaries of the pets of the given visits.
+//
+// Parameters:
+//   - petIDs: Unique identifiers of the pets
+//
+// Returns:
+//   - map[uint]*m.SimplifiedPet: Pet summaries with primary photo by ID
+//   - error: Database error or nil on success
+func GetVisitPets(petIDs []uint) (map[uint]*m.SimplifiedPet, error) {
+	byID := make(map[uint]*m.SimplifiedPet, len(petIDs))
+	if len(petIDs) == 0 {
+		return byID, nil
+	}
+
+	gormDB := db.ORMOpen()
+
+	var pets []m.Pet
+	result := gormDB.Preload("Photos", "is_primary = ?", true).
+		Where("id IN ?", petIDs).
+		Find(&pets)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer mascotas de las visitas: %v", result.Error)
+	}
+
+	for _, pet := range pets {
+		summary := toSimplifiedPet(pet)
+		byID[pet.ID] = &summary
+	}
+
+	return byID, nil
+}
+
+// ========================================
+// VISIT BOOKING OPERATIONS
+// ========================================
+
+// BookVisit claims a slot and inserts the visit booked in it, in one transaction.
+// The visit takes the time and location of the slot.
+//
+// Parameters:
+//   - visit: Visit to insert (SlotID must be set; updated with ID, times and timestamps)
+//   - slot: Slot to claim
+//   - now: Current time; slots already started cannot be booked
+//
+// Returns:
+//   - bool: false if the slot was booked by someone else or has already started
+//   - error: Database error or nil on success
+func BookVisit(visit *m.Visit, slot *m.VisitSlot, now time.Time) (bool, error) {
+	gormDB := db.ORMOpen()
+
+	claimed := false
+	err := gormDB.Transaction(func(tx *gorm.DB) error {
+		ok, err := claimVisitSlot(tx, slot.ID, now)
+		if err != nil || !ok {
+			return err
+		}
+
+		visit.SlotID = &slot.ID
+		visit.StartTime = slot.StartTime
+		visit.EndTime = slot.EndTime
+		visit.Location = slot.Location
+		if err := tx.Create(visit).Error; err != nil {
+			return err
+		}
+
+		claimed = true
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("error al reservar visita en la franja %d: %v", slot.ID, err)
+	}
+
+	return claimed, nil
+}
+
+// RescheduleVisit moves a visit to another slot, in one transaction.
+// The new slot is claimed, the previous one released and the calendar sequence increased.
+// The reminder is reset so it is sent again for the new time.
+//
+// Parameters:
+//   - visit: Visit to move (updated with the new slot, times and sequence)
+//   - slot: New slot to claim
+//   - now: Current time; slots already started cannot be booked
+//
+// Returns:
+//   - bool: false if the new slot was booked by someone else or has already started
+//   - error: Database error or nil on success
+func RescheduleVisit(visit *m.Visit, slot *m.VisitSlot, now time.Time) (bool, error) {
+	gormDB := db.ORMOpen()
+
+	claimed := false
+	err := gormDB.Transaction(func(tx *gorm.DB) error {
+		ok, err := claimVisitSlot(tx, slot.ID, now)
+		if err != nil || !ok {
+			return err
+		}
+
+		if err := releaseVisitSlot(tx, visit.SlotID); err != nil {
+			return err
+		}
+
+		result := tx.Model(&m.Visit{}).
+			Where("id = ? AND status = ?", visit.ID, m.VisitStatusScheduled).
+			Updates(map[string]any{
+				"slot_id":          slot.ID,
+				"start_time":       slot.StartTime,
+				"end_time":         slot.EndTime,
+				"location":         slot.Location,
+				"sequence":         gorm.Expr("sequence + 1"),
+				"reminder_sent_at": nil,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("la visita %d ya no está programada", visit.ID)
+		}
+
+		claimed = true
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("error al cambiar la visita %d a la franja %d: %v", visit.ID, slot.ID, err)
+	}
+
+	if claimed {
+		visit.SlotID = &slot.ID
+		visit.StartTime = slot.StartTime
+		visit.EndTime = slot.EndTime
+		visit.Location = slot.Location
+		visit.Sequence++
+		visit.ReminderSentAt = nil
+	}
+
+	return claimed, nil
+}
+
+// UpdateVisitStatus changes the status of a scheduled visit.
+// Cancelling releases its slot and increases the calendar sequence, in one transaction.
+//
+// Parameters:
+//   - visit: Visit with the new Status (and CancelledAt/CancelReason when cancelling)
+//
+// Returns:
+//   - bool: false if the visit was no longer scheduled
+//   - error: Database error or nil on success
+func UpdateVisitStatus(visit *m.Visit) (bool, error) {
+	gormDB := db.ORMOpen()
+
+	changes := map[string]any{"status": visit.Status}
+	if visit.Status == m.VisitStatusCancelled {
+		changes["cancelled_at"] = visit.CancelledAt
+		changes["cancel_reason"] = visit.CancelReason
+		changes["sequence"] = gorm.Expr("sequence + 1")
+	}
+
+	updated := false
+	err := gormDB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&m.Visit{}).
+			Where("id = ? AND status = ?", visit.ID, m.VisitStatusScheduled).
+			Updates(changes)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+
+		if visit.Status == m.VisitStatusCancelled {
+			if err := releaseVisitSlot(tx, visit.SlotID); err != nil {
+				return err
+			}
+		}
+
+		updated = true
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("error al actualizar estado de la visita %d: %v", visit.ID, err)
+	}
+
+	if updated && visit.Status == m.VisitStatusCancelled {
+		visit.Sequence++
+	}
+
+	return updated, nil
+}
+
+// SetVisitApplication links a visit to an adoption application, or unlinks it with nil.
+//
+// Parameters:
+//   - id: Unique identifier of the visit
+//   - applicationID: Adoption application ID, or nil to unlink
+//
+// Returns:
+//   - error: Database error or nil on success
+func SetVisitApplication(id uint, applicationID *uint) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Model(&m.Visit{}).Where("id = ?", id).Update("application_id", applicationID)
+	if result.Error != nil {
+		return fmt.Errorf("error al enlazar la visita %d con la solicitud: %v", id, result.Error)
+	}
+
+	return nil
+}
+
+// MarkVisitReminderSent records that the reminder of a visit was sent.
+//
+// Parameters:
+//   - id: Unique identifier of the visit
+//   - sentAt: Time the reminder was sent
+//
+// Returns:
+//   - error: Database error or nil on success
+func MarkVisitReminderSent(id uint, sentAt time.Time) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Model(&m.Visit{}).Where("id = ?", id).Update("reminder_sent_at", sentAt)
+	if result.Error != nil {
+		return fmt.Errorf("error al registrar recordatorio de la visita %d: %v", id, result.Error)
+	}
+
+	return nil
+}
@@ -0,0 +1,812 @@
+// Package dao implements data access objects for volunteers and their shifts.
+// This layer is responsible for:
+// - CRUD operations on volunteer profiles, certifications and shifts
+// - Signing volunteers up for shifts and cancelling without exceeding their capacity
+// - Recording attendance and summing the hours worked by each volunteer
+// - Finding the signups due for a reminder email
+package dao
+
+import (
+	"backend/internal/db"
+	"backend/internal/db/query"
+	m "backend/internal/models"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// VolunteerListSchema is the allowlist of sort fields and filters accepted by volunteer list queries.
+//
+// Filters:
+//   - skill: Volunteers with the skill
+//   - active: true/false
+//   - user: Volunteer user ID
+//
+// Sort fields: crt_date, id
+var VolunteerListSchema = query.Schema{
+	Sorts: map[string]query.SortColumn{
+		"id":       {Column: "id"},
+		"crt_date": {Column: "crt_date"},
+	},
+	Filters: map[string]query.FilterFunc{
+		"skill":  hasSkillFilter,
+		"active": query.Bool("active"),
+		"user":   query.Uint("user_id"),
+	},
+	DefaultSort: "-crt_date",
+}
+
+// VolunteerShiftListSchema is the allowlist of sort fields and filters accepted by shift list queries.
+//
+// Filters:
+//   - has_places: true for shifts with free places, false for full shifts
+//   - skill: Required skill
+//   - from, to: Range of the start date (YYYY-MM-DD)
+//
+// Sort fields: start_time, crt_date, id
+var VolunteerShiftListSchema = query.Schema{
+	Sorts: map[string]query.SortColumn{
+		"id":         {Column: "id"},
+		"start_time": {Column: "start_time"},
+		"crt_date":   {Column: "crt_date"},
+	},
+	Filters: map[string]query.FilterFunc{
+		"has_places": hasPlacesFilter,
+		"skill":      query.Equals("required_skill"),
+		"from":       query.DateFrom("start_time"),
+		"to":         query.DateTo("start_time"),
+	},
+	DefaultSort: "start_time",
+}
+
+// hasSkillFilter keeps volunteers who declared a skill.
+func hasSkillFilter(value string) (query.Scope, error) {
+	return func(tx *gorm.DB) *gorm.DB {
+		return tx.Where("JSON_CONTAINS(skills, JSON_QUOTE(?))", value)
+	}, nil
+}
+
+// hasPlacesFilter keeps shifts with (true) or without (false) free places.
+func hasPlacesFilter(value string) (query.Scope, error) {
+	free, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, fmt.Errorf("valor booleano inválido para has_places: %s", value)
+	}
+
+	return func(tx *gorm.DB) *gorm.DB {
+		if free {
+			return tx.Where("signed_up < capacity")
+		}
+		return tx.Where("signed_up >= capacity")
+	}, nil
+}
+
+// ========================================
+// VOLUNTEER RETRIEVAL OPERATIONS
+// ========================================
+
+// GetVolunteers retrieves one page of an organisation's volunteers matching the list query.
+//
+// Parameters:
+//   - params: Parsed list query (see VolunteerListSchema)
+//   - orgID: Organisation whose volunteers are listed
+//
+// Returns:
+//   - *query.Page[m.Volunteer]: Requested page of volunteers with their certifications
+//   - error: Database error or nil on success
+func GetVolunteers(params *query.Params, orgID uint) (*query.Page[m.Volunteer], error) {
+	gormDB := db.ORMOpen()
+
+	page, err := query.Find[m.Volunteer](gormDB.Model(&m.Volunteer{}).
+		Preload("Certifications", func(tx *gorm.DB) *gorm.DB { return tx.Order("name, id") }).
+		Scopes(inOrganization(orgID)), params)
+	if err != nil {
+		return nil, fmt.Errorf("error al leer voluntarios: %v", err)
+	}
+
+	return page, nil
+}
+
+// GetVolunteer retrieves a volunteer of an organisation with their certifications.
+//
+// Parameters:
+//   - id: Unique identifier of the volunteer
+//   - orgID: Organisation the volunteer must belong to, or AllOrganizations
+//
+// Returns:
+//   - *m.Volunteer: Volunteer data
+//   - error: Database error or record not found error
+func GetVolunteer(id uint, orgID uint) (*m.Volunteer, error) {
+	gormDB := db.ORMOpen()
+
+	var volunteer m.Volunteer
+	result := gormDB.Preload("Certifications", func(tx *gorm.DB) *gorm.DB { return tx.Order("name, id") }).
+		Scopes(inOrganization(orgID)).
+		First(&volunteer, id)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer voluntario %d: %v", id, result.Error)
+	}
+
+	return &volunteer, nil
+}
+
+// GetVolunteerByUser retrieves the volunteer profile of a user in an organisation with their certifications.
+//
+// Parameters:
+//   - orgID: Unique identifier of the organisation
+//   - userID: Unique identifier of the user
+//
+// Returns:
+//   - *m.Volunteer: Volunteer, or nil if the user does not volunteer in the organisation
+//   - error: Database error or nil on success
+func GetVolunteerByUser(orgID uint, userID uint) (*m.Volunteer, error) {
+	gormDB := db.ORMOpen()
+
+	var volunteer m.Volunteer
+	result := gormDB.Preload("Certifications").
+		Where("organization_id = ? AND user_id = ?", orgID, userID).
+		First(&volunteer)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al buscar voluntario del usuario %d: %v", userID, result.Error)
+	}
+
+	return &volunteer, nil
+}
+
+// GetUserVolunteers retrieves the volunteer profiles of a user in every organisation.
+//
+// Parameters:
+//   - userID: Unique identifier of the user
+//
+// Returns:
+//   - []m.Volunteer: Volunteer profiles with their certifications
+//   - error: Database error or nil on success
+func GetUserVolunteers(userID uint) ([]m.Volunteer, error) {
+	gormDB := db.ORMOpen()
+
+	var volunteers []m.Volunteer
+	result := gormDB.Preload("Certifications", func(tx *gorm.DB) *gorm.DB { return tx.Order("name, id") }).
+		Where("user_id = ?", userID).
+		Order("organization_id").
+		Find(&volunteers)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer perfiles de voluntario del usuario %d: %v", userID, result.Error)
+	}
+
+	return volunteers, nil
+}
+
+// GetVolunteerUsers retrieves the user summaries of the given volunteers.
+//
+// Parameters:
+//   - userIDs: Unique identifiers of the users
+//
+// Returns:
+//   - map[uint]*m.SimplifiedUser: User summaries by ID
+//   - error: Database error or nil on success
+func GetVolunteerUsers(userIDs []uint) (map[uint]*m.SimplifiedUser, error) {
+	byID := make(map[uint]*m.SimplifiedUser, len(userIDs))
+	if len(userIDs) == 0 {
+		return byID, nil
+	}
+
+	gormDB := db.ORMOpen()
+
+	var users []m.SimplifiedUser
+	if err := gormDB.Model(&m.User{}).Where("id IN ?", userIDs).Find(&users).Error; err != nil {
+		return nil, fmt.Errorf("error al leer usuarios de los voluntarios: %v", err)
+	}
+
+	for i := range users {
+		byID[users[i].ID] = &users[i]
+	}
+
+	return byID, nil
+}
+
+// ========================================
+// VOLUNTEER CRUD OPERATIONS
+// ========================================
+
+// CreateVolunteer inserts a new volunteer profile.
+//
+// Parameters:
+//   - volunteer: Volunteer to insert (will be updated with ID and timestamps)
+//
+// Returns:
+//   - error: Database error or nil on success
+func CreateVolunteer(volunteer *m.Volunteer) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Omit("Certifications").Create(volunteer)
+	if result.Error != nil {
+		return fmt.Errorf("error al crear voluntario: %v", result.Error)
+	}
+
+	return nil
+}
+
+// UpdateVolunteer updates the profile of a volunteer of an organisation.
+// The organisation and the volunteer user cannot be changed.
+//
+// Parameters:
+//   - volunteer: Volunteer with updated data (must include ID and OrganizationID)
+//   - fields: Columns to update (staff update every field, volunteers only their own details)
+//
+// Returns:
+//   - error: Database error, record not found error or nil on success
+func UpdateVolunteer(volunteer *m.Volunteer, fields ...string) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Model(&m.Volunteer{}).
+		Where("id = ? AND organization_id = ?", volunteer.ID, volunteer.OrganizationID).
+		Select(fields).
+		Updates(volunteer)
+	if result.Error != nil {
+		return fmt.Errorf("error al actualizar voluntario %d: %v", volunteer.ID, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("voluntario con id %d no encontrado", volunteer.ID)
+	}
+
+	return nil
+}
+
+// DeleteVolunteer removes a volunteer of an organisation with their certifications.
+// Callers must ensure the volunteer has no signups, whose hours would be lost.
+//
+// Parameters:
+//   - id: Unique identifier of the volunteer
+//   - orgID: Organisation the volunteer must belong to
+//
+// Returns:
+//   - error: Database error, record not found error or nil on success
+func DeleteVolunteer(id uint, orgID uint) error {
+	gormDB := db.ORMOpen()
+
+	return gormDB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("organization_id = ?", orgID).Delete(&m.Volunteer{}, id)
+		if result.Error != nil {
+			return fmt.Errorf("error al eliminar voluntario %d: %v", id, result.Error)
+		}
+
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("voluntario con id %d no encontrado", id)
+		}
+
+		if err := tx.Where("volunteer_id = ?", id).Delete(&m.VolunteerCertification{}).Error; err != nil {
+			return fmt.Errorf("error al eliminar certificaciones del voluntario %d: %v", id, err)
+		}
+
+		return nil
+	})
+}
+
+// CountVolunteerSignups counts the signups of a volunteer, including cancelled ones.
+//
+// Parameters:
+//   - volunteerID: Unique identifier of the volunteer
+//
+// Returns:
+//   - int64: Number of signups
+//   - error: Database error or nil on success
+func CountVolunteerSignups(volunteerID uint) (int64, error) {
+	gormDB := db.ORMOpen()
+
+	var count int64
+	result := gormDB.Model(&m.VolunteerSignup{}).Where("volunteer_id = ?", volunteerID).Count(&count)
+	if result.Error != nil {
+		return 0, fmt.Errorf("error al contar turnos del voluntario %d: %v", volunteerID, result.Error)
+	}
+
+	return count, nil
+}
+
+// CreateVolunteerCertification inserts a certification of a volunteer.
+//
+// Parameters:
+//   - certification: Certification to insert (will be updated with ID and timestamps)
+//
+// Returns:
+//   - error: Database error or nil on success
+func CreateVolunteerCertification(certification *m.VolunteerCertification) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Create(certification)
+	if result.Error != nil {
+		return fmt.Errorf("error al crear certificación: %v", result.Error)
+	}
+
+	return nil
+}
+
+// DeleteVolunteerCertification removes a certification of a volunteer.
+//
+// Parameters:
+//   - id: Unique identifier of the certification
+//   - volunteerID: Volunteer the certification must belong to
+//
+// Returns:
+//   - bool: false if the certification does not exist
+//   - error: Database error or nil on success
+func DeleteVolunteerCertification(id uint, volunteerID uint) (bool, error) {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Where("volunteer_id = ?", volunteerID).Delete(&m.VolunteerCertification{}, id)
+	if result.Error != nil {
+		return false, fmt.Errorf("error al eliminar certificación %d: %v", id, result.Error)
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// ========================================
+// VOLUNTEER SHIFT OPERATIONS
+// ========================================
+
+// GetVolunteerShifts retrieves one page of an organisation's shifts matching the list query.
+//
+// Parameters:
+//   - params: Parsed list query (see VolunteerShiftListSchema)
+//   - orgID: Organisation whose shifts are listed
+//
+// Returns:
+//   - *query.Page[m.VolunteerShift]: Requested page of shifts with total count and links
+//   - error: Database error or nil on success
+func GetVolunteerShifts(params *query.Params, orgID uint) (*query.Page[m.VolunteerShift], error) {
+	gormDB := db.ORMOpen()
+
+	page, err := query.Find[m.VolunteerShift](gormDB.Model(&m.VolunteerShift{}).Scopes(inOrganization(orgID)), params)
+	if err != nil {
+		return nil, fmt.Errorf("error al leer turnos de voluntariado: %v", err)
+	}
+
+	return page, nil
+}
+
+// GetVolunteerShift retrieves a shift of an organisation.
+//
+// Parameters:
+//   - id: Unique identifier of the shift
+//   - orgID: Organisation the shift must belong to, or AllOrganizations
+//
+// Returns:
+//   - *m.VolunteerShift: Shift data
+//   - error: Database error or record not found error
+func GetVolunteerShift(id uint, orgID uint) (*m.VolunteerShift, error) {
+	gormDB := db.ORMOpen()
+
+	var shift m.VolunteerShift
+	result := gormDB.Scopes(inOrganization(orgID)).First(&shift, id)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer turno de voluntariado %d: %v", id, result.Error)
+	}
+
+	return &shift, nil
+}
+
+// GetVolunteerShiftsByID retrieves shifts by their identifiers.
+//
+// Parameters:
+//   - ids: Unique identifiers of the shifts
+//
+// Returns:
+//   - map[uint]*m.VolunteerShift: Shifts by ID
+//   - error: Database error or nil on success
+func GetVolunteerShiftsByID(ids []uint) (map[uint]*m.VolunteerShift, error) {
+	byID := make(map[uint]*m.VolunteerShift, len(ids))
+	if len(ids) == 0 {
+		return byID, nil
+	}
+
+	gormDB := db.ORMOpen()
+
+	var shifts []m.VolunteerShift
+	if err := gormDB.Where("id IN ?", ids).Find(&shifts).Error; err != nil {
+		return nil, fmt.Errorf("error al leer turnos de voluntariado: %v", err)
+	}
+
+	for i := range shifts {
+		byID[shifts[i].ID] = &shifts[i]
+	}
+
+	return byID, nil
+}
+
+// GetUpcomingVolunteerShifts retrieves the shifts of some organisations starting after a time.
+//
+// Parameters:
+//   - orgIDs: Organisations whose shifts are listed
+//   - from: Only shifts starting after this time are returned
+//
+// Returns:
+//   - []m.VolunteerShift: Shifts, earliest first
+//   - error: Database error or nil on success
+func GetUpcomingVolunteerShifts(orgIDs []uint, from time.Time) ([]m.VolunteerShift, error) {
+	if len(orgIDs) == 0 {
+		return []m.VolunteerShift{}, nil
+	}
+
+	gormDB := db.ORMOpen()
+
+	var shifts []m.VolunteerShift
+	result := gormDB.Where("organization_id IN ? AND start_time > ?", orgIDs, from).
+		Order("start_time, id").
+		Find(&shifts)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer próximos turnos de voluntariado: %v", result.Error)
+	}
+
+	return shifts, nil
+}
+
+// CreateVolunteerShift inserts a new shift.
+//
+// Parameters:
+//   - shift: Shift to insert (will be updated with ID and timestamps)
+//
+// Returns:
+//   - error: Database error or nil on success
+func CreateVolunteerShift(shift *m.VolunteerShift) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Omit("SignedUp").Create(shift)
+	if result.Error != nil {
+		return fmt.Errorf("error al crear turno de voluntariado: %v", result.Error)
+	}
+
+	return nil
+}
+
+// UpdateVolunteerShift updates a shift of an organisation, unless the new capacity is below its signups.
+// The check and the update are a single statement, so a concurrent signup is never lost.
+//
+// Parameters:
+//   - shift: Shift with updated data (must include ID and OrganizationID)
+//
+// Returns:
+//   - bool: false if the shift does not exist or has more signups than the new capacity
+//   - error: Database error or nil on success
+func UpdateVolunteerShift(shift *m.VolunteerShift) (bool, error) {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Model(&m.VolunteerShift{}).
+		Where("id = ? AND organization_id = ? AND signed_up <= ?", shift.ID, shift.OrganizationID, shift.Capacity).
+		Select("title", "description", "required_skill", "required_certification",
+			"start_time", "end_time", "location", "capacity").
+		Updates(shift)
+	if result.Error != nil {
+		return false, fmt.Errorf("error al actualizar turno de voluntariado %d: %v", shift.ID, result.Error)
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// DeleteEmptyVolunteerShift removes a shift of an organisation unless a volunteer holds a place in it.
+// The check and the deletion are a single statement, so a concurrent signup is never lost.
+// Cancelled signups of the shift are removed with it.
+//
+// Parameters:
+//   - id: Unique identifier of the shift
+//   - orgID: Organisation the shift must belong to
+//
+// Returns:
+//   - bool: false if the shift does not exist or has signups
+//   - error: Database error or nil on success
+func DeleteEmptyVolunteerShift(id uint, orgID uint) (bool, error) {
+	gormDB := db.ORMOpen()
+
+	deleted := false
+	err := gormDB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("organization_id = ? AND signed_up = 0", orgID).Delete(&m.VolunteerShift{}, id)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+
+		if err := tx.Where("shift_id = ?", id).Delete(&m.VolunteerSignup{}).Error; err != nil {
+			return err
+		}
+
+		deleted = true
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("error al eliminar turno de voluntariado %d: %v", id, err)
+	}
+
+	return deleted, nil
+}
+
+// ========================================
+// VOLUNTEER SIGNUP OPERATIONS
+// ========================================
+
+// GetShiftSignups retrieves the signups of the given shifts.
+//
+// Parameters:
+//   - shiftIDs: Unique identifiers of the shifts
+//
+// Returns:
+//   - []m.VolunteerSignup: Signups ordered by shift and signup time
+//   - error: Database error or nil on success
+func GetShiftSignups(shiftIDs []uint) ([]m.VolunteerSignup, error) {
+	if len(shiftIDs) == 0 {
+		return []m.VolunteerSignup{}, nil
+	}
+
+	gormDB := db.ORMOpen()
+
+	var signups []m.VolunteerSignup
+	result := gormDB.Where("shift_id IN ?", shiftIDs).Order("shift_id, crt_date, id").Find(&signups)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer inscripciones de los turnos: %v", result.Error)
+	}
+
+	return signups, nil
+}
+
+// GetShiftSignup retrieves the signup of a volunteer in a shift.
+//
+// Parameters:
+//   - shiftID: Unique identifier of the shift
+//   - volunteerID: Unique identifier of the volunteer
+//
+// Returns:
+//   - *m.VolunteerSignup: Signup, or nil if the volunteer never signed up for the shift
+//   - error: Database error or nil on success
+func GetShiftSignup(shiftID uint, volunteerID uint) (*m.VolunteerSignup, error) {
+	gormDB := db.ORMOpen()
+
+	var signup m.VolunteerSignup
+	result := gormDB.Where("shift_id = ? AND volunteer_id = ?", shiftID, volunteerID).First(&signup)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al buscar inscripción en el turno %d: %v", shiftID, result.Error)
+	}
+
+	return &signup, nil
+}
+
+// GetUserVolunteerSignups retrieves the signups of a user in every organisation.
+//
+// Parameters:
+//   - userID: Unique identifier of the user
+//   - from: Only signups of shifts starting after this time are returned
+//
+// Returns:
+//   - []m.VolunteerSignup: Signups, earliest shift first
+//   - error: Database error or nil on success
+func GetUserVolunteerSignups(userID uint, from time.Time) ([]m.VolunteerSignup, error) {
+	gormDB := db.ORMOpen()
+
+	var signups []m.VolunteerSignup
+	result := gormDB.Select("Volunteer_Signups.*").
+		Joins("JOIN Volunteer_Shifts ON Volunteer_Shifts.id = Volunteer_Signups.shift_id").
+		Where("Volunteer_Signups.user_id = ? AND Volunteer_Shifts.start_time > ?", userID, from).
+		Order("Volunteer_Shifts.start_time, Volunteer_Signups.id").
+		Find(&signups)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer turnos del usuario %d: %v", userID, result.Error)
+	}
+
+	return signups, nil
+}
+
+// GetVolunteerSignupsDueForReminder retrieves the active signups of shifts starting within a time range
+// whose reminder has not been sent yet.
+//
+// Parameters:
+//   - from, to: Range of the shift start time
+//
+// Returns:
+//   - []m.VolunteerSignup: Signups due for a reminder, earliest shift first
+//   - error: Database error or nil on success
+func GetVolunteerSignupsDueForReminder(from time.Time, to time.Time) ([]m.VolunteerSignup, error) {
+	gormDB := db.ORMOpen()
+
+	var signups []m.VolunteerSignup
+	result := gormDB.Select("Volunteer_Signups.*").
+		Joins("JOIN Volunteer_Shifts ON Volunteer_Shifts.id = Volunteer_Signups.shift_id").
+		Where("Volunteer_Signups.status = ? AND Volunteer_Signups.reminder_sent_at IS NULL", m.SignupStatusSignedUp).
+		Where("Volunteer_Shifts.start_time > ? AND Volunteer_Shifts.start_time <= ?", from, to).
+		Order("Volunteer_Shifts.start_time, Volunteer_Signups.id").
+		Find(&signups)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al leer inscripciones pendientes de recordatorio: %v", result.Error)
+	}
+
+	return signups, nil
+}
+
+// SignUpForShift takes a place in a shift and records the signup, in one transaction.
+// A cancelled signup of the same volunteer is reused, so each volunteer has one signup per shift.
+//
+// Parameters:
+//   - signup: Signup to record (ShiftID, VolunteerID, UserID and OrganizationID set; updated with ID and status)
+//   - now: Current time; shifts already started cannot be joined
+//
+// Returns:
+//   - bool: false if the shift is full or has already started
+//   - error: Database error or nil on success
+func SignUpForShift(signup *m.VolunteerSignup, now time.Time) (bool, error) {
+	gormDB := db.ORMOpen()
+
+	claimed := false
+	err := gormDB.Transaction(func(tx *gorm.DB) error {
+		// The conditional update is atomic, so concurrent signups never exceed the capacity
+		result := tx.Model(&m.VolunteerShift{}).
+			Where("id = ? AND signed_up < capacity AND start_time > ?", signup.ShiftID, now).
+			Update("signed_up", gorm.Expr("signed_up + 1"))
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+
+		signup.Status = m.SignupStatusSignedUp
+		signup.CancelledAt = nil
+		signup.ReminderSentAt = nil
+		if signup.ID != 0 {
+			result = tx.Model(&m.VolunteerSignup{}).
+				Where("id = ? AND status = ?", signup.ID, m.SignupStatusCancelled).
+				Updates(map[string]any{
+					"status":           m.SignupStatusSignedUp,
+					"cancelled_at":     nil,
+					"reminder_sent_at": nil,
+				})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return fmt.Errorf("la inscripción %d ya está activa", signup.ID)
+			}
+		} else if err := tx.Create(signup).Error; err != nil {
+			return err
+		}
+
+		claimed = true
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("error al inscribir en el turno %d: %v", signup.ShiftID, err)
+	}
+
+	return claimed, nil
+}
+
+// CancelShiftSignup cancels an active signup and releases its place, in one transaction.
+//
+// Parameters:
+//   - signup: Signup to cancel
+//   - now: Cancellation time
+//
+// Returns:
+//   - bool: false if the signup was not active anymore
+//   - error: Database error or nil on success
+func CancelShiftSignup(signup *m.VolunteerSignup, now time.Time) (bool, error) {
+	gormDB := db.ORMOpen()
+
+	cancelled := false
+	err := gormDB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&m.VolunteerSignup{}).
+			Where("id = ? AND status = ?", signup.ID, m.SignupStatusSignedUp).
+			Updates(map[string]any{"status": m.SignupStatusCancelled, "cancelled_at": now})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+
+		result = tx.Model(&m.VolunteerShift{}).
+			Where("id = ? AND signed_up > 0", signup.ShiftID).
+			Update("signed_up", gorm.Expr("signed_up - 1"))
+		if result.Error != nil {
+			return result.Error
+		}
+
+		cancelled = true
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("error al cancelar la inscripción %d: %v", signup.ID, err)
+	}
+
+	if cancelled {
+		signup.Status = m.SignupStatusCancelled
+		signup.CancelledAt = &now
+	}
+
+	return cancelled, nil
+}
+
+// RecordSignupAttendance records whether a volunteer worked a shift.
+// Cancelled signups cannot be changed; attendance already recorded can be corrected.
+//
+// Parameters:
+//   - signupID: Unique identifier of the signup
+//   - shiftID: Shift the signup must belong to
+//   - status: attended or no_show
+//   - minutes: Time worked when different from the shift length (nil for the shift length)
+//   - staffID: Staff user recording the attendance
+//
+// Returns:
+//   - bool: false if the signup does not exist in the shift or was cancelled
+//   - error: Database error or nil on success
+func RecordSignupAttendance(signupID uint, shiftID uint, status string, minutes *int, staffID uint) (bool, error) {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Model(&m.VolunteerSignup{}).
+		Where("id = ? AND shift_id = ? AND status <> ?", signupID, shiftID, m.SignupStatusCancelled).
+		Updates(map[string]any{
+			"status":         status,
+			"minutes_worked": minutes,
+			"attendance_by":  staffID,
+		})
+	if result.Error != nil {
+		return false, fmt.Errorf("error al registrar asistencia de la inscripción %d: %v", signupID, result.Error)
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// MarkSignupReminderSent records that the reminder of a signup was sent.
+//
+// Parameters:
+//   - id: Unique identifier of the signup
+//   - sentAt: Time the reminder was sent
+//
+// Returns:
+//   - error: Database error or nil on success
+func MarkSignupReminderSent(id uint, sentAt time.Time) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Model(&m.VolunteerSignup{}).Where("id = ?", id).Update("reminder_sent_at", sentAt)
+	if result.Error != nil {
+		return fmt.Errorf("error al registrar recordatorio de la inscripción %d: %v", id, result.Error)
+	}
+
+	return nil
+}
+
+// ========================================
+// VOLUNTEER HOURS OPERATIONS
+// ========================================
+
+// GetVolunteerHours sums the shifts worked and missed by each volunteer of an organisation
+// in shifts starting within a time range. Attended signups count MinutesWorked, or the
+// shift length when not set.
+//
+// Parameters:
+//   - orgID: Organisation whose volunteers are reported
+//   - from, to: Range of the shift start time (to excluded)
+//
+// Returns:
+//   - []m.VolunteerHours: One row per volunteer with attendance recorded, most hours first
+//   - error: Database error or nil on success
+func GetVolunteerHours(orgID uint, from time.Time, to time.Time) ([]m.VolunteerHours, error) {
+	gormDB := db.ORMOpen()
+
+	var rows []m.VolunteerHours
+	result := gormDB.Table("Volunteer_Signups AS vs").
+		Select("vs.volunteer_id, vs.user_id, "+
+			"SUM(CASE WHEN vs.status = ? THEN 1 ELSE 0 END) AS shifts, "+
+			"SUM(CASE WHEN vs.status = ? THEN 1 ELSE 0 END) AS no_shows, "+
+			"COALESCE(SUM(CASE WHEN vs.status = ? THEN COALESCE(vs.minutes_worked, TIMESTAMPDIFF(MINUTE, sh.start_time, sh.end_time)) END), 0) AS minutes",
+			m.SignupStatusAttended, m.SignupStatusNoShow, m.SignupStatusAttended).
+		Joins("JOIN Volunteer_Shifts AS sh ON sh.id = vs.shift_id").
+		Where("vs.organization_id = ? AND vs.status IN ? AND sh.start_time >= ? AND sh.start_time < ?",
+			orgID, []string{m.SignupStatusAttended, m.SignupStatusNoShow}, from, to).
+		Group("vs.volunteer_id, vs.user_id").
+		Order("minutes DESC, vs.volunteer_id").
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al calcular horas de voluntariado: %v", result.Error)
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,389 @@
+// Package dao implements data access objects for outbound webhooks.
+// This layer is responsible for:
+// - CRUD operations on webhook endpoints
+// - The persistent queue of deliveries, claimed before each attempt
+// - The log of delivery attempts
+package dao
+
+import (
+	"backend/internal/db"
+	"backend/internal/db/query"
+	m "backend/internal/models"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// WebhookDeliveryListSchema is the allowlist of sort fields and filters accepted by webhook delivery log queries.
+//
+// Filters:
+//   - status: pending, succeeded or failed
+//   - event: Event type
+//   - event_id: Event identifier
+//   - from, to: Creation date range (YYYY-MM-DD)
+//
+// Sort fields: id, crt_date, next_attempt_at
+var WebhookDeliveryListSchema = query.Schema{
+	Sorts: map[string]query.SortColumn{
+		"id":              {Column: "id"},
+		"crt_date":        {Column: "crt_date"},
+		"next_attempt_at": {Column: "next_attempt_at", Nullable: true},
+	},
+	Filters: map[string]query.FilterFunc{
+		"status":   query.OneOf("status", m.WebhookDeliveryStatuses...),
+		"event":    query.OneOf("event_type", m.WebhookEventTypes...),
+		"event_id": webhookEventIDFilter,
+		"from":     query.DateFrom("crt_date"),
+		"to":       query.DateTo("crt_date"),
+	},
+	DefaultSort: "-id",
+}
+
+// webhookEventIDFilter keeps the deliveries of one event (the original and its replays).
+func webhookEventIDFilter(value string) (query.Scope, error) {
+	if value == "" || len(value) > 40 {
+		return nil, fmt.Errorf("event_id inválido")
+	}
+
+	return func(tx *gorm.DB) *gorm.DB {
+		return tx.Where("event_id = ?", value)
+	}, nil
+}
+
+// ========================================
+// WEBHOOK CRUD OPERATIONS
+// ========================================
+
+// GetWebhooks retrieves every registered webhook.
+//
+// Returns:
+//   - []m.Webhook: Webhooks ordered by ID
+//   - error: Database error or nil on success
+func GetWebhooks() ([]m.Webhook, error) {
+	gormDB := db.ORMOpen()
+
+	var webhooks []m.Webhook
+	if err := gormDB.Order("id").Find(&webhooks).Error; err != nil {
+		return nil, fmt.Errorf("error al leer webhooks: %v", err)
+	}
+
+	return webhooks, nil
+}
+
+// GetActiveWebhooks retrieves the webhooks that receive new events.
+//
+// Returns:
+//   - []m.Webhook: Active webhooks ordered by ID
+//   - error: Database error or nil on success
+func GetActiveWebhooks() ([]m.Webhook, error) {
+	gormDB := db.ORMOpen()
+
+	var webhooks []m.Webhook
+	if err := gormDB.Where("active = ?", true).Order("id").Find(&webhooks).Error; err != nil {
+		return nil, fmt.Errorf("error al leer webhooks activos: %v", err)
+	}
+
+	return webhooks, nil
+}
+
+// GetWebhook retrieves a webhook.
+//
+// Parameters:
+//   - id: Unique identifier of the webhook
+//
+// Returns:
+//   - *m.Webhook: Webhook data, including its secret
+//   - error: Database error or record not found error
+func GetWebhook(id uint) (*m.Webhook, error) {
+	gormDB := db.ORMOpen()
+
+	var webhook m.Webhook
+	if err := gormDB.First(&webhook, id).Error; err != nil {
+		return nil, fmt.Errorf("error al leer webhook %d: %v", id, err)
+	}
+
+	return &webhook, nil
+}
+
+// CreateWebhook inserts a webhook.
+//
+// Parameters:
+//   - webhook: Webhook to insert (will be updated with ID and timestamps)
+//
+// Returns:
+//   - error: Database error or nil on success
+func CreateWebhook(webhook *m.Webhook) error {
+	gormDB := db.ORMOpen()
+
+	if err := gormDB.Create(webhook).Error; err != nil {
+		return fmt.Errorf("error al crear webhook: %v", err)
+	}
+
+	return nil
+}
+
+// UpdateWebhook replaces the endpoint, filters and state of a webhook. The secret is not changed.
+//
+// Parameters:
+//   - webhook: Webhook with the new values (must include ID)
+//
+// Returns:
+//   - error: Database error, record not found error or nil on success
+func UpdateWebhook(webhook *m.Webhook) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Model(webhook).
+		Select("url", "description", "event_types", "organization_id", "active").
+		Updates(webhook)
+	if result.Error != nil {
+		return fmt.Errorf("error al actualizar webhook %d: %v", webhook.ID, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("webhook con id %d no encontrado", webhook.ID)
+	}
+
+	return nil
+}
+
+// UpdateWebhookSecret replaces the signing secret of a webhook.
+//
+// Parameters:
+//   - id: Unique identifier of the webhook
+//   - secret: New signing secret
+//
+// Returns:
+//   - error: Database error, record not found error or nil on success
+func UpdateWebhookSecret(id uint, secret string) error {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Model(&m.Webhook{}).Where("id = ?", id).Update("secret", secret)
+	if result.Error != nil {
+		return fmt.Errorf("error al cambiar secreto del webhook %d: %v", id, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("webhook con id %d no encontrado", id)
+	}
+
+	return nil
+}
+
+// DeleteWebhook removes a webhook with its deliveries and their attempts.
+//
+// Parameters:
+//   - id: Unique identifier of the webhook
+//
+// Returns:
+//   - error: Database error, record not found error or nil on success
+func DeleteWebhook(id uint) error {
+	gormDB := db.ORMOpen()
+
+	var deleted int64
+	err := gormDB.Transaction(func(tx *gorm.DB) error {
+		deliveries := tx.Model(&m.WebhookDelivery{}).Select("id").Where("webhook_id = ?", id)
+		if err := tx.Where("delivery_id IN (?)", deliveries).Delete(&m.WebhookAttempt{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("webhook_id = ?", id).Delete(&m.WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Delete(&m.Webhook{}, id)
+		deleted = result.RowsAffected
+		return result.Error
+	})
+	if err != nil {
+		return fmt.Errorf("error al eliminar webhook %d: %v", id, err)
+	}
+
+	if deleted == 0 {
+		return fmt.Errorf("webhook con id %d no encontrado", id)
+	}
+
+	return nil
+}
+
+// ========================================
+// WEBHOOK DELIVERY OPERATIONS
+// ========================================
+
+// CreateWebhookDeliveries queues deliveries.
+//
+// Parameters:
+//   - deliveries: Deliveries to insert (will be updated with IDs and timestamps)
+//
+// Returns:
+//   - error: Database error or nil on success
+func CreateWebhookDeliveries(deliveries []m.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	gormDB := db.ORMOpen()
+
+	if err := gormDB.Create(&deliveries).Error; err != nil {
+		return fmt.Errorf("error al encolar entregas de webhook: %v", err)
+	}
+
+	return nil
+}
+
+// GetWebhookDeliveries retrieves one page of the delivery log of a webhook.
+//
+// Parameters:
+//   - params: Parsed list query (see WebhookDeliveryListSchema)
+//   - webhookID: Unique identifier of the webhook
+//
+// Returns:
+//   - *query.Page[m.WebhookDelivery]: Requested page of deliveries with total count and links
+//   - error: Database error or nil on success
+func GetWebhookDeliveries(params *query.Params, webhookID uint) (*query.Page[m.WebhookDelivery], error) {
+	gormDB := db.ORMOpen()
+
+	page, err := query.Find[m.WebhookDelivery](gormDB.Model(&m.WebhookDelivery{}).Where("webhook_id = ?", webhookID), params)
+	if err != nil {
+		return nil, fmt.Errorf("error al leer entregas de webhook: %v", err)
+	}
+
+	return page, nil
+}
+
+// GetWebhookDelivery retrieves a delivery of a webhook.
+//
+// Parameters:
+//   - webhookID: Unique identifier of the webhook
+//   - id: Unique identifier of the delivery
+//
+// Returns:
+//   - *m.WebhookDelivery: Delivery data
+//   - error: Database error or record not found error
+func GetWebhookDelivery(webhookID uint, id uint) (*m.WebhookDelivery, error) {
+	gormDB := db.ORMOpen()
+
+	var delivery m.WebhookDelivery
+	if err := gormDB.Where("webhook_id = ?", webhookID).First(&delivery, id).Error; err != nil {
+		return nil, fmt.Errorf("error al leer entrega %d: %v", id, err)
+	}
+
+	return &delivery, nil
+}
+
+// GetWebhookDeliveriesByID retrieves deliveries by ID.
+//
+// Parameters:
+//   - ids: Unique identifiers of the deliveries
+//
+// Returns:
+//   - []m.WebhookDelivery: Deliveries ordered by ID
+//   - error: Database error or nil on success
+func GetWebhookDeliveriesByID(ids []uint) ([]m.WebhookDelivery, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	gormDB := db.ORMOpen()
+
+	var deliveries []m.WebhookDelivery
+	if err := gormDB.Where("id IN ?", ids).Order("id").Find(&deliveries).Error; err != nil {
+		return nil, fmt.Errorf("error al leer entregas de webhook: %v", err)
+	}
+
+	return deliveries, nil
+}
+
+// GetDueWebhookDeliveries retrieves the pending deliveries of active webhooks whose attempt is due.
+//
+// Parameters:
+//   - now: Reference time
+//   - limit: Maximum number of deliveries
+//
+// Returns:
+//   - []m.WebhookDelivery: Due deliveries, longest waiting first
+//   - error: Database error or nil on success
+func GetDueWebhookDeliveries(now time.Time, limit int) ([]m.WebhookDelivery, error) {
+	gormDB := db.ORMOpen()
+
+	var deliveries []m.WebhookDelivery
+	result := gormDB.Joins("JOIN Webhooks ON Webhooks.id = Webhook_Deliveries.webhook_id").
+		Where("Webhooks.active = ? AND Webhook_Deliveries.status = ? AND Webhook_Deliveries.next_attempt_at <= ?", true, m.WebhookDeliveryPending, now).
+		Order("Webhook_Deliveries.next_attempt_at, Webhook_Deliveries.id").
+		Limit(limit).
+		Find(&deliveries)
+	if result.Error != nil {
+		return nil, fmt.Errorf("error al buscar entregas de webhook pendientes: %v", result.Error)
+	}
+
+	return deliveries, nil
+}
+
+// ClaimWebhookDelivery reserves a due delivery for one attempt, so concurrent workers never send it twice.
+// The claim moves the next attempt to leaseUntil; recording the attempt replaces it.
+//
+// Parameters:
+//   - id: Unique identifier of the delivery
+//   - now: Reference time (the attempt must be due)
+//   - leaseUntil: When the delivery becomes due again if the worker stops before recording the attempt
+//
+// Returns:
+//   - bool: Whether the delivery was claimed (false if another worker claimed it or it is no longer pending)
+//   - error: Database error or nil on success
+func ClaimWebhookDelivery(id uint, now time.Time, leaseUntil time.Time) (bool, error) {
+	gormDB := db.ORMOpen()
+
+	result := gormDB.Model(&m.WebhookDelivery{}).
+		Where("id = ? AND status = ? AND next_attempt_at <= ?", id, m.WebhookDeliveryPending, now).
+		Update("next_attempt_at", leaseUntil)
+	if result.Error != nil {
+		return false, fmt.Errorf("error al reservar entrega %d: %v", id, result.Error)
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// RecordWebhookAttempt stores an attempt and the resulting state of its delivery, in one transaction.
+//
+// Parameters:
+//   - delivery: Delivery with its new Status, Attempts, NextAttemptAt, LastAttemptAt, ResponseStatus, LastError and DeliveredAt
+//   - attempt: Attempt to insert (will be updated with ID and timestamps)
+//
+// Returns:
+//   - error: Database error or nil on success
+func RecordWebhookAttempt(delivery *m.WebhookDelivery, attempt *m.WebhookAttempt) error {
+	gormDB := db.ORMOpen()
+
+	err := gormDB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(attempt).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(delivery).
+			Select("status", "attempts", "next_attempt_at", "last_attempt_at", "response_status", "last_error", "delivered_at").
+			Updates(delivery).Error
+	})
+	if err != nil {
+		return fmt.Errorf("error al registrar intento de entrega %d: %v", delivery.ID, err)
+	}
+
+	return nil
+}
+
+// GetWebhookAttempts retrieves the attempts of a delivery.
+//
+// Parameters:
+//   - deliveryID: Unique identifier of the delivery
+//
+// Returns:
+//   - []m.WebhookAttempt: Attempts, oldest first
+//   - error: Database error or nil on success
+func GetWebhookAttempts(deliveryID uint) ([]m.WebhookAttempt, error) {
+	gormDB := db.ORMOpen()
+
+	var attempts []m.WebhookAttempt
+	if err := gormDB.Where("delivery_id = ?", deliveryID).Order("id").Find(&attempts).Error; err != nil {
+		return nil, fmt.Errorf("error al leer intentos de la entrega %d: %v", deliveryID, err)
+	}
+
+	return attempts, nil
+}
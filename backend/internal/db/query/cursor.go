@@ -0,0 +1,170 @@
+package query
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// cursorTimeLayout is the format used to encode time values inside cursors.
+// It matches the MySQL DATETIME literal format so values compare correctly.
+const cursorTimeLayout = "2006-01-02 15:04:05.999999"
+
+// cursor is the decoded content of a keyset pagination token.
+// Values holds the sort column values of the boundary row, in sort order.
+type cursor struct {
+	Values   []any `json:"v"`
+	Previous bool  `json:"p,omitempty"` // true when paging backwards from the boundary row
+}
+
+// encodeCursor serialises a cursor into an opaque URL-safe token.
+func encodeCursor(c cursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor parses an opaque cursor token.
+func decodeCursor(token string) (*cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("cursor inválido")
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var c cursor
+	if err := decoder.Decode(&c); err != nil || len(c.Values) == 0 {
+		return nil, fmt.Errorf("cursor inválido")
+	}
+
+	for i, value := range c.Values {
+		switch v := value.(type) {
+		case json.Number:
+			if n, err := v.Int64(); err == nil {
+				c.Values[i] = n
+			} else if f, err := v.Float64(); err == nil {
+				c.Values[i] = f
+			}
+		case string, bool, nil:
+		default:
+			return nil, fmt.Errorf("cursor inválido")
+		}
+	}
+
+	return &c, nil
+}
+
+// seek restricts tx to the rows strictly after (or before, when paging backwards)
+// the cursor boundary row using the lexicographic order of the sort fields:
+//
+//	(c1 > v1) OR (c1 = v1 AND c2 > v2) OR ...
+//
+// Nullable fields compare NULL explicitly (see seekField), since "c = NULL" and
+// "c > NULL" never match and would end the pages early.
+func (p *Params) seek(tx *gorm.DB) *gorm.DB {
+	var (
+		conditions []string
+		vars       []any
+	)
+
+	for i, field := range p.Sort {
+		// Ascending fields move forward with ">", descending with "<"; backwards paging flips both
+		operator := ">"
+		if field.Desc != p.cursor.Previous {
+			operator = "<"
+		}
+
+		after, afterVars := seekField(field, operator, p.cursor.Values[i])
+		if after == "" {
+			continue
+		}
+
+		var parts []string
+		for j := 0; j < i; j++ {
+			equal, equalVars := seekField(p.Sort[j], "=", p.cursor.Values[j])
+			parts = append(parts, equal)
+			vars = append(vars, equalVars...)
+		}
+		parts = append(parts, after)
+		vars = append(vars, afterVars...)
+
+		conditions = append(conditions, "("+strings.Join(parts, " AND ")+")")
+	}
+
+	if len(conditions) == 0 {
+		return tx.Where("1 = 0")
+	}
+
+	return tx.Where(strings.Join(conditions, " OR "), vars...)
+}
+
+// seekField compares a sort field with its cursor value using "=", ">" or "<".
+// It returns an empty condition when no row can be after the value.
+//
+// NULL values are first in ascending order and last in descending order (see Params.order):
+//   - Equal to NULL: "c IS NULL"
+//   - After NULL ascending: every non-NULL value; descending: nothing
+//   - After a value descending: smaller values and NULL
+func seekField(field SortField, operator string, value any) (string, []any) {
+	column := clause.Column{Name: field.Column}
+	if !field.Nullable {
+		return "? " + operator + " ?", []any{column, value}
+	}
+
+	if value == nil {
+		switch operator {
+		case "=":
+			return "? IS NULL", []any{column}
+		case ">":
+			return "? IS NOT NULL", []any{column}
+		default:
+			return "", nil
+		}
+	}
+
+	if operator == "<" {
+		return "(? < ? OR ? IS NULL)", []any{column, value, column}
+	}
+
+	return "? " + operator + " ?", []any{column, value}
+}
+
+// cursorFor builds the cursor token pointing at item.
+func cursorFor[T any](tx *gorm.DB, item *T, fields []SortField, previous bool) (string, error) {
+	stmt := &gorm.Statement{DB: tx}
+	if err := stmt.Parse(item); err != nil {
+		return "", fmt.Errorf("error al generar cursor: %v", err)
+	}
+
+	values := make([]any, 0, len(fields))
+	for _, field := range fields {
+		schemaField := stmt.Schema.LookUpField(field.Column)
+		if schemaField == nil {
+			return "", fmt.Errorf("error al generar cursor: columna %s desconocida", field.Column)
+		}
+
+		value, _ := schemaField.ValueOf(context.Background(), reflect.ValueOf(item).Elem())
+		// Nullable columns are pointers: store the value they point to, or null
+		if rv := reflect.ValueOf(value); rv.Kind() == reflect.Pointer {
+			value = nil
+			if !rv.IsNil() {
+				value = rv.Elem().Interface()
+			}
+		}
+		if t, ok := value.(time.Time); ok {
+			value = t.Local().Format(cursorTimeLayout)
+		}
+		values = append(values, value)
+	}
+
+	return encodeCursor(cursor{Values: values, Previous: previous}), nil
+}
@@ -0,0 +1,217 @@
+package query
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ========================================
+// FILTER CONSTRUCTORS
+// ========================================
+
+// Equals filters rows where column equals the value.
+// A comma-separated value matches any of the listed values.
+func Equals(column string) FilterFunc {
+	return func(value string) (Scope, error) {
+		values := strings.Split(value, ",")
+		if len(values) == 1 {
+			return where("? = ?", clause.Column{Name: column}, value), nil
+		}
+
+		return where("? IN ?", clause.Column{Name: column}, values), nil
+	}
+}
+
+// OneOf filters rows where column equals the value, restricted to an allowed set.
+// A comma-separated value matches any of the listed values.
+func OneOf(column string, allowed ...string) FilterFunc {
+	return func(value string) (Scope, error) {
+		values := strings.Split(value, ",")
+		for _, v := range values {
+			if !contains(allowed, v) {
+				return nil, fmt.Errorf("valor no permitido para %s: %s (permitidos: %s)", column, v, strings.Join(allowed, ", "))
+			}
+		}
+
+		return where("? IN ?", clause.Column{Name: column}, values), nil
+	}
+}
+
+// Contains filters rows where column contains the value (case-insensitive by collation).
+// LIKE wildcards in the value are escaped.
+func Contains(column string) FilterFunc {
+	return func(value string) (Scope, error) {
+		escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+		return where("? LIKE ?", clause.Column{Name: column}, "%"+escaped+"%"), nil
+	}
+}
+
+// Bool filters rows where a boolean column matches the value ("true"/"false"/"1"/"0").
+func Bool(column string) FilterFunc {
+	return func(value string) (Scope, error) {
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("valor booleano inválido para %s: %s", column, value)
+		}
+
+		return where("? = ?", clause.Column{Name: column}, b), nil
+	}
+}
+
+// Uint filters rows where an integer ID column equals the value.
+func Uint(column string) FilterFunc {
+	return func(value string) (Scope, error) {
+		id, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("valor numérico inválido para %s: %s", column, value)
+		}
+
+		return where("? = ?", clause.Column{Name: column}, id), nil
+	}
+}
+
+// MinAge filters rows whose age in whole years, computed from a birth date column, is at least the value.
+func MinAge(birthDateColumn string) FilterFunc {
+	return func(value string) (Scope, error) {
+		years, err := parseYears(value)
+		if err != nil {
+			return nil, err
+		}
+
+		limit := time.Now().AddDate(-years, 0, 0)
+		return where("? <= ?", clause.Column{Name: birthDateColumn}, limit), nil
+	}
+}
+
+// MaxAge filters rows whose age in whole years, computed from a birth date column, is at most the value.
+func MaxAge(birthDateColumn string) FilterFunc {
+	return func(value string) (Scope, error) {
+		years, err := parseYears(value)
+		if err != nil {
+			return nil, err
+		}
+
+		limit := time.Now().AddDate(-(years + 1), 0, 0)
+		return where("? > ?", clause.Column{Name: birthDateColumn}, limit), nil
+	}
+}
+
+// DateFrom filters rows where a date column is on or after the value (YYYY-MM-DD).
+func DateFrom(column string) FilterFunc {
+	return func(value string) (Scope, error) {
+		date, err := time.ParseInLocation(time.DateOnly, value, time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("fecha inválida para %s: %s (formato YYYY-MM-DD)", column, value)
+		}
+
+		return where("? >= ?", clause.Column{Name: column}, date), nil
+	}
+}
+
+// DateTo filters rows where a date column is on or before the value (YYYY-MM-DD, inclusive).
+func DateTo(column string) FilterFunc {
+	return func(value string) (Scope, error) {
+		date, err := time.ParseInLocation(time.DateOnly, value, time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("fecha inválida para %s: %s (formato YYYY-MM-DD)", column, value)
+		}
+
+		return where("? < ?", clause.Column{Name: column}, date.AddDate(0, 0, 1)), nil
+	}
+}
+
+// Near filters rows whose coordinates fall within a radius of a point.
+// The value is "lat,lng,radius_km" (radius up to 500 km). A bounding box is used,
+// so rows slightly outside the circle near its corners are also returned.
+// The box is clamped at the poles and split in two when it crosses the ±180° meridian.
+func Near(latColumn string, lngColumn string) FilterFunc {
+	return func(value string) (Scope, error) {
+		parts := strings.Split(value, ",")
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("valor inválido para near: %s (formato lat,lng,radio_km)", value)
+		}
+
+		var coords [3]float64
+		for i, part := range parts {
+			f, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+			if err != nil {
+				return nil, fmt.Errorf("valor inválido para near: %s (formato lat,lng,radio_km)", value)
+			}
+			coords[i] = f
+		}
+
+		lat, lng, radius := coords[0], coords[1], coords[2]
+		if lat < -90 || lat > 90 || lng < -180 || lng > 180 || radius <= 0 || radius > 500 {
+			return nil, fmt.Errorf("valor fuera de rango para near: %s", value)
+		}
+
+		// One degree of latitude is ~111 km; longitude degrees shrink with the latitude
+		latDelta := radius / 111.0
+		minLat, maxLat := math.Max(lat-latDelta, -90), math.Min(lat+latDelta, 90)
+		latitude := clause.Column{Name: latColumn}
+		longitude := clause.Column{Name: lngColumn}
+
+		// A box reaching a pole covers every longitude
+		if minLat == -90 || maxLat == 90 {
+			return where("? BETWEEN ? AND ?", latitude, minLat, maxLat), nil
+		}
+
+		lngDelta := radius / (111.0 * math.Cos(lat*math.Pi/180))
+		if lngDelta >= 180 {
+			return where("? BETWEEN ? AND ?", latitude, minLat, maxLat), nil
+		}
+
+		minLng, maxLng := lng-lngDelta, lng+lngDelta
+		switch {
+		case minLng < -180:
+			return where("? BETWEEN ? AND ? AND (? BETWEEN ? AND 180 OR ? BETWEEN -180 AND ?)",
+				latitude, minLat, maxLat,
+				longitude, minLng+360,
+				longitude, maxLng,
+			), nil
+		case maxLng > 180:
+			return where("? BETWEEN ? AND ? AND (? BETWEEN ? AND 180 OR ? BETWEEN -180 AND ?)",
+				latitude, minLat, maxLat,
+				longitude, minLng,
+				longitude, maxLng-360,
+			), nil
+		}
+
+		return where("? BETWEEN ? AND ? AND ? BETWEEN ? AND ?",
+			latitude, minLat, maxLat,
+			longitude, minLng, maxLng,
+		), nil
+	}
+}
+
+func parseYears(value string) (int, error) {
+	years, err := strconv.Atoi(value)
+	if err != nil || years < 0 || years > 100 {
+		return 0, fmt.Errorf("edad inválida: %s", value)
+	}
+
+	return years, nil
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// where builds a scope adding a parameterised WHERE condition.
+func where(query string, args ...any) Scope {
+	return func(tx *gorm.DB) *gorm.DB {
+		return tx.Where(query, args...)
+	}
+}
@@ -0,0 +1,153 @@
+package query
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+
+	"gorm.io/gorm"
+)
+
+// Page is the standard paged list response.
+//
+// Fields:
+//   - Items: Rows of the current page
+//   - Total: Number of rows matching the filters across all pages
+//   - Page: Current page number (offset pagination only)
+//   - PageSize: Maximum number of rows per page
+//   - Next/Prev: Relative links to the adjacent pages, empty when there is none
+//   - NextCursor/PrevCursor: Keyset tokens for the adjacent pages (cursor pagination only)
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	Total      int64  `json:"total"`
+	Page       int    `json:"page,omitempty"`
+	PageSize   int    `json:"page_size"`
+	Next       string `json:"next,omitempty"`
+	Prev       string `json:"prev,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+}
+
+// Find runs a paged list query.
+//
+// Process:
+// 1. Applies the request filters to base and counts the matching rows
+// 2. Applies sorting and either offset or keyset pagination
+// 3. Loads one extra row to detect whether another page exists
+// 4. Builds next/prev links and cursors
+//
+// Parameters:
+//   - base: Base query with the model set (e.g. gormDB.Model(&m.Pet{})) and any fixed conditions
+//   - params: Parsed list query
+//   - scopes: Extra scopes applied only when loading rows (e.g. Preload)
+//
+// Returns:
+//   - *Page[T]: Requested page with totals and links
+//   - error: Database error
+func Find[T any](base *gorm.DB, params *Params, scopes ...func(*gorm.DB) *gorm.DB) (*Page[T], error) {
+	filtered := params.Filter(base)
+
+	var total int64
+	if err := filtered.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, fmt.Errorf("error al contar registros: %v", err)
+	}
+
+	rows := filtered.Session(&gorm.Session{}).Scopes(scopes...).Limit(params.PageSize + 1)
+
+	previous := params.cursor != nil && params.cursor.Previous
+	if params.cursorMode {
+		if params.cursor != nil {
+			rows = params.seek(rows)
+		}
+		rows = params.order(rows, previous)
+	} else {
+		rows = params.order(rows, false).Offset((params.Page - 1) * params.PageSize)
+	}
+
+	var items []T
+	if err := rows.Find(&items).Error; err != nil {
+		return nil, fmt.Errorf("error al leer registros: %v", err)
+	}
+
+	hasMore := len(items) > params.PageSize
+	if hasMore {
+		items = items[:params.PageSize]
+	}
+
+	// Backwards pages are loaded in reverse order
+	if previous {
+		slices.Reverse(items)
+	}
+
+	page := &Page[T]{
+		Items:    items,
+		Total:    total,
+		PageSize: params.PageSize,
+	}
+	if page.Items == nil {
+		page.Items = []T{}
+	}
+
+	if !params.cursorMode {
+		page.Page = params.Page
+		if hasMore {
+			page.Next = params.link(map[string]string{"page": strconv.Itoa(params.Page + 1)})
+		}
+		if params.Page > 1 {
+			page.Prev = params.link(map[string]string{"page": strconv.Itoa(params.Page - 1)})
+		}
+		return page, nil
+	}
+
+	if len(items) == 0 {
+		return page, nil
+	}
+
+	// Forward: more rows after the last one exist if we over-fetched, or if we came from a later page
+	if (!previous && hasMore) || previous {
+		token, err := cursorFor(base, &items[len(items)-1], params.Sort, false)
+		if err != nil {
+			return nil, err
+		}
+		page.NextCursor = token
+		page.Next = params.link(map[string]string{"cursor": token})
+	}
+
+	// Backward: rows before the first one exist if we came from an earlier page, or over-fetched backwards
+	if (!previous && params.cursor != nil) || (previous && hasMore) {
+		token, err := cursorFor(base, &items[0], params.Sort, true)
+		if err != nil {
+			return nil, err
+		}
+		page.PrevCursor = token
+		page.Prev = params.link(map[string]string{"cursor": token})
+	}
+
+	return page, nil
+}
+
+// MapPage converts the items of a page while keeping its totals and links.
+//
+// Parameters:
+//   - page: Source page
+//   - fn: Conversion applied to every item
+//
+// Returns:
+//   - *Page[U]: Page with converted items
+func MapPage[T any, U any](page *Page[T], fn func(T) U) *Page[U] {
+	items := make([]U, 0, len(page.Items))
+	for _, item := range page.Items {
+		items = append(items, fn(item))
+	}
+
+	return &Page[U]{
+		Items:      items,
+		Total:      page.Total,
+		Page:       page.Page,
+		PageSize:   page.PageSize,
+		Next:       page.Next,
+		Prev:       page.Prev,
+		NextCursor: page.NextCursor,
+		PrevCursor: page.PrevCursor,
+	}
+}
@@ -0,0 +1,265 @@
+// Package query implements the shared list query layer used by every list endpoint.
+// This layer is responsible for:
+// - Parsing pagination (page, page_size or cursor), sorting and filter parameters
+// - Validating every sort field and filter against a per-resource allowlist (Schema)
+// - Translating the parameters into parameterised GORM clauses
+// - Building paged responses with total counts and next/prev links
+//
+// Query Parameters:
+//   - page, page_size: Offset pagination (page starts at 1, page_size defaults to 20, max 100)
+//   - cursor: Keyset pagination token returned in next_cursor/prev_cursor (takes precedence over page)
+//   - sort: Comma-separated fields, prefixed with "-" for descending order (e.g. "-crt_date,name")
+//   - any filter name declared in the resource Schema
+package query
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// DefaultPageSize is used when page_size is not provided.
+	DefaultPageSize = 20
+
+	// MaxPageSize is the largest page_size accepted.
+	MaxPageSize = 100
+)
+
+// reservedParams are query parameters handled by the query layer itself and never treated as filters.
+var reservedParams = map[string]bool{"page": true, "page_size": true, "cursor": true, "sort": true}
+
+// SortColumn maps a public sort field to a database column.
+// Invert reverses the direction, e.g. sorting by "age" ascending orders by birth_date descending.
+// Nullable must be set for columns that may hold NULL, so ordering and cursors place NULL
+// values explicitly: first in ascending order and last in descending order.
+type SortColumn struct {
+	Column   string
+	Invert   bool
+	Nullable bool
+}
+
+// Scope modifies a query, typically by adding parameterised conditions.
+type Scope = func(*gorm.DB) *gorm.DB
+
+// FilterFunc validates a raw filter value and returns the scope that applies it.
+// Scopes must only use parameter binding for values.
+type FilterFunc func(value string) (Scope, error)
+
+// Schema is the allowlist of sort fields and filters accepted by a list endpoint.
+type Schema struct {
+	Sorts       map[string]SortColumn // Public sort field name to column
+	Filters     map[string]FilterFunc // Query parameter name to filter
+	DefaultSort string                // Sort used when none is requested (same syntax as the sort parameter)
+	TieBreaker  string                // Unique column appended to every sort for stable paging (default "id")
+	Extra       []string              // Parameters read by the caller itself (kept in links, never treated as filters)
+}
+
+// SortField is a resolved sort instruction.
+type SortField struct {
+	Column   string
+	Desc     bool
+	Nullable bool
+}
+
+// Params holds a parsed and validated list query.
+type Params struct {
+	Page     int
+	PageSize int
+	Sort     []SortField
+
+	cursor     *cursor
+	cursorMode bool
+	filters    []Scope
+	path       string
+	values     url.Values
+}
+
+// Parse validates list query parameters against schema.
+//
+// Parameters:
+//   - path: Request path used to build next/prev links (e.g. "/api/pets")
+//   - values: Raw query parameters of the request
+//   - schema: Allowlist of sort fields and filters for the resource
+//
+// Returns:
+//   - *Params: Parsed query ready to be applied
+//   - error: Validation error describing the offending parameter
+func Parse(path string, values url.Values, schema Schema) (*Params, error) {
+	params := &Params{
+		Page:     1,
+		PageSize: DefaultPageSize,
+		path:     path,
+		values:   values,
+	}
+
+	// Pagination
+	if raw := values.Get("page_size"); raw != "" {
+		size, err := strconv.Atoi(raw)
+		if err != nil || size < 1 || size > MaxPageSize {
+			return nil, fmt.Errorf("page_size debe estar entre 1 y %d", MaxPageSize)
+		}
+		params.PageSize = size
+	}
+
+	if raw := values.Get("page"); raw != "" {
+		page, err := strconv.Atoi(raw)
+		if err != nil || page < 1 {
+			return nil, fmt.Errorf("page debe ser un entero positivo")
+		}
+		params.Page = page
+	}
+
+	if _, ok := values["cursor"]; ok {
+		params.cursorMode = true
+		if raw := values.Get("cursor"); raw != "" {
+			decoded, err := decodeCursor(raw)
+			if err != nil {
+				return nil, err
+			}
+			params.cursor = decoded
+		}
+	}
+
+	// Sorting
+	sortExpr := values.Get("sort")
+	if sortExpr == "" {
+		sortExpr = schema.DefaultSort
+	}
+
+	fields, err := parseSort(sortExpr, schema)
+	if err != nil {
+		return nil, err
+	}
+	params.Sort = fields
+
+	if params.cursor != nil && len(params.cursor.Values) != len(params.Sort) {
+		return nil, fmt.Errorf("cursor no corresponde con la ordenación solicitada")
+	}
+
+	// Filters, applied in a deterministic order
+	names := make([]string, 0, len(values))
+	for name := range values {
+		if !reservedParams[name] && !contains(schema.Extra, name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		filter, ok := schema.Filters[name]
+		if !ok {
+			return nil, fmt.Errorf("filtro no permitido: %s", name)
+		}
+
+		for _, value := range values[name] {
+			if value == "" {
+				continue
+			}
+			scope, err := filter(value)
+			if err != nil {
+				return nil, err
+			}
+			params.filters = append(params.filters, scope)
+		}
+	}
+
+	return params, nil
+}
+
+// parseSort resolves a sort expression against the schema allowlist and appends the tie breaker.
+func parseSort(expr string, schema Schema) ([]SortField, error) {
+	tieBreaker := schema.TieBreaker
+	if tieBreaker == "" {
+		tieBreaker = "id"
+	}
+
+	var fields []SortField
+	hasTieBreaker := false
+
+	for _, name := range strings.Split(expr, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		desc := strings.HasPrefix(name, "-")
+		name = strings.TrimPrefix(name, "-")
+
+		column, ok := schema.Sorts[name]
+		if !ok {
+			return nil, fmt.Errorf("campo de ordenación no permitido: %s", name)
+		}
+
+		fields = append(fields, SortField{Column: column.Column, Desc: desc != column.Invert, Nullable: column.Nullable})
+		hasTieBreaker = hasTieBreaker || column.Column == tieBreaker
+	}
+
+	if !hasTieBreaker {
+		fields = append(fields, SortField{Column: tieBreaker})
+	}
+
+	return fields, nil
+}
+
+// Filter applies the selected filters to tx. Use it to build queries that need
+// the request filters but not pagination (e.g. aggregates).
+//
+// Parameters:
+//   - tx: Base query (typically gormDB.Model(&Entity{}))
+//
+// Returns:
+//   - *gorm.DB: Filtered query
+func (p *Params) Filter(tx *gorm.DB) *gorm.DB {
+	return tx.Scopes(p.filters...)
+}
+
+// order applies the sort fields to tx, optionally reversing every direction.
+// Nullable columns are preceded by "column IS NULL" so NULL values come first in ascending
+// order and last in descending order on every database.
+func (p *Params) order(tx *gorm.DB, reverse bool) *gorm.DB {
+	columns := make([]clause.OrderByColumn, 0, len(p.Sort))
+	for _, field := range p.Sort {
+		desc := field.Desc != reverse
+		if field.Nullable {
+			columns = append(columns, clause.OrderByColumn{
+				Column: clause.Column{Name: tx.Statement.Quote(field.Column) + " IS NULL", Raw: true},
+				Desc:   !desc,
+			})
+		}
+		columns = append(columns, clause.OrderByColumn{
+			Column: clause.Column{Name: field.Column},
+			Desc:   desc,
+		})
+	}
+
+	return tx.Clauses(clause.OrderBy{Columns: columns})
+}
+
+// CursorMode reports whether the request asked for keyset pagination.
+func (p *Params) CursorMode() bool {
+	return p.cursorMode
+}
+
+// link builds a relative URL for the same request with the given parameter overrides.
+func (p *Params) link(overrides map[string]string) string {
+	values := url.Values{}
+	for key, list := range p.values {
+		if key == "page" || key == "cursor" {
+			continue
+		}
+		values[key] = list
+	}
+
+	for key, value := range overrides {
+		values.Set(key, value)
+	}
+	values.Set("page_size", strconv.Itoa(p.PageSize))
+
+	return p.path + "?" + values.Encode()
+}
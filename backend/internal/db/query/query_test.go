@@ -0,0 +1,344 @@
+package query
+
+import (
+	"encoding/base64"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+var testSchema = Schema{
+	Sorts: map[string]SortColumn{
+		"id":   {Column: "id"},
+		"name": {Column: "name"},
+		"age":  {Column: "birth_date", Invert: true},
+	},
+	Filters: map[string]FilterFunc{
+		"species": OneOf("species", "dog", "cat"),
+		"adopted": Bool("is_adopted"),
+	},
+	DefaultSort: "name",
+	Extra:       []string{"q"},
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		wantErr string
+	}{
+		{name: "defaults", query: ""},
+		{name: "allowed sort and filters", query: "sort=-age,name&species=dog,cat&adopted=false"},
+		{name: "extra parameter", query: "q=luna"},
+		{name: "empty filter value is ignored", query: "species="},
+		{name: "offset pagination", query: "page=3&page_size=100"},
+		{name: "first cursor page", query: "cursor="},
+		{name: "unknown sort field", query: "sort=password", wantErr: "campo de ordenación no permitido: password"},
+		{name: "unknown descending sort field", query: "sort=name,-Session_ID", wantErr: "campo de ordenación no permitido: Session_ID"},
+		{name: "column name instead of sort field", query: "sort=birth_date", wantErr: "campo de ordenación no permitido: birth_date"},
+		{name: "unknown filter", query: "adopt_user_id=1", wantErr: "filtro no permitido: adopt_user_id"},
+		{name: "filter value not allowed", query: "species=dragon", wantErr: "valor no permitido para species"},
+		{name: "invalid filter value", query: "adopted=maybe", wantErr: "valor booleano inválido"},
+		{name: "page size too large", query: "page_size=101", wantErr: "page_size debe estar entre 1 y 100"},
+		{name: "page size zero", query: "page_size=0", wantErr: "page_size debe estar entre 1 y 100"},
+		{name: "negative page", query: "page=-1", wantErr: "page debe ser un entero positivo"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			params, err := Parse("/api/pets", values, testSchema)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Parse(%q) error = %v", tt.query, err)
+				}
+				if params == nil {
+					t.Fatalf("Parse(%q) returned nil params", tt.query)
+				}
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Parse(%q) error = %v, want %q", tt.query, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseSort(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+		want []SortField
+	}{
+		{name: "tie breaker appended", expr: "name", want: []SortField{{Column: "name"}, {Column: "id"}}},
+		{name: "descending", expr: "-name", want: []SortField{{Column: "name", Desc: true}, {Column: "id"}}},
+		{name: "inverted column", expr: "age", want: []SortField{{Column: "birth_date", Desc: true}, {Column: "id"}}},
+		{name: "inverted descending column", expr: "-age", want: []SortField{{Column: "birth_date"}, {Column: "id"}}},
+		{name: "tie breaker requested", expr: "-id", want: []SortField{{Column: "id", Desc: true}}},
+		{name: "blank fields skipped", expr: " name , ,", want: []SortField{{Column: "name"}, {Column: "id"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSort(tt.expr, testSchema)
+			if err != nil {
+				t.Fatalf("parseSort(%q) error = %v", tt.expr, err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("parseSort(%q) = %v, want %v", tt.expr, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("parseSort(%q)[%d] = %v, want %v", tt.expr, i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	token := encodeCursor(cursor{Values: []any{"Luna", int64(42)}, Previous: true})
+
+	decoded, err := decodeCursor(token)
+	if err != nil {
+		t.Fatalf("decodeCursor error = %v", err)
+	}
+	if !decoded.Previous || len(decoded.Values) != 2 || decoded.Values[0] != "Luna" || decoded.Values[1] != int64(42) {
+		t.Errorf("decodeCursor = %+v, want Luna, 42 backwards", decoded)
+	}
+
+	values := url.Values{"cursor": {token}, "sort": {"name"}}
+	params, err := Parse("/api/pets", values, testSchema)
+	if err != nil {
+		t.Fatalf("Parse with cursor error = %v", err)
+	}
+	if !params.CursorMode() {
+		t.Error("CursorMode() = false, want true")
+	}
+}
+
+func TestDecodeCursorTampering(t *testing.T) {
+	raw := func(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "not base64", token: "%%%"},
+		{name: "standard base64", token: base64.StdEncoding.EncodeToString([]byte(`{"v":["a?b"]}`))},
+		{name: "not json", token: raw("not json")},
+		{name: "no values", token: raw(`{"v":[]}`)},
+		{name: "missing values", token: raw(`{"p":true}`)},
+		{name: "object value", token: raw(`{"v":[{"$gt":""}]}`)},
+		{name: "array value", token: raw(`{"v":[[1,2]]}`)},
+		{name: "values not a list", token: raw(`{"v":"1 OR 1=1"}`)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := decodeCursor(tt.token); err == nil {
+				t.Errorf("decodeCursor(%q) error = nil, want cursor inválido", tt.token)
+			}
+
+			values := url.Values{"cursor": {tt.token}}
+			if _, err := Parse("/api/pets", values, testSchema); err == nil {
+				t.Errorf("Parse with cursor %q error = nil, want cursor inválido", tt.token)
+			}
+		})
+	}
+}
+
+func TestParseCursorSortMismatch(t *testing.T) {
+	// A cursor issued for "name" (name, id) must not be replayed with another sort
+	token := encodeCursor(cursor{Values: []any{"Luna", int64(42)}})
+
+	values := url.Values{"cursor": {token}, "sort": {"-id"}}
+	_, err := Parse("/api/pets", values, testSchema)
+	if err == nil || !strings.Contains(err.Error(), "cursor no corresponde") {
+		t.Fatalf("Parse error = %v, want cursor mismatch", err)
+	}
+}
+
+// dryRunDB returns a MySQL gorm session that builds statements without a database connection.
+func dryRunDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(mysql.New(mysql.Config{SkipInitializeWithVersion: true}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return db
+}
+
+type placement struct {
+	ID      uint
+	EndDate *time.Time
+}
+
+func TestSeekNullable(t *testing.T) {
+	sortAsc := []SortField{{Column: "end_date", Nullable: true}, {Column: "id"}}
+	sortDesc := []SortField{{Column: "end_date", Desc: true, Nullable: true}, {Column: "id", Desc: true}}
+
+	tests := []struct {
+		name   string
+		sort   []SortField
+		cursor cursor
+		want   string
+	}{
+		{
+			name:   "ascending after null",
+			sort:   sortAsc,
+			cursor: cursor{Values: []any{nil, int64(7)}},
+			want:   "WHERE (`end_date` IS NOT NULL) OR (`end_date` IS NULL AND `id` > 7) ORDER BY `end_date` IS NULL DESC,`end_date`,`id`",
+		},
+		{
+			name:   "ascending after value",
+			sort:   sortAsc,
+			cursor: cursor{Values: []any{"2026-01-01 00:00:00", int64(7)}},
+			want:   "WHERE (`end_date` > '2026-01-01 00:00:00') OR (`end_date` = '2026-01-01 00:00:00' AND `id` > 7) ORDER BY `end_date` IS NULL DESC,`end_date`,`id`",
+		},
+		{
+			name:   "ascending before value",
+			sort:   sortAsc,
+			cursor: cursor{Values: []any{"2026-01-01 00:00:00", int64(7)}, Previous: true},
+			want:   "WHERE ((`end_date` < '2026-01-01 00:00:00' OR `end_date` IS NULL)) OR (`end_date` = '2026-01-01 00:00:00' AND `id` < 7) ORDER BY `end_date` IS NULL,`end_date` DESC,`id` DESC",
+		},
+		{
+			name:   "ascending before null",
+			sort:   sortAsc,
+			cursor: cursor{Values: []any{nil, int64(7)}, Previous: true},
+			want:   "WHERE (`end_date` IS NULL AND `id` < 7) ORDER BY `end_date` IS NULL,`end_date` DESC,`id` DESC",
+		},
+		{
+			name:   "descending after value",
+			sort:   sortDesc,
+			cursor: cursor{Values: []any{"2026-01-01 00:00:00", int64(7)}},
+			want:   "WHERE ((`end_date` < '2026-01-01 00:00:00' OR `end_date` IS NULL)) OR (`end_date` = '2026-01-01 00:00:00' AND `id` < 7) ORDER BY `end_date` IS NULL,`end_date` DESC,`id` DESC",
+		},
+		{
+			name:   "descending after null",
+			sort:   sortDesc,
+			cursor: cursor{Values: []any{nil, int64(7)}},
+			want:   "WHERE (`end_date` IS NULL AND `id` < 7) ORDER BY `end_date` IS NULL,`end_date` DESC,`id` DESC",
+		},
+		{
+			name:   "not nullable",
+			sort:   []SortField{{Column: "id"}},
+			cursor: cursor{Values: []any{int64(7)}},
+			want:   "WHERE (`id` > 7) ORDER BY `id`",
+		},
+	}
+
+	db := dryRunDB(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := &Params{Sort: tt.sort, cursor: &tt.cursor}
+			got := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+				return params.order(params.seek(tx.Model(&placement{})), tt.cursor.Previous).Find(&[]placement{})
+			})
+			if !strings.HasSuffix(got, tt.want) {
+				t.Errorf("seek SQL = %s, want suffix %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCursorForNullable(t *testing.T) {
+	end := time.Date(2026, 1, 1, 0, 0, 0, 0, time.Local)
+	fields := []SortField{{Column: "end_date", Nullable: true}, {Column: "id"}}
+
+	tests := []struct {
+		name string
+		item placement
+		want []any
+	}{
+		{name: "null", item: placement{ID: 7}, want: []any{nil, int64(7)}},
+		{name: "value", item: placement{ID: 7, EndDate: &end}, want: []any{"2026-01-01 00:00:00", int64(7)}},
+	}
+
+	db := dryRunDB(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := cursorFor(db, &tt.item, fields, false)
+			if err != nil {
+				t.Fatalf("cursorFor error = %v", err)
+			}
+			decoded, err := decodeCursor(token)
+			if err != nil {
+				t.Fatalf("decodeCursor error = %v", err)
+			}
+			if !reflect.DeepEqual(decoded.Values, tt.want) {
+				t.Errorf("cursor values = %v, want %v", decoded.Values, tt.want)
+			}
+		})
+	}
+}
+
+func TestNear(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "inside the meridians",
+			value: "0,0,111",
+			want:  "WHERE `lat` BETWEEN -1 AND 1 AND `lng` BETWEEN -1 AND 1",
+		},
+		{
+			name:  "crossing 180 east",
+			value: "0,179.5,111",
+			want:  "WHERE `lat` BETWEEN -1 AND 1 AND (`lng` BETWEEN 178.5 AND 180 OR `lng` BETWEEN -180 AND -179.5)",
+		},
+		{
+			name:  "crossing 180 west",
+			value: "0,-179.5,111",
+			want:  "WHERE `lat` BETWEEN -1 AND 1 AND (`lng` BETWEEN 179.5 AND 180 OR `lng` BETWEEN -180 AND -178.5)",
+		},
+		{
+			name:  "reaching a pole",
+			value: "89.5,10,111",
+			want:  "WHERE `lat` BETWEEN 88.5 AND 90",
+		},
+		{
+			name:  "at the pole",
+			value: "-90,0,10",
+			want:  "WHERE `lat` BETWEEN -90 AND -89.90990990990991",
+		},
+		{name: "latitude out of range", value: "91,0,10", wantErr: true},
+		{name: "radius too large", value: "0,0,501", wantErr: true},
+		{name: "missing radius", value: "0,0", wantErr: true},
+	}
+
+	db := dryRunDB(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scope, err := Near("lat", "lng")(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Near(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			got := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+				return tx.Model(&placement{}).Scopes(scope).Find(&[]placement{})
+			})
+			if !strings.HasSuffix(got, tt.want) {
+				t.Errorf("Near(%q) SQL = %s, want suffix %s", tt.value, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+// Package models contains data models for the pet adoption system.
+// These models define the structure of finalised adoptions and the contract templates used for them.
+package models
+
+import "time"
+
+// TableName returns the database table name for the Adoption model.
+// This method implements the GORM Tabler interface to specify custom table names.
+func (Adoption) TableName() string {
+	return "Adoptions"
+}
+
+// Adoption represents a finalised adoption: a pet handed over to its adopter under a signed contract.
+//
+// Database Table: Adoptions
+// Relationships:
+//   - Organization: Many-to-One relationship with Organization (foreign key: OrganizationID)
+//   - Pet: Many-to-One relationship with Pet (foreign key: PetID)
+//   - Adopter: Many-to-One relationship with User (foreign key: AdopterUserID)
+//
+// Business Rules:
+//   - Finalising an adoption marks the pet as adopted by the adopter
+//   - A pet has at most one active adoption (ActivePetID is unique); it ends when the pet leaves the adopted status
+//   - The contract PDF is generated once and never modified; ContractHash (SHA-256) detects later tampering
+//   - The contract is visible to the organisation's staff and to the adopter
+type Adoption struct {
+	ID                  uint            `json:"id" gorm:"primaryKey;autoIncrement"`       // Unique identifier for the adoption
+	OrganizationID      uint            `json:"organization_id" gorm:"not null;index"`    // Organisation that gave the pet in adoption
+	PetID               uint            `json:"pet_id" gorm:"not null;index"`             // Adopted pet
+	ActivePetID         *uint           `json:"-" gorm:"uniqueIndex"`                     // PetID while the pet is still adopted under this adoption, NULL afterwards
+	Pet                 *SimplifiedPet  `json:"pet,omitempty" gorm:"-"`                   // Adopted pet summary (computed)
+	AdopterUserID       uint            `json:"adopter_user_id" gorm:"not null;index"`    // User who adopted the pet
+	Adopter             *SimplifiedUser `json:"adopter,omitempty" gorm:"-"`               // Adopter summary (computed)
+	AdoptionDate        time.Time       `json:"adoption_date" gorm:"type:date;not null"`  // Date the pet was handed over
+	FeeCents            int64           `json:"fee_cents" gorm:"not null;default:0"`      // Adoption fee in cents
+	Currency            string          `json:"currency" gorm:"type:varchar(3);not null"` // ISO 4217 currency code of the fee
+	PaidCents           int64           `json:"paid_cents" gorm:"-"`                      // Fee paid so far minus refunds (computed)
+	Clauses             []string        `json:"clauses" gorm:"serializer:json"`           // Clauses added to the template for this adoption
+	Notes               string          `json:"notes,omitempty" gorm:"type:text"`         // Internal notes (staff only)
+	ContractKey         string          `json:"-" gorm:"type:varchar(255)"`               // Storage key of the contract PDF
+	ContractHash        string          `json:"contract_sha256" gorm:"type:char(64)"`     // SHA-256 of the contract PDF (hex)
+	ContractGeneratedAt *time.Time      `json:"contract_generated_at"`                    // When the contract PDF was generated
+	ContractSentAt      *time.Time      `json:"contract_sent_at"`                         // When the contract was last emailed to the adopter
+	ContractURL         string          `json:"contract_url,omitempty" gorm:"-"`          // Download URL of the contract (computed)
+	CreatedBy           uint            `json:"created_by,omitempty"`                     // Staff user who finalised the adoption (staff only)
+	CrtDate             time.Time       `json:"crt_date" gorm:"autoCreateTime"`           // Record creation timestamp
+	UptDate             time.Time       `json:"upt_date" gorm:"autoUpdateTime"`           // Record last update timestamp
+}
+
+// TableName returns the database table name for the ContractTemplate model.
+// This method implements the GORM Tabler interface to specify custom table names.
+func (ContractTemplate) TableName() string {
+	return "Contract_Templates"
+}
+
+// ContractTemplate represents the adoption contract text of an organisation.
+// Texts are Go text/template templates filled with the contract data, e.g. {{.Pet.Name}} or {{.Adopter.FullName}}.
+//
+// Database Table: Contract_Templates
+// Relationships:
+//   - Organization: One-to-One relationship with Organization (foreign key: OrganizationID)
+//
+// Business Rules:
+//   - Organisations without a template use the default one
+//   - Changing the template never alters contracts already generated
+type ContractTemplate struct {
+	ID             uint      `json:"id" gorm:"primaryKey;autoIncrement"`          // Unique identifier for the template
+	OrganizationID uint      `json:"organization_id" gorm:"not null;uniqueIndex"` // Organisation the template belongs to
+	Title          string    `json:"title" gorm:"type:varchar(200);not null"`     // Contract title
+	Intro          string    `json:"intro" gorm:"type:text"`                      // Opening paragraph (template)
+	Clauses        []string  `json:"clauses" gorm:"serializer:json"`              // Standard clauses (templates)
+	Closing        string    `json:"closing" gorm:"type:text"`                    // Closing paragraph before the signatures (template)
+	UpdatedBy      uint      `json:"updated_by"`                                  // Staff user who last changed the template
+	CrtDate        time.Time `json:"crt_date" gorm:"autoCreateTime"`              // Record creation timestamp
+	UptDate        time.Time `json:"upt_date" gorm:"autoUpdateTime"`              // Record last update timestamp
+}
@@ -0,0 +1,125 @@
+// Package models contains data models for the pet adoption system.
+// These models define the structure of conversation threads between users and shelter staff,
+// their messages and the files attached to them.
+package models
+
+import "time"
+
+// Conversation statuses in the staff inbox.
+const (
+	ConversationStatusOpen   = "open"   // Waiting for an answer or follow-up
+	ConversationStatusClosed = "closed" // Resolved; a new message reopens it
+)
+
+// ConversationStatuses lists every valid conversation status.
+var ConversationStatuses = []string{ConversationStatusOpen, ConversationStatusClosed}
+
+// TableName returns the database table name for the Conversation model.
+// This method implements the GORM Tabler interface to specify custom table names.
+func (Conversation) TableName() string {
+	return "Conversations"
+}
+
+// Conversation represents a message thread between a user and the staff of an organisation,
+// optionally about a pet or an adoption application.
+//
+// Database Table: Conversations
+// Relationships:
+//   - Organization: Many-to-One relationship with Organization (foreign key: OrganizationID)
+//   - User: Many-to-One relationship with User (foreign key: UserID)
+//   - Pet: Optional Many-to-One relationship with Pet (foreign key: PetID)
+//   - Assignee: Optional Many-to-One relationship with User (foreign key: AssignedTo)
+//   - Messages: One-to-Many relationship with Message (foreign key: ConversationID)
+//
+// Business Rules:
+//   - The user side is a single user; the staff side is shared by every staff member of the organisation
+//   - ApplicationID links the conversation to an adoption application (plain reference, no constraint)
+//   - Staff can assign the conversation to a member of the organisation and close it
+//   - A new message reopens a closed conversation
+type Conversation struct {
+	ID             uint            `json:"id" gorm:"primaryKey;autoIncrement"`                   // Unique identifier for the conversation
+	OrganizationID uint            `json:"organization_id" gorm:"not null;index"`                // Organisation whose staff take part
+	UserID         uint            `json:"user_id" gorm:"not null;index"`                        // User taking part
+	User           *SimplifiedUser `json:"user,omitempty" gorm:"-"`                              // User summary (computed)
+	PetID          *uint           `json:"pet_id" gorm:"index"`                                  // Pet the conversation is about (optional)
+	Pet            *SimplifiedPet  `json:"pet,omitempty" gorm:"-"`                               // Pet summary (computed)
+	ApplicationID  *uint           `json:"application_id" gorm:"index"`                          // Adoption application the conversation is about (optional)
+	Subject        string          `json:"subject" gorm:"type:varchar(200);not null"`            // Subject of the thread
+	Status         string          `json:"status" gorm:"type:varchar(10);not null;default:open"` // open or closed
+	AssignedTo     *uint           `json:"assigned_to"`                                          // Staff member handling the conversation (optional)
+	Assignee       *SimplifiedUser `json:"assignee,omitempty" gorm:"-"`                          // Assigned staff member summary (computed)
+	LastMessageAt  time.Time       `json:"last_message_at" gorm:"not null"`                      // When the latest message was sent
+	ClosedAt       *time.Time      `json:"closed_at"`                                            // When the conversation was closed (nil while open)
+	CreatedBy      uint            `json:"created_by"`                                           // User who started the conversation (the user or a staff member)
+	Unread         int64           `json:"unread" gorm:"-"`                                      // Messages from the other side not read by the caller (computed)
+	Messages       []Message       `json:"messages,omitempty" gorm:"-"`                          // Messages, oldest first (computed, detail only)
+	CrtDate        time.Time       `json:"crt_date" gorm:"autoCreateTime"`                       // Record creation timestamp
+	UptDate        time.Time       `json:"upt_date" gorm:"autoUpdateTime"`                       // Record last update timestamp
+}
+
+// IsOpen reports whether the conversation is waiting for an answer or follow-up.
+func (c *Conversation) IsOpen() bool {
+	return c.Status == ConversationStatusOpen
+}
+
+// TableName returns the database table name for the Message model.
+// This method implements the GORM Tabler interface to specify custom table names.
+func (Message) TableName() string {
+	return "Messages"
+}
+
+// Message represents a message posted in a conversation, by the user or by a staff member.
+//
+// Database Table: Messages
+// Relationships:
+//   - Conversation: Many-to-One relationship with Conversation (foreign key: ConversationID)
+//   - Sender: Many-to-One relationship with User (foreign key: SenderID)
+//   - Attachments: One-to-Many relationship with MessageAttachment (foreign key: MessageID)
+//
+// Business Rules:
+//   - ReadAt is the read receipt: set when the other side opens the conversation
+//   - Messages still unread after MESSAGE_NOTIFY_DELAY are notified by email once (NotifiedAt)
+type Message struct {
+	ID             uint                `json:"id" gorm:"primaryKey;autoIncrement"`       // Unique identifier for the message
+	ConversationID uint                `json:"conversation_id" gorm:"not null;index"`    // Conversation the message belongs to
+	SenderID       uint                `json:"sender_id" gorm:"not null"`                // User who wrote the message
+	Sender         *SimplifiedUser     `json:"sender,omitempty" gorm:"-"`                // Sender summary (computed)
+	FromStaff      bool                `json:"from_staff" gorm:"not null;default:false"` // Whether the message was written by the staff side
+	Body           string              `json:"body" gorm:"type:text;not null"`           // Message text
+	ReadAt         *time.Time          `json:"read_at"`                                  // When the other side read the message (nil while unread)
+	NotifiedAt     *time.Time          `json:"-"`                                        // When the unread message was notified by email
+	Attachments    []MessageAttachment `json:"attachments" gorm:"foreignKey:MessageID"`  // Attached files (relationship)
+	CrtDate        time.Time           `json:"crt_date" gorm:"autoCreateTime"`           // Record creation timestamp
+}
+
+// TableName returns the database table name for the MessageAttachment model.
+// This method implements the GORM Tabler interface to specify custom table names.
+func (MessageAttachment) TableName() string {
+	return "Message_Attachments"
+}
+
+// MessageAttachment represents a file attached to a message: a photo or a PDF document.
+//
+// Database Table: Message_Attachments
+// Relationships:
+//   - Message: Many-to-One relationship with Message (foreign key: MessageID)
+//
+// Business Rules:
+//   - Files are stored as uploaded in the configured storage and only served to the participants
+type MessageAttachment struct {
+	ID          uint      `json:"id" gorm:"primaryKey;autoIncrement"`             // Unique identifier for the attachment
+	MessageID   uint      `json:"message_id" gorm:"not null;index"`               // Message the file is attached to
+	FileName    string    `json:"file_name" gorm:"type:varchar(255);not null"`    // Original file name
+	ContentType string    `json:"content_type" gorm:"type:varchar(100);not null"` // MIME type detected from the content
+	Size        int64     `json:"size" gorm:"not null"`                           // File size in bytes
+	StorageKey  string    `json:"-" gorm:"type:varchar(255);not null"`            // Storage key of the file
+	URL         string    `json:"url" gorm:"-"`                                   // Download URL (computed)
+	CrtDate     time.Time `json:"crt_date" gorm:"autoCreateTime"`                 // Record creation timestamp
+}
+
+// MessageUpload is a file to attach to a new message, already read and validated.
+type MessageUpload struct {
+	FileName    string // Original file name
+	ContentType string // MIME type detected from the content
+	Data        []byte // File content
+}
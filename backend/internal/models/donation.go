@@ -0,0 +1,137 @@
+// Package models contains data models for the pet adoption system.
+// These models define the structure of donations, pet sponsorships and donation receipts.
+package models
+
+import "time"
+
+// Donation frequencies.
+const (
+	DonationOneOff  = "one_off" // Single donation
+	DonationMonthly = "monthly" // Repeated every month
+	DonationYearly  = "yearly"  // Repeated every year
+)
+
+// DonationFrequencies lists every valid donation frequency.
+var DonationFrequencies = []string{DonationOneOff, DonationMonthly, DonationYearly}
+
+// Donation statuses.
+const (
+	DonationStatusPending   = "pending"   // Waiting for the first payment
+	DonationStatusActive    = "active"    // Recurring donation with its first payment made
+	DonationStatusCompleted = "completed" // One-off donation paid
+	DonationStatusCancelled = "cancelled" // Cancelled by the donor or the organisation
+)
+
+// DonationStatuses lists every valid donation status.
+var DonationStatuses = []string{
+	DonationStatusPending,
+	DonationStatusActive,
+	DonationStatusCompleted,
+	DonationStatusCancelled,
+}
+
+// TableName returns the database table name for the Donation model.
+// This method implements the GORM Tabler interface to specify custom table names.
+func (Donation) TableName() string {
+	return "Donations"
+}
+
+// Donation represents a one-off or recurring donation of a supporter to an organisation,
+// optionally designated to the care of one of its pets (a sponsorship).
+//
+// Database Table: Donations
+// Relationships:
+//   - Organization: Many-to-One relationship with Organization (foreign key: OrganizationID)
+//   - Donor: Many-to-One relationship with User (foreign key: DonorUserID)
+//   - Pet: Many-to-One relationship with Pet (foreign key: PetID, optional)
+//   - Payments: One-to-Many relationship with Payment (foreign key: DonationID)
+//
+// Business Rules:
+//   - Every charge is a Payment through the payment provider's hosted checkout
+//   - Recurring donations are charged again on NextChargeDate: the donor receives a new checkout link
+//   - Donations designated to a pet add up to the pet's public sponsorship total
+type Donation struct {
+	ID             uint            `json:"id" gorm:"primaryKey;autoIncrement"`                              // Unique identifier for the donation
+	OrganizationID uint            `json:"organization_id" gorm:"not null;index"`                           // Organisation receiving the donation
+	DonorUserID    uint            `json:"donor_user_id" gorm:"not null;index"`                             // User making the donation
+	Donor          *SimplifiedUser `json:"donor,omitempty" gorm:"-"`                                        // Donor summary (computed, staff only)
+	PetID          *uint           `json:"pet_id" gorm:"index"`                                             // Sponsored pet (nil for the organisation in general)
+	Pet            *SimplifiedPet  `json:"pet,omitempty" gorm:"-"`                                          // Sponsored pet summary (computed)
+	Frequency      string          `json:"frequency" gorm:"type:varchar(10);not null"`                      // one_off, monthly or yearly
+	AmountCents    int64           `json:"amount_cents" gorm:"not null"`                                    // Amount of each charge in cents
+	Currency       string          `json:"currency" gorm:"type:varchar(3);not null"`                        // ISO 4217 currency code
+	Status         string          `json:"status" gorm:"type:varchar(20);not null;default:'pending';index"` // Donation status
+	TaxID          string          `json:"tax_id,omitempty" gorm:"type:varchar(20)"`                        // Donor tax ID shown on receipts (optional)
+	Message        string          `json:"message,omitempty" gorm:"type:varchar(500)"`                      // Message of the donor to the organisation
+	NextChargeDate *time.Time      `json:"next_charge_date" gorm:"type:date"`                               // Next charge of recurring donations
+	PaidCents      int64           `json:"paid_cents" gorm:"-"`                                             // Total paid minus refunds (computed)
+	CheckoutURL    string          `json:"checkout_url,omitempty" gorm:"-"`                                 // Checkout of the pending charge (computed)
+	CancelledAt    *time.Time      `json:"cancelled_at"`                                                    // When the donation was cancelled
+	CrtDate        time.Time       `json:"crt_date" gorm:"autoCreateTime"`                                  // Record creation timestamp
+	UptDate        time.Time       `json:"upt_date" gorm:"autoUpdateTime"`                                  // Record last update timestamp
+}
+
+// IsRecurring reports whether the donation is charged periodically.
+func (d Donation) IsRecurring() bool {
+	return d.Frequency == DonationMonthly || d.Frequency == DonationYearly
+}
+
+// NextCharge returns the charge date following the given one.
+func (d Donation) NextCharge(from time.Time) time.Time {
+	if d.Frequency == DonationYearly {
+		return from.AddDate(1, 0, 0)
+	}
+
+	return from.AddDate(0, 1, 0)
+}
+
+// PetSponsorship is the public total of the donations designated to a pet.
+type PetSponsorship struct {
+	Sponsors int64         `json:"sponsors"` // Number of different donors
+	Totals   []MoneyAmount `json:"totals"`   // Amount donated by currency
+}
+
+// MoneyAmount is an amount of money in a currency.
+type MoneyAmount struct {
+	Currency    string `json:"currency"`     // ISO 4217 currency code
+	AmountCents int64  `json:"amount_cents"` // Amount in cents
+}
+
+// TableName returns the database table name for the DonationReceipt model.
+// This method implements the GORM Tabler interface to specify custom table names.
+func (DonationReceipt) TableName() string {
+	return "Donation_Receipts"
+}
+
+// DonationReceipt represents the yearly certificate of the donations a donor made to an organisation.
+//
+// Database Table: Donation_Receipts
+// Relationships:
+//   - Organization: Many-to-One relationship with Organization (foreign key: OrganizationID)
+//   - Donor: Many-to-One relationship with User (foreign key: DonorUserID)
+//
+// Business Rules:
+//   - One receipt per organisation, donor, year and currency, generated once the year is over
+//   - The PDF is kept in storage with its SHA-256, like adoption contracts
+type DonationReceipt struct {
+	ID             uint       `json:"id" gorm:"primaryKey;autoIncrement"`                                        // Unique identifier for the receipt
+	OrganizationID uint       `json:"organization_id" gorm:"not null;uniqueIndex:idx_donation_receipt"`          // Organisation issuing the receipt
+	DonorUserID    uint       `json:"donor_user_id" gorm:"not null;uniqueIndex:idx_donation_receipt"`            // Donor the receipt is issued to
+	Year           int        `json:"year" gorm:"not null;uniqueIndex:idx_donation_receipt"`                     // Year of the donations
+	Currency       string     `json:"currency" gorm:"type:varchar(3);not null;uniqueIndex:idx_donation_receipt"` // ISO 4217 currency code
+	TotalCents     int64      `json:"total_cents" gorm:"not null"`                                               // Total donated in the year, net of refunds
+	Number         string     `json:"number" gorm:"type:varchar(30)"`                                            // Receipt number, e.g. DON-2026-000012
+	FileKey        string     `json:"-" gorm:"type:varchar(255)"`                                                // Storage key of the receipt PDF
+	FileHash       string     `json:"sha256" gorm:"type:char(64)"`                                               // SHA-256 of the receipt PDF (hex)
+	SentAt         *time.Time `json:"sent_at"`                                                                   // When the receipt was emailed to the donor
+	DownloadURL    string     `json:"download_url,omitempty" gorm:"-"`                                           // Download URL of the PDF (computed)
+	CrtDate        time.Time  `json:"crt_date" gorm:"autoCreateTime"`                                            // Record creation timestamp
+}
+
+// DonationYearTotal is the net amount a donor gave to an organisation in a year, in one currency.
+type DonationYearTotal struct {
+	OrganizationID uint
+	DonorUserID    uint
+	Currency       string
+	TotalCents     int64
+}
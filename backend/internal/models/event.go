@@ -0,0 +1,38 @@
+// Package models contains data models for the pet adoption system.
+// These models define the payloads of the real-time events pushed to clients.
+package models
+
+// Adoption process stages reported by application-updated events.
+const (
+	ApplicationEventAdopted      = "adopted"       // The adoption was finalised and the contract generated
+	ApplicationEventContractSent = "contract_sent" // The contract was emailed to the adopter again
+)
+
+// PetEvent is the payload of pet-created and pet-status-changed events.
+// It carries the public pet summary; clients reload the pet for further details.
+type PetEvent struct {
+	PetID          uint   `json:"pet_id"`                    // Pet that changed
+	OrganizationID uint   `json:"organization_id"`           // Organisation that owns the pet
+	Name           string `json:"name"`                      // Pet's name
+	Species        string `json:"species"`                   // Pet's species
+	Status         string `json:"status"`                    // Current adoption status
+	PreviousStatus string `json:"previous_status,omitempty"` // Status before the change (pet-status-changed only)
+}
+
+// ApplicationEvent is the payload of application-updated events, sent to the organisation's staff and the adopter.
+type ApplicationEvent struct {
+	AdoptionID     uint   `json:"adoption_id"`     // Adoption record
+	OrganizationID uint   `json:"organization_id"` // Organisation giving the pet in adoption
+	PetID          uint   `json:"pet_id"`          // Adopted pet
+	UserID         uint   `json:"user_id"`         // Adopter
+	Status         string `json:"status"`          // adopted or contract_sent
+}
+
+// MessageEvent is the payload of new-message events, sent to the organisation's staff and the user of the conversation.
+// The body is not included; clients reload the conversation, which also sets the read receipts.
+type MessageEvent struct {
+	ConversationID uint `json:"conversation_id"` // Conversation the message was posted in
+	MessageID      uint `json:"message_id"`      // New message
+	SenderID       uint `json:"sender_id"`       // Author of the message
+	FromStaff      bool `json:"from_staff"`      // Whether the message was written by the staff side
+}
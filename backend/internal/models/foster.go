@@ -0,0 +1,163 @@
+// Package models contains data models for the pet adoption system.
+// These models define the structure of foster homes, foster placements and the updates fosters post.
+package models
+
+import "time"
+
+// Foster home types.
+const (
+	FosterHomeHouse     = "house"     // House, usually with private outdoor space
+	FosterHomeApartment = "apartment" // Flat or apartment
+	FosterHomeRural     = "rural"     // Farm or rural property
+)
+
+// FosterHomeTypes lists every valid foster home type.
+var FosterHomeTypes = []string{FosterHomeHouse, FosterHomeApartment, FosterHomeRural}
+
+// TableName returns the database table name for the FosterHome model.
+// This method implements the GORM Tabler interface to specify custom table names.
+func (FosterHome) TableName() string {
+	return "Foster_Homes"
+}
+
+// FosterHome represents a family that temporarily houses the organisation's pets.
+// The foster signs in with their own user account to post updates about the pets they care for.
+//
+// Database Table: Foster_Homes
+// Relationships:
+//   - Organization: Many-to-One relationship with Organization (foreign key: OrganizationID)
+//   - User: Many-to-One relationship with User (foreign key: UserID)
+//   - Placements: One-to-Many relationship with FosterPlacement (foreign key: FosterHomeID)
+//
+// Business Rules:
+//   - A user has at most one foster home per organisation
+//   - A home never holds more pets at once than its capacity
+//   - An empty AcceptedSpecies list accepts every species
+//   - Inactive homes keep their history but do not receive new placements
+type FosterHome struct {
+	ID              uint              `json:"id" gorm:"primaryKey;autoIncrement"`                              // Unique identifier for the foster home
+	OrganizationID  uint              `json:"organization_id" gorm:"not null;uniqueIndex:idx_foster_org_user"` // Organisation the home fosters for
+	UserID          uint              `json:"user_id" gorm:"not null;uniqueIndex:idx_foster_org_user"`         // User account of the foster
+	User            *SimplifiedUser   `json:"user,omitempty" gorm:"-"`                                         // Foster user summary (computed)
+	Capacity        int               `json:"capacity" gorm:"not null;default:1"`                              // Maximum number of pets at once
+	AcceptedSpecies []string          `json:"accepted_species" gorm:"serializer:json"`                         // Species the home accepts (empty for any)
+	HomeType        string            `json:"home_type" gorm:"type:varchar(20);not null"`                      // house, apartment or rural
+	HasGarden       bool              `json:"has_garden" gorm:"default:false"`                                 // Whether the home has private outdoor space
+	HasChildren     bool              `json:"has_children" gorm:"default:false"`                               // Whether children live in the home
+	HasOtherPets    bool              `json:"has_other_pets" gorm:"default:false"`                             // Whether the family has pets of their own
+	Address         string            `json:"address" gorm:"type:varchar(255)"`                                // Postal address of the home
+	Phone           string            `json:"phone" gorm:"type:varchar(30)"`                                   // Contact phone
+	Notes           string            `json:"notes" gorm:"type:text"`                                          // Internal notes (staff only)
+	Active          bool              `json:"active" gorm:"not null;default:true"`                             // Whether the home accepts new placements
+	Occupied        int               `json:"occupied" gorm:"-"`                                               // Pets currently placed in the home (computed)
+	FreeCapacity    int               `json:"free_capacity" gorm:"-"`                                          // Pets the home can still take (computed)
+	Placements      []FosterPlacement `json:"placements,omitempty" gorm:"-"`                                   // Current placements (computed)
+	CrtDate         time.Time         `json:"crt_date" gorm:"autoCreateTime"`                                  // Record creation timestamp
+	UptDate         time.Time         `json:"upt_date" gorm:"autoUpdateTime"`                                  // Record last update timestamp
+}
+
+// AcceptsSpecies reports whether the home accepts pets of the given species.
+func (h *FosterHome) AcceptsSpecies(species string) bool {
+	if len(h.AcceptedSpecies) == 0 {
+		return true
+	}
+
+	for _, accepted := range h.AcceptedSpecies {
+		if accepted == species {
+			return true
+		}
+	}
+
+	return false
+}
+
+// TableName returns the database table name for the FosterPlacement model.
+// This method implements the GORM Tabler interface to specify custom table names.
+func (FosterPlacement) TableName() string {
+	return "Foster_Placements"
+}
+
+// FosterPlacement represents a stay of a pet in a foster home.
+//
+// Database Table: Foster_Placements
+// Relationships:
+//   - Pet: Many-to-One relationship with Pet (foreign key: PetID)
+//   - FosterHome: Many-to-One relationship with FosterHome (foreign key: FosterHomeID)
+//
+// Business Rules:
+//   - A placement is current while EndDate is not set
+//   - A pet has at most one current placement
+//   - The pet and the foster home belong to the same organisation
+//   - Adopting a pet ends its current placement
+type FosterPlacement struct {
+	ID              uint           `json:"id" gorm:"primaryKey;autoIncrement"`    // Unique identifier for the placement
+	OrganizationID  uint           `json:"organization_id" gorm:"not null;index"` // Organisation of the pet and the home
+	PetID           uint           `json:"pet_id" gorm:"not null;index"`          // Fostered pet
+	Pet             Pet            `json:"-" gorm:"foreignKey:PetID"`             // Fostered pet (relationship)
+	PetSummary      *SimplifiedPet `json:"pet,omitempty" gorm:"-"`                // Fostered pet summary (computed)
+	FosterHomeID    uint           `json:"foster_home_id" gorm:"not null;index"`  // Foster home the pet lives in
+	StartDate       time.Time      `json:"start_date" gorm:"type:date;not null"`  // Date the pet moved to the home
+	ExpectedEndDate *time.Time     `json:"expected_end_date" gorm:"type:date"`    // Planned end of the placement (optional)
+	EndDate         *time.Time     `json:"end_date" gorm:"type:date;index"`       // Date the pet left the home (nil while current)
+	Notes           string         `json:"notes" gorm:"type:text"`                // Internal notes (staff only)
+	CreatedBy       uint           `json:"created_by"`                            // Staff user who registered the placement
+	CrtDate         time.Time      `json:"crt_date" gorm:"autoCreateTime"`        // Record creation timestamp
+	UptDate         time.Time      `json:"upt_date" gorm:"autoUpdateTime"`        // Record last update timestamp
+}
+
+// IsCurrent reports whether the pet still lives in the foster home.
+func (p *FosterPlacement) IsCurrent() bool {
+	return p.EndDate == nil
+}
+
+// TableName returns the database table name for the FosterUpdate model.
+// This method implements the GORM Tabler interface to specify custom table names.
+func (FosterUpdate) TableName() string {
+	return "Foster_Updates"
+}
+
+// FosterUpdate represents news about a fostered pet posted by its foster.
+//
+// Database Table: Foster_Updates
+// Relationships:
+//   - Placement: Many-to-One relationship with FosterPlacement (foreign key: PlacementID)
+//   - Photos: One-to-Many relationship with FosterUpdatePhoto (foreign key: UpdateID)
+//
+// Business Rules:
+//   - Only the foster of a current placement can post updates
+//   - Updates are visible to the foster and to the organisation's staff
+type FosterUpdate struct {
+	ID           uint                `json:"id" gorm:"primaryKey;autoIncrement"` // Unique identifier for the update
+	PlacementID  uint                `json:"placement_id" gorm:"not null;index"` // Placement the update is about
+	AuthorUserID uint                `json:"author_user_id" gorm:"not null"`     // User who posted the update
+	Message      string              `json:"message" gorm:"type:text;not null"`  // News about the pet
+	Photos       []FosterUpdatePhoto `json:"photos" gorm:"foreignKey:UpdateID"`  // Update photos (relationship)
+	CrtDate      time.Time           `json:"crt_date" gorm:"autoCreateTime"`     // Record creation timestamp
+}
+
+// TableName returns the database table name for the FosterUpdatePhoto model.
+// This method implements the GORM Tabler interface to specify custom table names.
+func (FosterUpdatePhoto) TableName() string {
+	return "Foster_Update_Photos"
+}
+
+// FosterUpdatePhoto represents an image posted with a foster update.
+// Photos are processed like pet photos: re-encoded without EXIF metadata and
+// stored with thumbnails under a common key prefix (StorageKey).
+//
+// Database Table: Foster_Update_Photos
+// Relationships:
+//   - Update: Many-to-One relationship with FosterUpdate (foreign key: UpdateID)
+type FosterUpdatePhoto struct {
+	ID          uint              `json:"id" gorm:"primaryKey;autoIncrement"`            // Unique identifier for the photo
+	UpdateID    uint              `json:"update_id" gorm:"not null;index"`               // ID of the update the photo belongs to
+	StorageKey  string            `json:"-" gorm:"type:varchar(255);not null"`           // Key prefix of the stored objects
+	ContentType string            `json:"content_type" gorm:"type:varchar(50);not null"` // MIME type of the stored original
+	Extension   string            `json:"-" gorm:"type:varchar(10);not null"`            // File extension of the stored original
+	Size        int64             `json:"size"`                                          // Size in bytes of the stored original
+	Width       int               `json:"width"`                                         // Width in pixels of the stored original
+	Height      int               `json:"height"`                                        // Height in pixels of the stored original
+	URL         string            `json:"url" gorm:"-"`                                  // Download URL of the original (computed)
+	Thumbnails  map[string]string `json:"thumbnails" gorm:"-"`                           // Download URLs by thumbnail size (computed)
+	CrtDate     time.Time         `json:"crt_date" gorm:"autoCreateTime"`                // Record creation timestamp
+}
@@ -0,0 +1,104 @@
+// Package models contains data models for the pet adoption system.
+// These models define the structure of intake records: how and when each pet arrived at the shelter.
+package models
+
+import "time"
+
+// Intake sources: how a pet arrived at the shelter.
+const (
+	IntakeSourceStray          = "stray"           // Found loose and brought in
+	IntakeSourceOwnerSurrender = "owner_surrender" // Handed over by its owner
+	IntakeSourceTransfer       = "transfer"        // Transferred from another shelter or organisation
+	IntakeSourceBornInCare     = "born_in_care"    // Born while its mother was in the shelter's care
+)
+
+// IntakeSources lists every valid intake source.
+var IntakeSources = []string{
+	IntakeSourceStray,
+	IntakeSourceOwnerSurrender,
+	IntakeSourceTransfer,
+	IntakeSourceBornInCare,
+}
+
+// Intake conditions: the health of a pet when it arrived.
+const (
+	IntakeConditionHealthy   = "healthy"   // No health issues
+	IntakeConditionTreatable = "treatable" // Minor issues that need treatment
+	IntakeConditionInjured   = "injured"   // Injured
+	IntakeConditionSick      = "sick"      // Ill
+	IntakeConditionCritical  = "critical"  // Needs urgent veterinary care
+)
+
+// IntakeConditions lists every valid intake condition.
+var IntakeConditions = []string{
+	IntakeConditionHealthy,
+	IntakeConditionTreatable,
+	IntakeConditionInjured,
+	IntakeConditionSick,
+	IntakeConditionCritical,
+}
+
+// Outcome types counted by the intake statistics.
+const (
+	OutcomeAdopted = "adopted" // The pet left the shelter with its adopter
+)
+
+// TableName returns the database table name for the Intake model.
+// This method implements the GORM Tabler interface to specify custom table names.
+func (Intake) TableName() string {
+	return "Intakes"
+}
+
+// Intake represents the arrival of a pet at the shelter: where it came from, who brought it and in what condition.
+//
+// Database Table: Intakes
+// Relationships:
+//   - Organization: Many-to-One relationship with Organization (foreign key: OrganizationID)
+//   - Pet: One-to-One relationship with Pet (foreign key: PetID)
+//
+// Business Rules:
+//   - Recording an intake creates its pet in the same transaction
+//   - Surrenderer details are only filled for owner surrenders; TransferFrom only for transfers
+//   - Found location fields are only filled for strays
+//   - Intakes are visible to the organisation's staff only
+type Intake struct {
+	ID                 uint           `json:"id" gorm:"primaryKey;autoIncrement"`           // Unique identifier for the intake
+	OrganizationID     uint           `json:"organization_id" gorm:"not null;index"`        // Organisation receiving the pet
+	PetID              uint           `json:"pet_id" gorm:"not null;uniqueIndex"`           // Pet that arrived
+	Pet                *SimplifiedPet `json:"pet,omitempty" gorm:"-"`                       // Pet summary (computed)
+	Source             string         `json:"source" gorm:"type:varchar(20);not null"`      // stray, owner_surrender, transfer or born_in_care
+	IntakeDate         time.Time      `json:"intake_date" gorm:"type:date;not null"`        // Date the pet arrived
+	Condition          string         `json:"condition" gorm:"type:varchar(20);not null"`   // healthy, treatable, injured, sick or critical
+	ConditionNotes     string         `json:"condition_notes" gorm:"type:text"`             // Description of the initial condition (optional)
+	SurrendererName    string         `json:"surrenderer_name" gorm:"type:varchar(150)"`    // Full name of the person surrendering the pet
+	SurrendererEmail   string         `json:"surrenderer_email" gorm:"type:varchar(255)"`   // Email of the person surrendering the pet (optional)
+	SurrendererPhone   string         `json:"surrenderer_phone" gorm:"type:varchar(30)"`    // Phone of the person surrendering the pet (optional)
+	SurrendererAddress string         `json:"surrenderer_address" gorm:"type:varchar(255)"` // Postal address of the person surrendering the pet (optional)
+	SurrenderReason    string         `json:"surrender_reason" gorm:"type:text"`            // Why the owner gave the pet up (optional)
+	TransferFrom       string         `json:"transfer_from" gorm:"type:varchar(150)"`       // Shelter or organisation the pet came from
+	FoundLocation      string         `json:"found_location" gorm:"type:varchar(255)"`      // Where the stray was found, e.g. "Parc de la Ciutadella"
+	FoundLatitude      *float64       `json:"found_latitude"`                               // Latitude of the found location (optional)
+	FoundLongitude     *float64       `json:"found_longitude"`                              // Longitude of the found location (optional)
+	Notes              string         `json:"notes" gorm:"type:text"`                       // Internal notes (optional)
+	CreatedBy          uint           `json:"created_by"`                                   // Staff user who recorded the intake
+	CrtDate            time.Time      `json:"crt_date" gorm:"autoCreateTime"`               // Record creation timestamp
+	UptDate            time.Time      `json:"upt_date" gorm:"autoUpdateTime"`               // Record last update timestamp
+}
+
+// IntakeMonthStats counts the pets that arrived at and left the shelter in one month.
+type IntakeMonthStats struct {
+	Month     string         `json:"month"`      // Month, e.g. "2026-03" (empty for the totals)
+	Intakes   int            `json:"intakes"`    // Pets that arrived
+	BySource  map[string]int `json:"by_source"`  // Pets that arrived by intake source
+	Outcomes  int            `json:"outcomes"`   // Pets that left
+	ByOutcome map[string]int `json:"by_outcome"` // Pets that left by outcome type
+	Net       int            `json:"net"`        // Intakes minus outcomes
+}
+
+// IntakeStats compares intakes and outcomes month by month.
+type IntakeStats struct {
+	From   string             `json:"from"`   // First month, e.g. "2026-01"
+	To     string             `json:"to"`     // Last month, included
+	Months []IntakeMonthStats `json:"months"` // One entry per month, oldest first (months without movements included)
+	Totals IntakeMonthStats   `json:"totals"` // Sum of every month
+}
@@ -0,0 +1,49 @@
+// Package models contains data models for the pet adoption system.
+// These models define the structure of background job run records.
+package models
+
+import "time"
+
+// Job run statuses.
+const (
+	JobRunRunning = "running"
+	JobRunSuccess = "success"
+	JobRunFailed  = "failed"
+)
+
+// Job run triggers.
+const (
+	JobTriggerSchedule = "schedule"
+	JobTriggerManual   = "manual"
+)
+
+// TableName returns the database table name for the JobRun model.
+// This method implements the GORM Tabler interface to specify custom table names.
+func (JobRun) TableName() string {
+	return "Job_Runs"
+}
+
+// JobRun records one execution of a background job.
+//
+// Database Table: Job_Runs
+//
+// Business Rules:
+//   - RunKey identifies the period a run covers (e.g. the day for daily jobs)
+//   - Job and RunKey are unique, so a period is processed once even across restarts
+//   - Failed periods can be claimed again, and so can runs left as running (e.g. after a crash) once SCHEDULER_RUN_LEASE has passed
+//   - Dry runs and forced manual runs have no RunKey and never block scheduled runs
+//   - Only the latest runs of pollers (frequent queue jobs) are kept, see SCHEDULER_POLL_RETENTION
+type JobRun struct {
+	ID         uint       `json:"id" gorm:"primaryKey;autoIncrement"`                               // Unique identifier for the run
+	Job        string     `json:"job" gorm:"type:varchar(50);not null;uniqueIndex:idx_job_run_key"` // Job name
+	RunKey     *string    `json:"run_key" gorm:"type:varchar(50);uniqueIndex:idx_job_run_key"`      // Period covered by the run (nil for dry and forced runs)
+	Trigger    string     `json:"trigger" gorm:"type:varchar(20);not null;column:triggered_by"`     // What started the run (schedule, manual)
+	DryRun     bool       `json:"dry_run" gorm:"not null;default:false"`                            // Whether the run only previewed its work
+	Status     string     `json:"status" gorm:"type:varchar(20);not null"`                          // Run status (running, success, failed)
+	Items      int        `json:"items" gorm:"not null;default:0"`                                  // Number of items processed (e.g. emails sent)
+	Summary    string     `json:"summary" gorm:"type:text"`                                         // Human readable outcome
+	Error      string     `json:"error" gorm:"type:text"`                                           // Error message of failed runs
+	StartedAt  time.Time  `json:"started_at" gorm:"not null"`                                       // When the run started
+	FinishedAt *time.Time `json:"finished_at"`                                                      // When the run finished (nil while running)
+	Preview    any        `json:"preview,omitempty" gorm:"-"`                                       // Work a dry run would have done (not stored)
+}
@@ -0,0 +1,124 @@
+// Package models contains data models for the pet adoption system.
+// These models define the structure of the physical locations of a shelter
+// (buildings, rooms and kennels) and the history of where each pet was housed.
+package models
+
+import "time"
+
+// Location kinds, from the largest to the smallest.
+const (
+	LocationKindBuilding = "building" // Building or site of the shelter
+	LocationKindRoom     = "room"     // Room, yard or wing inside a building
+	LocationKindKennel   = "kennel"   // Kennel, cage or enclosure where pets are housed
+)
+
+// LocationKinds lists every valid location kind.
+var LocationKinds = []string{LocationKindBuilding, LocationKindRoom, LocationKindKennel}
+
+// TableName returns the database table name for the Location model.
+// This method implements the GORM Tabler interface to specify custom table names.
+func (Location) TableName() string {
+	return "Locations"
+}
+
+// Location represents a place of a shelter: a building, a room inside it or a kennel where pets live.
+//
+// Database Table: Locations
+// Relationships:
+//   - Organization: Many-to-One relationship with Organization (foreign key: OrganizationID)
+//   - Parent: Many-to-One relationship with Location (foreign key: ParentID)
+//   - Pets: One-to-Many relationship with PetLocation (foreign key: LocationID)
+//
+// Business Rules:
+//   - Buildings have no parent, rooms belong to a building and kennels to a room or a building
+//   - Pets are only housed in kennels; the capacity of buildings and rooms is the sum of their kennels
+//   - AcceptedSpecies restricts the species of a kennel and of every kennel inside a building or room
+//   - Inactive kennels (e.g. under repair) receive no new pets
+type Location struct {
+	ID              uint          `json:"id" gorm:"primaryKey;autoIncrement"`      // Unique identifier for the location
+	OrganizationID  uint          `json:"organization_id" gorm:"not null;index"`   // Organisation the location belongs to
+	ParentID        *uint         `json:"parent_id" gorm:"index"`                  // Building or room containing the location (nil for buildings)
+	Kind            string        `json:"kind" gorm:"type:varchar(20);not null"`   // building, room or kennel
+	Name            string        `json:"name" gorm:"type:varchar(100);not null"`  // Name, e.g. "Nave A", "Jaula 12"
+	Capacity        int           `json:"capacity" gorm:"not null;default:0"`      // Pets the kennel holds (computed total for buildings and rooms)
+	AcceptedSpecies []string      `json:"accepted_species" gorm:"serializer:json"` // Species allowed (empty allows any)
+	Active          bool          `json:"active" gorm:"not null;default:true"`     // Whether the location receives new pets
+	Notes           string        `json:"notes" gorm:"type:text"`                  // Internal notes (optional)
+	Path            string        `json:"path" gorm:"-"`                           // Full name, e.g. "Nave A / Perros / Jaula 12" (computed)
+	Occupied        int           `json:"occupied" gorm:"-"`                       // Pets housed in the location (computed)
+	FreeCapacity    int           `json:"free_capacity" gorm:"-"`                  // Places left (computed)
+	OverCapacity    bool          `json:"over_capacity" gorm:"-"`                  // Whether more pets are housed than its capacity (computed)
+	Pets            []PetLocation `json:"pets,omitempty" gorm:"-"`                 // Pets currently housed (computed, kennel detail only)
+	CrtDate         time.Time     `json:"crt_date" gorm:"autoCreateTime"`          // Record creation timestamp
+	UptDate         time.Time     `json:"upt_date" gorm:"autoUpdateTime"`          // Record last update timestamp
+}
+
+// AcceptsSpecies reports whether the location accepts pets of the given species.
+func (l *Location) AcceptsSpecies(species string) bool {
+	if len(l.AcceptedSpecies) == 0 {
+		return true
+	}
+
+	for _, accepted := range l.AcceptedSpecies {
+		if accepted == species {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsKennel reports whether pets can be housed in the location.
+func (l *Location) IsKennel() bool {
+	return l.Kind == LocationKindKennel
+}
+
+// TableName returns the database table name for the PetLocation model.
+// This method implements the GORM Tabler interface to specify custom table names.
+func (PetLocation) TableName() string {
+	return "Pet_Locations"
+}
+
+// PetLocation represents the stay of a pet in a kennel. The open stay (EndedAt nil) is where the pet
+// lives now; finished stays are the history of its moves.
+//
+// Database Table: Pet_Locations
+// Relationships:
+//   - Pet: Many-to-One relationship with Pet (foreign key: PetID)
+//   - Location: Many-to-One relationship with Location (foreign key: LocationID)
+//
+// Business Rules:
+//   - A pet has at most one open stay; moving it ends the open stay and opens a new one
+//   - Stays end when the pet is adopted or placed in a foster home
+type PetLocation struct {
+	ID             uint           `json:"id" gorm:"primaryKey;autoIncrement"`    // Unique identifier for the stay
+	OrganizationID uint           `json:"organization_id" gorm:"not null;index"` // Organisation of the pet
+	PetID          uint           `json:"pet_id" gorm:"not null;index"`          // Housed pet
+	Pet            *SimplifiedPet `json:"pet,omitempty" gorm:"-"`                // Pet summary (computed, kennel detail only)
+	LocationID     uint           `json:"location_id" gorm:"not null;index"`     // Kennel housing the pet
+	LocationPath   string         `json:"location_path" gorm:"-"`                // Full name of the kennel (computed)
+	StartedAt      time.Time      `json:"started_at" gorm:"not null"`            // When the pet moved in
+	EndedAt        *time.Time     `json:"ended_at"`                              // When the pet moved out (nil for the current stay)
+	Reason         string         `json:"reason" gorm:"type:varchar(255)"`       // Reason of the move, e.g. "ingreso", "cuarentena"
+	EndReason      string         `json:"end_reason" gorm:"type:varchar(255)"`   // Why the stay ended, e.g. "traslado", "adoptada"
+	MovedBy        *uint          `json:"moved_by,omitempty"`                    // Staff user who moved the pet (nil for automatic moves)
+	CrtDate        time.Time      `json:"crt_date" gorm:"autoCreateTime"`        // Record creation timestamp
+}
+
+// IsCurrent reports whether the pet still lives in the location of the stay.
+func (p *PetLocation) IsCurrent() bool {
+	return p.EndedAt == nil
+}
+
+// OccupancyReport summarises the free kennel places of an organisation and whether an intake fits.
+type OccupancyReport struct {
+	Species        string     `json:"species,omitempty"` // Species of the intake (empty for any)
+	Incoming       int        `json:"incoming"`          // Pets about to arrive
+	Capacity       int        `json:"capacity"`          // Places of the active kennels
+	Occupied       int        `json:"occupied"`          // Pets housed in kennels
+	Free           int        `json:"free"`              // Places left in active kennels
+	FreeForSpecies int        `json:"free_for_species"`  // Places left in active kennels accepting the species
+	Warning        bool       `json:"warning"`           // Whether the intake exceeds the places left
+	Message        string     `json:"message,omitempty"` // Explanation of the warning
+	Kennels        []Location `json:"kennels"`           // Occupancy of each kennel
+}
@@ -0,0 +1,129 @@
+// Package models contains data models for the pet adoption system.
+// These models define the structure of lost and found pet reports submitted by the community.
+package models
+
+import "time"
+
+// Lost and found report types.
+const (
+	LostFoundTypeLost  = "lost"  // The reporter lost their pet
+	LostFoundTypeFound = "found" // The reporter found an animal
+)
+
+// LostFoundTypes lists every valid report type.
+var LostFoundTypes = []string{LostFoundTypeLost, LostFoundTypeFound}
+
+// Lost and found report moderation statuses.
+const (
+	LostFoundStatusPending  = "pending"  // Waiting for staff moderation (not public)
+	LostFoundStatusApproved = "approved" // Published and matched against pets
+	LostFoundStatusRejected = "rejected" // Rejected by staff (not public)
+	LostFoundStatusResolved = "resolved" // Pet reunited or case closed (not public)
+)
+
+// LostFoundStatuses lists every valid report status.
+var LostFoundStatuses = []string{
+	LostFoundStatusPending,
+	LostFoundStatusApproved,
+	LostFoundStatusRejected,
+	LostFoundStatusResolved,
+}
+
+// TableName returns the database table name for the LostFoundReport model.
+// This method implements the GORM Tabler interface to specify custom table names.
+func (LostFoundReport) TableName() string {
+	return "Lost_Found_Reports"
+}
+
+// LostFoundReport represents a lost or found animal reported by a member of the community.
+// Reports are not shelter pets: they only become public once approved by staff.
+//
+// Database Table: Lost_Found_Reports
+// Relationships:
+//   - Photos: One-to-Many relationship with LostFoundPhoto (foreign key: ReportID)
+//   - ReporterUser: Optional Many-to-One relationship with User (foreign key: ReporterUserID)
+//
+// Business Rules:
+//   - Anyone can submit a report; it starts as pending
+//   - Only approved reports are public, and never with the reporter's contact details
+//   - Approved reports are matched against shelter pets; the reporter is emailed about new candidates
+type LostFoundReport struct {
+	ID             uint             `json:"id" gorm:"primaryKey;autoIncrement"`                         // Unique identifier for the report
+	Type           string           `json:"type" gorm:"type:varchar(10);not null;index"`                // lost or found
+	Status         string           `json:"status" gorm:"type:varchar(20);not null;default:pending"`    // Moderation status
+	Species        string           `json:"species" gorm:"type:varchar(100);not null;index"`            // Species of the animal
+	Breed          string           `json:"breed" gorm:"type:varchar(100)"`                             // Breed, if known
+	Color          string           `json:"color" gorm:"type:varchar(50)"`                              // Coat colour(s)
+	Microchip      *string          `json:"microchip" gorm:"type:varchar(15);index"`                    // Microchip number, if known (normalised)
+	Description    string           `json:"description" gorm:"type:text"`                               // Distinguishing features and circumstances
+	Latitude       float64          `json:"latitude" gorm:"not null"`                                   // Last-seen (lost) or found location latitude
+	Longitude      float64          `json:"longitude" gorm:"not null"`                                  // Last-seen (lost) or found location longitude
+	Location       string           `json:"location" gorm:"type:varchar(255)"`                          // Human readable location (optional)
+	SeenDate       time.Time        `json:"seen_date" gorm:"type:date;not null"`                        // Date the animal was lost or found
+	ReporterName   string           `json:"reporter_name" gorm:"type:varchar(100);not null"`            // Name of the reporter
+	ReporterEmail  string           `json:"reporter_email,omitempty" gorm:"type:varchar(255);not null"` // Reporter email (staff only)
+	ReporterPhone  string           `json:"reporter_phone,omitempty" gorm:"type:varchar(30)"`           // Reporter phone (staff only)
+	ReporterUserID *uint            `json:"reporter_user_id,omitempty"`                                 // Registered user who submitted the report (staff only)
+	ModeratedBy    *uint            `json:"moderated_by,omitempty"`                                     // Staff user who last moderated the report (staff only)
+	ModeratedAt    *time.Time       `json:"moderated_at,omitempty"`                                     // Last moderation time (staff only)
+	ModerationNote string           `json:"moderation_note,omitempty" gorm:"type:varchar(500)"`         // Internal moderation note (staff only)
+	Photos         []LostFoundPhoto `json:"photos" gorm:"foreignKey:ReportID"`                          // Report photos (relationship)
+	CrtDate        time.Time        `json:"crt_date" gorm:"autoCreateTime"`                             // Record creation timestamp
+	UptDate        time.Time        `json:"upt_date" gorm:"autoUpdateTime"`                             // Record last update timestamp
+}
+
+// TableName returns the database table name for the LostFoundPhoto model.
+// This method implements the GORM Tabler interface to specify custom table names.
+func (LostFoundPhoto) TableName() string {
+	return "Lost_Found_Photos"
+}
+
+// LostFoundPhoto represents an image submitted with a lost or found report.
+// Photos are processed like pet photos: re-encoded without EXIF metadata and
+// stored with thumbnails under a common key prefix (StorageKey).
+//
+// Database Table: Lost_Found_Photos
+// Relationships:
+//   - Report: Many-to-One relationship with LostFoundReport (foreign key: ReportID)
+type LostFoundPhoto struct {
+	ID          uint              `json:"id" gorm:"primaryKey;autoIncrement"`            // Unique identifier for the photo
+	ReportID    uint              `json:"report_id" gorm:"not null;index"`               // ID of the report the photo belongs to
+	StorageKey  string            `json:"-" gorm:"type:varchar(255);not null"`           // Key prefix of the stored objects
+	ContentType string            `json:"content_type" gorm:"type:varchar(50);not null"` // MIME type of the stored original
+	Extension   string            `json:"-" gorm:"type:varchar(10);not null"`            // File extension of the stored original
+	Size        int64             `json:"size"`                                          // Size in bytes of the stored original
+	Width       int               `json:"width"`                                         // Width in pixels of the stored original
+	Height      int               `json:"height"`                                        // Height in pixels of the stored original
+	URL         string            `json:"url" gorm:"-"`                                  // Download URL of the original (computed)
+	Thumbnails  map[string]string `json:"thumbnails" gorm:"-"`                           // Download URLs by thumbnail size (computed)
+	CrtDate     time.Time         `json:"crt_date" gorm:"autoCreateTime"`                // Record creation timestamp
+}
+
+// TableName returns the database table name for the LostFoundMatch model.
+// This method implements the GORM Tabler interface to specify custom table names.
+func (LostFoundMatch) TableName() string {
+	return "Lost_Found_Matches"
+}
+
+// LostFoundMatch represents a shelter pet found to be a candidate for a lost or found report.
+//
+// Database Table: Lost_Found_Matches
+// Relationships:
+//   - Report: Many-to-One relationship with LostFoundReport (foreign key: ReportID)
+//   - Pet: Many-to-One relationship with Pet (foreign key: PetID)
+//
+// Business Rules:
+//   - A pet is a candidate at most once per report
+//   - Reasons lists the matching criteria (microchip, species, breed, color)
+//   - NotifiedAt is set once the reporter has been emailed about the candidate
+type LostFoundMatch struct {
+	ID         uint           `json:"id" gorm:"primaryKey;autoIncrement"`                      // Unique identifier for the match
+	ReportID   uint           `json:"report_id" gorm:"not null;uniqueIndex:idx_report_pet"`    // Matched report
+	PetID      uint           `json:"pet_id" gorm:"not null;uniqueIndex:idx_report_pet;index"` // Candidate pet
+	Pet        Pet            `json:"-" gorm:"foreignKey:PetID"`                               // Candidate pet (relationship)
+	Candidate  *SimplifiedPet `json:"pet,omitempty" gorm:"-"`                                  // Candidate pet summary (computed)
+	Score      int            `json:"score" gorm:"not null"`                                   // Match strength (0-100)
+	Reasons    string         `json:"reasons" gorm:"type:varchar(100);not null"`               // Comma-separated matching criteria
+	NotifiedAt *time.Time     `json:"notified_at"`                                             // When the reporter was emailed (nil while pending)
+	CrtDate    time.Time      `json:"crt_date" gorm:"autoCreateTime"`                          // Record creation timestamp
+}
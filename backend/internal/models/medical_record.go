@@ -0,0 +1,125 @@
+// Package models contains data models for the pet adoption system.
+// These models define the structure of pet medical records and their public summary.
+package models
+
+import "time"
+
+// Medical record types.
+// Each record type uses a subset of the MedicalRecord fields (see MedicalRecord).
+const (
+	MedicalVaccination = "vaccination"
+	MedicalTreatment   = "treatment"
+	MedicalVetVisit    = "vet_visit"
+	MedicalWeight      = "weight"
+	MedicalAllergy     = "allergy"
+	MedicalSpayNeuter  = "spay_neuter"
+)
+
+// MedicalRecordTypes lists every valid medical record type.
+var MedicalRecordTypes = []string{
+	MedicalVaccination, MedicalTreatment, MedicalVetVisit, MedicalWeight, MedicalAllergy, MedicalSpayNeuter,
+}
+
+// TableName returns the database table name for the MedicalRecord model.
+// This method implements the GORM Tabler interface to specify custom table names.
+func (MedicalRecord) TableName() string {
+	return "Pet_Medical_Records"
+}
+
+// MedicalRecord represents one entry of a pet's medical history.
+// Only staff can read or modify full records; adopters see a PetMedicalSummary.
+//
+// Database Table: Pet_Medical_Records
+// Relationships:
+//   - Pet: Many-to-One relationship with Pet (foreign key: PetID)
+//
+// Fields by type:
+//   - vaccination: Name (vaccine), Date (administered), DueDate (next dose)
+//   - treatment: Name (medication or procedure), Date (start), EndDate (end, if finished)
+//   - vet_visit: Name (reason), Date, Vet
+//   - weight: WeightKg, Date
+//   - allergy: Name (allergen), Date (diagnosed)
+//   - spay_neuter: Date, Vet
+//
+// Business Rules:
+//   - Notes are internal and never shown to adopters
+type MedicalRecord struct {
+	ID        uint       `json:"id" gorm:"primaryKey;autoIncrement"`          // Unique identifier for the record
+	PetID     uint       `json:"pet_id" gorm:"not null;index"`                // Pet the record belongs to
+	Type      string     `json:"type" gorm:"type:varchar(20);not null;index"` // Record type (vaccination, treatment, ...)
+	Name      string     `json:"name" gorm:"type:varchar(150)"`               // Vaccine, treatment, visit reason or allergen
+	Date      time.Time  `json:"date" gorm:"type:date;not null"`              // Date of the event
+	DueDate   *time.Time `json:"due_date" gorm:"type:date;index"`             // Next vaccination dose (vaccinations only)
+	EndDate   *time.Time `json:"end_date" gorm:"type:date"`                   // End of treatment (treatments only)
+	WeightKg  *float64   `json:"weight_kg"`                                   // Weight in kilograms (weight only)
+	Vet       string     `json:"vet" gorm:"type:varchar(150)"`                // Veterinarian or clinic (optional)
+	Notes     string     `json:"notes" gorm:"type:text"`                      // Internal notes (staff only)
+	CreatedBy uint       `json:"created_by"`                                  // Staff user who registered the record
+	CrtDate   time.Time  `json:"crt_date" gorm:"autoCreateTime"`              // Record creation timestamp
+	UptDate   time.Time  `json:"upt_date" gorm:"autoUpdateTime"`              // Record last update timestamp
+}
+
+// PetMedicalSummary is the adopter-facing view of a pet's medical history.
+// It is computed from the medical records and never includes internal notes.
+type PetMedicalSummary struct {
+	PetID            uint                 `json:"pet_id"`              // Pet the summary belongs to
+	Vaccinated       bool                 `json:"vaccinated"`          // Whether the pet has at least one vaccination
+	VaccinesUpToDate bool                 `json:"vaccines_up_to_date"` // Whether no vaccination dose is overdue
+	Vaccinations     []SummaryVaccination `json:"vaccinations"`        // Latest dose of each vaccine
+	Neutered         bool                 `json:"neutered"`            // Whether the pet is spayed or neutered
+	NeuteredDate     *time.Time           `json:"neutered_date"`       // Date of the spay/neuter surgery (if any)
+	Allergies        []string             `json:"allergies"`           // Known allergens
+	Treatments       []SummaryTreatment   `json:"treatments"`          // Treatments, most recent first
+	Weight           *SummaryWeight       `json:"weight"`              // Latest weight measurement (if any)
+	LastVetVisit     *time.Time           `json:"last_vet_visit"`      // Date of the latest vet visit (if any)
+}
+
+// SummaryVaccination is a vaccination as shown to adopters.
+type SummaryVaccination struct {
+	Name    string     `json:"name"`     // Vaccine name
+	Date    time.Time  `json:"date"`     // Date of the latest dose
+	DueDate *time.Time `json:"due_date"` // Next dose (if any)
+	Overdue bool       `json:"overdue"`  // Whether the next dose date has passed
+}
+
+// SummaryTreatment is a treatment as shown to adopters.
+type SummaryTreatment struct {
+	Name    string     `json:"name"`     // Medication or procedure
+	Date    time.Time  `json:"date"`     // Start date
+	EndDate *time.Time `json:"end_date"` // End date (nil while ongoing)
+	Active  bool       `json:"active"`   // Whether the treatment is ongoing
+}
+
+// SummaryWeight is a weight measurement as shown to adopters.
+type SummaryWeight struct {
+	Kg   float64   `json:"kg"`   // Weight in kilograms
+	Date time.Time `json:"date"` // Measurement date
+}
+
+// MedicalReminder is a vaccination or treatment due soon, with the name of its pet.
+// Used by the staff reminder digest.
+type MedicalReminder struct {
+	MedicalRecord
+	PetName        string `json:"pet_name"`        // Name of the pet the record belongs to
+	OrganizationID uint   `json:"organization_id"` // Organisation that owns the pet
+}
+
+// TableName returns the database table name for the MedicalReminderDelivery model.
+// This method implements the GORM Tabler interface to specify custom table names.
+func (MedicalReminderDelivery) TableName() string {
+	return "Medical_Reminder_Deliveries"
+}
+
+// MedicalReminderDelivery records that a staff member got the medical reminder digest of a day.
+//
+// Database Table: Medical_Reminder_Deliveries
+//
+// Business Rules:
+//   - One row per day, organisation and staff member, so a retried run never sends a digest twice
+//   - Rows of previous days are deleted by the next run
+type MedicalReminderDelivery struct {
+	DigestDate     time.Time `json:"digest_date" gorm:"primaryKey;type:date"`               // Day of the digest
+	OrganizationID uint      `json:"organization_id" gorm:"primaryKey;autoIncrement:false"` // Organisation of the digest
+	UserID         uint      `json:"user_id" gorm:"primaryKey;autoIncrement:false"`         // Staff member who got it
+	CrtDate        time.Time `json:"crt_date" gorm:"autoCreateTime"`                        // When the digest was queued
+}
@@ -0,0 +1,78 @@
+// Package models contains data models for the pet adoption system.
+// These models define the structure of user notification preferences and the
+// in-app notification centre.
+package models
+
+import "time"
+
+// Notification types users can configure. Account emails (2FA codes, passwords)
+// and documents (donation receipts) are not configurable and always sent.
+const (
+	NotificationSearchAlerts       = "search_alerts"       // Saved-search digests
+	NotificationFavorites          = "favorites"           // Status changes of favourite pets
+	NotificationLostFound          = "lost_found"          // Pets matching a lost and found report
+	NotificationMessages           = "messages"            // Unread messages in conversations
+	NotificationApplicationUpdates = "application_updates" // Adoption contracts, visit confirmations and cancellations
+	NotificationReminders          = "reminders"           // Visits, volunteer shifts, donation renewals and medical care due
+)
+
+// NotificationTypes lists every configurable notification type.
+var NotificationTypes = []string{
+	NotificationSearchAlerts, NotificationFavorites, NotificationLostFound,
+	NotificationMessages, NotificationApplicationUpdates, NotificationReminders,
+}
+
+// TableName returns the database table name for the NotificationPreference model.
+// This method implements the GORM Tabler interface to specify custom table names.
+func (NotificationPreference) TableName() string {
+	return "Notification_Preferences"
+}
+
+// NotificationPreference represents the channels a user receives a notification type on.
+//
+// Database Table: Notification_Preferences
+// Relationships:
+//   - User: Many-to-One relationship with User (foreign key: UserID)
+//
+// Business Rules:
+//   - One row per user and type; types without a row have every channel enabled
+//   - Guests and organisation addresses have no preferences and always get the email
+type NotificationPreference struct {
+	UserID  uint      `json:"-" gorm:"primaryKey;autoIncrement:false"`           // Owner of the preference
+	Type    string    `json:"type" gorm:"primaryKey;type:varchar(30)"`           // Notification type (see NotificationTypes)
+	Email   bool      `json:"email" gorm:"not null;default:true"`                // Whether the type is emailed
+	InApp   bool      `json:"in_app" gorm:"column:in_app;not null;default:true"` // Whether the type appears in the notification centre
+	UptDate time.Time `json:"upt_date" gorm:"autoUpdateTime"`                    // Record last update timestamp
+}
+
+// TableName returns the database table name for the Notification model.
+// This method implements the GORM Tabler interface to specify custom table names.
+func (Notification) TableName() string {
+	return "Notifications"
+}
+
+// Notification represents an entry of a user's in-app notification centre.
+//
+// Database Table: Notifications
+// Relationships:
+//   - User: Many-to-One relationship with User (foreign key: UserID)
+//
+// Business Rules:
+//   - Created by the notification service with the subject and summary of the email, in the user's locale
+//   - ReadAt is set when the user marks it as read; unread notifications have it null
+type Notification struct {
+	ID      uint       `json:"id" gorm:"primaryKey;autoIncrement"`      // Unique identifier for the notification
+	UserID  uint       `json:"-" gorm:"not null;index:idx_user_read"`   // Recipient user
+	Type    string     `json:"type" gorm:"type:varchar(30);not null"`   // Notification type (see NotificationTypes)
+	Title   string     `json:"title" gorm:"type:varchar(255);not null"` // Title (subject of the email)
+	Body    string     `json:"body" gorm:"type:text"`                   // One-line summary
+	URL     string     `json:"url" gorm:"type:varchar(500)"`            // Page the notification links to (optional)
+	ReadAt  *time.Time `json:"read_at" gorm:"index:idx_user_read"`      // When the user read it, null while unread
+	CrtDate time.Time  `json:"crt_date" gorm:"autoCreateTime"`          // Record creation timestamp
+}
+
+// NotificationUnreadCount is the number of unread notifications of a user, in total and by type.
+type NotificationUnreadCount struct {
+	Total  int64            `json:"total"`   // Unread notifications of every type
+	ByType map[string]int64 `json:"by_type"` // Unread notifications per type (types without any are 0)
+}
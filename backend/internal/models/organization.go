@@ -0,0 +1,108 @@
+// Package models contains data models for the pet adoption system.
+// These models define the structure of shelter organisations, their settings and staff memberships.
+package models
+
+import "time"
+
+// Organisation membership roles.
+const (
+	OrgRoleStaff   = "staff"   // Manages the organisation's pets and species
+	OrgRoleManager = "manager" // Staff who also manage the organisation's settings and members
+)
+
+// OrgRoles lists every valid membership role.
+var OrgRoles = []string{OrgRoleStaff, OrgRoleManager}
+
+// Adoption questionnaire question types.
+const (
+	QuestionText     = "text"     // Short free-text answer
+	QuestionTextarea = "textarea" // Long free-text answer
+	QuestionYesNo    = "yes_no"   // Yes or no answer
+	QuestionNumber   = "number"   // Numeric answer
+	QuestionChoice   = "choice"   // One of Options
+)
+
+// QuestionTypes lists every valid questionnaire question type.
+var QuestionTypes = []string{QuestionText, QuestionTextarea, QuestionYesNo, QuestionNumber, QuestionChoice}
+
+// DefaultOrganizationID is the organisation that owned every pet, species and staff
+// member before the system supported several shelters.
+const DefaultOrganizationID uint = 1
+
+// TableName returns the database table name for the Organization model.
+// This method implements the GORM Tabler interface to specify custom table names.
+func (Organization) TableName() string {
+	return "Organizations"
+}
+
+// Organization represents a shelter or rescue that owns pets and has its own staff and settings.
+//
+// Database Table: Organizations
+// Relationships:
+//   - Pets: One-to-Many relationship with Pet (foreign key: OrganizationID)
+//   - Species: One-to-Many relationship with Species (foreign key: OrganizationID)
+//   - Members: One-to-Many relationship with OrganizationMember (foreign key: OrganizationID)
+//
+// Business Rules:
+//   - Slugs are unique and used in public URLs
+//   - Emails about the organisation's pets are sent with its sender identity, when configured
+//   - The adoption questionnaire is shown to adopters of the organisation's pets
+type Organization struct {
+	ID                    uint                    `json:"id" gorm:"primaryKey;autoIncrement"`                      // Unique identifier for the organisation
+	Name                  string                  `json:"name" gorm:"type:varchar(150);not null"`                  // Display name
+	Slug                  string                  `json:"slug" gorm:"type:varchar(60);not null;uniqueIndex"`       // URL identifier (lowercase letters, digits and dashes)
+	Email                 string                  `json:"email" gorm:"type:varchar(255)"`                          // Public contact email
+	Phone                 string                  `json:"phone" gorm:"type:varchar(30)"`                           // Public contact phone
+	Address               string                  `json:"address" gorm:"type:varchar(255)"`                        // Postal address
+	Website               string                  `json:"website" gorm:"type:varchar(255)"`                        // Website URL
+	SenderName            string                  `json:"sender_name" gorm:"type:varchar(100)"`                    // Email sender display name (optional)
+	SenderEmail           string                  `json:"sender_email" gorm:"type:varchar(255)"`                   // Email sender address (optional, must be allowed by the SMTP server)
+	ReplyTo               string                  `json:"reply_to" gorm:"type:varchar(255)"`                       // Reply-To address for emails (optional)
+	AdoptionQuestionnaire []QuestionnaireQuestion `json:"adoption_questionnaire" gorm:"type:json;serializer:json"` // Questions asked to adopters
+	CrtDate               time.Time               `json:"crt_date" gorm:"autoCreateTime"`                          // Record creation timestamp
+	UptDate               time.Time               `json:"upt_date" gorm:"autoUpdateTime"`                          // Record last update timestamp
+}
+
+// QuestionnaireQuestion is one question of an organisation's adoption questionnaire.
+//
+// Business Rules:
+//   - Keys are unique within the questionnaire and identify answers
+//   - Options are only used (and required) by choice questions
+type QuestionnaireQuestion struct {
+	Key      string   `json:"key"`               // Stable identifier of the question (e.g. "has_garden")
+	Label    string   `json:"label"`             // Question text shown to adopters
+	Type     string   `json:"type"`              // text, textarea, yes_no, number or choice
+	Options  []string `json:"options,omitempty"` // Allowed answers of choice questions
+	Required bool     `json:"required"`          // Whether an answer is mandatory
+}
+
+// TableName returns the database table name for the OrganizationMember model.
+// This method implements the GORM Tabler interface to specify custom table names.
+func (OrganizationMember) TableName() string {
+	return "Organization_Members"
+}
+
+// OrganizationMember represents a staff user's membership of an organisation.
+//
+// Database Table: Organization_Members
+// Relationships:
+//   - Organization: Many-to-One relationship with Organization (foreign key: OrganizationID)
+//   - User: Many-to-One relationship with User (foreign key: UserID)
+//
+// Business Rules:
+//   - A user is a member of each organisation at most once
+//   - Staff can only manage the pets and species of organisations they are members of
+//   - Administrators act as managers of every organisation without a membership
+type OrganizationMember struct {
+	ID             uint            `json:"id" gorm:"primaryKey;autoIncrement"`                       // Unique identifier for the membership
+	OrganizationID uint            `json:"organization_id" gorm:"not null;uniqueIndex:idx_org_user"` // Organisation
+	UserID         uint            `json:"user_id" gorm:"not null;uniqueIndex:idx_org_user;index"`   // Member user
+	User           *SimplifiedUser `json:"user,omitempty" gorm:"-"`                                  // Member user summary (filled by the dao)
+	Role           string          `json:"role" gorm:"type:varchar(20);not null;default:'staff'"`    // staff or manager
+	CrtDate        time.Time       `json:"crt_date" gorm:"autoCreateTime"`                           // When the user joined the organisation
+}
+
+// IsManager reports whether the membership allows managing the organisation's settings and members.
+func (o OrganizationMember) IsManager() bool {
+	return o.Role == OrgRoleManager
+}
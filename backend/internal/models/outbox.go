@@ -0,0 +1,48 @@
+// Package models contains data models for the pet adoption system.
+// These models define the structure of the email outbox, the persistent queue
+// every email goes through before it is sent.
+package models
+
+import "time"
+
+// Outbox email statuses.
+const (
+	OutboxEmailPending = "pending" // Waiting for its first attempt or a retry
+	OutboxEmailSent    = "sent"    // Accepted by the mail server
+	OutboxEmailFailed  = "failed"  // Dead letter: rejected or out of attempts; only an admin resend sends it again, until its message is cleared
+)
+
+// OutboxEmailStatuses lists every valid outbox email status.
+var OutboxEmailStatuses = []string{OutboxEmailPending, OutboxEmailSent, OutboxEmailFailed}
+
+// TableName returns the database table name for the OutboxEmail model.
+// This method implements the GORM Tabler interface to specify custom table names.
+func (OutboxEmail) TableName() string {
+	return "Email_Outbox"
+}
+
+// OutboxEmail represents an email queued to be sent.
+//
+// Database Table: Email_Outbox
+//
+// Business Rules:
+//   - Services write emails to the outbox, in the same transaction as their changes when needed; a worker sends them
+//   - Failed attempts are retried with exponential backoff until MAIL_MAX_ATTEMPTS
+//   - Permanent rejections (5xx SMTP replies) and emails out of attempts are dead-lettered as failed
+//   - The message is stored ready to send; it is never returned by the API because it may hold codes or passwords
+//   - The message is cleared once the email is sent, and MAIL_FAILED_RETENTION after it is dead-lettered
+type OutboxEmail struct {
+	ID            uint       `json:"id" gorm:"primaryKey;autoIncrement"`         // Unique identifier for the email
+	Sender        string     `json:"sender" gorm:"type:varchar(255);not null"`   // Envelope sender address
+	Recipients    []string   `json:"recipients" gorm:"serializer:json;not null"` // Envelope recipient addresses
+	Subject       string     `json:"subject" gorm:"type:varchar(255);not null"`  // Subject, for the admin log
+	Message       []byte     `json:"-" gorm:"type:mediumblob;not null"`          // Complete MIME message, empty once cleared
+	Status        string     `json:"status" gorm:"type:varchar(10);not null"`    // pending, sent or failed
+	Attempts      int        `json:"attempts" gorm:"not null;default:0"`         // Attempts made so far
+	NextAttemptAt *time.Time `json:"next_attempt_at" gorm:"index"`               // When the next attempt is due (pending only)
+	LastAttemptAt *time.Time `json:"last_attempt_at"`                            // When the latest attempt was made
+	LastError     string     `json:"last_error" gorm:"type:varchar(500)"`        // Error of the latest failed attempt
+	SentAt        *time.Time `json:"sent_at"`                                    // When the mail server accepted the email
+	CrtDate       time.Time  `json:"crt_date" gorm:"autoCreateTime"`             // When the email was queued
+	UptDate       time.Time  `json:"upt_date" gorm:"autoUpdateTime"`             // Record last update timestamp
+}
@@ -0,0 +1,154 @@
+// Package models contains data models for the pet adoption system.
+// These models define the structure of adoption fee schedules, payments and refunds.
+package models
+
+import "time"
+
+// Payment statuses.
+const (
+	PaymentStatusPending           = "pending"            // Checkout created, waiting for the payer
+	PaymentStatusPaid              = "paid"               // Paid in full
+	PaymentStatusFailed            = "failed"             // Payment rejected by the provider
+	PaymentStatusExpired           = "expired"            // Checkout abandoned or expired
+	PaymentStatusPartiallyRefunded = "partially_refunded" // Paid, part of the amount refunded
+	PaymentStatusRefunded          = "refunded"           // Paid, whole amount refunded
+	PaymentStatusReview            = "review"             // Paid with another amount or currency than expected, to check with the provider
+)
+
+// PaymentStatuses lists every valid payment status.
+var PaymentStatuses = []string{
+	PaymentStatusPending,
+	PaymentStatusPaid,
+	PaymentStatusFailed,
+	PaymentStatusExpired,
+	PaymentStatusPartiallyRefunded,
+	PaymentStatusRefunded,
+	PaymentStatusReview,
+}
+
+// TableName returns the database table name for the FeeSchedule model.
+// This method implements the GORM Tabler interface to specify custom table names.
+func (FeeSchedule) TableName() string {
+	return "Fee_Schedules"
+}
+
+// FeeSchedule represents an adoption fee of an organisation for pets of a species and age range.
+//
+// Database Table: Fee_Schedules
+// Relationships:
+//   - Organization: Many-to-One relationship with Organization (foreign key: OrganizationID)
+//
+// Business Rules:
+//   - An empty Species applies to every species; a nil MaxAgeMonths has no upper age limit
+//   - Ages are in whole months: a pet matches when MinAgeMonths <= age < MaxAgeMonths
+//   - Schedules for the pet's species win over generic ones, then the narrowest age range wins
+//   - Pets with an unknown birth date only match schedules without age limits
+type FeeSchedule struct {
+	ID             uint      `json:"id" gorm:"primaryKey;autoIncrement"`        // Unique identifier for the schedule
+	OrganizationID uint      `json:"organization_id" gorm:"not null;index"`     // Organisation the fee belongs to
+	Name           string    `json:"name" gorm:"type:varchar(100);not null"`    // Label shown to staff, e.g. "Cachorros"
+	Species        string    `json:"species" gorm:"type:varchar(100);not null"` // Species the fee applies to (empty for any)
+	MinAgeMonths   int       `json:"min_age_months" gorm:"not null;default:0"`  // Minimum age in months (inclusive)
+	MaxAgeMonths   *int      `json:"max_age_months"`                            // Maximum age in months (exclusive, nil for none)
+	AmountCents    int64     `json:"amount_cents" gorm:"not null"`              // Fee in cents
+	Currency       string    `json:"currency" gorm:"type:varchar(3);not null"`  // ISO 4217 currency code
+	CrtDate        time.Time `json:"crt_date" gorm:"autoCreateTime"`            // Record creation timestamp
+	UptDate        time.Time `json:"upt_date" gorm:"autoUpdateTime"`            // Record last update timestamp
+}
+
+// FeeQuote is the adoption fee that applies to a pet.
+type FeeQuote struct {
+	PetID       uint   `json:"pet_id"`                // Pet the fee was computed for
+	ScheduleID  *uint  `json:"schedule_id,omitempty"` // Matching schedule (nil when no schedule applies)
+	Name        string `json:"name,omitempty"`        // Label of the matching schedule
+	AgeMonths   *int   `json:"age_months,omitempty"`  // Age of the pet in months (nil when unknown)
+	AmountCents int64  `json:"amount_cents"`          // Fee in cents (0 when no schedule applies)
+	Currency    string `json:"currency"`              // ISO 4217 currency code
+}
+
+// TableName returns the database table name for the Payment model.
+// This method implements the GORM Tabler interface to specify custom table names.
+func (Payment) TableName() string {
+	return "Payments"
+}
+
+// Payment represents a payment of an adoption fee or a donation through a payment provider's hosted checkout.
+//
+// Database Table: Payments
+// Relationships:
+//   - Adoption: Many-to-One relationship with Adoption (foreign key: AdoptionID)
+//   - Donation: Many-to-One relationship with Donation (foreign key: DonationID)
+//   - Refunds: One-to-Many relationship with PaymentRefund
+//
+// Business Rules:
+//   - Every payment belongs to either an adoption or a donation
+//   - The payer is redirected to CheckoutURL; the provider confirms the outcome through a signed webhook
+//   - Refunds never exceed the paid amount
+type Payment struct {
+	ID             uint            `json:"id" gorm:"primaryKey;autoIncrement"`                              // Unique identifier for the payment
+	OrganizationID uint            `json:"organization_id" gorm:"not null;index"`                           // Organisation receiving the payment
+	AdoptionID     *uint           `json:"adoption_id,omitempty" gorm:"index"`                              // Adoption whose fee is paid
+	DonationID     *uint           `json:"donation_id,omitempty" gorm:"index"`                              // Donation being paid
+	Provider       string          `json:"provider" gorm:"type:varchar(30);not null"`                       // Payment provider name
+	CheckoutID     *string         `json:"checkout_id" gorm:"type:varchar(100)"`                            // Provider's checkout identifier
+	CheckoutURL    string          `json:"checkout_url,omitempty" gorm:"type:varchar(500)"`                 // Hosted checkout page the payer is sent to
+	AmountCents    int64           `json:"amount_cents" gorm:"not null"`                                    // Amount charged in cents
+	Currency       string          `json:"currency" gorm:"type:varchar(3);not null"`                        // ISO 4217 currency code
+	Status         string          `json:"status" gorm:"type:varchar(20);not null;default:'pending';index"` // Payment status
+	RefundedCents  int64           `json:"refunded_cents" gorm:"not null;default:0"`                        // Amount refunded so far in cents
+	FailureReason  string          `json:"failure_reason,omitempty" gorm:"type:varchar(255)"`               // Why the payment failed (failed payments only)
+	PaidAt         *time.Time      `json:"paid_at"`                                                         // When the provider confirmed the payment
+	CreatedBy      uint            `json:"created_by,omitempty"`                                            // User who started the checkout
+	Refunds        []PaymentRefund `json:"refunds,omitempty" gorm:"foreignKey:PaymentID"`                   // Refunds of the payment (relationship)
+	CrtDate        time.Time       `json:"crt_date" gorm:"autoCreateTime"`                                  // Record creation timestamp
+	UptDate        time.Time       `json:"upt_date" gorm:"autoUpdateTime"`                                  // Record last update timestamp
+}
+
+// NetCents returns the amount kept by the organisation: the paid amount minus refunds.
+func (p Payment) NetCents() int64 {
+	if p.PaidAt == nil {
+		return 0
+	}
+
+	return p.AmountCents - p.RefundedCents
+}
+
+// TableName returns the database table name for the PaymentRefund model.
+// This method implements the GORM Tabler interface to specify custom table names.
+func (PaymentRefund) TableName() string {
+	return "Payment_Refunds"
+}
+
+// PaymentRefund represents a full or partial refund of a payment.
+//
+// Database Table: Payment_Refunds
+// Relationships:
+//   - Payment: Many-to-One relationship with Payment (foreign key: PaymentID)
+type PaymentRefund struct {
+	ID               uint      `json:"id" gorm:"primaryKey;autoIncrement"`          // Unique identifier for the refund
+	PaymentID        uint      `json:"payment_id" gorm:"not null;index"`            // Refunded payment
+	ProviderRefundID string    `json:"provider_refund_id" gorm:"type:varchar(100)"` // Provider's refund identifier
+	AmountCents      int64     `json:"amount_cents" gorm:"not null"`                // Refunded amount in cents
+	Reason           string    `json:"reason,omitempty" gorm:"type:varchar(500)"`   // Why the payment was refunded
+	CreatedBy        uint      `json:"created_by"`                                  // Staff user who issued the refund
+	CrtDate          time.Time `json:"crt_date" gorm:"autoCreateTime"`              // Record creation timestamp
+}
+
+// TableName returns the database table name for the PaymentEvent model.
+// This method implements the GORM Tabler interface to specify custom table names.
+func (PaymentEvent) TableName() string {
+	return "Payment_Events"
+}
+
+// PaymentEvent records a webhook event received from a payment provider.
+// The unique (Provider, EventID) pair makes webhook processing idempotent: duplicated deliveries are ignored.
+//
+// Database Table: Payment_Events
+type PaymentEvent struct {
+	ID         uint      `json:"id" gorm:"primaryKey;autoIncrement"`                                       // Unique identifier for the record
+	Provider   string    `json:"provider" gorm:"type:varchar(30);not null;uniqueIndex:idx_payment_event"`  // Provider that sent the event
+	EventID    string    `json:"event_id" gorm:"type:varchar(100);not null;uniqueIndex:idx_payment_event"` // Provider's event identifier
+	Type       string    `json:"type" gorm:"type:varchar(30);not null"`                                    // Event type
+	PaymentID  *uint     `json:"payment_id"`                                                               // Payment the event refers to (nil if unknown)
+	ReceivedAt time.Time `json:"received_at" gorm:"autoCreateTime"`                                        // When the event was processed
+}
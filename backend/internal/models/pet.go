@@ -4,6 +4,18 @@ package models
 
 import "time"
 
+// Pet adoption statuses.
+// A pet starts as available, may be reserved while an adoption is in progress,
+// and ends as adopted. IsAdopted is kept in sync with PetStatusAdopted.
+const (
+	PetStatusAvailable = "available"
+	PetStatusReserved  = "reserved"
+	PetStatusAdopted   = "adopted"
+)
+
+// PetStatuses lists every valid pet status.
+var PetStatuses = []string{PetStatusAvailable, PetStatusReserved, PetStatusAdopted}
+
 // TableName returns the database table name for the Pet model.
 // This method implements the GORM Tabler interface to specify custom table names.
 func (Pet) TableName() string {
@@ -17,19 +29,34 @@ func (Pet) TableName() string {
 // Database Table: Pets
 // Relationships:
 //   - AdoptUser: Many-to-One relationship with User (foreign key: AdoptUserID)
+//   - Photos: One-to-Many relationship with PetPhoto (foreign key: PetID)
+//   - Organization: Many-to-One relationship with Organization (foreign key: OrganizationID)
+//
+// Business Rules:
+//   - Microchip numbers are stored normalised (digits only) and are unique across pets
+//   - Every pet belongs to one organisation, whose staff are the only ones allowed to manage it
 type Pet struct {
-	ID          uint      `json:"id" gorm:"primaryKey;autoIncrement"`        // Unique identifier for the pet
-	Name        string    `json:"name" gorm:"type:varchar(100);not null"`    // Pet's name
-	Species     string    `json:"species" gorm:"type:varchar(100);not null"` // Pet's species (dog, cat, etc.)
-	Breed       string    `json:"breed" gorm:"type:varchar(100)"`            // Pet's breed (optional)
-	IsAdopted   bool      `json:"is_adopted" gorm:"default:false"`           // Whether the pet has been adopted
-	BirthDate   time.Time `json:"birth_date"`                                // Pet's date of birth
-	AdoptDate   time.Time `json:"adopt_date"`                                // Date when the pet was adopted
-	Description string    `json:"description" gorm:"type:text"`              // Detailed description of the pet
-	AdoptUserID uint      `json:"adopt_user_id"`                             // ID of the user who adopted the pet
-	AdoptUser   User      `json:"adopt_user" gorm:"foreignKey:AdoptUserID"`  // User who adopted the pet (relationship)
-	CrtDate     time.Time `json:"crt_date" gorm:"autoCreateTime"`            // Record creation timestamp
-	UptDate     time.Time `json:"upt_date" gorm:"autoUpdateTime"`            // Record last update timestamp
+	ID             uint       `json:"id" gorm:"primaryKey;autoIncrement"`                          // Unique identifier for the pet
+	OrganizationID uint       `json:"organization_id" gorm:"not null;index"`                       // Organisation that owns the pet
+	Name           string     `json:"name" gorm:"type:varchar(100);not null"`                      // Pet's name
+	Species        string     `json:"species" gorm:"type:varchar(100);not null"`                   // Pet's species (dog, cat, etc.)
+	Breed          string     `json:"breed" gorm:"type:varchar(100)"`                              // Pet's breed (optional)
+	Status         string     `json:"status" gorm:"type:varchar(20);not null;default:'available'"` // Adoption status (available, reserved, adopted)
+	IsAdopted      bool       `json:"is_adopted" gorm:"default:false"`                             // Whether the pet has been adopted
+	BirthDate      time.Time  `json:"birth_date"`                                                  // Pet's date of birth
+	AdoptDate      time.Time  `json:"adopt_date"`                                                  // Date when the pet was adopted
+	Description    string     `json:"description" gorm:"type:text"`                                // Detailed description of the pet
+	Color          string     `json:"color" gorm:"type:varchar(50)"`                               // Coat colour(s), e.g. "negro y blanco"
+	Microchip      *string    `json:"microchip" gorm:"type:varchar(15);uniqueIndex"`               // ISO 11784/11785 microchip number (15 digits, unique, optional)
+	AdoptUserID    uint       `json:"adopt_user_id"`                                               // ID of the user who adopted the pet
+	AdoptUser      User       `json:"-" gorm:"foreignKey:AdoptUserID"`                             // User who adopted the pet (relationship, never serialised)
+	Photos         []PetPhoto `json:"photos" gorm:"foreignKey:PetID"`                              // Pet photos ordered by position (relationship)
+	CrtDate        time.Time  `json:"crt_date" gorm:"autoCreateTime"`                              // Record creation timestamp
+	UptDate        time.Time  `json:"upt_date" gorm:"autoUpdateTime"`                              // Record last update timestamp
+
+	Favorited     bool            `json:"favorited" gorm:"-"`                // Whether the caller favourited the pet (false for anonymous callers)
+	FavoriteCount *int64          `json:"favorite_count,omitempty" gorm:"-"` // Number of users who favourited the pet (staff only)
+	Sponsorship   *PetSponsorship `json:"sponsorship,omitempty" gorm:"-"`    // Public total of donations designated to the pet (pet detail only)
 }
 
 // SimplifiedPet represents a minimal pet entity with essential information.
@@ -38,13 +65,43 @@ type Pet struct {
 //
 // Business Rules:
 //   - Used primarily for listing and summary operations
-//   - Includes adoption status and user information for quick reference
+//   - Includes adoption status for quick reference, never the adopter's data
 //   - Excludes detailed fields like description and dates for performance
 type SimplifiedPet struct {
-	ID        uint   `json:"id"`         // Unique identifier for the pet
-	Name      string `json:"name"`       // Pet's name
-	Species   string `json:"species"`    // Pet's species (dog, cat, etc.)
-	Breed     string `json:"breed"`      // Pet's breed (optional)
-	IsAdopted bool   `json:"is_adopted"` // Whether the pet has been adopted
-	AdoptUser User   `json:"adopt_user"` // User who adopted the pet (if adopted)
+	ID             uint   `json:"id"`              // Unique identifier for the pet
+	OrganizationID uint   `json:"organization_id"` // Organisation that owns the pet
+	Name           string `json:"name"`            // Pet's name
+	Species        string `json:"species"`         // Pet's species (dog, cat, etc.)
+	Breed          string `json:"breed"`           // Pet's breed (optional)
+	Status         string `json:"status"`          // Adoption status (available, reserved, adopted)
+	IsAdopted      bool   `json:"is_adopted"`      // Whether the pet has been adopted
+
+	PrimaryPhoto *PetPhoto `json:"primary_photo,omitempty"` // Main photo used in cards and lists (if any)
+
+	Favorited     bool   `json:"favorited"`                // Whether the caller favourited the pet (false for anonymous callers)
+	FavoriteCount *int64 `json:"favorite_count,omitempty"` // Number of users who favourited the pet (staff only)
+}
+
+// MicrochipLookup is the result of looking up a pet by its microchip number.
+//
+// Business Rules:
+//   - Adopter contact details are only included for staff
+type MicrochipLookup struct {
+	PetID        uint            `json:"pet_id"`                  // Unique identifier of the pet
+	Name         string          `json:"name"`                    // Pet's name
+	Species      string          `json:"species"`                 // Pet's species
+	Breed        string          `json:"breed"`                   // Pet's breed
+	Status       string          `json:"status"`                  // Adoption status
+	Microchip    string          `json:"microchip"`               // Normalised microchip number
+	PrimaryPhoto *PetPhoto       `json:"primary_photo,omitempty"` // Main photo to confirm the identification (if any)
+	Adopter      *AdopterContact `json:"adopter,omitempty"`       // Adopter contact details (staff only, adopted pets only)
+}
+
+// AdopterContact holds the contact details of the user who adopted a pet.
+type AdopterContact struct {
+	ID      uint   `json:"id"`      // Adopter user ID
+	Name    string `json:"name"`    // Adopter's first name
+	Surname string `json:"surname"` // Adopter's last name
+	Email   string `json:"email"`   // Adopter's email address
+	Address string `json:"address"` // Adopter's physical address
 }
@@ -0,0 +1,28 @@
+// Package models contains data models for the pet adoption system.
+// These models define the structure of pet favourites.
+package models
+
+import "time"
+
+// TableName returns the database table name for the PetFavorite model.
+// This method implements the GORM Tabler interface to specify custom table names.
+func (PetFavorite) TableName() string {
+	return "Pet_Favorites"
+}
+
+// PetFavorite represents a pet added to a user's favourites (watchlist).
+//
+// Database Table: Pet_Favorites
+// Relationships:
+//   - User: Many-to-One relationship with User (foreign key: UserID)
+//   - Pet: Many-to-One relationship with Pet (foreign key: PetID)
+//
+// Business Rules:
+//   - A user can favourite each pet only once
+//   - Users are emailed when a favourited pet becomes reserved or adopted
+type PetFavorite struct {
+	ID      uint      `json:"id" gorm:"primaryKey;autoIncrement"`                    // Unique identifier for the favourite
+	UserID  uint      `json:"user_id" gorm:"not null;uniqueIndex:idx_user_pet"`      // User who favourited the pet
+	PetID   uint      `json:"pet_id" gorm:"not null;uniqueIndex:idx_user_pet;index"` // Favourited pet
+	CrtDate time.Time `json:"crt_date" gorm:"autoCreateTime"`                        // When the pet was favourited
+}
@@ -0,0 +1,40 @@
+// Package models contains data models for the pet adoption system.
+// These models define the structure of pet photo database entities.
+package models
+
+import "time"
+
+// TableName returns the database table name for the PetPhoto model.
+// This method implements the GORM Tabler interface to specify custom table names.
+func (PetPhoto) TableName() string {
+	return "Pet_Photos"
+}
+
+// PetPhoto represents an image uploaded for a pet.
+// The original image and its generated thumbnails are stored in the configured
+// storage backend under a common key prefix (StorageKey).
+//
+// Business Rules:
+//   - Photos are ordered by Position (ascending) when displayed
+//   - Exactly one photo per pet is marked as primary while the pet has photos
+//   - Stored images are re-encoded, so EXIF metadata is never kept
+//
+// Database Table: Pet_Photos
+// Relationships:
+//   - Pet: Many-to-One relationship with Pet (foreign key: PetID)
+type PetPhoto struct {
+	ID          uint              `json:"id" gorm:"primaryKey;autoIncrement"`            // Unique identifier for the photo
+	PetID       uint              `json:"pet_id" gorm:"not null;index"`                  // ID of the pet the photo belongs to
+	StorageKey  string            `json:"-" gorm:"type:varchar(255);not null"`           // Key prefix of the stored objects
+	ContentType string            `json:"content_type" gorm:"type:varchar(50);not null"` // MIME type of the stored original
+	Extension   string            `json:"-" gorm:"type:varchar(10);not null"`            // File extension of the stored original
+	Size        int64             `json:"size"`                                          // Size in bytes of the stored original
+	Width       int               `json:"width"`                                         // Width in pixels of the stored original
+	Height      int               `json:"height"`                                        // Height in pixels of the stored original
+	Position    int               `json:"position" gorm:"default:0"`                     // Display order (ascending)
+	IsPrimary   bool              `json:"is_primary" gorm:"default:false"`               // Whether this is the pet's main photo
+	URL         string            `json:"url" gorm:"-"`                                  // Download URL of the original (computed)
+	Thumbnails  map[string]string `json:"thumbnails" gorm:"-"`                           // Download URLs by thumbnail size (computed)
+	CrtDate     time.Time         `json:"crt_date" gorm:"autoCreateTime"`                // Record creation timestamp
+	UptDate     time.Time         `json:"upt_date" gorm:"autoUpdateTime"`                // Record last update timestamp
+}
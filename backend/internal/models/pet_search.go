@@ -0,0 +1,23 @@
+// Package models contains data models for the pet adoption system.
+// These models define the structure of pet search results.
+package models
+
+// PetSearchResult represents a pet returned by full-text search.
+// It extends the simplified pet with its relevance score and the matched text.
+//
+// Business Rules:
+//   - Results are ordered by Relevance unless another sort is requested
+//   - Highlights contain HTML-escaped text with matches wrapped in <mark></mark>
+type PetSearchResult struct {
+	SimplifiedPet
+	Relevance  float64             `json:"relevance"`  // Database ranking score (higher is more relevant)
+	Highlights PetSearchHighlights `json:"highlights"` // Highlighted fragments of the matched fields
+}
+
+// PetSearchHighlights holds the highlighted fragments of a search result.
+// Fields that did not match the query are left empty.
+type PetSearchHighlights struct {
+	Name        string `json:"name,omitempty"`        // Highlighted pet name
+	Breed       string `json:"breed,omitempty"`       // Highlighted breed
+	Description string `json:"description,omitempty"` // Snippet of the description around the first match
+}
@@ -0,0 +1,64 @@
+// Package models contains data models for the pet adoption system.
+// These models define the structure of saved searches and their email alerts.
+package models
+
+import "time"
+
+// TableName returns the database table name for the SavedSearch model.
+// This method implements the GORM Tabler interface to specify custom table names.
+func (SavedSearch) TableName() string {
+	return "Saved_Searches"
+}
+
+// SavedSearch represents a pet search stored by a user to be alerted about new matches.
+// Every criterion is optional; empty criteria match any pet.
+//
+// Database Table: Saved_Searches
+// Relationships:
+//   - User: Many-to-One relationship with User (foreign key: UserID)
+//
+// Business Rules:
+//   - A pet matches when it is available and meets every non-empty criterion
+//   - Keywords must all appear in the pet name, breed or description (Spanish stemming applies)
+//   - Alerts can be disabled without deleting the search (e.g. from the unsubscribe link)
+type SavedSearch struct {
+	ID            uint      `json:"id" gorm:"primaryKey;autoIncrement"`          // Unique identifier for the saved search
+	UserID        uint      `json:"user_id" gorm:"not null;index"`               // Owner of the saved search
+	Name          string    `json:"name" gorm:"type:varchar(100);not null"`      // Display name chosen by the user
+	Species       string    `json:"species" gorm:"type:varchar(100)"`            // Exact species (optional)
+	Breed         string    `json:"breed" gorm:"type:varchar(100)"`              // Breed contains this value (optional)
+	AgeMin        *int      `json:"age_min"`                                     // Minimum age in whole years (optional)
+	AgeMax        *int      `json:"age_max"`                                     // Maximum age in whole years (optional)
+	Keywords      string    `json:"keywords" gorm:"type:varchar(200)"`           // Free-text keywords (optional)
+	AlertsEnabled bool      `json:"alerts_enabled" gorm:"not null;default:true"` // Whether matches are emailed
+	CrtDate       time.Time `json:"crt_date" gorm:"autoCreateTime"`              // Record creation timestamp
+	UptDate       time.Time `json:"upt_date" gorm:"autoUpdateTime"`              // Record last update timestamp
+}
+
+// TableName returns the database table name for the SavedSearchMatch model.
+// This method implements the GORM Tabler interface to specify custom table names.
+func (SavedSearchMatch) TableName() string {
+	return "Saved_Search_Matches"
+}
+
+// SavedSearchMatch represents a pet that matched a saved search and is queued for the
+// owner's daily digest email.
+//
+// Database Table: Saved_Search_Matches
+// Relationships:
+//   - SavedSearch: Many-to-One relationship with SavedSearch (foreign key: SavedSearchID)
+//   - Pet: Many-to-One relationship with Pet (foreign key: PetID)
+//
+// Business Rules:
+//   - A pet is queued at most once per saved search
+//   - NotifiedAt is set when the match is included in a digest; it also limits digests to one per day
+type SavedSearchMatch struct {
+	ID            uint        `json:"id" gorm:"primaryKey;autoIncrement"`                         // Unique identifier for the match
+	SavedSearchID uint        `json:"saved_search_id" gorm:"not null;uniqueIndex:idx_search_pet"` // Matched saved search
+	SavedSearch   SavedSearch `json:"-" gorm:"foreignKey:SavedSearchID"`                          // Matched saved search (relationship)
+	UserID        uint        `json:"user_id" gorm:"not null;index"`                              // Owner of the saved search (denormalised for digests)
+	PetID         uint        `json:"pet_id" gorm:"not null;uniqueIndex:idx_search_pet"`          // Matching pet
+	Pet           Pet         `json:"-" gorm:"foreignKey:PetID"`                                  // Matching pet (relationship)
+	NotifiedAt    *time.Time  `json:"notified_at"`                                                // When the match was emailed (nil while pending)
+	CrtDate       time.Time   `json:"crt_date" gorm:"autoCreateTime"`                             // Record creation timestamp
+}
@@ -12,15 +12,16 @@ func (Species) TableName() string {
 // This model defines the types of animals that can be registered for adoption.
 //
 // Business Rules:
-//   - Species names must be unique across the system
+//   - Species belong to an organisation; names are unique within each organisation
 //   - Species are used to categorize pets for better organization and searching
 //   - Common species include: Dog, Cat, Bird, Rabbit, etc.
 //   - Species cannot be deleted if pets are associated with them
 //
 // Database Table: Species
 // Constraints:
-//   - Name field has a unique constraint to prevent duplicates
+//   - Name and OrganizationID have a composite unique constraint to prevent duplicates
 type Species struct {
-	ID   uint   `json:"id" gorm:"primaryKey;autoIncrement"`            // Unique identifier for the species
-	Name string `json:"name" gorm:"type:varchar(100);not null;unique"` // Species name (e.g., "Dog", "Cat", "Bird")
+	ID             uint   `json:"id" gorm:"primaryKey;autoIncrement"`                                      // Unique identifier for the species
+	OrganizationID uint   `json:"organization_id" gorm:"not null;uniqueIndex:idx_species_org_name"`        // Organisation that defined the species
+	Name           string `json:"name" gorm:"type:varchar(100);not null;uniqueIndex:idx_species_org_name"` // Species name (e.g., "Dog", "Cat", "Bird")
 }
@@ -4,6 +4,29 @@ package models
 
 import "time"
 
+// User roles.
+// Every account starts as a regular user; staff and admin roles are granted by
+// administrators directly in the database and cannot be set through the API.
+const (
+	UserRoleUser  = "user"
+	UserRoleStaff = "staff"
+	UserRoleAdmin = "admin"
+)
+
+// User locales.
+// The locale selects the language of the emails sent to the user; guests get DefaultLocale.
+const (
+	LocaleSpanish = "es"
+	LocaleEnglish = "en"
+	LocaleCatalan = "ca"
+)
+
+// DefaultLocale is the locale of new users and of emails sent to guests.
+const DefaultLocale = LocaleSpanish
+
+// Locales lists the supported user locales.
+var Locales = []string{LocaleSpanish, LocaleEnglish, LocaleCatalan}
+
 // TableName returns the database table name for the User model.
 // This method implements the GORM Tabler interface to specify custom table names.
 func (User) TableName() string {
@@ -19,15 +42,15 @@ func (User) TableName() string {
 //
 // Database Table: Users
 type FullUser struct {
-	ID            uint   `json:"id" gorm:"primaryKey;autoIncrement"`                               // Unique identifier for the user
-	Name          string `json:"name" gorm:"type:varchar(100);not null"`                           // User's first name
-	Surname       string `json:"surname" gorm:"type:varchar(100);not null"`                        // User's last name
-	Email         string `json:"email" gorm:"type:varchar(150);uniqueIndex;not null"`              // User's email address (unique)
-	SessionID     string `json:"session_id" gorm:"type:varchar(50);uniqueIndex;column:Session_ID"` // Current session identifier
-	Address       string `json:"address" gorm:"type:varchar(255)"`                                 // User's physical address
-	FailedLogins  uint   `json:"failed_logins" gorm:"default:0;column:Failed_Logins"`              // Count of failed login attempts
-	IsBlocked     bool   `json:"is_blocked" gorm:"default:false;column:Is_Blocked"`                // Whether the user account is blocked
-	TwoFactorAuth string `json:"two_factor_auth" gorm:"type:varchar(6);column:Two_Factor_Auth"`    // Two-factor authentication code
+	ID            uint   `json:"id" gorm:"primaryKey;autoIncrement"`                            // Unique identifier for the user
+	Name          string `json:"name" gorm:"type:varchar(100);not null"`                        // User's first name
+	Surname       string `json:"surname" gorm:"type:varchar(100);not null"`                     // User's last name
+	Email         string `json:"email" gorm:"type:varchar(150);uniqueIndex;not null"`           // User's email address (unique)
+	SessionID     string `json:"-" gorm:"type:varchar(50);uniqueIndex;column:Session_ID"`       // Current session identifier (never serialised)
+	Address       string `json:"address" gorm:"type:varchar(255)"`                              // User's physical address
+	FailedLogins  uint   `json:"failed_logins" gorm:"default:0;column:Failed_Logins"`           // Count of failed login attempts
+	IsBlocked     bool   `json:"is_blocked" gorm:"default:false;column:Is_Blocked"`             // Whether the user account is blocked
+	TwoFactorAuth string `json:"two_factor_auth" gorm:"type:varchar(6);column:Two_Factor_Auth"` // Two-factor authentication code
 
 	Password   string `json:"password,omitempty" gorm:"type:varchar(255);column:Password"`       // Hashed password (omitted from JSON)
 	Provider   string `json:"provider" gorm:"default:'local';type:varchar(255);column:Provider"` // Authentication provider (local, google, etc.)
@@ -35,6 +58,10 @@ type FullUser struct {
 
 	ChangePassword bool `json:"change_password" gorm:"default:false;column:Change_Password"` // Flag indicating if user must change password on next login
 
+	Role string `json:"role" gorm:"type:varchar(20);not null;default:'user'"` // Access role (user, staff, admin)
+
+	Locale string `json:"locale" gorm:"type:varchar(5);not null;default:'es'"` // Language of the user's emails (es, en, ca)
+
 	CrtDate time.Time `json:"crt_date" gorm:"autoCreateTime"` // Record creation timestamp
 	UptDate time.Time `json:"upt_date" gorm:"autoUpdateTime"` // Record last update timestamp
 }
@@ -49,7 +76,7 @@ type User struct {
 	Name         string    `json:"name" gorm:"type:varchar(100);not null"`
 	Surname      string    `json:"surname" gorm:"type:varchar(100);not null"`
 	Email        string    `json:"email" gorm:"type:varchar(150);uniqueIndex;not null"`
-	SessionID    string    `json:"session_id" gorm:"type:varchar(50);uniqueIndex;column:Session_ID"`
+	SessionID    string    `json:"-" gorm:"type:varchar(50);uniqueIndex;column:Session_ID"` // Never serialised; only login responses carry it (see AuthenticatedUser)
 	Address      string    `json:"address" gorm:"type:varchar(255)"`
 	Provider     string    `json:"provider" gorm:"default:'local';type:varchar(255);column:Provider"` // Authentication provider (local, google, etc.)
 	ProviderID   string    `json:"provider_id" gorm:"type:varchar(255);column:Provider_ID"`           // Provider-specific user ID
@@ -57,6 +84,8 @@ type User struct {
 	ChangePass   bool      `json:"change_pass" gorm:"default:false;column:Change_Password"`
 	FailedLogins uint      `json:"failed_logins" gorm:"default:0;column:Failed_Logins"`
 	IsBlocked    bool      `json:"is_blocked" gorm:"default:false;column:Is_Blocked"`
+	Role         string    `json:"role" gorm:"type:varchar(20);not null;default:'user'"` // Access role (user, staff, admin)
+	Locale       string    `json:"locale" gorm:"type:varchar(5);not null;default:'es'"`  // Language of the user's emails (es, en, ca)
 	CrtDate      time.Time `json:"crt_date" gorm:"autoCreateTime"`
 	UptDate      time.Time `json:"upt_date" gorm:"autoUpdateTime"`
 }
@@ -75,10 +104,26 @@ type NonValidatedUser struct {
 	FailedLogins uint      `json:"failed_logins" gorm:"default:0;column:Failed_Logins"`
 	Provider     string    `json:"provider" gorm:"default:'local';type:varchar(255);column:Provider"` // Authentication provider (local, google, etc.)
 	IsBlocked    bool      `json:"is_blocked" gorm:"default:false;column:Is_Blocked"`
+	Role         string    `json:"role" gorm:"type:varchar(20);not null;default:'user'"` // Access role (user, staff, admin)
+	Locale       string    `json:"locale" gorm:"type:varchar(5);not null;default:'es'"`  // Language of the user's emails (es, en, ca)
 	CrtDate      time.Time `json:"crt_date" gorm:"autoCreateTime"`
 	UptDate      time.Time `json:"upt_date" gorm:"autoUpdateTime"`
 }
 
+// IsStaff reports whether the user can access staff features (staff or admin role).
+func (u NonValidatedUser) IsStaff() bool {
+	return u.Role == UserRoleStaff || u.Role == UserRoleAdmin
+}
+
+// AuthenticatedUser represents the response of a successful login.
+// It is the only payload that carries the session identifier, which the client
+// keeps to authenticate its next requests; every other user model hides it.
+type AuthenticatedUser struct {
+	NonValidatedUser
+	ChangePass bool   `json:"change_pass"` // Flag indicating if user must change password before continuing
+	SessionID  string `json:"session_id"`  // Session identifier generated by the login
+}
+
 // SimplifiedUser represents a minimal user entity with only essential information.
 // This model is used for operations that require only basic user data,
 // such as user lists, search results, or reference lookups.
@@ -90,4 +135,5 @@ type SimplifiedUser struct {
 	Surname string `json:"surname" gorm:"type:varchar(100);not null"`           // User's last name
 	Email   string `json:"email" gorm:"type:varchar(150);uniqueIndex;not null"` // User's email address (unique)
 	Address string `json:"address" gorm:"type:varchar(255)"`                    // User's physical address
+	Locale  string `json:"locale" gorm:"type:varchar(5)"`                       // Language of the user's emails (es, en, ca)
 }
@@ -0,0 +1,100 @@
+// Package models contains data models for the pet adoption system.
+// These models define the structure of meet-and-greet availability slots and the visits booked in them.
+package models
+
+import "time"
+
+// Visit statuses.
+const (
+	VisitStatusScheduled = "scheduled" // Booked and upcoming
+	VisitStatusCancelled = "cancelled" // Cancelled by the visitor or by staff (the slot is released)
+	VisitStatusCompleted = "completed" // The visitor met the pet
+	VisitStatusNoShow    = "no_show"   // The visitor did not come
+)
+
+// VisitStatuses lists every valid visit status.
+var VisitStatuses = []string{
+	VisitStatusScheduled,
+	VisitStatusCancelled,
+	VisitStatusCompleted,
+	VisitStatusNoShow,
+}
+
+// TableName returns the database table name for the VisitSlot model.
+// This method implements the GORM Tabler interface to specify custom table names.
+func (VisitSlot) TableName() string {
+	return "Visit_Slots"
+}
+
+// VisitSlot represents a time window in which staff can receive one visitor.
+//
+// Database Table: Visit_Slots
+// Relationships:
+//   - Organization: Many-to-One relationship with Organization (foreign key: OrganizationID)
+//   - Pet: Optional Many-to-One relationship with Pet (foreign key: PetID)
+//
+// Business Rules:
+//   - A slot holds at most one scheduled visit; Booked is set atomically when booking
+//   - Slots without a pet can be booked to meet any pet of the organisation
+//   - Slots of the same pet never overlap
+type VisitSlot struct {
+	ID             uint      `json:"id" gorm:"primaryKey;autoIncrement"`    // Unique identifier for the slot
+	OrganizationID uint      `json:"organization_id" gorm:"not null;index"` // Organisation receiving the visit
+	PetID          *uint     `json:"pet_id" gorm:"index"`                   // Pet the slot is reserved for (nil for any pet)
+	StartTime      time.Time `json:"start_time" gorm:"not null;index"`      // Start of the visit
+	EndTime        time.Time `json:"end_time" gorm:"not null"`              // End of the visit
+	Location       string    `json:"location" gorm:"type:varchar(255)"`     // Where the visitor is received
+	Booked         bool      `json:"booked" gorm:"not null;default:false"`  // Whether a scheduled visit holds the slot
+	CreatedBy      uint      `json:"created_by,omitempty"`                  // Staff user who created the slot (staff only)
+	CrtDate        time.Time `json:"crt_date" gorm:"autoCreateTime"`        // Record creation timestamp
+	UptDate        time.Time `json:"upt_date" gorm:"autoUpdateTime"`        // Record last update timestamp
+}
+
+// TableName returns the database table name for the Visit model.
+// This method implements the GORM Tabler interface to specify custom table names.
+func (Visit) TableName() string {
+	return "Visits"
+}
+
+// Visit represents a meet-and-greet between a prospective adopter and a pet.
+// The time and location are copied from the slot, so the visit keeps them if the slot is deleted later.
+//
+// Database Table: Visits
+// Relationships:
+//   - Slot: Many-to-One relationship with VisitSlot (foreign key: SlotID, nil once the slot is deleted)
+//   - Pet: Many-to-One relationship with Pet (foreign key: PetID)
+//   - User: Optional Many-to-One relationship with User (foreign key: UserID)
+//
+// Business Rules:
+//   - Anyone can book a free slot; signed-in visitors get the visit linked to their account
+//   - The visitor manages the visit (cancel, reschedule) through a signed link sent by email
+//   - Sequence increases on every change so calendar clients replace the previous invite
+//   - ApplicationID links the visit to an adoption application (plain reference, no constraint)
+type Visit struct {
+	ID             uint           `json:"id" gorm:"primaryKey;autoIncrement"`                        // Unique identifier for the visit
+	OrganizationID uint           `json:"organization_id" gorm:"not null;index"`                     // Organisation receiving the visit
+	SlotID         *uint          `json:"slot_id" gorm:"index"`                                      // Booked slot (nil once the slot is deleted)
+	PetID          uint           `json:"pet_id" gorm:"not null;index"`                              // Pet the visitor meets
+	Pet            *SimplifiedPet `json:"pet,omitempty" gorm:"-"`                                    // Pet summary (computed)
+	StartTime      time.Time      `json:"start_time" gorm:"not null;index"`                          // Start of the visit
+	EndTime        time.Time      `json:"end_time" gorm:"not null"`                                  // End of the visit
+	Location       string         `json:"location" gorm:"type:varchar(255)"`                         // Where the visitor is received
+	UserID         *uint          `json:"user_id,omitempty" gorm:"index"`                            // Registered user who booked the visit
+	VisitorName    string         `json:"visitor_name" gorm:"type:varchar(100);not null"`            // Name of the visitor
+	VisitorEmail   string         `json:"visitor_email" gorm:"type:varchar(255);not null"`           // Email the confirmation and reminder are sent to
+	VisitorPhone   string         `json:"visitor_phone" gorm:"type:varchar(30)"`                     // Contact phone of the visitor
+	ApplicationID  *uint          `json:"application_id" gorm:"index"`                               // Adoption application the visit belongs to (optional)
+	Notes          string         `json:"notes" gorm:"type:text"`                                    // Message from the visitor
+	Status         string         `json:"status" gorm:"type:varchar(20);not null;default:scheduled"` // Visit status
+	Sequence       int            `json:"-" gorm:"not null;default:0"`                               // Calendar revision (iCalendar SEQUENCE)
+	CancelledAt    *time.Time     `json:"cancelled_at"`                                              // Cancellation time
+	CancelReason   string         `json:"cancel_reason" gorm:"type:varchar(500)"`                    // Reason given when cancelling (optional)
+	ReminderSentAt *time.Time     `json:"reminder_sent_at,omitempty"`                                // When the reminder email was sent
+	CrtDate        time.Time      `json:"crt_date" gorm:"autoCreateTime"`                            // Record creation timestamp
+	UptDate        time.Time      `json:"upt_date" gorm:"autoUpdateTime"`                            // Record last update timestamp
+}
+
+// IsScheduled reports whether the visit is still upcoming and can be cancelled or rescheduled.
+func (v *Visit) IsScheduled() bool {
+	return v.Status == VisitStatusScheduled
+}
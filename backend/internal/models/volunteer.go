@@ -0,0 +1,212 @@
+// Package models contains data models for the pet adoption system.
+// These models define the structure of volunteers, their certifications, the shifts
+// they work and their signups.
+package models
+
+import "time"
+
+// Volunteer signup statuses.
+const (
+	SignupStatusSignedUp  = "signed_up" // Booked and upcoming (or waiting for attendance)
+	SignupStatusCancelled = "cancelled" // Cancelled by the volunteer (the place is released)
+	SignupStatusAttended  = "attended"  // The volunteer worked the shift
+	SignupStatusNoShow    = "no_show"   // The volunteer did not come
+)
+
+// SignupStatuses lists every valid signup status.
+var SignupStatuses = []string{
+	SignupStatusSignedUp,
+	SignupStatusCancelled,
+	SignupStatusAttended,
+	SignupStatusNoShow,
+}
+
+// TableName returns the database table name for the Volunteer model.
+// This method implements the GORM Tabler interface to specify custom table names.
+func (Volunteer) TableName() string {
+	return "Volunteers"
+}
+
+// Volunteer represents a user who helps an organisation in shifts (walking dogs, cleaning kennels...).
+// The volunteer signs in with their own user account to sign up for shifts.
+//
+// Database Table: Volunteers
+// Relationships:
+//   - Organization: Many-to-One relationship with Organization (foreign key: OrganizationID)
+//   - User: Many-to-One relationship with User (foreign key: UserID)
+//   - Certifications: One-to-Many relationship with VolunteerCertification (foreign key: VolunteerID)
+//   - Signups: One-to-Many relationship with VolunteerSignup (foreign key: VolunteerID)
+//
+// Business Rules:
+//   - A user is a volunteer of each organisation at most once
+//   - Only active volunteers can sign up for shifts
+//   - Volunteers keep their own contact details and skills up to date; Notes are internal
+type Volunteer struct {
+	ID               uint                     `json:"id" gorm:"primaryKey;autoIncrement"`                                 // Unique identifier for the volunteer
+	OrganizationID   uint                     `json:"organization_id" gorm:"not null;uniqueIndex:idx_volunteer_org_user"` // Organisation the volunteer helps
+	UserID           uint                     `json:"user_id" gorm:"not null;uniqueIndex:idx_volunteer_org_user;index"`   // User account of the volunteer
+	User             *SimplifiedUser          `json:"user,omitempty" gorm:"-"`                                            // Volunteer user summary (computed)
+	Phone            string                   `json:"phone" gorm:"type:varchar(30)"`                                      // Contact phone
+	Bio              string                   `json:"bio" gorm:"type:text"`                                               // Presentation written by the volunteer
+	Skills           []string                 `json:"skills" gorm:"serializer:json"`                                      // Skills, e.g. "paseos", "limpieza"
+	EmergencyContact string                   `json:"emergency_contact" gorm:"type:varchar(100)"`                         // Name of the emergency contact
+	EmergencyPhone   string                   `json:"emergency_phone" gorm:"type:varchar(30)"`                            // Phone of the emergency contact
+	Notes            string                   `json:"notes,omitempty" gorm:"type:text"`                                   // Internal notes (staff only)
+	Active           bool                     `json:"active" gorm:"not null;default:true"`                                // Whether the volunteer can sign up for shifts
+	Certifications   []VolunteerCertification `json:"certifications" gorm:"foreignKey:VolunteerID"`                       // Certifications (relationship)
+	CrtDate          time.Time                `json:"crt_date" gorm:"autoCreateTime"`                                     // Record creation timestamp
+	UptDate          time.Time                `json:"upt_date" gorm:"autoUpdateTime"`                                     // Record last update timestamp
+}
+
+// HasSkill reports whether the volunteer declared the given skill.
+func (v *Volunteer) HasSkill(skill string) bool {
+	for _, declared := range v.Skills {
+		if declared == skill {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasCertification reports whether the volunteer holds a certification with the given name valid on a date.
+func (v *Volunteer) HasCertification(name string, on time.Time) bool {
+	for _, certification := range v.Certifications {
+		if certification.Name == name && certification.IsValid(on) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// TableName returns the database table name for the VolunteerCertification model.
+// This method implements the GORM Tabler interface to specify custom table names.
+func (VolunteerCertification) TableName() string {
+	return "Volunteer_Certifications"
+}
+
+// VolunteerCertification represents a training or certificate held by a volunteer
+// (e.g. first aid for animals, handling of reactive dogs).
+//
+// Database Table: Volunteer_Certifications
+// Relationships:
+//   - Volunteer: Many-to-One relationship with Volunteer (foreign key: VolunteerID)
+//
+// Business Rules:
+//   - Certifications without an expiry date never expire
+//   - Shifts may require a certification valid on the day of the shift
+type VolunteerCertification struct {
+	ID          uint       `json:"id" gorm:"primaryKey;autoIncrement"`     // Unique identifier for the certification
+	VolunteerID uint       `json:"volunteer_id" gorm:"not null;index"`     // Volunteer holding the certification
+	Name        string     `json:"name" gorm:"type:varchar(100);not null"` // Certification name
+	IssuedBy    string     `json:"issued_by" gorm:"type:varchar(150)"`     // Issuing body (optional)
+	IssuedOn    *time.Time `json:"issued_on" gorm:"type:date"`             // Issue date (optional)
+	ExpiresOn   *time.Time `json:"expires_on" gorm:"type:date"`            // Expiry date (nil if it never expires)
+	CreatedBy   uint       `json:"created_by,omitempty"`                   // Staff user who recorded the certification
+	CrtDate     time.Time  `json:"crt_date" gorm:"autoCreateTime"`         // Record creation timestamp
+}
+
+// IsValid reports whether the certification is valid on a date.
+func (c *VolunteerCertification) IsValid(on time.Time) bool {
+	if c.ExpiresOn == nil {
+		return true
+	}
+
+	day := time.Date(on.Year(), on.Month(), on.Day(), 0, 0, 0, 0, time.UTC)
+	expires := time.Date(c.ExpiresOn.Year(), c.ExpiresOn.Month(), c.ExpiresOn.Day(), 0, 0, 0, 0, time.UTC)
+
+	return !day.After(expires)
+}
+
+// TableName returns the database table name for the VolunteerShift model.
+// This method implements the GORM Tabler interface to specify custom table names.
+func (VolunteerShift) TableName() string {
+	return "Volunteer_Shifts"
+}
+
+// VolunteerShift represents a time window in which the organisation needs volunteers.
+//
+// Database Table: Volunteer_Shifts
+// Relationships:
+//   - Organization: Many-to-One relationship with Organization (foreign key: OrganizationID)
+//   - Signups: One-to-Many relationship with VolunteerSignup (foreign key: ShiftID)
+//
+// Business Rules:
+//   - A shift never has more signups than its capacity; SignedUp is updated atomically with the signups
+//   - Cancelled signups release their place; attended and missed signups keep it
+//   - Signups close when the shift starts and cancellations VOLUNTEER_CANCEL_NOTICE before
+//   - RequiredSkill and RequiredCertification, when set, restrict who can sign up
+type VolunteerShift struct {
+	ID                    uint              `json:"id" gorm:"primaryKey;autoIncrement"`              // Unique identifier for the shift
+	OrganizationID        uint              `json:"organization_id" gorm:"not null;index"`           // Organisation the shift is for
+	Title                 string            `json:"title" gorm:"type:varchar(150);not null"`         // Short description, e.g. "Paseo de perros"
+	Description           string            `json:"description" gorm:"type:text"`                    // Details of the tasks
+	RequiredSkill         string            `json:"required_skill" gorm:"type:varchar(100)"`         // Skill volunteers must have (optional)
+	RequiredCertification string            `json:"required_certification" gorm:"type:varchar(100)"` // Certification volunteers must hold (optional)
+	StartTime             time.Time         `json:"start_time" gorm:"not null;index"`                // Start of the shift
+	EndTime               time.Time         `json:"end_time" gorm:"not null"`                        // End of the shift
+	Location              string            `json:"location" gorm:"type:varchar(255)"`               // Where volunteers meet
+	Capacity              int               `json:"capacity" gorm:"not null"`                        // Maximum number of volunteers
+	SignedUp              int               `json:"signed_up" gorm:"not null;default:0"`             // Signups holding a place
+	FreePlaces            int               `json:"free_places" gorm:"-"`                            // Places left (computed)
+	Joined                bool              `json:"joined" gorm:"-"`                                 // Whether the current volunteer signed up (computed)
+	Signups               []VolunteerSignup `json:"signups,omitempty" gorm:"-"`                      // Signups with their volunteer (computed, staff only)
+	CreatedBy             uint              `json:"created_by,omitempty"`                            // Staff user who created the shift (staff only)
+	CrtDate               time.Time         `json:"crt_date" gorm:"autoCreateTime"`                  // Record creation timestamp
+	UptDate               time.Time         `json:"upt_date" gorm:"autoUpdateTime"`                  // Record last update timestamp
+}
+
+// Minutes returns the scheduled length of the shift in minutes.
+func (s *VolunteerShift) Minutes() int {
+	return int(s.EndTime.Sub(s.StartTime).Minutes())
+}
+
+// TableName returns the database table name for the VolunteerSignup model.
+// This method implements the GORM Tabler interface to specify custom table names.
+func (VolunteerSignup) TableName() string {
+	return "Volunteer_Signups"
+}
+
+// VolunteerSignup represents a volunteer's place in a shift and, once the shift is over, their attendance.
+//
+// Database Table: Volunteer_Signups
+// Relationships:
+//   - Shift: Many-to-One relationship with VolunteerShift (foreign key: ShiftID)
+//   - Volunteer: Many-to-One relationship with Volunteer (foreign key: VolunteerID)
+//
+// Business Rules:
+//   - A volunteer has one signup per shift; signing up again after cancelling reuses it
+//   - Attended signups count towards the volunteer's hours: MinutesWorked, or the shift length when not set
+type VolunteerSignup struct {
+	ID             uint            `json:"id" gorm:"primaryKey;autoIncrement"`                                  // Unique identifier for the signup
+	OrganizationID uint            `json:"organization_id" gorm:"not null;index"`                               // Organisation of the shift
+	ShiftID        uint            `json:"shift_id" gorm:"not null;uniqueIndex:idx_signup_shift_volunteer"`     // Shift
+	Shift          *VolunteerShift `json:"shift,omitempty" gorm:"-"`                                            // Shift summary (computed, volunteer listings)
+	VolunteerID    uint            `json:"volunteer_id" gorm:"not null;uniqueIndex:idx_signup_shift_volunteer"` // Volunteer holding the place
+	UserID         uint            `json:"user_id" gorm:"not null;index"`                                       // User account of the volunteer
+	User           *SimplifiedUser `json:"user,omitempty" gorm:"-"`                                             // Volunteer user summary (computed, staff only)
+	Status         string          `json:"status" gorm:"type:varchar(20);not null;default:'signed_up'"`         // Signup status
+	MinutesWorked  *int            `json:"minutes_worked"`                                                      // Time worked, when different from the shift length
+	CancelledAt    *time.Time      `json:"cancelled_at"`                                                        // Cancellation time
+	AttendanceBy   *uint           `json:"attendance_by,omitempty"`                                             // Staff user who recorded the attendance
+	ReminderSentAt *time.Time      `json:"reminder_sent_at,omitempty"`                                          // When the reminder email was sent
+	CrtDate        time.Time       `json:"crt_date" gorm:"autoCreateTime"`                                      // Record creation timestamp
+	UptDate        time.Time       `json:"upt_date" gorm:"autoUpdateTime"`                                      // Record last update timestamp
+}
+
+// HoldsPlace reports whether the signup occupies a place in its shift.
+func (s *VolunteerSignup) HoldsPlace() bool {
+	return s.Status != SignupStatusCancelled
+}
+
+// VolunteerHours is one row of the hours report: the shifts a volunteer worked in a date range.
+type VolunteerHours struct {
+	VolunteerID uint            `json:"volunteer_id"`            // Volunteer
+	UserID      uint            `json:"user_id"`                 // User account of the volunteer
+	User        *SimplifiedUser `json:"user,omitempty" gorm:"-"` // Volunteer user summary (computed)
+	Shifts      int64           `json:"shifts"`                  // Shifts attended
+	NoShows     int64           `json:"no_shows"`                // Shifts missed
+	Minutes     int64           `json:"minutes"`                 // Time worked in minutes
+	Hours       float64         `json:"hours"`                   // Time worked in hours (rounded to 2 decimals)
+}
@@ -0,0 +1,168 @@
+// Package models contains data models for the pet adoption system.
+// These models define the structure of the webhook endpoints registered by partners
+// and the queue and log of the deliveries made to them.
+package models
+
+import "time"
+
+// Webhook event types.
+const (
+	WebhookEventPetCreated        = "pet.created"        // A pet was added
+	WebhookEventPetUpdated        = "pet.updated"        // A pet's details or status changed
+	WebhookEventPetAdopted        = "pet.adopted"        // A pet became adopted
+	WebhookEventPetDeleted        = "pet.deleted"        // A pet was removed
+	WebhookEventSpeciesCreated    = "species.created"    // An organisation defined a species
+	WebhookEventSpeciesDeleted    = "species.deleted"    // An organisation removed a species
+	WebhookEventAdoptionFinalized = "adoption.finalized" // An adoption was finalised with its contract
+)
+
+// WebhookEventTypes lists every event type webhooks can subscribe to.
+var WebhookEventTypes = []string{
+	WebhookEventPetCreated,
+	WebhookEventPetUpdated,
+	WebhookEventPetAdopted,
+	WebhookEventPetDeleted,
+	WebhookEventSpeciesCreated,
+	WebhookEventSpeciesDeleted,
+	WebhookEventAdoptionFinalized,
+}
+
+// Webhook delivery statuses.
+const (
+	WebhookDeliveryPending   = "pending"   // Waiting for its first attempt or a retry
+	WebhookDeliverySucceeded = "succeeded" // The endpoint answered with a 2xx status
+	WebhookDeliveryFailed    = "failed"    // Every attempt failed; only a replay sends it again
+)
+
+// WebhookDeliveryStatuses lists every valid delivery status.
+var WebhookDeliveryStatuses = []string{WebhookDeliveryPending, WebhookDeliverySucceeded, WebhookDeliveryFailed}
+
+// TableName returns the database table name for the Webhook model.
+// This method implements the GORM Tabler interface to specify custom table names.
+func (Webhook) TableName() string {
+	return "Webhooks"
+}
+
+// Webhook represents an endpoint of a partner that receives the events it subscribed to.
+//
+// Database Table: Webhooks
+// Relationships:
+//   - Organization: Optional Many-to-One relationship with Organization (foreign key: OrganizationID)
+//   - Deliveries: One-to-Many relationship with WebhookDelivery (foreign key: WebhookID)
+//
+// Business Rules:
+//   - Only admins manage webhooks
+//   - Every delivery is signed with the webhook secret, which is only shown when created or rotated
+//   - Without OrganizationID the webhook receives the events of every organisation
+//   - Inactive webhooks receive no new events; their pending deliveries wait until reactivated
+type Webhook struct {
+	ID             uint      `json:"id" gorm:"primaryKey;autoIncrement"`          // Unique identifier for the webhook
+	URL            string    `json:"url" gorm:"type:varchar(500);not null"`       // Endpoint receiving the POST requests
+	Description    string    `json:"description" gorm:"type:varchar(255)"`        // Who the endpoint belongs to (optional)
+	EventTypes     []string  `json:"event_types" gorm:"serializer:json;not null"` // Subscribed event types
+	OrganizationID *uint     `json:"organization_id" gorm:"index"`                // Only events of this organisation (optional)
+	Secret         string    `json:"-" gorm:"type:varchar(100);not null"`         // HMAC signing secret
+	SigningSecret  string    `json:"signing_secret,omitempty" gorm:"-"`           // Signing secret, only returned when created or rotated
+	Active         bool      `json:"active" gorm:"not null;default:true"`         // Whether the webhook receives new events
+	CreatedBy      uint      `json:"created_by"`                                  // Admin who registered the webhook
+	CrtDate        time.Time `json:"crt_date" gorm:"autoCreateTime"`              // Record creation timestamp
+	UptDate        time.Time `json:"upt_date" gorm:"autoUpdateTime"`              // Record last update timestamp
+}
+
+// Subscribes reports whether the webhook receives an event of an organisation.
+func (w *Webhook) Subscribes(eventType string, orgID uint) bool {
+	if !w.Active || (w.OrganizationID != nil && *w.OrganizationID != orgID) {
+		return false
+	}
+
+	for _, subscribed := range w.EventTypes {
+		if subscribed == eventType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// TableName returns the database table name for the WebhookDelivery model.
+// This method implements the GORM Tabler interface to specify custom table names.
+func (WebhookDelivery) TableName() string {
+	return "Webhook_Deliveries"
+}
+
+// WebhookDelivery represents an event queued for, or delivered to, a webhook.
+//
+// Database Table: Webhook_Deliveries
+// Relationships:
+//   - Webhook: Many-to-One relationship with Webhook (foreign key: WebhookID)
+//   - Attempts: One-to-Many relationship with WebhookAttempt (foreign key: DeliveryID)
+//
+// Business Rules:
+//   - Pending deliveries are retried with exponential backoff until WEBHOOK_MAX_ATTEMPTS
+//   - A replay is a new delivery of the same event (same EventID and payload)
+type WebhookDelivery struct {
+	ID             uint             `json:"id" gorm:"primaryKey;autoIncrement"`              // Unique identifier for the delivery
+	WebhookID      uint             `json:"webhook_id" gorm:"not null;index"`                // Webhook the event is delivered to
+	EventID        string           `json:"event_id" gorm:"type:varchar(40);not null;index"` // Event identifier, shared by replays
+	EventType      string           `json:"event_type" gorm:"type:varchar(40);not null"`     // Event type
+	Payload        string           `json:"payload" gorm:"type:mediumtext;not null"`         // JSON body sent to the endpoint
+	Status         string           `json:"status" gorm:"type:varchar(10);not null"`         // pending, succeeded or failed
+	Attempts       int              `json:"attempts" gorm:"not null;default:0"`              // Attempts made so far
+	NextAttemptAt  *time.Time       `json:"next_attempt_at" gorm:"index"`                    // When the next attempt is due (pending only)
+	LastAttemptAt  *time.Time       `json:"last_attempt_at"`                                 // When the latest attempt was made
+	ResponseStatus int              `json:"response_status"`                                 // HTTP status of the latest attempt (0 without response)
+	LastError      string           `json:"last_error" gorm:"type:varchar(500)"`             // Error of the latest failed attempt
+	DeliveredAt    *time.Time       `json:"delivered_at"`                                    // When the endpoint accepted the event
+	ReplayOf       *uint            `json:"replay_of"`                                       // Delivery replayed by this one (optional)
+	AttemptLog     []WebhookAttempt `json:"attempt_log,omitempty" gorm:"-"`                  // Attempts, oldest first (computed, detail only)
+	CrtDate        time.Time        `json:"crt_date" gorm:"autoCreateTime"`                  // Record creation timestamp
+	UptDate        time.Time        `json:"upt_date" gorm:"autoUpdateTime"`                  // Record last update timestamp
+}
+
+// TableName returns the database table name for the WebhookAttempt model.
+// This method implements the GORM Tabler interface to specify custom table names.
+func (WebhookAttempt) TableName() string {
+	return "Webhook_Attempts"
+}
+
+// WebhookAttempt represents one HTTP request made for a delivery.
+//
+// Database Table: Webhook_Attempts
+// Relationships:
+//   - Delivery: Many-to-One relationship with WebhookDelivery (foreign key: DeliveryID)
+type WebhookAttempt struct {
+	ID             uint      `json:"id" gorm:"primaryKey;autoIncrement"` // Unique identifier for the attempt
+	DeliveryID     uint      `json:"delivery_id" gorm:"not null;index"`  // Delivery the attempt belongs to
+	Attempt        int       `json:"attempt" gorm:"not null"`            // Attempt number, from 1
+	ResponseStatus int       `json:"response_status"`                    // HTTP status (0 without response)
+	ResponseBody   string    `json:"response_body" gorm:"type:text"`     // Beginning of the response body
+	Error          string    `json:"error" gorm:"type:varchar(500)"`     // Network error or rejected status
+	DurationMs     int64     `json:"duration_ms"`                        // Time taken by the request
+	CrtDate        time.Time `json:"crt_date" gorm:"autoCreateTime"`     // When the attempt was made
+}
+
+// WebhookPetData is the data of pet events. Deleted pets only include their IDs.
+type WebhookPetData struct {
+	ID             uint   `json:"id"`                // Pet ID
+	OrganizationID uint   `json:"organization_id"`   // Organisation that owns the pet
+	Name           string `json:"name,omitempty"`    // Pet's name
+	Species        string `json:"species,omitempty"` // Pet's species
+	Breed          string `json:"breed,omitempty"`   // Pet's breed
+	Status         string `json:"status,omitempty"`  // available, reserved or adopted
+	URL            string `json:"url,omitempty"`     // Public page of the pet
+}
+
+// WebhookSpeciesData is the data of species events.
+type WebhookSpeciesData struct {
+	ID             uint   `json:"id"`              // Species ID
+	OrganizationID uint   `json:"organization_id"` // Organisation that defined the species
+	Name           string `json:"name,omitempty"`  // Species name
+}
+
+// WebhookAdoptionData is the data of adoption events. The adopter's personal details are not included.
+type WebhookAdoptionData struct {
+	ID             uint   `json:"id"`              // Adoption ID
+	OrganizationID uint   `json:"organization_id"` // Organisation that gave the pet in adoption
+	PetID          uint   `json:"pet_id"`          // Adopted pet
+	AdoptionDate   string `json:"adoption_date"`   // Hand-over date (YYYY-MM-DD)
+}
@@ -0,0 +1,615 @@
+// Package services provides business logic services for finalised adoptions.
+// This layer records adoptions, generates their contract PDF from the organisation's
+// contract template, stores it with a SHA-256 hash to detect later tampering,
+// emails it to the adopter and marks the pet as adopted.
+package services
+
+import (
+	"backend/internal/db/dao"
+	"backend/internal/db/query"
+	m "backend/internal/models"
+	"backend/internal/services/contract"
+	mailer "backend/internal/services/mail"
+	"backend/internal/services/security"
+	"backend/internal/services/storage"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrAdoptionNotFound is returned for adoptions that do not exist or are not visible to the user.
+	ErrAdoptionNotFound = errors.New("adopción no encontrada")
+
+	// ErrAdoptionPetNotFound is returned when the pet does not exist in the organisation.
+	ErrAdoptionPetNotFound = errors.New("mascota no encontrada")
+
+	// ErrAdoptionPetAdopted is returned when finalising the adoption of a pet that is already adopted.
+	ErrAdoptionPetAdopted = errors.New("la mascota ya ha sido adoptada")
+
+	// ErrAdoptionAdopterNotFound is returned when the adopter user does not exist.
+	ErrAdoptionAdopterNotFound = errors.New("adoptante no encontrado")
+
+	// ErrAdoptionContractMissing is returned for adoptions without a stored contract.
+	ErrAdoptionContractMissing = errors.New("la adopción no tiene contrato generado")
+
+	// ErrAdoptionContractTampered is returned when the stored contract no longer matches its hash.
+	ErrAdoptionContractTampered = errors.New("el contrato almacenado no coincide con su huella")
+)
+
+// AdoptionContractCheck is the result of verifying a stored contract against its recorded hash.
+type AdoptionContractCheck struct {
+	AdoptionID uint   `json:"adoption_id"`     // Adoption whose contract was checked
+	Stored     string `json:"stored_sha256"`   // Hash recorded when the contract was generated
+	Actual     string `json:"computed_sha256"` // Hash of the contract currently in storage
+	Valid      bool   `json:"valid"`           // Whether both hashes match
+}
+
+// ========================================
+// ADOPTION RETRIEVAL SERVICES
+// ========================================
+
+// NewAdoptionListQuery parses and validates the pagination, sorting and filter
+// parameters of an adoption list request against dao.AdoptionListSchema.
+//
+// Parameters:
+//   - path: Request path used to build next/prev links
+//   - values: Raw query parameters
+//
+// Returns:
+//   - *query.Params: Validated list query
+//   - error: Validation error describing the invalid parameter
+func NewAdoptionListQuery(path string, values url.Values) (*query.Params, error) {
+	return query.Parse(path, values, dao.AdoptionListSchema)
+}
+
+// ListAdoptions retrieves one page of an organisation's adoptions with pet and adopter summaries.
+//
+// Parameters:
+//   - params: Validated list query (see NewAdoptionListQuery)
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - *query.Page[m.Adoption]: Requested page of adoptions
+//   - error: Database error or nil on success
+func ListAdoptions(params *query.Params, orgID uint) (*query.Page[m.Adoption], error) {
+	adoptions, err := dao.GetAdoptions(params, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener adopciones: %v", err)
+	}
+
+	if err := fillAdoptions(adoptions.Items); err != nil {
+		return nil, fmt.Errorf("error al obtener adopciones: %v", err)
+	}
+
+	return adoptions, nil
+}
+
+// GetAdoption retrieves an adoption of an organisation with pet and adopter summaries.
+//
+// Parameters:
+//   - id: Unique identifier of the adoption
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - *m.Adoption: Adoption data
+//   - error: ErrAdoptionNotFound or database error
+func GetAdoption(id uint, orgID uint) (*m.Adoption, error) {
+	adoption, err := dao.GetAdoption(id, orgID)
+	if err != nil {
+		return nil, ErrAdoptionNotFound
+	}
+
+	adoptions := []m.Adoption{*adoption}
+	if err := fillAdoptions(adoptions); err != nil {
+		return nil, fmt.Errorf("error al obtener adopción: %v", err)
+	}
+
+	return &adoptions[0], nil
+}
+
+// ListUserAdoptions retrieves the adoptions of the current user, in every organisation.
+// Internal notes and the staff member who recorded the adoption are not shown.
+//
+// Parameters:
+//   - userID: Unique identifier of the adopter
+//
+// Returns:
+//   - []m.Adoption: Adoptions with pet summaries and contract links, most recent first
+//   - error: Database error or nil on success
+func ListUserAdoptions(userID uint) ([]m.Adoption, error) {
+	adoptions, err := dao.GetUserAdoptions(userID)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener adopciones: %v", err)
+	}
+
+	if err := fillAdoptions(adoptions); err != nil {
+		return nil, fmt.Errorf("error al obtener adopciones: %v", err)
+	}
+
+	for i := range adoptions {
+		adoptions[i].Notes = ""
+		adoptions[i].CreatedBy = 0
+		adoptions[i].Adopter = nil
+	}
+
+	return adoptions, nil
+}
+
+// ========================================
+// ADOPTION FINALISATION SERVICES
+// ========================================
+
+// FinalizeAdoption records an adoption, generates and stores its contract, marks the pet
+// as adopted and emails the contract to the adopter.
+//
+// Business Logic:
+// - The pet must belong to the organisation and not be adopted yet
+// - The pet is claimed and marked adopted in the same transaction that records the adoption, so concurrent requests adopt it once
+// - The contract is filled from the organisation's template (or the default one) and never regenerated
+// - The SHA-256 of the stored PDF is recorded to detect later tampering
+// - If the contract cannot be stored, the adoption record is removed and the pet restored
+// - Follow-up work (favourites, foster placement and kennel, pet events and webhooks) runs only once the adoption is kept
+// - Email failures are logged; the contract can be resent later
+// - Sends the adoption.finalized webhook event, without the adopter's personal details
+//
+// Parameters:
+//   - adoption: Validated adoption (OrganizationID, PetID, AdopterUserID, AdoptionDate, fee, clauses, notes, CreatedBy)
+//
+// Returns:
+//   - *m.Adoption: Created adoption with contract metadata
+//   - error: ErrAdoptionPetNotFound, ErrAdoptionPetAdopted, ErrAdoptionAdopterNotFound, contract.ErrInvalidTemplate, storage or database error
+func FinalizeAdoption(adoption *m.Adoption) (*m.Adoption, error) {
+	pet, err := findOrganizationPet(adoption.PetID, adoption.OrganizationID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrAdoptionPetNotFound, err)
+	}
+	if pet.Status == m.PetStatusAdopted || pet.IsAdopted {
+		return nil, ErrAdoptionPetAdopted
+	}
+
+	adopter, err := dao.GetUserByID(adoption.AdopterUserID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrAdoptionAdopterNotFound, err)
+	}
+
+	organization, err := dao.GetOrganizationByID(adoption.OrganizationID)
+	if err != nil {
+		return nil, fmt.Errorf("error al leer organización: %v", err)
+	}
+
+	tmpl, err := GetContractTemplate(adoption.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	previous, err := dao.CreateAdoption(adoption)
+	if err != nil {
+		return nil, fmt.Errorf("error al registrar adopción: %v", err)
+	}
+	if previous == nil {
+		return nil, ErrAdoptionPetAdopted
+	}
+
+	// The contract number is derived from the adoption ID, so it is rendered after the insert
+	now := time.Now()
+	data := contract.NewData(adoption.ID, *organization, *pet, m.User{
+		Name:    adopter.Name,
+		Surname: adopter.Surname,
+		Email:   adopter.Email,
+		Address: adopter.Address,
+	}, adoption.AdoptionDate, adoption.FeeCents, adoption.Currency, adoption.Clauses)
+
+	pdf, err := storeAdoptionContract(adoption, toContractTemplate(tmpl), data, now)
+	if err != nil {
+		undoAdoption(adoption, previous)
+		return nil, err
+	}
+
+	// The pet was marked adopted with the adoption record; notify the change now that it is kept
+	pet.Status = m.PetStatusAdopted
+	pet.IsAdopted = true
+	pet.AdoptUserID = adoption.AdopterUserID
+	pet.AdoptDate = adoption.AdoptionDate
+	petUpdated(pet, previous.Status)
+
+	sendAdoptionContract(adoption, data, pdf)
+	publishApplicationUpdated(adoption, m.ApplicationEventAdopted)
+	emitWebhookEvent(m.WebhookEventAdoptionFinalized, adoption.OrganizationID, m.WebhookAdoptionData{
+		ID:             adoption.ID,
+		OrganizationID: adoption.OrganizationID,
+		PetID:          adoption.PetID,
+		AdoptionDate:   adoption.AdoptionDate.Format("2006-01-02"),
+	})
+
+	adoptions := []m.Adoption{*adoption}
+	if err := fillAdoptions(adoptions); err != nil {
+		log.Printf("could not load summaries of adoption %d: %v", adoption.ID, err)
+	}
+
+	return &adoptions[0], nil
+}
+
+// EndActiveAdoptionForPet ends the active adoption of a pet, if any.
+// Called when a pet leaves the adopted status (e.g. it is returned), so it can be adopted again; failures are logged.
+// The adoption record and its contract are kept.
+//
+// Parameters:
+//   - petID: Unique identifier of the pet
+func EndActiveAdoptionForPet(petID uint) {
+	if err := dao.EndActiveAdoption(petID); err != nil {
+		log.Printf("could not end active adoption of pet %d: %v", petID, err)
+	}
+}
+
+// ========================================
+// ADOPTION CONTRACT SERVICES
+// ========================================
+
+// OpenAdoptionContract opens the stored contract of an adoption for download.
+//
+// Parameters:
+//   - id: Unique identifier of the adoption
+//   - viewer: Current user; must be the adopter or staff of the adoption's organisation
+//
+// Returns:
+//   - io.ReadCloser: PDF content (caller must close it)
+//   - string: File name for the download
+//   - error: ErrAdoptionNotFound, ErrAdoptionContractMissing or storage error
+func OpenAdoptionContract(id uint, viewer *m.NonValidatedUser) (io.ReadCloser, string, error) {
+	adoption, err := findVisibleAdoption(id, viewer)
+	if err != nil {
+		return nil, "", err
+	}
+	if adoption.ContractKey == "" {
+		return nil, "", ErrAdoptionContractMissing
+	}
+
+	content, err := storage.Open().Get(context.Background(), adoption.ContractKey)
+	if err != nil {
+		return nil, "", fmt.Errorf("error al leer contrato: %v", err)
+	}
+
+	return content, contractFilename(adoption.ID), nil
+}
+
+// VerifyAdoptionContract recomputes the hash of a stored contract and compares it with the recorded one.
+//
+// Parameters:
+//   - id: Unique identifier of the adoption
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - *AdoptionContractCheck: Recorded and computed hashes
+//   - error: ErrAdoptionNotFound, ErrAdoptionContractMissing or storage error
+func VerifyAdoptionContract(id uint, orgID uint) (*AdoptionContractCheck, error) {
+	adoption, err := dao.GetAdoption(id, orgID)
+	if err != nil {
+		return nil, ErrAdoptionNotFound
+	}
+
+	content, err := readAdoptionContract(adoption)
+	if err != nil {
+		return nil, err
+	}
+
+	actual := contractHash(content)
+	return &AdoptionContractCheck{
+		AdoptionID: adoption.ID,
+		Stored:     adoption.ContractHash,
+		Actual:     actual,
+		Valid:      actual == adoption.ContractHash,
+	}, nil
+}
+
+// ResendAdoptionContract emails the stored contract to the adopter again.
+// Contracts that no longer match their hash are not sent.
+//
+// Parameters:
+//   - id: Unique identifier of the adoption
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - *m.Adoption: Adoption with the updated sending time
+//   - error: ErrAdoptionNotFound, ErrAdoptionContractMissing, ErrAdoptionContractTampered, storage or SMTP error
+func ResendAdoptionContract(id uint, orgID uint) (*m.Adoption, error) {
+	adoption, err := dao.GetAdoption(id, orgID)
+	if err != nil {
+		return nil, ErrAdoptionNotFound
+	}
+
+	content, err := readAdoptionContract(adoption)
+	if err != nil {
+		return nil, err
+	}
+	if contractHash(content) != adoption.ContractHash {
+		return nil, ErrAdoptionContractTampered
+	}
+
+	pet, err := findOrganizationPet(adoption.PetID, dao.AllOrganizations)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrAdoptionPetNotFound, err)
+	}
+
+	adopter, err := dao.GetUserByID(adoption.AdopterUserID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrAdoptionAdopterNotFound, err)
+	}
+
+	data := contract.Data{
+		Number:  contract.Number(adoption.ID),
+		Date:    adoption.AdoptionDate,
+		Pet:     contract.Pet{Name: pet.Name},
+		Adopter: contract.Adopter{FullName: strings.TrimSpace(adopter.Name + " " + adopter.Surname), Email: adopter.Email},
+	}
+	if err := emailAdoptionContract(adoption, data, content); err != nil {
+		return nil, fmt.Errorf("error al enviar contrato: %v", err)
+	}
+	publishApplicationUpdated(adoption, m.ApplicationEventContractSent)
+
+	return GetAdoption(adoption.ID, orgID)
+}
+
+// ========================================
+// CONTRACT TEMPLATE SERVICES
+// ========================================
+
+// GetContractTemplate retrieves the contract template of an organisation.
+// Organisations that have not configured one get the default template (with ID 0).
+//
+// Parameters:
+//   - orgID: Unique identifier of the organisation
+//
+// Returns:
+//   - *m.ContractTemplate: Template in use
+//   - error: Database error or nil on success
+func GetContractTemplate(orgID uint) (*m.ContractTemplate, error) {
+	tmpl, err := dao.GetContractTemplate(orgID)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener plantilla de contrato: %v", err)
+	}
+
+	if tmpl == nil {
+		tmpl = &m.ContractTemplate{
+			OrganizationID: orgID,
+			Title:          contract.DefaultTemplate.Title,
+			Intro:          contract.DefaultTemplate.Intro,
+			Clauses:        append([]string(nil), contract.DefaultTemplate.Clauses...),
+			Closing:        contract.DefaultTemplate.Closing,
+		}
+	}
+
+	return tmpl, nil
+}
+
+// SaveContractTemplate creates or replaces the contract template of an organisation.
+// Contracts already generated are not affected.
+//
+// Parameters:
+//   - tmpl: Template (OrganizationID, texts and UpdatedBy)
+//
+// Returns:
+//   - *m.ContractTemplate: Saved template
+//   - error: contract.ErrInvalidTemplate (wrapped with the failing text) or database error
+func SaveContractTemplate(tmpl *m.ContractTemplate) (*m.ContractTemplate, error) {
+	if err := contract.Validate(toContractTemplate(tmpl)); err != nil {
+		return nil, err
+	}
+
+	if err := dao.SaveContractTemplate(tmpl); err != nil {
+		return nil, fmt.Errorf("error al guardar plantilla de contrato: %v", err)
+	}
+
+	return GetContractTemplate(tmpl.OrganizationID)
+}
+
+// ========================================
+// ADOPTION HELPERS
+// ========================================
+
+// fillAdoptions sets the pet and adopter summaries and the contract download URL of adoptions.
+func fillAdoptions(adoptions []m.Adoption) error {
+	if len(adoptions) == 0 {
+		return nil
+	}
+
+	petIDs := make([]uint, len(adoptions))
+	userIDs := make([]uint, len(adoptions))
+	for i, adoption := range adoptions {
+		petIDs[i] = adoption.PetID
+		userIDs[i] = adoption.AdopterUserID
+	}
+
+	pets, err := dao.GetAdoptionPets(petIDs)
+	if err != nil {
+		return err
+	}
+
+	adopters, err := dao.GetAdoptionAdopters(userIDs)
+	if err != nil {
+		return err
+	}
+
+	adoptionIDs := make([]uint, len(adoptions))
+	for i, adoption := range adoptions {
+		adoptionIDs[i] = adoption.ID
+	}
+
+	paid, err := dao.GetAdoptionPaidCents(adoptionIDs)
+	if err != nil {
+		return err
+	}
+
+	for i := range adoptions {
+		adoptions[i].Pet = pets[adoptions[i].PetID]
+		if adoptions[i].Pet != nil && adoptions[i].Pet.PrimaryPhoto != nil {
+			fillPhotoURL(adoptions[i].Pet.PrimaryPhoto)
+		}
+		adoptions[i].Adopter = adopters[adoptions[i].AdopterUserID]
+		adoptions[i].PaidCents = paid[adoptions[i].ID]
+		if adoptions[i].ContractKey != "" {
+			adoptions[i].ContractURL = fmt.Sprintf("/api/adoptions/%d/contract", adoptions[i].ID)
+		}
+	}
+
+	return nil
+}
+
+// findVisibleAdoption retrieves an adoption the viewer may see: its adopter, or staff of its organisation.
+// Other users get ErrAdoptionNotFound, so the adoption's existence is not revealed.
+func findVisibleAdoption(id uint, viewer *m.NonValidatedUser) (*m.Adoption, error) {
+	adoption, err := dao.GetAdoption(id, dao.AllOrganizations)
+	if err != nil || viewer == nil {
+		return nil, ErrAdoptionNotFound
+	}
+
+	if adoption.AdopterUserID == viewer.ID {
+		return adoption, nil
+	}
+
+	if viewer.IsStaff() {
+		if _, err := ResolveMembership(viewer, adoption.OrganizationID); err == nil {
+			return adoption, nil
+		}
+	}
+
+	return nil, ErrAdoptionNotFound
+}
+
+// toContractTemplate converts a stored template to the contract package type.
+func toContractTemplate(tmpl *m.ContractTemplate) contract.Template {
+	return contract.Template{
+		Title:   tmpl.Title,
+		Intro:   tmpl.Intro,
+		Clauses: tmpl.Clauses,
+		Closing: tmpl.Closing,
+	}
+}
+
+// storeAdoptionContract renders the contract of an adoption, stores it and records its key and hash.
+// The stored object is removed if the record cannot be updated.
+func storeAdoptionContract(adoption *m.Adoption, tmpl contract.Template, data contract.Data, now time.Time) ([]byte, error) {
+	pdf, err := contract.Render(tmpl, data, now)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	store := storage.Open()
+
+	key := fmt.Sprintf("adoptions/%d/%d/%s/contract.pdf",
+		adoption.OrganizationID, adoption.ID, strings.ToLower(security.Generate2FA(20)))
+	if err := store.Put(ctx, key, pdf, contract.ContentType); err != nil {
+		return nil, fmt.Errorf("error al guardar contrato: %v", err)
+	}
+
+	adoption.ContractKey = key
+	adoption.ContractHash = contractHash(pdf)
+	adoption.ContractGeneratedAt = &now
+	if err := dao.SetAdoptionContract(adoption); err != nil {
+		if err := store.Delete(ctx, key); err != nil {
+			log.Printf("could not remove stored contract %s: %v", key, err)
+		}
+		adoption.ContractKey = ""
+		return nil, err
+	}
+
+	return pdf, nil
+}
+
+// undoAdoption removes an adoption whose finalisation failed, with its stored contract, and restores its pet.
+func undoAdoption(adoption *m.Adoption, previous *m.Pet) {
+	if adoption.ContractKey != "" {
+		if err := storage.Open().Delete(context.Background(), adoption.ContractKey); err != nil {
+			log.Printf("could not remove stored contract %s: %v", adoption.ContractKey, err)
+		}
+	}
+
+	if err := dao.UndoAdoption(adoption.ID, previous); err != nil {
+		log.Printf("could not remove failed adoption %d: %v", adoption.ID, err)
+	}
+}
+
+// readAdoptionContract reads the whole stored contract of an adoption.
+func readAdoptionContract(adoption *m.Adoption) ([]byte, error) {
+	if adoption.ContractKey == "" {
+		return nil, ErrAdoptionContractMissing
+	}
+
+	content, err := storage.Open().Get(context.Background(), adoption.ContractKey)
+	if err != nil {
+		return nil, fmt.Errorf("error al leer contrato: %v", err)
+	}
+	defer content.Close()
+
+	var b bytes.Buffer
+	if _, err := io.Copy(&b, content); err != nil {
+		return nil, fmt.Errorf("error al leer contrato: %v", err)
+	}
+
+	return b.Bytes(), nil
+}
+
+// sendAdoptionContract emails a newly generated contract to the adopter.
+// Failures are logged and never undo the adoption.
+func sendAdoptionContract(adoption *m.Adoption, data contract.Data, pdf []byte) {
+	if err := emailAdoptionContract(adoption, data, pdf); err != nil {
+		log.Printf("could not send contract of adoption %d: %v", adoption.ID, err)
+	}
+}
+
+// emailAdoptionContract emails a contract to the adopter and records the sending time.
+// The contract is always emailed; the in-app notification follows the adopter's application updates preference.
+func emailAdoptionContract(adoption *m.Adoption, data contract.Data, pdf []byte) error {
+	sender := organizationSender(adoption.OrganizationID)
+
+	email, err := mailer.ComposeAdoptionContract(data.Adopter.Email, userLocale(&adoption.AdopterUserID), mailer.AdoptionContractData{
+		AdopterName:  data.Adopter.FullName,
+		PetName:      data.Pet.Name,
+		Organization: sender.Name,
+		Number:       data.Number,
+		Date:         adoption.AdoptionDate.Format("02/01/2006"),
+		Hash:         adoption.ContractHash,
+	}, contractFilename(adoption.ID), pdf, sender)
+	if err != nil {
+		return err
+	}
+
+	err = notify(notification{
+		UserID:   &adoption.AdopterUserID,
+		Type:     m.NotificationApplicationUpdates,
+		Required: true,
+		URL:      fmt.Sprintf("%s/adoptions/%d", frontendURL, adoption.ID),
+		Email:    email,
+	})
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if err := dao.MarkAdoptionContractSent(adoption.ID, now); err != nil {
+		log.Printf("could not mark contract of adoption %d as sent: %v", adoption.ID, err)
+	}
+	adoption.ContractSentAt = &now
+
+	return nil
+}
+
+// contractFilename returns the download file name of an adoption's contract.
+func contractFilename(adoptionID uint) string {
+	return "contrato-" + contract.Number(adoptionID) + ".pdf"
+}
+
+// contractHash returns the hex SHA-256 of a contract.
+func contractHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"backend/internal/services/contract"
+	"testing"
+	"time"
+)
+
+func TestContractHash(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{name: "empty", content: "", want: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+		{name: "abc", content: "abc", want: "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := contractHash([]byte(tt.content)); got != tt.want {
+				t.Errorf("contractHash(%q) = %s, want %s", tt.content, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContractHashDetectsTampering(t *testing.T) {
+	data := contract.Data{Number: contract.Number(12), Date: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)}
+	pdf, err := contract.Render(contract.DefaultTemplate, data, data.Date)
+	if err != nil {
+		t.Fatalf("Render error = %v", err)
+	}
+	stored := contractHash(pdf)
+
+	tests := []struct {
+		name   string
+		tamper func([]byte) []byte
+	}{
+		{name: "one byte changed", tamper: func(b []byte) []byte { b[len(b)/2] ^= 1; return b }},
+		{name: "truncated", tamper: func(b []byte) []byte { return b[:len(b)-1] }},
+		{name: "appended", tamper: func(b []byte) []byte { return append(b, '\n') }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tampered := tt.tamper(append([]byte{}, pdf...))
+			if contractHash(tampered) == stored {
+				t.Error("tampered contract has the recorded hash")
+			}
+		})
+	}
+
+	if contractHash(pdf) != stored {
+		t.Error("hash of the untouched contract changed")
+	}
+}
@@ -0,0 +1,705 @@
+// Package services provides business logic services for in-app messaging.
+// This layer manages the conversation threads between users and shelter staff,
+// their messages, read receipts and attachments, the staff shared inbox and the
+// emails sent about messages that stay unread.
+package services
+
+import (
+	"backend/internal/db/dao"
+	"backend/internal/db/query"
+	m "backend/internal/models"
+	mailer "backend/internal/services/mail"
+	"backend/internal/services/scheduler"
+	"backend/internal/services/security"
+	"backend/internal/services/storage"
+	"backend/internal/utils/env"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxMessageAttachments is the maximum number of files attached to a message.
+const MaxMessageAttachments = 5
+
+// MessageNotificationsJob is the scheduler job name of the unread message emails.
+const MessageNotificationsJob = "message-notifications"
+
+// messageExcerptLength is the maximum number of characters of a message quoted in its notification.
+const messageExcerptLength = 300
+
+var (
+	// messageNotifyDelay is how long a message stays unread before it is emailed (MESSAGE_NOTIFY_DELAY, default 15m).
+	messageNotifyDelay = env.GetDuration("MESSAGE_NOTIFY_DELAY", 15*time.Minute)
+
+	// messageNotifyInterval is how often unread messages are checked (MESSAGE_NOTIFY_INTERVAL, default 5m).
+	messageNotifyInterval = env.GetDuration("MESSAGE_NOTIFY_INTERVAL", 5*time.Minute)
+)
+
+var (
+	// ErrConversationNotFound is returned for conversations that do not exist or are not visible to the caller.
+	ErrConversationNotFound = errors.New("conversación no encontrada")
+
+	// ErrConversationPetNotFound is returned when the pet of a conversation does not exist (in the organisation, for staff).
+	ErrConversationPetNotFound = errors.New("mascota no encontrada")
+
+	// ErrConversationUserNotFound is returned when staff start a conversation with a user that does not exist.
+	ErrConversationUserNotFound = errors.New("usuario no encontrado")
+
+	// ErrConversationAssignee is returned when assigning a conversation to a user outside the organisation.
+	ErrConversationAssignee = errors.New("la conversación solo se puede asignar a miembros de la organización")
+
+	// ErrMessageAttachmentNotFound is returned for attachments that do not exist in the conversation.
+	ErrMessageAttachmentNotFound = errors.New("adjunto no encontrado")
+
+	// errNoMessageRecipient is returned when unread messages have nobody to be emailed to.
+	errNoMessageRecipient = errors.New("sin destinatario para el aviso")
+)
+
+// ========================================
+// CONVERSATION SERVICES
+// ========================================
+
+// NewConversationListQuery parses and validates the pagination, sorting and filter
+// parameters of a staff inbox request against dao.ConversationListSchema.
+//
+// Parameters:
+//   - path: Request path used to build next/prev links
+//   - values: Raw query parameters
+//
+// Returns:
+//   - *query.Params: Validated list query
+//   - error: Validation error describing the invalid parameter
+func NewConversationListQuery(path string, values url.Values) (*query.Params, error) {
+	return query.Parse(path, values, dao.ConversationListSchema)
+}
+
+// ListInbox retrieves one page of the staff shared inbox of an organisation.
+//
+// Parameters:
+//   - params: Validated list query (see NewConversationListQuery)
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - *query.Page[m.Conversation]: Conversations with user, pet, assignee and messages unread by staff
+//   - error: Database error or nil on success
+func ListInbox(params *query.Params, orgID uint) (*query.Page[m.Conversation], error) {
+	conversations, err := dao.GetConversations(params, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener conversaciones: %v", err)
+	}
+
+	if err := fillConversations(conversations.Items, true); err != nil {
+		return nil, fmt.Errorf("error al obtener conversaciones: %v", err)
+	}
+
+	return conversations, nil
+}
+
+// ListUserConversations retrieves the conversations of a user with every organisation.
+//
+// Parameters:
+//   - userID: Authenticated user ID
+//
+// Returns:
+//   - []m.Conversation: Conversations with pet, assignee and messages unread by the user, most recent activity first
+//   - error: Database error or nil on success
+func ListUserConversations(userID uint) ([]m.Conversation, error) {
+	conversations, err := dao.GetUserConversations(userID)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener conversaciones: %v", err)
+	}
+
+	if err := fillConversations(conversations, false); err != nil {
+		return nil, fmt.Errorf("error al obtener conversaciones: %v", err)
+	}
+
+	return conversations, nil
+}
+
+// StartConversation opens a conversation from a user with the staff of an organisation.
+//
+// Business Logic:
+// - A conversation about a pet goes to the pet's organisation; otherwise OrganizationID must exist
+// - The first message is written by the user
+//
+// Parameters:
+//   - conversation: Validated conversation (must include UserID, Subject and PetID or OrganizationID)
+//   - body: Text of the first message
+//
+// Returns:
+//   - *m.Conversation: Created conversation with its first message
+//   - error: ErrConversationPetNotFound, ErrOrganizationNotFound or database error
+func StartConversation(conversation *m.Conversation, body string) (*m.Conversation, error) {
+	if conversation.PetID != nil {
+		pet, err := findOrganizationPet(*conversation.PetID, dao.AllOrganizations)
+		if err != nil {
+			return nil, ErrConversationPetNotFound
+		}
+		conversation.OrganizationID = pet.OrganizationID
+	} else if _, err := GetOrganization(conversation.OrganizationID); err != nil {
+		return nil, err
+	}
+
+	conversation.CreatedBy = conversation.UserID
+
+	return createConversation(conversation, &m.Message{
+		SenderID:  conversation.UserID,
+		FromStaff: false,
+		Body:      body,
+	}, false)
+}
+
+// StartStaffConversation opens a conversation from the staff of an organisation with a user.
+//
+// Business Logic:
+// - The pet, when given, must belong to the organisation
+// - The first message is written by the staff member, who is recorded as its creator
+//
+// Parameters:
+//   - conversation: Validated conversation (must include OrganizationID, UserID, CreatedBy and Subject)
+//   - body: Text of the first message
+//
+// Returns:
+//   - *m.Conversation: Created conversation with its first message
+//   - error: ErrConversationUserNotFound, ErrConversationPetNotFound or database error
+func StartStaffConversation(conversation *m.Conversation, body string) (*m.Conversation, error) {
+	if _, err := dao.GetUserByID(conversation.UserID); err != nil {
+		return nil, ErrConversationUserNotFound
+	}
+
+	if conversation.PetID != nil {
+		if _, err := findOrganizationPet(*conversation.PetID, conversation.OrganizationID); err != nil {
+			return nil, ErrConversationPetNotFound
+		}
+	}
+
+	return createConversation(conversation, &m.Message{
+		SenderID:  conversation.CreatedBy,
+		FromStaff: true,
+		Body:      body,
+	}, true)
+}
+
+// GetConversation retrieves a conversation with its messages and marks the other side's messages as read.
+//
+// Business Logic:
+// - The user of the conversation reads it as the user side; staff of its organisation as the staff side
+// - Opening the conversation sets the read receipt of every unread message from the other side
+//
+// Parameters:
+//   - id: Unique identifier of the conversation
+//   - viewer: Current user
+//
+// Returns:
+//   - *m.Conversation: Conversation with user, pet, assignee and messages (senders and attachment URLs)
+//   - error: ErrConversationNotFound or database error
+func GetConversation(id uint, viewer *m.NonValidatedUser) (*m.Conversation, error) {
+	conversation, asStaff, err := findVisibleConversation(id, viewer)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := dao.MarkMessagesRead(conversation.ID, !asStaff, time.Now()); err != nil {
+		return nil, err
+	}
+
+	conversations := []m.Conversation{*conversation}
+	if err := fillConversations(conversations, asStaff); err != nil {
+		return nil, fmt.Errorf("error al obtener conversación: %v", err)
+	}
+	conversation = &conversations[0]
+
+	if err := fillConversationMessages(conversation); err != nil {
+		return nil, fmt.Errorf("error al obtener mensajes: %v", err)
+	}
+
+	return conversation, nil
+}
+
+// UpdateConversationInbox changes the inbox status and assignee of a conversation of an organisation.
+//
+// Business Logic:
+// - Closing records when the conversation was closed; reopening clears it
+// - The assignee must be a member of the organisation; nil unassigns the conversation
+//
+// Parameters:
+//   - update: Validated update (must include ID, OrganizationID and Status; AssignedTo optional)
+//
+// Returns:
+//   - *m.Conversation: Updated conversation with user, pet and assignee
+//   - error: ErrConversationNotFound, ErrConversationAssignee or database error
+func UpdateConversationInbox(update *m.Conversation) (*m.Conversation, error) {
+	conversation, err := dao.GetConversation(update.ID)
+	if err != nil || conversation.OrganizationID != update.OrganizationID {
+		return nil, ErrConversationNotFound
+	}
+
+	if update.AssignedTo != nil {
+		member, err := dao.GetMembership(conversation.OrganizationID, *update.AssignedTo)
+		if err != nil {
+			return nil, err
+		}
+		if member == nil {
+			return nil, ErrConversationAssignee
+		}
+	}
+
+	if update.Status != conversation.Status {
+		conversation.ClosedAt = nil
+		if update.Status == m.ConversationStatusClosed {
+			now := time.Now()
+			conversation.ClosedAt = &now
+		}
+	}
+	conversation.Status = update.Status
+	conversation.AssignedTo = update.AssignedTo
+
+	if err := dao.UpdateConversationInbox(conversation); err != nil {
+		return nil, fmt.Errorf("error al actualizar conversación: %v", err)
+	}
+
+	conversations := []m.Conversation{*conversation}
+	if err := fillConversations(conversations, true); err != nil {
+		return nil, fmt.Errorf("error al obtener conversación: %v", err)
+	}
+
+	return &conversations[0], nil
+}
+
+// ========================================
+// MESSAGE SERVICES
+// ========================================
+
+// PostMessage adds a message to a conversation, with its attachments.
+//
+// Business Logic:
+// - The side of the message (user or staff) follows the sender's role in the conversation
+// - A message reopens a closed conversation
+// - If an attachment cannot be stored, the message and every stored file are removed
+//
+// Parameters:
+//   - message: Validated message (must include ConversationID, SenderID and Body)
+//   - uploads: Attached files (content type already validated)
+//   - viewer: Current user; must be the user of the conversation or staff of its organisation
+//
+// Returns:
+//   - error: ErrConversationNotFound, storage or database error
+func PostMessage(message *m.Message, uploads []m.MessageUpload, viewer *m.NonValidatedUser) error {
+	conversation, asStaff, err := findVisibleConversation(message.ConversationID, viewer)
+	if err != nil {
+		return err
+	}
+
+	message.FromStaff = asStaff
+	message.Attachments = nil
+	if err := dao.CreateMessage(message); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	store := storage.Open()
+
+	// Removes the message and the files stored so far
+	rollback := func() {
+		for _, attachment := range message.Attachments {
+			if err := store.Delete(ctx, attachment.StorageKey); err != nil {
+				log.Printf("could not remove stored attachment %s: %v", attachment.StorageKey, err)
+			}
+		}
+		if err := dao.DeleteMessage(message.ID); err != nil {
+			log.Printf("could not remove incomplete message %d: %v", message.ID, err)
+		}
+	}
+
+	for _, upload := range uploads {
+		storageKey := fmt.Sprintf("messages/%d/%d/%s", conversation.ID, message.ID, strings.ToLower(security.Generate2FA(20)))
+		if err := store.Put(ctx, storageKey, upload.Data, upload.ContentType); err != nil {
+			rollback()
+			return fmt.Errorf("error al guardar adjunto: %v", err)
+		}
+
+		attachment := m.MessageAttachment{
+			MessageID:   message.ID,
+			FileName:    upload.FileName,
+			ContentType: upload.ContentType,
+			Size:        int64(len(upload.Data)),
+			StorageKey:  storageKey,
+		}
+		if err := dao.CreateMessageAttachment(&attachment); err != nil {
+			if err := store.Delete(ctx, storageKey); err != nil {
+				log.Printf("could not remove stored attachment %s: %v", storageKey, err)
+			}
+			rollback()
+			return err
+		}
+
+		message.Attachments = append(message.Attachments, attachment)
+	}
+
+	publishNewMessage(conversation, message)
+
+	if users, err := dao.GetConversationUsers([]uint{message.SenderID}); err == nil {
+		message.Sender = users[message.SenderID]
+	}
+	for i := range message.Attachments {
+		fillMessageAttachmentURL(conversation.ID, &message.Attachments[i])
+	}
+
+	return nil
+}
+
+// OpenMessageAttachment opens a stored attachment of a conversation for download.
+//
+// Parameters:
+//   - conversationID: Unique identifier of the conversation
+//   - attachmentID: Unique identifier of the attachment
+//   - viewer: Current user; must be the user of the conversation or staff of its organisation
+//
+// Returns:
+//   - io.ReadCloser: File content (caller must close it)
+//   - *m.MessageAttachment: Attachment metadata (file name and MIME type)
+//   - error: ErrConversationNotFound, ErrMessageAttachmentNotFound or storage error
+func OpenMessageAttachment(conversationID uint, attachmentID uint, viewer *m.NonValidatedUser) (io.ReadCloser, *m.MessageAttachment, error) {
+	if _, _, err := findVisibleConversation(conversationID, viewer); err != nil {
+		return nil, nil, err
+	}
+
+	attachment, err := dao.GetMessageAttachment(conversationID, attachmentID)
+	if err != nil {
+		return nil, nil, ErrMessageAttachmentNotFound
+	}
+
+	content, err := storage.Open().Get(context.Background(), attachment.StorageKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error al leer adjunto: %v", err)
+	}
+
+	return content, attachment, nil
+}
+
+// ========================================
+// MESSAGE NOTIFICATION JOB
+// ========================================
+
+// RunMessageNotifications emails the recipients of messages still unread after MESSAGE_NOTIFY_DELAY.
+//
+// Business Logic:
+// - Unread messages are grouped by conversation and side, so each recipient gets one email per conversation
+// - Messages from users go to the assigned staff member, or to the organisation email when unassigned
+// - Messages from staff go to the user of the conversation
+// - Each message is notified once; messages without a recipient address are skipped and not retried
+// - The run fails (and is retried) only if no email could be sent
+//
+// Parameters:
+//   - now: Reference time of the run
+//   - dryRun: Only list the messages, without sending the emails
+//
+// Returns:
+//   - scheduler.Result: Number of emails sent (or that would be sent)
+//   - error: Database error, or mail error when every email failed
+func RunMessageNotifications(now time.Time, dryRun bool) (scheduler.Result, error) {
+	messages, err := dao.GetMessagesDueForNotification(now.Add(-messageNotifyDelay))
+	if err != nil {
+		return scheduler.Result{}, err
+	}
+
+	// Group by conversation and side, keeping the order of the oldest message
+	type messageGroup struct {
+		conversationID uint
+		fromStaff      bool
+		messages       []m.Message
+	}
+	var groups []*messageGroup
+	byKey := make(map[string]*messageGroup)
+	for _, message := range messages {
+		key := fmt.Sprintf("%d-%t", message.ConversationID, message.FromStaff)
+		group := byKey[key]
+		if group == nil {
+			group = &messageGroup{conversationID: message.ConversationID, fromStaff: message.FromStaff}
+			byKey[key] = group
+			groups = append(groups, group)
+		}
+		group.messages = append(group.messages, message)
+	}
+
+	if dryRun {
+		ids := make([]uint, len(messages))
+		for i, message := range messages {
+			ids[i] = message.ID
+		}
+
+		return scheduler.Result{
+			Items:   len(groups),
+			Summary: fmt.Sprintf("se enviarían %d avisos de %d mensajes sin leer", len(groups), len(messages)),
+			Preview: ids,
+		}, nil
+	}
+
+	conversationIDs := make([]uint, len(groups))
+	for i, group := range groups {
+		conversationIDs[i] = group.conversationID
+	}
+
+	conversations, err := dao.GetConversationsByID(conversationIDs)
+	if err != nil {
+		return scheduler.Result{}, err
+	}
+
+	sent := 0
+	var lastErr error
+	for _, group := range groups {
+		conversation := conversations[group.conversationID]
+		if conversation == nil {
+			continue
+		}
+
+		ids := make([]uint, len(group.messages))
+		for i, message := range group.messages {
+			ids[i] = message.ID
+		}
+
+		err := sendMessageNotification(conversation, group.fromStaff, group.messages)
+		if err != nil && !errors.Is(err, errNoMessageRecipient) {
+			log.Printf("could not notify unread messages of conversation %d: %v", conversation.ID, err)
+			lastErr = err
+			continue
+		}
+
+		if err := dao.MarkMessagesNotified(ids, now); err != nil {
+			log.Printf("could not record notification of conversation %d messages: %v", conversation.ID, err)
+		}
+		if err == nil {
+			sent++
+		}
+	}
+
+	if sent == 0 && lastErr != nil {
+		return scheduler.Result{}, fmt.Errorf("no se ha podido enviar ningún aviso: %v", lastErr)
+	}
+
+	return scheduler.Result{Items: sent, Summary: fmt.Sprintf("%d avisos de mensajes enviados", sent)}, nil
+}
+
+// sendMessageNotification notifies the recipient of unread messages of one side of a conversation (see notify).
+func sendMessageNotification(conversation *m.Conversation, fromStaff bool, messages []m.Message) error {
+	sender := organizationSender(conversation.OrganizationID)
+	latest := messages[len(messages)-1]
+
+	data := mailer.MessageNotificationData{
+		Organization: sender.Name,
+		Subject:      conversation.Subject,
+		Count:        len(messages),
+		Excerpt:      messageExcerpt(latest.Body),
+		ToStaff:      !fromStaff,
+	}
+
+	author, err := dao.GetUserByID(latest.SenderID)
+	if err == nil {
+		data.SenderName = strings.TrimSpace(author.Name + " " + author.Surname)
+	}
+
+	var to string
+	var recipientID *uint
+	locale := m.DefaultLocale
+	if fromStaff {
+		user, err := dao.GetUserByID(conversation.UserID)
+		if err != nil {
+			return fmt.Errorf("error al obtener usuario: %v", err)
+		}
+		to = user.Email
+		recipientID = &user.ID
+		locale = user.Locale
+		data.RecipientName = strings.TrimSpace(user.Name + " " + user.Surname)
+		data.ConversationURL = fmt.Sprintf("%s/messages/%d", frontendURL, conversation.ID)
+	} else {
+		if conversation.AssignedTo != nil {
+			assignee, err := dao.GetUserByID(*conversation.AssignedTo)
+			if err != nil {
+				return fmt.Errorf("error al obtener responsable: %v", err)
+			}
+			to = assignee.Email
+			recipientID = &assignee.ID
+			locale = assignee.Locale
+			data.RecipientName = strings.TrimSpace(assignee.Name + " " + assignee.Surname)
+		} else {
+			organization, err := dao.GetOrganizationByID(conversation.OrganizationID)
+			if err != nil {
+				return fmt.Errorf("error al obtener organización: %v", err)
+			}
+			to = organization.Email
+			data.RecipientName = organization.Name
+		}
+		data.ConversationURL = fmt.Sprintf("%s/inbox/%d", frontendURL, conversation.ID)
+	}
+
+	if to == "" {
+		log.Printf("no recipient for unread messages of conversation %d, skipping", conversation.ID)
+		return errNoMessageRecipient
+	}
+
+	email, err := mailer.ComposeNewMessageNotification(to, locale, data, sender)
+	if err != nil {
+		return err
+	}
+
+	return notify(notification{
+		UserID: recipientID,
+		Type:   m.NotificationMessages,
+		URL:    data.ConversationURL,
+		Email:  email,
+	})
+}
+
+// ========================================
+// CONVERSATION HELPERS
+// ========================================
+
+// createConversation stores a new conversation with its first message and returns it filled in.
+func createConversation(conversation *m.Conversation, message *m.Message, asStaff bool) (*m.Conversation, error) {
+	conversation.Status = m.ConversationStatusOpen
+	conversation.LastMessageAt = time.Now()
+	conversation.ClosedAt = nil
+
+	if err := dao.CreateConversation(conversation, message); err != nil {
+		return nil, err
+	}
+	publishNewMessage(conversation, message)
+
+	conversations := []m.Conversation{*conversation}
+	if err := fillConversations(conversations, asStaff); err != nil {
+		return nil, fmt.Errorf("error al obtener conversación: %v", err)
+	}
+	conversation = &conversations[0]
+
+	if err := fillConversationMessages(conversation); err != nil {
+		return nil, fmt.Errorf("error al obtener mensajes: %v", err)
+	}
+
+	return conversation, nil
+}
+
+// findVisibleConversation retrieves a conversation the viewer takes part in and the side they act on:
+// its user (false), or staff of its organisation (true).
+// Other users get ErrConversationNotFound, so the conversation's existence is not revealed.
+func findVisibleConversation(id uint, viewer *m.NonValidatedUser) (*m.Conversation, bool, error) {
+	conversation, err := dao.GetConversation(id)
+	if err != nil || viewer == nil {
+		return nil, false, ErrConversationNotFound
+	}
+
+	if conversation.UserID == viewer.ID {
+		return conversation, false, nil
+	}
+
+	if viewer.IsStaff() {
+		if _, err := ResolveMembership(viewer, conversation.OrganizationID); err == nil {
+			return conversation, true, nil
+		}
+	}
+
+	return nil, false, ErrConversationNotFound
+}
+
+// fillConversations sets the user, pet, assignee and unread count of conversations.
+// Staff (forStaff) count unread messages from the user; users count unread messages from staff.
+func fillConversations(conversations []m.Conversation, forStaff bool) error {
+	if len(conversations) == 0 {
+		return nil
+	}
+
+	ids := make([]uint, 0, len(conversations))
+	userIDs := make([]uint, 0, len(conversations))
+	petIDs := make([]uint, 0, len(conversations))
+	for _, conversation := range conversations {
+		ids = append(ids, conversation.ID)
+		userIDs = append(userIDs, conversation.UserID)
+		if conversation.AssignedTo != nil {
+			userIDs = append(userIDs, *conversation.AssignedTo)
+		}
+		if conversation.PetID != nil {
+			petIDs = append(petIDs, *conversation.PetID)
+		}
+	}
+
+	users, err := dao.GetConversationUsers(userIDs)
+	if err != nil {
+		return err
+	}
+
+	pets, err := dao.GetConversationPets(petIDs)
+	if err != nil {
+		return err
+	}
+
+	unread, err := dao.CountUnreadMessages(ids, !forStaff)
+	if err != nil {
+		return err
+	}
+
+	for i := range conversations {
+		conversation := &conversations[i]
+		conversation.User = users[conversation.UserID]
+		if conversation.AssignedTo != nil {
+			conversation.Assignee = users[*conversation.AssignedTo]
+		}
+		if conversation.PetID != nil {
+			conversation.Pet = pets[*conversation.PetID]
+			if conversation.Pet != nil && conversation.Pet.PrimaryPhoto != nil {
+				fillPhotoURL(conversation.Pet.PrimaryPhoto)
+			}
+		}
+		conversation.Unread = unread[conversation.ID]
+	}
+
+	return nil
+}
+
+// fillConversationMessages loads the messages of a conversation with their senders and attachment URLs.
+func fillConversationMessages(conversation *m.Conversation) error {
+	messages, err := dao.GetConversationMessages(conversation.ID)
+	if err != nil {
+		return err
+	}
+
+	senderIDs := make([]uint, len(messages))
+	for i, message := range messages {
+		senderIDs[i] = message.SenderID
+	}
+
+	senders, err := dao.GetConversationUsers(senderIDs)
+	if err != nil {
+		return err
+	}
+
+	for i := range messages {
+		messages[i].Sender = senders[messages[i].SenderID]
+		for j := range messages[i].Attachments {
+			fillMessageAttachmentURL(conversation.ID, &messages[i].Attachments[j])
+		}
+	}
+	conversation.Messages = messages
+
+	return nil
+}
+
+// fillMessageAttachmentURL computes the download URL of an attachment.
+func fillMessageAttachmentURL(conversationID uint, attachment *m.MessageAttachment) {
+	attachment.URL = fmt.Sprintf("/api/conversations/%d/attachments/%d", conversationID, attachment.ID)
+}
+
+// messageExcerpt shortens a message body to quote it in a notification.
+func messageExcerpt(body string) string {
+	body = strings.TrimSpace(body)
+	if utf8.RuneCountInString(body) <= messageExcerptLength {
+		return body
+	}
+
+	runes := []rune(body)
+	return strings.TrimSpace(string(runes[:messageExcerptLength])) + "…"
+}
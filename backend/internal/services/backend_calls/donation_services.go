@@ -0,0 +1,882 @@
+// Package services provides business logic services for donations and pet sponsorships.
+// This layer records one-off and recurring donations, collects every charge through the
+// payment provider's hosted checkout, computes the public sponsorship total of each pet
+// and issues the yearly donation receipts.
+package services
+
+import (
+	"backend/internal/db/dao"
+	"backend/internal/db/query"
+	m "backend/internal/models"
+	mailer "backend/internal/services/mail"
+	"backend/internal/services/payments"
+	"backend/internal/services/receipt"
+	"backend/internal/services/scheduler"
+	"backend/internal/services/security"
+	"backend/internal/services/storage"
+	"backend/internal/utils/env"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Scheduler job names of donations.
+const (
+	DonationRenewalsJob = "donation-renewals" // Checkout links of recurring donations due
+	DonationReceiptsJob = "donation-receipts" // Receipts of the previous year
+)
+
+var (
+	// donationRenewalHour is the local hour the renewals of recurring donations are sent from (DONATION_RENEWAL_HOUR, default 9).
+	donationRenewalHour = int(env.GetInt("DONATION_RENEWAL_HOUR", 9))
+
+	// donationReceiptHour is the local hour receipts of the previous year are issued from (DONATION_RECEIPT_HOUR, default 6).
+	donationReceiptHour = int(env.GetInt("DONATION_RECEIPT_HOUR", 6))
+)
+
+var (
+	// ErrDonationNotFound is returned for donations that do not exist or are not visible to the user.
+	ErrDonationNotFound = errors.New("donación no encontrada")
+
+	// ErrDonationPetNotFound is returned when the sponsored pet does not exist (in the organisation, if given).
+	ErrDonationPetNotFound = errors.New("mascota no encontrada")
+
+	// ErrDonationOrganizationNotFound is returned when the organisation receiving the donation does not exist.
+	ErrDonationOrganizationNotFound = errors.New("organización no encontrada")
+
+	// ErrDonationClosed is returned when paying or cancelling a donation that is completed or cancelled.
+	ErrDonationClosed = errors.New("la donación ya está finalizada o cancelada")
+
+	// ErrDonationNothingDue is returned when paying an active recurring donation without a pending charge.
+	ErrDonationNothingDue = errors.New("la donación no tiene ningún cobro pendiente")
+
+	// ErrDonationReceiptNotFound is returned for receipts that do not exist or are not visible to the user.
+	ErrDonationReceiptNotFound = errors.New("certificado de donación no encontrado")
+)
+
+// ========================================
+// DONATION SERVICES
+// ========================================
+
+// CreateDonation records a donation and opens the checkout of its first charge.
+//
+// Business Logic:
+// - Donations designated to a pet go to the pet's organisation
+// - The donation stays pending until the provider confirms the first payment
+// - Then one-off donations are completed and recurring ones become active
+//
+// Parameters:
+//   - donation: Validated donation (OrganizationID or PetID, Frequency, AmountCents, Currency, TaxID, Message)
+//   - donor: Current user
+//
+// Returns:
+//   - *m.Donation: Created donation with the CheckoutURL to send the donor to
+//   - error: ErrDonationPetNotFound, ErrDonationOrganizationNotFound, ErrPaymentsDisabled, ErrPaymentProvider or database error
+func CreateDonation(donation *m.Donation, donor *m.NonValidatedUser) (*m.Donation, error) {
+	// Nothing is recorded when the donation could not be paid
+	if !payments.Enabled() {
+		return nil, ErrPaymentsDisabled
+	}
+
+	petName := ""
+	if donation.PetID != nil {
+		pet, err := dao.GetPetByID(*donation.PetID, dao.AllOrganizations)
+		if err != nil || (donation.OrganizationID != 0 && donation.OrganizationID != pet.OrganizationID) {
+			return nil, ErrDonationPetNotFound
+		}
+		donation.OrganizationID = pet.OrganizationID
+		petName = pet.Name
+	}
+
+	organization, err := dao.GetOrganizationByID(donation.OrganizationID)
+	if err != nil {
+		return nil, ErrDonationOrganizationNotFound
+	}
+
+	donation.DonorUserID = donor.ID
+	donation.Status = m.DonationStatusPending
+	donation.NextChargeDate = nil
+	if err := dao.CreateDonation(donation); err != nil {
+		return nil, err
+	}
+
+	payment, err := chargeDonation(donation, donor, organization.Name, petName)
+	if err != nil {
+		return nil, err
+	}
+	donation.CheckoutURL = payment.CheckoutURL
+
+	return donation, nil
+}
+
+// StartDonationCheckout returns the checkout of the pending charge of a donation, opening
+// a new one when the first charge of a pending donation failed or expired.
+//
+// Parameters:
+//   - id: Unique identifier of the donation
+//   - donor: Current user; must be the donor
+//
+// Returns:
+//   - *m.Donation: Donation with the CheckoutURL to send the donor to
+//   - error: ErrDonationNotFound, ErrDonationClosed, ErrDonationNothingDue, ErrPaymentsDisabled, ErrPaymentProvider or database error
+func StartDonationCheckout(id uint, donor *m.NonValidatedUser) (*m.Donation, error) {
+	donation, err := dao.GetDonation(id, dao.AllOrganizations)
+	if err != nil || donation.DonorUserID != donor.ID {
+		return nil, ErrDonationNotFound
+	}
+
+	if donation.Status == m.DonationStatusCompleted || donation.Status == m.DonationStatusCancelled {
+		return nil, ErrDonationClosed
+	}
+
+	checkouts, err := dao.GetPendingDonationCheckouts([]uint{donation.ID})
+	if err != nil {
+		return nil, err
+	}
+	if checkout, ok := checkouts[donation.ID]; ok {
+		donation.CheckoutURL = checkout
+		return donation, nil
+	}
+
+	// Later charges of recurring donations are opened by the renewal job
+	if donation.Status != m.DonationStatusPending {
+		return nil, ErrDonationNothingDue
+	}
+
+	organization, err := dao.GetOrganizationByID(donation.OrganizationID)
+	if err != nil {
+		return nil, ErrDonationOrganizationNotFound
+	}
+
+	petName := ""
+	if donation.PetID != nil {
+		if pet, err := dao.GetPetByID(*donation.PetID, dao.AllOrganizations); err == nil {
+			petName = pet.Name
+		}
+	}
+
+	payment, err := chargeDonation(donation, donor, organization.Name, petName)
+	if err != nil {
+		return nil, err
+	}
+	donation.CheckoutURL = payment.CheckoutURL
+
+	return donation, nil
+}
+
+// CancelDonation cancels a pending or recurring donation. Charges already paid are kept
+// (refunds are issued separately) and pending checkouts can no longer be paid.
+//
+// Parameters:
+//   - id: Unique identifier of the donation
+//   - viewer: Current user; must be the donor or staff of the donation's organisation
+//
+// Returns:
+//   - *m.Donation: Cancelled donation
+//   - error: ErrDonationNotFound, ErrDonationClosed or database error
+func CancelDonation(id uint, viewer *m.NonValidatedUser) (*m.Donation, error) {
+	donation, err := findVisibleDonation(id, viewer)
+	if err != nil {
+		return nil, err
+	}
+
+	cancelled, err := dao.CancelDonation(donation.ID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if !cancelled {
+		return nil, ErrDonationClosed
+	}
+
+	if err := dao.ExpirePendingDonationPayments(donation.ID); err != nil {
+		log.Printf("could not expire pending payments of donation %d: %v", donation.ID, err)
+	}
+
+	updated, err := dao.GetDonation(donation.ID, dao.AllOrganizations)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener donación: %v", err)
+	}
+
+	donations := []m.Donation{*updated}
+	if err := fillDonations(donations, false); err != nil {
+		return nil, err
+	}
+
+	return &donations[0], nil
+}
+
+// ListMyDonations retrieves the donations of the current user in every organisation.
+//
+// Parameters:
+//   - userID: Unique identifier of the donor
+//
+// Returns:
+//   - []m.Donation: Donations with sponsored pet, paid amount and pending checkout
+//   - error: Database error or nil on success
+func ListMyDonations(userID uint) ([]m.Donation, error) {
+	donations, err := dao.GetUserDonations(userID)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener donaciones: %v", err)
+	}
+
+	if err := fillDonations(donations, false); err != nil {
+		return nil, fmt.Errorf("error al obtener donaciones: %v", err)
+	}
+
+	return donations, nil
+}
+
+// NewDonationListQuery parses and validates the pagination, sorting and filter
+// parameters of a donation list request against dao.DonationListSchema.
+//
+// Parameters:
+//   - path: Request path used to build next/prev links
+//   - values: Raw query parameters
+//
+// Returns:
+//   - *query.Params: Validated list query
+//   - error: Validation error describing the invalid parameter
+func NewDonationListQuery(path string, values url.Values) (*query.Params, error) {
+	return query.Parse(path, values, dao.DonationListSchema)
+}
+
+// ListDonations retrieves one page of an organisation's donations.
+//
+// Parameters:
+//   - params: Validated list query (see NewDonationListQuery)
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - *query.Page[m.Donation]: Requested page of donations with donor and pet summaries
+//   - error: Database error or nil on success
+func ListDonations(params *query.Params, orgID uint) (*query.Page[m.Donation], error) {
+	page, err := dao.GetDonations(params, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener donaciones: %v", err)
+	}
+
+	if err := fillDonations(page.Items, true); err != nil {
+		return nil, fmt.Errorf("error al obtener donaciones: %v", err)
+	}
+
+	return page, nil
+}
+
+// GetDonation retrieves a donation of an organisation.
+//
+// Parameters:
+//   - id: Unique identifier of the donation
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - *m.Donation: Donation with donor and pet summaries
+//   - error: ErrDonationNotFound or database error
+func GetDonation(id uint, orgID uint) (*m.Donation, error) {
+	donation, err := dao.GetDonation(id, orgID)
+	if err != nil {
+		return nil, ErrDonationNotFound
+	}
+
+	donations := []m.Donation{*donation}
+	if err := fillDonations(donations, true); err != nil {
+		return nil, fmt.Errorf("error al obtener donación: %v", err)
+	}
+
+	return &donations[0], nil
+}
+
+// ListDonationPayments retrieves the charges of a donation.
+//
+// Parameters:
+//   - id: Unique identifier of the donation
+//   - viewer: Current user; must be the donor or staff of the donation's organisation
+//
+// Returns:
+//   - []m.Payment: Payments with refunds, most recent first
+//   - error: ErrDonationNotFound or database error
+func ListDonationPayments(id uint, viewer *m.NonValidatedUser) ([]m.Payment, error) {
+	if _, err := findVisibleDonation(id, viewer); err != nil {
+		return nil, err
+	}
+
+	payments, err := dao.GetDonationPayments(id)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener pagos de la donación: %v", err)
+	}
+
+	return payments, nil
+}
+
+// RunDonationRenewals opens the checkout of every recurring donation due and emails the link to the donor.
+// Each period is claimed before it is charged, so a donation is never charged twice for the same period.
+// Periods missed while the job was not running are skipped rather than charged at once.
+//
+// Parameters:
+//   - now: Time of the run
+//   - dryRun: Whether to only report the donations due
+//
+// Returns:
+//   - scheduler.Result: Number of renewals sent (or due, for dry runs)
+//   - error: Database error, or the last error if no renewal could be sent
+func RunDonationRenewals(now time.Time, dryRun bool) (scheduler.Result, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+
+	donations, err := dao.GetDonationsDue(today)
+	if err != nil {
+		return scheduler.Result{}, err
+	}
+
+	if dryRun {
+		ids := make([]uint, len(donations))
+		for i, donation := range donations {
+			ids[i] = donation.ID
+		}
+
+		return scheduler.Result{
+			Items:   len(donations),
+			Summary: fmt.Sprintf("se enviarían %d cobros de donaciones periódicas", len(donations)),
+			Preview: ids,
+		}, nil
+	}
+
+	sent := 0
+	var lastErr error
+	for i := range donations {
+		if err := renewDonation(&donations[i], today); err != nil {
+			log.Printf("could not renew donation %d: %v", donations[i].ID, err)
+			lastErr = err
+			continue
+		}
+		sent++
+	}
+
+	if sent == 0 && lastErr != nil {
+		return scheduler.Result{}, fmt.Errorf("no se ha podido enviar ningún cobro: %v", lastErr)
+	}
+
+	return scheduler.Result{Items: sent, Summary: fmt.Sprintf("%d cobros de donaciones periódicas enviados", sent)}, nil
+}
+
+// ========================================
+// DONATION RECEIPT SERVICES
+// ========================================
+
+// GenerateDonationReceipts issues the receipts of a year to every donor that does not have one yet,
+// and emails each receipt to its donor. Receipts already issued are never regenerated.
+//
+// Parameters:
+//   - orgID: Organisation issuing the receipts, or dao.AllOrganizations
+//   - year: Year of the donations (must be over)
+//   - now: Issue time
+//
+// Returns:
+//   - []m.DonationReceipt: Receipts issued by this call
+//   - error: Database error, or the last error if no receipt could be issued
+func GenerateDonationReceipts(orgID uint, year int, now time.Time) ([]m.DonationReceipt, error) {
+	totals, err := dao.GetDonationYearTotals(orgID, year)
+	if err != nil {
+		return nil, err
+	}
+
+	issued := []m.DonationReceipt{}
+	var lastErr error
+	for _, total := range totals {
+		record, err := issueDonationReceipt(total, year, now)
+		if err != nil {
+			log.Printf("could not issue %d receipt of donor %d in organization %d: %v", year, total.DonorUserID, total.OrganizationID, err)
+			lastErr = err
+			continue
+		}
+		if record != nil {
+			issued = append(issued, *record)
+		}
+	}
+
+	if len(issued) == 0 && lastErr != nil {
+		return nil, fmt.Errorf("no se ha podido emitir ningún certificado: %v", lastErr)
+	}
+
+	return issued, nil
+}
+
+// ListDonationReceipts retrieves the receipts issued by an organisation in a year.
+//
+// Parameters:
+//   - orgID: Organisation of the acting staff member
+//   - year: Year of the donations
+//
+// Returns:
+//   - []m.DonationReceipt: Receipts with their download URL
+//   - error: Database error or nil on success
+func ListDonationReceipts(orgID uint, year int) ([]m.DonationReceipt, error) {
+	receipts, err := dao.GetDonationReceipts(orgID, year)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener certificados de donación: %v", err)
+	}
+
+	fillReceiptURLs(receipts)
+	return receipts, nil
+}
+
+// ListMyDonationReceipts retrieves the receipts issued to the current user.
+//
+// Parameters:
+//   - userID: Unique identifier of the donor
+//
+// Returns:
+//   - []m.DonationReceipt: Receipts with their download URL, most recent year first
+//   - error: Database error or nil on success
+func ListMyDonationReceipts(userID uint) ([]m.DonationReceipt, error) {
+	receipts, err := dao.GetUserDonationReceipts(userID)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener certificados de donación: %v", err)
+	}
+
+	fillReceiptURLs(receipts)
+	return receipts, nil
+}
+
+// OpenDonationReceipt opens the stored PDF of a receipt for download.
+//
+// Parameters:
+//   - id: Unique identifier of the receipt
+//   - viewer: Current user; must be the donor or staff of the issuing organisation
+//
+// Returns:
+//   - io.ReadCloser: PDF content (caller must close it)
+//   - string: File name for the download
+//   - error: ErrDonationReceiptNotFound or storage error
+func OpenDonationReceipt(id uint, viewer *m.NonValidatedUser) (io.ReadCloser, string, error) {
+	record, err := dao.GetDonationReceipt(id)
+	if err != nil || viewer == nil || record.FileKey == "" {
+		return nil, "", ErrDonationReceiptNotFound
+	}
+
+	if record.DonorUserID != viewer.ID {
+		if !viewer.IsStaff() {
+			return nil, "", ErrDonationReceiptNotFound
+		}
+		if _, err := ResolveMembership(viewer, record.OrganizationID); err != nil {
+			return nil, "", ErrDonationReceiptNotFound
+		}
+	}
+
+	content, err := storage.Open().Get(context.Background(), record.FileKey)
+	if err != nil {
+		return nil, "", fmt.Errorf("error al leer certificado de donación: %v", err)
+	}
+
+	return content, receiptFilename(record.Number), nil
+}
+
+// RunDonationReceipts issues the receipts of the previous year that have not been issued yet.
+//
+// Parameters:
+//   - now: Time of the run
+//   - dryRun: Whether to only report the receipts that would be issued
+//
+// Returns:
+//   - scheduler.Result: Number of receipts issued (or pending, for dry runs)
+//   - error: Database error, or the last error if no receipt could be issued
+func RunDonationReceipts(now time.Time, dryRun bool) (scheduler.Result, error) {
+	year := now.Year() - 1
+
+	if dryRun {
+		totals, err := dao.GetDonationYearTotals(dao.AllOrganizations, year)
+		if err != nil {
+			return scheduler.Result{}, err
+		}
+
+		return scheduler.Result{
+			Items:   len(totals),
+			Summary: fmt.Sprintf("se emitirían %d certificados de %d", len(totals), year),
+			Preview: totals,
+		}, nil
+	}
+
+	issued, err := GenerateDonationReceipts(dao.AllOrganizations, year, now)
+	if err != nil {
+		return scheduler.Result{}, err
+	}
+
+	return scheduler.Result{Items: len(issued), Summary: fmt.Sprintf("%d certificados de %d emitidos", len(issued), year)}, nil
+}
+
+// ========================================
+// DONATION HELPERS
+// ========================================
+
+// GetPetSponsorship computes the public sponsorship total of a pet.
+func GetPetSponsorship(petID uint) (*m.PetSponsorship, error) {
+	sponsorship, err := dao.GetPetSponsorship(petID)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener apadrinamientos: %v", err)
+	}
+
+	return sponsorship, nil
+}
+
+// findVisibleDonation retrieves a donation the viewer may see: its donor, or staff of its organisation.
+// Other users get ErrDonationNotFound, so the donation's existence is not revealed.
+func findVisibleDonation(id uint, viewer *m.NonValidatedUser) (*m.Donation, error) {
+	donation, err := dao.GetDonation(id, dao.AllOrganizations)
+	if err != nil || viewer == nil {
+		return nil, ErrDonationNotFound
+	}
+
+	if donation.DonorUserID == viewer.ID {
+		return donation, nil
+	}
+
+	if viewer.IsStaff() {
+		if _, err := ResolveMembership(viewer, donation.OrganizationID); err == nil {
+			return donation, nil
+		}
+	}
+
+	return nil, ErrDonationNotFound
+}
+
+// fillDonations sets the computed fields of donations: sponsored pet, paid amount, pending
+// checkout and, for staff listings, the donor summary.
+func fillDonations(donations []m.Donation, withDonor bool) error {
+	if len(donations) == 0 {
+		return nil
+	}
+
+	ids := make([]uint, len(donations))
+	petIDs := make([]uint, 0, len(donations))
+	donorIDs := make([]uint, 0, len(donations))
+	for i, donation := range donations {
+		ids[i] = donation.ID
+		if donation.PetID != nil {
+			petIDs = append(petIDs, *donation.PetID)
+		}
+		donorIDs = append(donorIDs, donation.DonorUserID)
+	}
+
+	pets, err := dao.GetDonationPets(petIDs)
+	if err != nil {
+		return err
+	}
+
+	paid, err := dao.GetDonationPaidCents(ids)
+	if err != nil {
+		return err
+	}
+
+	checkouts, err := dao.GetPendingDonationCheckouts(ids)
+	if err != nil {
+		return err
+	}
+
+	donors := map[uint]*m.SimplifiedUser{}
+	if withDonor {
+		if donors, err = dao.GetDonationDonors(donorIDs); err != nil {
+			return err
+		}
+	}
+
+	for i := range donations {
+		if donations[i].PetID != nil {
+			donations[i].Pet = pets[*donations[i].PetID]
+			if donations[i].Pet != nil && donations[i].Pet.PrimaryPhoto != nil {
+				fillPhotoURL(donations[i].Pet.PrimaryPhoto)
+			}
+		}
+		donations[i].PaidCents = paid[donations[i].ID]
+		donations[i].CheckoutURL = checkouts[donations[i].ID]
+		donations[i].Donor = donors[donations[i].DonorUserID]
+	}
+
+	return nil
+}
+
+// chargeDonation opens the checkout of one charge of a donation.
+func chargeDonation(donation *m.Donation, donor *m.NonValidatedUser, organization string, petName string) (*m.Payment, error) {
+	payment := &m.Payment{
+		OrganizationID: donation.OrganizationID,
+		DonationID:     &donation.ID,
+		AmountCents:    donation.AmountCents,
+		Currency:       donation.Currency,
+		CreatedBy:      donor.ID,
+	}
+
+	returnURL := fmt.Sprintf("%s/donations/%d?result=", frontendURL, donation.ID)
+	if err := openCheckout(payment, donationDescription(donation, organization, petName), donor.Email, returnURL); err != nil {
+		return nil, err
+	}
+
+	return payment, nil
+}
+
+// activateDonation records the first paid charge of a donation: one-off donations are
+// completed and recurring ones are scheduled one period after the payment.
+func activateDonation(donationID uint, paidAt time.Time) {
+	donation, err := dao.GetDonation(donationID, dao.AllOrganizations)
+	if err != nil {
+		log.Printf("could not load paid donation %d: %v", donationID, err)
+		return
+	}
+
+	today := time.Date(paidAt.Year(), paidAt.Month(), paidAt.Day(), 0, 0, 0, 0, time.Local)
+	if err := dao.ActivateDonation(donation.ID, donation.NextCharge(today)); err != nil {
+		log.Printf("could not activate donation %d: %v", donation.ID, err)
+	}
+}
+
+// renewDonation claims the charge of a recurring donation due, opens its checkout and emails the link to the donor.
+func renewDonation(donation *m.Donation, today time.Time) error {
+	due := *donation.NextChargeDate
+	next := donation.NextCharge(due)
+	for !next.After(today) {
+		next = donation.NextCharge(next)
+	}
+
+	claimed, err := dao.ClaimDonationCharge(donation.ID, due, next)
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		// Renewed by a concurrent run, or cancelled meanwhile
+		return nil
+	}
+
+	donor, err := dao.GetUserByID(donation.DonorUserID)
+	if err != nil {
+		return fmt.Errorf("error al obtener donante: %v", err)
+	}
+
+	sender := organizationSender(donation.OrganizationID)
+
+	petName := ""
+	if donation.PetID != nil {
+		if pet, err := dao.GetPetByID(*donation.PetID, dao.AllOrganizations); err == nil {
+			petName = pet.Name
+		}
+	}
+
+	payment, err := chargeDonation(donation, donor, sender.Name, petName)
+	if err != nil {
+		return err
+	}
+
+	email, err := mailer.ComposeDonationRenewal(donor.Email, donor.Locale, mailer.DonationRenewalData{
+		DonorName:    strings.TrimSpace(donor.Name + " " + donor.Surname),
+		Organization: sender.Name,
+		PetName:      petName,
+		Frequency:    donation.Frequency,
+		Amount:       receipt.FormatAmount(donation.AmountCents, donation.Currency),
+		Date:         today.Format("02/01/2006"),
+		CheckoutURL:  payment.CheckoutURL,
+	}, sender)
+	if err != nil {
+		return err
+	}
+
+	return notify(notification{
+		UserID: &donor.ID,
+		Type:   m.NotificationReminders,
+		URL:    payment.CheckoutURL,
+		Email:  email,
+	})
+}
+
+// issueDonationReceipt generates, stores and emails the receipt of a donor's yearly total.
+// Returns nil without error when the receipt was issued concurrently.
+func issueDonationReceipt(total m.DonationYearTotal, year int, now time.Time) (*m.DonationReceipt, error) {
+	payments, err := dao.GetDonorYearPayments(total, year)
+	if err != nil {
+		return nil, err
+	}
+
+	donationIDs := make([]uint, 0, len(payments))
+	for _, payment := range payments {
+		donationIDs = append(donationIDs, *payment.DonationID)
+	}
+
+	donations, err := dao.GetDonationsByID(donationIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	petIDs := make([]uint, 0, len(donations))
+	for _, donation := range donations {
+		if donation.PetID != nil {
+			petIDs = append(petIDs, *donation.PetID)
+		}
+	}
+
+	pets, err := dao.GetDonationPets(petIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	donor, err := dao.GetUserByID(total.DonorUserID)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener donante: %v", err)
+	}
+
+	organization, err := dao.GetOrganizationByID(total.OrganizationID)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener organización: %v", err)
+	}
+
+	data := receipt.Data{
+		Year: year,
+		Issuer: receipt.Issuer{
+			Name:    organization.Name,
+			Email:   organization.Email,
+			Phone:   organization.Phone,
+			Address: organization.Address,
+		},
+		Donor: receipt.Donor{
+			FullName: strings.TrimSpace(donor.Name + " " + donor.Surname),
+			Email:    donor.Email,
+		},
+		Currency:   total.Currency,
+		TotalCents: total.TotalCents,
+	}
+	for _, payment := range payments {
+		donation := donations[*payment.DonationID]
+		petName := ""
+		if donation != nil && donation.PetID != nil && pets[*donation.PetID] != nil {
+			petName = pets[*donation.PetID].Name
+		}
+		if donation != nil && donation.TaxID != "" {
+			// The most recent tax ID given by the donor wins
+			data.Donor.TaxID = donation.TaxID
+		}
+
+		data.Lines = append(data.Lines, receipt.Line{
+			Date:        *payment.PaidAt,
+			Description: donationDescription(donation, "", petName),
+			AmountCents: payment.NetCents(),
+		})
+	}
+
+	record := &m.DonationReceipt{
+		OrganizationID: total.OrganizationID,
+		DonorUserID:    total.DonorUserID,
+		Year:           year,
+		Currency:       total.Currency,
+		TotalCents:     total.TotalCents,
+	}
+	created, err := dao.CreateDonationReceipt(record)
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		return nil, nil
+	}
+
+	record.Number = receipt.Number(year, record.ID)
+	data.Number = record.Number
+
+	pdf, err := storeDonationReceipt(record, data, now)
+	if err != nil {
+		if err := dao.DeleteDonationReceipt(record.ID); err != nil {
+			log.Printf("could not remove failed receipt %d: %v", record.ID, err)
+		}
+		return nil, err
+	}
+
+	sender := organizationSender(record.OrganizationID)
+	email, err := mailer.ComposeDonationReceipt(donor.Email, donor.Locale, mailer.DonationReceiptData{
+		DonorName:    data.Donor.FullName,
+		Organization: sender.Name,
+		Year:         year,
+		Number:       record.Number,
+		Total:        receipt.FormatAmount(record.TotalCents, record.Currency),
+		Hash:         record.FileHash,
+	}, receiptFilename(record.Number), pdf, sender)
+	if err == nil {
+		// Receipts are tax documents: always emailed, whatever the preferences
+		err = notify(notification{UserID: &donor.ID, Email: email})
+	}
+	if err != nil {
+		// The receipt stays available for download; it is not sent again automatically
+		log.Printf("could not send receipt %s: %v", record.Number, err)
+		return record, nil
+	}
+
+	if err := dao.MarkDonationReceiptSent(record.ID, now); err != nil {
+		log.Printf("could not mark receipt %s as sent: %v", record.Number, err)
+	}
+	record.SentAt = &now
+
+	return record, nil
+}
+
+// storeDonationReceipt renders a receipt, stores the PDF and records its key and hash.
+func storeDonationReceipt(record *m.DonationReceipt, data receipt.Data, now time.Time) ([]byte, error) {
+	pdf, err := receipt.Render(data, now)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	store := storage.Open()
+
+	key := fmt.Sprintf("receipts/%d/%d/%s/%s.pdf",
+		record.OrganizationID, record.Year, strings.ToLower(security.Generate2FA(20)), record.Number)
+	if err := store.Put(ctx, key, pdf, receipt.ContentType); err != nil {
+		return nil, fmt.Errorf("error al guardar certificado de donación: %v", err)
+	}
+
+	record.FileKey = key
+	record.FileHash = contractHash(pdf)
+	if err := dao.SetDonationReceiptFile(record); err != nil {
+		if err := store.Delete(ctx, key); err != nil {
+			log.Printf("could not remove stored receipt %s: %v", key, err)
+		}
+		return nil, err
+	}
+
+	return pdf, nil
+}
+
+// fillReceiptURLs sets the download URL of receipts.
+func fillReceiptURLs(receipts []m.DonationReceipt) {
+	for i := range receipts {
+		if receipts[i].FileKey != "" {
+			receipts[i].DownloadURL = fmt.Sprintf("/api/donation-receipts/%d", receipts[i].ID)
+		}
+	}
+}
+
+// receiptFilename returns the download file name of a receipt.
+func receiptFilename(number string) string {
+	return "certificado-" + number + ".pdf"
+}
+
+// donationDescription describes a donation charge, for the checkout page and receipts.
+// The organisation name is optional.
+func donationDescription(donation *m.Donation, organization string, petName string) string {
+	if petName != "" {
+		return "Apadrinamiento de " + petName
+	}
+
+	description := "Donación"
+	if donation != nil {
+		switch donation.Frequency {
+		case m.DonationMonthly:
+			description = "Donación mensual"
+		case m.DonationYearly:
+			description = "Donación anual"
+		default:
+			description = "Donación puntual"
+		}
+	}
+
+	if organization != "" {
+		description += " a " + organization
+	}
+
+	return description
+}
@@ -0,0 +1,136 @@
+// Package services provides business logic services for real-time updates.
+// This layer decides which events each client may receive and publishes the
+// events of the other services (pets, adoptions, messages and notifications) on the events hub.
+package services
+
+import (
+	"backend/internal/db/dao"
+	m "backend/internal/models"
+	"backend/internal/services/events"
+	"fmt"
+)
+
+// ========================================
+// EVENT STREAM SERVICES
+// ========================================
+
+// SubscribeEvents registers a client for real-time events and returns the events it missed.
+//
+// Business Logic:
+// - Everyone receives the public pet events
+// - Authenticated users also receive the events about their adoptions and conversations
+// - Staff receive the events of the organisations they belong to (admins, of every organisation)
+// - The memberships are read once; changes apply when the client reconnects
+//
+// Parameters:
+//   - viewer: Current user, or nil for anonymous clients
+//   - lastEventID: ID of the last event the client received (Last-Event-ID), empty for a new stream
+//
+// Returns:
+//   - *events.Subscription: Registered subscriber (release it with UnsubscribeEvents)
+//   - []events.Event: Missed events, or a resync event when they are no longer available
+//   - error: Database error or nil on success
+func SubscribeEvents(viewer *m.NonValidatedUser, lastEventID string) (*events.Subscription, []events.Event, error) {
+	var audience events.Viewer
+	if viewer != nil {
+		audience.UserID = viewer.ID
+
+		if viewer.Role == m.UserRoleAdmin {
+			audience.AllOrganizations = true
+		} else if viewer.IsStaff() {
+			memberships, err := dao.GetUserMemberships(viewer.ID)
+			if err != nil {
+				return nil, nil, fmt.Errorf("error al obtener organizaciones: %v", err)
+			}
+			for _, membership := range memberships {
+				audience.OrganizationIDs = append(audience.OrganizationIDs, membership.OrganizationID)
+			}
+		}
+	}
+
+	sub, missed := events.Subscribe(audience, lastEventID)
+	return sub, missed, nil
+}
+
+// UnsubscribeEvents releases a client registered with SubscribeEvents.
+//
+// Parameters:
+//   - sub: Subscriber to release
+func UnsubscribeEvents(sub *events.Subscription) {
+	events.Unsubscribe(sub)
+}
+
+// ========================================
+// EVENT PUBLISHING HELPERS
+// ========================================
+
+// publishPetCreated announces a new pet to every client.
+func publishPetCreated(pet *m.Pet) {
+	events.Publish(events.Event{
+		Type:   events.TypePetCreated,
+		Public: true,
+		Data: m.PetEvent{
+			PetID:          pet.ID,
+			OrganizationID: pet.OrganizationID,
+			Name:           pet.Name,
+			Species:        pet.Species,
+			Status:         pet.Status,
+		},
+	})
+}
+
+// publishPetStatusChanged announces a change of adoption status of a pet to every client.
+func publishPetStatusChanged(pet *m.Pet, previousStatus string) {
+	events.Publish(events.Event{
+		Type:   events.TypePetStatusChanged,
+		Public: true,
+		Data: m.PetEvent{
+			PetID:          pet.ID,
+			OrganizationID: pet.OrganizationID,
+			Name:           pet.Name,
+			Species:        pet.Species,
+			Status:         pet.Status,
+			PreviousStatus: previousStatus,
+		},
+	})
+}
+
+// publishApplicationUpdated announces a step of an adoption to the organisation's staff and the adopter.
+func publishApplicationUpdated(adoption *m.Adoption, status string) {
+	events.Publish(events.Event{
+		Type:           events.TypeApplicationUpdated,
+		OrganizationID: adoption.OrganizationID,
+		UserID:         adoption.AdopterUserID,
+		Data: m.ApplicationEvent{
+			AdoptionID:     adoption.ID,
+			OrganizationID: adoption.OrganizationID,
+			PetID:          adoption.PetID,
+			UserID:         adoption.AdopterUserID,
+			Status:         status,
+		},
+	})
+}
+
+// publishNewMessage announces a message to the staff of the conversation's organisation and its user.
+func publishNewMessage(conversation *m.Conversation, message *m.Message) {
+	events.Publish(events.Event{
+		Type:           events.TypeNewMessage,
+		OrganizationID: conversation.OrganizationID,
+		UserID:         conversation.UserID,
+		Data: m.MessageEvent{
+			ConversationID: conversation.ID,
+			MessageID:      message.ID,
+			SenderID:       message.SenderID,
+			FromStaff:      message.FromStaff,
+		},
+	})
+}
+
+// publishNotification announces a new entry of the notification centre to its user.
+func publishNotification(notification *m.Notification) {
+	events.Publish(events.Event{
+		Type:   events.TypeNotification,
+		UserID: notification.UserID,
+		Data:   notification,
+	})
+}
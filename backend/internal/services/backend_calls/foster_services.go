@@ -0,0 +1,616 @@
+// Package services provides business logic services for the foster care programme.
+// This layer manages foster homes and the placements of pets in them, keeps homes
+// within their capacity and lets fosters post updates and photos about the pets they care for.
+package services
+
+import (
+	"backend/internal/db/dao"
+	"backend/internal/db/query"
+	m "backend/internal/models"
+	"backend/internal/services/security"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// MaxFosterUpdatePhotos is the maximum number of photos posted with a foster update.
+const MaxFosterUpdatePhotos = 5
+
+var (
+	// ErrFosterHomeNotFound is returned when the foster home does not exist in the organisation.
+	ErrFosterHomeNotFound = errors.New("casa de acogida no encontrada")
+
+	// ErrFosterHomeExists is returned when the user already has a foster home in the organisation.
+	ErrFosterHomeExists = errors.New("el usuario ya tiene una casa de acogida en esta organización")
+
+	// ErrFosterHomeInUse is returned when deleting a foster home with placements.
+	ErrFosterHomeInUse = errors.New("la casa de acogida tiene acogidas registradas, desactívala en su lugar")
+
+	// ErrFosterUserNotFound is returned when the foster user does not exist.
+	ErrFosterUserNotFound = errors.New("usuario no encontrado")
+
+	// ErrFosterPlacementNotFound is returned for placements that do not exist or are not visible to the caller.
+	ErrFosterPlacementNotFound = errors.New("acogida no encontrada")
+
+	// ErrFosterPetNotFound is returned when the pet to place does not exist in the organisation.
+	ErrFosterPetNotFound = errors.New("mascota no encontrada")
+
+	// ErrFosterPlacementRejected is returned when a pet cannot be placed in a foster home.
+	// It is wrapped with the reason (inactive or full home, species not accepted, pet adopted or already fostered).
+	ErrFosterPlacementRejected = errors.New("no se puede realizar la acogida")
+
+	// ErrFosterPlacementEnded is returned when modifying a finished placement in a way that would reopen it,
+	// or posting updates about it.
+	ErrFosterPlacementEnded = errors.New("la acogida ya ha finalizado")
+)
+
+// ========================================
+// FOSTER HOME SERVICES
+// ========================================
+
+// NewFosterHomeListQuery parses and validates the pagination, sorting and filter
+// parameters of a foster home list request against dao.FosterHomeListSchema.
+//
+// Parameters:
+//   - path: Request path used to build next/prev links
+//   - values: Raw query parameters
+//
+// Returns:
+//   - *query.Params: Validated list query
+//   - error: Validation error describing the invalid parameter
+func NewFosterHomeListQuery(path string, values url.Values) (*query.Params, error) {
+	return query.Parse(path, values, dao.FosterHomeListSchema)
+}
+
+// ListFosterHomes retrieves one page of an organisation's foster homes with their occupancy.
+//
+// Parameters:
+//   - params: Validated list query (see NewFosterHomeListQuery)
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - *query.Page[m.FosterHome]: Foster homes with their foster, current placements and free capacity
+//   - error: Database error or nil on success
+func ListFosterHomes(params *query.Params, orgID uint) (*query.Page[m.FosterHome], error) {
+	homes, err := dao.GetFosterHomes(params, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener casas de acogida: %v", err)
+	}
+
+	if err := fillFosterHomes(homes.Items); err != nil {
+		return nil, err
+	}
+
+	return homes, nil
+}
+
+// GetFosterHome retrieves a foster home of an organisation with its occupancy.
+//
+// Parameters:
+//   - id: Unique identifier of the foster home
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - *m.FosterHome: Foster home with its foster, current placements and free capacity
+//   - error: ErrFosterHomeNotFound or database error
+func GetFosterHome(id uint, orgID uint) (*m.FosterHome, error) {
+	home, err := dao.GetFosterHome(id, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrFosterHomeNotFound, err)
+	}
+
+	homes := []m.FosterHome{*home}
+	if err := fillFosterHomes(homes); err != nil {
+		return nil, err
+	}
+
+	return &homes[0], nil
+}
+
+// CreateFosterHome registers a user as a foster home of an organisation.
+//
+// Business Logic:
+// - Any registered user can foster; the account does not need a staff role
+// - A user has at most one foster home per organisation
+//
+// Parameters:
+//   - home: Validated foster home (must include OrganizationID and UserID)
+//
+// Returns:
+//   - *m.FosterHome: Created foster home with its occupancy
+//   - error: ErrFosterUserNotFound, ErrFosterHomeExists or database error
+func CreateFosterHome(home *m.FosterHome) (*m.FosterHome, error) {
+	if _, err := dao.GetUserByID(home.UserID); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrFosterUserNotFound, err)
+	}
+
+	existing, err := dao.GetFosterHomeByUser(home.OrganizationID, home.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrFosterHomeExists
+	}
+
+	if err := dao.CreateFosterHome(home); err != nil {
+		return nil, fmt.Errorf("error al crear casa de acogida: %v", err)
+	}
+
+	return GetFosterHome(home.ID, home.OrganizationID)
+}
+
+// UpdateFosterHome replaces the profile of a foster home.
+// Lowering the capacity below the current occupancy is allowed: the home
+// keeps its pets but receives no new placements until pets leave.
+//
+// Parameters:
+//   - home: Validated foster home (must include ID and OrganizationID)
+//
+// Returns:
+//   - *m.FosterHome: Updated foster home with its occupancy
+//   - error: ErrFosterHomeNotFound or database error
+func UpdateFosterHome(home *m.FosterHome) (*m.FosterHome, error) {
+	if err := dao.UpdateFosterHome(home); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrFosterHomeNotFound, err)
+	}
+
+	return GetFosterHome(home.ID, home.OrganizationID)
+}
+
+// DeleteFosterHome removes a foster home without placements.
+// Homes with a placement history are kept and should be deactivated instead.
+//
+// Parameters:
+//   - id: Unique identifier of the foster home
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - error: ErrFosterHomeNotFound, ErrFosterHomeInUse or database error
+func DeleteFosterHome(id uint, orgID uint) error {
+	if _, err := dao.GetFosterHome(id, orgID); err != nil {
+		return fmt.Errorf("%w: %v", ErrFosterHomeNotFound, err)
+	}
+
+	placements, err := dao.CountFosterPlacements(id)
+	if err != nil {
+		return err
+	}
+	if placements > 0 {
+		return ErrFosterHomeInUse
+	}
+
+	if err := dao.DeleteFosterHome(id, orgID); err != nil {
+		return fmt.Errorf("error al eliminar casa de acogida: %v", err)
+	}
+
+	return nil
+}
+
+// ========================================
+// FOSTER PLACEMENT SERVICES
+// ========================================
+
+// NewFosterPlacementListQuery parses and validates the pagination, sorting and filter
+// parameters of a placement list request against dao.FosterPlacementListSchema.
+//
+// Parameters:
+//   - path: Request path used to build next/prev links
+//   - values: Raw query parameters
+//
+// Returns:
+//   - *query.Params: Validated list query
+//   - error: Validation error describing the invalid parameter
+func NewFosterPlacementListQuery(path string, values url.Values) (*query.Params, error) {
+	return query.Parse(path, values, dao.FosterPlacementListSchema)
+}
+
+// ListFosterPlacements retrieves one page of an organisation's placements.
+//
+// Parameters:
+//   - params: Validated list query (see NewFosterPlacementListQuery)
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - *query.Page[m.FosterPlacement]: Placements with pet summaries
+//   - error: Database error or nil on success
+func ListFosterPlacements(params *query.Params, orgID uint) (*query.Page[m.FosterPlacement], error) {
+	placements, err := dao.GetFosterPlacements(params, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener acogidas: %v", err)
+	}
+
+	for i := range placements.Items {
+		fillPlacementPhoto(&placements.Items[i])
+	}
+
+	return placements, nil
+}
+
+// GetFosterPlacement retrieves a placement of an organisation.
+//
+// Parameters:
+//   - id: Unique identifier of the placement
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - *m.FosterPlacement: Placement with pet summary
+//   - error: ErrFosterPlacementNotFound
+func GetFosterPlacement(id uint, orgID uint) (*m.FosterPlacement, error) {
+	placement, err := dao.GetFosterPlacement(id)
+	if err != nil || placement.OrganizationID != orgID {
+		return nil, ErrFosterPlacementNotFound
+	}
+
+	fillPlacementPhoto(placement)
+
+	return placement, nil
+}
+
+// CreateFosterPlacement moves a pet of the organisation to one of its foster homes.
+//
+// Business Logic:
+// - The foster home must be active, accept the pet's species and have free capacity
+// - The pet must not be adopted nor already live in a foster home
+// - The pet leaves its shelter kennel
+//
+// Parameters:
+//   - placement: Validated placement (must include PetID, FosterHomeID, StartDate and CreatedBy)
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - *m.FosterPlacement: Created placement with pet summary
+//   - error: ErrFosterPetNotFound, ErrFosterHomeNotFound, ErrFosterPlacementRejected (wrapped) or database error
+func CreateFosterPlacement(placement *m.FosterPlacement, orgID uint) (*m.FosterPlacement, error) {
+	pet, err := findOrganizationPet(placement.PetID, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrFosterPetNotFound, err)
+	}
+
+	home, err := GetFosterHome(placement.FosterHomeID, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case !home.Active:
+		return nil, fmt.Errorf("%w: la casa de acogida no está activa", ErrFosterPlacementRejected)
+	case home.FreeCapacity == 0:
+		return nil, fmt.Errorf("%w: la casa de acogida no tiene plazas libres", ErrFosterPlacementRejected)
+	case !home.AcceptsSpecies(pet.Species):
+		return nil, fmt.Errorf("%w: la casa de acogida no acepta la especie %s", ErrFosterPlacementRejected, pet.Species)
+	case pet.Status == m.PetStatusAdopted:
+		return nil, fmt.Errorf("%w: la mascota ya ha sido adoptada", ErrFosterPlacementRejected)
+	}
+
+	current, err := dao.GetCurrentPlacementForPet(pet.ID)
+	if err != nil {
+		return nil, err
+	}
+	if current != nil {
+		return nil, fmt.Errorf("%w: la mascota ya está en la casa de acogida %d", ErrFosterPlacementRejected, current.FosterHomeID)
+	}
+
+	placement.OrganizationID = orgID
+	placement.EndDate = nil
+	if err := dao.CreateFosterPlacement(placement); err != nil {
+		return nil, fmt.Errorf("error al crear acogida: %v", err)
+	}
+
+	EndPetLocationForPet(pet.ID, "acogida")
+
+	return GetFosterPlacement(placement.ID, orgID)
+}
+
+// UpdateFosterPlacement replaces the expected end date, end date and notes of a placement.
+// Setting the end date ends the placement and frees its place in the foster home.
+//
+// Business Logic:
+// - The end date cannot be before the start date
+// - Finished placements cannot be reopened (clearing the end date is rejected)
+//
+// Parameters:
+//   - update: Placement with the new data (must include ID)
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - *m.FosterPlacement: Updated placement
+//   - error: ErrFosterPlacementNotFound, ErrFosterPlacementEnded, ErrFosterPlacementRejected (wrapped) or database error
+func UpdateFosterPlacement(update *m.FosterPlacement, orgID uint) (*m.FosterPlacement, error) {
+	placement, err := GetFosterPlacement(update.ID, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !placement.IsCurrent() && update.EndDate == nil {
+		return nil, ErrFosterPlacementEnded
+	}
+
+	if update.EndDate != nil && update.EndDate.Before(placement.StartDate) {
+		return nil, fmt.Errorf("%w: end_date no puede ser anterior a start_date", ErrFosterPlacementRejected)
+	}
+	if update.ExpectedEndDate != nil && update.ExpectedEndDate.Before(placement.StartDate) {
+		return nil, fmt.Errorf("%w: expected_end_date no puede ser anterior a start_date", ErrFosterPlacementRejected)
+	}
+
+	if err := dao.UpdateFosterPlacement(update); err != nil {
+		return nil, fmt.Errorf("error al actualizar acogida: %v", err)
+	}
+
+	return GetFosterPlacement(update.ID, orgID)
+}
+
+// EndFosterPlacementForPet ends the current placement of a pet, if any, as of today.
+// Called when a pet is adopted; failures are logged.
+//
+// Parameters:
+//   - petID: Unique identifier of the pet
+func EndFosterPlacementForPet(petID uint) {
+	placement, err := dao.GetCurrentPlacementForPet(petID)
+	if err != nil {
+		log.Printf("could not load current foster placement of pet %d: %v", petID, err)
+		return
+	}
+	if placement == nil {
+		return
+	}
+
+	year, month, day := time.Now().Date()
+	today := time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+	placement.EndDate = &today
+	if err := dao.UpdateFosterPlacement(placement); err != nil {
+		log.Printf("could not end foster placement %d of adopted pet %d: %v", placement.ID, petID, err)
+	}
+}
+
+// ListUserFosterPlacements retrieves the placements of the foster homes of a user.
+// Internal notes are removed.
+//
+// Parameters:
+//   - userID: Unique identifier of the foster user
+//
+// Returns:
+//   - []m.FosterPlacement: Placements with pet summaries, current ones first
+//   - error: Database error or nil on success
+func ListUserFosterPlacements(userID uint) ([]m.FosterPlacement, error) {
+	placements, err := dao.GetUserFosterPlacements(userID)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener acogidas: %v", err)
+	}
+
+	for i := range placements {
+		placements[i].Notes = ""
+		fillPlacementPhoto(&placements[i])
+	}
+
+	return placements, nil
+}
+
+// ========================================
+// FOSTER UPDATE SERVICES
+// ========================================
+
+// ListFosterUpdates retrieves the updates posted about a placement, newest first.
+//
+// Parameters:
+//   - placementID: Unique identifier of the placement
+//   - viewer: Current user; must be the foster of the placement or staff of its organisation
+//
+// Returns:
+//   - []m.FosterUpdate: Updates with photo URLs
+//   - error: ErrFosterPlacementNotFound or database error
+func ListFosterUpdates(placementID uint, viewer *m.NonValidatedUser) ([]m.FosterUpdate, error) {
+	if _, err := findVisiblePlacement(placementID, viewer); err != nil {
+		return nil, err
+	}
+
+	updates, err := dao.GetFosterUpdates(placementID)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener novedades: %v", err)
+	}
+
+	for i := range updates {
+		for j := range updates[i].Photos {
+			fillFosterUpdatePhotoURL(&updates[i].Photos[j])
+		}
+	}
+
+	return updates, nil
+}
+
+// CreateFosterUpdate stores news posted by a foster about a pet in their care, with its photos.
+//
+// Business Logic:
+// - Only the foster of the placement can post, and only while the placement is current
+// - Photos are processed like pet photos (EXIF stripped, thumbnails generated)
+// - If a photo cannot be stored, the update and every stored photo are removed
+//
+// Parameters:
+//   - update: Validated update (must include PlacementID, AuthorUserID and Message)
+//   - photos: Raw image bytes of the photos (content type already validated)
+//
+// Returns:
+//   - error: ErrFosterPlacementNotFound, ErrFosterPlacementEnded, processing error
+//     (wrapping imaging.ErrInvalidImage), storage or database error
+func CreateFosterUpdate(update *m.FosterUpdate, photos [][]byte) error {
+	placement, err := dao.GetFosterPlacement(update.PlacementID)
+	if err != nil {
+		return ErrFosterPlacementNotFound
+	}
+
+	home, err := dao.GetFosterHome(placement.FosterHomeID, dao.AllOrganizations)
+	if err != nil || home.UserID != update.AuthorUserID {
+		return ErrFosterPlacementNotFound
+	}
+
+	if !placement.IsCurrent() {
+		return ErrFosterPlacementEnded
+	}
+
+	update.Photos = nil
+	if err := dao.CreateFosterUpdate(update); err != nil {
+		return fmt.Errorf("error al crear novedad: %v", err)
+	}
+
+	// Removes the update and the photos stored so far
+	rollback := func() {
+		for _, photo := range update.Photos {
+			deleteStoredImage(photo.StorageKey, photo.Extension)
+		}
+		if err := dao.DeleteFosterUpdate(update.ID); err != nil {
+			log.Printf("could not remove incomplete foster update %d: %v", update.ID, err)
+		}
+	}
+
+	for _, data := range photos {
+		storageKey := fmt.Sprintf("foster/%d/%d/%s", placement.ID, update.ID, strings.ToLower(security.Generate2FA(20)))
+		original, err := storeImage(storageKey, data)
+		if err != nil {
+			rollback()
+			return err
+		}
+
+		photo := m.FosterUpdatePhoto{
+			UpdateID:    update.ID,
+			StorageKey:  storageKey,
+			ContentType: original.ContentType,
+			Extension:   original.Extension,
+			Size:        int64(len(original.Data)),
+			Width:       original.Width,
+			Height:      original.Height,
+		}
+		if err := dao.CreateFosterUpdatePhoto(&photo); err != nil {
+			deleteStoredImage(storageKey, original.Extension)
+			rollback()
+			return fmt.Errorf("error al registrar foto: %v", err)
+		}
+
+		update.Photos = append(update.Photos, photo)
+	}
+
+	for i := range update.Photos {
+		fillFosterUpdatePhotoURL(&update.Photos[i])
+	}
+
+	return nil
+}
+
+// OpenFosterUpdatePhoto opens a stored update photo variant for download.
+//
+// Parameters:
+//   - updateID: Unique identifier of the update
+//   - photoID: Unique identifier of the photo
+//   - variant: "original" or one of the names in PhotoThumbnailSizes
+//   - viewer: Current user; must be the foster of the placement or staff of its organisation
+//
+// Returns:
+//   - io.ReadCloser: Image content (caller must close it)
+//   - string: MIME type of the content
+//   - error: Unknown variant, ErrFosterPlacementNotFound or storage error
+func OpenFosterUpdatePhoto(updateID uint, photoID uint, variant string, viewer *m.NonValidatedUser) (io.ReadCloser, string, error) {
+	if !isPhotoVariant(variant) {
+		return nil, "", fmt.Errorf("variante de foto desconocida: %s", variant)
+	}
+
+	update, err := dao.GetFosterUpdate(updateID)
+	if err != nil {
+		return nil, "", ErrFosterPlacementNotFound
+	}
+
+	if _, err := findVisiblePlacement(update.PlacementID, viewer); err != nil {
+		return nil, "", err
+	}
+
+	photo, err := dao.GetFosterUpdatePhoto(updateID, photoID)
+	if err != nil {
+		return nil, "", ErrFosterPlacementNotFound
+	}
+
+	return openStoredImage(photo.StorageKey, photo.Extension, photo.ContentType, variant)
+}
+
+// ========================================
+// FOSTER HELPERS
+// ========================================
+
+// fillFosterHomes sets the foster user, current placements, occupancy and free capacity of foster homes.
+func fillFosterHomes(homes []m.FosterHome) error {
+	if len(homes) == 0 {
+		return nil
+	}
+
+	homeIDs := make([]uint, len(homes))
+	userIDs := make([]uint, len(homes))
+	for i, home := range homes {
+		homeIDs[i] = home.ID
+		userIDs[i] = home.UserID
+	}
+
+	users, err := dao.GetFosterHomeUsers(userIDs)
+	if err != nil {
+		return err
+	}
+
+	placements, err := dao.GetCurrentPlacements(homeIDs)
+	if err != nil {
+		return err
+	}
+
+	byHome := make(map[uint][]m.FosterPlacement, len(homes))
+	for _, placement := range placements {
+		fillPlacementPhoto(&placement)
+		byHome[placement.FosterHomeID] = append(byHome[placement.FosterHomeID], placement)
+	}
+
+	for i := range homes {
+		homes[i].User = users[homes[i].UserID]
+		homes[i].Placements = byHome[homes[i].ID]
+		homes[i].Occupied = len(homes[i].Placements)
+		homes[i].FreeCapacity = max(homes[i].Capacity-homes[i].Occupied, 0)
+	}
+
+	return nil
+}
+
+// findVisiblePlacement retrieves a placement the viewer may follow: its foster, or staff of its organisation.
+// Other users get ErrFosterPlacementNotFound, so the placement's existence is not revealed.
+func findVisiblePlacement(placementID uint, viewer *m.NonValidatedUser) (*m.FosterPlacement, error) {
+	placement, err := dao.GetFosterPlacement(placementID)
+	if err != nil || viewer == nil {
+		return nil, ErrFosterPlacementNotFound
+	}
+
+	if viewer.IsStaff() {
+		if _, err := ResolveMembership(viewer, placement.OrganizationID); err == nil {
+			return placement, nil
+		}
+	}
+
+	home, err := dao.GetFosterHome(placement.FosterHomeID, dao.AllOrganizations)
+	if err != nil || home.UserID != viewer.ID {
+		return nil, ErrFosterPlacementNotFound
+	}
+
+	return placement, nil
+}
+
+// fillPlacementPhoto computes the download URLs of the primary photo of a placement's pet.
+func fillPlacementPhoto(placement *m.FosterPlacement) {
+	if placement.PetSummary != nil && placement.PetSummary.PrimaryPhoto != nil {
+		fillPhotoURL(placement.PetSummary.PrimaryPhoto)
+	}
+}
+
+// fillFosterUpdatePhotoURL computes the download URLs of an update photo and its thumbnails.
+func fillFosterUpdatePhotoURL(photo *m.FosterUpdatePhoto) {
+	base := fmt.Sprintf("/api/foster/updates/%d/photos/%d/", photo.UpdateID, photo.ID)
+
+	photo.URL = base + PhotoVariantOriginal
+	photo.Thumbnails = make(map[string]string, len(PhotoThumbnailSizes))
+	for _, size := range PhotoThumbnailSizes {
+		photo.Thumbnails[size.Name] = base + size.Name
+	}
+}
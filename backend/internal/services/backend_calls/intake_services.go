@@ -0,0 +1,234 @@
+// Package services provides business logic services for intake records.
+// This layer records how each pet arrived at the shelter, creating the pet in the same step,
+// and compares intakes with outcomes month by month.
+package services
+
+import (
+	"backend/internal/db/dao"
+	"backend/internal/db/query"
+	m "backend/internal/models"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// ErrIntakeNotFound is returned when the intake does not exist in the organisation.
+var ErrIntakeNotFound = errors.New("ingreso no encontrado")
+
+// ========================================
+// INTAKE SERVICES
+// ========================================
+
+// NewIntakeListQuery parses and validates the pagination, sorting and filter
+// parameters of an intake list request against dao.IntakeListSchema.
+//
+// Parameters:
+//   - path: Request path used to build next/prev links
+//   - values: Raw query parameters
+//
+// Returns:
+//   - *query.Params: Validated list query
+//   - error: Validation error describing the invalid parameter
+func NewIntakeListQuery(path string, values url.Values) (*query.Params, error) {
+	return query.Parse(path, values, dao.IntakeListSchema)
+}
+
+// ListIntakes retrieves one page of an organisation's intakes.
+//
+// Parameters:
+//   - params: Validated list query (see NewIntakeListQuery)
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - *query.Page[m.Intake]: Intakes with pet summaries
+//   - error: Database error or nil on success
+func ListIntakes(params *query.Params, orgID uint) (*query.Page[m.Intake], error) {
+	intakes, err := dao.GetIntakes(params, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener ingresos: %v", err)
+	}
+
+	if err := fillIntakePets(intakes.Items); err != nil {
+		return nil, err
+	}
+
+	return intakes, nil
+}
+
+// GetIntake retrieves an intake of an organisation.
+//
+// Parameters:
+//   - id: Unique identifier of the intake
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - *m.Intake: Intake with pet summary
+//   - error: ErrIntakeNotFound or database error
+func GetIntake(id uint, orgID uint) (*m.Intake, error) {
+	intake, err := dao.GetIntake(id, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrIntakeNotFound, err)
+	}
+
+	intakes := []m.Intake{*intake}
+	if err := fillIntakePets(intakes); err != nil {
+		return nil, err
+	}
+
+	return &intakes[0], nil
+}
+
+// CreateIntake records the arrival of a new pet and creates the pet in the same step.
+//
+// Business Logic:
+// - The pet and the intake are saved in one transaction; neither is saved if the other fails
+// - The pet goes through the same checks and follow-up work as pets created directly
+// - Saved search alerts and lost and found matching run for the new pet (strays are often reported lost)
+//
+// Parameters:
+//   - intake: Validated intake (must include Source, IntakeDate, Condition and CreatedBy)
+//   - pet: Validated pet data, including its organisation (will be updated with generated ID)
+//
+// Returns:
+//   - *m.Intake: Created intake with pet summary
+//   - error: ErrInvalidMicrochip, ErrMicrochipInUse or database error
+func CreateIntake(intake *m.Intake, pet *m.Pet) (*m.Intake, error) {
+	normalizePetStatus(pet)
+
+	if err := normalizePetMicrochip(pet); err != nil {
+		return nil, err
+	}
+
+	if err := dao.CreatePetWithIntake(pet, intake); err != nil {
+		return nil, err
+	}
+
+	petCreated(pet)
+
+	return GetIntake(intake.ID, intake.OrganizationID)
+}
+
+// UpdateIntake replaces the details of an intake. The pet is edited through the pet endpoints.
+//
+// Parameters:
+//   - update: Intake with the new data (must include ID and OrganizationID)
+//
+// Returns:
+//   - *m.Intake: Updated intake
+//   - error: ErrIntakeNotFound or database error
+func UpdateIntake(update *m.Intake) (*m.Intake, error) {
+	if _, err := dao.GetIntake(update.ID, update.OrganizationID); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrIntakeNotFound, err)
+	}
+
+	if err := dao.UpdateIntake(update); err != nil {
+		return nil, fmt.Errorf("error al actualizar ingreso: %v", err)
+	}
+
+	return GetIntake(update.ID, update.OrganizationID)
+}
+
+// ========================================
+// INTAKE STATISTICS SERVICES
+// ========================================
+
+// GetIntakeStats compares the pets that arrived at and left an organisation month by month.
+//
+// Business Logic:
+// - Intakes are counted in the month of their intake date, by source
+// - Outcomes are the pets adopted in the month (by adoption date)
+// - Every month of the range is listed, including months without movements
+//
+// Parameters:
+//   - orgID: Organisation of the acting staff member
+//   - from: First day of the first month
+//   - to: First day of the last month, included
+//
+// Returns:
+//   - *m.IntakeStats: Monthly intakes, outcomes and totals
+//   - error: Database error or nil on success
+func GetIntakeStats(orgID uint, from time.Time, to time.Time) (*m.IntakeStats, error) {
+	until := to.AddDate(0, 1, 0)
+
+	intakes, err := dao.CountIntakesByMonth(orgID, from, until)
+	if err != nil {
+		return nil, err
+	}
+
+	outcomes, err := dao.CountOutcomesByMonth(orgID, from, until)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &m.IntakeStats{
+		From:   from.Format("2006-01"),
+		To:     to.Format("2006-01"),
+		Months: []m.IntakeMonthStats{},
+		Totals: newIntakeMonthStats(""),
+	}
+
+	for month := from; month.Before(until); month = month.AddDate(0, 1, 0) {
+		key := month.Format("2006-01")
+		row := newIntakeMonthStats(key)
+
+		for source, count := range intakes[key] {
+			row.BySource[source] += count
+			row.Intakes += count
+			stats.Totals.BySource[source] += count
+		}
+		for outcome, count := range outcomes[key] {
+			row.ByOutcome[outcome] += count
+			row.Outcomes += count
+			stats.Totals.ByOutcome[outcome] += count
+		}
+		row.Net = row.Intakes - row.Outcomes
+
+		stats.Totals.Intakes += row.Intakes
+		stats.Totals.Outcomes += row.Outcomes
+		stats.Months = append(stats.Months, row)
+	}
+	stats.Totals.Net = stats.Totals.Intakes - stats.Totals.Outcomes
+
+	return stats, nil
+}
+
+// ========================================
+// INTAKE HELPERS
+// ========================================
+
+// newIntakeMonthStats returns an empty month with every source and outcome type at zero.
+func newIntakeMonthStats(month string) m.IntakeMonthStats {
+	row := m.IntakeMonthStats{
+		Month:     month,
+		BySource:  make(map[string]int, len(m.IntakeSources)),
+		ByOutcome: map[string]int{m.OutcomeAdopted: 0},
+	}
+	for _, source := range m.IntakeSources {
+		row.BySource[source] = 0
+	}
+
+	return row
+}
+
+// fillIntakePets loads the pet summaries of intakes and computes their photo URLs.
+func fillIntakePets(intakes []m.Intake) error {
+	petIDs := make([]uint, 0, len(intakes))
+	for _, intake := range intakes {
+		petIDs = append(petIDs, intake.PetID)
+	}
+
+	pets, err := dao.GetIntakePets(petIDs)
+	if err != nil {
+		return err
+	}
+
+	for i := range intakes {
+		intakes[i].Pet = pets[intakes[i].PetID]
+		if intakes[i].Pet != nil && intakes[i].Pet.PrimaryPhoto != nil {
+			fillPhotoURL(intakes[i].Pet.PrimaryPhoto)
+		}
+	}
+
+	return nil
+}
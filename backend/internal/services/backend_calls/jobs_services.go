@@ -0,0 +1,148 @@
+// Package services provides business logic services for background jobs.
+// This layer registers the application jobs with the scheduler and exposes
+// them to the admin endpoints (listing, run history and manual runs).
+package services
+
+import (
+	"backend/internal/db/dao"
+	m "backend/internal/models"
+	"backend/internal/services/scheduler"
+	"fmt"
+)
+
+// ========================================
+// BACKGROUND JOB SERVICES
+// ========================================
+
+// StartScheduler registers every background job and starts the scheduler.
+//
+// Jobs:
+// - medical-reminders: Daily staff digest of vaccinations and treatments due (MEDICAL_REMINDER_HOUR)
+// - search-alerts: Saved search digests for users (every SEARCH_ALERT_INTERVAL)
+// - visit-reminders: Reminders of upcoming meet-and-greet visits (every VISIT_REMINDER_INTERVAL)
+// - donation-renewals: Checkout links of recurring donations due (DONATION_RENEWAL_HOUR)
+// - donation-receipts: Donation receipts of the previous year (DONATION_RECEIPT_HOUR)
+// - volunteer-reminders: Reminders of upcoming volunteer shifts (every VOLUNTEER_REMINDER_INTERVAL)
+// - message-notifications: Emails about messages unread after MESSAGE_NOTIFY_DELAY (every MESSAGE_NOTIFY_INTERVAL)
+// - webhook-deliveries: Retries of webhook deliveries that are due (poller, every WEBHOOK_DELIVERY_INTERVAL)
+// - mail-outbox: Retries of queued emails that are due (poller, every MAIL_OUTBOX_INTERVAL)
+// - notification-cleanup: Deletion of notifications read more than NOTIFICATION_RETENTION ago (NOTIFICATION_CLEANUP_HOUR)
+func StartScheduler() {
+	scheduler.Register(scheduler.Job{
+		Name:     MedicalRemindersJob,
+		Schedule: scheduler.Daily(medicalReminderHour),
+		Run:      RunMedicalReminders,
+	})
+
+	scheduler.Register(scheduler.Job{
+		Name:     SearchAlertsJob,
+		Schedule: scheduler.Every(searchAlertInterval),
+		Run:      runSearchAlertDigests,
+	})
+
+	scheduler.Register(scheduler.Job{
+		Name:     VisitRemindersJob,
+		Schedule: scheduler.Every(visitReminderInterval),
+		Run:      RunVisitReminders,
+	})
+
+	scheduler.Register(scheduler.Job{
+		Name:     DonationRenewalsJob,
+		Schedule: scheduler.Daily(donationRenewalHour),
+		Run:      RunDonationRenewals,
+	})
+
+	scheduler.Register(scheduler.Job{
+		Name:     DonationReceiptsJob,
+		Schedule: scheduler.Daily(donationReceiptHour),
+		Run:      RunDonationReceipts,
+	})
+
+	scheduler.Register(scheduler.Job{
+		Name:     VolunteerRemindersJob,
+		Schedule: scheduler.Every(volunteerReminderInterval),
+		Run:      RunVolunteerReminders,
+	})
+
+	scheduler.Register(scheduler.Job{
+		Name:     MessageNotificationsJob,
+		Schedule: scheduler.Every(messageNotifyInterval),
+		Run:      RunMessageNotifications,
+	})
+
+	scheduler.Register(scheduler.Job{
+		Name:     WebhookDeliveriesJob,
+		Schedule: scheduler.Every(webhookDeliveryInterval),
+		Run:      RunWebhookDeliveries,
+		Poller:   true,
+	})
+
+	scheduler.Register(scheduler.Job{
+		Name:     MailOutboxJob,
+		Schedule: scheduler.Every(mailOutboxInterval),
+		Run:      RunMailOutbox,
+		Poller:   true,
+	})
+
+	scheduler.Register(scheduler.Job{
+		Name:     NotificationCleanupJob,
+		Schedule: scheduler.Daily(notificationCleanupHour),
+		Run:      RunNotificationCleanup,
+	})
+
+	scheduler.Start()
+}
+
+// ListJobs retrieves every registered job with its latest run.
+//
+// Returns:
+//   - []scheduler.JobStatus: Registered jobs sorted by name
+//   - error: Database error or nil on success
+func ListJobs() ([]scheduler.JobStatus, error) {
+	jobs, err := scheduler.List()
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener tareas: %v", err)
+	}
+
+	return jobs, nil
+}
+
+// ListJobRuns retrieves the latest runs of a job.
+//
+// Parameters:
+//   - name: Job name
+//   - limit: Maximum number of runs
+//
+// Returns:
+//   - []m.JobRun: Latest runs, newest first
+//   - error: scheduler.ErrUnknownJob or database error
+func ListJobRuns(name string, limit int) ([]m.JobRun, error) {
+	if !scheduler.Exists(name) {
+		return nil, scheduler.ErrUnknownJob
+	}
+
+	runs, err := dao.GetJobRuns(name, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener ejecuciones: %v", err)
+	}
+
+	return runs, nil
+}
+
+// TriggerJob runs a job immediately on behalf of an admin.
+//
+// Business Logic:
+// - Dry runs report what the job would do without sending anything
+// - Without force, a run for an already processed period is rejected with scheduler.ErrAlreadyRan
+//
+// Parameters:
+//   - name: Job name
+//   - dryRun: Whether to only preview the work
+//   - force: Whether to run even if the current period was already processed
+//
+// Returns:
+//   - *m.JobRun: Recorded run with its outcome (and preview for dry runs)
+//   - error: scheduler.ErrUnknownJob, scheduler.ErrAlreadyRan or database error
+func TriggerJob(name string, dryRun bool, force bool) (*m.JobRun, error) {
+	return scheduler.Trigger(name, dryRun, force)
+}
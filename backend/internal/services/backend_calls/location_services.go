@@ -0,0 +1,606 @@
+// Package services provides business logic services for shelter locations.
+// This layer manages the buildings, rooms and kennels of an organisation, houses pets
+// in kennels within their capacity and species restrictions, keeps the history of moves
+// and warns when an intake would not fit in the free places.
+package services
+
+import (
+	"backend/internal/db/dao"
+	"backend/internal/db/query"
+	m "backend/internal/models"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrLocationNotFound is returned when the location does not exist in the organisation.
+	ErrLocationNotFound = errors.New("ubicación no encontrada")
+
+	// ErrLocationParent is returned when the parent of a location is missing or of the wrong kind.
+	ErrLocationParent = errors.New("ubicación padre inválida")
+
+	// ErrLocationInUse is returned when deleting a location with child locations or housing history.
+	ErrLocationInUse = errors.New("la ubicación contiene otras ubicaciones o tiene historial de mascotas, desactívala en su lugar")
+
+	// ErrLocationFull is returned when moving a pet to a kennel without free places.
+	ErrLocationFull = errors.New("la jaula no tiene plazas libres")
+
+	// ErrLocationRejected is returned when a pet cannot be housed in a location.
+	// It is wrapped with the reason (not a kennel, inactive, species not accepted, pet adopted or fostered).
+	ErrLocationRejected = errors.New("no se puede alojar la mascota en esta ubicación")
+
+	// ErrLocationPetNotFound is returned when the pet does not exist in the organisation.
+	ErrLocationPetNotFound = errors.New("mascota no encontrada")
+
+	// ErrPetNotHoused is returned when the pet is not housed in any kennel.
+	ErrPetNotHoused = errors.New("la mascota no está alojada en ninguna jaula")
+)
+
+// ========================================
+// LOCATION SERVICES
+// ========================================
+
+// NewLocationListQuery parses and validates the pagination, sorting and filter
+// parameters of a location list request against dao.LocationListSchema.
+//
+// Parameters:
+//   - path: Request path used to build next/prev links
+//   - values: Raw query parameters
+//
+// Returns:
+//   - *query.Params: Validated list query
+//   - error: Validation error describing the invalid parameter
+func NewLocationListQuery(path string, values url.Values) (*query.Params, error) {
+	return query.Parse(path, values, dao.LocationListSchema)
+}
+
+// ListLocations retrieves one page of an organisation's locations with their occupancy.
+//
+// Parameters:
+//   - params: Validated list query (see NewLocationListQuery)
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - *query.Page[m.Location]: Locations with their path, capacity and occupancy
+//   - error: Database error or nil on success
+func ListLocations(params *query.Params, orgID uint) (*query.Page[m.Location], error) {
+	locations, err := dao.GetLocations(params, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener ubicaciones: %v", err)
+	}
+
+	tree, err := loadLocationTree(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range locations.Items {
+		if filled, ok := tree.byID[locations.Items[i].ID]; ok {
+			locations.Items[i] = *filled
+		}
+	}
+
+	return locations, nil
+}
+
+// GetLocation retrieves a location of an organisation with its occupancy.
+// Kennels include the pets currently housed in them.
+//
+// Parameters:
+//   - id: Unique identifier of the location
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - *m.Location: Location with its path, capacity, occupancy and pets
+//   - error: ErrLocationNotFound or database error
+func GetLocation(id uint, orgID uint) (*m.Location, error) {
+	tree, err := loadLocationTree(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	location, ok := tree.byID[id]
+	if !ok {
+		return nil, ErrLocationNotFound
+	}
+
+	if location.IsKennel() {
+		stays, err := dao.GetHousedPets(location.ID)
+		if err != nil {
+			return nil, err
+		}
+		if err := fillStayPets(stays); err != nil {
+			return nil, err
+		}
+		for i := range stays {
+			stays[i].LocationPath = location.Path
+		}
+		location.Pets = stays
+	}
+
+	return location, nil
+}
+
+// CreateLocation adds a building, room or kennel to an organisation.
+//
+// Business Logic:
+// - Buildings have no parent, rooms belong to a building and kennels to a room or a building
+// - Only kennels have their own capacity; buildings and rooms add up their kennels
+//
+// Parameters:
+//   - location: Validated location (must include OrganizationID and Kind)
+//
+// Returns:
+//   - *m.Location: Created location with its path
+//   - error: ErrLocationParent or database error
+func CreateLocation(location *m.Location) (*m.Location, error) {
+	if err := checkLocationParent(location); err != nil {
+		return nil, err
+	}
+
+	if !location.IsKennel() {
+		location.Capacity = 0
+	}
+
+	if err := dao.CreateLocation(location); err != nil {
+		return nil, fmt.Errorf("error al crear ubicación: %v", err)
+	}
+
+	return GetLocation(location.ID, location.OrganizationID)
+}
+
+// UpdateLocation replaces the name, capacity, species, status and notes of a location.
+// The kind and the parent cannot be changed; lowering the capacity of a kennel below its
+// current occupancy is allowed and reported as over capacity.
+//
+// Parameters:
+//   - update: Location with the new data (must include ID and OrganizationID)
+//
+// Returns:
+//   - *m.Location: Updated location with its occupancy
+//   - error: ErrLocationNotFound or database error
+func UpdateLocation(update *m.Location) (*m.Location, error) {
+	location, err := dao.GetLocation(update.ID, update.OrganizationID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrLocationNotFound, err)
+	}
+
+	if !location.IsKennel() {
+		update.Capacity = 0
+	}
+
+	if err := dao.UpdateLocation(update); err != nil {
+		return nil, fmt.Errorf("error al actualizar ubicación: %v", err)
+	}
+
+	return GetLocation(update.ID, update.OrganizationID)
+}
+
+// DeleteLocation removes a location of an organisation.
+//
+// Business Logic:
+// - Locations containing other locations cannot be deleted
+// - Kennels that have housed pets keep the history and cannot be deleted; deactivate them instead
+//
+// Parameters:
+//   - id: Unique identifier of the location
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - error: ErrLocationNotFound, ErrLocationInUse or database error
+func DeleteLocation(id uint, orgID uint) error {
+	if _, err := dao.GetLocation(id, orgID); err != nil {
+		return fmt.Errorf("%w: %v", ErrLocationNotFound, err)
+	}
+
+	children, err := dao.CountChildLocations(id)
+	if err != nil {
+		return err
+	}
+	stays, err := dao.CountLocationStays(id)
+	if err != nil {
+		return err
+	}
+	if children > 0 || stays > 0 {
+		return ErrLocationInUse
+	}
+
+	if err := dao.DeleteLocation(id, orgID); err != nil {
+		return fmt.Errorf("error al eliminar ubicación: %v", err)
+	}
+
+	return nil
+}
+
+// ========================================
+// PET HOUSING SERVICES
+// ========================================
+
+// MovePet houses a pet of the organisation in a kennel, ending its current stay if any.
+//
+// Business Logic:
+// - The location must be an active kennel accepting the pet's species, as must its room and building
+// - Adopted pets and pets living in a foster home cannot be housed
+// - The kennel must have a free place unless force is set; forced moves leave the kennel over capacity
+// - The previous stay ends with reason "traslado" and stays in the pet's history
+//
+// Parameters:
+//   - petID: Unique identifier of the pet
+//   - orgID: Organisation of the acting staff member
+//   - locationID: Kennel receiving the pet
+//   - reason: Reason of the move (optional)
+//   - force: Whether to house the pet even if the kennel is full
+//   - staffID: Staff user moving the pet
+//
+// Returns:
+//   - *m.PetLocation: New stay with the kennel path
+//   - error: ErrLocationPetNotFound, ErrLocationNotFound, ErrLocationRejected (wrapped), ErrLocationFull or database error
+func MovePet(petID uint, orgID uint, locationID uint, reason string, force bool, staffID uint) (*m.PetLocation, error) {
+	pet, err := findOrganizationPet(petID, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrLocationPetNotFound, err)
+	}
+
+	tree, err := loadLocationTree(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	location, ok := tree.byID[locationID]
+	if !ok {
+		return nil, ErrLocationNotFound
+	}
+
+	switch {
+	case !location.IsKennel():
+		return nil, fmt.Errorf("%w: las mascotas solo se alojan en jaulas", ErrLocationRejected)
+	case !tree.isActive(location):
+		return nil, fmt.Errorf("%w: la jaula no está activa", ErrLocationRejected)
+	case !tree.acceptsSpecies(location, pet.Species):
+		return nil, fmt.Errorf("%w: la jaula no acepta la especie %s", ErrLocationRejected, pet.Species)
+	case pet.Status == m.PetStatusAdopted:
+		return nil, fmt.Errorf("%w: la mascota ya ha sido adoptada", ErrLocationRejected)
+	}
+
+	placement, err := dao.GetCurrentPlacementForPet(pet.ID)
+	if err != nil {
+		return nil, err
+	}
+	if placement != nil {
+		return nil, fmt.Errorf("%w: la mascota está en la casa de acogida %d", ErrLocationRejected, placement.FosterHomeID)
+	}
+
+	current, err := dao.GetCurrentPetLocation(pet.ID)
+	if err != nil {
+		return nil, err
+	}
+	if current != nil && current.LocationID == location.ID {
+		return nil, fmt.Errorf("%w: la mascota ya está en %s", ErrLocationRejected, location.Path)
+	}
+
+	stay := &m.PetLocation{
+		OrganizationID: orgID,
+		PetID:          pet.ID,
+		LocationID:     location.ID,
+		StartedAt:      time.Now(),
+		Reason:         reason,
+		MovedBy:        &staffID,
+	}
+
+	moved, err := dao.MovePet(stay, location.Capacity, force)
+	if err != nil {
+		return nil, err
+	}
+	if !moved {
+		return nil, ErrLocationFull
+	}
+
+	stay.LocationPath = location.Path
+
+	return stay, nil
+}
+
+// ReleasePetLocation ends the current stay of a pet, when it leaves the shelter's kennels.
+//
+// Parameters:
+//   - petID: Unique identifier of the pet
+//   - orgID: Organisation of the acting staff member
+//   - reason: Why the pet left (optional, defaults to "salida")
+//
+// Returns:
+//   - error: ErrLocationPetNotFound, ErrPetNotHoused or database error
+func ReleasePetLocation(petID uint, orgID uint, reason string) error {
+	if _, err := findOrganizationPet(petID, orgID); err != nil {
+		return fmt.Errorf("%w: %v", ErrLocationPetNotFound, err)
+	}
+
+	if reason == "" {
+		reason = "salida"
+	}
+
+	ended, err := dao.EndPetLocation(petID, time.Now(), reason)
+	if err != nil {
+		return err
+	}
+	if !ended {
+		return ErrPetNotHoused
+	}
+
+	return nil
+}
+
+// GetPetLocationHistory retrieves every stay of a pet of the organisation.
+//
+// Parameters:
+//   - petID: Unique identifier of the pet
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - []m.PetLocation: Stays with the kennel path, most recent first (the current one has no ended_at)
+//   - error: ErrLocationPetNotFound or database error
+func GetPetLocationHistory(petID uint, orgID uint) ([]m.PetLocation, error) {
+	if _, err := findOrganizationPet(petID, orgID); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrLocationPetNotFound, err)
+	}
+
+	stays, err := dao.GetPetLocationHistory(petID)
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err := loadLocationTree(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range stays {
+		if location, ok := tree.byID[stays[i].LocationID]; ok {
+			stays[i].LocationPath = location.Path
+		}
+	}
+
+	return stays, nil
+}
+
+// EndPetLocationForPet ends the current stay of a pet, if any, as of now.
+// Called when a pet is adopted or placed in a foster home; failures are logged.
+//
+// Parameters:
+//   - petID: Unique identifier of the pet
+//   - reason: Why the pet left, e.g. "adoptada", "acogida"
+func EndPetLocationForPet(petID uint, reason string) {
+	if _, err := dao.EndPetLocation(petID, time.Now(), reason); err != nil {
+		log.Printf("could not end kennel stay of pet %d: %v", petID, err)
+	}
+}
+
+// ========================================
+// OCCUPANCY SERVICES
+// ========================================
+
+// GetOccupancy summarises the free kennel places of an organisation and checks whether an intake fits.
+//
+// Business Logic:
+// - Only active kennels inside active rooms and buildings count as free places
+// - With a species, only kennels accepting it (and whose room and building accept it) count
+// - Warns when the incoming pets exceed the free places, or when kennels are already over capacity
+//
+// Parameters:
+//   - orgID: Organisation of the acting staff member
+//   - species: Species of the intake (empty for any)
+//   - incoming: Number of pets about to arrive (0 to only report occupancy)
+//
+// Returns:
+//   - *m.OccupancyReport: Totals, warning and per-kennel occupancy
+//   - error: Database error or nil on success
+func GetOccupancy(orgID uint, species string, incoming int) (*m.OccupancyReport, error) {
+	tree, err := loadLocationTree(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &m.OccupancyReport{
+		Species:  species,
+		Incoming: incoming,
+		Kennels:  []m.Location{},
+	}
+
+	overCapacity := 0
+	for _, location := range tree.ordered {
+		if !location.IsKennel() {
+			continue
+		}
+
+		report.Occupied += location.Occupied
+		if location.OverCapacity {
+			overCapacity++
+		}
+
+		if tree.isActive(location) {
+			report.Capacity += location.Capacity
+			report.Free += location.FreeCapacity
+			if species == "" || tree.acceptsSpecies(location, species) {
+				report.FreeForSpecies += location.FreeCapacity
+			}
+		}
+
+		report.Kennels = append(report.Kennels, *location)
+	}
+
+	var messages []string
+	if incoming > report.FreeForSpecies {
+		if species != "" {
+			messages = append(messages, fmt.Sprintf("el ingreso de %d mascotas supera las %d plazas libres para la especie %s", incoming, report.FreeForSpecies, species))
+		} else {
+			messages = append(messages, fmt.Sprintf("el ingreso de %d mascotas supera las %d plazas libres", incoming, report.FreeForSpecies))
+		}
+	}
+	if overCapacity > 0 {
+		messages = append(messages, fmt.Sprintf("%d jaulas superan ya su capacidad", overCapacity))
+	}
+
+	report.Warning = len(messages) > 0
+	report.Message = strings.Join(messages, "; ")
+
+	return report, nil
+}
+
+// ========================================
+// LOCATION HELPERS
+// ========================================
+
+// locationTree holds every location of an organisation with its computed path and occupancy.
+type locationTree struct {
+	byID    map[uint]*m.Location
+	ordered []*m.Location
+}
+
+// loadLocationTree loads the locations of an organisation and computes their paths and occupancy.
+// Kennels count their housed pets; buildings and rooms add up the capacity and pets of their kennels.
+func loadLocationTree(orgID uint) (*locationTree, error) {
+	locations, err := dao.GetOrganizationLocations(orgID)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener ubicaciones: %v", err)
+	}
+
+	housed, err := dao.CountHousedPets(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	tree := &locationTree{byID: make(map[uint]*m.Location, len(locations))}
+	for i := range locations {
+		tree.byID[locations[i].ID] = &locations[i]
+		tree.ordered = append(tree.ordered, &locations[i])
+	}
+
+	for _, location := range tree.ordered {
+		location.Path = tree.path(location)
+		if !location.IsKennel() {
+			location.Capacity = 0
+		}
+	}
+
+	// Add the kennels up into their room and building
+	for _, location := range tree.ordered {
+		if !location.IsKennel() {
+			continue
+		}
+
+		location.Occupied = housed[location.ID]
+		location.OverCapacity = location.Occupied > location.Capacity
+		if tree.isActive(location) && !location.OverCapacity {
+			location.FreeCapacity = location.Capacity - location.Occupied
+		}
+
+		for parent := tree.parent(location); parent != nil; parent = tree.parent(parent) {
+			parent.Capacity += location.Capacity
+			parent.Occupied += location.Occupied
+			parent.FreeCapacity += location.FreeCapacity
+		}
+	}
+
+	for _, location := range tree.ordered {
+		if !location.IsKennel() {
+			location.OverCapacity = location.Occupied > location.Capacity
+		}
+	}
+
+	return tree, nil
+}
+
+// parent returns the parent of a location, or nil for buildings.
+func (t *locationTree) parent(location *m.Location) *m.Location {
+	if location.ParentID == nil {
+		return nil
+	}
+
+	return t.byID[*location.ParentID]
+}
+
+// path builds the full name of a location, e.g. "Nave A / Perros / Jaula 12".
+func (t *locationTree) path(location *m.Location) string {
+	names := []string{location.Name}
+	for parent := t.parent(location); parent != nil; parent = t.parent(parent) {
+		names = append([]string{parent.Name}, names...)
+	}
+
+	return strings.Join(names, " / ")
+}
+
+// isActive reports whether a location and every location containing it are active.
+func (t *locationTree) isActive(location *m.Location) bool {
+	for current := location; current != nil; current = t.parent(current) {
+		if !current.Active {
+			return false
+		}
+	}
+
+	return true
+}
+
+// acceptsSpecies reports whether a location and every location containing it accept a species.
+func (t *locationTree) acceptsSpecies(location *m.Location, species string) bool {
+	for current := location; current != nil; current = t.parent(current) {
+		if !current.AcceptsSpecies(species) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// checkLocationParent validates the parent of a new location against its kind.
+// Buildings have no parent, rooms belong to a building and kennels to a room or a building.
+func checkLocationParent(location *m.Location) error {
+	if location.Kind == m.LocationKindBuilding {
+		if location.ParentID != nil {
+			return fmt.Errorf("%w: los edificios no pueden estar dentro de otra ubicación", ErrLocationParent)
+		}
+		return nil
+	}
+
+	if location.ParentID == nil {
+		return fmt.Errorf("%w: parent_id es obligatorio para salas y jaulas", ErrLocationParent)
+	}
+
+	parent, err := dao.GetLocation(*location.ParentID, location.OrganizationID)
+	if err != nil {
+		return fmt.Errorf("%w: ubicación %d no encontrada", ErrLocationParent, *location.ParentID)
+	}
+
+	switch {
+	case location.Kind == m.LocationKindRoom && parent.Kind != m.LocationKindBuilding:
+		return fmt.Errorf("%w: las salas deben estar dentro de un edificio", ErrLocationParent)
+	case location.Kind == m.LocationKindKennel && parent.IsKennel():
+		return fmt.Errorf("%w: las jaulas deben estar dentro de una sala o un edificio", ErrLocationParent)
+	}
+
+	return nil
+}
+
+// fillStayPets loads the pet summaries of kennel stays and computes their photo URLs.
+func fillStayPets(stays []m.PetLocation) error {
+	petIDs := make([]uint, 0, len(stays))
+	for _, stay := range stays {
+		petIDs = append(petIDs, stay.PetID)
+	}
+
+	pets, err := dao.GetLocationPets(petIDs)
+	if err != nil {
+		return err
+	}
+
+	for i := range stays {
+		stays[i].Pet = pets[stays[i].PetID]
+		if stays[i].Pet != nil && stays[i].Pet.PrimaryPhoto != nil {
+			fillPhotoURL(stays[i].Pet.PrimaryPhoto)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,534 @@
+// Package services provides business logic services for lost and found pet reports.
+// This layer handles public submission and staff moderation of reports, and
+// matches approved reports against shelter pets, emailing reporters about candidates.
+package services
+
+import (
+	"backend/internal/db/dao"
+	"backend/internal/db/query"
+	m "backend/internal/models"
+	mailer "backend/internal/services/mail"
+	"backend/internal/services/search"
+	"backend/internal/services/security"
+	"backend/internal/utils/env"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// MaxLostFoundPhotos is the maximum number of photos submitted with a report.
+const MaxLostFoundPhotos = 5
+
+// ErrLostFoundNotFound is returned for reports that do not exist or are not visible to the caller.
+var ErrLostFoundNotFound = errors.New("aviso no encontrado")
+
+// Match scoring: a candidate needs at least lostFoundMinScore points.
+// A microchip match is conclusive; otherwise the species must match plus breed or colour.
+const (
+	lostFoundScoreMicrochip = 100
+	lostFoundScoreSpecies   = 20
+	lostFoundScoreBreed     = 40
+	lostFoundScoreColor     = 30
+	lostFoundMinScore       = 50
+)
+
+// Matching criteria stored in LostFoundMatch.Reasons.
+const (
+	lostFoundReasonMicrochip = "microchip"
+	lostFoundReasonSpecies   = "species"
+	lostFoundReasonBreed     = "breed"
+	lostFoundReasonColor     = "color"
+)
+
+// shelterEmail is the contact address given to reporters in match emails.
+// Configurable through the SHELTER_EMAIL environment variable.
+var shelterEmail = env.Get("SHELTER_EMAIL", "zanckor002@gmail.com")
+
+// ========================================
+// LOST AND FOUND SERVICES
+// ========================================
+
+// NewLostFoundListQuery parses and validates the pagination, sorting and filter
+// parameters of a lost and found list request against dao.LostFoundListSchema.
+//
+// Parameters:
+//   - path: Request path used to build next/prev links
+//   - values: Raw query parameters
+//
+// Returns:
+//   - *query.Params: Validated list query
+//   - error: Validation error describing the invalid parameter
+func NewLostFoundListQuery(path string, values url.Values) (*query.Params, error) {
+	return query.Parse(path, values, dao.LostFoundListSchema)
+}
+
+// ListLostFoundReports retrieves one page of lost and found reports.
+//
+// Business Logic:
+// - Staff see every report, including contact details and moderation data
+// - Everyone else only sees approved reports, without the reporter's contact details
+//
+// Parameters:
+//   - params: Validated list query (see NewLostFoundListQuery)
+//   - viewer: Current user, or nil for anonymous requests
+//
+// Returns:
+//   - *query.Page[m.LostFoundReport]: Requested page of reports with total count and links
+//   - error: Database error or nil on success
+func ListLostFoundReports(params *query.Params, viewer *m.NonValidatedUser) (*query.Page[m.LostFoundReport], error) {
+	staff := viewer != nil && viewer.IsStaff()
+
+	reports, err := dao.GetLostFoundReports(params, !staff)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener avisos: %v", err)
+	}
+
+	for i := range reports.Items {
+		prepareLostFoundReport(&reports.Items[i], staff)
+	}
+
+	return reports, nil
+}
+
+// GetLostFoundReport retrieves a lost or found report visible to the viewer.
+//
+// Parameters:
+//   - id: Unique identifier of the report
+//   - viewer: Current user, or nil for anonymous requests
+//
+// Returns:
+//   - *m.LostFoundReport: Report data with photo URLs
+//   - error: ErrLostFoundNotFound if the report does not exist or is not approved (non-staff)
+func GetLostFoundReport(id uint, viewer *m.NonValidatedUser) (*m.LostFoundReport, error) {
+	staff := viewer != nil && viewer.IsStaff()
+
+	report, err := dao.GetLostFoundReport(id)
+	if err != nil || (!staff && report.Status != m.LostFoundStatusApproved) {
+		return nil, ErrLostFoundNotFound
+	}
+
+	prepareLostFoundReport(report, staff)
+
+	return report, nil
+}
+
+// CreateLostFoundReport stores a new report submitted by the public, with its photos.
+//
+// Business Logic:
+// - The report starts as pending and is not public until approved by staff
+// - Photos are processed like pet photos (EXIF stripped, thumbnails generated)
+// - If a photo cannot be stored, the report and every stored photo are removed
+//
+// Parameters:
+//   - report: Validated report to create (will be updated with ID, status and photos)
+//   - photos: Raw image bytes of the photos (content type already validated)
+//
+// Returns:
+//   - error: Processing error (wrapping imaging.ErrInvalidImage), storage or database error
+func CreateLostFoundReport(report *m.LostFoundReport, photos [][]byte) error {
+	report.Status = m.LostFoundStatusPending
+	report.ModeratedBy = nil
+	report.ModeratedAt = nil
+	report.ModerationNote = ""
+	report.Photos = nil
+
+	if err := dao.CreateLostFoundReport(report); err != nil {
+		return fmt.Errorf("error al crear aviso: %v", err)
+	}
+
+	// Removes the report and the photos stored so far
+	rollback := func() {
+		for _, photo := range report.Photos {
+			deleteStoredImage(photo.StorageKey, photo.Extension)
+		}
+		if err := dao.DeleteLostFoundReport(report.ID); err != nil {
+			log.Printf("could not remove incomplete lost and found report %d: %v", report.ID, err)
+		}
+	}
+
+	for _, data := range photos {
+		storageKey := fmt.Sprintf("lost-found/%d/%s", report.ID, strings.ToLower(security.Generate2FA(20)))
+		original, err := storeImage(storageKey, data)
+		if err != nil {
+			rollback()
+			return err
+		}
+
+		photo := m.LostFoundPhoto{
+			ReportID:    report.ID,
+			StorageKey:  storageKey,
+			ContentType: original.ContentType,
+			Extension:   original.Extension,
+			Size:        int64(len(original.Data)),
+			Width:       original.Width,
+			Height:      original.Height,
+		}
+		if err := dao.CreateLostFoundPhoto(&photo); err != nil {
+			deleteStoredImage(storageKey, original.Extension)
+			rollback()
+			return fmt.Errorf("error al registrar foto: %v", err)
+		}
+
+		report.Photos = append(report.Photos, photo)
+	}
+
+	prepareLostFoundReport(report, true)
+
+	return nil
+}
+
+// ModerateLostFoundReport changes the moderation status of a report.
+//
+// Business Logic:
+// - Records the moderator, time and internal note
+// - Approving a report publishes it and starts matching it against shelter pets
+//
+// Parameters:
+//   - id: Unique identifier of the report
+//   - status: New status (pending, approved, rejected or resolved)
+//   - note: Internal moderation note
+//   - moderatorID: Staff user moderating the report
+//
+// Returns:
+//   - *m.LostFoundReport: Moderated report
+//   - error: ErrLostFoundNotFound or database error
+func ModerateLostFoundReport(id uint, status string, note string, moderatorID uint) (*m.LostFoundReport, error) {
+	report, err := dao.GetLostFoundReport(id)
+	if err != nil {
+		return nil, ErrLostFoundNotFound
+	}
+
+	previous := report.Status
+	now := time.Now()
+
+	report.Status = status
+	report.ModerationNote = note
+	report.ModeratedBy = &moderatorID
+	report.ModeratedAt = &now
+
+	if err := dao.UpdateLostFoundModeration(report); err != nil {
+		return nil, fmt.Errorf("error al moderar aviso: %v", err)
+	}
+
+	if status == m.LostFoundStatusApproved && previous != m.LostFoundStatusApproved {
+		MatchLostFoundReport(report.ID)
+	}
+
+	prepareLostFoundReport(report, true)
+
+	return report, nil
+}
+
+// DeleteLostFoundReport removes a report with its photos and matches.
+// Storage failures are logged but do not fail the operation.
+//
+// Parameters:
+//   - id: Unique identifier of the report
+//
+// Returns:
+//   - error: ErrLostFoundNotFound or database error
+func DeleteLostFoundReport(id uint) error {
+	report, err := dao.GetLostFoundReport(id)
+	if err != nil {
+		return ErrLostFoundNotFound
+	}
+
+	if err := dao.DeleteLostFoundReport(id); err != nil {
+		return fmt.Errorf("error al eliminar aviso: %v", err)
+	}
+
+	for _, photo := range report.Photos {
+		deleteStoredImage(photo.StorageKey, photo.Extension)
+	}
+
+	return nil
+}
+
+// ListLostFoundMatches retrieves the candidate pets of a report, strongest first.
+//
+// Parameters:
+//   - id: Unique identifier of the report
+//
+// Returns:
+//   - []m.LostFoundMatch: Matches with candidate pet summaries
+//   - error: ErrLostFoundNotFound or database error
+func ListLostFoundMatches(id uint) ([]m.LostFoundMatch, error) {
+	if _, err := dao.GetLostFoundReport(id); err != nil {
+		return nil, ErrLostFoundNotFound
+	}
+
+	matches, err := dao.GetLostFoundMatches(id)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener coincidencias: %v", err)
+	}
+
+	for i := range matches {
+		if matches[i].Candidate.PrimaryPhoto != nil {
+			fillPhotoURL(matches[i].Candidate.PrimaryPhoto)
+		}
+	}
+
+	return matches, nil
+}
+
+// OpenLostFoundPhoto opens a stored report photo variant for download.
+//
+// Parameters:
+//   - reportID: Unique identifier of the report
+//   - photoID: Unique identifier of the photo
+//   - variant: "original" or one of the names in PhotoThumbnailSizes
+//   - viewer: Current user, or nil for anonymous requests (only approved reports are public)
+//
+// Returns:
+//   - io.ReadCloser: Image content (caller must close it)
+//   - string: MIME type of the content
+//   - error: Unknown variant, ErrLostFoundNotFound or storage error
+func OpenLostFoundPhoto(reportID uint, photoID uint, variant string, viewer *m.NonValidatedUser) (io.ReadCloser, string, error) {
+	if !isPhotoVariant(variant) {
+		return nil, "", fmt.Errorf("variante de foto desconocida: %s", variant)
+	}
+
+	if _, err := GetLostFoundReport(reportID, viewer); err != nil {
+		return nil, "", err
+	}
+
+	photo, err := dao.GetLostFoundPhoto(reportID, photoID)
+	if err != nil {
+		return nil, "", ErrLostFoundNotFound
+	}
+
+	return openStoredImage(photo.StorageKey, photo.Extension, photo.ContentType, variant)
+}
+
+// ========================================
+// LOST AND FOUND MATCHING
+// ========================================
+
+// MatchLostFoundReport matches an approved report against shelter pets in the background
+// and emails the reporter about new candidates.
+//
+// Business Logic:
+// - Lost reports are matched against pets still in the shelter (not adopted)
+// - Found reports are matched against every pet, since the animal may be an adopted pet that escaped
+// - Pets already matched to the report are not notified again
+//
+// Parameters:
+//   - reportID: Unique identifier of the approved report
+func MatchLostFoundReport(reportID uint) {
+	go func() {
+		report, err := dao.GetLostFoundReport(reportID)
+		if err != nil {
+			log.Printf("could not load lost and found report %d: %v", reportID, err)
+			return
+		}
+
+		if report.Status != m.LostFoundStatusApproved {
+			return
+		}
+
+		pets, err := dao.GetMatchCandidatePets(report.Species, report.Microchip, report.Type == m.LostFoundTypeLost)
+		if err != nil {
+			log.Printf("could not load candidate pets for report %d: %v", reportID, err)
+			return
+		}
+
+		var matched []m.Pet
+		var matches []m.LostFoundMatch
+		for _, pet := range pets {
+			if match, ok := recordLostFoundMatch(report, &pet); ok {
+				matched = append(matched, pet)
+				matches = append(matches, *match)
+			}
+		}
+
+		notifyLostFoundMatches(report, matched, matches)
+	}()
+}
+
+// MatchPetToLostFoundReports matches a new or updated pet against approved reports in the
+// background and emails the reporters of the reports it is a new candidate for.
+//
+// Parameters:
+//   - petID: Unique identifier of the pet
+func MatchPetToLostFoundReports(petID uint) {
+	go func() {
+		pet, err := dao.GetPetByID(petID, dao.AllOrganizations)
+		if err != nil {
+			log.Printf("could not load pet %d to match lost and found reports: %v", petID, err)
+			return
+		}
+
+		reports, err := dao.GetApprovedReportsForPet(pet.Species, pet.Microchip)
+		if err != nil {
+			log.Printf("could not load lost and found reports for pet %d: %v", petID, err)
+			return
+		}
+
+		for _, report := range reports {
+			if report.Type == m.LostFoundTypeLost && pet.Status == m.PetStatusAdopted {
+				continue
+			}
+
+			if match, ok := recordLostFoundMatch(&report, pet); ok {
+				notifyLostFoundMatches(&report, []m.Pet{*pet}, []m.LostFoundMatch{*match})
+			}
+		}
+	}()
+}
+
+// ========================================
+// LOST AND FOUND HELPERS
+// ========================================
+
+// recordLostFoundMatch scores a pet against a report and stores the match when it is strong enough.
+// It returns the match only when it is new.
+func recordLostFoundMatch(report *m.LostFoundReport, pet *m.Pet) (*m.LostFoundMatch, bool) {
+	score, reasons := scoreLostFoundMatch(report, pet)
+	if score < lostFoundMinScore {
+		return nil, false
+	}
+
+	match := &m.LostFoundMatch{
+		ReportID: report.ID,
+		PetID:    pet.ID,
+		Score:    score,
+		Reasons:  strings.Join(reasons, ","),
+	}
+
+	created, err := dao.CreateLostFoundMatch(match)
+	if err != nil {
+		log.Printf("could not store match of report %d with pet %d: %v", report.ID, pet.ID, err)
+		return nil, false
+	}
+
+	return match, created
+}
+
+// scoreLostFoundMatch rates how likely a pet is the animal of a report (0-100)
+// and returns the criteria that matched.
+func scoreLostFoundMatch(report *m.LostFoundReport, pet *m.Pet) (int, []string) {
+	score := 0
+	var reasons []string
+
+	if report.Microchip != nil && pet.Microchip != nil && *report.Microchip == *pet.Microchip {
+		score += lostFoundScoreMicrochip
+		reasons = append(reasons, lostFoundReasonMicrochip)
+	}
+
+	if search.Normalize(report.Species) == search.Normalize(pet.Species) {
+		score += lostFoundScoreSpecies
+		reasons = append(reasons, lostFoundReasonSpecies)
+
+		if sharesWord(report.Breed, pet.Breed) {
+			score += lostFoundScoreBreed
+			reasons = append(reasons, lostFoundReasonBreed)
+		}
+
+		if sharesWord(report.Color, pet.Color) {
+			score += lostFoundScoreColor
+			reasons = append(reasons, lostFoundReasonColor)
+		}
+	}
+
+	return min(score, 100), reasons
+}
+
+// sharesWord reports whether two texts have a normalised word in common, ignoring stopwords
+// (e.g. "blanco y negro" and "negro" share "negro").
+func sharesWord(a string, b string) bool {
+	words := map[string]bool{}
+	for _, word := range search.Tokenize(a) {
+		if !search.IsStopword(word) {
+			words[word] = true
+		}
+	}
+
+	for _, word := range search.Tokenize(b) {
+		if words[word] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// notifyLostFoundMatches emails the reporter of a report about new candidate pets
+// and marks the matches as notified.
+func notifyLostFoundMatches(report *m.LostFoundReport, pets []m.Pet, matches []m.LostFoundMatch) {
+	if len(matches) == 0 {
+		return
+	}
+
+	data := mailer.LostFoundMatchData{
+		ReporterName:  report.ReporterName,
+		ReportType:    report.Type,
+		ReportSpecies: report.Species,
+		SeenDate:      report.SeenDate.Format("02/01/2006"),
+		ShelterEmail:  shelterEmail,
+	}
+
+	ids := make([]uint, len(matches))
+	for i, match := range matches {
+		ids[i] = match.ID
+
+		data.Pets = append(data.Pets, mailer.LostFoundMatchPet{
+			Name:    pets[i].Name,
+			Species: pets[i].Species,
+			Breed:   pets[i].Breed,
+			Reasons: strings.Split(match.Reasons, ","),
+			URL:     fmt.Sprintf("%s/pets/%d", frontendURL, pets[i].ID),
+		})
+	}
+
+	email, err := mailer.ComposeLostFoundMatch(report.ReporterEmail, userLocale(report.ReporterUserID), data)
+	if err == nil {
+		err = notify(notification{
+			UserID: report.ReporterUserID,
+			Type:   m.NotificationLostFound,
+			URL:    data.Pets[0].URL,
+			Email:  email,
+		})
+	}
+	if err != nil {
+		log.Printf("could not notify reporter of lost and found report %d: %v", report.ID, err)
+		return
+	}
+
+	if err := dao.MarkLostFoundMatchesNotified(ids, time.Now()); err != nil {
+		log.Printf("could not mark matches of report %d as notified: %v", report.ID, err)
+	}
+}
+
+// prepareLostFoundReport fills photo URLs and, unless the viewer is staff,
+// removes the reporter's contact details and moderation data.
+func prepareLostFoundReport(report *m.LostFoundReport, staff bool) {
+	for i := range report.Photos {
+		fillLostFoundPhotoURL(&report.Photos[i])
+	}
+
+	if staff {
+		return
+	}
+
+	report.ReporterEmail = ""
+	report.ReporterPhone = ""
+	report.ReporterUserID = nil
+	report.ModeratedBy = nil
+	report.ModeratedAt = nil
+	report.ModerationNote = ""
+}
+
+// fillLostFoundPhotoURL computes the download URLs of a report photo and its thumbnails.
+func fillLostFoundPhotoURL(photo *m.LostFoundPhoto) {
+	base := fmt.Sprintf("/api/lost-found/reports/%d/photos/%d/", photo.ReportID, photo.ID)
+
+	photo.URL = base + PhotoVariantOriginal
+	photo.Thumbnails = make(map[string]string, len(PhotoThumbnailSizes))
+	for _, size := range PhotoThumbnailSizes {
+		photo.Thumbnails[size.Name] = base + size.Name
+	}
+}
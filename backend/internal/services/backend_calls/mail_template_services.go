@@ -0,0 +1,65 @@
+// Package services provides business logic services for email templates.
+// This layer lets admins list the email templates and preview them with
+// sample data in each supported locale.
+package services
+
+import (
+	m "backend/internal/models"
+	mailer "backend/internal/services/mail"
+	"errors"
+)
+
+var (
+	// ErrMailTemplateNotFound is returned when previewing a template that does not exist.
+	ErrMailTemplateNotFound = mailer.ErrUnknownTemplate
+
+	// ErrUnsupportedLocale is returned when previewing a template in a locale that is not supported.
+	ErrUnsupportedLocale = errors.New("idioma no soportado")
+)
+
+// MailTemplate describes an email template that can be previewed.
+type MailTemplate struct {
+	Name    string   `json:"name"`    // Template name
+	Locales []string `json:"locales"` // Locales the template is available in
+}
+
+// ========================================
+// MAIL TEMPLATE SERVICES
+// ========================================
+
+// ListMailTemplates retrieves every email template with its locales.
+//
+// Returns:
+//   - []MailTemplate: Templates sorted by name
+func ListMailTemplates() []MailTemplate {
+	templates := make([]MailTemplate, len(mailer.Templates))
+	for i, name := range mailer.Templates {
+		templates[i] = MailTemplate{Name: name, Locales: m.Locales}
+	}
+
+	return templates
+}
+
+// PreviewMailTemplate renders an email template with sample data.
+//
+// Business Logic:
+// - An empty locale renders the template in m.DefaultLocale
+// - The sample data is fixed; no email is queued or sent
+//
+// Parameters:
+//   - name: Template name
+//   - locale: Locale to render (es, en, ca)
+//
+// Returns:
+//   - *mailer.Rendered: Subject, in-app summary, plain text and HTML of the email
+//   - error: ErrMailTemplateNotFound, ErrUnsupportedLocale or template error
+func PreviewMailTemplate(name string, locale string) (*mailer.Rendered, error) {
+	if locale == "" {
+		locale = m.DefaultLocale
+	}
+	if !IsValidLocale(locale) {
+		return nil, ErrUnsupportedLocale
+	}
+
+	return mailer.Preview(name, locale)
+}
@@ -0,0 +1,249 @@
+// Package services provides business logic services for medical due-date reminders.
+// This layer finds vaccinations and treatments due soon and emails a daily digest to staff.
+package services
+
+import (
+	"backend/internal/db/dao"
+	m "backend/internal/models"
+	mailer "backend/internal/services/mail"
+	"backend/internal/services/scheduler"
+	"backend/internal/utils/env"
+	"fmt"
+	"log"
+	"time"
+)
+
+// MedicalRemindersJob is the scheduler job name of the staff medical reminder digest.
+const MedicalRemindersJob = "medical-reminders"
+
+var (
+	// medicalReminderWindow is how many days ahead due dates are included (MEDICAL_REMINDER_WINDOW_DAYS, default 7).
+	medicalReminderWindow = int(env.GetInt("MEDICAL_REMINDER_WINDOW_DAYS", 7))
+
+	// medicalReminderHour is the local hour the daily digest is sent from (MEDICAL_REMINDER_HOUR, default 8).
+	medicalReminderHour = int(env.GetInt("MEDICAL_REMINDER_HOUR", 8))
+)
+
+// MedicalReminderPreview is the work a medical reminder run does, returned by dry runs.
+type MedicalReminderPreview struct {
+	WindowDays int                     `json:"window_days"` // Days ahead included in the digests
+	Digests    []MedicalReminderDigest `json:"digests"`     // One digest per organisation with something due
+}
+
+// MedicalReminderDigest is the reminder digest of one organisation.
+type MedicalReminderDigest struct {
+	OrganizationID uint                `json:"organization_id"` // Organisation that owns the pets
+	Overdue        []m.MedicalReminder `json:"overdue"`         // Vaccinations whose due date has passed
+	Upcoming       []m.MedicalReminder `json:"upcoming"`        // Vaccinations due within the window
+	Treatments     []m.MedicalReminder `json:"treatments"`      // Treatments ending within the window
+	Recipients     []string            `json:"recipients"`      // Staff emails the digest is sent to
+
+	staff []m.SimplifiedUser // Staff members the digest is sent to
+}
+
+// ========================================
+// MEDICAL REMINDER SERVICES
+// ========================================
+
+// RunMedicalReminders emails each organisation's staff the digest of vaccinations and treatments due soon.
+//
+// Process:
+// 1. Finds vaccinations due up to MEDICAL_REMINDER_WINDOW_DAYS ahead, including overdue ones
+// 2. Finds treatments ending within the same window
+// 3. Groups them by the organisation that owns the pet
+// 4. Sends each organisation's digest to its active staff members, with its sender identity
+//
+// Business Logic:
+// - Doses superseded by a later dose of the same vaccine are ignored
+// - Adopted pets are ignored
+// - Organisations with nothing due get no digest
+// - Each staff member gets the digest of a day once: deliveries are recorded before sending
+// - The run fails (and is retried) when some digest could not be sent; the retry only sends those
+//
+// Parameters:
+//   - now: Reference time of the run
+//   - dryRun: Only compute the digests, without sending them
+//
+// Returns:
+//   - scheduler.Result: Number of digests sent (or that would be sent) and the preview
+//   - error: Database error, or mail error when some digest failed
+func RunMedicalReminders(now time.Time, dryRun bool) (scheduler.Result, error) {
+	preview, err := buildMedicalReminders(now)
+	if err != nil {
+		return scheduler.Result{}, err
+	}
+
+	due, recipients := 0, 0
+	for _, digest := range preview.Digests {
+		due += len(digest.Overdue) + len(digest.Upcoming) + len(digest.Treatments)
+		recipients += len(digest.staff)
+	}
+
+	if due == 0 {
+		return scheduler.Result{Summary: "no hay vacunas ni tratamientos pendientes", Preview: preview}, nil
+	}
+
+	if dryRun {
+		return scheduler.Result{
+			Items:   recipients,
+			Summary: fmt.Sprintf("%d pendientes, se enviarían %d resúmenes", due, recipients),
+			Preview: preview,
+		}, nil
+	}
+
+	today := dateOnly(now)
+	if err := dao.DeleteMedicalReminderDeliveriesBefore(today); err != nil {
+		log.Printf("could not prune medical reminder deliveries: %v", err)
+	}
+
+	sent, already, failed := 0, 0, 0
+	var lastErr error
+	for _, digest := range preview.Digests {
+		sender := organizationSender(digest.OrganizationID)
+
+		for _, user := range digest.staff {
+			delivery := &m.MedicalReminderDelivery{DigestDate: today, OrganizationID: digest.OrganizationID, UserID: user.ID}
+			claimed, err := dao.ClaimMedicalReminderDelivery(delivery)
+			if err != nil {
+				log.Printf("could not claim medical reminders of organization %d for user %d: %v", digest.OrganizationID, user.ID, err)
+				lastErr = err
+				failed++
+				continue
+			}
+			if !claimed {
+				already++
+				continue
+			}
+
+			data := mailer.MedicalReminderData{
+				UserName:   user.Name,
+				WindowDays: preview.WindowDays,
+				Overdue:    medicalReminderItems(digest.Overdue, false),
+				Upcoming:   medicalReminderItems(digest.Upcoming, false),
+				Treatments: medicalReminderItems(digest.Treatments, true),
+			}
+
+			email, err := mailer.ComposeMedicalReminderDigest(user.Email, user.Locale, data, sender)
+			if err == nil {
+				err = notify(notification{UserID: &user.ID, Type: m.NotificationReminders, Email: email})
+			}
+			if err != nil {
+				log.Printf("could not send medical reminders of organization %d to user %d: %v", digest.OrganizationID, user.ID, err)
+				if err := dao.ReleaseMedicalReminderDelivery(delivery); err != nil {
+					log.Printf("could not release medical reminders of organization %d for user %d: %v", digest.OrganizationID, user.ID, err)
+				}
+				lastErr = err
+				failed++
+				continue
+			}
+			sent++
+		}
+	}
+
+	result := scheduler.Result{
+		Items:   sent,
+		Summary: fmt.Sprintf("%d pendientes, %d de %d resúmenes enviados (%d ya enviados antes)", due, sent, recipients, already),
+	}
+	if failed > 0 {
+		return result, fmt.Errorf("no se han podido enviar %d recordatorios: %v", failed, lastErr)
+	}
+
+	return result, nil
+}
+
+// ========================================
+// MEDICAL REMINDER HELPERS
+// ========================================
+
+// buildMedicalReminders loads the due vaccinations and treatments grouped by organisation,
+// with the recipients of each digest.
+func buildMedicalReminders(now time.Time) (*MedicalReminderPreview, error) {
+	today := dateOnly(now)
+	until := today.AddDate(0, 0, medicalReminderWindow)
+
+	vaccinations, err := dao.GetDueVaccinations(until)
+	if err != nil {
+		return nil, err
+	}
+
+	treatments, err := dao.GetEndingTreatments(today, until)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &MedicalReminderPreview{
+		WindowDays: medicalReminderWindow,
+		Digests:    []MedicalReminderDigest{},
+	}
+
+	// Reminders come ordered by organisation; digests keep that order
+	index := map[uint]int{}
+	digestFor := func(orgID uint) *MedicalReminderDigest {
+		i, ok := index[orgID]
+		if !ok {
+			i = len(preview.Digests)
+			index[orgID] = i
+			preview.Digests = append(preview.Digests, MedicalReminderDigest{
+				OrganizationID: orgID,
+				Overdue:        []m.MedicalReminder{},
+				Upcoming:       []m.MedicalReminder{},
+				Treatments:     []m.MedicalReminder{},
+				Recipients:     []string{},
+			})
+		}
+		return &preview.Digests[i]
+	}
+
+	for _, vaccination := range vaccinations {
+		digest := digestFor(vaccination.OrganizationID)
+		if vaccination.DueDate.Before(today) {
+			digest.Overdue = append(digest.Overdue, vaccination)
+		} else {
+			digest.Upcoming = append(digest.Upcoming, vaccination)
+		}
+	}
+
+	for _, treatment := range treatments {
+		digest := digestFor(treatment.OrganizationID)
+		digest.Treatments = append(digest.Treatments, treatment)
+	}
+
+	for i := range preview.Digests {
+		digest := &preview.Digests[i]
+
+		staff, err := dao.GetStaffUsers(digest.OrganizationID)
+		if err != nil {
+			return nil, err
+		}
+
+		digest.staff = staff
+		for _, user := range staff {
+			digest.Recipients = append(digest.Recipients, user.Email)
+		}
+	}
+
+	return preview, nil
+}
+
+// medicalReminderItems converts reminders to email items, using the end date for treatments.
+func medicalReminderItems(reminders []m.MedicalReminder, treatments bool) []mailer.MedicalReminderItem {
+	items := make([]mailer.MedicalReminderItem, 0, len(reminders))
+	for _, reminder := range reminders {
+		date := reminder.DueDate
+		if treatments {
+			date = reminder.EndDate
+		}
+
+		item := mailer.MedicalReminderItem{
+			PetName: reminder.PetName,
+			Name:    reminder.Name,
+			URL:     fmt.Sprintf("%s/pets/%d", frontendURL, reminder.PetID),
+		}
+		if date != nil {
+			item.Date = date.Format("02/01/2006")
+		}
+		items = append(items, item)
+	}
+
+	return items
+}
@@ -0,0 +1,253 @@
+// Package services provides business logic services for pet medical records.
+// This layer sits between handlers and DAOs, managing the staff-only medical
+// history of each pet and building the adopter-facing medical summary.
+package services
+
+import (
+	"backend/internal/db/dao"
+	m "backend/internal/models"
+	"fmt"
+	"time"
+)
+
+// ========================================
+// MEDICAL RECORD SERVICES
+// ========================================
+
+// ListMedicalRecords retrieves the medical history of a pet.
+//
+// Parameters:
+//   - petID: Pet whose records are retrieved
+//   - orgID: Organisation the pet must belong to, or dao.AllOrganizations
+//   - recordType: Record type to filter by, or "" for every type
+//
+// Returns:
+//   - []m.MedicalRecord: Medical records, most recent first
+//   - error: Pet not found or database error
+func ListMedicalRecords(petID uint, orgID uint, recordType string) ([]m.MedicalRecord, error) {
+	if _, err := findOrganizationPet(petID, orgID); err != nil {
+		return nil, err
+	}
+
+	records, err := dao.GetMedicalRecords(petID, recordType)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener historial médico: %v", err)
+	}
+
+	return records, nil
+}
+
+// GetMedicalRecord retrieves a medical record of a pet.
+//
+// Parameters:
+//   - petID: Pet the record belongs to
+//   - orgID: Organisation the pet must belong to
+//   - id: Unique identifier of the record
+//
+// Returns:
+//   - *m.MedicalRecord: Medical record data
+//   - error: Pet or record not found, or database error
+func GetMedicalRecord(petID uint, orgID uint, id uint) (*m.MedicalRecord, error) {
+	if _, err := findOrganizationPet(petID, orgID); err != nil {
+		return nil, err
+	}
+
+	record, err := dao.GetMedicalRecord(petID, id)
+	if err != nil {
+		return nil, fmt.Errorf("registro médico no encontrado: %v", err)
+	}
+
+	return record, nil
+}
+
+// CreateMedicalRecord adds a record to the medical history of a pet.
+//
+// Business Logic:
+// - Validates pet existence within the organisation
+// - Clears the fields that do not apply to the record type
+//
+// Parameters:
+//   - record: Medical record to create (PetID, Type and CreatedBy must be set; updated with ID)
+//   - orgID: Organisation the pet must belong to
+//
+// Returns:
+//   - error: Pet not found or database error
+func CreateMedicalRecord(record *m.MedicalRecord, orgID uint) error {
+	if _, err := findOrganizationPet(record.PetID, orgID); err != nil {
+		return err
+	}
+
+	normalizeMedicalRecord(record)
+
+	if err := dao.CreateMedicalRecord(record); err != nil {
+		return fmt.Errorf("error al crear registro médico: %v", err)
+	}
+
+	return nil
+}
+
+// UpdateMedicalRecord replaces the data of a medical record.
+// The record type cannot be changed.
+//
+// Parameters:
+//   - record: Medical record with updated data (ID and PetID must be set)
+//   - orgID: Organisation the pet must belong to
+//
+// Returns:
+//   - *m.MedicalRecord: Updated medical record
+//   - error: Pet or record not found, or database error
+func UpdateMedicalRecord(record *m.MedicalRecord, orgID uint) (*m.MedicalRecord, error) {
+	if _, err := findOrganizationPet(record.PetID, orgID); err != nil {
+		return nil, err
+	}
+
+	current, err := dao.GetMedicalRecord(record.PetID, record.ID)
+	if err != nil {
+		return nil, fmt.Errorf("registro médico no encontrado: %v", err)
+	}
+
+	record.Type = current.Type
+	normalizeMedicalRecord(record)
+
+	if err := dao.UpdateMedicalRecord(record); err != nil {
+		return nil, fmt.Errorf("error al actualizar registro médico: %v", err)
+	}
+
+	return dao.GetMedicalRecord(record.PetID, record.ID)
+}
+
+// DeleteMedicalRecord removes a record from the medical history of a pet.
+//
+// Parameters:
+//   - petID: Pet the record belongs to
+//   - orgID: Organisation the pet must belong to
+//   - id: Unique identifier of the record
+//
+// Returns:
+//   - error: Pet or record not found, or database error
+func DeleteMedicalRecord(petID uint, orgID uint, id uint) error {
+	if _, err := findOrganizationPet(petID, orgID); err != nil {
+		return err
+	}
+
+	if err := dao.DeleteMedicalRecord(petID, id); err != nil {
+		return fmt.Errorf("error al eliminar registro médico: %v", err)
+	}
+
+	return nil
+}
+
+// GetMedicalSummary builds the adopter-facing medical summary of a pet.
+//
+// Business Logic:
+// - Keeps only the latest dose of each vaccine; a dose is overdue when its due date has passed
+// - The pet is neutered when it has a spay_neuter record
+// - Treatments are active while they have no end date or it has not passed
+// - Internal notes and veterinarian names are never included
+//
+// Parameters:
+//   - petID: Pet whose summary is built
+//   - now: Reference time used to compute overdue doses and active treatments
+//
+// Returns:
+//   - *m.PetMedicalSummary: Medical summary
+//   - error: Pet not found or database error
+func GetMedicalSummary(petID uint, now time.Time) (*m.PetMedicalSummary, error) {
+	records, err := ListMedicalRecords(petID, dao.AllOrganizations, "")
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &m.PetMedicalSummary{
+		PetID:            petID,
+		VaccinesUpToDate: true,
+		Vaccinations:     []m.SummaryVaccination{},
+		Allergies:        []string{},
+		Treatments:       []m.SummaryTreatment{},
+	}
+	today := dateOnly(now)
+	seenVaccines := make(map[string]bool)
+
+	// Records are sorted by date descending: the first of each kind is the latest
+	for _, record := range records {
+		switch record.Type {
+		case m.MedicalVaccination:
+			if seenVaccines[record.Name] {
+				continue
+			}
+			seenVaccines[record.Name] = true
+
+			overdue := record.DueDate != nil && record.DueDate.Before(today)
+			summary.Vaccinated = true
+			summary.VaccinesUpToDate = summary.VaccinesUpToDate && !overdue
+			summary.Vaccinations = append(summary.Vaccinations, m.SummaryVaccination{
+				Name:    record.Name,
+				Date:    record.Date,
+				DueDate: record.DueDate,
+				Overdue: overdue,
+			})
+		case m.MedicalTreatment:
+			summary.Treatments = append(summary.Treatments, m.SummaryTreatment{
+				Name:    record.Name,
+				Date:    record.Date,
+				EndDate: record.EndDate,
+				Active:  record.EndDate == nil || !record.EndDate.Before(today),
+			})
+		case m.MedicalVetVisit:
+			if summary.LastVetVisit == nil {
+				date := record.Date
+				summary.LastVetVisit = &date
+			}
+		case m.MedicalWeight:
+			if summary.Weight == nil && record.WeightKg != nil {
+				summary.Weight = &m.SummaryWeight{Kg: *record.WeightKg, Date: record.Date}
+			}
+		case m.MedicalAllergy:
+			summary.Allergies = append(summary.Allergies, record.Name)
+		case m.MedicalSpayNeuter:
+			if !summary.Neutered {
+				date := record.Date
+				summary.Neutered = true
+				summary.NeuteredDate = &date
+			}
+		}
+	}
+
+	// Without vaccinations there is nothing to be up to date with
+	summary.VaccinesUpToDate = summary.Vaccinated && summary.VaccinesUpToDate
+
+	return summary, nil
+}
+
+// ========================================
+// MEDICAL RECORD HELPERS
+// ========================================
+
+// IsValidMedicalRecordType reports whether recordType is one of m.MedicalRecordTypes.
+func IsValidMedicalRecordType(recordType string) bool {
+	for _, valid := range m.MedicalRecordTypes {
+		if recordType == valid {
+			return true
+		}
+	}
+
+	return false
+}
+
+// normalizeMedicalRecord clears the fields that do not apply to the record type.
+func normalizeMedicalRecord(record *m.MedicalRecord) {
+	if record.Type != m.MedicalVaccination {
+		record.DueDate = nil
+	}
+	if record.Type != m.MedicalTreatment {
+		record.EndDate = nil
+	}
+	if record.Type != m.MedicalWeight {
+		record.WeightKg = nil
+	}
+}
+
+// dateOnly truncates t to midnight in its location.
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,181 @@
+// Package services provides business logic services for pet microchips.
+// This layer validates ISO 11784/11785 microchip numbers, keeps them unique
+// and resolves microchip lookups for finders and staff.
+package services
+
+import (
+	"backend/internal/db/dao"
+	m "backend/internal/models"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidMicrochip is returned for numbers that are not valid ISO 11784/11785 codes.
+var ErrInvalidMicrochip = errors.New("número de microchip no válido: debe tener 15 dígitos (ISO 11784/11785)")
+
+// ErrMicrochipInUse is returned when a microchip number is already assigned to another pet.
+var ErrMicrochipInUse = errors.New("el número de microchip ya está asignado a otra mascota")
+
+// ErrMicrochipNotFound is returned when no pet has the microchip number.
+var ErrMicrochipNotFound = errors.New("ninguna mascota tiene este número de microchip")
+
+const (
+	// microchipLength is the number of decimal digits of an ISO 11784/11785 (FDX-B) code.
+	microchipLength = 15
+
+	// microchipMaxNationalID is the largest 38-bit national identification code (2^38 - 1).
+	microchipMaxNationalID = 1<<38 - 1
+
+	// microchipTestCode is the country code reserved for test transponders.
+	microchipTestCode = 999
+)
+
+// ========================================
+// MICROCHIP SERVICES
+// ========================================
+
+// NormalizeMicrochip validates an ISO 11784/11785 microchip number and returns it as digits only.
+//
+// Validation:
+// - Spaces, dashes and dots are ignored (numbers are often printed in groups)
+// - Must have exactly 15 digits: a 3-digit country or manufacturer code and a 12-digit national ID
+// - The national ID must fit in 38 bits (at most 274877906943)
+// - Code 999 (test transponders) and code 000 are rejected
+//
+// Parameters:
+//   - number: Microchip number as typed or read by a scanner
+//
+// Returns:
+//   - string: Normalised 15-digit number
+//   - error: ErrInvalidMicrochip if the number is not valid
+func NormalizeMicrochip(number string) (string, error) {
+	digits := strings.Map(func(r rune) rune {
+		if r == ' ' || r == '-' || r == '.' {
+			return -1
+		}
+		return r
+	}, number)
+
+	if len(digits) != microchipLength {
+		return "", ErrInvalidMicrochip
+	}
+
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return "", ErrInvalidMicrochip
+		}
+	}
+
+	code, _ := strconv.Atoi(digits[:3])
+	if code == 0 || code == microchipTestCode {
+		return "", ErrInvalidMicrochip
+	}
+
+	nationalID, _ := strconv.ParseUint(digits[3:], 10, 64)
+	if nationalID > microchipMaxNationalID {
+		return "", ErrInvalidMicrochip
+	}
+
+	return digits, nil
+}
+
+// LookupMicrochip finds the pet with a microchip number.
+//
+// Business Logic:
+// - Anyone can identify a pet (e.g. a finder at the shelter desk)
+// - Adopter contact details are only returned for pets with an adopter
+// - Only staff of the pet's organisation and administrators see them
+//
+// Parameters:
+//   - number: Microchip number (any grouping, see NormalizeMicrochip)
+//   - viewer: Current user, or nil for anonymous requests
+//
+// Returns:
+//   - *m.MicrochipLookup: Identified pet
+//   - error: ErrInvalidMicrochip, ErrMicrochipNotFound or database error
+func LookupMicrochip(number string, viewer *m.NonValidatedUser) (*m.MicrochipLookup, error) {
+	microchip, err := NormalizeMicrochip(number)
+	if err != nil {
+		return nil, err
+	}
+
+	pet, err := dao.GetPetByMicrochip(microchip)
+	if err != nil {
+		return nil, fmt.Errorf("error al buscar microchip: %v", err)
+	}
+	if pet == nil {
+		return nil, ErrMicrochipNotFound
+	}
+
+	lookup := &m.MicrochipLookup{
+		PetID:     pet.ID,
+		Name:      pet.Name,
+		Species:   pet.Species,
+		Breed:     pet.Breed,
+		Status:    pet.Status,
+		Microchip: microchip,
+	}
+
+	if len(pet.Photos) > 0 {
+		lookup.PrimaryPhoto = &pet.Photos[0]
+		fillPhotoURL(lookup.PrimaryPhoto)
+	}
+
+	if viewer != nil && viewer.IsStaff() && pet.AdoptUserID != 0 {
+		if _, err := ResolveMembership(viewer, pet.OrganizationID); err != nil {
+			if errors.Is(err, ErrNotOrganizationMember) {
+				return lookup, nil
+			}
+			return nil, fmt.Errorf("error al comprobar organización de la mascota: %v", err)
+		}
+
+		adopter, err := dao.GetUserByID(pet.AdoptUserID)
+		if err != nil {
+			return nil, fmt.Errorf("error al leer adoptante: %v", err)
+		}
+		lookup.Adopter = &m.AdopterContact{
+			ID:      adopter.ID,
+			Name:    adopter.Name,
+			Surname: adopter.Surname,
+			Email:   adopter.Email,
+			Address: adopter.Address,
+		}
+	}
+
+	return lookup, nil
+}
+
+// ========================================
+// MICROCHIP HELPERS
+// ========================================
+
+// normalizePetMicrochip normalises the microchip of a pet being saved and checks it is not
+// assigned to another pet. An empty microchip is stored as NULL.
+func normalizePetMicrochip(pet *m.Pet) error {
+	if pet.Microchip == nil {
+		return nil
+	}
+
+	if strings.TrimSpace(*pet.Microchip) == "" {
+		pet.Microchip = nil
+		return nil
+	}
+
+	microchip, err := NormalizeMicrochip(*pet.Microchip)
+	if err != nil {
+		return err
+	}
+	pet.Microchip = &microchip
+
+	owner, err := dao.GetPetByMicrochip(microchip)
+	if err != nil {
+		return fmt.Errorf("error al comprobar microchip: %v", err)
+	}
+	if owner != nil && owner.ID != pet.ID {
+		return ErrMicrochipInUse
+	}
+
+	return nil
+}
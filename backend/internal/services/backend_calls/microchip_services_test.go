@@ -0,0 +1,40 @@
+package services
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalizeMicrochip(t *testing.T) {
+	tests := []struct {
+		name   string
+		number string
+		want   string
+		err    error
+	}{
+		{name: "digits only", number: "941000024512345", want: "941000024512345"},
+		{name: "grouped with spaces", number: "941 000 024 512 345", want: "941000024512345"},
+		{name: "grouped with dashes and dots", number: "941-000.024-512.345", want: "941000024512345"},
+		{name: "largest national id", number: "941274877906943", want: "941274877906943"},
+		{name: "empty", number: "", err: ErrInvalidMicrochip},
+		{name: "too short", number: "94100002451234", err: ErrInvalidMicrochip},
+		{name: "too long", number: "9410000245123456", err: ErrInvalidMicrochip},
+		{name: "letters", number: "94100002451234A", err: ErrInvalidMicrochip},
+		{name: "other separators", number: "941/000/024/512/345", err: ErrInvalidMicrochip},
+		{name: "code 000", number: "000000024512345", err: ErrInvalidMicrochip},
+		{name: "test transponder code", number: "999000024512345", err: ErrInvalidMicrochip},
+		{name: "national id above 38 bits", number: "941274877906944", err: ErrInvalidMicrochip},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeMicrochip(tt.number)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("NormalizeMicrochip(%q) error = %v, want %v", tt.number, err, tt.err)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeMicrochip(%q) = %q, want %q", tt.number, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,335 @@
+// Package services provides business logic services for user notifications.
+// This layer is the single path every notification takes: it checks the
+// recipient's preferences, queues the email and fills the in-app notification
+// centre. It also lets users manage their preferences and notifications.
+package services
+
+import (
+	"backend/internal/db/dao"
+	"backend/internal/db/query"
+	m "backend/internal/models"
+	mailer "backend/internal/services/mail"
+	"backend/internal/services/scheduler"
+	"backend/internal/utils/env"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"slices"
+	"time"
+)
+
+// NotificationCleanupJob is the scheduler job name of the notification centre cleanup.
+const NotificationCleanupJob = "notification-cleanup"
+
+var (
+	// notificationRetention is how long read notifications are kept (NOTIFICATION_RETENTION, default 90 days).
+	notificationRetention = env.GetDuration("NOTIFICATION_RETENTION", 90*24*time.Hour)
+
+	// notificationCleanupHour is the local hour read notifications are deleted at (NOTIFICATION_CLEANUP_HOUR, default 4).
+	notificationCleanupHour = int(env.GetInt("NOTIFICATION_CLEANUP_HOUR", 4))
+)
+
+var (
+	// ErrNotificationNotFound is returned when the notification does not exist or belongs to another user.
+	ErrNotificationNotFound = errors.New("notificación no encontrada")
+
+	// ErrUnknownNotificationType is returned for a type that is not one of m.NotificationTypes.
+	ErrUnknownNotificationType = errors.New("tipo de notificación desconocido")
+)
+
+// notification is a message for one recipient, delivered by notify.
+type notification struct {
+	UserID   *uint         // Registered recipient; nil for guests and organisation addresses, who only get the email
+	Type     string        // Notification type (see m.NotificationTypes); empty for emails users cannot disable
+	Required bool          // Email it whatever the preferences (documents); the in-app notification still follows them
+	URL      string        // Page the in-app notification links to (optional)
+	Email    *mailer.Email // Email composed in the recipient's locale
+}
+
+// ========================================
+// NOTIFICATION DELIVERY
+// ========================================
+
+// notify delivers a notification on the channels enabled by its recipient.
+// Every email of the system goes through here, except the account emails
+// (2FA codes and passwords) that are stored in the transaction of their change.
+//
+// Business Logic:
+// - Guests, organisation addresses and notifications without a type are always emailed, never in-app
+// - Registered users get the email and the in-app notification their preferences for the type allow
+// - Required notifications are emailed even when the user disabled the email channel
+// - The in-app notification reuses the email subject as title and its summary as body, and is pushed as an event
+// - A disabled channel is not an error: the notification counts as delivered
+//
+// Parameters:
+//   - n: Notification to deliver
+//
+// Returns:
+//   - error: Outbox or database error, or nil once delivered
+func notify(n notification) error {
+	email, inApp := true, false
+	if n.UserID != nil && n.Type != "" {
+		preference := notificationPreference(*n.UserID, n.Type)
+		email, inApp = preference.Email || n.Required, preference.InApp
+	}
+
+	if email {
+		if err := mailer.Queue(n.Email); err != nil {
+			return err
+		}
+	}
+
+	if inApp {
+		entry := &m.Notification{
+			UserID: *n.UserID,
+			Type:   n.Type,
+			Title:  truncateRunes(n.Email.Subject, 255),
+			Body:   n.Email.Notification,
+			URL:    truncateRunes(n.URL, 500),
+		}
+		if err := dao.CreateNotification(entry); err != nil {
+			return err
+		}
+		publishNotification(entry)
+	}
+
+	return nil
+}
+
+// notificationPreference returns the channels a user receives a notification type on.
+// Types without a stored preference, and preferences that cannot be read, have every channel enabled.
+func notificationPreference(userID uint, notificationType string) m.NotificationPreference {
+	preference := m.NotificationPreference{UserID: userID, Type: notificationType, Email: true, InApp: true}
+
+	stored, err := dao.GetNotificationPreferences(userID)
+	if err != nil {
+		log.Printf("could not read notification preferences of user %d: %v", userID, err)
+		return preference
+	}
+	for _, p := range stored {
+		if p.Type == notificationType {
+			return p
+		}
+	}
+
+	return preference
+}
+
+// ========================================
+// NOTIFICATION PREFERENCE SERVICES
+// ========================================
+
+// ListNotificationPreferences retrieves the preferences of a user for every notification type.
+//
+// Parameters:
+//   - userID: Current user
+//
+// Returns:
+//   - []m.NotificationPreference: One preference per type of m.NotificationTypes, defaults included
+//   - error: Database error or nil on success
+func ListNotificationPreferences(userID uint) ([]m.NotificationPreference, error) {
+	stored, err := dao.GetNotificationPreferences(userID)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener preferencias de notificación: %v", err)
+	}
+
+	preferences := make([]m.NotificationPreference, len(m.NotificationTypes))
+	for i, notificationType := range m.NotificationTypes {
+		preferences[i] = m.NotificationPreference{UserID: userID, Type: notificationType, Email: true, InApp: true}
+		for _, p := range stored {
+			if p.Type == notificationType {
+				preferences[i] = p
+			}
+		}
+	}
+
+	return preferences, nil
+}
+
+// UpdateNotificationPreferences changes the channels of some notification types of a user.
+//
+// Business Logic:
+// - Only the given types change; a nil channel keeps its current value
+// - Types not in m.NotificationTypes are rejected before anything is saved
+//
+// Parameters:
+//   - userID: Current user
+//   - changes: Type and channels to change (Email and InApp nil to keep them)
+//
+// Returns:
+//   - []m.NotificationPreference: Preferences of the user for every type after the change
+//   - error: ErrUnknownNotificationType or database error
+func UpdateNotificationPreferences(userID uint, changes []NotificationPreferenceChange) ([]m.NotificationPreference, error) {
+	current, err := ListNotificationPreferences(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := make([]m.NotificationPreference, 0, len(changes))
+	for _, change := range changes {
+		i := slices.Index(m.NotificationTypes, change.Type)
+		if i < 0 {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownNotificationType, change.Type)
+		}
+
+		if change.Email != nil {
+			current[i].Email = *change.Email
+		}
+		if change.InApp != nil {
+			current[i].InApp = *change.InApp
+		}
+		updated = append(updated, current[i])
+	}
+
+	if err := dao.SaveNotificationPreferences(updated); err != nil {
+		return nil, fmt.Errorf("error al guardar preferencias de notificación: %v", err)
+	}
+
+	return current, nil
+}
+
+// NotificationPreferenceChange is a change of the channels of a notification type.
+type NotificationPreferenceChange struct {
+	Type  string // Notification type (see m.NotificationTypes)
+	Email *bool  // New email channel value, nil to keep it
+	InApp *bool  // New in-app channel value, nil to keep it
+}
+
+// ========================================
+// NOTIFICATION CENTRE SERVICES
+// ========================================
+
+// NewNotificationListQuery parses and validates the pagination, sorting and filter
+// parameters of a notification centre request against dao.NotificationListSchema.
+//
+// Parameters:
+//   - path: Request path used to build next/prev links
+//   - values: Raw query parameters
+//
+// Returns:
+//   - *query.Params: Validated list query
+//   - error: Validation error describing the invalid parameter
+func NewNotificationListQuery(path string, values url.Values) (*query.Params, error) {
+	return query.Parse(path, values, dao.NotificationListSchema)
+}
+
+// ListNotifications retrieves one page of the notification centre of a user.
+//
+// Parameters:
+//   - params: Validated list query (see NewNotificationListQuery)
+//   - userID: Current user
+//
+// Returns:
+//   - *query.Page[m.Notification]: Notifications, newest first by default
+//   - error: Database error or nil on success
+func ListNotifications(params *query.Params, userID uint) (*query.Page[m.Notification], error) {
+	notifications, err := dao.GetNotifications(params, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener notificaciones: %v", err)
+	}
+
+	return notifications, nil
+}
+
+// CountUnreadNotifications counts the unread notifications of a user, in total and by type.
+//
+// Parameters:
+//   - userID: Current user
+//
+// Returns:
+//   - *m.NotificationUnreadCount: Unread notifications, with every type of m.NotificationTypes
+//   - error: Database error or nil on success
+func CountUnreadNotifications(userID uint) (*m.NotificationUnreadCount, error) {
+	counts, err := dao.CountUnreadNotifications(userID)
+	if err != nil {
+		return nil, fmt.Errorf("error al contar notificaciones: %v", err)
+	}
+
+	unread := &m.NotificationUnreadCount{ByType: make(map[string]int64, len(m.NotificationTypes))}
+	for _, notificationType := range m.NotificationTypes {
+		unread.ByType[notificationType] = counts[notificationType]
+		unread.Total += counts[notificationType]
+	}
+
+	return unread, nil
+}
+
+// MarkNotificationRead marks a notification of a user as read.
+// Notifications already read keep their original read date.
+//
+// Parameters:
+//   - userID: Current user
+//   - id: Notification to mark
+//
+// Returns:
+//   - *m.Notification: Notification after the change
+//   - error: ErrNotificationNotFound or database error
+func MarkNotificationRead(userID uint, id uint) (*m.Notification, error) {
+	if _, err := dao.GetNotification(userID, id); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNotificationNotFound, err)
+	}
+
+	if _, err := dao.MarkNotificationsRead(userID, id, "", time.Now()); err != nil {
+		return nil, fmt.Errorf("error al marcar notificación: %v", err)
+	}
+
+	notification, err := dao.GetNotification(userID, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNotificationNotFound, err)
+	}
+
+	return notification, nil
+}
+
+// MarkAllNotificationsRead marks every unread notification of a user as read.
+//
+// Parameters:
+//   - userID: Current user
+//   - notificationType: Only mark notifications of this type (optional)
+//
+// Returns:
+//   - int64: Number of notifications marked
+//   - error: ErrUnknownNotificationType or database error
+func MarkAllNotificationsRead(userID uint, notificationType string) (int64, error) {
+	if notificationType != "" && !slices.Contains(m.NotificationTypes, notificationType) {
+		return 0, fmt.Errorf("%w: %s", ErrUnknownNotificationType, notificationType)
+	}
+
+	marked, err := dao.MarkNotificationsRead(userID, 0, notificationType, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("error al marcar notificaciones: %v", err)
+	}
+
+	return marked, nil
+}
+
+// RunNotificationCleanup deletes the notifications read more than NOTIFICATION_RETENTION ago.
+// Unread notifications are kept until the user reads them.
+//
+// Parameters:
+//   - now: Reference time of the run
+//   - dryRun: Only report the cutoff, without deleting anything
+//
+// Returns:
+//   - scheduler.Result: Number of notifications deleted
+//   - error: Database error or nil on success
+func RunNotificationCleanup(now time.Time, dryRun bool) (scheduler.Result, error) {
+	before := now.Add(-notificationRetention)
+
+	if dryRun {
+		return scheduler.Result{
+			Summary: fmt.Sprintf("se borrarían las notificaciones leídas antes del %s", before.Format("02/01/2006 15:04")),
+		}, nil
+	}
+
+	deleted, err := dao.DeleteReadNotificationsBefore(before)
+	if err != nil {
+		return scheduler.Result{}, err
+	}
+
+	return scheduler.Result{
+		Items:   int(deleted),
+		Summary: fmt.Sprintf("%d notificaciones leídas borradas", deleted),
+	}, nil
+}
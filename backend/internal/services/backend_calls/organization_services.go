@@ -0,0 +1,313 @@
+// Package services provides business logic services for shelter organisations.
+// This layer sits between handlers and DAOs, managing organisations and their settings,
+// staff memberships and the organisation a staff request acts on.
+package services
+
+import (
+	"backend/internal/db/dao"
+	"backend/internal/db/query"
+	m "backend/internal/models"
+	mailer "backend/internal/services/mail"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+)
+
+var (
+	// ErrOrganizationNotFound is returned when the requested organisation does not exist.
+	ErrOrganizationNotFound = errors.New("organización no encontrada")
+
+	// ErrOrganizationRequired is returned when a staff member of several organisations does not say which one they act on.
+	ErrOrganizationRequired = errors.New("indica la organización con la cabecera X-Organization-ID")
+
+	// ErrNotOrganizationMember is returned when a staff member acts on an organisation they do not belong to.
+	ErrNotOrganizationMember = errors.New("no perteneces a esta organización")
+
+	// ErrSlugInUse is returned when the slug is already used by another organisation.
+	ErrSlugInUse = errors.New("el slug ya está en uso por otra organización")
+
+	// ErrMemberNotStaff is returned when adding a user without a staff or admin role to an organisation.
+	ErrMemberNotStaff = errors.New("solo los usuarios del personal pueden pertenecer a una organización")
+
+	// ErrMemberNotFound is returned when the user is not a member of the organisation.
+	ErrMemberNotFound = errors.New("el usuario no es miembro de la organización")
+)
+
+// ========================================
+// ORGANIZATION SERVICES
+// ========================================
+
+// NewOrganizationListQuery parses and validates the pagination, sorting and filter
+// parameters of an organisation list request against dao.OrganizationListSchema.
+//
+// Parameters:
+//   - path: Request path used to build next/prev links
+//   - values: Raw query parameters
+//
+// Returns:
+//   - *query.Params: Validated list query
+//   - error: Validation error describing the invalid parameter
+func NewOrganizationListQuery(path string, values url.Values) (*query.Params, error) {
+	return query.Parse(path, values, dao.OrganizationListSchema)
+}
+
+// ListOrganizations retrieves one page of organisations.
+//
+// Parameters:
+//   - params: Validated list query (see NewOrganizationListQuery)
+//
+// Returns:
+//   - *query.Page[m.Organization]: Requested page of organisations with total count and links
+//   - error: Database error or nil on success
+func ListOrganizations(params *query.Params) (*query.Page[m.Organization], error) {
+	organizations, err := dao.GetOrganizations(params)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener organizaciones: %v", err)
+	}
+
+	return organizations, nil
+}
+
+// GetOrganization retrieves an organisation with its settings.
+//
+// Parameters:
+//   - id: Unique identifier of the organisation
+//
+// Returns:
+//   - *m.Organization: Organisation data
+//   - error: ErrOrganizationNotFound or nil on success
+func GetOrganization(id uint) (*m.Organization, error) {
+	organization, err := dao.GetOrganizationByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrOrganizationNotFound, err)
+	}
+
+	return organization, nil
+}
+
+// CreateOrganization creates a new organisation.
+//
+// Parameters:
+//   - organization: Validated organisation data (will be updated with ID and timestamps)
+//
+// Returns:
+//   - error: ErrSlugInUse, creation error or nil on success
+func CreateOrganization(organization *m.Organization) error {
+	if err := checkOrganizationSlug(organization); err != nil {
+		return err
+	}
+
+	if err := dao.CreateOrganization(organization); err != nil {
+		return fmt.Errorf("error al crear organización: %v", err)
+	}
+
+	return nil
+}
+
+// UpdateOrganization updates the profile and settings of an organisation.
+//
+// Parameters:
+//   - organization: Validated organisation data (must include ID)
+//
+// Returns:
+//   - *m.Organization: Updated organisation
+//   - error: ErrSlugInUse, ErrOrganizationNotFound, update error or nil on success
+func UpdateOrganization(organization *m.Organization) (*m.Organization, error) {
+	if err := checkOrganizationSlug(organization); err != nil {
+		return nil, err
+	}
+
+	if _, err := GetOrganization(organization.ID); err != nil {
+		return nil, err
+	}
+
+	if err := dao.UpdateOrganization(organization); err != nil {
+		return nil, fmt.Errorf("error al actualizar organización: %v", err)
+	}
+
+	return GetOrganization(organization.ID)
+}
+
+// ========================================
+// ORGANIZATION MEMBERSHIP SERVICES
+// ========================================
+
+// ResolveMembership determines the organisation a staff request acts on.
+//
+// Business Logic:
+// - With an organisation ID, the user must be a member of it
+// - Without one, the user's only membership is used; users of several organisations must choose
+// - Administrators act as managers of any existing organisation, member or not
+//
+// Parameters:
+//   - user: Authenticated staff user
+//   - orgID: Requested organisation, or dao.AllOrganizations when not given
+//
+// Returns:
+//   - *m.OrganizationMember: Membership the request acts with
+//   - error: ErrOrganizationRequired, ErrNotOrganizationMember, ErrOrganizationNotFound or database error
+func ResolveMembership(user *m.NonValidatedUser, orgID uint) (*m.OrganizationMember, error) {
+	isAdmin := user.Role == m.UserRoleAdmin
+
+	var membership *m.OrganizationMember
+	if orgID != dao.AllOrganizations {
+		member, err := dao.GetMembership(orgID, user.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		if member == nil {
+			if !isAdmin {
+				return nil, ErrNotOrganizationMember
+			}
+			if _, err := GetOrganization(orgID); err != nil {
+				return nil, err
+			}
+			member = &m.OrganizationMember{OrganizationID: orgID, UserID: user.ID}
+		}
+		membership = member
+	} else {
+		members, err := dao.GetUserMemberships(user.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(members) != 1 {
+			return nil, ErrOrganizationRequired
+		}
+		membership = &members[0]
+	}
+
+	if isAdmin {
+		membership.Role = m.OrgRoleManager
+	}
+
+	return membership, nil
+}
+
+// ListUserMemberships retrieves the organisations a user is a member of.
+//
+// Parameters:
+//   - userID: Unique identifier of the user
+//
+// Returns:
+//   - []m.OrganizationMember: Memberships with the organisation ID and role
+//   - error: Database error or nil on success
+func ListUserMemberships(userID uint) ([]m.OrganizationMember, error) {
+	members, err := dao.GetUserMemberships(userID)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener organizaciones del usuario: %v", err)
+	}
+
+	return members, nil
+}
+
+// ListOrganizationMembers retrieves the members of an organisation.
+//
+// Parameters:
+//   - orgID: Unique identifier of the organisation
+//
+// Returns:
+//   - []m.OrganizationMember: Members with their user summary
+//   - error: Database error or nil on success
+func ListOrganizationMembers(orgID uint) ([]m.OrganizationMember, error) {
+	members, err := dao.GetOrganizationMembers(orgID)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener miembros de la organización: %v", err)
+	}
+
+	return members, nil
+}
+
+// SaveOrganizationMember adds a staff user to an organisation or changes their role.
+//
+// Business Logic:
+// - Only users with the staff or admin role can be members
+//
+// Parameters:
+//   - orgID: Unique identifier of the organisation
+//   - userID: Unique identifier of the user
+//   - role: staff or manager
+//
+// Returns:
+//   - *m.OrganizationMember: Saved membership
+//   - error: ErrMemberNotFound (unknown user), ErrMemberNotStaff or database error
+func SaveOrganizationMember(orgID uint, userID uint, role string) (*m.OrganizationMember, error) {
+	user, err := dao.GetUserByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMemberNotFound, err)
+	}
+
+	if !user.IsStaff() {
+		return nil, ErrMemberNotStaff
+	}
+
+	member := &m.OrganizationMember{OrganizationID: orgID, UserID: userID, Role: role}
+	if err := dao.SaveOrganizationMember(member); err != nil {
+		return nil, fmt.Errorf("error al guardar miembro: %v", err)
+	}
+
+	saved, err := dao.GetMembership(orgID, userID)
+	if err != nil || saved == nil {
+		return nil, fmt.Errorf("error al leer miembro guardado: %v", err)
+	}
+
+	return saved, nil
+}
+
+// RemoveOrganizationMember removes a user from an organisation.
+//
+// Parameters:
+//   - orgID: Unique identifier of the organisation
+//   - userID: Unique identifier of the user
+//
+// Returns:
+//   - error: ErrMemberNotFound or nil on success
+func RemoveOrganizationMember(orgID uint, userID uint) error {
+	if err := dao.RemoveOrganizationMember(orgID, userID); err != nil {
+		return fmt.Errorf("%w: %v", ErrMemberNotFound, err)
+	}
+
+	return nil
+}
+
+// ========================================
+// ORGANIZATION HELPERS
+// ========================================
+
+// checkOrganizationSlug returns ErrSlugInUse if another organisation already has the slug.
+func checkOrganizationSlug(organization *m.Organization) error {
+	owner, err := dao.GetOrganizationBySlug(organization.Slug)
+	if err != nil {
+		return err
+	}
+
+	if owner != nil && owner.ID != organization.ID {
+		return ErrSlugInUse
+	}
+
+	return nil
+}
+
+// organizationSender returns the email sender identity of an organisation,
+// or the default sender if it cannot be loaded.
+// Without a configured sender name, the organisation name is used.
+func organizationSender(orgID uint) mailer.Sender {
+	organization, err := dao.GetOrganizationByID(orgID)
+	if err != nil {
+		log.Printf("could not load organization %d sender, using default: %v", orgID, err)
+		return mailer.DefaultSender
+	}
+
+	name := organization.SenderName
+	if name == "" {
+		name = organization.Name
+	}
+
+	return mailer.Sender{
+		Name:    name,
+		Email:   organization.SenderEmail,
+		ReplyTo: organization.ReplyTo,
+	}
+}
@@ -0,0 +1,200 @@
+// Package services provides business logic services for the email outbox.
+// This layer runs the worker that retries the queued emails and lets admins
+// inspect the outbox and resend dead-lettered emails.
+package services
+
+import (
+	"backend/internal/db/dao"
+	"backend/internal/db/query"
+	m "backend/internal/models"
+	mailer "backend/internal/services/mail"
+	"backend/internal/services/scheduler"
+	"backend/internal/utils/env"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// MailOutboxJob is the scheduler job name of the email outbox worker.
+const MailOutboxJob = "mail-outbox"
+
+// mailOutboxBatch is the maximum number of emails sent by one job run.
+const mailOutboxBatch = 100
+
+// mailOutboxInterval is how often due emails are retried (MAIL_OUTBOX_INTERVAL, default 1m).
+var mailOutboxInterval = env.GetDuration("MAIL_OUTBOX_INTERVAL", time.Minute)
+
+// mailFailedRetention is how long a dead-lettered email keeps its message for a resend (MAIL_FAILED_RETENTION, default 72h).
+var mailFailedRetention = env.GetDuration("MAIL_FAILED_RETENTION", 72*time.Hour)
+
+var (
+	// ErrOutboxEmailNotFound is returned when the email does not exist in the outbox.
+	ErrOutboxEmailNotFound = errors.New("email no encontrado")
+
+	// ErrOutboxEmailNotFailed is returned when resending an email that is not dead-lettered.
+	ErrOutboxEmailNotFailed = errors.New("solo se pueden reenviar los emails fallidos")
+
+	// ErrOutboxEmailCleared is returned when resending an email whose message was already cleared.
+	ErrOutboxEmailCleared = errors.New("el contenido del email ya se ha borrado y no se puede reenviar")
+)
+
+// ========================================
+// OUTBOX SERVICES
+// ========================================
+
+// NewOutboxListQuery parses and validates the pagination, sorting and filter
+// parameters of an outbox request against dao.OutboxEmailListSchema.
+//
+// Parameters:
+//   - path: Request path used to build next/prev links
+//   - values: Raw query parameters
+//
+// Returns:
+//   - *query.Params: Validated list query
+//   - error: Validation error describing the invalid parameter
+func NewOutboxListQuery(path string, values url.Values) (*query.Params, error) {
+	return query.Parse(path, values, dao.OutboxEmailListSchema)
+}
+
+// ListOutboxEmails retrieves one page of the outbox, without the messages.
+//
+// Parameters:
+//   - params: Validated list query (see NewOutboxListQuery)
+//
+// Returns:
+//   - *query.Page[m.OutboxEmail]: Emails with their delivery state
+//   - error: Database error or nil on success
+func ListOutboxEmails(params *query.Params) (*query.Page[m.OutboxEmail], error) {
+	emails, err := dao.GetOutboxEmails(params)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener emails: %v", err)
+	}
+
+	return emails, nil
+}
+
+// GetOutboxEmail retrieves an email of the outbox. Its message is never returned.
+//
+// Parameters:
+//   - id: Unique identifier of the email
+//
+// Returns:
+//   - *m.OutboxEmail: Email with its delivery state
+//   - error: ErrOutboxEmailNotFound
+func GetOutboxEmail(id uint) (*m.OutboxEmail, error) {
+	email, err := dao.GetOutboxEmail(id)
+	if err != nil {
+		return nil, ErrOutboxEmailNotFound
+	}
+
+	return email, nil
+}
+
+// ResendOutboxEmail moves a dead-lettered email back to the queue and attempts it right away.
+//
+// Business Logic:
+// - Only failed emails can be resent; the attempts start again from zero
+// - Emails dead-lettered longer than MAIL_FAILED_RETENTION ago have no message left to resend
+// - A failed attempt is not an error: the email is retried like any queued email
+//
+// Parameters:
+//   - id: Unique identifier of the email
+//
+// Returns:
+//   - *m.OutboxEmail: Email with the outcome of the new attempt
+//   - error: ErrOutboxEmailNotFound, ErrOutboxEmailNotFailed, ErrOutboxEmailCleared or database error
+func ResendOutboxEmail(id uint) (*m.OutboxEmail, error) {
+	email, err := GetOutboxEmail(id)
+	if err != nil {
+		return nil, err
+	}
+	if email.Status != m.OutboxEmailFailed {
+		return nil, ErrOutboxEmailNotFailed
+	}
+
+	requeued, err := dao.RequeueOutboxEmail(id, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if !requeued {
+		// Another resend requeued it first, or its message is cleared
+		if current, err := dao.GetOutboxEmail(id); err == nil && current.Status != m.OutboxEmailFailed {
+			return nil, ErrOutboxEmailNotFailed
+		}
+		return nil, ErrOutboxEmailCleared
+	}
+
+	email, err = GetOutboxEmail(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Deliver records the outcome in the email; if the worker claimed it first, return its current state
+	if err := mailer.Deliver(email); errors.Is(err, mailer.ErrEmailClaimed) {
+		return GetOutboxEmail(id)
+	}
+
+	return email, nil
+}
+
+// ========================================
+// OUTBOX JOB
+// ========================================
+
+// RunMailOutbox sends the queued emails whose attempt is due.
+//
+// Business Logic:
+// - New emails are attempted as soon as they are queued; this job sends the retries and the emails left by a restart
+// - Failed attempts are rescheduled with backoff or dead-lettered (see mailer.Deliver)
+// - Messages of emails dead-lettered longer than MAIL_FAILED_RETENTION ago are cleared
+// - Mail server failures do not fail the run; only database errors do
+//
+// Parameters:
+//   - now: Reference time of the run
+//   - dryRun: Only list the due emails, without sending them
+//
+// Returns:
+//   - scheduler.Result: Number of emails attempted (or that would be attempted)
+//   - error: Database error or nil on success
+func RunMailOutbox(now time.Time, dryRun bool) (scheduler.Result, error) {
+	emails, err := dao.GetDueOutboxEmails(now, mailOutboxBatch)
+	if err != nil {
+		return scheduler.Result{}, err
+	}
+
+	if dryRun {
+		ids := make([]uint, len(emails))
+		for i, email := range emails {
+			ids[i] = email.ID
+		}
+
+		return scheduler.Result{
+			Items:   len(emails),
+			Summary: fmt.Sprintf("se intentarían %d emails", len(emails)),
+			Preview: ids,
+		}, nil
+	}
+
+	sent, failed := 0, 0
+	for i := range emails {
+		err := mailer.Deliver(&emails[i])
+		switch {
+		case errors.Is(err, mailer.ErrEmailClaimed):
+		case err != nil:
+			failed++
+		default:
+			sent++
+		}
+	}
+
+	cleared, err := dao.ClearFailedOutboxMessages(now.Add(-mailFailedRetention))
+	if err != nil {
+		return scheduler.Result{}, err
+	}
+
+	return scheduler.Result{
+		Items:   sent + failed,
+		Summary: fmt.Sprintf("%d emails enviados, %d fallidos, %d mensajes borrados", sent, failed, cleared),
+	}, nil
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	m "backend/internal/models"
+	"backend/internal/services/payments"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAgeInMonths(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 12, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name  string
+		birth time.Time
+		now   time.Time
+		want  int
+	}{
+		{name: "born today", birth: date(2026, 10, 18), now: date(2026, 10, 18), want: 0},
+		{name: "one day short of a month", birth: date(2026, 9, 19), now: date(2026, 10, 18), want: 0},
+		{name: "exactly one month", birth: date(2026, 9, 18), now: date(2026, 10, 18), want: 1},
+		{name: "across a year", birth: date(2025, 11, 30), now: date(2026, 2, 28), want: 2},
+		{name: "exactly one year", birth: date(2025, 10, 18), now: date(2026, 10, 18), want: 12},
+		{name: "born on the 31st", birth: date(2026, 1, 31), now: date(2026, 3, 1), want: 1},
+		{name: "leap day", birth: date(2024, 2, 29), now: date(2025, 2, 28), want: 11},
+		{name: "birth in the future", birth: date(2027, 1, 1), now: date(2026, 10, 18), want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ageInMonths(tt.birth, tt.now); got != tt.want {
+				t.Errorf("ageInMonths(%s, %s) = %d, want %d", tt.birth.Format(time.DateOnly), tt.now.Format(time.DateOnly), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuoteFee(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	months := func(n int) *int { return &n }
+	bornMonthsAgo := func(n int) time.Time { return now.AddDate(0, -n, 0) }
+
+	// Schedules of one organisation, already filtered by the pet's species (or any species)
+	schedules := []m.FeeSchedule{
+		{ID: 1, Name: "General", AmountCents: 10000, Currency: "EUR"},
+		{ID: 2, Name: "Cachorros", MaxAgeMonths: months(12), AmountCents: 15000, Currency: "EUR"},
+		{ID: 3, Name: "Senior", MinAgeMonths: 96, AmountCents: 5000, Currency: "EUR"},
+		{ID: 4, Name: "Perros", Species: "perro", AmountCents: 12000, Currency: "EUR"},
+		{ID: 5, Name: "Perros cachorros", Species: "perro", MaxAgeMonths: months(12), AmountCents: 18000, Currency: "EUR"},
+		{ID: 6, Name: "Primeros meses", MaxAgeMonths: months(6), AmountCents: 20000, Currency: "GBP"},
+	}
+
+	tests := []struct {
+		name       string
+		pet        m.Pet
+		schedules  []m.FeeSchedule
+		wantID     uint
+		wantAmount int64
+		wantAge    *int
+	}{
+		{name: "no schedules", pet: m.Pet{BirthDate: bornMonthsAgo(30)}, schedules: nil, wantAmount: 0, wantAge: months(30)},
+		{name: "adult uses the general fee", pet: m.Pet{BirthDate: bornMonthsAgo(30)}, schedules: schedules[:3], wantID: 1, wantAmount: 10000, wantAge: months(30)},
+		{name: "puppy below the exclusive maximum", pet: m.Pet{BirthDate: bornMonthsAgo(11)}, schedules: schedules[:3], wantID: 2, wantAmount: 15000, wantAge: months(11)},
+		{name: "maximum age is exclusive", pet: m.Pet{BirthDate: bornMonthsAgo(12)}, schedules: schedules[:3], wantID: 1, wantAmount: 10000, wantAge: months(12)},
+		{name: "minimum age is inclusive", pet: m.Pet{BirthDate: bornMonthsAgo(96)}, schedules: schedules[:3], wantID: 3, wantAmount: 5000, wantAge: months(96)},
+		{name: "species beats any species", pet: m.Pet{BirthDate: bornMonthsAgo(30)}, schedules: schedules[:5], wantID: 4, wantAmount: 12000, wantAge: months(30)},
+		{name: "species then age range", pet: m.Pet{BirthDate: bornMonthsAgo(3)}, schedules: schedules, wantID: 5, wantAmount: 18000, wantAge: months(3)},
+		{name: "narrower range among any species", pet: m.Pet{BirthDate: bornMonthsAgo(3)}, schedules: []m.FeeSchedule{schedules[1], schedules[5]}, wantID: 6, wantAmount: 20000, wantAge: months(3)},
+		{name: "unknown age only matches open schedules", pet: m.Pet{}, schedules: schedules, wantID: 4, wantAmount: 12000},
+		{name: "birth date in the future is unknown age", pet: m.Pet{BirthDate: now.AddDate(0, 1, 0)}, schedules: schedules[1:3], wantAmount: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quote := quoteFee(&tt.pet, tt.schedules, now)
+
+			var gotID uint
+			if quote.ScheduleID != nil {
+				gotID = *quote.ScheduleID
+			}
+			if gotID != tt.wantID || quote.AmountCents != tt.wantAmount {
+				t.Errorf("quoteFee = schedule %d, %d cents, want schedule %d, %d cents", gotID, quote.AmountCents, tt.wantID, tt.wantAmount)
+			}
+			if (quote.AgeMonths == nil) != (tt.wantAge == nil) || quote.AgeMonths != nil && *quote.AgeMonths != *tt.wantAge {
+				t.Errorf("quoteFee age = %v, want %v", quote.AgeMonths, tt.wantAge)
+			}
+			if tt.wantID == 0 && quote.Currency != defaultCurrency {
+				t.Errorf("quoteFee currency = %s, want %s when no schedule applies", quote.Currency, defaultCurrency)
+			}
+		})
+	}
+}
+
+func TestPaymentEventChanges(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	payment := &m.Payment{ID: 7, AmountCents: 15000, Currency: "EUR"}
+	unpaid := []string{m.PaymentStatusPending, m.PaymentStatusFailed, m.PaymentStatusExpired}
+
+	tests := []struct {
+		name   string
+		event  payments.Event
+		from   []string
+		status string
+		paid   bool
+	}{
+		{name: "paid", event: payments.Event{Type: payments.EventPaid, AmountCents: 15000, Currency: "EUR"}, from: unpaid, status: m.PaymentStatusPaid, paid: true},
+		{name: "paid without currency", event: payments.Event{Type: payments.EventPaid, AmountCents: 15000}, from: unpaid, status: m.PaymentStatusPaid, paid: true},
+		{name: "paid less", event: payments.Event{Type: payments.EventPaid, AmountCents: 100, Currency: "EUR"}, from: unpaid, status: m.PaymentStatusReview},
+		{name: "paid more", event: payments.Event{Type: payments.EventPaid, AmountCents: 15001, Currency: "EUR"}, from: unpaid, status: m.PaymentStatusReview},
+		{name: "paid in another currency", event: payments.Event{Type: payments.EventPaid, AmountCents: 15000, Currency: "USD"}, from: unpaid, status: m.PaymentStatusReview},
+		{name: "failed", event: payments.Event{Type: payments.EventFailed}, from: []string{m.PaymentStatusPending}, status: m.PaymentStatusFailed},
+		{name: "expired", event: payments.Event{Type: payments.EventExpired}, from: []string{m.PaymentStatusPending}, status: m.PaymentStatusExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, changes := paymentEventChanges(payment, &tt.event, now)
+			if !reflect.DeepEqual(from, tt.from) {
+				t.Errorf("from statuses = %v, want %v", from, tt.from)
+			}
+			if changes["status"] != tt.status {
+				t.Errorf("status = %v, want %s", changes["status"], tt.status)
+			}
+			if _, ok := changes["paid_at"]; ok != tt.paid {
+				t.Errorf("paid_at set = %v, want %v", ok, tt.paid)
+			}
+			if tt.status == m.PaymentStatusReview && changes["failure_reason"] == "" {
+				t.Error("failure_reason is empty, want the charged amount")
+			}
+		})
+	}
+}
@@ -0,0 +1,220 @@
+// Package services provides business logic services for pet favourites.
+// This layer sits between handlers and DAOs, managing users' favourite pets,
+// annotating pet responses for the caller and notifying followers of status changes.
+package services
+
+import (
+	"backend/internal/db/dao"
+	"backend/internal/db/query"
+	m "backend/internal/models"
+	mailer "backend/internal/services/mail"
+	"fmt"
+	"log"
+)
+
+// favoriteNotifiedStatuses are the pet statuses that notify followers.
+var favoriteNotifiedStatuses = map[string]bool{
+	m.PetStatusReserved: true,
+	m.PetStatusAdopted:  true,
+}
+
+// ========================================
+// PET FAVORITE SERVICES
+// ========================================
+
+// AddFavorite adds a pet to the user's favourites.
+//
+// Business Logic:
+// - Validates pet existence
+// - Favouriting a pet twice has no effect
+//
+// Parameters:
+//   - userID: User adding the favourite
+//   - petID: Pet to favourite
+//
+// Returns:
+//   - error: Pet not found or database error
+func AddFavorite(userID uint, petID uint) error {
+	if _, err := dao.GetPetByID(petID, dao.AllOrganizations); err != nil {
+		return fmt.Errorf("mascota no encontrada: %v", err)
+	}
+
+	if err := dao.AddFavorite(userID, petID); err != nil {
+		return fmt.Errorf("error al añadir favorito: %v", err)
+	}
+
+	return nil
+}
+
+// RemoveFavorite removes a pet from the user's favourites.
+// Removing a pet that is not a favourite has no effect.
+//
+// Parameters:
+//   - userID: User removing the favourite
+//   - petID: Pet to remove
+//
+// Returns:
+//   - error: Database error or nil on success
+func RemoveFavorite(userID uint, petID uint) error {
+	if err := dao.RemoveFavorite(userID, petID); err != nil {
+		return fmt.Errorf("error al eliminar favorito: %v", err)
+	}
+
+	return nil
+}
+
+// ListFavorites retrieves one page of the user's favourite pets.
+//
+// Parameters:
+//   - user: Current user
+//   - params: Validated list query (see NewPetListQuery)
+//
+// Returns:
+//   - *query.Page[m.SimplifiedPet]: Requested page of favourite pets
+//   - error: Database error or nil on success
+func ListFavorites(user *m.NonValidatedUser, params *query.Params) (*query.Page[m.SimplifiedPet], error) {
+	pets, err := dao.GetFavoritePets(user.ID, params)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener favoritos: %v", err)
+	}
+
+	for i := range pets.Items {
+		if pets.Items[i].PrimaryPhoto != nil {
+			fillPhotoURL(pets.Items[i].PrimaryPhoto)
+		}
+	}
+
+	if err := annotateFavorites(user, simplifiedPetRefs(pets.Items)); err != nil {
+		return nil, err
+	}
+
+	return pets, nil
+}
+
+// NotifyFavoriteStatusChange emails the followers of a pet if it is now reserved or adopted.
+// The adopter is not notified. Emails use the sender identity of the pet's organisation.
+// Runs in the background; failures are logged.
+//
+// Parameters:
+//   - petID: Pet whose status just changed
+func NotifyFavoriteStatusChange(petID uint) {
+	go func() {
+		pet, err := dao.GetPetByID(petID, dao.AllOrganizations)
+		if err != nil {
+			log.Printf("could not load pet %d to notify followers: %v", petID, err)
+			return
+		}
+
+		if !favoriteNotifiedStatuses[pet.Status] {
+			return
+		}
+
+		followers, err := dao.GetPetFollowers(pet.ID)
+		if err != nil {
+			log.Printf("could not load followers of pet %d: %v", pet.ID, err)
+			return
+		}
+
+		sender := organizationSender(pet.OrganizationID)
+		for _, follower := range followers {
+			if follower.ID == pet.AdoptUserID {
+				continue
+			}
+
+			petURL := fmt.Sprintf("%s/pets/%d", frontendURL, pet.ID)
+			email, err := mailer.ComposeFavoriteStatusChange(follower.Email, follower.Locale, mailer.FavoriteStatusData{
+				UserName: follower.Name,
+				PetName:  pet.Name,
+				Status:   pet.Status,
+				PetURL:   petURL,
+			}, sender)
+			if err == nil {
+				err = notify(notification{UserID: &follower.ID, Type: m.NotificationFavorites, URL: petURL, Email: email})
+			}
+			if err != nil {
+				log.Printf("could not notify user %d about pet %d: %v", follower.ID, pet.ID, err)
+			}
+		}
+	}()
+}
+
+// ========================================
+// PET FAVORITE HELPERS
+// ========================================
+
+// annotateFavorites sets the favourited flag of each pet for the viewer and,
+// for staff, the favourite count. Anonymous viewers get no annotations.
+func annotateFavorites(viewer *m.NonValidatedUser, pets []*m.SimplifiedPet) error {
+	if viewer == nil || len(pets) == 0 {
+		return nil
+	}
+
+	ids := make([]uint, len(pets))
+	for i, pet := range pets {
+		ids[i] = pet.ID
+	}
+
+	favorited, counts, err := favoriteInfo(viewer, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, pet := range pets {
+		pet.Favorited = favorited[pet.ID]
+		if counts != nil {
+			count := counts[pet.ID]
+			pet.FavoriteCount = &count
+		}
+	}
+
+	return nil
+}
+
+// annotatePetFavorites is annotateFavorites for a single detailed pet.
+func annotatePetFavorites(viewer *m.NonValidatedUser, pet *m.Pet) error {
+	if viewer == nil {
+		return nil
+	}
+
+	favorited, counts, err := favoriteInfo(viewer, []uint{pet.ID})
+	if err != nil {
+		return err
+	}
+
+	pet.Favorited = favorited[pet.ID]
+	if counts != nil {
+		count := counts[pet.ID]
+		pet.FavoriteCount = &count
+	}
+
+	return nil
+}
+
+// favoriteInfo loads the viewer's favourites among petIDs and, for staff only, their counts.
+func favoriteInfo(viewer *m.NonValidatedUser, petIDs []uint) (map[uint]bool, map[uint]int64, error) {
+	favorited, err := dao.GetFavoritedPetIDs(viewer.ID, petIDs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error al obtener favoritos: %v", err)
+	}
+
+	if !viewer.IsStaff() {
+		return favorited, nil, nil
+	}
+
+	counts, err := dao.CountFavorites(petIDs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error al contar favoritos: %v", err)
+	}
+
+	return favorited, counts, nil
+}
+
+// simplifiedPetRefs returns pointers to the pets of a slice so they can be annotated in place.
+func simplifiedPetRefs(pets []m.SimplifiedPet) []*m.SimplifiedPet {
+	refs := make([]*m.SimplifiedPet, len(pets))
+	for i := range pets {
+		refs[i] = &pets[i]
+	}
+
+	return refs
+}
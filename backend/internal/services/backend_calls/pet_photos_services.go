@@ -0,0 +1,346 @@
+// Package services provides business logic services for pet photo management.
+// This layer sits between handlers and DAOs, orchestrating image processing,
+// object storage and photo metadata persistence.
+package services
+
+import (
+	"backend/internal/db/dao"
+	m "backend/internal/models"
+	"backend/internal/services/imaging"
+	"backend/internal/services/security"
+	"backend/internal/services/storage"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+)
+
+// PhotoThumbnailSizes defines the generated thumbnail variants and the maximum
+// width/height in pixels of each one.
+var PhotoThumbnailSizes = []struct {
+	Name    string
+	MaxSide int
+}{
+	{Name: "small", MaxSide: 160},
+	{Name: "medium", MaxSide: 480},
+	{Name: "large", MaxSide: 1024},
+}
+
+// PhotoVariantOriginal is the variant name used to download the sanitized original image.
+const PhotoVariantOriginal = "original"
+
+// ========================================
+// PET PHOTO SERVICES
+// ========================================
+
+// ListPetPhotos retrieves the photos of a pet in display order with their download URLs.
+//
+// Parameters:
+//   - petID: Unique identifier of the pet
+//
+// Returns:
+//   - []m.PetPhoto: Photos ordered by position
+//   - error: Database error or pet not found error
+func ListPetPhotos(petID uint) ([]m.PetPhoto, error) {
+	if _, err := dao.GetPetByID(petID, dao.AllOrganizations); err != nil {
+		return nil, fmt.Errorf("mascota no encontrada: %v", err)
+	}
+
+	photos, err := dao.GetPetPhotos(petID)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener fotos: %v", err)
+	}
+
+	fillPhotoURLs(photos)
+
+	return photos, nil
+}
+
+// UploadPetPhoto processes and stores a new photo for a pet.
+//
+// Process:
+// 1. Processes and stores the image and its thumbnails (see storeImage)
+// 2. Persists the photo metadata (first photo becomes primary)
+//
+// Stored objects are removed again if persisting the metadata fails.
+//
+// Parameters:
+//   - petID: Unique identifier of the pet
+//   - orgID: Organisation the pet must belong to
+//   - data: Raw uploaded image bytes (content type already validated)
+//
+// Returns:
+//   - *m.PetPhoto: Created photo with download URLs
+//   - error: Processing error (wrapping imaging.ErrInvalidImage), storage or database error
+func UploadPetPhoto(petID uint, orgID uint, data []byte) (*m.PetPhoto, error) {
+	if _, err := findOrganizationPet(petID, orgID); err != nil {
+		return nil, err
+	}
+
+	storageKey := fmt.Sprintf("pets/%d/%s", petID, strings.ToLower(security.Generate2FA(20)))
+	original, err := storeImage(storageKey, data)
+	if err != nil {
+		return nil, err
+	}
+
+	photo := &m.PetPhoto{
+		PetID:       petID,
+		StorageKey:  storageKey,
+		ContentType: original.ContentType,
+		Extension:   original.Extension,
+		Size:        int64(len(original.Data)),
+		Width:       original.Width,
+		Height:      original.Height,
+	}
+
+	// Persist metadata
+	if err := dao.CreatePetPhoto(photo); err != nil {
+		deleteStoredImage(storageKey, original.Extension)
+		return nil, fmt.Errorf("error al registrar foto: %v", err)
+	}
+
+	fillPhotoURL(photo)
+
+	return photo, nil
+}
+
+// DeletePetPhoto removes a photo record and all of its stored variants.
+// Storage failures are logged but do not fail the operation, since the photo
+// is no longer reachable once its record is gone.
+//
+// Parameters:
+//   - petID: Unique identifier of the pet
+//   - orgID: Organisation the pet must belong to
+//   - photoID: Unique identifier of the photo
+//
+// Returns:
+//   - error: Database error, pet or photo not found error
+func DeletePetPhoto(petID uint, orgID uint, photoID uint) error {
+	if _, err := findOrganizationPet(petID, orgID); err != nil {
+		return err
+	}
+
+	photo, err := dao.GetPetPhoto(petID, photoID)
+	if err != nil {
+		return fmt.Errorf("foto no encontrada: %v", err)
+	}
+
+	if err := dao.DeletePetPhoto(petID, photoID); err != nil {
+		return fmt.Errorf("error al eliminar foto: %v", err)
+	}
+
+	deleteStoredImage(photo.StorageKey, photo.Extension)
+
+	return nil
+}
+
+// ReorderPetPhotos changes the display order of a pet's photos.
+//
+// Parameters:
+//   - petID: Unique identifier of the pet
+//   - orgID: Organisation the pet must belong to
+//   - photoIDs: Every photo ID of the pet in the desired order
+//
+// Returns:
+//   - []m.PetPhoto: Photos in their new order
+//   - error: Validation, pet not found or database error
+func ReorderPetPhotos(petID uint, orgID uint, photoIDs []uint) ([]m.PetPhoto, error) {
+	if _, err := findOrganizationPet(petID, orgID); err != nil {
+		return nil, err
+	}
+
+	if err := dao.ReorderPetPhotos(petID, photoIDs); err != nil {
+		return nil, fmt.Errorf("error al ordenar fotos: %v", err)
+	}
+
+	return ListPetPhotos(petID)
+}
+
+// SetPrimaryPetPhoto marks a photo as the main photo of its pet.
+//
+// Parameters:
+//   - petID: Unique identifier of the pet
+//   - orgID: Organisation the pet must belong to
+//   - photoID: Unique identifier of the photo
+//
+// Returns:
+//   - []m.PetPhoto: Photos of the pet after the change
+//   - error: Database error, pet or photo not found error
+func SetPrimaryPetPhoto(petID uint, orgID uint, photoID uint) ([]m.PetPhoto, error) {
+	if _, err := findOrganizationPet(petID, orgID); err != nil {
+		return nil, err
+	}
+
+	if err := dao.SetPrimaryPetPhoto(petID, photoID); err != nil {
+		return nil, fmt.Errorf("error al marcar foto principal: %v", err)
+	}
+
+	return ListPetPhotos(petID)
+}
+
+// OpenPetPhoto opens a stored photo variant for download.
+//
+// Parameters:
+//   - petID: Unique identifier of the pet
+//   - photoID: Unique identifier of the photo
+//   - variant: "original" or one of the names in PhotoThumbnailSizes
+//
+// Returns:
+//   - io.ReadCloser: Image content (caller must close it)
+//   - string: MIME type of the content
+//   - error: Unknown variant, photo not found or storage error
+func OpenPetPhoto(petID uint, photoID uint, variant string) (io.ReadCloser, string, error) {
+	if !isPhotoVariant(variant) {
+		return nil, "", fmt.Errorf("variante de foto desconocida: %s", variant)
+	}
+
+	photo, err := dao.GetPetPhoto(petID, photoID)
+	if err != nil {
+		return nil, "", fmt.Errorf("foto no encontrada: %v", err)
+	}
+
+	return openStoredImage(photo.StorageKey, photo.Extension, photo.ContentType, variant)
+}
+
+// ========================================
+// PET PHOTO HELPERS
+// ========================================
+
+// photoVariants returns the names of every stored variant of a photo.
+func photoVariants() []string {
+	variants := []string{PhotoVariantOriginal}
+	for _, size := range PhotoThumbnailSizes {
+		variants = append(variants, size.Name)
+	}
+
+	return variants
+}
+
+// isPhotoVariant reports whether variant is a known photo variant name.
+func isPhotoVariant(variant string) bool {
+	for _, name := range photoVariants() {
+		if name == variant {
+			return true
+		}
+	}
+
+	return false
+}
+
+// imageObjectKey returns the storage key of an image variant.
+// Originals keep their extension; thumbnails are always JPEG.
+func imageObjectKey(storageKey string, extension string, variant string) string {
+	if variant == PhotoVariantOriginal {
+		return storageKey + "/original." + extension
+	}
+
+	return storageKey + "/" + variant + ".jpg"
+}
+
+// storeImage decodes an uploaded image and stores its variants under storageKey.
+//
+// Process:
+// 1. Decodes the image (rejecting oversized or corrupt files)
+// 2. Re-encodes the original, which strips EXIF and other metadata
+// 3. Generates every thumbnail size defined in PhotoThumbnailSizes
+// 4. Stores all variants in the storage backend
+//
+// Stored objects are removed again if any step fails.
+//
+// Returns:
+//   - *imaging.Processed: Stored original (content type, extension, size and dimensions)
+//   - error: Processing error (wrapping imaging.ErrInvalidImage) or storage error
+func storeImage(storageKey string, data []byte) (*imaging.Processed, error) {
+	img, err := imaging.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	original, err := imaging.Sanitize(img)
+	if err != nil {
+		return nil, fmt.Errorf("error al procesar imagen: %v", err)
+	}
+
+	ctx := context.Background()
+	store := storage.Open()
+	var stored []string
+
+	cleanup := func() {
+		for _, key := range stored {
+			if err := store.Delete(ctx, key); err != nil {
+				log.Printf("could not remove stored photo %s: %v", key, err)
+			}
+		}
+	}
+
+	// Store sanitized original
+	originalKey := imageObjectKey(storageKey, original.Extension, PhotoVariantOriginal)
+	if err := store.Put(ctx, originalKey, original.Data, original.ContentType); err != nil {
+		return nil, fmt.Errorf("error al guardar imagen: %v", err)
+	}
+	stored = append(stored, originalKey)
+
+	// Generate and store thumbnails
+	for _, size := range PhotoThumbnailSizes {
+		thumbnail, err := imaging.Thumbnail(img, size.MaxSide)
+		if err != nil {
+			cleanup()
+			return nil, fmt.Errorf("error al generar miniatura %s: %v", size.Name, err)
+		}
+
+		key := imageObjectKey(storageKey, original.Extension, size.Name)
+		if err := store.Put(ctx, key, thumbnail.Data, thumbnail.ContentType); err != nil {
+			cleanup()
+			return nil, fmt.Errorf("error al guardar miniatura %s: %v", size.Name, err)
+		}
+		stored = append(stored, key)
+	}
+
+	return original, nil
+}
+
+// deleteStoredImage removes every stored variant of an image.
+// Storage failures are logged, since the image is no longer referenced.
+func deleteStoredImage(storageKey string, extension string) {
+	ctx := context.Background()
+	store := storage.Open()
+	for _, variant := range photoVariants() {
+		key := imageObjectKey(storageKey, extension, variant)
+		if err := store.Delete(ctx, key); err != nil {
+			log.Printf("could not remove stored photo %s: %v", key, err)
+		}
+	}
+}
+
+// openStoredImage opens a stored image variant and returns it with its MIME type.
+func openStoredImage(storageKey string, extension string, contentType string, variant string) (io.ReadCloser, string, error) {
+	content, err := storage.Open().Get(context.Background(), imageObjectKey(storageKey, extension, variant))
+	if err != nil {
+		return nil, "", fmt.Errorf("error al leer foto: %v", err)
+	}
+
+	if variant != PhotoVariantOriginal {
+		contentType = "image/jpeg"
+	}
+
+	return content, contentType, nil
+}
+
+// fillPhotoURL computes the download URLs of a photo and its thumbnails.
+func fillPhotoURL(photo *m.PetPhoto) {
+	base := fmt.Sprintf("/api/pets/%d/photos/%d/", photo.PetID, photo.ID)
+
+	photo.URL = base + PhotoVariantOriginal
+	photo.Thumbnails = make(map[string]string, len(PhotoThumbnailSizes))
+	for _, size := range PhotoThumbnailSizes {
+		photo.Thumbnails[size.Name] = base + size.Name
+	}
+}
+
+// fillPhotoURLs computes the download URLs of every photo in the slice.
+func fillPhotoURLs(photos []m.PetPhoto) {
+	for i := range photos {
+		fillPhotoURL(&photos[i])
+	}
+}
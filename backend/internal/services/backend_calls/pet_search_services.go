@@ -0,0 +1,172 @@
+// Package services provides business logic services for full-text pet search.
+// This layer sits between handlers and DAOs, analysing the query text,
+// correcting typos against the indexed vocabulary and building highlighted results.
+package services
+
+import (
+	"backend/internal/db/dao"
+	"backend/internal/db/query"
+	m "backend/internal/models"
+	"backend/internal/services/search"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// ErrNoSearchTerms is returned when a search query only contains stopwords or punctuation.
+var ErrNoSearchTerms = errors.New("la búsqueda no contiene términos válidos")
+
+// petVocabularyTTL is how long the typo-tolerance vocabulary is reused before being rebuilt.
+// Pet changes also invalidate it immediately.
+const petVocabularyTTL = 10 * time.Minute
+
+// searchSnippetWords is the maximum number of words in description snippets.
+const searchSnippetWords = 30
+
+// petVocabulary caches the vocabulary of indexed pet words.
+var petVocabulary struct {
+	sync.Mutex
+	vocabulary *search.Vocabulary
+	loaded     time.Time
+}
+
+// ========================================
+// PET SEARCH SERVICES
+// ========================================
+
+// NewPetSearchQuery parses and validates the pagination, sorting and filter
+// parameters of a pet search request against dao.PetSearchSchema.
+//
+// Business Logic:
+// - Accepts every pet list filter and sort field plus "relevance" (default)
+// - Only offset pagination is supported, since relevance is not a stored column
+//
+// Parameters:
+//   - path: Request path used to build next/prev links
+//   - values: Raw query parameters (q is read separately)
+//
+// Returns:
+//   - *query.Params: Validated list query
+//   - error: Validation error describing the invalid parameter
+func NewPetSearchQuery(path string, values url.Values) (*query.Params, error) {
+	params, err := query.Parse(path, values, dao.PetSearchSchema)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.CursorMode() {
+		return nil, fmt.Errorf("la búsqueda no admite paginación por cursor, usa page")
+	}
+
+	return params, nil
+}
+
+// SearchPets performs a full-text search over pet names, breeds and descriptions.
+//
+// Process:
+// 1. Analyses the query (normalisation, stopwords, Spanish stemming)
+// 2. Adds typo corrections for words that match nothing in the vocabulary
+// 3. Runs the ranked full-text query combined with the structured filters
+// 4. Builds highlighted name, breed and description snippets for every result
+// 5. Flags the viewer's favourites (and favourite counts for staff)
+//
+// Parameters:
+//   - q: Search text typed by the user
+//   - params: Validated list query (see NewPetSearchQuery)
+//   - viewer: Current user, or nil for anonymous requests
+//
+// Returns:
+//   - *query.Page[m.PetSearchResult]: Requested page of results with total count and links
+//   - error: ErrNoSearchTerms or database error
+func SearchPets(q string, params *query.Params, viewer *m.NonValidatedUser) (*query.Page[m.PetSearchResult], error) {
+	terms := search.Parse(q)
+	if len(terms) == 0 {
+		return nil, ErrNoSearchTerms
+	}
+
+	// Typo tolerance is best effort: search without it if the vocabulary cannot be loaded
+	if vocabulary, err := loadPetVocabulary(); err != nil {
+		log.Printf("could not load pet search vocabulary: %v", err)
+	} else {
+		terms = vocabulary.Expand(terms)
+	}
+
+	hits, err := dao.SearchPets(terms, params)
+	if err != nil {
+		return nil, fmt.Errorf("error al buscar mascotas: %v", err)
+	}
+
+	var prefixes []string
+	for _, term := range terms {
+		prefixes = append(prefixes, term.Prefixes()...)
+	}
+
+	results := query.MapPage(hits, func(hit dao.PetSearchHit) m.PetSearchResult {
+		return newPetSearchResult(hit, prefixes)
+	})
+
+	pets := make([]*m.SimplifiedPet, len(results.Items))
+	for i := range results.Items {
+		pets[i] = &results.Items[i].SimplifiedPet
+	}
+
+	if err := annotateFavorites(viewer, pets); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// ========================================
+// PET SEARCH HELPERS
+// ========================================
+
+// newPetSearchResult builds a search result with highlights from a matched pet.
+func newPetSearchResult(hit dao.PetSearchHit, prefixes []string) m.PetSearchResult {
+	pet := hit.Pet
+	if pet.PrimaryPhoto != nil {
+		fillPhotoURL(pet.PrimaryPhoto)
+	}
+
+	return m.PetSearchResult{
+		SimplifiedPet: pet,
+		Relevance:     hit.Relevance,
+		Highlights: m.PetSearchHighlights{
+			Name:        search.Highlight(pet.Name, prefixes),
+			Breed:       search.Highlight(pet.Breed, prefixes),
+			Description: search.Snippet(hit.Description, prefixes, searchSnippetWords),
+		},
+	}
+}
+
+// loadPetVocabulary returns the cached vocabulary, rebuilding it when it is missing or expired.
+func loadPetVocabulary() (*search.Vocabulary, error) {
+	petVocabulary.Lock()
+	defer petVocabulary.Unlock()
+
+	if petVocabulary.vocabulary != nil && time.Since(petVocabulary.loaded) < petVocabularyTTL {
+		return petVocabulary.vocabulary, nil
+	}
+
+	texts, err := dao.GetPetSearchTexts()
+	if err != nil {
+		return nil, err
+	}
+
+	petVocabulary.vocabulary = search.NewVocabulary(texts...)
+	petVocabulary.loaded = time.Now()
+
+	return petVocabulary.vocabulary, nil
+}
+
+// invalidatePetVocabulary forces the vocabulary to be rebuilt on the next search.
+// Called whenever pet names, breeds or descriptions may have changed.
+func invalidatePetVocabulary() {
+	petVocabulary.Lock()
+	defer petVocabulary.Unlock()
+
+	petVocabulary.vocabulary = nil
+}
@@ -0,0 +1,44 @@
+package services
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestNewPetSearchQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		wantErr string
+	}{
+		{name: "relevance by default", query: "q=labrador"},
+		{name: "offset pagination", query: "q=labrador&page=2&page_size=10"},
+		{name: "pet list sort", query: "q=labrador&sort=-crt_date"},
+		{name: "first cursor page", query: "q=labrador&cursor=", wantErr: "no admite paginación por cursor"},
+		{name: "valid cursor token", query: "q=labrador&cursor=eyJ2IjpbMSwyXX0", wantErr: "no admite paginación por cursor"},
+		{name: "cursor with page", query: "q=labrador&page=2&cursor=", wantErr: "no admite paginación por cursor"},
+		{name: "unknown filter", query: "q=labrador&adopt_user_id=1", wantErr: "filtro no permitido"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = NewPetSearchQuery("/api/pets/search", values)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("NewPetSearchQuery(%q) error = %v", tt.query, err)
+				}
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("NewPetSearchQuery(%q) error = %v, want %q", tt.query, err, tt.wantErr)
+			}
+		})
+	}
+}
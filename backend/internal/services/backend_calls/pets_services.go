@@ -5,33 +5,65 @@ package services
 
 import (
 	"backend/internal/db/dao"
+	"backend/internal/db/query"
 	m "backend/internal/models"
 	"fmt"
+	"net/url"
 )
 
 // ========================================
 // PET MANAGEMENT SERVICES
 // ========================================
 
-// ListAllPets retrieves all pets from the database.
+// NewPetListQuery parses and validates the pagination, sorting and filter
+// parameters of a pet list request against dao.PetListSchema.
+//
+// Parameters:
+//   - path: Request path used to build next/prev links
+//   - values: Raw query parameters
+//
+// Returns:
+//   - *query.Params: Validated list query
+//   - error: Validation error describing the invalid parameter
+func NewPetListQuery(path string, values url.Values) (*query.Params, error) {
+	return query.Parse(path, values, dao.PetListSchema)
+}
+
+// ListAllPets retrieves one page of pets from the database.
 // Returns simplified pet data suitable for listing and overview purposes.
 //
 // Business Logic:
-// - Retrieves all pets regardless of status
+// - Applies the requested filters, sorting and pagination
 // - Returns simplified data to reduce payload size
 // - Used for pet browsing and administrative overviews
+// - Flags the viewer's favourites; staff also get favourite counts
+//
+// Parameters:
+//   - params: Validated list query (see NewPetListQuery)
+//   - viewer: Current user, or nil for anonymous requests
 //
 // Returns:
-//   - *[]m.SimplifiedPet: Slice of all pets with essential information
+//   - *query.Page[m.SimplifiedPet]: Requested page of pets with total count and links
 //   - error: Database error or nil on success
-func ListAllPets() (*[]m.SimplifiedPet, error) {
-	// Retrieve all pets from database
-	pets, err := dao.GetAllPets()
+func ListAllPets(params *query.Params, viewer *m.NonValidatedUser) (*query.Page[m.SimplifiedPet], error) {
+	// Retrieve requested page of pets from database (every organisation, narrowed by the organization filter)
+	pets, err := dao.GetAllPets(params, dao.AllOrganizations)
 	if err != nil {
 		return nil, fmt.Errorf("error al obtener mascotas: %v", err)
 	}
 
-	return &pets, nil
+	// Resolve photo download URLs for list thumbnails
+	for i := range pets.Items {
+		if pets.Items[i].PrimaryPhoto != nil {
+			fillPhotoURL(pets.Items[i].PrimaryPhoto)
+		}
+	}
+
+	if err := annotateFavorites(viewer, simplifiedPetRefs(pets.Items)); err != nil {
+		return nil, err
+	}
+
+	return pets, nil
 }
 
 // GetPetByID retrieves a specific pet by its unique identifier.
@@ -41,20 +73,37 @@ func ListAllPets() (*[]m.SimplifiedPet, error) {
 // - Validates pet existence in database
 // - Returns full pet data for detailed views
 // - Used for pet profiles and detailed information
+// - Flags whether the viewer favourited the pet; staff also get the favourite count
+// - Includes the public sponsorship total of the pet
 //
 // Parameters:
 //   - id: Unique identifier of the pet to retrieve
+//   - viewer: Current user, or nil for anonymous requests
 //
 // Returns:
 //   - *m.Pet: Complete pet data with all information
 //   - error: Database error or pet not found error
-func GetPetByID(id uint) (*m.Pet, error) {
+func GetPetByID(id uint, viewer *m.NonValidatedUser) (*m.Pet, error) {
 	// Retrieve specific pet from database
-	pet, err := dao.GetPetByID(id)
+	pet, err := dao.GetPetByID(id, dao.AllOrganizations)
 	if err != nil {
 		return nil, fmt.Errorf("mascota no encontrada: %v", err)
 	}
 
+	// Resolve photo download URLs
+	fillPhotoURLs(pet.Photos)
+
+	if err := annotatePetFavorites(viewer, pet); err != nil {
+		return nil, err
+	}
+
+	// Public sponsorship total of the pet
+	sponsorship, err := GetPetSponsorship(pet.ID)
+	if err != nil {
+		return nil, err
+	}
+	pet.Sponsorship = sponsorship
+
 	return pet, nil
 }
 
@@ -66,13 +115,23 @@ func GetPetByID(id uint) (*m.Pet, error) {
 // - Assigns creation timestamps
 // - Updates the input pet object with generated ID
 // - Ensures data consistency
+// - Queues saved search alerts for available pets
+// - Matches the pet against approved lost and found reports
+// - Normalises the microchip number and checks it is not assigned to another pet
 //
 // Parameters:
-//   - pet: Pet data to be created (will be updated with generated ID)
+//   - pet: Pet data to be created, including its organisation (will be updated with generated ID)
 //
 // Returns:
-//   - error: Creation error or nil on success
+//   - error: ErrInvalidMicrochip, ErrMicrochipInUse, creation error or nil on success
 func CreatePet(pet *m.Pet) error {
+	// Keep status and adoption flag consistent
+	normalizePetStatus(pet)
+
+	if err := normalizePetMicrochip(pet); err != nil {
+		return err
+	}
+
 	// Create pet in database
 	created, err := dao.CreatePet(pet)
 	if err != nil {
@@ -87,6 +146,8 @@ func CreatePet(pet *m.Pet) error {
 	// Update input object with created data (including ID)
 	*pet = *created
 
+	petCreated(pet)
+
 	return nil
 }
 
@@ -94,23 +155,44 @@ func CreatePet(pet *m.Pet) error {
 // Handles pet data modification with proper validation.
 //
 // Business Logic:
-// - Validates pet existence before update
+// - Validates pet existence within the organisation before update
 // - Preserves data integrity during updates
 // - Updates modification timestamps
 // - Ensures referential integrity
+// - Queues saved search alerts when the pet moves to available
+// - Notifies followers when the pet becomes reserved or adopted
+// - Matches the pet against approved lost and found reports
+// - Normalises the microchip number and checks it is not assigned to another pet
+// - Ends the pet's foster placement and kennel stay when it is adopted
+// - Sends the pet.updated webhook event, and pet.adopted when the pet becomes adopted
 //
 // Parameters:
-//   - pet: Pet data with updated information (must include valid ID)
+//   - pet: Pet data with updated information (must include valid ID and OrganizationID)
 //
 // Returns:
-//   - error: Update error or nil on success
+//   - error: ErrInvalidMicrochip, ErrMicrochipInUse, update error or nil on success
 func UpdatePet(pet *m.Pet) error {
+	// Keep status and adoption flag consistent
+	normalizePetStatus(pet)
+
+	if err := normalizePetMicrochip(pet); err != nil {
+		return err
+	}
+
+	// Remember the previous status to detect pets moving to available
+	previous, err := dao.GetPetByID(pet.ID, pet.OrganizationID)
+	if err != nil {
+		return fmt.Errorf("error al actualizar mascota: %v", err)
+	}
+
 	// Update pet in database
-	err := dao.UpdatePet(pet)
+	err = dao.UpdatePet(pet)
 	if err != nil {
 		return fmt.Errorf("error al actualizar mascota: %v", err)
 	}
 
+	petUpdated(pet, previous.Status)
+
 	return nil
 }
 
@@ -118,21 +200,122 @@ func UpdatePet(pet *m.Pet) error {
 // Handles pet deletion with proper constraint checking.
 //
 // Business Logic:
-// - Validates pet existence before deletion
+// - Validates pet existence within the organisation before deletion
 // - Checks for adoption records or other constraints
 // - May perform soft deletion to preserve data integrity
 // - Ensures referential integrity is maintained
+// - Sends the pet.deleted webhook event
 //
 // Parameters:
 //   - id: Unique identifier of the pet to delete
+//   - orgID: Organisation the pet must belong to
 //
 // Returns:
 //   - error: Deletion error or nil on success
-func DeletePet(id uint) error {
+func DeletePet(id uint, orgID uint) error {
 	// Delete pet from database
-	if err := dao.DeletePetByID(id); err != nil {
+	if err := dao.DeletePetByID(id, orgID); err != nil {
 		return fmt.Errorf("error al eliminar mascota: %v", err)
 	}
 
+	invalidatePetVocabulary()
+
+	emitWebhookEvent(m.WebhookEventPetDeleted, orgID, m.WebhookPetData{ID: id, OrganizationID: orgID})
+
 	return nil
 }
+
+// ========================================
+// PET HELPERS
+// ========================================
+
+// petUpdated runs the follow-up work of a pet whose changes were saved.
+// Shared by UpdatePet and FinalizeAdoption, which marks the pet adopted in its own transaction.
+func petUpdated(pet *m.Pet, previousStatus string) {
+	invalidatePetVocabulary()
+
+	if previousStatus != m.PetStatusAvailable {
+		QueueSearchAlerts(pet)
+	}
+
+	if previousStatus != pet.Status {
+		NotifyFavoriteStatusChange(pet.ID)
+		publishPetStatusChanged(pet, previousStatus)
+	}
+
+	// Adopted pets leave their foster home and kennel
+	if previousStatus != m.PetStatusAdopted && pet.Status == m.PetStatusAdopted {
+		EndFosterPlacementForPet(pet.ID)
+		EndPetLocationForPet(pet.ID, "adoptada")
+	}
+
+	// Returned pets can be adopted again
+	if previousStatus == m.PetStatusAdopted && pet.Status != m.PetStatusAdopted {
+		EndActiveAdoptionForPet(pet.ID)
+	}
+
+	MatchPetToLostFoundReports(pet.ID)
+
+	emitWebhookEvent(m.WebhookEventPetUpdated, pet.OrganizationID, webhookPetData(pet))
+	if previousStatus != m.PetStatusAdopted && pet.Status == m.PetStatusAdopted {
+		emitWebhookEvent(m.WebhookEventPetAdopted, pet.OrganizationID, webhookPetData(pet))
+	}
+}
+
+// petCreated runs the follow-up work of a newly registered pet.
+// Shared by CreatePet and CreateIntake.
+func petCreated(pet *m.Pet) {
+	// New words become available for search typo correction
+	invalidatePetVocabulary()
+
+	// Alert users whose saved searches match the new pet
+	QueueSearchAlerts(pet)
+
+	// Check whether the pet matches a lost or found report
+	MatchPetToLostFoundReports(pet.ID)
+
+	// Push the new pet to connected clients
+	publishPetCreated(pet)
+
+	// Notify partner integrations
+	emitWebhookEvent(m.WebhookEventPetCreated, pet.OrganizationID, webhookPetData(pet))
+}
+
+// normalizePetStatus keeps Status and IsAdopted consistent.
+// An empty status is derived from IsAdopted, and IsAdopted always mirrors the adopted status.
+func normalizePetStatus(pet *m.Pet) {
+	if pet.Status == "" {
+		pet.Status = m.PetStatusAvailable
+		if pet.IsAdopted {
+			pet.Status = m.PetStatusAdopted
+		}
+	}
+
+	pet.IsAdopted = pet.Status == m.PetStatusAdopted
+}
+
+// findOrganizationPet loads a pet, ensuring it belongs to the organisation
+// (any organisation for dao.AllOrganizations).
+func findOrganizationPet(petID uint, orgID uint) (*m.Pet, error) {
+	pet, err := dao.GetPetByID(petID, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("mascota no encontrada: %v", err)
+	}
+
+	return pet, nil
+}
+
+// IsValidPetStatus reports whether status is empty or one of m.PetStatuses.
+func IsValidPetStatus(status string) bool {
+	if status == "" {
+		return true
+	}
+
+	for _, valid := range m.PetStatuses {
+		if status == valid {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,397 @@
+// Package services provides business logic services for saved searches and their email alerts.
+// This layer sits between handlers and DAOs, matching newly available pets against
+// saved searches and sending the daily digest emails.
+package services
+
+import (
+	"backend/internal/db/dao"
+	m "backend/internal/models"
+	mailer "backend/internal/services/mail"
+	"backend/internal/services/scheduler"
+	"backend/internal/services/search"
+	"backend/internal/services/security"
+	"backend/internal/utils/env"
+	"fmt"
+	"log"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxSavedSearches is the maximum number of saved searches per user.
+const MaxSavedSearches = 20
+
+// ErrTooManySavedSearches is returned when a user reaches MaxSavedSearches.
+var ErrTooManySavedSearches = fmt.Errorf("no se pueden guardar más de %d búsquedas", MaxSavedSearches)
+
+// SearchAlertsJob is the scheduler job name of the saved search digests.
+const SearchAlertsJob = "search-alerts"
+
+// searchAlertMinGap is the minimum time between two digests sent to the same user.
+const searchAlertMinGap = 24 * time.Hour
+
+// searchAlertUnsubscribePurpose binds unsubscribe tokens to this use.
+const searchAlertUnsubscribePurpose = "search-alerts-unsubscribe"
+
+var (
+	// searchAlertInterval is how often pending matches are checked (SEARCH_ALERT_INTERVAL, default 1h).
+	searchAlertInterval = env.GetDuration("SEARCH_ALERT_INTERVAL", time.Hour)
+
+	// publicBaseURL is the externally reachable URL of this API, used in email links.
+	publicBaseURL = strings.TrimSuffix(env.Get("PUBLIC_BASE_URL", "http://localhost:8080"), "/")
+
+	// frontendURL is the URL of the web application, used to link pets in emails.
+	frontendURL = strings.TrimSuffix(env.Get("FRONTEND_URL", "http://localhost:4200"), "/")
+)
+
+// ========================================
+// SAVED SEARCH SERVICES
+// ========================================
+
+// ListSavedSearches retrieves every saved search of a user.
+//
+// Parameters:
+//   - userID: Owner of the saved searches
+//
+// Returns:
+//   - []m.SavedSearch: Saved searches, newest first
+//   - error: Database error or nil on success
+func ListSavedSearches(userID uint) ([]m.SavedSearch, error) {
+	searches, err := dao.GetSavedSearchesByUser(userID)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener búsquedas guardadas: %v", err)
+	}
+
+	return searches, nil
+}
+
+// CreateSavedSearch stores a new saved search for its owner.
+//
+// Business Logic:
+// - Enforces MaxSavedSearches per user
+// - Trims text criteria and derives a name from the criteria when none is given
+// - Alerts only cover pets that become available after the search is saved
+//
+// Parameters:
+//   - search: Saved search to create (UserID must be set; updated with ID)
+//
+// Returns:
+//   - error: ErrTooManySavedSearches or database error
+func CreateSavedSearch(search *m.SavedSearch) error {
+	count, err := dao.CountSavedSearches(search.UserID)
+	if err != nil {
+		return fmt.Errorf("error al crear búsqueda guardada: %v", err)
+	}
+
+	if count >= MaxSavedSearches {
+		return ErrTooManySavedSearches
+	}
+
+	normalizeSavedSearch(search)
+
+	if err := dao.CreateSavedSearch(search); err != nil {
+		return fmt.Errorf("error al crear búsqueda guardada: %v", err)
+	}
+
+	return nil
+}
+
+// UpdateSavedSearch replaces the criteria of a saved search owned by search.UserID.
+//
+// Parameters:
+//   - search: Saved search with updated data (ID and UserID must be set)
+//
+// Returns:
+//   - *m.SavedSearch: Updated saved search
+//   - error: Database error or saved search not found error
+func UpdateSavedSearch(search *m.SavedSearch) (*m.SavedSearch, error) {
+	normalizeSavedSearch(search)
+
+	if err := dao.UpdateSavedSearch(search); err != nil {
+		return nil, fmt.Errorf("error al actualizar búsqueda guardada: %v", err)
+	}
+
+	return dao.GetSavedSearch(search.UserID, search.ID)
+}
+
+// DeleteSavedSearch removes a saved search owned by the user.
+//
+// Parameters:
+//   - userID: Owner of the saved search
+//   - id: Unique identifier of the saved search
+//
+// Returns:
+//   - error: Database error or saved search not found error
+func DeleteSavedSearch(userID uint, id uint) error {
+	if err := dao.DeleteSavedSearch(userID, id); err != nil {
+		return fmt.Errorf("error al eliminar búsqueda guardada: %v", err)
+	}
+
+	return nil
+}
+
+// UnsubscribeSearchAlerts disables alerts from a signed unsubscribe link.
+// The token identifies the user and either one saved search or all of them.
+//
+// Parameters:
+//   - token: Token from the unsubscribe link
+//
+// Returns:
+//   - error: security.ErrInvalidToken or database error
+func UnsubscribeSearchAlerts(token string) error {
+	value, err := security.VerifyToken(searchAlertUnsubscribePurpose, token)
+	if err != nil {
+		return err
+	}
+
+	userPart, searchPart, ok := strings.Cut(value, ":")
+	userID, userErr := strconv.ParseUint(userPart, 10, 64)
+	searchID, searchErr := strconv.ParseUint(searchPart, 10, 64)
+	if !ok || userErr != nil || searchErr != nil {
+		return security.ErrInvalidToken
+	}
+
+	if err := dao.DisableSearchAlerts(uint(userID), uint(searchID)); err != nil {
+		return fmt.Errorf("error al desactivar alertas: %v", err)
+	}
+
+	return nil
+}
+
+// ========================================
+// SEARCH ALERT SERVICES
+// ========================================
+
+// QueueSearchAlerts queues a pet for the digest of every saved search it matches.
+// Called when a pet is created as available or moves to available.
+// Failures are logged and never block the pet operation.
+//
+// Parameters:
+//   - pet: Pet that just became available
+func QueueSearchAlerts(pet *m.Pet) {
+	if pet.Status != m.PetStatusAvailable {
+		return
+	}
+
+	candidates, err := dao.GetAlertSearchesForSpecies(pet.Species)
+	if err != nil {
+		log.Printf("could not load saved searches for pet %d: %v", pet.ID, err)
+		return
+	}
+
+	now := time.Now()
+	var matches []m.SavedSearchMatch
+	for _, saved := range candidates {
+		if savedSearchMatches(saved, pet, now) {
+			matches = append(matches, m.SavedSearchMatch{SavedSearchID: saved.ID, UserID: saved.UserID, PetID: pet.ID})
+		}
+	}
+
+	if err := dao.QueueSearchMatches(matches); err != nil {
+		log.Printf("could not queue saved search matches for pet %d: %v", pet.ID, err)
+	}
+}
+
+// SendSearchAlertDigests emails every user with pending matches who has not
+// received a digest in the last 24 hours.
+//
+// Process:
+// 1. Selects users with pending matches and no digest since now - 24h
+// 2. Drops matches whose pet is no longer available or whose search has alerts disabled
+// 3. Sends one email per user, grouped by saved search, with unsubscribe links
+// 4. Marks the included matches as notified (failed emails are retried on the next run)
+//
+// Parameters:
+//   - now: Current time
+//
+// Returns:
+//   - int: Number of digests sent
+//   - error: Database error loading the users due for a digest
+func SendSearchAlertDigests(now time.Time) (int, error) {
+	userIDs, err := dao.GetUsersWithPendingMatches(now.Add(-searchAlertMinGap))
+	if err != nil {
+		return 0, fmt.Errorf("error al obtener usuarios con alertas pendientes: %v", err)
+	}
+
+	sent := 0
+	for _, userID := range userIDs {
+		ok, err := sendSearchAlertDigest(userID, now)
+		if err != nil {
+			log.Printf("could not send search alert digest to user %d: %v", userID, err)
+			continue
+		}
+		if ok {
+			sent++
+		}
+	}
+
+	return sent, nil
+}
+
+// runSearchAlertDigests is the scheduler entry point of the search-alerts job.
+// Dry runs only count the users due for a digest.
+func runSearchAlertDigests(now time.Time, dryRun bool) (scheduler.Result, error) {
+	if dryRun {
+		userIDs, err := dao.GetUsersWithPendingMatches(now.Add(-searchAlertMinGap))
+		if err != nil {
+			return scheduler.Result{}, err
+		}
+
+		return scheduler.Result{
+			Items:   len(userIDs),
+			Summary: fmt.Sprintf("se enviarían %d resúmenes", len(userIDs)),
+			Preview: userIDs,
+		}, nil
+	}
+
+	sent, err := SendSearchAlertDigests(now)
+	if err != nil {
+		return scheduler.Result{}, err
+	}
+
+	return scheduler.Result{Items: sent, Summary: fmt.Sprintf("%d resúmenes enviados", sent)}, nil
+}
+
+// ========================================
+// SAVED SEARCH HELPERS
+// ========================================
+
+// sendSearchAlertDigest builds and sends the digest of one user.
+// It reports false when every pending match was stale and nothing was sent.
+func sendSearchAlertDigest(userID uint, now time.Time) (bool, error) {
+	user, err := dao.GetUserByID(userID)
+	if err != nil {
+		return false, err
+	}
+
+	matches, err := dao.GetPendingMatches(userID)
+	if err != nil {
+		return false, err
+	}
+
+	var (
+		included []uint
+		stale    []uint
+		groups   []mailer.SearchAlertGroup
+		groupOf  = map[uint]int{}
+	)
+
+	for _, match := range matches {
+		if !match.SavedSearch.AlertsEnabled || match.Pet.Status != m.PetStatusAvailable {
+			stale = append(stale, match.ID)
+			continue
+		}
+
+		i, ok := groupOf[match.SavedSearchID]
+		if !ok {
+			i = len(groups)
+			groupOf[match.SavedSearchID] = i
+			groups = append(groups, mailer.SearchAlertGroup{
+				SearchName:     match.SavedSearch.Name,
+				UnsubscribeURL: searchAlertUnsubscribeURL(userID, match.SavedSearchID),
+			})
+		}
+
+		groups[i].Pets = append(groups[i].Pets, mailer.SearchAlertPet{
+			Name:    match.Pet.Name,
+			Species: match.Pet.Species,
+			Breed:   match.Pet.Breed,
+			URL:     fmt.Sprintf("%s/pets/%d", frontendURL, match.PetID),
+		})
+		included = append(included, match.ID)
+	}
+
+	if err := dao.DeleteSearchMatches(stale); err != nil {
+		return false, err
+	}
+
+	if len(included) == 0 {
+		return false, nil
+	}
+
+	email, err := mailer.ComposeSearchAlertDigest(user.Email, user.Locale, mailer.SearchAlertData{
+		UserName:          user.Name,
+		Groups:            groups,
+		UnsubscribeAllURL: searchAlertUnsubscribeURL(userID, 0),
+	})
+	if err != nil {
+		return false, err
+	}
+
+	err = notify(notification{UserID: &user.ID, Type: m.NotificationSearchAlerts, URL: groups[0].Pets[0].URL, Email: email})
+	if err != nil {
+		return false, err
+	}
+
+	return true, dao.MarkMatchesNotified(included, now)
+}
+
+// savedSearchMatches reports whether a pet meets every criterion of a saved search.
+func savedSearchMatches(saved m.SavedSearch, pet *m.Pet, now time.Time) bool {
+	if saved.Species != "" && !strings.EqualFold(saved.Species, pet.Species) {
+		return false
+	}
+
+	if saved.Breed != "" && !strings.Contains(search.Normalize(pet.Breed), search.Normalize(saved.Breed)) {
+		return false
+	}
+
+	// Same age semantics as the age_min/age_max list filters
+	if saved.AgeMin != nil && pet.BirthDate.After(now.AddDate(-*saved.AgeMin, 0, 0)) {
+		return false
+	}
+
+	if saved.AgeMax != nil && !pet.BirthDate.After(now.AddDate(-(*saved.AgeMax+1), 0, 0)) {
+		return false
+	}
+
+	return search.MatchesAll(search.Parse(saved.Keywords), pet.Name, pet.Breed, pet.Description)
+}
+
+// normalizeSavedSearch trims text criteria and fills a default name.
+func normalizeSavedSearch(saved *m.SavedSearch) {
+	saved.Name = strings.TrimSpace(saved.Name)
+	saved.Species = strings.TrimSpace(saved.Species)
+	saved.Breed = strings.TrimSpace(saved.Breed)
+	saved.Keywords = strings.TrimSpace(saved.Keywords)
+
+	if saved.Name != "" {
+		return
+	}
+
+	var parts []string
+	for _, part := range []string{saved.Species, saved.Breed, saved.Keywords} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	if saved.AgeMin != nil || saved.AgeMax != nil {
+		parts = append(parts, "edad "+ageRange(saved.AgeMin, saved.AgeMax))
+	}
+
+	name := []rune(strings.Join(parts, ", "))
+	if len(name) > 100 {
+		name = name[:100]
+	}
+	saved.Name = string(name)
+}
+
+// ageRange formats an optional age range in years.
+func ageRange(from *int, to *int) string {
+	switch {
+	case from != nil && to != nil:
+		return fmt.Sprintf("%d-%d", *from, *to)
+	case from != nil:
+		return fmt.Sprintf("%d+", *from)
+	default:
+		return fmt.Sprintf("≤%d", *to)
+	}
+}
+
+// searchAlertUnsubscribeURL builds the signed unsubscribe link for one saved search,
+// or for every saved search of the user when searchID is 0.
+func searchAlertUnsubscribeURL(userID uint, searchID uint) string {
+	token := security.SignToken(searchAlertUnsubscribePurpose, fmt.Sprintf("%d:%d", userID, searchID))
+	return publicBaseURL + "/api/saved-searches/unsubscribe?token=" + url.QueryEscape(token)
+}
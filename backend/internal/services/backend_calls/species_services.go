@@ -5,28 +5,47 @@ package services
 
 import (
 	"backend/internal/db/dao"
+	"backend/internal/db/query"
 	m "backend/internal/models"
 	"fmt"
+	"net/url"
 )
 
 // ========================================
 // SPECIES MANAGEMENT SERVICES
 // ========================================
 
-// ListAllSpecies retrieves all species from the database.
-// Returns all available species for use in pet registration and categorization.
+// NewSpeciesListQuery parses and validates the pagination, sorting and filter
+// parameters of a species list request against dao.SpeciesListSchema.
+//
+// Parameters:
+//   - path: Request path used to build next/prev links
+//   - values: Raw query parameters
+//
+// Returns:
+//   - *query.Params: Validated list query
+//   - error: Validation error describing the invalid parameter
+func NewSpeciesListQuery(path string, values url.Values) (*query.Params, error) {
+	return query.Parse(path, values, dao.SpeciesListSchema)
+}
+
+// ListAllSpecies retrieves one page of species from the database.
+// Returns available species for use in pet registration and categorization.
 //
 // Business Logic:
-// - Retrieves all species regardless of status
+// - Applies the requested filters, sorting and pagination
 // - Used for populating dropdown menus and filters
 // - Provides reference data for pet management
 //
+// Parameters:
+//   - params: Validated list query (see NewSpeciesListQuery)
+//
 // Returns:
-//   - []m.Species: Slice of all species with their information
+//   - *query.Page[m.Species]: Requested page of species with total count and links
 //   - error: Database error or nil on success
-func ListAllSpecies() ([]m.Species, error) {
-	// Retrieve all species from database
-	species, err := dao.GetAllSpecies()
+func ListAllSpecies(params *query.Params) (*query.Page[m.Species], error) {
+	// Retrieve requested page of species from database (every organisation, narrowed by the organization filter)
+	species, err := dao.GetAllSpecies(params, dao.AllOrganizations)
 	if err != nil {
 		return nil, fmt.Errorf("error al obtener especies: %v", err)
 	}
@@ -50,7 +69,7 @@ func ListAllSpecies() ([]m.Species, error) {
 //   - error: Database error or species not found error
 func GetSpeciesByID(id uint) (*m.Species, error) {
 	// Retrieve specific species from database
-	species, err := dao.GetSpeciesByID(uint(id))
+	species, err := dao.GetSpeciesByID(uint(id), dao.AllOrganizations)
 	if err != nil {
 		return nil, fmt.Errorf("especie no encontrada: %v", err)
 	}
@@ -63,12 +82,13 @@ func GetSpeciesByID(id uint) (*m.Species, error) {
 //
 // Business Logic:
 // - Validates species data before creation
-// - Ensures species name uniqueness
+// - Ensures species name uniqueness within the organisation
 // - Assigns creation timestamps
 // - Updates the input species object with generated ID
+// - Sends the species.created webhook event
 //
 // Parameters:
-//   - species: Species data to be created (will be updated with generated ID)
+//   - species: Species data to be created, including its organisation (will be updated with generated ID)
 //
 // Returns:
 //   - error: Creation error or nil on success
@@ -79,6 +99,12 @@ func CreateSpecies(species *m.Species) error {
 		return fmt.Errorf("error al crear especie: %v", err)
 	}
 
+	emitWebhookEvent(m.WebhookEventSpeciesCreated, species.OrganizationID, m.WebhookSpeciesData{
+		ID:             species.ID,
+		OrganizationID: species.OrganizationID,
+		Name:           species.Name,
+	})
+
 	return nil
 }
 
@@ -90,17 +116,21 @@ func CreateSpecies(species *m.Species) error {
 // - Checks for pets associated with the species
 // - Prevents deletion if pets are still using the species
 // - Ensures referential integrity is maintained
+// - Sends the species.deleted webhook event
 //
 // Parameters:
 //   - id: Unique identifier of the species to delete
+//   - orgID: Organisation the species must belong to
 //
 // Returns:
 //   - error: Deletion error (including constraint violations) or nil on success
-func DeleteSpecies(id uint) error {
+func DeleteSpecies(id uint, orgID uint) error {
 	// Delete species from database with constraint checking
-	if err := dao.DeleteSpeciesByID(uint(id)); err != nil {
+	if err := dao.DeleteSpeciesByID(uint(id), orgID); err != nil {
 		return fmt.Errorf("error al eliminar especie: %v", err)
 	}
 
+	emitWebhookEvent(m.WebhookEventSpeciesDeleted, orgID, m.WebhookSpeciesData{ID: id, OrganizationID: orgID})
+
 	return nil
 }
@@ -6,14 +6,20 @@ package services
 import (
 	r_models "backend/internal/api/routes/models"
 	"backend/internal/db/dao"
+	"backend/internal/db/query"
 	m "backend/internal/models"
 	mailer "backend/internal/services/mail"
 	"context"
+	"errors"
 	"fmt"
+	"net/url"
 
 	"google.golang.org/api/idtoken"
 )
 
+// ErrUserBlocked is returned when a blocked user tries to use an active session.
+var ErrUserBlocked = errors.New("la cuenta de usuario está bloqueada")
+
 // ========================================
 // AUTHENTICATION SERVICES
 // ========================================
@@ -100,8 +106,8 @@ func AuthenticateUser2FA(userData r_models.TwoFactorRequest) (*m.NonValidatedUse
 // This is used when the user needs a new 2FA code (expired, lost, etc.).
 //
 // Process:
-// 1. Generates a new 2FA token in the database
-// 2. Sends the token via email to the user
+// 1. Generates a new 2FA token and queues its email in the outbox, in one transaction
+// 2. Sends the email in the background; the outbox worker retries it if the mail server fails
 // 3. Returns the generated token for verification
 //
 // Parameters:
@@ -109,20 +115,19 @@ func AuthenticateUser2FA(userData r_models.TwoFactorRequest) (*m.NonValidatedUse
 //
 // Returns:
 //   - string: Generated 2FA token
-//   - error: Generation or sending error, nil on success
+//   - error: Generation or queueing error, nil on success
 func RefreshUser2FAToken(userData r_models.RefreshTokenRequest) (string, error) {
-	// Generate new 2FA token and update in database
-	generated2FAToken, _2faErr := dao.UpdateTwoFactorCode(userData.Email)
+	// Generate new 2FA token, update it in database and queue its email
+	generated2FAToken, notification, _2faErr := dao.UpdateTwoFactorCode(userData.Email, func(code string) (*m.OutboxEmail, error) {
+		return mailer.Compose2FAToken(userData.Email, emailLocale(userData.Email), code)
+	})
 
 	if generated2FAToken == "" || _2faErr != nil {
 		return "", fmt.Errorf("error al generar el token 2FA: %v", _2faErr)
 	}
 
-	// Send 2FA token via email
-	mailerErr := mailer.Send2FAToken(userData.Email, generated2FAToken)
-	if mailerErr != nil {
-		return "", fmt.Errorf("error al enviar el token 2FA al email %s: %v", userData.Email, mailerErr)
-	}
+	// Send 2FA token via email without waiting for the mail server
+	mailer.DeliverAsync(notification)
 
 	return generated2FAToken, nil
 }
@@ -223,6 +228,7 @@ func AuthenticateGoogleUser(userData r_models.GoogleLoginRequest) (*m.User, erro
 			SessionID:    sessionID,
 			FailedLogins: nonValidatedUser.FailedLogins,
 			IsBlocked:    nonValidatedUser.IsBlocked,
+			Role:         nonValidatedUser.Role,
 		}
 	}
 
@@ -254,17 +260,19 @@ func ResetPassword(email string) (*string, error) {
 }
 
 func SendNewPassword(email string, password string) error {
-	// Set ChangePassword flag to true
-	err := dao.SetChangePasswordFlag(email, true)
+	notification, mailerErr := mailer.ComposePassword(email, emailLocale(email), password)
+	if mailerErr != nil {
+		return fmt.Errorf("error al enviar la nueva contraseña al email %s: %v", email, mailerErr)
+	}
+
+	// Set ChangePassword flag to true and queue the new password email together
+	err := dao.SetChangePasswordFlag(email, true, notification)
 	if err != nil {
 		return fmt.Errorf("error al establecer la bandera de cambio de contraseña: %v", err)
 	}
 
-	// Send new password via email
-	mailerErr := mailer.SendPassword(email, password)
-	if mailerErr != nil {
-		return fmt.Errorf("error al enviar la nueva contraseña al email %s: %v", email, mailerErr)
-	}
+	// Send new password via email without waiting for the mail server
+	mailer.DeliverAsync(notification)
 
 	return nil
 }
@@ -273,19 +281,36 @@ func SendNewPassword(email string, password string) error {
 // USER MANAGEMENT SERVICES
 // ========================================
 
-// ListAllUsers retrieves all users from the database.
+// NewUserListQuery parses and validates the pagination, sorting and filter
+// parameters of a user list request against dao.UserListSchema.
+//
+// Parameters:
+//   - path: Request path used to build next/prev links
+//   - values: Raw query parameters
+//
+// Returns:
+//   - *query.Params: Validated list query
+//   - error: Validation error describing the invalid parameter
+func NewUserListQuery(path string, values url.Values) (*query.Params, error) {
+	return query.Parse(path, values, dao.UserListSchema)
+}
+
+// ListAllUsers retrieves one page of users from the database.
 // Returns non-validated user data (without sensitive information like passwords).
 //
+// Parameters:
+//   - params: Validated list query (see NewUserListQuery)
+//
 // Returns:
-//   - *[]m.NonValidatedUser: Slice of all users without sensitive data
+//   - *query.Page[m.NonValidatedUser]: Requested page of users without sensitive data
 //   - error: Database error or nil on success
-func ListAllUsers() (*[]m.NonValidatedUser, error) {
-	users, err := dao.GetAllUsers()
+func ListAllUsers(params *query.Params) (*query.Page[m.NonValidatedUser], error) {
+	users, err := dao.GetAllUsers(params)
 	if err != nil {
 		return nil, fmt.Errorf("error al leer usuarios: %v", err)
 	}
 
-	return &users, nil
+	return users, nil
 }
 
 // GetUserProfile retrieves a specific user by their ID.
@@ -306,6 +331,32 @@ func GetUserProfile(id uint) (*m.NonValidatedUser, error) {
 	return user, nil
 }
 
+// GetSessionUser resolves the user owning an active session.
+// Used to authenticate API requests that act on behalf of the current user.
+//
+// Business Logic:
+// - The session must belong to an existing user
+// - Blocked users are rejected with ErrUserBlocked
+//
+// Parameters:
+//   - sessionID: Session identifier sent by the client
+//
+// Returns:
+//   - *m.NonValidatedUser: Owner of the session
+//   - error: ErrUserBlocked, or an error if the session is unknown
+func GetSessionUser(sessionID string) (*m.NonValidatedUser, error) {
+	user, err := dao.GetUserBySessionID(sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("sesión no válida: %v", err)
+	}
+
+	if user.IsBlocked {
+		return nil, ErrUserBlocked
+	}
+
+	return user, nil
+}
+
 // RegisterUser creates a new user account in the system.
 // Handles the complete user registration process including validation and storage.
 //
@@ -374,6 +425,51 @@ func DeactivateUser(id uint) (*m.SimplifiedUser, error) {
 	return deleted, nil
 }
 
+// ========================================
+// USER HELPERS
+// ========================================
+
+// userLocale returns the locale the emails of a registered user are written in.
+// Guests (nil ID) and users that cannot be loaded get m.DefaultLocale.
+func userLocale(userID *uint) string {
+	if userID == nil {
+		return m.DefaultLocale
+	}
+
+	user, err := dao.GetUserByID(*userID)
+	if err != nil {
+		return m.DefaultLocale
+	}
+
+	return user.Locale
+}
+
+// IsValidLocale reports whether locale is empty or one of m.Locales.
+func IsValidLocale(locale string) bool {
+	if locale == "" {
+		return true
+	}
+
+	for _, valid := range m.Locales {
+		if locale == valid {
+			return true
+		}
+	}
+
+	return false
+}
+
+// emailLocale returns the locale of the user registered with an email address,
+// or m.DefaultLocale when there is none.
+func emailLocale(email string) string {
+	user, err := dao.GetUserByEmail(email)
+	if err != nil {
+		return m.DefaultLocale
+	}
+
+	return user.Locale
+}
+
 // ========================================
 // GOOGLE AUTHENTICATION HELPERS
 // ========================================
@@ -0,0 +1,715 @@
+// Package services provides business logic services for meet-and-greet visits.
+// This layer manages the availability slots staff publish, books visits in them without
+// double-booking, lets visitors cancel or reschedule through signed links and emails
+// confirmations, reminders and cancellations with calendar invites attached.
+package services
+
+import (
+	"backend/internal/db/dao"
+	"backend/internal/db/query"
+	m "backend/internal/models"
+	"backend/internal/services/calendar"
+	mailer "backend/internal/services/mail"
+	"backend/internal/services/scheduler"
+	"backend/internal/services/security"
+	"backend/internal/utils/env"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// VisitRemindersJob is the scheduler job name of the visit reminder emails.
+const VisitRemindersJob = "visit-reminders"
+
+// visitManagePurpose binds the tokens of visit management links to this use.
+const visitManagePurpose = "visit-manage"
+
+// visitManageGrace is how long after the end of a visit its management link keeps working.
+const visitManageGrace = 24 * time.Hour
+
+var (
+	// visitReminderLead is how long before a visit its reminder is sent (VISIT_REMINDER_LEAD, default 24h).
+	visitReminderLead = env.GetDuration("VISIT_REMINDER_LEAD", 24*time.Hour)
+
+	// visitReminderInterval is how often visits due for a reminder are checked (VISIT_REMINDER_INTERVAL, default 1h).
+	visitReminderInterval = env.GetDuration("VISIT_REMINDER_INTERVAL", time.Hour)
+)
+
+var (
+	// ErrVisitSlotNotFound is returned when the slot does not exist in the organisation.
+	ErrVisitSlotNotFound = errors.New("franja de visita no encontrada")
+
+	// ErrVisitSlotOverlap is returned when creating a slot that overlaps another slot of the same pet.
+	ErrVisitSlotOverlap = errors.New("la mascota ya tiene una franja de visita en ese horario")
+
+	// ErrVisitSlotBooked is returned when deleting a slot that holds a scheduled visit.
+	ErrVisitSlotBooked = errors.New("la franja tiene una visita reservada, cancélala antes de eliminarla")
+
+	// ErrVisitSlotUnavailable is returned when booking a slot that is already booked or has started.
+	ErrVisitSlotUnavailable = errors.New("la franja de visita ya no está disponible")
+
+	// ErrVisitPetNotFound is returned when the pet does not exist in the organisation of the slot.
+	ErrVisitPetNotFound = errors.New("mascota no encontrada")
+
+	// ErrVisitPetUnavailable is returned when the pet cannot be visited in the slot.
+	// It is wrapped with the reason (slot reserved for another pet, pet adopted or already visited at that time).
+	ErrVisitPetUnavailable = errors.New("no se puede reservar la visita")
+
+	// ErrVisitNotFound is returned for visits that do not exist in the organisation.
+	ErrVisitNotFound = errors.New("visita no encontrada")
+
+	// ErrVisitNotScheduled is returned when changing a visit that was already cancelled, completed or missed.
+	ErrVisitNotScheduled = errors.New("la visita ya no está programada")
+
+	// ErrVisitLinkExpired is returned when a visit management link is used after it expired.
+	ErrVisitLinkExpired = errors.New("el enlace de la visita ha caducado")
+)
+
+// ========================================
+// VISIT SLOT SERVICES
+// ========================================
+
+// NewVisitSlotListQuery parses and validates the pagination, sorting and filter
+// parameters of a slot list request against dao.VisitSlotListSchema.
+//
+// Parameters:
+//   - path: Request path used to build next/prev links
+//   - values: Raw query parameters
+//
+// Returns:
+//   - *query.Params: Validated list query
+//   - error: Validation error describing the invalid parameter
+func NewVisitSlotListQuery(path string, values url.Values) (*query.Params, error) {
+	return query.Parse(path, values, dao.VisitSlotListSchema)
+}
+
+// ListVisitSlots retrieves one page of an organisation's slots, booked or free.
+//
+// Parameters:
+//   - params: Validated list query (see NewVisitSlotListQuery)
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - *query.Page[m.VisitSlot]: Requested page of slots
+//   - error: Database error or nil on success
+func ListVisitSlots(params *query.Params, orgID uint) (*query.Page[m.VisitSlot], error) {
+	slots, err := dao.GetVisitSlots(params, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener franjas de visita: %v", err)
+	}
+
+	return slots, nil
+}
+
+// CreateVisitSlot publishes a new availability slot of an organisation.
+//
+// Business Logic:
+// - A slot reserved for a pet requires the pet to belong to the organisation
+// - Slots of the same pet cannot overlap; slots open to any pet can
+//
+// Parameters:
+//   - slot: Validated slot (must include OrganizationID, StartTime, EndTime and CreatedBy)
+//
+// Returns:
+//   - *m.VisitSlot: Created slot
+//   - error: ErrVisitPetNotFound, ErrVisitSlotOverlap or database error
+func CreateVisitSlot(slot *m.VisitSlot) (*m.VisitSlot, error) {
+	if slot.PetID != nil {
+		if _, err := findOrganizationPet(*slot.PetID, slot.OrganizationID); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrVisitPetNotFound, err)
+		}
+
+		overlapping, err := dao.CountOverlappingVisitSlots(*slot.PetID, slot.StartTime, slot.EndTime)
+		if err != nil {
+			return nil, err
+		}
+		if overlapping > 0 {
+			return nil, ErrVisitSlotOverlap
+		}
+	}
+
+	slot.Booked = false
+	if err := dao.CreateVisitSlot(slot); err != nil {
+		return nil, fmt.Errorf("error al crear franja de visita: %v", err)
+	}
+
+	return slot, nil
+}
+
+// DeleteVisitSlot removes a free slot of an organisation.
+// Booked slots are kept until their visit is cancelled; past visits keep their time and location.
+//
+// Parameters:
+//   - id: Unique identifier of the slot
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - error: ErrVisitSlotNotFound, ErrVisitSlotBooked or database error
+func DeleteVisitSlot(id uint, orgID uint) error {
+	if _, err := dao.GetVisitSlot(id, orgID); err != nil {
+		return fmt.Errorf("%w: %v", ErrVisitSlotNotFound, err)
+	}
+
+	deleted, err := dao.DeleteFreeVisitSlot(id, orgID)
+	if err != nil {
+		return fmt.Errorf("error al eliminar franja de visita: %v", err)
+	}
+	if !deleted {
+		return ErrVisitSlotBooked
+	}
+
+	return nil
+}
+
+// ListAvailableVisitSlots retrieves the free future slots in which a pet can be visited.
+// Adopted pets have no slots.
+//
+// Parameters:
+//   - petID: Unique identifier of the pet
+//
+// Returns:
+//   - []m.VisitSlot: Free slots reserved for the pet or open to any pet of its organisation, earliest first
+//   - error: ErrVisitPetNotFound or database error
+func ListAvailableVisitSlots(petID uint) ([]m.VisitSlot, error) {
+	pet, err := dao.GetPetByID(petID, dao.AllOrganizations)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrVisitPetNotFound, err)
+	}
+
+	if pet.Status == m.PetStatusAdopted {
+		return []m.VisitSlot{}, nil
+	}
+
+	slots, err := dao.GetAvailableVisitSlots(pet.OrganizationID, pet.ID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener franjas libres: %v", err)
+	}
+
+	for i := range slots {
+		slots[i].CreatedBy = 0
+	}
+
+	return slots, nil
+}
+
+// ========================================
+// VISIT SERVICES
+// ========================================
+
+// NewVisitListQuery parses and validates the pagination, sorting and filter
+// parameters of a visit list request against dao.VisitListSchema.
+//
+// Parameters:
+//   - path: Request path used to build next/prev links
+//   - values: Raw query parameters
+//
+// Returns:
+//   - *query.Params: Validated list query
+//   - error: Validation error describing the invalid parameter
+func NewVisitListQuery(path string, values url.Values) (*query.Params, error) {
+	return query.Parse(path, values, dao.VisitListSchema)
+}
+
+// ListVisits retrieves one page of an organisation's visits.
+//
+// Parameters:
+//   - params: Validated list query (see NewVisitListQuery)
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - *query.Page[m.Visit]: Requested page of visits with pet summaries
+//   - error: Database error or nil on success
+func ListVisits(params *query.Params, orgID uint) (*query.Page[m.Visit], error) {
+	visits, err := dao.GetVisits(params, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener visitas: %v", err)
+	}
+
+	if err := fillVisitPets(visits.Items); err != nil {
+		return nil, err
+	}
+
+	return visits, nil
+}
+
+// GetVisit retrieves a visit of an organisation.
+//
+// Parameters:
+//   - id: Unique identifier of the visit
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - *m.Visit: Visit with pet summary
+//   - error: ErrVisitNotFound or database error
+func GetVisit(id uint, orgID uint) (*m.Visit, error) {
+	visit, err := dao.GetVisit(id, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrVisitNotFound, err)
+	}
+
+	visits := []m.Visit{*visit}
+	if err := fillVisitPets(visits); err != nil {
+		return nil, err
+	}
+
+	return &visits[0], nil
+}
+
+// BookVisit books a slot to meet a pet and emails the confirmation with the calendar invite.
+//
+// Business Logic:
+// - Slots reserved for a pet can only be booked for that pet; PetID defaults to it when omitted
+// - The pet must belong to the organisation of the slot, not be adopted and have no other visit at that time
+// - The slot is claimed atomically: when two visitors book the same slot, only one succeeds
+// - A failed confirmation email is logged; the visit stays booked
+//
+// Parameters:
+//   - visit: Validated visit (must include SlotID and visitor data; PetID optional for pet slots)
+//
+// Returns:
+//   - *m.Visit: Booked visit with pet summary
+//   - error: ErrVisitSlotNotFound, ErrVisitPetNotFound, ErrVisitPetUnavailable (wrapped),
+//     ErrVisitSlotUnavailable or database error
+func BookVisit(visit *m.Visit) (*m.Visit, error) {
+	if visit.SlotID == nil {
+		return nil, ErrVisitSlotNotFound
+	}
+
+	slot, err := dao.GetVisitSlot(*visit.SlotID, dao.AllOrganizations)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrVisitSlotNotFound, err)
+	}
+
+	if visit.PetID == 0 && slot.PetID != nil {
+		visit.PetID = *slot.PetID
+	}
+
+	if err := checkVisitPet(visit.ID, visit.PetID, slot); err != nil {
+		return nil, err
+	}
+
+	visit.OrganizationID = slot.OrganizationID
+	visit.Status = m.VisitStatusScheduled
+	visit.Sequence = 0
+
+	booked, err := dao.BookVisit(visit, slot, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("error al reservar visita: %v", err)
+	}
+	if !booked {
+		return nil, ErrVisitSlotUnavailable
+	}
+
+	created, err := GetVisit(visit.ID, visit.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	notifyVisitor(created, time.Now())
+
+	return created, nil
+}
+
+// UpdateVisitStatus records the outcome of a scheduled visit, or cancels it on behalf of the organisation.
+// Cancelling releases the slot and emails the visitor the calendar cancellation.
+//
+// Parameters:
+//   - id: Unique identifier of the visit
+//   - orgID: Organisation of the acting staff member
+//   - status: cancelled, completed or no_show
+//   - reason: Cancellation reason shown to the visitor (optional)
+//
+// Returns:
+//   - *m.Visit: Updated visit
+//   - error: ErrVisitNotFound, ErrVisitNotScheduled or database error
+func UpdateVisitStatus(id uint, orgID uint, status string, reason string) (*m.Visit, error) {
+	visit, err := GetVisit(id, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := changeVisitStatus(visit, status, reason); err != nil {
+		return nil, err
+	}
+
+	return visit, nil
+}
+
+// LinkVisitApplication links a visit of an organisation to an adoption application, or unlinks it with nil.
+//
+// Parameters:
+//   - id: Unique identifier of the visit
+//   - orgID: Organisation of the acting staff member
+//   - applicationID: Adoption application ID, or nil to unlink
+//
+// Returns:
+//   - *m.Visit: Updated visit
+//   - error: ErrVisitNotFound or database error
+func LinkVisitApplication(id uint, orgID uint, applicationID *uint) (*m.Visit, error) {
+	if _, err := dao.GetVisit(id, orgID); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrVisitNotFound, err)
+	}
+
+	if err := dao.SetVisitApplication(id, applicationID); err != nil {
+		return nil, fmt.Errorf("error al enlazar la visita: %v", err)
+	}
+
+	return GetVisit(id, orgID)
+}
+
+// ========================================
+// VISIT MANAGEMENT LINK SERVICES
+// ========================================
+
+// GetManagedVisit retrieves the visit of a signed management link.
+//
+// Parameters:
+//   - token: Token from the link sent to the visitor
+//
+// Returns:
+//   - *m.Visit: Visit with pet summary
+//   - error: security.ErrInvalidToken, ErrVisitLinkExpired, ErrVisitNotFound or database error
+func GetManagedVisit(token string) (*m.Visit, error) {
+	id, err := parseVisitManageToken(token, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return GetVisit(id, dao.AllOrganizations)
+}
+
+// CancelManagedVisit cancels a visit from its signed management link.
+// The slot is released and the visitor gets the calendar cancellation.
+//
+// Parameters:
+//   - token: Token from the link sent to the visitor
+//   - reason: Why the visitor cancels (optional)
+//
+// Returns:
+//   - *m.Visit: Cancelled visit
+//   - error: security.ErrInvalidToken, ErrVisitLinkExpired, ErrVisitNotFound, ErrVisitNotScheduled or database error
+func CancelManagedVisit(token string, reason string) (*m.Visit, error) {
+	visit, err := GetManagedVisit(token)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := changeVisitStatus(visit, m.VisitStatusCancelled, reason); err != nil {
+		return nil, err
+	}
+
+	return visit, nil
+}
+
+// RescheduleManagedVisit moves a visit to another free slot from its signed management link.
+//
+// Business Logic:
+// - The new slot must belong to the same organisation and be open to the visit's pet
+// - The new slot is claimed before the previous one is released, so a failed move keeps the visit as it was
+// - The visitor gets an updated calendar invite, which replaces the previous one
+//
+// Parameters:
+//   - token: Token from the link sent to the visitor
+//   - slotID: Unique identifier of the new slot
+//
+// Returns:
+//   - *m.Visit: Rescheduled visit
+//   - error: security.ErrInvalidToken, ErrVisitLinkExpired, ErrVisitNotFound, ErrVisitNotScheduled, ErrVisitSlotNotFound,
+//     ErrVisitPetUnavailable (wrapped), ErrVisitSlotUnavailable or database error
+func RescheduleManagedVisit(token string, slotID uint) (*m.Visit, error) {
+	visit, err := GetManagedVisit(token)
+	if err != nil {
+		return nil, err
+	}
+
+	if !visit.IsScheduled() {
+		return nil, ErrVisitNotScheduled
+	}
+
+	slot, err := dao.GetVisitSlot(slotID, visit.OrganizationID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrVisitSlotNotFound, err)
+	}
+
+	if err := checkVisitPet(visit.ID, visit.PetID, slot); err != nil {
+		return nil, err
+	}
+
+	moved, err := dao.RescheduleVisit(visit, slot, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("error al cambiar la visita: %v", err)
+	}
+	if !moved {
+		return nil, ErrVisitSlotUnavailable
+	}
+
+	notifyVisitor(visit, time.Now())
+
+	return visit, nil
+}
+
+// ========================================
+// VISIT REMINDER SERVICES
+// ========================================
+
+// RunVisitReminders emails visitors a reminder, with the calendar invite, of visits starting soon.
+//
+// Business Logic:
+// - Covers scheduled visits starting within VISIT_REMINDER_LEAD whose reminder was not sent
+// - Rescheduled visits get a new reminder for the new time
+// - The run fails (and is retried) only if no reminder could be sent
+//
+// Parameters:
+//   - now: Reference time of the run
+//   - dryRun: Only list the visits, without sending the reminders
+//
+// Returns:
+//   - scheduler.Result: Number of reminders sent (or that would be sent)
+//   - error: Database error, or mail error when every reminder failed
+func RunVisitReminders(now time.Time, dryRun bool) (scheduler.Result, error) {
+	visits, err := dao.GetVisitsDueForReminder(now, now.Add(visitReminderLead))
+	if err != nil {
+		return scheduler.Result{}, err
+	}
+
+	if dryRun {
+		ids := make([]uint, len(visits))
+		for i, visit := range visits {
+			ids[i] = visit.ID
+		}
+
+		return scheduler.Result{
+			Items:   len(visits),
+			Summary: fmt.Sprintf("se enviarían %d recordatorios", len(visits)),
+			Preview: ids,
+		}, nil
+	}
+
+	if err := fillVisitPets(visits); err != nil {
+		return scheduler.Result{}, err
+	}
+
+	sent := 0
+	var lastErr error
+	for i := range visits {
+		visit := &visits[i]
+
+		if err := sendVisitEmail(visit, mailer.ComposeVisitReminder, m.NotificationReminders, calendar.MethodPublish, now); err != nil {
+			log.Printf("could not send reminder of visit %d: %v", visit.ID, err)
+			lastErr = err
+			continue
+		}
+
+		if err := dao.MarkVisitReminderSent(visit.ID, now); err != nil {
+			log.Printf("could not record reminder of visit %d: %v", visit.ID, err)
+		}
+		sent++
+	}
+
+	if sent == 0 && lastErr != nil {
+		return scheduler.Result{}, fmt.Errorf("no se ha podido enviar ningún recordatorio: %v", lastErr)
+	}
+
+	return scheduler.Result{Items: sent, Summary: fmt.Sprintf("%d recordatorios enviados", sent)}, nil
+}
+
+// ========================================
+// VISIT HELPERS
+// ========================================
+
+// checkVisitPet checks that a pet can be visited in a slot.
+// visitID is the visit being rescheduled (0 for a new booking), which does not conflict with itself.
+func checkVisitPet(visitID uint, petID uint, slot *m.VisitSlot) error {
+	if slot.PetID != nil && *slot.PetID != petID {
+		return fmt.Errorf("%w: la franja está reservada para otra mascota", ErrVisitPetUnavailable)
+	}
+
+	pet, err := findOrganizationPet(petID, slot.OrganizationID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrVisitPetNotFound, err)
+	}
+
+	if pet.Status == m.PetStatusAdopted {
+		return fmt.Errorf("%w: la mascota ya ha sido adoptada", ErrVisitPetUnavailable)
+	}
+
+	overlapping, err := dao.CountOverlappingVisits(petID, slot.StartTime, slot.EndTime, visitID)
+	if err != nil {
+		return err
+	}
+	if overlapping > 0 {
+		return fmt.Errorf("%w: la mascota ya tiene una visita en ese horario", ErrVisitPetUnavailable)
+	}
+
+	return nil
+}
+
+// changeVisitStatus moves a scheduled visit to a final status.
+// Cancellations release the slot and are emailed to the visitor.
+func changeVisitStatus(visit *m.Visit, status string, reason string) error {
+	if !visit.IsScheduled() {
+		return ErrVisitNotScheduled
+	}
+
+	now := time.Now()
+	visit.Status = status
+	if status == m.VisitStatusCancelled {
+		visit.CancelledAt = &now
+		visit.CancelReason = reason
+	}
+
+	updated, err := dao.UpdateVisitStatus(visit)
+	if err != nil {
+		return fmt.Errorf("error al actualizar la visita: %v", err)
+	}
+	if !updated {
+		return ErrVisitNotScheduled
+	}
+
+	if status == m.VisitStatusCancelled {
+		notifyVisitor(visit, now)
+	}
+
+	return nil
+}
+
+// fillVisitPets sets the pet summary of visits.
+func fillVisitPets(visits []m.Visit) error {
+	if len(visits) == 0 {
+		return nil
+	}
+
+	petIDs := make([]uint, len(visits))
+	for i, visit := range visits {
+		petIDs[i] = visit.PetID
+	}
+
+	pets, err := dao.GetVisitPets(petIDs)
+	if err != nil {
+		return err
+	}
+
+	for i := range visits {
+		visits[i].Pet = pets[visits[i].PetID]
+		if visits[i].Pet != nil && visits[i].Pet.PrimaryPhoto != nil {
+			fillPhotoURL(visits[i].Pet.PrimaryPhoto)
+		}
+	}
+
+	return nil
+}
+
+// notifyVisitor notifies the visitor of the confirmation (scheduled visits) or the cancellation of a visit.
+// Failures are logged and never undo the change.
+func notifyVisitor(visit *m.Visit, now time.Time) {
+	compose, method := mailer.ComposeVisitConfirmation, calendar.MethodPublish
+	if visit.Status == m.VisitStatusCancelled {
+		compose, method = mailer.ComposeVisitCancellation, calendar.MethodCancel
+	}
+
+	if err := sendVisitEmail(visit, compose, m.NotificationApplicationUpdates, method, now); err != nil {
+		log.Printf("could not email visitor of visit %d: %v", visit.ID, err)
+	}
+}
+
+// sendVisitEmail builds the email data and calendar file of a visit, composes the email with the given
+// mailer function and notifies the visitor with the given notification type.
+func sendVisitEmail(visit *m.Visit, compose func(string, string, mailer.VisitData, []byte, mailer.Sender) (*mailer.Email, error), notificationType string, method string, now time.Time) error {
+	sender := organizationSender(visit.OrganizationID)
+
+	petName := fmt.Sprintf("mascota %d", visit.PetID)
+	if visit.Pet != nil {
+		petName = visit.Pet.Name
+	}
+
+	organizerEmail := sender.Email
+	if organizerEmail == "" {
+		organizerEmail = mailer.DefaultSender.Email
+	}
+
+	manageURL := visitManageURL(visit.ID, visit.EndTime)
+	event := calendar.Event{
+		UID:            visitEventUID(visit.ID),
+		Sequence:       visit.Sequence,
+		Start:          visit.StartTime,
+		End:            visit.EndTime,
+		Summary:        fmt.Sprintf("Visita para conocer a %s", petName),
+		Description:    fmt.Sprintf("Visita de presentación con %s (%s). Gestiona tu visita: %s", petName, sender.Name, manageURL),
+		Location:       visit.Location,
+		URL:            manageURL,
+		Organizer:      sender.Name,
+		OrganizerEmail: organizerEmail,
+	}
+
+	start, end := visit.StartTime.In(time.Local), visit.EndTime.In(time.Local)
+	data := mailer.VisitData{
+		VisitorName:  visit.VisitorName,
+		PetName:      petName,
+		Organization: sender.Name,
+		Date:         start.Format("02/01/2006"),
+		StartTime:    start.Format("15:04"),
+		EndTime:      end.Format("15:04"),
+		Location:     visit.Location,
+		ManageURL:    manageURL,
+		Reason:       visit.CancelReason,
+	}
+
+	email, err := compose(visit.VisitorEmail, userLocale(visit.UserID), data, event.ICS(method, now), sender)
+	if err != nil {
+		return err
+	}
+
+	return notify(notification{UserID: visit.UserID, Type: notificationType, URL: manageURL, Email: email})
+}
+
+// visitManageURL builds the signed link the visitor uses to cancel or reschedule a visit.
+// The link opens the web application, which calls the /api/visits/manage endpoints with the token.
+// The token holds "<visit id>:<expiry unix time>" and expires visitManageGrace after the visit ends;
+// rescheduling sends a new link for the new time.
+func visitManageURL(visitID uint, end time.Time) string {
+	value := fmt.Sprintf("%d:%d", visitID, end.Add(visitManageGrace).Unix())
+	return frontendURL + "/visits/manage?token=" + url.QueryEscape(security.SignToken(visitManagePurpose, value))
+}
+
+// parseVisitManageToken verifies a visit management token and returns the visit ID.
+func parseVisitManageToken(token string, now time.Time) (uint, error) {
+	value, err := security.VerifyToken(visitManagePurpose, token)
+	if err != nil {
+		return 0, err
+	}
+
+	idPart, expiresPart, ok := strings.Cut(value, ":")
+	if !ok {
+		return 0, security.ErrInvalidToken
+	}
+
+	id, err := strconv.ParseUint(idPart, 10, 64)
+	if err != nil {
+		return 0, security.ErrInvalidToken
+	}
+
+	expires, err := strconv.ParseInt(expiresPart, 10, 64)
+	if err != nil {
+		return 0, security.ErrInvalidToken
+	}
+
+	if now.After(time.Unix(expires, 0)) {
+		return 0, ErrVisitLinkExpired
+	}
+
+	return uint(id), nil
+}
+
+// visitEventUID builds the stable calendar UID of a visit, scoped to the host of the API.
+func visitEventUID(visitID uint) string {
+	host := "localhost"
+	if base, err := url.Parse(publicBaseURL); err == nil && base.Hostname() != "" {
+		host = base.Hostname()
+	}
+
+	return fmt.Sprintf("visit-%d@%s", visitID, host)
+}
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"backend/internal/services/security"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// visitToken extracts the token from a visit management link.
+func visitToken(t *testing.T, link string) string {
+	t.Helper()
+
+	parsed, err := url.Parse(link)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return parsed.Query().Get("token")
+}
+
+func TestVisitManageURL(t *testing.T) {
+	end := time.Date(2026, 10, 20, 11, 0, 0, 0, time.UTC)
+	link := visitManageURL(42, end)
+	if !strings.HasPrefix(link, frontendURL+"/visits/manage?token=") {
+		t.Fatalf("visitManageURL = %s, want a link to the frontend", link)
+	}
+
+	value, err := security.VerifyToken(visitManagePurpose, visitToken(t, link))
+	if err != nil {
+		t.Fatalf("VerifyToken error = %v", err)
+	}
+	if want := "42:" + strconv.FormatInt(end.Add(visitManageGrace).Unix(), 10); value != want {
+		t.Errorf("token value = %q, want %q", value, want)
+	}
+}
+
+func TestParseVisitManageToken(t *testing.T) {
+	end := time.Date(2026, 10, 20, 11, 0, 0, 0, time.UTC)
+	token := visitToken(t, visitManageURL(42, end))
+
+	tests := []struct {
+		name string
+		now  time.Time
+		err  error
+	}{
+		{name: "before the visit", now: end.Add(-72 * time.Hour)},
+		{name: "after the visit, within the grace period", now: end.Add(visitManageGrace - time.Second)},
+		{name: "at the expiry", now: end.Add(visitManageGrace)},
+		{name: "expired", now: end.Add(visitManageGrace + time.Second), err: ErrVisitLinkExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := parseVisitManageToken(token, tt.now)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("parseVisitManageToken error = %v, want %v", err, tt.err)
+			}
+			if err == nil && id != 42 {
+				t.Errorf("parseVisitManageToken = %d, want 42", id)
+			}
+		})
+	}
+}
+
+func TestGetManagedVisitTampering(t *testing.T) {
+	end := time.Now().Add(time.Hour)
+	token := visitToken(t, visitManageURL(42, end))
+	value, signature, _ := strings.Cut(token, ".")
+	_, expires, _ := strings.Cut(value, ":")
+	flipped := []byte(token)
+	flipped[len(flipped)-1] ^= 1
+	later := strconv.FormatInt(end.Add(365*24*time.Hour).Unix(), 10)
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "empty", token: ""},
+		{name: "no signature", token: value},
+		{name: "other visit", token: "43:" + expires + "." + signature},
+		{name: "expiry extended", token: "42:" + later + "." + signature},
+		{name: "signature changed", token: string(flipped)},
+		{name: "signature truncated", token: token[:len(token)-4]},
+		{name: "signed for another purpose", token: security.SignToken("unsubscribe", value)},
+		{name: "without expiry", token: security.SignToken(visitManagePurpose, "42")},
+		{name: "signed value is not an id", token: security.SignToken(visitManagePurpose, "42 OR 1=1:"+expires)},
+		{name: "signed expiry is not a time", token: security.SignToken(visitManagePurpose, "42:mañana")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := GetManagedVisit(tt.token); !errors.Is(err, security.ErrInvalidToken) {
+				t.Errorf("GetManagedVisit(%q) error = %v, want ErrInvalidToken", tt.token, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,923 @@
+// Package services provides business logic services for the volunteer programme.
+// This layer manages volunteer profiles with their skills and certifications, the shifts
+// staff publish, signups and cancellations within each shift's capacity, attendance,
+// the hours report and the reminder emails sent before each shift.
+package services
+
+import (
+	"backend/internal/db/dao"
+	"backend/internal/db/query"
+	m "backend/internal/models"
+	mailer "backend/internal/services/mail"
+	"backend/internal/services/scheduler"
+	"backend/internal/utils/env"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// VolunteerRemindersJob is the scheduler job name of the volunteer shift reminder emails.
+const VolunteerRemindersJob = "volunteer-reminders"
+
+var (
+	// volunteerCancelNotice is how long before a shift volunteers can still cancel (VOLUNTEER_CANCEL_NOTICE, default 24h).
+	volunteerCancelNotice = env.GetDuration("VOLUNTEER_CANCEL_NOTICE", 24*time.Hour)
+
+	// volunteerReminderLead is how long before a shift its reminder is sent (VOLUNTEER_REMINDER_LEAD, default 24h).
+	volunteerReminderLead = env.GetDuration("VOLUNTEER_REMINDER_LEAD", 24*time.Hour)
+
+	// volunteerReminderInterval is how often signups due for a reminder are checked (VOLUNTEER_REMINDER_INTERVAL, default 1h).
+	volunteerReminderInterval = env.GetDuration("VOLUNTEER_REMINDER_INTERVAL", time.Hour)
+)
+
+var (
+	// ErrVolunteerNotFound is returned for volunteers that do not exist or are not visible to the caller.
+	ErrVolunteerNotFound = errors.New("voluntario no encontrado")
+
+	// ErrVolunteerExists is returned when the user is already a volunteer of the organisation.
+	ErrVolunteerExists = errors.New("el usuario ya es voluntario de esta organización")
+
+	// ErrVolunteerInUse is returned when deleting a volunteer with signups.
+	ErrVolunteerInUse = errors.New("el voluntario tiene turnos registrados, desactívalo en su lugar")
+
+	// ErrVolunteerUserNotFound is returned when the volunteer user does not exist.
+	ErrVolunteerUserNotFound = errors.New("usuario no encontrado")
+
+	// ErrVolunteerCertificationNotFound is returned when the certification does not exist for the volunteer.
+	ErrVolunteerCertificationNotFound = errors.New("certificación no encontrada")
+
+	// ErrVolunteerShiftNotFound is returned for shifts that do not exist or are not visible to the caller.
+	ErrVolunteerShiftNotFound = errors.New("turno de voluntariado no encontrado")
+
+	// ErrVolunteerShiftInUse is returned when deleting a shift with volunteers signed up.
+	ErrVolunteerShiftInUse = errors.New("el turno tiene voluntarios inscritos, cancela sus inscripciones antes de eliminarlo")
+
+	// ErrVolunteerShiftCapacity is returned when lowering the capacity of a shift below its signups.
+	ErrVolunteerShiftCapacity = errors.New("la capacidad no puede ser menor que el número de voluntarios inscritos")
+
+	// ErrVolunteerShiftFull is returned when signing up for a shift that is full or has already started.
+	ErrVolunteerShiftFull = errors.New("el turno ya no tiene plazas disponibles")
+
+	// ErrVolunteerNotEligible is returned when the volunteer cannot sign up for a shift.
+	// It is wrapped with the reason (inactive volunteer, missing skill or certification).
+	ErrVolunteerNotEligible = errors.New("no puedes apuntarte a este turno")
+
+	// ErrVolunteerAlreadySignedUp is returned when signing up twice for the same shift.
+	ErrVolunteerAlreadySignedUp = errors.New("ya estás inscrito en este turno")
+
+	// ErrVolunteerSignupNotFound is returned when the volunteer has no active signup in the shift.
+	ErrVolunteerSignupNotFound = errors.New("inscripción no encontrada")
+
+	// ErrVolunteerCancelDeadline is returned when cancelling a signup after the cancellation deadline.
+	ErrVolunteerCancelDeadline = errors.New("ya no se puede cancelar la inscripción, avisa directamente a la protectora")
+
+	// ErrVolunteerShiftNotStarted is returned when recording attendance of a shift that has not started.
+	ErrVolunteerShiftNotStarted = errors.New("no se puede registrar la asistencia de un turno que no ha empezado")
+)
+
+// ========================================
+// VOLUNTEER SERVICES
+// ========================================
+
+// NewVolunteerListQuery parses and validates the pagination, sorting and filter
+// parameters of a volunteer list request against dao.VolunteerListSchema.
+//
+// Parameters:
+//   - path: Request path used to build next/prev links
+//   - values: Raw query parameters
+//
+// Returns:
+//   - *query.Params: Validated list query
+//   - error: Validation error describing the invalid parameter
+func NewVolunteerListQuery(path string, values url.Values) (*query.Params, error) {
+	return query.Parse(path, values, dao.VolunteerListSchema)
+}
+
+// ListVolunteers retrieves one page of an organisation's volunteers.
+//
+// Parameters:
+//   - params: Validated list query (see NewVolunteerListQuery)
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - *query.Page[m.Volunteer]: Volunteers with their user and certifications
+//   - error: Database error or nil on success
+func ListVolunteers(params *query.Params, orgID uint) (*query.Page[m.Volunteer], error) {
+	volunteers, err := dao.GetVolunteers(params, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener voluntarios: %v", err)
+	}
+
+	if err := fillVolunteerUsers(volunteers.Items); err != nil {
+		return nil, err
+	}
+
+	return volunteers, nil
+}
+
+// GetVolunteer retrieves a volunteer of an organisation.
+//
+// Parameters:
+//   - id: Unique identifier of the volunteer
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - *m.Volunteer: Volunteer with their user and certifications
+//   - error: ErrVolunteerNotFound or database error
+func GetVolunteer(id uint, orgID uint) (*m.Volunteer, error) {
+	volunteer, err := dao.GetVolunteer(id, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrVolunteerNotFound, err)
+	}
+
+	volunteers := []m.Volunteer{*volunteer}
+	if err := fillVolunteerUsers(volunteers); err != nil {
+		return nil, err
+	}
+
+	return &volunteers[0], nil
+}
+
+// CreateVolunteer registers a user as a volunteer of an organisation.
+//
+// Business Logic:
+// - Any registered user can volunteer; the account does not need a staff role
+// - A user is a volunteer of each organisation at most once
+//
+// Parameters:
+//   - volunteer: Validated volunteer (must include OrganizationID and UserID)
+//
+// Returns:
+//   - *m.Volunteer: Created volunteer with their user
+//   - error: ErrVolunteerUserNotFound, ErrVolunteerExists or database error
+func CreateVolunteer(volunteer *m.Volunteer) (*m.Volunteer, error) {
+	if _, err := dao.GetUserByID(volunteer.UserID); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrVolunteerUserNotFound, err)
+	}
+
+	existing, err := dao.GetVolunteerByUser(volunteer.OrganizationID, volunteer.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrVolunteerExists
+	}
+
+	if err := dao.CreateVolunteer(volunteer); err != nil {
+		return nil, fmt.Errorf("error al crear voluntario: %v", err)
+	}
+
+	return GetVolunteer(volunteer.ID, volunteer.OrganizationID)
+}
+
+// UpdateVolunteer replaces the profile of a volunteer on behalf of staff.
+// Deactivated volunteers keep their signups but cannot sign up for new shifts.
+//
+// Parameters:
+//   - volunteer: Validated volunteer (must include ID and OrganizationID)
+//
+// Returns:
+//   - *m.Volunteer: Updated volunteer
+//   - error: ErrVolunteerNotFound or database error
+func UpdateVolunteer(volunteer *m.Volunteer) (*m.Volunteer, error) {
+	err := dao.UpdateVolunteer(volunteer, "phone", "bio", "skills", "emergency_contact", "emergency_phone", "notes", "active")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrVolunteerNotFound, err)
+	}
+
+	return GetVolunteer(volunteer.ID, volunteer.OrganizationID)
+}
+
+// DeleteVolunteer removes a volunteer without signups.
+// Volunteers with a signup history are kept, so their hours still count, and should be deactivated instead.
+//
+// Parameters:
+//   - id: Unique identifier of the volunteer
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - error: ErrVolunteerNotFound, ErrVolunteerInUse or database error
+func DeleteVolunteer(id uint, orgID uint) error {
+	if _, err := dao.GetVolunteer(id, orgID); err != nil {
+		return fmt.Errorf("%w: %v", ErrVolunteerNotFound, err)
+	}
+
+	signups, err := dao.CountVolunteerSignups(id)
+	if err != nil {
+		return err
+	}
+	if signups > 0 {
+		return ErrVolunteerInUse
+	}
+
+	if err := dao.DeleteVolunteer(id, orgID); err != nil {
+		return fmt.Errorf("error al eliminar voluntario: %v", err)
+	}
+
+	return nil
+}
+
+// AddVolunteerCertification records a certification of a volunteer of an organisation.
+//
+// Parameters:
+//   - certification: Validated certification (must include VolunteerID and CreatedBy)
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - *m.Volunteer: Volunteer with the new certification
+//   - error: ErrVolunteerNotFound or database error
+func AddVolunteerCertification(certification *m.VolunteerCertification, orgID uint) (*m.Volunteer, error) {
+	if _, err := dao.GetVolunteer(certification.VolunteerID, orgID); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrVolunteerNotFound, err)
+	}
+
+	if err := dao.CreateVolunteerCertification(certification); err != nil {
+		return nil, err
+	}
+
+	return GetVolunteer(certification.VolunteerID, orgID)
+}
+
+// DeleteVolunteerCertification removes a certification of a volunteer of an organisation.
+//
+// Parameters:
+//   - id: Unique identifier of the certification
+//   - volunteerID: Volunteer holding the certification
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - error: ErrVolunteerNotFound, ErrVolunteerCertificationNotFound or database error
+func DeleteVolunteerCertification(id uint, volunteerID uint, orgID uint) error {
+	if _, err := dao.GetVolunteer(volunteerID, orgID); err != nil {
+		return fmt.Errorf("%w: %v", ErrVolunteerNotFound, err)
+	}
+
+	deleted, err := dao.DeleteVolunteerCertification(id, volunteerID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrVolunteerCertificationNotFound
+	}
+
+	return nil
+}
+
+// ListMyVolunteerProfiles retrieves the volunteer profiles of a user in every organisation.
+// Internal notes of the staff are not returned.
+//
+// Parameters:
+//   - userID: Authenticated user ID
+//
+// Returns:
+//   - []m.Volunteer: Volunteer profiles with their certifications
+//   - error: Database error or nil on success
+func ListMyVolunteerProfiles(userID uint) ([]m.Volunteer, error) {
+	volunteers, err := dao.GetUserVolunteers(userID)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener perfiles de voluntario: %v", err)
+	}
+
+	for i := range volunteers {
+		volunteers[i].Notes = ""
+	}
+
+	return volunteers, nil
+}
+
+// UpdateMyVolunteerProfile lets a volunteer keep their contact details and skills up to date.
+// Notes and the active flag are managed by staff and cannot be changed.
+//
+// Parameters:
+//   - profile: Validated profile (must include ID)
+//   - userID: Authenticated user ID, who must own the profile
+//
+// Returns:
+//   - *m.Volunteer: Updated volunteer profile
+//   - error: ErrVolunteerNotFound or database error
+func UpdateMyVolunteerProfile(profile *m.Volunteer, userID uint) (*m.Volunteer, error) {
+	volunteer, err := dao.GetVolunteer(profile.ID, dao.AllOrganizations)
+	if err != nil || volunteer.UserID != userID {
+		return nil, ErrVolunteerNotFound
+	}
+
+	profile.OrganizationID = volunteer.OrganizationID
+	err = dao.UpdateVolunteer(profile, "phone", "bio", "skills", "emergency_contact", "emergency_phone")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrVolunteerNotFound, err)
+	}
+
+	updated, err := dao.GetVolunteer(profile.ID, volunteer.OrganizationID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrVolunteerNotFound, err)
+	}
+	updated.Notes = ""
+
+	return updated, nil
+}
+
+// ========================================
+// VOLUNTEER SHIFT SERVICES
+// ========================================
+
+// NewVolunteerShiftListQuery parses and validates the pagination, sorting and filter
+// parameters of a shift list request against dao.VolunteerShiftListSchema.
+//
+// Parameters:
+//   - path: Request path used to build next/prev links
+//   - values: Raw query parameters
+//
+// Returns:
+//   - *query.Params: Validated list query
+//   - error: Validation error describing the invalid parameter
+func NewVolunteerShiftListQuery(path string, values url.Values) (*query.Params, error) {
+	return query.Parse(path, values, dao.VolunteerShiftListSchema)
+}
+
+// ListVolunteerShifts retrieves one page of an organisation's shifts.
+//
+// Parameters:
+//   - params: Validated list query (see NewVolunteerShiftListQuery)
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - *query.Page[m.VolunteerShift]: Shifts with their free places
+//   - error: Database error or nil on success
+func ListVolunteerShifts(params *query.Params, orgID uint) (*query.Page[m.VolunteerShift], error) {
+	shifts, err := dao.GetVolunteerShifts(params, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener turnos de voluntariado: %v", err)
+	}
+
+	for i := range shifts.Items {
+		fillShiftPlaces(&shifts.Items[i])
+	}
+
+	return shifts, nil
+}
+
+// GetVolunteerShift retrieves a shift of an organisation with its signups.
+//
+// Parameters:
+//   - id: Unique identifier of the shift
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - *m.VolunteerShift: Shift with its free places and signups (with the volunteer user)
+//   - error: ErrVolunteerShiftNotFound or database error
+func GetVolunteerShift(id uint, orgID uint) (*m.VolunteerShift, error) {
+	shift, err := dao.GetVolunteerShift(id, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrVolunteerShiftNotFound, err)
+	}
+
+	signups, err := dao.GetShiftSignups([]uint{shift.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]uint, len(signups))
+	for i, signup := range signups {
+		userIDs[i] = signup.UserID
+	}
+
+	users, err := dao.GetVolunteerUsers(userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range signups {
+		signups[i].User = users[signups[i].UserID]
+	}
+
+	shift.Signups = signups
+	fillShiftPlaces(shift)
+
+	return shift, nil
+}
+
+// CreateVolunteerShift publishes a new shift of an organisation.
+//
+// Parameters:
+//   - shift: Validated shift (must include OrganizationID, times, Capacity and CreatedBy)
+//
+// Returns:
+//   - *m.VolunteerShift: Created shift
+//   - error: Database error or nil on success
+func CreateVolunteerShift(shift *m.VolunteerShift) (*m.VolunteerShift, error) {
+	if err := dao.CreateVolunteerShift(shift); err != nil {
+		return nil, err
+	}
+
+	return GetVolunteerShift(shift.ID, shift.OrganizationID)
+}
+
+// UpdateVolunteerShift replaces the details of a shift.
+//
+// Business Logic:
+// - The capacity cannot be lowered below the volunteers already signed up
+// - Changing the time does not resend reminders already sent
+//
+// Parameters:
+//   - shift: Validated shift (must include ID and OrganizationID)
+//
+// Returns:
+//   - *m.VolunteerShift: Updated shift
+//   - error: ErrVolunteerShiftNotFound, ErrVolunteerShiftCapacity or database error
+func UpdateVolunteerShift(shift *m.VolunteerShift) (*m.VolunteerShift, error) {
+	if _, err := dao.GetVolunteerShift(shift.ID, shift.OrganizationID); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrVolunteerShiftNotFound, err)
+	}
+
+	updated, err := dao.UpdateVolunteerShift(shift)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, ErrVolunteerShiftCapacity
+	}
+
+	return GetVolunteerShift(shift.ID, shift.OrganizationID)
+}
+
+// DeleteVolunteerShift removes a shift nobody is signed up for.
+//
+// Parameters:
+//   - id: Unique identifier of the shift
+//   - orgID: Organisation of the acting staff member
+//
+// Returns:
+//   - error: ErrVolunteerShiftNotFound, ErrVolunteerShiftInUse or database error
+func DeleteVolunteerShift(id uint, orgID uint) error {
+	if _, err := dao.GetVolunteerShift(id, orgID); err != nil {
+		return fmt.Errorf("%w: %v", ErrVolunteerShiftNotFound, err)
+	}
+
+	deleted, err := dao.DeleteEmptyVolunteerShift(id, orgID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrVolunteerShiftInUse
+	}
+
+	return nil
+}
+
+// ========================================
+// VOLUNTEER SIGNUP SERVICES
+// ========================================
+
+// ListOpenVolunteerShifts retrieves the upcoming shifts a user can sign up for, or already joined,
+// in the organisations where they are an active volunteer.
+//
+// Parameters:
+//   - userID: Authenticated user ID
+//   - now: Reference time; shifts already started are not listed
+//
+// Returns:
+//   - []m.VolunteerShift: Shifts with their free places and whether the user joined them, earliest first
+//   - error: Database error or nil on success
+func ListOpenVolunteerShifts(userID uint, now time.Time) ([]m.VolunteerShift, error) {
+	volunteers, err := dao.GetUserVolunteers(userID)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener perfiles de voluntario: %v", err)
+	}
+
+	byOrganization := make(map[uint]*m.Volunteer, len(volunteers))
+	orgIDs := make([]uint, 0, len(volunteers))
+	for i := range volunteers {
+		if volunteers[i].Active {
+			byOrganization[volunteers[i].OrganizationID] = &volunteers[i]
+			orgIDs = append(orgIDs, volunteers[i].OrganizationID)
+		}
+	}
+
+	shifts, err := dao.GetUpcomingVolunteerShifts(orgIDs, now)
+	if err != nil {
+		return nil, err
+	}
+
+	signups, err := dao.GetUserVolunteerSignups(userID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	joined := make(map[uint]bool, len(signups))
+	for _, signup := range signups {
+		joined[signup.ShiftID] = signup.HoldsPlace()
+	}
+
+	open := make([]m.VolunteerShift, 0, len(shifts))
+	for _, shift := range shifts {
+		fillShiftPlaces(&shift)
+		shift.Joined = joined[shift.ID]
+		shift.CreatedBy = 0
+
+		eligible := checkVolunteerEligible(byOrganization[shift.OrganizationID], &shift) == nil
+		if shift.Joined || (eligible && shift.FreePlaces > 0) {
+			open = append(open, shift)
+		}
+	}
+
+	return open, nil
+}
+
+// SignUpForShift takes a place in a shift for the current user.
+//
+// Business Logic:
+// - The user must be an active volunteer of the shift's organisation
+// - The volunteer must have the required skill and a certification valid on the day of the shift
+// - Places are taken atomically, so the shift never exceeds its capacity
+// - A volunteer who cancelled can sign up again while places are left
+//
+// Parameters:
+//   - shiftID: Unique identifier of the shift
+//   - userID: Authenticated user ID
+//   - now: Signup time
+//
+// Returns:
+//   - *m.VolunteerSignup: Active signup with the shift
+//   - error: ErrVolunteerShiftNotFound, ErrVolunteerNotEligible, ErrVolunteerAlreadySignedUp, ErrVolunteerShiftFull or database error
+func SignUpForShift(shiftID uint, userID uint, now time.Time) (*m.VolunteerSignup, error) {
+	shift, err := dao.GetVolunteerShift(shiftID, dao.AllOrganizations)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrVolunteerShiftNotFound, err)
+	}
+
+	volunteer, err := dao.GetVolunteerByUser(shift.OrganizationID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if volunteer == nil {
+		// Users who do not volunteer for the organisation do not see its shifts
+		return nil, ErrVolunteerShiftNotFound
+	}
+
+	if err := checkVolunteerEligible(volunteer, shift); err != nil {
+		return nil, err
+	}
+
+	signup, err := dao.GetShiftSignup(shift.ID, volunteer.ID)
+	if err != nil {
+		return nil, err
+	}
+	if signup != nil && signup.Status != m.SignupStatusCancelled {
+		return nil, ErrVolunteerAlreadySignedUp
+	}
+	if signup == nil {
+		signup = &m.VolunteerSignup{
+			OrganizationID: shift.OrganizationID,
+			ShiftID:        shift.ID,
+			VolunteerID:    volunteer.ID,
+			UserID:         userID,
+		}
+	}
+
+	claimed, err := dao.SignUpForShift(signup, now)
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		return nil, ErrVolunteerShiftFull
+	}
+
+	shift.SignedUp++
+	fillShiftPlaces(shift)
+	shift.Joined = true
+	shift.CreatedBy = 0
+	signup.Shift = shift
+
+	return signup, nil
+}
+
+// CancelShiftSignup cancels the current user's signup in a shift and releases the place.
+// Cancellations close VOLUNTEER_CANCEL_NOTICE before the shift starts; later, the volunteer
+// must tell the organisation directly.
+//
+// Parameters:
+//   - shiftID: Unique identifier of the shift
+//   - userID: Authenticated user ID
+//   - now: Cancellation time
+//
+// Returns:
+//   - *m.VolunteerSignup: Cancelled signup with the shift
+//   - error: ErrVolunteerShiftNotFound, ErrVolunteerSignupNotFound, ErrVolunteerCancelDeadline or database error
+func CancelShiftSignup(shiftID uint, userID uint, now time.Time) (*m.VolunteerSignup, error) {
+	shift, err := dao.GetVolunteerShift(shiftID, dao.AllOrganizations)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrVolunteerShiftNotFound, err)
+	}
+
+	volunteer, err := dao.GetVolunteerByUser(shift.OrganizationID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if volunteer == nil {
+		return nil, ErrVolunteerShiftNotFound
+	}
+
+	signup, err := dao.GetShiftSignup(shift.ID, volunteer.ID)
+	if err != nil {
+		return nil, err
+	}
+	if signup == nil || signup.Status != m.SignupStatusSignedUp {
+		return nil, ErrVolunteerSignupNotFound
+	}
+
+	if !now.Before(shift.StartTime.Add(-volunteerCancelNotice)) {
+		return nil, ErrVolunteerCancelDeadline
+	}
+
+	cancelled, err := dao.CancelShiftSignup(signup, now)
+	if err != nil {
+		return nil, err
+	}
+	if !cancelled {
+		return nil, ErrVolunteerSignupNotFound
+	}
+
+	shift.SignedUp = max(shift.SignedUp-1, 0)
+	fillShiftPlaces(shift)
+	shift.CreatedBy = 0
+	signup.Shift = shift
+
+	return signup, nil
+}
+
+// ListMyVolunteerSignups retrieves the signups of a user in shifts starting after a time.
+//
+// Parameters:
+//   - userID: Authenticated user ID
+//   - from: Only signups of shifts starting after this time are returned
+//
+// Returns:
+//   - []m.VolunteerSignup: Signups with their shift, earliest first
+//   - error: Database error or nil on success
+func ListMyVolunteerSignups(userID uint, from time.Time) ([]m.VolunteerSignup, error) {
+	signups, err := dao.GetUserVolunteerSignups(userID, from)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener turnos del usuario: %v", err)
+	}
+
+	shiftIDs := make([]uint, len(signups))
+	for i, signup := range signups {
+		shiftIDs[i] = signup.ShiftID
+	}
+
+	shifts, err := dao.GetVolunteerShiftsByID(shiftIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range signups {
+		if shift := shifts[signups[i].ShiftID]; shift != nil {
+			fillShiftPlaces(shift)
+			shift.Joined = signups[i].HoldsPlace()
+			shift.CreatedBy = 0
+			signups[i].Shift = shift
+		}
+		signups[i].AttendanceBy = nil
+	}
+
+	return signups, nil
+}
+
+// ========================================
+// VOLUNTEER ATTENDANCE SERVICES
+// ========================================
+
+// RecordVolunteerAttendance records which volunteers worked a shift and for how long.
+//
+// Business Logic:
+// - Attendance can be recorded once the shift has started, and corrected later
+// - Cancelled signups cannot be marked as attended or missed
+// - Without minutes_worked, an attended signup counts the scheduled length of the shift
+//
+// Parameters:
+//   - shiftID: Unique identifier of the shift
+//   - orgID: Organisation of the acting staff member
+//   - entries: Validated signups with ID, Status (attended or no_show) and optional MinutesWorked
+//   - staffID: Staff user recording the attendance
+//   - now: Recording time
+//
+// Returns:
+//   - *m.VolunteerShift: Shift with its updated signups
+//   - error: ErrVolunteerShiftNotFound, ErrVolunteerShiftNotStarted, ErrVolunteerSignupNotFound or database error
+func RecordVolunteerAttendance(shiftID uint, orgID uint, entries []m.VolunteerSignup, staffID uint, now time.Time) (*m.VolunteerShift, error) {
+	shift, err := dao.GetVolunteerShift(shiftID, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrVolunteerShiftNotFound, err)
+	}
+
+	if now.Before(shift.StartTime) {
+		return nil, ErrVolunteerShiftNotStarted
+	}
+
+	for _, entry := range entries {
+		recorded, err := dao.RecordSignupAttendance(entry.ID, shift.ID, entry.Status, entry.MinutesWorked, staffID)
+		if err != nil {
+			return nil, err
+		}
+		if !recorded {
+			return nil, fmt.Errorf("%w: %d", ErrVolunteerSignupNotFound, entry.ID)
+		}
+	}
+
+	return GetVolunteerShift(shift.ID, orgID)
+}
+
+// GetVolunteerHoursReport sums the hours each volunteer of an organisation worked in a date range.
+//
+// Parameters:
+//   - orgID: Organisation of the acting staff member
+//   - from, to: Range of the shift start time (to excluded)
+//
+// Returns:
+//   - []m.VolunteerHours: One row per volunteer with attendance recorded, most hours first
+//   - error: Database error or nil on success
+func GetVolunteerHoursReport(orgID uint, from time.Time, to time.Time) ([]m.VolunteerHours, error) {
+	rows, err := dao.GetVolunteerHours(orgID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]uint, len(rows))
+	for i, row := range rows {
+		userIDs[i] = row.UserID
+	}
+
+	users, err := dao.GetVolunteerUsers(userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range rows {
+		rows[i].User = users[rows[i].UserID]
+		rows[i].Hours = math.Round(float64(rows[i].Minutes)/60*100) / 100
+	}
+
+	return rows, nil
+}
+
+// ========================================
+// VOLUNTEER REMINDER JOB
+// ========================================
+
+// RunVolunteerReminders emails volunteers a reminder of the shifts they signed up for that start soon.
+//
+// Business Logic:
+// - Covers active signups of shifts starting within VOLUNTEER_REMINDER_LEAD whose reminder was not sent
+// - Volunteers who cancel and sign up again get a new reminder
+// - The run fails (and is retried) only if no reminder could be sent
+//
+// Parameters:
+//   - now: Reference time of the run
+//   - dryRun: Only list the signups, without sending the reminders
+//
+// Returns:
+//   - scheduler.Result: Number of reminders sent (or that would be sent)
+//   - error: Database error, or mail error when every reminder failed
+func RunVolunteerReminders(now time.Time, dryRun bool) (scheduler.Result, error) {
+	signups, err := dao.GetVolunteerSignupsDueForReminder(now, now.Add(volunteerReminderLead))
+	if err != nil {
+		return scheduler.Result{}, err
+	}
+
+	if dryRun {
+		ids := make([]uint, len(signups))
+		for i, signup := range signups {
+			ids[i] = signup.ID
+		}
+
+		return scheduler.Result{
+			Items:   len(signups),
+			Summary: fmt.Sprintf("se enviarían %d recordatorios", len(signups)),
+			Preview: ids,
+		}, nil
+	}
+
+	shiftIDs := make([]uint, len(signups))
+	for i, signup := range signups {
+		shiftIDs[i] = signup.ShiftID
+	}
+
+	shifts, err := dao.GetVolunteerShiftsByID(shiftIDs)
+	if err != nil {
+		return scheduler.Result{}, err
+	}
+
+	sent := 0
+	var lastErr error
+	for _, signup := range signups {
+		shift := shifts[signup.ShiftID]
+		if shift == nil {
+			continue
+		}
+
+		if err := sendVolunteerShiftReminder(&signup, shift, now); err != nil {
+			log.Printf("could not send reminder of volunteer signup %d: %v", signup.ID, err)
+			lastErr = err
+			continue
+		}
+
+		if err := dao.MarkSignupReminderSent(signup.ID, now); err != nil {
+			log.Printf("could not record reminder of volunteer signup %d: %v", signup.ID, err)
+		}
+		sent++
+	}
+
+	if sent == 0 && lastErr != nil {
+		return scheduler.Result{}, fmt.Errorf("no se ha podido enviar ningún recordatorio: %v", lastErr)
+	}
+
+	return scheduler.Result{Items: sent, Summary: fmt.Sprintf("%d recordatorios enviados", sent)}, nil
+}
+
+// ========================================
+// VOLUNTEER HELPERS
+// ========================================
+
+// checkVolunteerEligible checks that a volunteer can sign up for a shift.
+func checkVolunteerEligible(volunteer *m.Volunteer, shift *m.VolunteerShift) error {
+	if volunteer == nil || !volunteer.Active {
+		return fmt.Errorf("%w: no eres voluntario activo de la organización", ErrVolunteerNotEligible)
+	}
+
+	if shift.RequiredSkill != "" && !volunteer.HasSkill(shift.RequiredSkill) {
+		return fmt.Errorf("%w: el turno requiere la habilidad %q", ErrVolunteerNotEligible, shift.RequiredSkill)
+	}
+
+	if shift.RequiredCertification != "" && !volunteer.HasCertification(shift.RequiredCertification, shift.StartTime.In(time.Local)) {
+		return fmt.Errorf("%w: el turno requiere la certificación %q en vigor", ErrVolunteerNotEligible, shift.RequiredCertification)
+	}
+
+	return nil
+}
+
+// fillShiftPlaces sets the free places of a shift.
+func fillShiftPlaces(shift *m.VolunteerShift) {
+	shift.FreePlaces = max(shift.Capacity-shift.SignedUp, 0)
+}
+
+// fillVolunteerUsers sets the user summary of volunteers.
+func fillVolunteerUsers(volunteers []m.Volunteer) error {
+	if len(volunteers) == 0 {
+		return nil
+	}
+
+	userIDs := make([]uint, len(volunteers))
+	for i, volunteer := range volunteers {
+		userIDs[i] = volunteer.UserID
+	}
+
+	users, err := dao.GetVolunteerUsers(userIDs)
+	if err != nil {
+		return err
+	}
+
+	for i := range volunteers {
+		volunteers[i].User = users[volunteers[i].UserID]
+	}
+
+	return nil
+}
+
+// sendVolunteerShiftReminder notifies a volunteer of an upcoming shift (see notify).
+func sendVolunteerShiftReminder(signup *m.VolunteerSignup, shift *m.VolunteerShift, now time.Time) error {
+	user, err := dao.GetUserByID(signup.UserID)
+	if err != nil {
+		return fmt.Errorf("error al obtener voluntario: %v", err)
+	}
+
+	sender := organizationSender(shift.OrganizationID)
+
+	cancelBefore := ""
+	if deadline := shift.StartTime.Add(-volunteerCancelNotice); now.Before(deadline) {
+		cancelBefore = deadline.In(time.Local).Format("02/01/2006 15:04")
+	}
+
+	start, end := shift.StartTime.In(time.Local), shift.EndTime.In(time.Local)
+	shiftsURL := frontendURL + "/volunteering/shifts"
+	email, err := mailer.ComposeVolunteerShiftReminder(user.Email, user.Locale, mailer.VolunteerShiftData{
+		VolunteerName: strings.TrimSpace(user.Name + " " + user.Surname),
+		Organization:  sender.Name,
+		Title:         shift.Title,
+		Description:   shift.Description,
+		Date:          start.Format("02/01/2006"),
+		StartTime:     start.Format("15:04"),
+		EndTime:       end.Format("15:04"),
+		Location:      shift.Location,
+		CancelBefore:  cancelBefore,
+		ShiftsURL:     shiftsURL,
+	}, sender)
+	if err != nil {
+		return err
+	}
+
+	return notify(notification{UserID: &user.ID, Type: m.NotificationReminders, URL: shiftsURL, Email: email})
+}
@@ -0,0 +1,552 @@
+// Package services provides business logic services for outbound webhooks.
+// This layer manages the endpoints admins register for partners, turns the events
+// of the pet, species and adoption services into signed deliveries queued in the
+// database, and sends them with retries, a log of every attempt and manual replays.
+package services
+
+import (
+	"backend/internal/db/dao"
+	"backend/internal/db/query"
+	m "backend/internal/models"
+	"backend/internal/services/scheduler"
+	"backend/internal/services/security"
+	"backend/internal/services/webhooks"
+	"backend/internal/utils/env"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// WebhookDeliveriesJob is the scheduler job name of the webhook delivery retries.
+const WebhookDeliveriesJob = "webhook-deliveries"
+
+const (
+	// webhookDeliveryBatch is the maximum number of deliveries attempted by one job run.
+	webhookDeliveryBatch = 200
+
+	// webhookDeliveryLease is how long a claimed delivery is reserved for its attempt.
+	webhookDeliveryLease = 5 * time.Minute
+)
+
+var (
+	// webhookMaxAttempts is how many times a delivery is attempted before it fails (WEBHOOK_MAX_ATTEMPTS, default 8).
+	webhookMaxAttempts = int(env.GetInt("WEBHOOK_MAX_ATTEMPTS", 8))
+
+	// webhookRetryBase is the delay before the first retry, doubled on each attempt (WEBHOOK_RETRY_BASE, default 1m).
+	webhookRetryBase = env.GetDuration("WEBHOOK_RETRY_BASE", time.Minute)
+
+	// webhookRetryMax is the longest delay between two attempts (WEBHOOK_RETRY_MAX, default 6h).
+	webhookRetryMax = env.GetDuration("WEBHOOK_RETRY_MAX", 6*time.Hour)
+
+	// webhookDeliveryInterval is how often due retries are sent (WEBHOOK_DELIVERY_INTERVAL, default 1m).
+	webhookDeliveryInterval = env.GetDuration("WEBHOOK_DELIVERY_INTERVAL", time.Minute)
+)
+
+var (
+	// ErrWebhookNotFound is returned when the webhook does not exist.
+	ErrWebhookNotFound = errors.New("webhook no encontrado")
+
+	// ErrWebhookDeliveryNotFound is returned when the delivery does not exist for the webhook.
+	ErrWebhookDeliveryNotFound = errors.New("entrega de webhook no encontrada")
+
+	// errWebhookDeliveryClaimed is returned when another worker is already attempting a delivery.
+	errWebhookDeliveryClaimed = errors.New("entrega reservada por otro proceso")
+)
+
+// webhookEnvelope is the JSON body of every delivery.
+type webhookEnvelope struct {
+	ID             string    `json:"id"`              // Event identifier, shared by retries and replays
+	Type           string    `json:"type"`            // Event type
+	CreatedAt      time.Time `json:"created_at"`      // When the event happened
+	OrganizationID uint      `json:"organization_id"` // Organisation the event belongs to
+	Data           any       `json:"data"`            // Event data (see the m.Webhook*Data types)
+}
+
+// ========================================
+// WEBHOOK SERVICES
+// ========================================
+
+// ListWebhooks retrieves every registered webhook.
+//
+// Returns:
+//   - []m.Webhook: Webhooks without their secrets
+//   - error: Database error or nil on success
+func ListWebhooks() ([]m.Webhook, error) {
+	webhooks, err := dao.GetWebhooks()
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener webhooks: %v", err)
+	}
+
+	return webhooks, nil
+}
+
+// GetWebhook retrieves a webhook.
+//
+// Parameters:
+//   - id: Unique identifier of the webhook
+//
+// Returns:
+//   - *m.Webhook: Webhook without its secret
+//   - error: ErrWebhookNotFound
+func GetWebhook(id uint) (*m.Webhook, error) {
+	webhook, err := dao.GetWebhook(id)
+	if err != nil {
+		return nil, ErrWebhookNotFound
+	}
+
+	return webhook, nil
+}
+
+// CreateWebhook registers a webhook with a new signing secret.
+//
+// Business Logic:
+// - The organisation filter, when given, must be an existing organisation
+// - The signing secret is returned in SigningSecret; it is not shown again
+//
+// Parameters:
+//   - webhook: Validated webhook (URL, Description, EventTypes, OrganizationID, Active, CreatedBy)
+//
+// Returns:
+//   - *m.Webhook: Created webhook with its signing secret
+//   - error: ErrOrganizationNotFound, secret generation or database error
+func CreateWebhook(webhook *m.Webhook) (*m.Webhook, error) {
+	if webhook.OrganizationID != nil {
+		if _, err := GetOrganization(*webhook.OrganizationID); err != nil {
+			return nil, err
+		}
+	}
+
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	webhook.Secret = secret
+	if err := dao.CreateWebhook(webhook); err != nil {
+		return nil, err
+	}
+
+	webhook.SigningSecret = webhook.Secret
+	return webhook, nil
+}
+
+// UpdateWebhook replaces the endpoint, event filter and state of a webhook.
+//
+// Parameters:
+//   - webhook: Validated webhook (must include ID)
+//
+// Returns:
+//   - *m.Webhook: Updated webhook
+//   - error: ErrWebhookNotFound, ErrOrganizationNotFound or database error
+func UpdateWebhook(webhook *m.Webhook) (*m.Webhook, error) {
+	if _, err := dao.GetWebhook(webhook.ID); err != nil {
+		return nil, ErrWebhookNotFound
+	}
+
+	if webhook.OrganizationID != nil {
+		if _, err := GetOrganization(*webhook.OrganizationID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := dao.UpdateWebhook(webhook); err != nil {
+		return nil, err
+	}
+
+	return GetWebhook(webhook.ID)
+}
+
+// RotateWebhookSecret replaces the signing secret of a webhook.
+// Deliveries attempted from now on, including retries, are signed with the new secret.
+//
+// Parameters:
+//   - id: Unique identifier of the webhook
+//
+// Returns:
+//   - *m.Webhook: Webhook with its new signing secret
+//   - error: ErrWebhookNotFound, secret generation or database error
+func RotateWebhookSecret(id uint) (*m.Webhook, error) {
+	webhook, err := GetWebhook(id)
+	if err != nil {
+		return nil, err
+	}
+
+	webhook.Secret, err = newWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := dao.UpdateWebhookSecret(id, webhook.Secret); err != nil {
+		return nil, err
+	}
+
+	webhook.SigningSecret = webhook.Secret
+	return webhook, nil
+}
+
+// DeleteWebhook removes a webhook with its delivery log.
+//
+// Parameters:
+//   - id: Unique identifier of the webhook
+//
+// Returns:
+//   - error: ErrWebhookNotFound or database error
+func DeleteWebhook(id uint) error {
+	if _, err := dao.GetWebhook(id); err != nil {
+		return ErrWebhookNotFound
+	}
+
+	return dao.DeleteWebhook(id)
+}
+
+// ========================================
+// WEBHOOK DELIVERY SERVICES
+// ========================================
+
+// NewWebhookDeliveryListQuery parses and validates the pagination, sorting and filter
+// parameters of a delivery log request against dao.WebhookDeliveryListSchema.
+//
+// Parameters:
+//   - path: Request path used to build next/prev links
+//   - values: Raw query parameters
+//
+// Returns:
+//   - *query.Params: Validated list query
+//   - error: Validation error describing the invalid parameter
+func NewWebhookDeliveryListQuery(path string, values url.Values) (*query.Params, error) {
+	return query.Parse(path, values, dao.WebhookDeliveryListSchema)
+}
+
+// ListWebhookDeliveries retrieves one page of the delivery log of a webhook.
+//
+// Parameters:
+//   - params: Validated list query (see NewWebhookDeliveryListQuery)
+//   - webhookID: Unique identifier of the webhook
+//
+// Returns:
+//   - *query.Page[m.WebhookDelivery]: Deliveries with their latest outcome
+//   - error: ErrWebhookNotFound or database error
+func ListWebhookDeliveries(params *query.Params, webhookID uint) (*query.Page[m.WebhookDelivery], error) {
+	if _, err := dao.GetWebhook(webhookID); err != nil {
+		return nil, ErrWebhookNotFound
+	}
+
+	deliveries, err := dao.GetWebhookDeliveries(params, webhookID)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener entregas: %v", err)
+	}
+
+	return deliveries, nil
+}
+
+// GetWebhookDelivery retrieves a delivery of a webhook with every attempt made.
+//
+// Parameters:
+//   - webhookID: Unique identifier of the webhook
+//   - id: Unique identifier of the delivery
+//
+// Returns:
+//   - *m.WebhookDelivery: Delivery with its attempt log
+//   - error: ErrWebhookDeliveryNotFound or database error
+func GetWebhookDelivery(webhookID uint, id uint) (*m.WebhookDelivery, error) {
+	delivery, err := dao.GetWebhookDelivery(webhookID, id)
+	if err != nil {
+		return nil, ErrWebhookDeliveryNotFound
+	}
+
+	attempts, err := dao.GetWebhookAttempts(delivery.ID)
+	if err != nil {
+		return nil, err
+	}
+	delivery.AttemptLog = attempts
+
+	return delivery, nil
+}
+
+// ReplayWebhookDelivery sends an event to a webhook again, as a new delivery attempted right away.
+//
+// Business Logic:
+// - The replay keeps the event ID and payload, so receivers can recognise the event
+// - It is attempted immediately, even for inactive webhooks; failures are retried like any delivery
+//
+// Parameters:
+//   - webhookID: Unique identifier of the webhook
+//   - id: Unique identifier of the delivery to replay
+//
+// Returns:
+//   - *m.WebhookDelivery: New delivery with the outcome of its first attempt
+//   - error: ErrWebhookNotFound, ErrWebhookDeliveryNotFound or database error
+func ReplayWebhookDelivery(webhookID uint, id uint) (*m.WebhookDelivery, error) {
+	webhook, err := GetWebhook(webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	original, err := dao.GetWebhookDelivery(webhookID, id)
+	if err != nil {
+		return nil, ErrWebhookDeliveryNotFound
+	}
+
+	now := time.Now()
+	replay := []m.WebhookDelivery{{
+		WebhookID:     webhook.ID,
+		EventID:       original.EventID,
+		EventType:     original.EventType,
+		Payload:       original.Payload,
+		Status:        m.WebhookDeliveryPending,
+		NextAttemptAt: &now,
+		ReplayOf:      &original.ID,
+	}}
+	if err := dao.CreateWebhookDeliveries(replay); err != nil {
+		return nil, err
+	}
+
+	// The outcome is recorded in the delivery; a failed attempt is not an error of the replay
+	if err := attemptWebhookDelivery(&replay[0], webhook); err != nil {
+		log.Printf("replay %d of webhook delivery %d failed: %v", replay[0].ID, original.ID, err)
+	}
+
+	return GetWebhookDelivery(webhook.ID, replay[0].ID)
+}
+
+// ========================================
+// WEBHOOK DELIVERY JOB
+// ========================================
+
+// RunWebhookDeliveries sends the pending webhook deliveries whose attempt is due.
+//
+// Business Logic:
+// - New events are attempted as soon as they happen; this job sends the retries
+// - Failed attempts are retried after WEBHOOK_RETRY_BASE, doubled each time up to WEBHOOK_RETRY_MAX
+// - After WEBHOOK_MAX_ATTEMPTS the delivery fails and is only sent again by a replay
+// - Endpoints refusing deliveries do not fail the run; only database errors do
+//
+// Parameters:
+//   - now: Reference time of the run
+//   - dryRun: Only list the due deliveries, without sending them
+//
+// Returns:
+//   - scheduler.Result: Number of deliveries attempted (or that would be attempted)
+//   - error: Database error or nil on success
+func RunWebhookDeliveries(now time.Time, dryRun bool) (scheduler.Result, error) {
+	deliveries, err := dao.GetDueWebhookDeliveries(now, webhookDeliveryBatch)
+	if err != nil {
+		return scheduler.Result{}, err
+	}
+
+	if dryRun {
+		ids := make([]uint, len(deliveries))
+		for i, delivery := range deliveries {
+			ids[i] = delivery.ID
+		}
+
+		return scheduler.Result{
+			Items:   len(deliveries),
+			Summary: fmt.Sprintf("se intentarían %d entregas de webhooks", len(deliveries)),
+			Preview: ids,
+		}, nil
+	}
+
+	registered, err := dao.GetWebhooks()
+	if err != nil {
+		return scheduler.Result{}, err
+	}
+	byID := make(map[uint]*m.Webhook, len(registered))
+	for i := range registered {
+		byID[registered[i].ID] = &registered[i]
+	}
+
+	delivered, failed := 0, 0
+	for i := range deliveries {
+		webhook := byID[deliveries[i].WebhookID]
+		if webhook == nil {
+			continue
+		}
+
+		err := attemptWebhookDelivery(&deliveries[i], webhook)
+		switch {
+		case errors.Is(err, errWebhookDeliveryClaimed):
+		case err != nil:
+			failed++
+		default:
+			delivered++
+		}
+	}
+
+	return scheduler.Result{
+		Items:   delivered + failed,
+		Summary: fmt.Sprintf("%d entregas de webhooks correctas, %d fallidas", delivered, failed),
+	}, nil
+}
+
+// ========================================
+// WEBHOOK HELPERS
+// ========================================
+
+// emitWebhookEvent queues an event for every active webhook subscribed to it and attempts the deliveries
+// in the background. Failures are logged: webhooks never make the originating operation fail.
+func emitWebhookEvent(eventType string, orgID uint, data any) {
+	active, err := dao.GetActiveWebhooks()
+	if err != nil {
+		log.Printf("could not load webhooks for %s event: %v", eventType, err)
+		return
+	}
+
+	var subscribed []m.Webhook
+	for _, webhook := range active {
+		if webhook.Subscribes(eventType, orgID) {
+			subscribed = append(subscribed, webhook)
+		}
+	}
+	if len(subscribed) == 0 {
+		return
+	}
+
+	now := time.Now()
+	event := webhookEnvelope{
+		ID:             "evt_" + strings.ToLower(security.Generate2FA(24)),
+		Type:           eventType,
+		CreatedAt:      now,
+		OrganizationID: orgID,
+		Data:           data,
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("could not encode %s webhook event: %v", eventType, err)
+		return
+	}
+
+	deliveries := make([]m.WebhookDelivery, len(subscribed))
+	for i, webhook := range subscribed {
+		deliveries[i] = m.WebhookDelivery{
+			WebhookID:     webhook.ID,
+			EventID:       event.ID,
+			EventType:     eventType,
+			Payload:       string(payload),
+			Status:        m.WebhookDeliveryPending,
+			NextAttemptAt: &now,
+		}
+	}
+	if err := dao.CreateWebhookDeliveries(deliveries); err != nil {
+		log.Printf("could not queue %s webhook event %s: %v", eventType, event.ID, err)
+		return
+	}
+
+	// First attempt right away; the queue keeps the deliveries if the backend stops meanwhile
+	go func() {
+		for i := range deliveries {
+			if err := attemptWebhookDelivery(&deliveries[i], &subscribed[i]); err != nil && !errors.Is(err, errWebhookDeliveryClaimed) {
+				log.Printf("webhook delivery %d to webhook %d failed, will retry: %v", deliveries[i].ID, subscribed[i].ID, err)
+			}
+		}
+	}()
+}
+
+// attemptWebhookDelivery claims a due delivery, sends it and records the attempt and the next retry.
+// Returns errWebhookDeliveryClaimed when another worker has it, or the error of the attempt.
+func attemptWebhookDelivery(delivery *m.WebhookDelivery, webhook *m.Webhook) error {
+	now := time.Now()
+	claimed, err := dao.ClaimWebhookDelivery(delivery.ID, now, now.Add(webhookDeliveryLease))
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return errWebhookDeliveryClaimed
+	}
+
+	resp, sendErr := webhooks.Send(context.Background(), webhooks.Request{
+		URL:        webhook.URL,
+		Secret:     webhook.Secret,
+		EventType:  delivery.EventType,
+		EventID:    delivery.EventID,
+		DeliveryID: delivery.ID,
+		Body:       []byte(delivery.Payload),
+	})
+
+	delivery.Attempts++
+	delivery.LastAttemptAt = &now
+	delivery.ResponseStatus = resp.StatusCode
+
+	attempt := &m.WebhookAttempt{
+		DeliveryID:     delivery.ID,
+		Attempt:        delivery.Attempts,
+		ResponseStatus: resp.StatusCode,
+		ResponseBody:   resp.Body,
+		DurationMs:     resp.Duration.Milliseconds(),
+	}
+
+	if sendErr == nil {
+		deliveredAt := time.Now()
+		delivery.Status = m.WebhookDeliverySucceeded
+		delivery.DeliveredAt = &deliveredAt
+		delivery.NextAttemptAt = nil
+		delivery.LastError = ""
+	} else {
+		attempt.Error = truncateWebhookError(sendErr)
+		delivery.LastError = attempt.Error
+		if delivery.Attempts >= webhookMaxAttempts {
+			delivery.Status = m.WebhookDeliveryFailed
+			delivery.NextAttemptAt = nil
+		} else {
+			next := time.Now().Add(webhookRetryDelay(delivery.Attempts))
+			delivery.NextAttemptAt = &next
+		}
+	}
+
+	if err := dao.RecordWebhookAttempt(delivery, attempt); err != nil {
+		// The lease expires and the delivery is attempted again
+		log.Printf("could not record attempt of webhook delivery %d: %v", delivery.ID, err)
+	}
+
+	return sendErr
+}
+
+// webhookRetryDelay returns the wait before the next attempt after the given number of failed attempts.
+func webhookRetryDelay(attempts int) time.Duration {
+	delay := webhookRetryBase
+	for i := 1; i < attempts && delay < webhookRetryMax; i++ {
+		delay *= 2
+	}
+
+	return min(delay, webhookRetryMax)
+}
+
+// truncateWebhookError shortens an attempt error to fit the delivery log.
+func truncateWebhookError(err error) string {
+	message := err.Error()
+	if runes := []rune(message); len(runes) > 500 {
+		return string(runes[:500])
+	}
+
+	return message
+}
+
+// newWebhookSecret generates a random signing secret: 32 bytes from crypto/rand, hex encoded.
+func newWebhookSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("error al generar secreto del webhook: %v", err)
+	}
+
+	return "whsec_" + hex.EncodeToString(secret), nil
+}
+
+// webhookPetData returns the data of a pet event.
+func webhookPetData(pet *m.Pet) m.WebhookPetData {
+	return m.WebhookPetData{
+		ID:             pet.ID,
+		OrganizationID: pet.OrganizationID,
+		Name:           pet.Name,
+		Species:        pet.Species,
+		Breed:          pet.Breed,
+		Status:         pet.Status,
+		URL:            fmt.Sprintf("%s/pets/%d", frontendURL, pet.ID),
+	}
+}
@@ -0,0 +1,41 @@
+package services
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWebhookRetryDelay(t *testing.T) {
+	// Default schedule: 1m doubled after every failed attempt, capped at 6h
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 0, want: time.Minute},
+		{attempts: 1, want: time.Minute},
+		{attempts: 2, want: 2 * time.Minute},
+		{attempts: 3, want: 4 * time.Minute},
+		{attempts: 7, want: 64 * time.Minute},
+		{attempts: 9, want: 256 * time.Minute},
+		{attempts: 10, want: 6 * time.Hour},
+		{attempts: 1000, want: 6 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		if got := webhookRetryDelay(tt.attempts); got != tt.want {
+			t.Errorf("webhookRetryDelay(%d) = %s, want %s", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestWebhookRetrySchedule(t *testing.T) {
+	// With the default 8 attempts, a delivery is retried for about two hours before it fails
+	var total time.Duration
+	for attempts := 1; attempts < webhookMaxAttempts; attempts++ {
+		total += webhookRetryDelay(attempts)
+	}
+
+	if want := 127 * time.Minute; total != want {
+		t.Errorf("total wait before the last attempt = %s, want %s", total, want)
+	}
+}
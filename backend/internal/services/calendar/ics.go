@@ -0,0 +1,138 @@
+// Package calendar builds iCalendar (RFC 5545) files, attached to emails so
+// recipients can add events to their calendar with one click.
+//
+// Every change of an event must be sent with the same UID and a higher
+// Sequence, so calendar clients replace the previous version instead of
+// adding a second event. Cancellations are sent with MethodCancel.
+package calendar
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// iCalendar methods (RFC 5546).
+const (
+	MethodPublish = "PUBLISH" // New or updated event
+	MethodCancel  = "CANCEL"  // Cancelled event
+)
+
+// ContentType is the MIME type of iCalendar files.
+const ContentType = "text/calendar"
+
+// productID identifies the application that produced the files.
+const productID = "-//Adoption System//Visits//ES"
+
+// Event is a calendar event.
+type Event struct {
+	UID            string    // Globally unique, stable identifier of the event
+	Sequence       int       // Revision of the event, increased on every change
+	Start          time.Time // Start of the event
+	End            time.Time // End of the event
+	Summary        string    // Title
+	Description    string    // Details (optional)
+	Location       string    // Location (optional)
+	URL            string    // Link to manage the event (optional)
+	Organizer      string    // Name of the organiser (optional)
+	OrganizerEmail string    // Email of the organiser (optional)
+}
+
+// ICS renders the event as an iCalendar file for the given method.
+// Cancelled events (MethodCancel) are marked with STATUS:CANCELLED.
+//
+// Parameters:
+//   - method: MethodPublish or MethodCancel
+//   - now: Time the file is generated (DTSTAMP)
+//
+// Returns:
+//   - []byte: iCalendar file content (CRLF line endings, folded lines)
+func (e Event) ICS(method string, now time.Time) []byte {
+	var b strings.Builder
+
+	line := func(name string, value string) {
+		writeFolded(&b, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", productID)
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", method)
+	line("BEGIN", "VEVENT")
+	line("UID", escapeText(e.UID))
+	line("SEQUENCE", fmt.Sprint(e.Sequence))
+	line("DTSTAMP", formatTime(now))
+	line("DTSTART", formatTime(e.Start))
+	line("DTEND", formatTime(e.End))
+	line("SUMMARY", escapeText(e.Summary))
+	if e.Description != "" {
+		line("DESCRIPTION", escapeText(e.Description))
+	}
+	if e.Location != "" {
+		line("LOCATION", escapeText(e.Location))
+	}
+	if e.URL != "" {
+		line("URL", e.URL)
+	}
+	if e.OrganizerEmail != "" {
+		organizer := "ORGANIZER"
+		if e.Organizer != "" {
+			organizer += ";CN=" + quoteParam(e.Organizer)
+		}
+		writeFolded(&b, organizer+":mailto:"+e.OrganizerEmail)
+	}
+	if method == MethodCancel {
+		line("STATUS", "CANCELLED")
+	} else {
+		line("STATUS", "CONFIRMED")
+	}
+	line("END", "VEVENT")
+	line("END", "VCALENDAR")
+
+	return []byte(b.String())
+}
+
+// formatTime formats a time in UTC, as iCalendar DATE-TIME values.
+func formatTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// escapeText escapes a TEXT value: backslashes, separators and line breaks.
+func escapeText(value string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	).Replace(value)
+}
+
+// quoteParam quotes a parameter value, removing the characters it cannot contain.
+func quoteParam(value string) string {
+	return `"` + strings.NewReplacer(`"`, "", "\r", "", "\n", " ").Replace(value) + `"`
+}
+
+// writeFolded writes a content line, folded at 75 octets without splitting UTF-8 characters.
+func writeFolded(b *strings.Builder, line string) {
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		// Move back to the start of a UTF-8 character
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+
+		// Continuation lines start with a space, which counts towards the limit
+		limit = 74
+	}
+
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
@@ -0,0 +1,139 @@
+package calendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+// unfold joins folded content lines and splits the file into lines.
+func unfold(ics []byte) []string {
+	return strings.Split(strings.TrimSuffix(strings.ReplaceAll(string(ics), "\r\n ", ""), "\r\n"), "\r\n")
+}
+
+func TestICS(t *testing.T) {
+	madrid := time.FixedZone("CEST", 2*60*60)
+	event := Event{
+		UID:            "visit-7@example.org",
+		Sequence:       2,
+		Start:          time.Date(2026, 10, 18, 11, 0, 0, 0, madrid),
+		End:            time.Date(2026, 10, 18, 11, 30, 0, 0, madrid),
+		Summary:        "Visita a Luna; protectora, centro",
+		Description:    "Trae la correa\nY el DNI",
+		Location:       `Calle Mayor 1, Madrid \ España`,
+		URL:            "https://example.org/visits/manage?token=7.abc",
+		Organizer:      `Protectora "Huellas"`,
+		OrganizerEmail: "hola@example.org",
+	}
+	now := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		event  Event
+		method string
+		want   []string
+		absent []string
+	}{
+		{
+			name:   "published event",
+			event:  event,
+			method: MethodPublish,
+			want: []string{
+				"METHOD:PUBLISH",
+				"UID:visit-7@example.org",
+				"SEQUENCE:2",
+				"DTSTAMP:20261001T080000Z",
+				"DTSTART:20261018T090000Z",
+				"DTEND:20261018T093000Z",
+				`SUMMARY:Visita a Luna\; protectora\, centro`,
+				`DESCRIPTION:Trae la correa\nY el DNI`,
+				`LOCATION:Calle Mayor 1\, Madrid \\ España`,
+				"URL:https://example.org/visits/manage?token=7.abc",
+				`ORGANIZER;CN="Protectora Huellas":mailto:hola@example.org`,
+				"STATUS:CONFIRMED",
+			},
+		},
+		{
+			name:   "cancelled event",
+			event:  event,
+			method: MethodCancel,
+			want:   []string{"METHOD:CANCEL", "UID:visit-7@example.org", "STATUS:CANCELLED"},
+			absent: []string{"STATUS:CONFIRMED"},
+		},
+		{
+			name:   "optional fields omitted",
+			event:  Event{UID: "visit-8@example.org", Start: event.Start, End: event.End, Summary: "Visita"},
+			method: MethodPublish,
+			want:   []string{"SEQUENCE:0", "SUMMARY:Visita"},
+			absent: []string{"DESCRIPTION:", "LOCATION:", "URL:", "ORGANIZER"},
+		},
+		{
+			name:   "organizer without name",
+			event:  Event{UID: "visit-9@example.org", Summary: "Visita", OrganizerEmail: "hola@example.org"},
+			method: MethodPublish,
+			want:   []string{"ORGANIZER:mailto:hola@example.org"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := unfold(tt.event.ICS(tt.method, now))
+			if lines[0] != "BEGIN:VCALENDAR" || lines[len(lines)-1] != "END:VCALENDAR" {
+				t.Fatalf("ICS is not wrapped in VCALENDAR: %q", lines)
+			}
+
+			joined := "\n" + strings.Join(lines, "\n") + "\n"
+			for _, want := range tt.want {
+				if !strings.Contains(joined, "\n"+want+"\n") {
+					t.Errorf("ICS missing line %q in %q", want, lines)
+				}
+			}
+			for _, absent := range tt.absent {
+				if strings.Contains(joined, "\n"+absent) {
+					t.Errorf("ICS contains %q", absent)
+				}
+			}
+		})
+	}
+}
+
+func TestWriteFolded(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+	}{
+		{name: "short", line: "SUMMARY:Visita"},
+		{name: "exactly 75 octets", line: "SUMMARY:" + strings.Repeat("a", 67)},
+		{name: "long ascii", line: "DESCRIPTION:" + strings.Repeat("abcdefghij", 30)},
+		{name: "multibyte characters on the boundary", line: "DESCRIPTION:" + strings.Repeat("ñ", 100)},
+		{name: "four byte characters", line: "SUMMARY:" + strings.Repeat("🐶", 60)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b strings.Builder
+			writeFolded(&b, tt.line)
+			out := b.String()
+
+			if !strings.HasSuffix(out, "\r\n") {
+				t.Fatal("line not terminated with CRLF")
+			}
+			for i, line := range strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n") {
+				if len(line) > 75 {
+					t.Errorf("line %d has %d octets, want at most 75", i, len(line))
+				}
+				if i > 0 && !strings.HasPrefix(line, " ") {
+					t.Errorf("continuation line %d does not start with a space", i)
+				}
+				if !utf8.ValidString(strings.TrimPrefix(line, " ")) {
+					t.Errorf("line %d splits a UTF-8 character", i)
+				}
+			}
+
+			if got := strings.ReplaceAll(strings.TrimSuffix(out, "\r\n"), "\r\n ", ""); got != tt.line {
+				t.Errorf("unfolded line = %q, want %q", got, tt.line)
+			}
+		})
+	}
+}
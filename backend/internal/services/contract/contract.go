@@ -0,0 +1,249 @@
+// Package contract generates adoption contract PDFs from an organisation's contract template.
+// This package is responsible for:
+// - Filling the template texts (Go text/template) with the pet, adopter, fee and clause data
+// - Validating templates before they are saved, so contracts never fail to render
+// - Laying out the contract as a PDF document with numbered clauses and signature boxes
+//
+// Templates only see the fields of Data, never the full models, so secrets such
+// as password hashes cannot leak into a contract.
+package contract
+
+import (
+	"backend/internal/models"
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// ErrInvalidTemplate is returned when a template text cannot be parsed or filled.
+var ErrInvalidTemplate = errors.New("plantilla de contrato inválida")
+
+// Template is the text of a contract. Every text is a Go text/template filled with Data.
+type Template struct {
+	Title   string   // Contract title
+	Intro   string   // Opening paragraph
+	Clauses []string // Standard clauses, numbered in order
+	Closing string   // Closing paragraph before the signatures
+}
+
+// DefaultTemplate is used by organisations that have not configured their own template.
+var DefaultTemplate = Template{
+	Title: "Contrato de adopción",
+	Intro: "En {{date .Date}}, {{.Organization.Name}} (en adelante, la protectora) entrega en adopción a " +
+		"{{.Adopter.FullName}} (en adelante, el adoptante) el animal descrito a continuación, " +
+		"de acuerdo con las cláusulas de este contrato.",
+	Clauses: []string{
+		"El adoptante se compromete a proporcionar a {{.Pet.Name}} alimentación adecuada, agua limpia, " +
+			"un alojamiento digno y la atención veterinaria que necesite.",
+		"El adoptante no podrá vender, ceder ni abandonar al animal. Si no pudiera seguir haciéndose cargo de él, " +
+			"deberá comunicarlo a la protectora, que decidirá su nuevo destino.",
+		"El adoptante permitirá que la protectora compruebe el bienestar del animal mediante visitas o " +
+			"contactos de seguimiento previamente acordados.",
+		"El adoptante comunicará a la protectora cualquier cambio de domicilio, así como la pérdida o el " +
+			"fallecimiento del animal.",
+		"El incumplimiento de cualquiera de estas cláusulas faculta a la protectora para recuperar al animal.",
+	},
+	Closing: "Y en prueba de conformidad, ambas partes firman el presente contrato en la fecha indicada.",
+}
+
+// Party is the organisation giving the pet in adoption, as shown in the contract.
+type Party struct {
+	Name    string
+	Email   string
+	Phone   string
+	Address string
+}
+
+// Pet is the adopted pet, as shown in the contract.
+type Pet struct {
+	Name      string
+	Species   string
+	Breed     string
+	Color     string
+	Microchip string
+	BirthDate time.Time // Zero when unknown
+}
+
+// Adopter is the adopter, as shown in the contract.
+type Adopter struct {
+	Name     string
+	Surname  string
+	FullName string
+	Email    string
+	Address  string
+}
+
+// Data is the content of a contract, available to template texts as {{.Field}}.
+//
+// Template functions:
+//   - date: formats a time as dd/mm/yyyy
+//   - upper: converts a string to upper case
+type Data struct {
+	Number       string    // Contract number, e.g. ADP-000012
+	Date         time.Time // Adoption date
+	Organization Party
+	Pet          Pet
+	Adopter      Adopter
+	Fee          string   // Formatted fee, e.g. "150,00 EUR" (empty when the adoption is free)
+	Clauses      []string // Clauses added for this adoption, after the template clauses
+}
+
+// NewData builds the contract data from the models involved in an adoption.
+//
+// Parameters:
+//   - adoptionID: ID of the adoption record (used for the contract number)
+//   - organization: Organisation giving the pet in adoption
+//   - pet: Adopted pet
+//   - adopter: Adopter user
+//   - date: Adoption date
+//   - feeCents: Adoption fee in cents
+//   - currency: ISO 4217 currency code of the fee
+//   - clauses: Clauses added for this adoption
+//
+// Returns:
+//   - Data: Contract data ready to render
+func NewData(adoptionID uint, organization models.Organization, pet models.Pet, adopter models.User,
+	date time.Time, feeCents int64, currency string, clauses []string) Data {
+	microchip := ""
+	if pet.Microchip != nil {
+		microchip = *pet.Microchip
+	}
+
+	return Data{
+		Number: Number(adoptionID),
+		Date:   date,
+		Organization: Party{
+			Name:    organization.Name,
+			Email:   organization.Email,
+			Phone:   organization.Phone,
+			Address: organization.Address,
+		},
+		Pet: Pet{
+			Name:      pet.Name,
+			Species:   pet.Species,
+			Breed:     pet.Breed,
+			Color:     pet.Color,
+			Microchip: microchip,
+			BirthDate: pet.BirthDate,
+		},
+		Adopter: Adopter{
+			Name:     adopter.Name,
+			Surname:  adopter.Surname,
+			FullName: strings.TrimSpace(adopter.Name + " " + adopter.Surname),
+			Email:    adopter.Email,
+			Address:  adopter.Address,
+		},
+		Fee:     FormatFee(feeCents, currency),
+		Clauses: clauses,
+	}
+}
+
+// Number returns the contract number of an adoption.
+func Number(adoptionID uint) string {
+	return fmt.Sprintf("ADP-%06d", adoptionID)
+}
+
+// FormatFee formats a fee in cents the Spanish way (e.g. "150,00 EUR").
+// Free adoptions return an empty string.
+func FormatFee(cents int64, currency string) string {
+	if cents <= 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("%d,%02d %s", cents/100, cents%100, currency)
+}
+
+// Validate checks that every text of the template parses and can be filled with contract data.
+//
+// Returns:
+//   - error: ErrInvalidTemplate (wrapped with the failing text) if the template is not valid
+func Validate(t Template) error {
+	_, err := fill(t, sampleData())
+	return err
+}
+
+// filled is a template with every text already filled with the contract data.
+type filled struct {
+	Title   string
+	Intro   string
+	Clauses []string
+	Closing string
+}
+
+// fill executes every text of the template with the contract data.
+// Extra clauses of the adoption are appended verbatim after the template clauses.
+func fill(t Template, data Data) (filled, error) {
+	var result filled
+	var err error
+
+	if result.Title, err = execute("title", t.Title, data); err != nil {
+		return filled{}, err
+	}
+	if result.Intro, err = execute("intro", t.Intro, data); err != nil {
+		return filled{}, err
+	}
+	for i, clause := range t.Clauses {
+		text, err := execute(fmt.Sprintf("clause %d", i+1), clause, data)
+		if err != nil {
+			return filled{}, err
+		}
+		if text != "" {
+			result.Clauses = append(result.Clauses, text)
+		}
+	}
+	for _, clause := range data.Clauses {
+		if clause = strings.TrimSpace(clause); clause != "" {
+			result.Clauses = append(result.Clauses, clause)
+		}
+	}
+	if result.Closing, err = execute("closing", t.Closing, data); err != nil {
+		return filled{}, err
+	}
+
+	return result, nil
+}
+
+// templateFuncs are the functions available to template texts.
+var templateFuncs = template.FuncMap{
+	"date":  formatDate,
+	"upper": strings.ToUpper,
+}
+
+// execute parses and fills one template text.
+func execute(name string, text string, data Data) (string, error) {
+	tmpl, err := template.New(name).Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s: %v", ErrInvalidTemplate, name, err)
+	}
+
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("%w: %s: %v", ErrInvalidTemplate, name, err)
+	}
+
+	return strings.TrimSpace(b.String()), nil
+}
+
+// formatDate formats a date as dd/mm/yyyy, or an empty string for zero dates.
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Format("02/01/2006")
+}
+
+// sampleData returns data with every field set, used to validate templates.
+func sampleData() Data {
+	return Data{
+		Number:       Number(1),
+		Date:         time.Now(),
+		Organization: Party{Name: "Protectora", Email: "info@example.com", Phone: "600000000", Address: "Calle Mayor 1"},
+		Pet:          Pet{Name: "Luna", Species: "perro", Breed: "mestizo", Color: "negro", Microchip: "941000000000000", BirthDate: time.Now()},
+		Adopter:      Adopter{Name: "Ana", Surname: "García", FullName: "Ana García", Email: "ana@example.com", Address: "Calle Sol 2"},
+		Fee:          FormatFee(15000, "EUR"),
+	}
+}
@@ -0,0 +1,98 @@
+package contract
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template Template
+		err      error
+	}{
+		{name: "default template", template: DefaultTemplate},
+		{name: "functions", template: Template{Title: "{{upper .Pet.Name}}", Intro: "{{date .Pet.BirthDate}}"}},
+		{name: "syntax error", template: Template{Title: "{{.Pet.Name"}, err: ErrInvalidTemplate},
+		{name: "unknown field", template: Template{Intro: "{{.Pet.Owner}}"}, err: ErrInvalidTemplate},
+		{name: "model fields not exposed", template: Template{Intro: "{{.Adopter.Password}}"}, err: ErrInvalidTemplate},
+		{name: "unknown function", template: Template{Closing: "{{lower .Pet.Name}}"}, err: ErrInvalidTemplate},
+		{name: "invalid clause", template: Template{Clauses: []string{"ok", "{{end}}"}}, err: ErrInvalidTemplate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Validate(tt.template); !errors.Is(err, tt.err) {
+				t.Errorf("Validate error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestFormatFee(t *testing.T) {
+	tests := []struct {
+		cents    int64
+		currency string
+		want     string
+	}{
+		{cents: 15000, currency: "EUR", want: "150,00 EUR"},
+		{cents: 5, currency: "EUR", want: "0,05 EUR"},
+		{cents: 12345, currency: "GBP", want: "123,45 GBP"},
+		{cents: 0, currency: "EUR", want: ""},
+		{cents: -100, currency: "EUR", want: ""},
+	}
+
+	for _, tt := range tests {
+		if got := FormatFee(tt.cents, tt.currency); got != tt.want {
+			t.Errorf("FormatFee(%d, %s) = %q, want %q", tt.cents, tt.currency, got, tt.want)
+		}
+	}
+}
+
+func TestRender(t *testing.T) {
+	data := sampleData()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	pdf, err := Render(DefaultTemplate, data, now)
+	if err != nil {
+		t.Fatalf("Render error = %v", err)
+	}
+	if !bytes.HasPrefix(pdf, []byte("%PDF-")) {
+		t.Errorf("Render output starts with %q, want a PDF document", pdf[:8])
+	}
+
+	if _, err := Render(Template{Title: "{{.Pet.Owner}}"}, data, now); !errors.Is(err, ErrInvalidTemplate) {
+		t.Errorf("Render with an invalid template error = %v, want ErrInvalidTemplate", err)
+	}
+}
+
+func TestRenderReproducible(t *testing.T) {
+	data := sampleData()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	first, err := Render(DefaultTemplate, data, now)
+	if err != nil {
+		t.Fatalf("Render error = %v", err)
+	}
+
+	for i := 0; i < 20; i++ {
+		again, err := Render(DefaultTemplate, data, now)
+		if err != nil {
+			t.Fatalf("Render error = %v", err)
+		}
+		if !bytes.Equal(first, again) {
+			t.Fatal("Render gave different documents for the same data and time")
+		}
+	}
+
+	data.Adopter.FullName += " Ruiz"
+	changed, err := Render(DefaultTemplate, data, now)
+	if err != nil {
+		t.Fatalf("Render error = %v", err)
+	}
+	if bytes.Equal(first, changed) {
+		t.Error("Render gave the same document for different data")
+	}
+}
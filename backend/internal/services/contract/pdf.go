@@ -0,0 +1,191 @@
+package contract
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+)
+
+// ContentType is the MIME type of the generated contracts.
+const ContentType = "application/pdf"
+
+// Page layout, in millimetres (A4 portrait).
+const (
+	pageWidth  = 210.0
+	pageHeight = 297.0
+	margin     = 20.0
+	labelWidth = 45.0
+	lineHeight = 6.0
+	fontFamily = "Helvetica"
+)
+
+// Render fills the template with the contract data and lays it out as a PDF document.
+//
+// The document contains the title, the organisation details, the contract number and date,
+// the opening paragraph, the pet and adopter details, the fee, the numbered clauses
+// (template clauses first, then the clauses of the adoption), the closing paragraph
+// and a signature box for each party. Every page shows the contract number and page count.
+//
+// Parameters:
+//   - t: Contract template
+//   - data: Contract data
+//   - now: Generation time (stored as the PDF creation date); the same data and time give the same document
+//
+// Returns:
+//   - []byte: PDF document
+//   - error: ErrInvalidTemplate if the template cannot be filled, or the PDF generation error
+func Render(t Template, data Data, now time.Time) ([]byte, error) {
+	text, err := fill(t, data)
+	if err != nil {
+		return nil, err
+	}
+
+	pdf := fpdf.New("P", "mm", "A4", "")
+	// Write the font and image catalogues in a fixed order, so the same data always gives the same bytes
+	pdf.SetCatalogSort(true)
+	// Core fonts are encoded in cp1252, which covers Spanish and Catalan accents
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	pdf.SetTitle(text.Title, true)
+	pdf.SetAuthor(data.Organization.Name, true)
+	pdf.SetSubject(data.Number, true)
+	pdf.SetCreator("Sistema de Adopciones", true)
+	pdf.SetCreationDate(now)
+	pdf.SetModificationDate(now)
+	pdf.SetMargins(margin, margin, margin)
+	pdf.SetAutoPageBreak(true, margin)
+	pdf.AliasNbPages("")
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-15)
+		pdf.SetFont(fontFamily, "I", 8)
+		pdf.SetTextColor(120, 120, 120)
+		footer := fmt.Sprintf("%s - Página %d de {nb}", data.Number, pdf.PageNo())
+		pdf.CellFormat(0, 10, tr(footer), "", 0, "C", false, 0, "")
+		pdf.SetTextColor(0, 0, 0)
+	})
+	pdf.AddPage()
+
+	// Header
+	pdf.SetFont(fontFamily, "B", 16)
+	pdf.MultiCell(0, 8, tr(text.Title), "", "C", false)
+	pdf.SetFont(fontFamily, "", 9)
+	pdf.MultiCell(0, 5, tr(joinNonEmpty(" - ", data.Organization.Name, data.Organization.Address)), "", "C", false)
+	pdf.MultiCell(0, 5, tr(joinNonEmpty(" - ", data.Organization.Email, data.Organization.Phone)), "", "C", false)
+	pdf.Ln(4)
+
+	pdf.SetFont(fontFamily, "", 10)
+	pdf.CellFormat(0, lineHeight, tr("Contrato nº "+data.Number), "", 1, "R", false, 0, "")
+	pdf.CellFormat(0, lineHeight, tr("Fecha: "+formatDate(data.Date)), "", 1, "R", false, 0, "")
+	pdf.Ln(4)
+
+	pdf.SetFont(fontFamily, "", 11)
+	pdf.MultiCell(0, lineHeight, tr(text.Intro), "", "J", false)
+
+	section := func(title string) {
+		pdf.Ln(4)
+		pdf.SetFont(fontFamily, "B", 12)
+		pdf.CellFormat(0, 8, tr(title), "B", 1, "L", false, 0, "")
+		pdf.Ln(2)
+	}
+	field := func(label string, value string) {
+		if value == "" {
+			return
+		}
+		pdf.SetFont(fontFamily, "B", 10)
+		pdf.CellFormat(labelWidth, lineHeight, tr(label+":"), "", 0, "L", false, 0, "")
+		pdf.SetFont(fontFamily, "", 10)
+		pdf.MultiCell(0, lineHeight, tr(value), "", "L", false)
+	}
+
+	section("Datos del animal")
+	field("Nombre", data.Pet.Name)
+	field("Especie", data.Pet.Species)
+	field("Raza", data.Pet.Breed)
+	field("Color", data.Pet.Color)
+	field("Microchip", data.Pet.Microchip)
+	field("Fecha de nacimiento", formatDate(data.Pet.BirthDate))
+
+	section("Datos del adoptante")
+	field("Nombre", data.Adopter.FullName)
+	field("Correo electrónico", data.Adopter.Email)
+	field("Dirección", data.Adopter.Address)
+
+	section("Tasa de adopción")
+	if data.Fee != "" {
+		field("Importe", data.Fee)
+	} else {
+		field("Importe", "Adopción sin tasa")
+	}
+
+	if len(text.Clauses) > 0 {
+		section("Cláusulas")
+		for i, clause := range text.Clauses {
+			pdf.SetFont(fontFamily, "B", 10)
+			pdf.CellFormat(10, lineHeight, fmt.Sprintf("%d.", i+1), "", 0, "L", false, 0, "")
+			pdf.SetFont(fontFamily, "", 10)
+			pdf.MultiCell(0, lineHeight, tr(clause), "", "J", false)
+			pdf.Ln(1)
+		}
+	}
+
+	if text.Closing != "" {
+		pdf.Ln(4)
+		pdf.SetFont(fontFamily, "", 11)
+		pdf.MultiCell(0, lineHeight, tr(text.Closing), "", "J", false)
+	}
+
+	signatures(pdf, tr, data.Organization.Name, data.Adopter.FullName)
+
+	var out bytes.Buffer
+	if err := pdf.Output(&out); err != nil {
+		return nil, fmt.Errorf("could not generate contract PDF: %w", err)
+	}
+
+	return out.Bytes(), nil
+}
+
+// signatures draws the signature boxes of both parties, side by side, on a single page.
+func signatures(pdf *fpdf.Fpdf, tr func(string) string, organization string, adopter string) {
+	const boxHeight = 25.0
+	const gap = 10.0
+	width := (pageWidth - 2*margin - gap) / 2
+
+	pdf.Ln(10)
+	y := pdf.GetY()
+	if y+boxHeight+15 > pageHeight-margin {
+		pdf.AddPage()
+		y = pdf.GetY()
+	}
+
+	right := margin + width + gap
+	pdf.SetFont(fontFamily, "B", 10)
+	pdf.SetXY(margin, y)
+	pdf.CellFormat(width, lineHeight, tr("Por la protectora"), "", 0, "L", false, 0, "")
+	pdf.SetXY(right, y)
+	pdf.CellFormat(width, lineHeight, tr("El adoptante"), "", 0, "L", false, 0, "")
+
+	pdf.SetDrawColor(150, 150, 150)
+	pdf.Rect(margin, y+lineHeight+1, width, boxHeight, "D")
+	pdf.Rect(right, y+lineHeight+1, width, boxHeight, "D")
+
+	pdf.SetFont(fontFamily, "", 9)
+	pdf.SetXY(margin, y+lineHeight+boxHeight+2)
+	pdf.CellFormat(width, 5, tr(organization), "", 0, "C", false, 0, "")
+	pdf.SetXY(right, y+lineHeight+boxHeight+2)
+	pdf.CellFormat(width, 5, tr(adopter), "", 1, "C", false, 0, "")
+}
+
+// joinNonEmpty joins the non-empty values with the separator.
+func joinNonEmpty(separator string, values ...string) string {
+	parts := make([]string, 0, len(values))
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			parts = append(parts, value)
+		}
+	}
+
+	return strings.Join(parts, separator)
+}
@@ -0,0 +1,254 @@
+// Package events is an in-process publish/subscribe hub for real-time updates.
+//
+// Services publish events with an audience (everyone, the staff of an organisation
+// and/or one user) and every subscriber receives the events it may see. Events get
+// an ID made of the hub epoch and a sequence number; the latest events are kept so
+// that a client reconnecting with Last-Event-ID receives what it missed. When the
+// events it missed are no longer kept (or the backend restarted), the client gets a
+// resync event and must reload its data.
+//
+// Each subscriber has a bounded buffer. A subscriber that does not keep up is
+// dropped: its channel is closed and it is expected to reconnect and resume.
+//
+// The hub lives in memory, so with several backend instances each one only
+// delivers the events published by itself.
+//
+// Configuration (environment variables):
+//   - SSE_HISTORY_SIZE: Number of latest events kept for resuming (default 1000)
+//   - SSE_CLIENT_BUFFER: Events buffered per subscriber before it is dropped (default 64)
+//   - SSE_HEARTBEAT_INTERVAL: How often idle streams send a heartbeat (default 25s)
+//   - SSE_MAX_DURATION: How long a stream stays open before the client must reconnect (default 1h)
+package events
+
+import (
+	"backend/internal/utils/env"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Event types.
+const (
+	TypePetCreated         = "pet-created"         // A pet was added to an organisation (public)
+	TypePetStatusChanged   = "pet-status-changed"  // A pet became available, reserved or adopted (public)
+	TypeApplicationUpdated = "application-updated" // The adoption process of a user changed (staff and the adopter)
+	TypeNewMessage         = "new-message"         // A message was posted in a conversation (staff and the user)
+	TypeNotification       = "notification"        // A notification was added to the notification centre (the user)
+	TypeResync             = "resync"              // Missed events are no longer available; the client must reload
+)
+
+var (
+	// HeartbeatInterval is how often idle streams send a heartbeat comment (SSE_HEARTBEAT_INTERVAL, default 25s).
+	HeartbeatInterval = env.GetDuration("SSE_HEARTBEAT_INTERVAL", 25*time.Second)
+
+	// MaxStreamDuration is how long a stream stays open; clients reconnect and resume (SSE_MAX_DURATION, default 1h).
+	// It bounds how long a stream outlives a closed session or a change of organisation memberships.
+	MaxStreamDuration = env.GetDuration("SSE_MAX_DURATION", time.Hour)
+
+	defaultHub = NewHub(int(env.GetInt("SSE_HISTORY_SIZE", 1000)), int(env.GetInt("SSE_CLIENT_BUFFER", 64)))
+)
+
+// Event is a real-time update delivered to the subscribers in its audience.
+type Event struct {
+	ID   string    // Assigned by Publish: "<hub epoch>-<sequence>"
+	Type string    // One of the Type constants
+	Data any       // Payload, encoded as JSON
+	Time time.Time // When the event was published
+
+	// Audience: an event is delivered when any of these matches the subscriber
+	Public         bool // Every subscriber, including anonymous ones
+	OrganizationID uint // Staff of this organisation (0 for none)
+	UserID         uint // This user (0 for none)
+
+	seq uint64
+}
+
+// Viewer describes what a subscriber may see.
+type Viewer struct {
+	UserID           uint   // Authenticated user (0 for anonymous subscribers)
+	AllOrganizations bool   // Whether the user sees the staff events of every organisation (admins)
+	OrganizationIDs  []uint // Organisations whose staff events the user sees
+}
+
+// canSee reports whether the viewer is in the audience of an event.
+func (v Viewer) canSee(event Event) bool {
+	if event.Public {
+		return true
+	}
+	if event.UserID != 0 && event.UserID == v.UserID {
+		return true
+	}
+	if event.OrganizationID != 0 && v.UserID != 0 {
+		return v.AllOrganizations || slices.Contains(v.OrganizationIDs, event.OrganizationID)
+	}
+
+	return false
+}
+
+// Subscription is a subscriber registered with a hub.
+type Subscription struct {
+	events  chan Event
+	viewer  Viewer
+	dropped bool
+}
+
+// Events returns the channel delivering the subscriber's events.
+// It is closed when the subscriber is dropped for not keeping up, or unsubscribed.
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Hub delivers published events to its subscribers and keeps the latest ones for resuming.
+type Hub struct {
+	mu          sync.Mutex
+	epoch       string
+	seq         uint64
+	history     []Event
+	historySize int
+	bufferSize  int
+	subscribers map[*Subscription]struct{}
+}
+
+// NewHub creates a hub keeping historySize events and buffering bufferSize events per subscriber.
+func NewHub(historySize int, bufferSize int) *Hub {
+	return &Hub{
+		epoch:       strconv.FormatInt(time.Now().UnixNano(), 36),
+		historySize: max(historySize, 0),
+		bufferSize:  max(bufferSize, 1),
+		subscribers: map[*Subscription]struct{}{},
+	}
+}
+
+// Publish assigns an ID to an event, keeps it for resuming and delivers it to every subscriber that may see it.
+// Subscribers whose buffer is full are dropped. Publish never blocks on subscribers.
+func (h *Hub) Publish(event Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.seq++
+	event.seq = h.seq
+	event.ID = fmt.Sprintf("%s-%d", h.epoch, h.seq)
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+
+	if h.historySize > 0 {
+		h.history = append(h.history, event)
+		if len(h.history) > h.historySize {
+			h.history = h.history[len(h.history)-h.historySize:]
+		}
+	}
+
+	for sub := range h.subscribers {
+		if !sub.viewer.canSee(event) {
+			continue
+		}
+
+		select {
+		case sub.events <- event:
+		default:
+			// Slow subscriber: drop it so it reconnects and resumes from its last event
+			h.drop(sub)
+		}
+	}
+}
+
+// Subscribe registers a subscriber and returns the events it missed since lastEventID.
+//
+// Behaviour:
+//   - Empty lastEventID: No missed events
+//   - lastEventID still in the history: Every later event the viewer may see
+//   - Otherwise (unknown, too old or from before a restart): A single resync event
+//
+// Parameters:
+//   - viewer: What the subscriber may see
+//   - lastEventID: ID of the last event the client received (Last-Event-ID)
+//
+// Returns:
+//   - *Subscription: Registered subscriber (call Unsubscribe when done)
+//   - []Event: Missed events to send before the subscription's events
+func (h *Hub) Subscribe(viewer Viewer, lastEventID string) (*Subscription, []Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var missed []Event
+	if lastEventID = strings.TrimSpace(lastEventID); lastEventID != "" {
+		missed = h.missedSince(viewer, lastEventID)
+	}
+
+	sub := &Subscription{events: make(chan Event, h.bufferSize), viewer: viewer}
+	h.subscribers[sub] = struct{}{}
+
+	return sub, missed
+}
+
+// Unsubscribe removes a subscriber and closes its channel. Calling it again has no effect.
+func (h *Hub) Unsubscribe(sub *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.drop(sub)
+}
+
+// missedSince returns the events after lastEventID the viewer may see, or a resync event.
+// It must be called with h.mu held.
+func (h *Hub) missedSince(viewer Viewer, lastEventID string) []Event {
+	resync := []Event{{ID: fmt.Sprintf("%s-%d", h.epoch, h.seq), Type: TypeResync, Time: time.Now()}}
+
+	epoch, seqText, ok := strings.Cut(lastEventID, "-")
+	if !ok || epoch != h.epoch {
+		return resync
+	}
+
+	seq, err := strconv.ParseUint(seqText, 10, 64)
+	if err != nil || seq > h.seq {
+		return resync
+	}
+
+	// Nothing missed, or everything missed is still kept
+	oldest := h.seq + 1
+	if len(h.history) > 0 {
+		oldest = h.history[0].seq
+	}
+	if seq+1 < oldest {
+		return resync
+	}
+
+	var missed []Event
+	for _, event := range h.history {
+		if event.seq > seq && viewer.canSee(event) {
+			missed = append(missed, event)
+		}
+	}
+
+	return missed
+}
+
+// drop removes a subscriber and closes its channel. It must be called with h.mu held.
+func (h *Hub) drop(sub *Subscription) {
+	if sub.dropped {
+		return
+	}
+
+	sub.dropped = true
+	delete(h.subscribers, sub)
+	close(sub.events)
+}
+
+// Publish publishes an event on the application hub (see Hub.Publish).
+func Publish(event Event) {
+	defaultHub.Publish(event)
+}
+
+// Subscribe registers a subscriber with the application hub (see Hub.Subscribe).
+func Subscribe(viewer Viewer, lastEventID string) (*Subscription, []Event) {
+	return defaultHub.Subscribe(viewer, lastEventID)
+}
+
+// Unsubscribe removes a subscriber from the application hub (see Hub.Unsubscribe).
+func Unsubscribe(sub *Subscription) {
+	defaultHub.Unsubscribe(sub)
+}
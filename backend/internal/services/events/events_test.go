@@ -0,0 +1,163 @@
+package events
+
+import "testing"
+
+// received drains the events buffered in a subscription without blocking.
+func received(sub *Subscription) []Event {
+	var events []Event
+	for {
+		select {
+		case event, ok := <-sub.Events():
+			if !ok {
+				return events
+			}
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+// closed reports whether the channel of a subscription is closed and drained.
+func closed(sub *Subscription) bool {
+	select {
+	case _, ok := <-sub.Events():
+		return !ok
+	default:
+		return false
+	}
+}
+
+func TestSubscribeAudience(t *testing.T) {
+	hub := NewHub(10, 10)
+
+	anonymous, _ := hub.Subscribe(Viewer{}, "")
+	adopter, _ := hub.Subscribe(Viewer{UserID: 1}, "")
+	staff, _ := hub.Subscribe(Viewer{UserID: 2, OrganizationIDs: []uint{7}}, "")
+	otherStaff, _ := hub.Subscribe(Viewer{UserID: 3, OrganizationIDs: []uint{8}}, "")
+	admin, _ := hub.Subscribe(Viewer{UserID: 4, AllOrganizations: true}, "")
+
+	hub.Publish(Event{Type: TypePetCreated, Public: true})
+	hub.Publish(Event{Type: TypeApplicationUpdated, OrganizationID: 7, UserID: 1})
+	hub.Publish(Event{Type: TypeNewMessage, UserID: 2})
+	hub.Publish(Event{Type: TypeNewMessage, OrganizationID: 8})
+
+	tests := []struct {
+		name string
+		sub  *Subscription
+		want []string
+	}{
+		{name: "anonymous", sub: anonymous, want: []string{TypePetCreated}},
+		{name: "adopter", sub: adopter, want: []string{TypePetCreated, TypeApplicationUpdated}},
+		{name: "staff", sub: staff, want: []string{TypePetCreated, TypeApplicationUpdated, TypeNewMessage}},
+		{name: "staff of another organisation", sub: otherStaff, want: []string{TypePetCreated, TypeNewMessage}},
+		{name: "admin", sub: admin, want: []string{TypePetCreated, TypeApplicationUpdated, TypeNewMessage}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := received(tt.sub)
+			if len(got) != len(tt.want) {
+				t.Fatalf("received %d events %v, want %v", len(got), got, tt.want)
+			}
+			for i := range got {
+				if got[i].Type != tt.want[i] {
+					t.Errorf("event %d = %s, want %s", i, got[i].Type, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestUnsubscribe(t *testing.T) {
+	hub := NewHub(10, 10)
+
+	sub, _ := hub.Subscribe(Viewer{}, "")
+	other, _ := hub.Subscribe(Viewer{}, "")
+
+	hub.Unsubscribe(sub)
+	if !closed(sub) {
+		t.Fatal("channel still open after Unsubscribe")
+	}
+
+	// Unsubscribing twice has no effect, and publishing skips removed subscribers
+	hub.Unsubscribe(sub)
+	hub.Publish(Event{Type: TypePetCreated, Public: true})
+
+	if got := len(received(other)); got != 1 {
+		t.Errorf("remaining subscriber received %d events, want 1", got)
+	}
+	if got := len(hub.subscribers); got != 1 {
+		t.Errorf("hub has %d subscribers, want 1", got)
+	}
+}
+
+func TestSlowSubscriberDropped(t *testing.T) {
+	hub := NewHub(10, 2)
+
+	slow, _ := hub.Subscribe(Viewer{}, "")
+	for range 3 {
+		hub.Publish(Event{Type: TypePetCreated, Public: true})
+	}
+
+	if got := received(slow); len(got) != 2 {
+		t.Fatalf("slow subscriber received %d events, want the 2 buffered", len(got))
+	}
+	if !closed(slow) {
+		t.Fatal("slow subscriber not dropped")
+	}
+
+	// Unsubscribing a dropped subscriber must not close its channel again
+	hub.Unsubscribe(slow)
+}
+
+func TestSubscribeResume(t *testing.T) {
+	hub := NewHub(3, 10)
+
+	// The history keeps the last 3 of 4 events; the subscriber sees events 1, 2 and 4
+	var ids []string
+	sub, _ := hub.Subscribe(Viewer{UserID: 1}, "")
+	for _, event := range []Event{
+		{Type: TypePetCreated, Public: true},
+		{Type: TypeNewMessage, UserID: 1},
+		{Type: TypeNewMessage, UserID: 2},
+		{Type: TypePetStatusChanged, Public: true},
+	} {
+		hub.Publish(event)
+	}
+	for _, event := range received(sub) {
+		ids = append(ids, event.ID)
+	}
+	hub.Unsubscribe(sub)
+
+	tests := []struct {
+		name        string
+		lastEventID string
+		want        []string
+	}{
+		{name: "new stream", lastEventID: "", want: nil},
+		{name: "up to date", lastEventID: ids[2], want: nil},
+		{name: "some events missed", lastEventID: ids[1], want: []string{TypePetStatusChanged}},
+		{name: "everything missed still kept", lastEventID: ids[0], want: []string{TypeNewMessage, TypePetStatusChanged}},
+		{name: "events no longer kept", lastEventID: hub.epoch + "-0", want: []string{TypeResync}},
+		{name: "previous epoch", lastEventID: "old-1", want: []string{TypeResync}},
+		{name: "future sequence", lastEventID: hub.epoch + "-99", want: []string{TypeResync}},
+		{name: "malformed", lastEventID: "garbage", want: []string{TypeResync}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resumed, missed := hub.Subscribe(Viewer{UserID: 1}, tt.lastEventID)
+			defer hub.Unsubscribe(resumed)
+
+			if len(missed) != len(tt.want) {
+				t.Fatalf("missed %d events %v, want %v", len(missed), missed, tt.want)
+			}
+			for i := range missed {
+				if missed[i].Type != tt.want[i] {
+					t.Errorf("missed event %d = %s, want %s", i, missed[i].Type, tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+// Package imaging implements image validation and processing for user uploads.
+// This package is responsible for:
+// - Detecting the real content type of uploaded files (ignoring client headers)
+// - Re-encoding images so that EXIF and other metadata are stripped
+// - Applying EXIF orientation before metadata is discarded
+// - Generating resized thumbnails
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+
+	_ "image/gif"
+
+	xdraw "golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+// MaxPixels is the maximum number of pixels accepted in a decoded image.
+// It protects the server against decompression bombs.
+const MaxPixels = 40_000_000
+
+// ErrUnsupportedType is returned when the uploaded content is not an accepted image format.
+var ErrUnsupportedType = errors.New("tipo de imagen no soportado")
+
+// ErrInvalidImage is returned when the uploaded content cannot be decoded as an image.
+var ErrInvalidImage = errors.New("imagen inválida")
+
+// supportedTypes lists the accepted MIME types detected by content sniffing.
+var supportedTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+	"image/webp": true,
+}
+
+// Processed is an encoded image ready to be stored.
+type Processed struct {
+	Data        []byte // Encoded image bytes
+	ContentType string // MIME type of Data
+	Extension   string // File extension matching ContentType (without dot)
+	Width       int    // Width in pixels
+	Height      int    // Height in pixels
+}
+
+// Sniff detects the content type of data from its first bytes and verifies it is supported.
+//
+// Parameters:
+//   - data: Raw uploaded bytes
+//
+// Returns:
+//   - string: Detected MIME type
+//   - error: ErrUnsupportedType if the content is not an accepted image
+func Sniff(data []byte) (string, error) {
+	contentType := http.DetectContentType(data)
+	if !supportedTypes[contentType] {
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedType, contentType)
+	}
+
+	return contentType, nil
+}
+
+// Decode decodes an uploaded image, rejecting oversized images and applying
+// the EXIF orientation of JPEG files so the pixels are stored upright.
+//
+// Parameters:
+//   - data: Raw uploaded bytes
+//
+// Returns:
+//   - image.Image: Decoded and oriented image
+//   - error: ErrInvalidImage if decoding fails or the image is too large
+func Decode(data []byte) (image.Image, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > MaxPixels {
+		return nil, fmt.Errorf("%w: dimensiones %dx%d no permitidas", ErrInvalidImage, cfg.Width, cfg.Height)
+	}
+
+	img, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+
+	if format == "jpeg" {
+		img = orient(img, jpegOrientation(data))
+	}
+
+	return img, nil
+}
+
+// Sanitize re-encodes a decoded image without any metadata.
+// Images with transparency are encoded as PNG, everything else as JPEG.
+//
+// Parameters:
+//   - img: Decoded image
+//
+// Returns:
+//   - *Processed: Re-encoded image
+//   - error: Encoding error or nil on success
+func Sanitize(img image.Image) (*Processed, error) {
+	if hasAlpha(img) {
+		return encodePNG(img)
+	}
+
+	return encodeJPEG(img, 90)
+}
+
+// Thumbnail scales img to fit inside a square of maxSide pixels, preserving the
+// aspect ratio, and encodes it as JPEG. Images are never upscaled.
+//
+// Parameters:
+//   - img: Decoded image
+//   - maxSide: Maximum width and height of the thumbnail in pixels
+//
+// Returns:
+//   - *Processed: Encoded thumbnail
+//   - error: Encoding error or nil on success
+func Thumbnail(img image.Image, maxSide int) (*Processed, error) {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	if width > maxSide || height > maxSide {
+		if width >= height {
+			height = max(1, height*maxSide/width)
+			width = maxSide
+		} else {
+			width = max(1, width*maxSide/height)
+			height = maxSide
+		}
+	}
+
+	// Paint on white so transparent areas do not turn black in JPEG
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	xdraw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, xdraw.Over, nil)
+
+	return encodeJPEG(dst, 85)
+}
+
+func encodeJPEG(img image.Image, quality int) (*Processed, error) {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality}); err != nil {
+		return nil, fmt.Errorf("error al codificar JPEG: %v", err)
+	}
+
+	return &Processed{
+		Data:        buf.Bytes(),
+		ContentType: "image/jpeg",
+		Extension:   "jpg",
+		Width:       img.Bounds().Dx(),
+		Height:      img.Bounds().Dy(),
+	}, nil
+}
+
+func encodePNG(img image.Image) (*Processed, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("error al codificar PNG: %v", err)
+	}
+
+	return &Processed{
+		Data:        buf.Bytes(),
+		ContentType: "image/png",
+		Extension:   "png",
+		Width:       img.Bounds().Dx(),
+		Height:      img.Bounds().Dy(),
+	}, nil
+}
+
+// hasAlpha reports whether img contains any non-opaque pixel.
+func hasAlpha(img image.Image) bool {
+	if opaque, ok := img.(interface{ Opaque() bool }); ok {
+		return !opaque.Opaque()
+	}
+
+	return false
+}
@@ -0,0 +1,255 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+// encode returns img encoded as PNG or JPEG.
+func encode(t *testing.T, img image.Image, format string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	var err error
+	if format == "png" {
+		err = png.Encode(&buf, img)
+	} else {
+		err = jpeg.Encode(&buf, img, nil)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+// pngHeader returns the signature and IHDR chunk of a PNG declaring the given size.
+// It is enough for image.DecodeConfig, which never reads the pixel data.
+func pngHeader(width uint32, height uint32) []byte {
+	ihdr := make([]byte, 17)
+	copy(ihdr, "IHDR")
+	binary.BigEndian.PutUint32(ihdr[4:], width)
+	binary.BigEndian.PutUint32(ihdr[8:], height)
+	copy(ihdr[12:], []byte{8, 6, 0, 0, 0})
+
+	data := []byte("\x89PNG\r\n\x1a\n")
+	data = binary.BigEndian.AppendUint32(data, 13)
+	data = append(data, ihdr...)
+	return binary.BigEndian.AppendUint32(data, crc32.ChecksumIEEE(ihdr))
+}
+
+// withOrientation inserts an EXIF APP1 segment with the given orientation after the SOI marker of a JPEG.
+func withOrientation(data []byte, orientation uint16) []byte {
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08")
+	tiff = binary.BigEndian.AppendUint16(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, 0x0112)
+	tiff = binary.BigEndian.AppendUint16(tiff, 3)
+	tiff = binary.BigEndian.AppendUint32(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, orientation)
+	tiff = append(tiff, 0, 0, 0, 0, 0, 0)
+
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+	app1 := []byte{0xFF, 0xE1}
+	app1 = binary.BigEndian.AppendUint16(app1, uint16(len(segment)+2))
+	app1 = append(app1, segment...)
+
+	out := append([]byte{}, data[:2]...)
+	out = append(out, app1...)
+	return append(out, data[2:]...)
+}
+
+func TestSniff(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+
+	tests := []struct {
+		name string
+		data []byte
+		want string
+		err  error
+	}{
+		{name: "jpeg", data: encode(t, img, "jpeg"), want: "image/jpeg"},
+		{name: "png", data: encode(t, img, "png"), want: "image/png"},
+		{name: "gif", data: []byte("GIF89a\x01\x00\x01\x00"), want: "image/gif"},
+		{name: "webp", data: []byte("RIFF\x00\x00\x00\x00WEBPVP8 "), want: "image/webp"},
+		{name: "text", data: []byte("hola"), err: ErrUnsupportedType},
+		{name: "pdf", data: []byte("%PDF-1.7\n"), err: ErrUnsupportedType},
+		{name: "html disguised as image", data: []byte("<html><script>alert(1)</script>"), err: ErrUnsupportedType},
+		{name: "empty", data: nil, err: ErrUnsupportedType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Sniff(tt.data)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Sniff error = %v, want %v", err, tt.err)
+			}
+			if got != tt.want {
+				t.Errorf("Sniff = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	valid := encode(t, image.NewRGBA(image.Rect(0, 0, 4, 2)), "png")
+
+	tests := []struct {
+		name string
+		data []byte
+		err  error
+	}{
+		{name: "valid", data: valid},
+		{name: "truncated", data: valid[:len(valid)/2], err: ErrInvalidImage},
+		{name: "not an image", data: []byte("hola"), err: ErrInvalidImage},
+		{name: "decompression bomb", data: pngHeader(100000, 100000), err: ErrInvalidImage},
+		{name: "zero width", data: pngHeader(0, 10), err: ErrInvalidImage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img, err := Decode(tt.data)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Decode error = %v, want %v", err, tt.err)
+			}
+			if err == nil && img.Bounds().Dx() != 4 {
+				t.Errorf("Decode width = %d, want 4", img.Bounds().Dx())
+			}
+		})
+	}
+}
+
+func TestDecodeMaxPixelsGuard(t *testing.T) {
+	// A header at exactly MaxPixels passes the guard and only fails because the pixel data is missing,
+	// one more row is rejected before decoding starts
+	_, err := Decode(pngHeader(8000, 5000))
+	if err == nil || strings.Contains(err.Error(), "dimensiones") {
+		t.Errorf("Decode(8000x5000) error = %v, want a decoding error", err)
+	}
+
+	_, err = Decode(pngHeader(8000, 5001))
+	if err == nil || !strings.Contains(err.Error(), "dimensiones 8000x5001") {
+		t.Errorf("Decode(8000x5001) error = %v, want dimensions rejected", err)
+	}
+}
+
+func TestDecodeOrientation(t *testing.T) {
+	// A 4x2 image with a red top-left pixel
+	img := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 4; x++ {
+			img.Set(x, y, color.White)
+		}
+	}
+	img.Set(0, 0, color.RGBA{R: 255, A: 255})
+	data := encode(t, img, "jpeg")
+
+	tests := []struct {
+		name        string
+		orientation uint16
+		width       int
+		height      int
+	}{
+		{name: "normal", orientation: 1, width: 4, height: 2},
+		{name: "rotate 180", orientation: 3, width: 4, height: 2},
+		{name: "rotate 90 clockwise", orientation: 6, width: 2, height: 4},
+		{name: "rotate 90 counter-clockwise", orientation: 8, width: 2, height: 4},
+		{name: "out of range ignored", orientation: 9, width: 4, height: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tagged := withOrientation(data, tt.orientation)
+			if got := jpegOrientation(tagged); tt.orientation <= 8 && got != int(tt.orientation) {
+				t.Fatalf("jpegOrientation = %d, want %d", got, tt.orientation)
+			}
+
+			decoded, err := Decode(tagged)
+			if err != nil {
+				t.Fatalf("Decode error = %v", err)
+			}
+			if decoded.Bounds().Dx() != tt.width || decoded.Bounds().Dy() != tt.height {
+				t.Errorf("Decode size = %dx%d, want %dx%d", decoded.Bounds().Dx(), decoded.Bounds().Dy(), tt.width, tt.height)
+			}
+		})
+	}
+}
+
+func TestSanitizeStripsMetadata(t *testing.T) {
+	opaque := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 4; x++ {
+			opaque.Set(x, y, color.White)
+		}
+	}
+	transparent := image.NewNRGBA(image.Rect(0, 0, 4, 2))
+
+	tests := []struct {
+		name        string
+		data        []byte
+		contentType string
+	}{
+		{name: "jpeg with exif", data: withOrientation(encode(t, opaque, "jpeg"), 6), contentType: "image/jpeg"},
+		{name: "png with transparency", data: encode(t, transparent, "png"), contentType: "image/png"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img, err := Decode(tt.data)
+			if err != nil {
+				t.Fatalf("Decode error = %v", err)
+			}
+
+			processed, err := Sanitize(img)
+			if err != nil {
+				t.Fatalf("Sanitize error = %v", err)
+			}
+			if processed.ContentType != tt.contentType {
+				t.Errorf("ContentType = %s, want %s", processed.ContentType, tt.contentType)
+			}
+			if bytes.Contains(processed.Data, []byte("Exif")) {
+				t.Error("sanitized image still contains EXIF data")
+			}
+			if jpegOrientation(processed.Data) != 1 {
+				t.Error("sanitized image still carries an orientation tag")
+			}
+		})
+	}
+}
+
+func TestThumbnail(t *testing.T) {
+	tests := []struct {
+		name          string
+		width, height int
+		maxSide       int
+		wantW, wantH  int
+	}{
+		{name: "landscape", width: 800, height: 400, maxSide: 200, wantW: 200, wantH: 100},
+		{name: "portrait", width: 100, height: 400, maxSide: 200, wantW: 50, wantH: 200},
+		{name: "square", width: 300, height: 300, maxSide: 200, wantW: 200, wantH: 200},
+		{name: "never upscaled", width: 50, height: 30, maxSide: 200, wantW: 50, wantH: 30},
+		{name: "thin strip keeps one pixel", width: 1000, height: 1, maxSide: 200, wantW: 200, wantH: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			thumb, err := Thumbnail(image.NewNRGBA(image.Rect(0, 0, tt.width, tt.height)), tt.maxSide)
+			if err != nil {
+				t.Fatalf("Thumbnail error = %v", err)
+			}
+			if thumb.Width != tt.wantW || thumb.Height != tt.wantH {
+				t.Errorf("Thumbnail size = %dx%d, want %dx%d", thumb.Width, thumb.Height, tt.wantW, tt.wantH)
+			}
+			if thumb.ContentType != "image/jpeg" || thumb.Extension != "jpg" {
+				t.Errorf("Thumbnail type = %s/%s, want image/jpeg/jpg", thumb.ContentType, thumb.Extension)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package mailer
+
+import (
+	"bytes"
+
+	"github.com/go-mail/mail"
+)
+
+// AdoptionContractData is the content of the email sent to the adopter with the adoption contract.
+type AdoptionContractData struct {
+	AdopterName  string
+	PetName      string
+	Organization string // Name of the organisation giving the pet in adoption
+	Number       string // Contract number
+	Date         string // Adoption date, formatted for display
+	Hash         string // SHA-256 of the contract PDF (hex)
+}
+
+// ComposeAdoptionContract builds the email delivering the adoption contract PDF to the adopter.
+//
+// Parameters:
+//   - to: Adopter email address
+//   - locale: Adopter locale
+//   - data: Email content
+//   - filename: Name of the attached PDF
+//   - contract: Contract PDF
+//   - sender: Organisation sender identity
+//
+// Returns:
+//   - *Email: Email to queue, see Queue
+//   - error: Template error
+func ComposeAdoptionContract(to string, locale string, data AdoptionContractData, filename string, contract []byte, sender Sender) (*Email, error) {
+	email, err := newEmail("adoption_contract", locale, data, to, sender)
+	if err != nil {
+		return nil, err
+	}
+
+	email.message.AttachReader(filename, bytes.NewReader(contract), mail.SetHeader(map[string][]string{
+		"Content-Type": {`application/pdf; name="` + filename + `"`},
+	}))
+
+	return email, nil
+}
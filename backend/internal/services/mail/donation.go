@@ -0,0 +1,74 @@
+package mailer
+
+import (
+	"bytes"
+
+	"github.com/go-mail/mail"
+)
+
+// DonationRenewalData is the content of the email asking a donor to pay the next charge of a recurring donation.
+type DonationRenewalData struct {
+	DonorName    string
+	Organization string // Name of the organisation receiving the donation
+	PetName      string // Sponsored pet (empty for general donations)
+	Frequency    string // Donation frequency (monthly or yearly), translated by the template
+	Amount       string // Formatted amount, e.g. "10,00 EUR"
+	Date         string // Charge date, formatted for display
+	CheckoutURL  string // Hosted checkout page of the payment
+}
+
+// DonationReceiptData is the content of the email sent to a donor with the yearly donation receipt.
+type DonationReceiptData struct {
+	DonorName    string
+	Organization string // Name of the organisation issuing the receipt
+	Year         int    // Year of the donations
+	Number       string // Receipt number
+	Total        string // Formatted total, e.g. "120,00 EUR"
+	Hash         string // SHA-256 of the receipt PDF (hex)
+}
+
+// ComposeDonationRenewal builds the email with the checkout link of the next charge of a recurring donation.
+//
+// Parameters:
+//   - to: Donor email address
+//   - locale: Donor locale
+//   - data: Email content
+//   - sender: Organisation sender identity
+//
+// Returns:
+//   - *Email: Email to queue, see Queue
+//   - error: Template error
+func ComposeDonationRenewal(to string, locale string, data DonationRenewalData, sender Sender) (*Email, error) {
+	email, err := newEmail("donation_renewal", locale, data, to, sender)
+	if err != nil {
+		return nil, err
+	}
+
+	return email, nil
+}
+
+// ComposeDonationReceipt builds the email delivering the yearly donation receipt PDF to the donor.
+//
+// Parameters:
+//   - to: Donor email address
+//   - locale: Donor locale
+//   - data: Email content
+//   - filename: Name of the attached PDF
+//   - receipt: Receipt PDF
+//   - sender: Organisation sender identity
+//
+// Returns:
+//   - *Email: Email to queue, see Queue
+//   - error: Template error
+func ComposeDonationReceipt(to string, locale string, data DonationReceiptData, filename string, receipt []byte, sender Sender) (*Email, error) {
+	email, err := newEmail("donation_receipt", locale, data, to, sender)
+	if err != nil {
+		return nil, err
+	}
+
+	email.message.AttachReader(filename, bytes.NewReader(receipt), mail.SetHeader(map[string][]string{
+		"Content-Type": {`application/pdf; name="` + filename + `"`},
+	}))
+
+	return email, nil
+}
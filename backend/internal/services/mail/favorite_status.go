@@ -0,0 +1,30 @@
+package mailer
+
+// FavoriteStatusData is the content of the email sent when a favourite pet changes status.
+type FavoriteStatusData struct {
+	UserName string
+	PetName  string
+	Status   string // New pet status (reserved or adopted), translated by the template
+	PetURL   string
+}
+
+// ComposeFavoriteStatusChange builds the email telling a user that one of their favourite pets was reserved or adopted.
+// The email is sent with the sender identity of the organisation that owns the pet.
+//
+// Parameters:
+//   - to: User email address
+//   - locale: User locale
+//   - data: Email content
+//   - sender: Organisation sender identity
+//
+// Returns:
+//   - *Email: Email to queue, see Queue
+//   - error: Template error
+func ComposeFavoriteStatusChange(to string, locale string, data FavoriteStatusData, sender Sender) (*Email, error) {
+	email, err := newEmail("favorite_status", locale, data, to, sender)
+	if err != nil {
+		return nil, err
+	}
+
+	return email, nil
+}
@@ -0,0 +1,92 @@
+package mailer
+
+import (
+	models "backend/internal/models"
+	"strings"
+)
+
+// labels translates the codes passed in email data (pet statuses, donation frequencies,
+// lost and found report types and matching criteria) and the shared layout strings.
+// Keys are "<group>.<code>"; templates read them with the label function.
+var labels = map[string]map[string]string{
+	models.LocaleSpanish: {
+		"layout.system":       "Sistema de Adopciones",
+		"pet_status.reserved": "reservada",
+		"pet_status.adopted":  "adoptada",
+		"frequency.monthly":   "mensual",
+		"frequency.yearly":    "anual",
+		"lost_found.lost":     "perdido",
+		"lost_found.found":    "encontrado",
+		"reason.microchip":    "microchip",
+		"reason.species":      "especie",
+		"reason.breed":        "raza",
+		"reason.color":        "color",
+	},
+	models.LocaleEnglish: {
+		"layout.system":       "Adoption System",
+		"pet_status.reserved": "reserved",
+		"pet_status.adopted":  "adopted",
+		"frequency.monthly":   "monthly",
+		"frequency.yearly":    "yearly",
+		"lost_found.lost":     "lost",
+		"lost_found.found":    "found",
+		"reason.microchip":    "microchip",
+		"reason.species":      "species",
+		"reason.breed":        "breed",
+		"reason.color":        "colour",
+	},
+	models.LocaleCatalan: {
+		"layout.system":       "Sistema d'Adopcions",
+		"pet_status.reserved": "reservada",
+		"pet_status.adopted":  "adoptada",
+		"frequency.monthly":   "mensual",
+		"frequency.yearly":    "anual",
+		"lost_found.lost":     "perdut",
+		"lost_found.found":    "trobat",
+		"reason.microchip":    "microxip",
+		"reason.species":      "espècie",
+		"reason.breed":        "raça",
+		"reason.color":        "color",
+	},
+}
+
+// IsSupportedLocale reports whether emails can be rendered in a locale (see models.Locales).
+func IsSupportedLocale(locale string) bool {
+	_, ok := labels[locale]
+	return ok
+}
+
+// resolveLocale returns the locale an email is rendered in.
+// Regional variants ("en-GB") use their language, and unsupported or empty locales fall back to models.DefaultLocale.
+func resolveLocale(locale string) string {
+	locale = strings.ToLower(strings.TrimSpace(locale))
+	if i := strings.IndexAny(locale, "-_"); i >= 0 {
+		locale = locale[:i]
+	}
+
+	if !IsSupportedLocale(locale) {
+		return models.DefaultLocale
+	}
+
+	return locale
+}
+
+// label translates a code of a label group into a locale.
+// Unknown codes are returned unchanged so a new value never breaks an email.
+func label(locale string, group string, code string) string {
+	if text, ok := labels[locale][group+"."+code]; ok {
+		return text
+	}
+
+	return code
+}
+
+// labelList translates a list of codes of a label group and joins them with commas.
+func labelList(locale string, group string, codes []string) string {
+	translated := make([]string, len(codes))
+	for i, code := range codes {
+		translated[i] = label(locale, group, code)
+	}
+
+	return strings.Join(translated, ", ")
+}
@@ -0,0 +1,39 @@
+package mailer
+
+// LostFoundMatchPet is a shelter pet listed as a possible match for a report.
+type LostFoundMatchPet struct {
+	Name    string
+	Species string
+	Breed   string
+	Reasons []string // Matching criteria (microchip, species, breed, color), translated by the template
+	URL     string
+}
+
+// LostFoundMatchData is the content of the email sent when a report gets new candidate pets.
+type LostFoundMatchData struct {
+	ReporterName  string
+	ReportType    string // Report type (lost or found), translated by the template
+	ReportSpecies string // Species of the reported animal
+	SeenDate      string // Date the animal was lost or found, formatted for display
+	Pets          []LostFoundMatchPet
+	ShelterEmail  string // Address the reporter should contact to check the candidates
+}
+
+// ComposeLostFoundMatch builds the email telling the reporter of a lost or found report about new candidate pets.
+//
+// Parameters:
+//   - to: Reporter email address
+//   - locale: Reporter locale (models.DefaultLocale for guests)
+//   - data: Email content
+//
+// Returns:
+//   - *Email: Email to queue, see Queue
+//   - error: Template error
+func ComposeLostFoundMatch(to string, locale string, data LostFoundMatchData) (*Email, error) {
+	email, err := newEmail("lost_found_match", locale, data, to, DefaultSender)
+	if err != nil {
+		return nil, err
+	}
+
+	return email, nil
+}
@@ -1,114 +1,35 @@
 package mailer
 
-import (
-	"bytes"
-	_ "embed"
-	"log"
-	"text/template"
-
-	"github.com/go-mail/mail"
-)
-
-//go:embed templates/password.html
-var passwordTemplate string
+import models "backend/internal/models"
 
+// PasswordData is the content of the email delivering a new password.
 type PasswordData struct {
 	Password string
 }
 
-//go:embed templates/2fa.html
-var twoFATemplate string
-
+// TwoFAData is the content of the email delivering a 2FA code.
 type TwoFAData struct {
 	Code string
 }
 
-func SendMail(to string, subject string, body string) error {
-	m := mail.NewMessage()
-	m.SetHeader("From", "Adoption System <zanckor002@gmail.com>")
-	m.SetHeader("To", to)
-	m.SetHeader("Subject", subject)
-	m.SetBody("text/plain", body)
-
-	d := mail.NewDialer("smtp.gmail.com", 465, "zanckor002@gmail.com", "caib nqve pbrw gqjq")
-	d.StartTLSPolicy = mail.MandatoryStartTLS
-
-	if err := d.DialAndSend(m); err != nil {
-		log.Printf("could not send email: %v", err)
-		return err
-	}
-
-	return nil
-}
-
-func Send2FAToken(to string, _2fa string) error {
-	m := mail.NewMessage()
-	m.SetHeader("From", "Adoption System <zanckor002@gmail.com>")
-	m.SetHeader("To", to)
-	m.SetHeader("Subject", "Código de Autenticación 2FA")
-
-	data := TwoFAData{Code: _2fa}
-
-	tmpl, err := template.New("2fa").Parse(twoFATemplate)
+// Compose2FAToken builds the outbox email delivering a 2FA code.
+// The caller stores it together with the code (see dao.UpdateTwoFactorCode) and then calls DeliverAsync.
+func Compose2FAToken(to string, locale string, _2fa string) (*models.OutboxEmail, error) {
+	email, err := newEmail("2fa", locale, TwoFAData{Code: _2fa}, to, DefaultSender)
 	if err != nil {
-		log.Printf("error parsing 2fa template: %v", err)
-		return err
+		return nil, err
 	}
 
-	var htmlBody bytes.Buffer
-	if err := tmpl.Execute(&htmlBody, data); err != nil {
-		log.Printf("error executing 2fa template: %v", err)
-		return err
-	}
-
-	plainBody := "Tu código de autenticación 2FA es: " + _2fa
-
-	m.SetBody("text/plain", plainBody)
-	m.AddAlternative("text/html", htmlBody.String())
-
-	d := mail.NewDialer("smtp.gmail.com", 465, "zanckor002@gmail.com", "caib nqve pbrw gqjq")
-	d.StartTLSPolicy = mail.MandatoryStartTLS
-
-	if err := d.DialAndSend(m); err != nil {
-		log.Printf("could not send email: %v", err)
-		return err
-	}
-
-	return nil
+	return compose(email.message)
 }
 
-func SendPassword(to string, password string) error {
-	m := mail.NewMessage()
-	m.SetHeader("From", "Adoption System <zanckor002@gmail.com>")
-	m.SetHeader("To", to)
-	m.SetHeader("Subject", "Tu nueva contraseña")
-
-	data := PasswordData{Password: password}
-
-	tmpl, err := template.New("password").Parse(passwordTemplate)
+// ComposePassword builds the outbox email delivering a new password.
+// The caller stores it together with the password change (see dao.SetChangePasswordFlag) and then calls DeliverAsync.
+func ComposePassword(to string, locale string, password string) (*models.OutboxEmail, error) {
+	email, err := newEmail("password", locale, PasswordData{Password: password}, to, DefaultSender)
 	if err != nil {
-		log.Printf("error parsing password template: %v", err)
-		return err
-	}
-
-	var htmlBody bytes.Buffer
-	if err := tmpl.Execute(&htmlBody, data); err != nil {
-		log.Printf("error executing password template: %v", err)
-		return err
-	}
-
-	plainBody := "Tu nueva contraseña es: " + password
-
-	m.SetBody("text/plain", plainBody)
-	m.AddAlternative("text/html", htmlBody.String())
-
-	d := mail.NewDialer("smtp.gmail.com", 465, "zanckor002@gmail.com", "caib nqve pbrw gqjq")
-	d.StartTLSPolicy = mail.MandatoryStartTLS
-
-	if err := d.DialAndSend(m); err != nil {
-		log.Printf("could not send email: %v", err)
-		return err
+		return nil, err
 	}
 
-	return nil
+	return compose(email.message)
 }
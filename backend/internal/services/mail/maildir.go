@@ -0,0 +1,71 @@
+package mailer
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// MaildirTransport writes every message to a Maildir instead of sending it.
+// It is intended for development: the directory can be opened with any mail client (e.g. mutt -f ./mail).
+type MaildirTransport struct {
+	dir      string
+	hostname string
+	count    atomic.Uint64
+}
+
+// NewMaildir creates a maildir transport rooted at dir, creating its tmp, new and cur directories if needed.
+//
+// Parameters:
+//   - dir: Maildir directory
+//
+// Returns:
+//   - *MaildirTransport: Initialised maildir transport
+//   - error: Filesystem error or nil on success
+func NewMaildir(dir string) (*MaildirTransport, error) {
+	for _, sub := range []string{"tmp", "new", "cur"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0o755); err != nil {
+			return nil, fmt.Errorf("error al crear maildir %s: %v", dir, err)
+		}
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "localhost"
+	}
+
+	// Maildir file names cannot contain slashes or colons
+	hostname = strings.NewReplacer("/", "_", ":", "_").Replace(hostname)
+
+	return &MaildirTransport{dir: dir, hostname: hostname}, nil
+}
+
+// Send writes the message to tmp and moves it into new, so readers never see partial messages.
+// The envelope is recorded in Return-Path and Delivered-To headers, as a local delivery would.
+func (t *MaildirTransport) Send(from string, to []string, message []byte) error {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "Return-Path: <%s>\r\n", from)
+	for _, recipient := range to {
+		fmt.Fprintf(&b, "Delivered-To: %s\r\n", recipient)
+	}
+	b.Write(message)
+
+	now := time.Now()
+	name := fmt.Sprintf("%d.M%dP%dQ%d.%s", now.Unix(), now.Nanosecond()/1000, os.Getpid(), t.count.Add(1), t.hostname)
+
+	tmp := filepath.Join(t.dir, "tmp", name)
+	if err := os.WriteFile(tmp, b.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("error al escribir email en maildir: %v", err)
+	}
+
+	if err := os.Rename(tmp, filepath.Join(t.dir, "new", name)); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("error al escribir email en maildir: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package mailer
+
+// MedicalReminderItem is a vaccination or treatment listed in the staff reminder digest.
+type MedicalReminderItem struct {
+	PetName string
+	Name    string // Vaccine or treatment name
+	Date    string // Due date or end date, formatted for display
+	URL     string // Link to the pet
+}
+
+// MedicalReminderData is the content of the daily staff reminder digest.
+type MedicalReminderData struct {
+	UserName   string
+	WindowDays int
+	Overdue    []MedicalReminderItem // Vaccinations whose due date has passed
+	Upcoming   []MedicalReminderItem // Vaccinations due within the window
+	Treatments []MedicalReminderItem // Treatments ending within the window
+}
+
+// ComposeMedicalReminderDigest builds the daily digest of vaccinations and treatments due for a staff member.
+// The digest covers one organisation and is sent with its sender identity.
+//
+// Parameters:
+//   - to: Staff member email address
+//   - locale: Staff member locale
+//   - data: Email content
+//   - sender: Organisation sender identity
+//
+// Returns:
+//   - *Email: Email to queue, see Queue
+//   - error: Template error
+func ComposeMedicalReminderDigest(to string, locale string, data MedicalReminderData, sender Sender) (*Email, error) {
+	email, err := newEmail("medical_reminder", locale, data, to, sender)
+	if err != nil {
+		return nil, err
+	}
+
+	return email, nil
+}
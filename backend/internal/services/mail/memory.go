@@ -0,0 +1,113 @@
+package mailer
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	netmail "net/mail"
+	"slices"
+	"sync"
+	"time"
+)
+
+// SentMessage is a message recorded by MemoryTransport.
+type SentMessage struct {
+	From    string    // Envelope sender
+	To      []string  // Envelope recipients
+	Message []byte    // Complete MIME message
+	SentAt  time.Time // When the message was sent
+}
+
+// Parse parses the headers and body of the message.
+func (s SentMessage) Parse() (*netmail.Message, error) {
+	return netmail.ReadMessage(bytes.NewReader(s.Message))
+}
+
+// Header returns the decoded value of a header of the message, or "" if it is missing.
+func (s SentMessage) Header(name string) string {
+	msg, err := s.Parse()
+	if err != nil {
+		return ""
+	}
+
+	value := msg.Header.Get(name)
+	if decoded, err := new(mime.WordDecoder).DecodeHeader(value); err == nil {
+		return decoded
+	}
+
+	return value
+}
+
+// MemoryTransport keeps the sent messages in memory instead of sending them.
+// It is intended for tests, which install it with Use and assert on Messages:
+//
+//	transport := mailer.NewMemory()
+//	mailer.Use(transport)
+//	// ... call the code under test ...
+//	sent, err := transport.Wait(1, 5*time.Second)
+//	// sent[0].To, sent[0].Header("Subject") ...
+type MemoryTransport struct {
+	mu   sync.Mutex
+	sent []SentMessage
+}
+
+// NewMemory creates an empty memory transport.
+func NewMemory() *MemoryTransport {
+	return &MemoryTransport{}
+}
+
+// Send records the message.
+func (t *MemoryTransport) Send(from string, to []string, message []byte) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.sent = append(t.sent, SentMessage{
+		From:    from,
+		To:      slices.Clone(to),
+		Message: bytes.Clone(message),
+		SentAt:  time.Now(),
+	})
+
+	return nil
+}
+
+// Messages returns the messages sent so far, oldest first.
+func (t *MemoryTransport) Messages() []SentMessage {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return slices.Clone(t.sent)
+}
+
+// Reset forgets the messages sent so far.
+func (t *MemoryTransport) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.sent = nil
+}
+
+// Wait waits until at least n messages were sent. Emails are sent in the background after being
+// queued, so tests wait for them instead of reading Messages right away.
+//
+// Parameters:
+//   - n: Number of messages to wait for
+//   - timeout: Maximum wait
+//
+// Returns:
+//   - []SentMessage: Messages sent so far
+//   - error: Timeout error if fewer than n messages were sent in time
+func (t *MemoryTransport) Wait(n int, timeout time.Duration) ([]SentMessage, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		sent := t.Messages()
+		if len(sent) >= n {
+			return sent, nil
+		}
+		if time.Now().After(deadline) {
+			return sent, fmt.Errorf("se esperaban %d emails y se enviaron %d", n, len(sent))
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,33 @@
+package mailer
+
+// MessageNotificationData is the content of the email sent about messages still unread in a conversation.
+type MessageNotificationData struct {
+	RecipientName   string // User, assigned staff member or organisation receiving the email
+	Organization    string // Name of the organisation taking part in the conversation
+	Subject         string // Conversation subject
+	Count           int    // Number of unread messages
+	SenderName      string // Author of the latest unread message (optional)
+	Excerpt         string // Beginning of the latest unread message
+	ConversationURL string // Page of the conversation
+	ToStaff         bool   // Whether the email goes to the organisation's staff
+}
+
+// ComposeNewMessageNotification builds the email telling the other side of a conversation about messages they have not read.
+//
+// Parameters:
+//   - to: Recipient email address
+//   - locale: Recipient locale
+//   - data: Email content
+//   - sender: Organisation sender identity
+//
+// Returns:
+//   - *Email: Email to queue, see Queue
+//   - error: Template error
+func ComposeNewMessageNotification(to string, locale string, data MessageNotificationData, sender Sender) (*Email, error) {
+	email, err := newEmail("message", locale, data, to, sender)
+	if err != nil {
+		return nil, err
+	}
+
+	return email, nil
+}
@@ -0,0 +1,199 @@
+package mailer
+
+import (
+	"backend/internal/db/dao"
+	models "backend/internal/models"
+	"backend/internal/utils/env"
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	netmail "net/mail"
+	"net/textproto"
+	"time"
+
+	"github.com/go-mail/mail"
+)
+
+// ErrEmailClaimed is returned by Deliver when another worker is already sending the email.
+var ErrEmailClaimed = errors.New("email reservado por otro proceso")
+
+// emailLease is how long a claimed email is reserved for its attempt.
+const emailLease = 5 * time.Minute
+
+var (
+	// maxEmailAttempts is how many times an email is attempted before it is dead-lettered (MAIL_MAX_ATTEMPTS, default 6).
+	maxEmailAttempts = int(env.GetInt("MAIL_MAX_ATTEMPTS", 6))
+
+	// emailRetryBase is the delay before the first retry, doubled on each attempt (MAIL_RETRY_BASE, default 1m).
+	emailRetryBase = env.GetDuration("MAIL_RETRY_BASE", time.Minute)
+
+	// emailRetryMax is the longest delay between two attempts (MAIL_RETRY_MAX, default 2h).
+	emailRetryMax = env.GetDuration("MAIL_RETRY_MAX", 2*time.Hour)
+)
+
+// Queue writes a composed email to the outbox and attempts it in the background.
+// Emails reach users through the notification service, which decides whether they are queued.
+//
+// Parameters:
+//   - email: Email built by one of the ComposeX functions
+//
+// Returns:
+//   - error: Outbox error, or nil once queued
+func Queue(email *Email) error {
+	return queue(email.message)
+}
+
+// queue writes a message to the outbox and attempts it in the background.
+// Callers return as soon as the email is stored; the outbox worker retries failed attempts.
+func queue(m *mail.Message) error {
+	email, err := compose(m)
+	if err != nil {
+		return err
+	}
+
+	if err := dao.CreateOutboxEmail(email); err != nil {
+		log.Printf("could not queue email %q: %v", email.Subject, err)
+		return err
+	}
+
+	DeliverAsync(email)
+	return nil
+}
+
+// compose converts a message into a pending outbox email.
+// The envelope addresses are taken from the From, To, Cc and Bcc headers.
+func compose(m *mail.Message) (*models.OutboxEmail, error) {
+	from := m.GetHeader("From")
+	if len(from) == 0 {
+		return nil, errors.New("el email no tiene remitente")
+	}
+	sender, err := netmail.ParseAddress(from[0])
+	if err != nil {
+		return nil, fmt.Errorf("remitente inválido: %v", err)
+	}
+
+	var recipients []string
+	for _, field := range []string{"To", "Cc", "Bcc"} {
+		for _, value := range m.GetHeader(field) {
+			address, err := netmail.ParseAddress(value)
+			if err != nil {
+				return nil, fmt.Errorf("destinatario inválido %q: %v", value, err)
+			}
+			recipients = append(recipients, address.Address)
+		}
+	}
+	if len(recipients) == 0 {
+		return nil, errors.New("el email no tiene destinatarios")
+	}
+
+	subject := ""
+	if values := m.GetHeader("Subject"); len(values) > 0 {
+		subject = values[0]
+	}
+	if runes := []rune(subject); len(runes) > 255 {
+		subject = string(runes[:255])
+	}
+
+	// Bcc is part of the envelope only; go-mail leaves it out of the written message
+	var message bytes.Buffer
+	if _, err := m.WriteTo(&message); err != nil {
+		return nil, fmt.Errorf("error al generar el email: %v", err)
+	}
+
+	now := time.Now()
+	return &models.OutboxEmail{
+		Sender:        sender.Address,
+		Recipients:    recipients,
+		Subject:       subject,
+		Message:       message.Bytes(),
+		Status:        models.OutboxEmailPending,
+		NextAttemptAt: &now,
+	}, nil
+}
+
+// Deliver claims a due outbox email, sends it and records the outcome.
+//
+// Business Logic:
+// - Failed attempts are retried after MAIL_RETRY_BASE, doubled each time up to MAIL_RETRY_MAX
+// - Permanent rejections (5xx SMTP replies) and emails out of attempts are dead-lettered as failed
+//
+// Parameters:
+//   - email: Pending email, with its message
+//
+// Returns:
+//   - error: ErrEmailClaimed when another worker has it, the error of the attempt, or nil once sent
+func Deliver(email *models.OutboxEmail) error {
+	now := time.Now()
+	claimed, err := dao.ClaimOutboxEmail(email.ID, now, now.Add(emailLease))
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return ErrEmailClaimed
+	}
+
+	sendErr := Open().Send(email.Sender, email.Recipients, email.Message)
+
+	email.Attempts++
+	email.LastAttemptAt = &now
+
+	if sendErr == nil {
+		sentAt := time.Now()
+		email.Status = models.OutboxEmailSent
+		email.SentAt = &sentAt
+		email.NextAttemptAt = nil
+		email.LastError = ""
+	} else {
+		email.LastError = sendErr.Error()
+		if runes := []rune(email.LastError); len(runes) > 500 {
+			email.LastError = string(runes[:500])
+		}
+
+		if email.Attempts >= maxEmailAttempts || isPermanent(sendErr) {
+			email.Status = models.OutboxEmailFailed
+			email.NextAttemptAt = nil
+		} else {
+			next := time.Now().Add(retryDelay(email.Attempts))
+			email.NextAttemptAt = &next
+		}
+	}
+
+	if err := dao.RecordOutboxAttempt(email); err != nil {
+		// The lease expires and the email is attempted again
+		log.Printf("could not record attempt of email %d: %v", email.ID, err)
+	}
+
+	return sendErr
+}
+
+// DeliverAsync attempts queued emails in the background, logging failures.
+// Emails that are not sent stay in the outbox for the retry worker.
+//
+// Parameters:
+//   - emails: Queued emails, with their messages
+func DeliverAsync(emails ...*models.OutboxEmail) {
+	go func() {
+		for _, email := range emails {
+			if err := Deliver(email); err != nil && !errors.Is(err, ErrEmailClaimed) {
+				log.Printf("could not send email %d, status %s: %v", email.ID, email.Status, err)
+			}
+		}
+	}()
+}
+
+// isPermanent reports whether the mail server rejected the email for good (5xx reply).
+func isPermanent(err error) bool {
+	var reply *textproto.Error
+	return errors.As(err, &reply) && reply.Code >= 500
+}
+
+// retryDelay returns the wait before the next attempt after the given number of failed attempts.
+func retryDelay(attempts int) time.Duration {
+	delay := emailRetryBase
+	for i := 1; i < attempts && delay < emailRetryMax; i++ {
+		delay *= 2
+	}
+
+	return min(delay, emailRetryMax)
+}
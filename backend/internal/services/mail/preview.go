@@ -0,0 +1,120 @@
+package mailer
+
+import models "backend/internal/models"
+
+// sampleData is the example content each template is previewed with.
+// The visit template is previewed as a confirmation.
+var sampleData = map[string]any{
+	"2fa":      TwoFAData{Code: "482913"},
+	"password": PasswordData{Password: "Tmp-8fK2q9Lz"},
+	"adoption_contract": AdoptionContractData{
+		AdopterName:  "Laura Martínez",
+		PetName:      "Luna",
+		Organization: "Protectora de ejemplo",
+		Number:       "ADP-2026-000123",
+		Date:         "18/10/2026",
+		Hash:         "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+	},
+	"donation_receipt": DonationReceiptData{
+		DonorName:    "Laura Martínez",
+		Organization: "Protectora de ejemplo",
+		Year:         2025,
+		Number:       "REC-2025-000045",
+		Total:        "120,00 EUR",
+		Hash:         "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+	},
+	"donation_renewal": DonationRenewalData{
+		DonorName:    "Laura Martínez",
+		Organization: "Protectora de ejemplo",
+		PetName:      "Luna",
+		Frequency:    models.DonationMonthly,
+		Amount:       "10,00 EUR",
+		Date:         "01/11/2026",
+		CheckoutURL:  "https://example.com/checkout/sample",
+	},
+	"favorite_status": FavoriteStatusData{
+		UserName: "Laura",
+		PetName:  "Luna",
+		Status:   models.PetStatusReserved,
+		PetURL:   "https://example.com/pets/1",
+	},
+	"lost_found_match": LostFoundMatchData{
+		ReporterName:  "Laura Martínez",
+		ReportType:    models.LostFoundTypeLost,
+		ReportSpecies: "perro",
+		SeenDate:      "15/10/2026",
+		Pets: []LostFoundMatchPet{
+			{Name: "Toby", Species: "perro", Breed: "beagle", Reasons: []string{"microchip", "breed"}, URL: "https://example.com/pets/2"},
+			{Name: "Rex", Species: "perro", Breed: "mestizo", Reasons: []string{"species", "color"}, URL: "https://example.com/pets/3"},
+		},
+		ShelterEmail: "refugio@example.com",
+	},
+	"medical_reminder": MedicalReminderData{
+		UserName:   "Laura",
+		WindowDays: 7,
+		Overdue:    []MedicalReminderItem{{PetName: "Luna", Name: "Rabia", Date: "10/10/2026", URL: "https://example.com/pets/1"}},
+		Upcoming:   []MedicalReminderItem{{PetName: "Toby", Name: "Moquillo", Date: "22/10/2026", URL: "https://example.com/pets/2"}},
+		Treatments: []MedicalReminderItem{{PetName: "Rex", Name: "Antibiótico", Date: "20/10/2026", URL: "https://example.com/pets/3"}},
+	},
+	"message": MessageNotificationData{
+		RecipientName:   "Laura Martínez",
+		Organization:    "Protectora de ejemplo",
+		Subject:         "Adopción de Luna",
+		Count:           2,
+		SenderName:      "Equipo de adopciones",
+		Excerpt:         "Hola Laura,\nya tenemos la fecha para la visita.",
+		ConversationURL: "https://example.com/messages/1",
+	},
+	"search_alert": SearchAlertData{
+		UserName: "Laura",
+		Groups: []SearchAlertGroup{{
+			SearchName:     "Perros pequeños",
+			Pets:           []SearchAlertPet{{Name: "Toby", Species: "perro", Breed: "beagle", URL: "https://example.com/pets/2"}},
+			UnsubscribeURL: "https://example.com/unsubscribe/1",
+		}},
+		UnsubscribeAllURL: "https://example.com/unsubscribe",
+	},
+	"visit": visitEmail{
+		VisitData: VisitData{
+			VisitorName:  "Laura Martínez",
+			PetName:      "Luna",
+			Organization: "Protectora de ejemplo",
+			Date:         "25/10/2026",
+			StartTime:    "11:00",
+			EndTime:      "11:30",
+			Location:     "Calle Mayor 1, Madrid",
+			ManageURL:    "https://example.com/visits/1/manage",
+		},
+		Kind: visitConfirmation,
+	},
+	"volunteer_shift": VolunteerShiftData{
+		VolunteerName: "Laura Martínez",
+		Organization:  "Protectora de ejemplo",
+		Title:         "Paseo de perros",
+		Description:   "Paseos por el parque con los perros del refugio.",
+		Date:          "26/10/2026",
+		StartTime:     "10:00",
+		EndTime:       "12:00",
+		Location:      "Refugio",
+		CancelBefore:  "25/10/2026 10:00",
+		ShiftsURL:     "https://example.com/volunteering/shifts",
+	},
+}
+
+// Preview renders a template with sample data, so admins can review how an email looks in each locale.
+//
+// Parameters:
+//   - name: Template name (one of Templates)
+//   - locale: Locale to render (unsupported locales fall back to models.DefaultLocale)
+//
+// Returns:
+//   - *Rendered: Subject and bodies rendered with sample data
+//   - error: ErrUnknownTemplate, or template error
+func Preview(name string, locale string) (*Rendered, error) {
+	data, ok := sampleData[name]
+	if !ok {
+		return nil, ErrUnknownTemplate
+	}
+
+	return render(name, locale, data)
+}
@@ -0,0 +1,202 @@
+package mailer
+
+import (
+	models "backend/internal/models"
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"log"
+	"strings"
+	"sync"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/go-mail/mail"
+)
+
+// templateFiles holds the email templates:
+//   - templates/layout.html: Shared HTML layout (header, styles and footer)
+//   - templates/<locale>/<name>.html: "title", "content" and "footer" blocks (and optionally "subtitle") of the HTML body
+//   - templates/<locale>/<name>.txt: "subject" and "text" (plain text body) of the email, and optionally
+//     "notification", the one-line summary shown in the in-app notification centre
+//
+//go:embed templates
+var templateFiles embed.FS
+
+// Templates lists the email templates, each available in every locale of models.Locales.
+var Templates = []string{
+	"2fa",
+	"adoption_contract",
+	"donation_receipt",
+	"donation_renewal",
+	"favorite_status",
+	"lost_found_match",
+	"medical_reminder",
+	"message",
+	"password",
+	"search_alert",
+	"visit",
+	"volunteer_shift",
+}
+
+// ErrUnknownTemplate is returned when rendering a template that is not in Templates.
+var ErrUnknownTemplate = errors.New("plantilla de email no encontrada")
+
+// Rendered is an email rendered from its template.
+type Rendered struct {
+	Locale       string `json:"locale"`                 // Locale the email was rendered in
+	Subject      string `json:"subject"`                // Subject line, also the title of in-app notifications
+	Notification string `json:"notification,omitempty"` // One-line summary for in-app notifications (optional)
+	Text         string `json:"text"`                   // Plain text body
+	HTML         string `json:"html"`                   // HTML body
+}
+
+// Email is a rendered email ready to be queued with Queue.
+// The notification service reuses its subject and summary for in-app notifications.
+type Email struct {
+	Rendered
+	message *mail.Message
+}
+
+// emailTemplate is a parsed template of one locale.
+type emailTemplate struct {
+	html *htmltemplate.Template // Shared layout with the email blocks
+	text *texttemplate.Template // Subject and plain text body
+}
+
+var (
+	templatesOnce sync.Once
+	templates     map[string]emailTemplate // Keyed by "<locale>/<name>"
+	templatesErr  error
+)
+
+// render renders an email template in a locale.
+// Unsupported locales fall back to models.DefaultLocale (see resolveLocale).
+//
+// Parameters:
+//   - name: Template name (one of Templates)
+//   - locale: Recipient locale
+//   - data: Template data (the XData struct of the email)
+//
+// Returns:
+//   - *Rendered: Subject, summary and bodies
+//   - error: ErrUnknownTemplate, parse or execution error
+func render(name string, locale string, data any) (*Rendered, error) {
+	templatesOnce.Do(func() {
+		templates, templatesErr = parseTemplates()
+	})
+	if templatesErr != nil {
+		return nil, templatesErr
+	}
+
+	locale = resolveLocale(locale)
+	tmpl, ok := templates[locale+"/"+name]
+	if !ok {
+		return nil, ErrUnknownTemplate
+	}
+
+	var subject, notification, text, html bytes.Buffer
+	if err := tmpl.text.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return nil, fmt.Errorf("error al generar el asunto de %s: %v", name, err)
+	}
+	if tmpl.text.Lookup("notification") != nil {
+		if err := tmpl.text.ExecuteTemplate(&notification, "notification", data); err != nil {
+			return nil, fmt.Errorf("error al generar el resumen de %s: %v", name, err)
+		}
+	}
+	if err := tmpl.text.ExecuteTemplate(&text, "text", data); err != nil {
+		return nil, fmt.Errorf("error al generar el texto de %s: %v", name, err)
+	}
+	if err := tmpl.html.ExecuteTemplate(&html, "layout.html", data); err != nil {
+		return nil, fmt.Errorf("error al generar el HTML de %s: %v", name, err)
+	}
+
+	return &Rendered{
+		Locale:       locale,
+		Subject:      strings.Join(strings.Fields(subject.String()), " "),
+		Notification: strings.Join(strings.Fields(notification.String()), " "),
+		Text:         strings.TrimSpace(text.String()) + "\n",
+		HTML:         html.String(),
+	}, nil
+}
+
+// newEmail renders an email template and builds the message with its subject,
+// plain text body and HTML alternative.
+func newEmail(name string, locale string, data any, to string, sender Sender) (*Email, error) {
+	rendered, err := render(name, locale, data)
+	if err != nil {
+		log.Printf("error rendering %s email: %v", name, err)
+		return nil, err
+	}
+
+	m := mail.NewMessage()
+	setSender(m, sender)
+	m.SetHeader("To", to)
+	m.SetHeader("Subject", rendered.Subject)
+	m.SetBody("text/plain", rendered.Text)
+	m.AddAlternative("text/html", rendered.HTML)
+
+	return &Email{Rendered: *rendered, message: m}, nil
+}
+
+// parseTemplates parses every template of every locale.
+// html/template escapes the names, notes and messages entered by users and staff.
+func parseTemplates() (map[string]emailTemplate, error) {
+	parsed := make(map[string]emailTemplate)
+
+	for _, locale := range models.Locales {
+		funcs := templateFuncs(locale)
+
+		layout, err := htmltemplate.New("layout.html").Funcs(funcs).ParseFS(templateFiles, "templates/layout.html")
+		if err != nil {
+			return nil, fmt.Errorf("error al leer la plantilla base: %v", err)
+		}
+
+		for _, name := range Templates {
+			file := "templates/" + locale + "/" + name
+
+			html, err := layout.Clone()
+			if err != nil {
+				return nil, err
+			}
+			if _, err := html.ParseFS(templateFiles, file+".html"); err != nil {
+				return nil, fmt.Errorf("error al leer la plantilla %s.html: %v", file, err)
+			}
+
+			text, err := texttemplate.New(name+".txt").Funcs(texttemplate.FuncMap(funcs)).ParseFS(templateFiles, file+".txt")
+			if err != nil {
+				return nil, fmt.Errorf("error al leer la plantilla %s.txt: %v", file, err)
+			}
+
+			parsed[locale+"/"+name] = emailTemplate{html: html, text: text}
+		}
+	}
+
+	return parsed, nil
+}
+
+// templateFuncs returns the functions available to the templates of a locale.
+//
+// Functions:
+// - label: Translates a code of a label group, e.g. {{label "pet_status" .Status}}
+// - labels: Translates a list of codes and joins them with commas
+// - locale: Locale being rendered
+// - year: Current year
+func templateFuncs(locale string) htmltemplate.FuncMap {
+	return htmltemplate.FuncMap{
+		"label": func(group string, code string) string {
+			return label(locale, group, code)
+		},
+		"labels": func(group string, codes []string) string {
+			return labelList(locale, group, codes)
+		},
+		"locale": func() string {
+			return locale
+		},
+		"year": func() int {
+			return time.Now().Year()
+		},
+	}
+}
@@ -0,0 +1,46 @@
+package mailer
+
+// SearchAlertPet is a pet listed in a saved search digest.
+type SearchAlertPet struct {
+	Name    string
+	Species string
+	Breed   string
+	URL     string
+}
+
+// SearchAlertGroup lists the new pets matching one saved search.
+type SearchAlertGroup struct {
+	SearchName     string
+	Pets           []SearchAlertPet
+	UnsubscribeURL string // Disables alerts for this saved search only
+}
+
+// SearchAlertData is the content of a saved search digest email.
+type SearchAlertData struct {
+	UserName          string
+	Groups            []SearchAlertGroup
+	UnsubscribeAllURL string // Disables alerts for every saved search of the user
+}
+
+// ComposeSearchAlertDigest builds the daily digest of pets matching the user's saved searches.
+// The message carries List-Unsubscribe headers so mail clients can offer one-click unsubscribe.
+//
+// Parameters:
+//   - to: User email address
+//   - locale: User locale
+//   - data: Email content
+//
+// Returns:
+//   - *Email: Email to queue, see Queue
+//   - error: Template error
+func ComposeSearchAlertDigest(to string, locale string, data SearchAlertData) (*Email, error) {
+	email, err := newEmail("search_alert", locale, data, to, DefaultSender)
+	if err != nil {
+		return nil, err
+	}
+
+	email.message.SetHeader("List-Unsubscribe", "<"+data.UnsubscribeAllURL+">")
+	email.message.SetHeader("List-Unsubscribe-Post", "List-Unsubscribe=One-Click")
+
+	return email, nil
+}
@@ -0,0 +1,41 @@
+package mailer
+
+import (
+	"backend/internal/utils/env"
+
+	"github.com/go-mail/mail"
+)
+
+// Sender is the identity an email is sent with.
+// Organisations can configure their own name, address and Reply-To for emails about their pets.
+type Sender struct {
+	Name    string // Display name
+	Email   string // From address (must be allowed by the SMTP server)
+	ReplyTo string // Reply-To address (optional)
+}
+
+// DefaultSender is the system identity, used for account emails and
+// for organisations without a configured sender (MAIL_FROM_NAME and MAIL_FROM_ADDRESS).
+var DefaultSender = Sender{
+	Name:  env.Get("MAIL_FROM_NAME", "Adoption System"),
+	Email: env.Get("MAIL_FROM_ADDRESS", "zanckor002@gmail.com"),
+}
+
+// setSender sets the From and Reply-To headers of a message.
+// Empty name or address fall back to DefaultSender.
+func setSender(m *mail.Message, sender Sender) {
+	name := sender.Name
+	if name == "" {
+		name = DefaultSender.Name
+	}
+
+	email := sender.Email
+	if email == "" {
+		email = DefaultSender.Email
+	}
+
+	m.SetAddressHeader("From", email, name)
+	if sender.ReplyTo != "" {
+		m.SetHeader("Reply-To", sender.ReplyTo)
+	}
+}
@@ -0,0 +1,78 @@
+package mailer
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/go-mail/mail"
+)
+
+// TLSMode is how the SMTP transport secures the connection.
+type TLSMode string
+
+// SMTP TLS modes.
+const (
+	TLSImplicit TLSMode = "implicit" // TLS from the first byte (SMTPS, usually port 465)
+	TLSStartTLS TLSMode = "starttls" // Plain connection upgraded with a required STARTTLS (usually port 587)
+	TLSNone     TLSMode = "none"     // No TLS, for local relays and mail catchers only
+)
+
+// SMTPConfig holds the connection parameters of an SMTP server.
+type SMTPConfig struct {
+	Host     string        // Server host name
+	Port     int           // Server port
+	TLS      TLSMode       // How the connection is secured
+	Username string        // Authentication user (optional; no authentication when empty)
+	Password string        // Authentication password
+	Timeout  time.Duration // Connection and command timeout
+}
+
+// SMTPTransport sends messages through an SMTP server, opening a connection per message.
+type SMTPTransport struct {
+	dialer *mail.Dialer
+}
+
+// NewSMTP creates an SMTP transport.
+//
+// Parameters:
+//   - cfg: Connection parameters of the SMTP server
+//
+// Returns:
+//   - *SMTPTransport: Initialised SMTP transport
+//   - error: Configuration error or nil on success
+func NewSMTP(cfg SMTPConfig) (*SMTPTransport, error) {
+	if cfg.Host == "" || cfg.Port <= 0 || cfg.Port > 65535 {
+		return nil, fmt.Errorf("configuración SMTP inválida: host y puerto son obligatorios")
+	}
+
+	d := mail.NewDialer(cfg.Host, cfg.Port, cfg.Username, cfg.Password)
+	d.Timeout = cfg.Timeout
+
+	// NewDialer guesses SSL from the port; the mode is explicit instead
+	switch cfg.TLS {
+	case TLSImplicit:
+		d.SSL = true
+	case TLSStartTLS:
+		d.SSL = false
+		d.StartTLSPolicy = mail.MandatoryStartTLS
+	case TLSNone:
+		d.SSL = false
+		d.StartTLSPolicy = mail.NoStartTLS
+	default:
+		return nil, fmt.Errorf("modo TLS de SMTP desconocido: %s", cfg.TLS)
+	}
+
+	return &SMTPTransport{dialer: d}, nil
+}
+
+// Send delivers a message through the SMTP server.
+func (t *SMTPTransport) Send(from string, to []string, message []byte) error {
+	s, err := t.dialer.Dial()
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	return s.Send(from, to, bytes.NewReader(message))
+}
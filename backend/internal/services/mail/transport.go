@@ -0,0 +1,107 @@
+// Package mailer composes the emails of the adoption system, queues them in the
+// outbox and delivers them through a pluggable transport.
+//
+// Emails are rendered from embedded templates (see render.go) in the recipient's
+// locale (es, en or ca), with a shared HTML layout and a plain text alternative.
+// The ComposeX functions only build emails: the notification service checks the
+// recipient's preferences and queues them with Queue.
+//
+// Available transports:
+//   - smtp: Sends through an SMTP server (implicit TLS, STARTTLS or plain, with optional authentication)
+//   - maildir: Writes every message to a Maildir on disk instead of sending it, for development
+//   - memory: Keeps the messages in memory so tests can assert on them (see Use and MemoryTransport)
+//
+// Configuration (environment variables):
+//   - MAIL_TRANSPORT: "smtp" (default), "maildir" or "memory"
+//   - SMTP_HOST, SMTP_PORT: Mail server (default smtp.gmail.com:465)
+//   - SMTP_TLS: "implicit" (default, SMTPS), "starttls" (required STARTTLS, usually port 587) or "none"
+//   - SMTP_USERNAME, SMTP_PASSWORD: Credentials (no default); without username no authentication is attempted
+//   - SMTP_TIMEOUT: Connection and command timeout (default 30s)
+//   - MAIL_MAILDIR: Directory of the maildir transport (default "./mail")
+//   - MAIL_FROM_NAME, MAIL_FROM_ADDRESS: System sender (see DefaultSender)
+package mailer
+
+import (
+	"backend/internal/utils/env"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// Transport is the interface implemented by every mail transport.
+type Transport interface {
+	// Send delivers a complete MIME message to the envelope recipients.
+	Send(from string, to []string, message []byte) error
+}
+
+var (
+	instance Transport
+	once     sync.Once
+	mu       sync.RWMutex
+)
+
+// Open returns the transport configured through environment variables,
+// ensuring that it is only initialised once, unless Use installed another one.
+// If the transport cannot be initialised, it logs a fatal error and exits the program.
+func Open() Transport {
+	once.Do(func() {
+		transport, err := New(env.Get("MAIL_TRANSPORT", "smtp"))
+		if err != nil {
+			log.Fatalf("failed to initialise mail transport: %v", err)
+		}
+
+		mu.Lock()
+		if instance == nil {
+			instance = transport
+		}
+		mu.Unlock()
+	})
+
+	mu.RLock()
+	defer mu.RUnlock()
+
+	return instance
+}
+
+// Use replaces the transport every email is sent with.
+// Intended for tests, e.g. mailer.Use(mailer.NewMemory()).
+//
+// Parameters:
+//   - transport: Transport to use from now on
+func Use(transport Transport) {
+	// Skip the environment configuration: it may point to a real mail server
+	once.Do(func() {})
+
+	mu.Lock()
+	instance = transport
+	mu.Unlock()
+}
+
+// New creates a transport by name using the environment configuration.
+//
+// Parameters:
+//   - name: Transport name ("smtp", "maildir" or "memory")
+//
+// Returns:
+//   - Transport: Initialised transport
+//   - error: Configuration error or nil on success
+func New(name string) (Transport, error) {
+	switch name {
+	case "smtp":
+		return NewSMTP(SMTPConfig{
+			Host:     env.Get("SMTP_HOST", "smtp.gmail.com"),
+			Port:     int(env.GetInt("SMTP_PORT", 465)),
+			TLS:      TLSMode(env.Get("SMTP_TLS", string(TLSImplicit))),
+			Username: env.Get("SMTP_USERNAME", ""),
+			Password: env.Get("SMTP_PASSWORD", ""),
+			Timeout:  env.GetDuration("SMTP_TIMEOUT", 30*time.Second),
+		})
+	case "maildir":
+		return NewMaildir(env.Get("MAIL_MAILDIR", "./mail"))
+	case "memory":
+		return NewMemory(), nil
+	default:
+		return nil, fmt.Errorf("transporte de email desconocido: %s", name)
+	}
+}
@@ -0,0 +1,67 @@
+package mailer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewSMTPWithoutCredentials(t *testing.T) {
+	t.Setenv("SMTP_USERNAME", "")
+	t.Setenv("SMTP_PASSWORD", "")
+
+	transport, err := New("smtp")
+	if err != nil {
+		t.Fatalf("New(smtp) error = %v", err)
+	}
+
+	dialer := transport.(*SMTPTransport).dialer
+	if dialer.Username != "" || dialer.Password != "" {
+		t.Errorf("credentials = %q/%q, want none so no authentication is attempted", dialer.Username, dialer.Password)
+	}
+}
+
+func TestNewUnknownTransport(t *testing.T) {
+	if _, err := New("pigeon"); err == nil {
+		t.Error("New(pigeon) error = nil, want unknown transport error")
+	}
+}
+
+func TestUseMemoryTransport(t *testing.T) {
+	transport := NewMemory()
+	Use(transport)
+
+	email, err := Compose2FAToken("adopter@example.com", "en", "123456")
+	if err != nil {
+		t.Fatalf("Compose2FAToken error = %v", err)
+	}
+	if err := Open().Send(email.Sender, email.Recipients, email.Message); err != nil {
+		t.Fatalf("Send error = %v", err)
+	}
+
+	sent, err := transport.Wait(1, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sent) != 1 {
+		t.Fatalf("sent %d messages, want 1", len(sent))
+	}
+	if len(sent[0].To) != 1 || sent[0].To[0] != "adopter@example.com" {
+		t.Errorf("To = %v, want [adopter@example.com]", sent[0].To)
+	}
+	if sent[0].Header("Subject") != email.Subject {
+		t.Errorf("Subject = %q, want %q", sent[0].Header("Subject"), email.Subject)
+	}
+
+	msg, err := sent[0].Parse()
+	if err != nil {
+		t.Fatalf("Parse error = %v", err)
+	}
+	if msg.Header.Get("To") == "" {
+		t.Error("message has no To header")
+	}
+
+	transport.Reset()
+	if got := len(transport.Messages()); got != 0 {
+		t.Errorf("after Reset %d messages, want 0", got)
+	}
+}
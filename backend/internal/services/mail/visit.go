@@ -0,0 +1,67 @@
+package mailer
+
+import (
+	"backend/internal/services/calendar"
+	"bytes"
+
+	"github.com/go-mail/mail"
+)
+
+// Kinds of visit emails, selecting the title, introduction and subject of the visit template.
+const (
+	visitConfirmation = "confirmation"
+	visitReminder     = "reminder"
+	visitCancellation = "cancellation"
+)
+
+// VisitData is the content of the emails sent to the visitor of a meet-and-greet.
+type VisitData struct {
+	VisitorName  string
+	PetName      string
+	Organization string // Name of the organisation receiving the visit
+	Date         string // Visit date, formatted for display
+	StartTime    string // Start time, formatted for display
+	EndTime      string // End time, formatted for display
+	Location     string
+	ManageURL    string // Signed link to cancel or reschedule the visit
+	Reason       string // Cancellation reason (cancellations only, optional)
+}
+
+// visitEmail is the data of the visit template.
+type visitEmail struct {
+	VisitData
+	Kind      string // visitConfirmation, visitReminder or visitCancellation
+	Cancelled bool
+}
+
+// ComposeVisitConfirmation builds the email confirming a booked or rescheduled visit, with the calendar invite attached.
+func ComposeVisitConfirmation(to string, locale string, data VisitData, invite []byte, sender Sender) (*Email, error) {
+	return composeVisitEmail(to, locale, visitEmail{VisitData: data, Kind: visitConfirmation}, invite, calendar.MethodPublish, sender)
+}
+
+// ComposeVisitReminder builds the email reminding the visitor of an upcoming visit, with the calendar invite attached.
+func ComposeVisitReminder(to string, locale string, data VisitData, invite []byte, sender Sender) (*Email, error) {
+	return composeVisitEmail(to, locale, visitEmail{VisitData: data, Kind: visitReminder}, invite, calendar.MethodPublish, sender)
+}
+
+// ComposeVisitCancellation builds the email telling the visitor a visit was cancelled, with the calendar
+// cancellation attached so calendar clients remove the event.
+func ComposeVisitCancellation(to string, locale string, data VisitData, invite []byte, sender Sender) (*Email, error) {
+	return composeVisitEmail(to, locale, visitEmail{VisitData: data, Kind: visitCancellation, Cancelled: true}, invite, calendar.MethodCancel, sender)
+}
+
+// composeVisitEmail renders a visit email with the iCalendar file attached.
+func composeVisitEmail(to string, locale string, data visitEmail, invite []byte, method string, sender Sender) (*Email, error) {
+	email, err := newEmail("visit", locale, data, to, sender)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(invite) > 0 {
+		email.message.AttachReader("visita.ics", bytes.NewReader(invite), mail.SetHeader(map[string][]string{
+			"Content-Type": {calendar.ContentType + "; charset=UTF-8; method=" + method + `; name="visita.ics"`},
+		}))
+	}
+
+	return email, nil
+}
@@ -0,0 +1,35 @@
+package mailer
+
+// VolunteerShiftData is the content of the reminder sent to a volunteer before a shift.
+type VolunteerShiftData struct {
+	VolunteerName string
+	Organization  string // Name of the organisation the shift is for
+	Title         string // Shift title
+	Description   string // Tasks of the shift (optional)
+	Date          string // Shift date, formatted for display
+	StartTime     string // Start time, formatted for display
+	EndTime       string // End time, formatted for display
+	Location      string
+	CancelBefore  string // Last moment to cancel, formatted for display (empty if already passed)
+	ShiftsURL     string // Page listing the volunteer's shifts
+}
+
+// ComposeVolunteerShiftReminder builds the email reminding a volunteer of an upcoming shift they signed up for.
+//
+// Parameters:
+//   - to: Volunteer email address
+//   - locale: Volunteer locale
+//   - data: Email content
+//   - sender: Organisation sender identity
+//
+// Returns:
+//   - *Email: Email to queue, see Queue
+//   - error: Template error
+func ComposeVolunteerShiftReminder(to string, locale string, data VolunteerShiftData, sender Sender) (*Email, error) {
+	email, err := newEmail("volunteer_shift", locale, data, to, sender)
+	if err != nil {
+		return nil, err
+	}
+
+	return email, nil
+}
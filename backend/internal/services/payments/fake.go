@@ -0,0 +1,288 @@
+package payments
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	_ "embed"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FakeSignatureHeader carries the webhook signature of the fake provider: "t=<unix time>,v1=<hex HMAC-SHA256>".
+// The HMAC is computed with the signing secret over "<unix time>.<body>".
+const FakeSignatureHeader = "X-Fake-Signature"
+
+// fakeSignatureTolerance is the maximum age of a signed webhook, which limits replays.
+const fakeSignatureTolerance = 5 * time.Minute
+
+// ErrCheckoutNotFound is returned when the fake checkout does not exist (or the server was restarted).
+var ErrCheckoutNotFound = errors.New("pago de prueba no encontrado")
+
+//go:embed templates/fake_checkout.html
+var fakeCheckoutTemplate string
+
+// FakeProvider simulates a payment provider for local development and testing.
+// Checkouts are kept in memory and paid from a local page that sends a signed webhook,
+// exactly like a real provider would. Completing the same checkout twice sends the
+// same event again, which exercises duplicate webhook handling.
+type FakeProvider struct {
+	secret  []byte
+	baseURL string
+
+	mu        sync.Mutex
+	checkouts map[string]FakeCheckout
+}
+
+// FakeCheckout is a checkout of the fake provider.
+type FakeCheckout struct {
+	ID          string
+	Reference   string
+	Description string
+	AmountCents int64
+	Currency    string
+	SuccessURL  string
+	CancelURL   string
+}
+
+// NewFake creates a fake provider.
+//
+// Parameters:
+//   - secret: Webhook signing secret
+//   - baseURL: Externally reachable URL of this API, where the checkout page is served
+//
+// Returns:
+//   - *FakeProvider: Initialised provider
+func NewFake(secret string, baseURL string) *FakeProvider {
+	return &FakeProvider{
+		secret:    []byte(secret),
+		baseURL:   baseURL,
+		checkouts: make(map[string]FakeCheckout),
+	}
+}
+
+// Name returns "fake".
+func (p *FakeProvider) Name() string {
+	return "fake"
+}
+
+// CreateCheckout registers the checkout in memory and returns the URL of the local checkout page.
+func (p *FakeProvider) CreateCheckout(ctx context.Context, req CheckoutRequest) (*Checkout, error) {
+	checkout := FakeCheckout{
+		ID:          "fake_cs_" + randomID(),
+		Reference:   req.Reference,
+		Description: req.Description,
+		AmountCents: req.AmountCents,
+		Currency:    req.Currency,
+		SuccessURL:  req.SuccessURL,
+		CancelURL:   req.CancelURL,
+	}
+
+	p.mu.Lock()
+	p.checkouts[checkout.ID] = checkout
+	p.mu.Unlock()
+
+	return &Checkout{
+		ID:  checkout.ID,
+		URL: p.baseURL + "/api/payments/fake/checkout/" + checkout.ID,
+	}, nil
+}
+
+// ParseWebhook verifies the FakeSignatureHeader of the request and decodes its event.
+func (p *FakeProvider) ParseWebhook(header http.Header, body []byte) (*Event, error) {
+	if err := p.verify(header.Get(FakeSignatureHeader), body, time.Now()); err != nil {
+		return nil, err
+	}
+
+	var event fakeEvent
+	if err := json.Unmarshal(body, &event); err != nil || event.ID == "" || event.CheckoutID == "" {
+		return nil, ErrInvalidEvent
+	}
+
+	switch event.Type {
+	case EventPaid, EventFailed, EventExpired, EventRefunded:
+	default:
+		return nil, fmt.Errorf("%w: tipo %q", ErrInvalidEvent, event.Type)
+	}
+
+	return &Event{
+		ID:          event.ID,
+		Type:        event.Type,
+		CheckoutID:  event.CheckoutID,
+		AmountCents: event.AmountCents,
+		Currency:    event.Currency,
+		Reason:      event.Reason,
+	}, nil
+}
+
+// Refund accepts every refund immediately.
+func (p *FakeProvider) Refund(ctx context.Context, req RefundRequest) (*Refund, error) {
+	if req.AmountCents <= 0 {
+		return nil, fmt.Errorf("importe de reembolso inválido: %d", req.AmountCents)
+	}
+
+	return &Refund{ID: "fake_re_" + randomID()}, nil
+}
+
+// Checkout retrieves a checkout created by this provider.
+func (p *FakeProvider) Checkout(id string) (FakeCheckout, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	checkout, ok := p.checkouts[id]
+	if !ok {
+		return FakeCheckout{}, ErrCheckoutNotFound
+	}
+
+	return checkout, nil
+}
+
+// CheckoutPage renders the local checkout page, with one button per outcome.
+func (p *FakeProvider) CheckoutPage(id string) ([]byte, error) {
+	checkout, err := p.Checkout(id)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := template.New("fake_checkout").Parse(fakeCheckoutTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	err = tmpl.Execute(&b, map[string]interface{}{
+		"Checkout": checkout,
+		"Amount":   fmt.Sprintf("%d,%02d %s", checkout.AmountCents/100, checkout.AmountCents%100, checkout.Currency),
+		"Action":   "/api/payments/fake/checkout/" + checkout.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// Complete simulates the payer finishing the checkout with the given outcome.
+// It returns the signed webhook the provider would send and the URL the payer returns to.
+//
+// Parameters:
+//   - id: Checkout identifier
+//   - outcome: EventPaid, EventFailed or EventExpired
+//
+// Returns:
+//   - []byte: Webhook body
+//   - http.Header: Webhook headers, including the signature
+//   - string: Return URL of the payer (SuccessURL when paid, CancelURL otherwise)
+//   - error: ErrCheckoutNotFound or invalid outcome
+func (p *FakeProvider) Complete(id string, outcome string) ([]byte, http.Header, string, error) {
+	checkout, err := p.Checkout(id)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	event := fakeEvent{
+		// The event ID only depends on the checkout and the outcome, so completing twice sends a duplicate
+		ID:          "fake_evt_" + strings.TrimPrefix(checkout.ID, "fake_cs_") + "_" + outcome,
+		Type:        outcome,
+		CheckoutID:  checkout.ID,
+		AmountCents: checkout.AmountCents,
+		Currency:    checkout.Currency,
+	}
+
+	returnURL := checkout.CancelURL
+	switch outcome {
+	case EventPaid:
+		returnURL = checkout.SuccessURL
+	case EventFailed:
+		event.Reason = "tarjeta rechazada (simulado)"
+	case EventExpired:
+	default:
+		return nil, nil, "", fmt.Errorf("resultado de pago desconocido: %s", outcome)
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	header.Set(FakeSignatureHeader, p.Sign(body, time.Now()))
+
+	return body, header, returnURL, nil
+}
+
+// Sign returns the FakeSignatureHeader value of a webhook body signed at the given time.
+func (p *FakeProvider) Sign(body []byte, at time.Time) string {
+	timestamp := strconv.FormatInt(at.Unix(), 10)
+	return "t=" + timestamp + ",v1=" + p.mac(timestamp, body)
+}
+
+// verify checks a FakeSignatureHeader value against the body.
+func (p *FakeProvider) verify(signature string, body []byte, now time.Time) error {
+	var timestamp, mac string
+	for _, part := range strings.Split(signature, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			mac = value
+		}
+	}
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || mac == "" {
+		return ErrInvalidSignature
+	}
+
+	age := now.Sub(time.Unix(unix, 0))
+	if age > fakeSignatureTolerance || age < -fakeSignatureTolerance {
+		return ErrInvalidSignature
+	}
+
+	if !hmac.Equal([]byte(mac), []byte(p.mac(timestamp, body))) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+// mac computes the hex HMAC-SHA256 of "<timestamp>.<body>".
+func (p *FakeProvider) mac(timestamp string, body []byte) string {
+	h := hmac.New(sha256.New, p.secret)
+	h.Write([]byte(timestamp))
+	h.Write([]byte("."))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// fakeEvent is the webhook payload of the fake provider.
+type fakeEvent struct {
+	ID          string `json:"id"`
+	Type        string `json:"type"`
+	CheckoutID  string `json:"checkout_id"`
+	AmountCents int64  `json:"amount_cents"`
+	Currency    string `json:"currency"`
+	Reason      string `json:"reason,omitempty"`
+}
+
+// randomID returns a random hexadecimal identifier.
+func randomID() string {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("could not generate random id: %v", err))
+	}
+
+	return hex.EncodeToString(b)
+}
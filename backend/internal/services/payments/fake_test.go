@@ -0,0 +1,114 @@
+package payments
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFakeVerify(t *testing.T) {
+	provider := NewFake("test-secret", "http://localhost:8080")
+	other := NewFake("other-secret", "http://localhost:8080")
+
+	body := []byte(`{"id":"fake_evt_1","type":"paid","checkout_id":"fake_cs_1","amount_cents":15000,"currency":"EUR"}`)
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	signature := provider.Sign(body, now)
+	_, mac, _ := strings.Cut(signature, ",v1=")
+
+	tests := []struct {
+		name      string
+		signature string
+		body      []byte
+		now       time.Time
+		wantErr   bool
+	}{
+		{name: "valid", signature: signature, body: body, now: now},
+		{name: "spaces after the comma", signature: strings.Replace(signature, ",", ", ", 1), body: body, now: now},
+		{name: "signed at the edge of the tolerance", signature: signature, body: body, now: now.Add(fakeSignatureTolerance)},
+		{name: "clock slightly behind", signature: signature, body: body, now: now.Add(-fakeSignatureTolerance)},
+		{name: "too old", signature: signature, body: body, now: now.Add(fakeSignatureTolerance + time.Second), wantErr: true},
+		{name: "too far in the future", signature: signature, body: body, now: now.Add(-fakeSignatureTolerance - time.Second), wantErr: true},
+		{name: "body changed", signature: signature, body: []byte(strings.Replace(string(body), "15000", "1", 1)), now: now, wantErr: true},
+		{name: "timestamp changed", signature: strings.Split(provider.Sign(body, now.Add(time.Second)), ",")[0] + ",v1=" + mac, body: body, now: now, wantErr: true},
+		{name: "other secret", signature: other.Sign(body, now), body: body, now: now, wantErr: true},
+		{name: "missing mac", signature: strings.Split(signature, ",")[0], body: body, now: now, wantErr: true},
+		{name: "missing timestamp", signature: "v1=" + mac, body: body, now: now, wantErr: true},
+		{name: "empty", signature: "", body: body, now: now, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := provider.verify(tt.signature, tt.body, tt.now)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("verify error = %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidSignature) {
+				t.Errorf("verify error = %v, want ErrInvalidSignature", err)
+			}
+		})
+	}
+}
+
+func TestFakeCheckoutWebhook(t *testing.T) {
+	provider := NewFake("test-secret", "http://localhost:8080")
+
+	checkout, err := provider.CreateCheckout(context.Background(), CheckoutRequest{
+		Reference:   "payment-7",
+		AmountCents: 15000,
+		Currency:    "EUR",
+		SuccessURL:  "http://localhost:5173/payments/7/success",
+		CancelURL:   "http://localhost:5173/payments/7/cancelled",
+	})
+	if err != nil {
+		t.Fatalf("CreateCheckout error = %v", err)
+	}
+
+	tests := []struct {
+		outcome    string
+		wantReturn string
+	}{
+		{outcome: EventPaid, wantReturn: "http://localhost:5173/payments/7/success"},
+		{outcome: EventFailed, wantReturn: "http://localhost:5173/payments/7/cancelled"},
+		{outcome: EventExpired, wantReturn: "http://localhost:5173/payments/7/cancelled"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.outcome, func(t *testing.T) {
+			body, header, returnURL, err := provider.Complete(checkout.ID, tt.outcome)
+			if err != nil {
+				t.Fatalf("Complete error = %v", err)
+			}
+			if returnURL != tt.wantReturn {
+				t.Errorf("return URL = %s, want %s", returnURL, tt.wantReturn)
+			}
+
+			event, err := provider.ParseWebhook(header, body)
+			if err != nil {
+				t.Fatalf("ParseWebhook error = %v", err)
+			}
+			if event.Type != tt.outcome || event.CheckoutID != checkout.ID || event.AmountCents != 15000 || event.Currency != "EUR" {
+				t.Errorf("event = %+v, want %s of 15000 EUR for %s", event, tt.outcome, checkout.ID)
+			}
+
+			// Completing twice sends the same event, so duplicates can be detected
+			_, header, _, _ = provider.Complete(checkout.ID, tt.outcome)
+			again, err := provider.ParseWebhook(header, body)
+			if err != nil || again.ID != event.ID {
+				t.Errorf("duplicate event = %+v, %v, want the same ID %s", again, err, event.ID)
+			}
+		})
+	}
+
+	if _, _, _, err := provider.Complete("fake_cs_missing", EventPaid); !errors.Is(err, ErrCheckoutNotFound) {
+		t.Errorf("Complete of an unknown checkout error = %v, want ErrCheckoutNotFound", err)
+	}
+	if _, _, _, err := provider.Complete(checkout.ID, EventRefunded); err == nil {
+		t.Error("Complete with a refund outcome error = nil, want unknown outcome")
+	}
+	if _, err := provider.ParseWebhook(http.Header{}, []byte(`{}`)); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("ParseWebhook without signature error = %v, want ErrInvalidSignature", err)
+	}
+}
@@ -0,0 +1,184 @@
+// Package payments provides a provider-agnostic payment gateway abstraction.
+// It is used to collect adoption fees through a provider's hosted checkout page.
+//
+// Payment flow:
+//  1. CreateCheckout registers the payment with the provider and returns the hosted page URL
+//  2. The payer is redirected to that page and back to SuccessURL or CancelURL
+//  3. The provider reports the outcome to the webhook endpoint; ParseWebhook verifies
+//     the signature and returns the event, which callers must process idempotently
+//     (providers retry deliveries, so the same event can arrive several times)
+//
+// Available providers:
+//   - fake: Local provider with a simulated checkout page, for development and testing only
+//
+// New providers implement Provider and are added to New. Without PAYMENT_PROVIDER online
+// payments are disabled: every request fails with ErrNotConfigured.
+//
+// Configuration (environment variables):
+//   - PAYMENT_PROVIDER: Provider name (no default)
+//   - PAYMENT_FAKE_ENABLED: Must be true to use the fake provider, which lets anyone mark checkouts as paid
+//   - PAYMENT_FAKE_SECRET: Webhook signing secret of the fake provider (required, no default)
+//   - PUBLIC_BASE_URL: Externally reachable URL of this API, used by the fake checkout page
+package payments
+
+import (
+	"backend/internal/utils/env"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// Webhook event types.
+const (
+	EventPaid     = "paid"     // Checkout paid
+	EventFailed   = "failed"   // Payment rejected
+	EventExpired  = "expired"  // Checkout abandoned or expired
+	EventRefunded = "refunded" // Payment refunded (AmountCents is the total refunded so far)
+)
+
+var (
+	// ErrInvalidSignature is returned when a webhook is not signed by the provider or is too old.
+	ErrInvalidSignature = errors.New("firma de webhook inválida")
+
+	// ErrInvalidEvent is returned when a webhook payload cannot be parsed.
+	ErrInvalidEvent = errors.New("evento de webhook inválido")
+
+	// ErrNotConfigured is returned by every request when no payment provider is configured.
+	ErrNotConfigured = errors.New("no hay ningún proveedor de pagos configurado")
+)
+
+// Provider is the interface implemented by every payment provider.
+type Provider interface {
+	// Name returns the provider name, used in webhook URLs and stored with each payment.
+	Name() string
+
+	// CreateCheckout registers a payment and returns the hosted checkout page to send the payer to.
+	CreateCheckout(ctx context.Context, req CheckoutRequest) (*Checkout, error)
+
+	// ParseWebhook verifies the signature of a webhook request and returns its event.
+	// Returns ErrInvalidSignature or ErrInvalidEvent when the request must be rejected.
+	ParseWebhook(header http.Header, body []byte) (*Event, error)
+
+	// Refund returns part or all of a paid checkout to the payer.
+	Refund(ctx context.Context, req RefundRequest) (*Refund, error)
+}
+
+// CheckoutRequest describes a payment to collect.
+type CheckoutRequest struct {
+	Reference     string // Local reference of the payment, echoed by the provider
+	AmountCents   int64  // Amount in cents
+	Currency      string // ISO 4217 currency code
+	Description   string // Shown to the payer on the checkout page
+	CustomerEmail string // Payer email (optional)
+	SuccessURL    string // Where the payer returns after paying
+	CancelURL     string // Where the payer returns after cancelling
+}
+
+// Checkout is a payment registered with the provider.
+type Checkout struct {
+	ID  string // Provider's checkout identifier
+	URL string // Hosted checkout page
+}
+
+// Event is a verified webhook event.
+type Event struct {
+	ID          string // Provider's event identifier (unique per provider)
+	Type        string // EventPaid, EventFailed, EventExpired or EventRefunded
+	CheckoutID  string // Checkout the event refers to
+	AmountCents int64  // Paid amount, or total refunded amount for EventRefunded
+	Currency    string // ISO 4217 currency code
+	Reason      string // Failure reason (EventFailed only, optional)
+}
+
+// RefundRequest describes a refund of a paid checkout.
+type RefundRequest struct {
+	CheckoutID  string // Checkout to refund
+	AmountCents int64  // Amount to refund in cents
+	Currency    string // ISO 4217 currency code
+	Reason      string // Why the payment is refunded (optional)
+}
+
+// Refund is a refund accepted by the provider.
+type Refund struct {
+	ID string // Provider's refund identifier
+}
+
+var (
+	instance Provider
+	once     sync.Once
+)
+
+// Open returns the payment provider configured through environment variables,
+// ensuring that it is only initialised once.
+// Without PAYMENT_PROVIDER it returns a provider that rejects every request with ErrNotConfigured.
+// If the provider cannot be initialised, it logs a fatal error and exits the program.
+func Open() Provider {
+	once.Do(func() {
+		var err error
+		instance, err = New(env.Get("PAYMENT_PROVIDER", ""))
+		if err != nil {
+			log.Fatalf("failed to initialise payment provider: %v", err)
+		}
+	})
+
+	return instance
+}
+
+// Enabled reports whether a payment provider is configured.
+func Enabled() bool {
+	_, disabled := Open().(disabledProvider)
+	return !disabled
+}
+
+// New creates a payment provider by name using the environment configuration.
+//
+// Parameters:
+//   - name: Provider name ("fake"), or "" to disable online payments
+//
+// Returns:
+//   - Provider: Initialised provider
+//   - error: Configuration error or nil on success
+func New(name string) (Provider, error) {
+	switch name {
+	case "":
+		return disabledProvider{}, nil
+	case "fake":
+		if !env.GetBool("PAYMENT_FAKE_ENABLED", false) {
+			return nil, errors.New("el proveedor de pagos fake solo es para desarrollo: requiere PAYMENT_FAKE_ENABLED=true")
+		}
+		secret := env.Get("PAYMENT_FAKE_SECRET", "")
+		if secret == "" {
+			return nil, errors.New("PAYMENT_FAKE_SECRET es obligatorio")
+		}
+		return NewFake(secret, strings.TrimSuffix(env.Get("PUBLIC_BASE_URL", "http://localhost:8080"), "/")), nil
+	default:
+		return nil, fmt.Errorf("proveedor de pagos desconocido: %s", name)
+	}
+}
+
+// disabledProvider is used when no provider is configured: it rejects every request.
+type disabledProvider struct{}
+
+// Name returns "", which never matches the provider of a payment or a webhook URL.
+func (disabledProvider) Name() string {
+	return ""
+}
+
+// CreateCheckout returns ErrNotConfigured.
+func (disabledProvider) CreateCheckout(ctx context.Context, req CheckoutRequest) (*Checkout, error) {
+	return nil, ErrNotConfigured
+}
+
+// ParseWebhook returns ErrNotConfigured.
+func (disabledProvider) ParseWebhook(header http.Header, body []byte) (*Event, error) {
+	return nil, ErrNotConfigured
+}
+
+// Refund returns ErrNotConfigured.
+func (disabledProvider) Refund(ctx context.Context, req RefundRequest) (*Refund, error) {
+	return nil, ErrNotConfigured
+}
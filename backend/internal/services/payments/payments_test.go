@@ -0,0 +1,56 @@
+package payments
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider string
+		env      map[string]string
+		want     string
+		wantErr  bool
+	}{
+		{name: "not configured", provider: "", want: ""},
+		{name: "fake without the development flag", provider: "fake", env: map[string]string{"PAYMENT_FAKE_SECRET": "s"}, wantErr: true},
+		{name: "fake without secret", provider: "fake", env: map[string]string{"PAYMENT_FAKE_ENABLED": "true"}, wantErr: true},
+		{name: "fake", provider: "fake", env: map[string]string{"PAYMENT_FAKE_ENABLED": "true", "PAYMENT_FAKE_SECRET": "s"}, want: "fake"},
+		{name: "unknown", provider: "stripe", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("PAYMENT_FAKE_ENABLED", "")
+			t.Setenv("PAYMENT_FAKE_SECRET", "")
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			provider, err := New(tt.provider)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("New(%q) error = %v, wantErr %v", tt.provider, err, tt.wantErr)
+			}
+			if err == nil && provider.Name() != tt.want {
+				t.Errorf("New(%q) provider = %q, want %q", tt.provider, provider.Name(), tt.want)
+			}
+		})
+	}
+}
+
+func TestDisabledProvider(t *testing.T) {
+	provider := disabledProvider{}
+
+	if _, err := provider.CreateCheckout(context.Background(), CheckoutRequest{AmountCents: 100}); !errors.Is(err, ErrNotConfigured) {
+		t.Errorf("CreateCheckout error = %v, want ErrNotConfigured", err)
+	}
+	if _, err := provider.ParseWebhook(http.Header{}, []byte("{}")); !errors.Is(err, ErrNotConfigured) {
+		t.Errorf("ParseWebhook error = %v, want ErrNotConfigured", err)
+	}
+	if _, err := provider.Refund(context.Background(), RefundRequest{AmountCents: 100}); !errors.Is(err, ErrNotConfigured) {
+		t.Errorf("Refund error = %v, want ErrNotConfigured", err)
+	}
+}
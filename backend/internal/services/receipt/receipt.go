@@ -0,0 +1,189 @@
+// Package receipt generates the yearly donation receipt PDFs that organisations issue to their donors.
+// A receipt certifies the donations a donor made to an organisation during a calendar year,
+// listing every payment (net of refunds) and the total, so donors can use it for tax purposes.
+package receipt
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+)
+
+// ContentType is the MIME type of the generated receipts.
+const ContentType = "application/pdf"
+
+// Page layout, in millimetres (A4 portrait).
+const (
+	margin      = 20.0
+	labelWidth  = 45.0
+	lineHeight  = 6.0
+	dateWidth   = 30.0
+	amountWidth = 35.0
+	fontFamily  = "Helvetica"
+)
+
+// Issuer is the organisation issuing the receipt.
+type Issuer struct {
+	Name    string
+	Email   string
+	Phone   string
+	Address string
+}
+
+// Donor is the donor the receipt is issued to.
+type Donor struct {
+	FullName string
+	Email    string
+	TaxID    string // Optional
+}
+
+// Line is a donation included in the receipt.
+type Line struct {
+	Date        time.Time // Payment date
+	Description string    // e.g. "Donación mensual" or "Apadrinamiento de Luna"
+	AmountCents int64     // Net amount in cents
+}
+
+// Data is the content of a receipt.
+type Data struct {
+	Number     string // Receipt number, e.g. DON-2026-000012
+	Year       int    // Year of the donations
+	Issuer     Issuer
+	Donor      Donor
+	Currency   string // ISO 4217 currency code of every line
+	Lines      []Line
+	TotalCents int64
+}
+
+// Number returns the receipt number of a receipt record.
+func Number(year int, receiptID uint) string {
+	return fmt.Sprintf("DON-%d-%06d", year, receiptID)
+}
+
+// FormatAmount formats an amount in cents the Spanish way (e.g. "150,00 EUR").
+func FormatAmount(cents int64, currency string) string {
+	return fmt.Sprintf("%d,%02d %s", cents/100, cents%100, currency)
+}
+
+// Render lays out a receipt as a PDF document: the organisation and donor details,
+// a certification paragraph, the table of donations and the total.
+//
+// Parameters:
+//   - data: Receipt data
+//   - now: Generation time (stored as the PDF creation date and shown as the issue date)
+//
+// Returns:
+//   - []byte: PDF document
+//   - error: PDF generation error or nil on success
+func Render(data Data, now time.Time) ([]byte, error) {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	// Core fonts are encoded in cp1252, which covers Spanish and Catalan accents
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	title := fmt.Sprintf("Certificado de donaciones %d", data.Year)
+	pdf.SetTitle(title, true)
+	pdf.SetAuthor(data.Issuer.Name, true)
+	pdf.SetSubject(data.Number, true)
+	pdf.SetCreator("Sistema de Adopciones", true)
+	pdf.SetCreationDate(now)
+	pdf.SetModificationDate(now)
+	pdf.SetMargins(margin, margin, margin)
+	pdf.SetAutoPageBreak(true, margin)
+	pdf.AliasNbPages("")
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-15)
+		pdf.SetFont(fontFamily, "I", 8)
+		pdf.SetTextColor(120, 120, 120)
+		footer := fmt.Sprintf("%s - Página %d de {nb}", data.Number, pdf.PageNo())
+		pdf.CellFormat(0, 10, tr(footer), "", 0, "C", false, 0, "")
+		pdf.SetTextColor(0, 0, 0)
+	})
+	pdf.AddPage()
+
+	// Header
+	pdf.SetFont(fontFamily, "B", 16)
+	pdf.MultiCell(0, 8, tr(title), "", "C", false)
+	pdf.SetFont(fontFamily, "", 9)
+	pdf.MultiCell(0, 5, tr(joinNonEmpty(" - ", data.Issuer.Name, data.Issuer.Address)), "", "C", false)
+	pdf.MultiCell(0, 5, tr(joinNonEmpty(" - ", data.Issuer.Email, data.Issuer.Phone)), "", "C", false)
+	pdf.Ln(4)
+
+	pdf.SetFont(fontFamily, "", 10)
+	pdf.CellFormat(0, lineHeight, tr("Certificado nº "+data.Number), "", 1, "R", false, 0, "")
+	pdf.CellFormat(0, lineHeight, tr("Fecha de emisión: "+now.Format("02/01/2006")), "", 1, "R", false, 0, "")
+	pdf.Ln(4)
+
+	section := func(title string) {
+		pdf.Ln(4)
+		pdf.SetFont(fontFamily, "B", 12)
+		pdf.CellFormat(0, 8, tr(title), "B", 1, "L", false, 0, "")
+		pdf.Ln(2)
+	}
+	field := func(label string, value string) {
+		if value == "" {
+			return
+		}
+		pdf.SetFont(fontFamily, "B", 10)
+		pdf.CellFormat(labelWidth, lineHeight, tr(label+":"), "", 0, "L", false, 0, "")
+		pdf.SetFont(fontFamily, "", 10)
+		pdf.MultiCell(0, lineHeight, tr(value), "", "L", false)
+	}
+
+	section("Datos del donante")
+	field("Nombre", data.Donor.FullName)
+	field("NIF", data.Donor.TaxID)
+	field("Correo electrónico", data.Donor.Email)
+
+	pdf.Ln(4)
+	pdf.SetFont(fontFamily, "", 11)
+	certification := fmt.Sprintf("%s certifica que %s ha realizado durante el año %d las donaciones "+
+		"detalladas a continuación, con carácter irrevocable y sin contraprestación, por un importe total de %s.",
+		data.Issuer.Name, data.Donor.FullName, data.Year, FormatAmount(data.TotalCents, data.Currency))
+	pdf.MultiCell(0, lineHeight, tr(certification), "", "J", false)
+
+	section("Donaciones")
+	descriptionWidth := 210.0 - 2*margin - dateWidth - amountWidth
+	pdf.SetFont(fontFamily, "B", 10)
+	pdf.SetFillColor(235, 235, 235)
+	pdf.CellFormat(dateWidth, lineHeight+1, tr("Fecha"), "B", 0, "L", true, 0, "")
+	pdf.CellFormat(descriptionWidth, lineHeight+1, tr("Concepto"), "B", 0, "L", true, 0, "")
+	pdf.CellFormat(amountWidth, lineHeight+1, tr("Importe"), "B", 1, "R", true, 0, "")
+
+	pdf.SetFont(fontFamily, "", 10)
+	for _, line := range data.Lines {
+		pdf.CellFormat(dateWidth, lineHeight, line.Date.Format("02/01/2006"), "", 0, "L", false, 0, "")
+		pdf.CellFormat(descriptionWidth, lineHeight, tr(line.Description), "", 0, "L", false, 0, "")
+		pdf.CellFormat(amountWidth, lineHeight, tr(FormatAmount(line.AmountCents, data.Currency)), "", 1, "R", false, 0, "")
+	}
+
+	pdf.SetFont(fontFamily, "B", 10)
+	pdf.CellFormat(dateWidth+descriptionWidth, lineHeight+1, tr("Total"), "T", 0, "R", false, 0, "")
+	pdf.CellFormat(amountWidth, lineHeight+1, tr(FormatAmount(data.TotalCents, data.Currency)), "T", 1, "R", false, 0, "")
+
+	pdf.Ln(8)
+	pdf.SetFont(fontFamily, "I", 9)
+	pdf.MultiCell(0, 5, tr("Los importes son netos de devoluciones. Este certificado se emite a efectos "+
+		"de la deducción fiscal que corresponda al donante según la normativa aplicable."), "", "J", false)
+
+	var out bytes.Buffer
+	if err := pdf.Output(&out); err != nil {
+		return nil, fmt.Errorf("could not generate receipt PDF: %w", err)
+	}
+
+	return out.Bytes(), nil
+}
+
+// joinNonEmpty joins the non-empty values with the separator.
+func joinNonEmpty(separator string, values ...string) string {
+	parts := make([]string, 0, len(values))
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			parts = append(parts, value)
+		}
+	}
+
+	return strings.Join(parts, separator)
+}
@@ -0,0 +1,51 @@
+package scheduler
+
+import (
+	"fmt"
+	"time"
+)
+
+// Schedule decides when a job is due.
+type Schedule interface {
+	// Key returns the period containing now and whether the job is due in it.
+	// A job runs at most once per key.
+	Key(now time.Time) (string, bool)
+
+	// String describes the schedule for admin listings.
+	String() string
+}
+
+// Daily returns a schedule that runs once per local day, from the given hour on.
+// If the backend is down at that hour, the job runs as soon as it starts that day.
+func Daily(hour int) Schedule {
+	return daily{hour: hour}
+}
+
+// Every returns a schedule that runs once per interval, aligned to the interval.
+func Every(interval time.Duration) Schedule {
+	return every{interval: interval}
+}
+
+type daily struct {
+	hour int
+}
+
+func (d daily) Key(now time.Time) (string, bool) {
+	return now.Format(time.DateOnly), now.Hour() >= d.hour
+}
+
+func (d daily) String() string {
+	return fmt.Sprintf("daily at %02d:00", d.hour)
+}
+
+type every struct {
+	interval time.Duration
+}
+
+func (e every) Key(now time.Time) (string, bool) {
+	return now.UTC().Truncate(e.interval).Format(time.RFC3339), true
+}
+
+func (e every) String() string {
+	return fmt.Sprintf("every %s", e.interval)
+}
@@ -0,0 +1,301 @@
+// Package scheduler runs background jobs on a schedule and records every run.
+//
+// Each schedule maps the current time to a period key (e.g. the day for daily
+// jobs). Before running, the scheduler claims the period in the Job_Runs table,
+// so a period is processed at most once even if the backend restarts or several
+// instances run at the same time. Failed periods are retried on the next tick, and
+// periods whose run is still marked as running after SCHEDULER_RUN_LEASE (the
+// process running it died) are claimed again.
+//
+// Jobs can also be triggered manually, optionally as a dry run that computes
+// the work without performing it (e.g. without sending emails).
+//
+// Pollers are frequent jobs that drain a queue (e.g. the email outbox). They are
+// checked by their own loop and each one has its own lock, so a long job never
+// delays them, and their runs are deleted after SCHEDULER_POLL_RETENTION.
+//
+// Configuration (environment variables):
+//   - SCHEDULER_TICK: How often schedules are checked (default 1m)
+//   - SCHEDULER_POLL_RETENTION: How long the runs of pollers are kept (default 24h)
+//   - SCHEDULER_RUN_LEASE: How long a run may stay as running before its period is claimed again (default 2h)
+package scheduler
+
+import (
+	"backend/internal/db/dao"
+	m "backend/internal/models"
+	"backend/internal/utils/env"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrUnknownJob is returned when triggering a job that is not registered.
+var ErrUnknownJob = errors.New("tarea no encontrada")
+
+// ErrAlreadyRan is returned when a manual run targets a period that was already processed.
+var ErrAlreadyRan = errors.New("la tarea ya se ha ejecutado en este periodo")
+
+// Result is the outcome of a job run.
+type Result struct {
+	Items   int    // Number of items processed (e.g. emails sent or that would be sent)
+	Summary string // Human readable outcome
+	Preview any    // Work a dry run would have done (returned to the caller, not stored)
+}
+
+// Job is a unit of background work.
+type Job struct {
+	Name     string
+	Schedule Schedule
+
+	// Run performs the job for the given time. With dryRun set it must not
+	// change anything or send anything, only report what it would do.
+	Run func(now time.Time, dryRun bool) (Result, error)
+
+	// Poller marks frequent jobs that drain a queue: they run apart from the
+	// other jobs and only their latest runs are kept (see SCHEDULER_POLL_RETENTION).
+	Poller bool
+}
+
+// JobStatus describes a registered job and its latest run.
+type JobStatus struct {
+	Name     string    `json:"name"`     // Job name
+	Schedule string    `json:"schedule"` // Human readable schedule
+	LastRun  *m.JobRun `json:"last_run"` // Latest run (nil if the job never ran)
+}
+
+var (
+	jobsMu sync.Mutex
+	jobs   = map[string]Job{}
+
+	// runMu serialises job runs so manual triggers never overlap scheduled runs
+	runMu sync.Mutex
+
+	// pollerMu holds the lock of each poller, which only serialises the runs of that poller
+	pollerMu    sync.Mutex
+	pollerLocks = map[string]*sync.Mutex{}
+
+	tick = env.GetDuration("SCHEDULER_TICK", time.Minute)
+
+	pollRetention = env.GetDuration("SCHEDULER_POLL_RETENTION", 24*time.Hour)
+
+	runLease = env.GetDuration("SCHEDULER_RUN_LEASE", 2*time.Hour)
+)
+
+// pruneInterval is how often the old runs of each poller are deleted.
+const pruneInterval = time.Hour
+
+// Register adds a job to the scheduler. Registering a name twice replaces the job.
+func Register(job Job) {
+	jobsMu.Lock()
+	defer jobsMu.Unlock()
+
+	jobs[job.Name] = job
+}
+
+// Start checks every registered job immediately and then every SCHEDULER_TICK in the background.
+// Pollers are checked by a loop of their own.
+func Start() {
+	for _, pollers := range []bool{false, true} {
+		go func() {
+			// Periods already claimed or skipped by this process, to avoid querying every tick
+			checked := map[string]string{}
+			pruned := map[string]time.Time{}
+
+			runDue(time.Now(), pollers, checked, pruned)
+			ticker := time.NewTicker(tick)
+			defer ticker.Stop()
+
+			for now := range ticker.C {
+				runDue(now, pollers, checked, pruned)
+			}
+		}()
+	}
+}
+
+// Trigger runs a job immediately.
+//
+// Behaviour:
+//   - dryRun: Runs the job without side effects; never claims the period
+//   - force: Runs even if the current period was already processed; never claims the period
+//   - Otherwise claims the current period like a scheduled run, returning ErrAlreadyRan if it was processed
+//
+// Parameters:
+//   - name: Job name
+//   - dryRun: Whether to only preview the work
+//   - force: Whether to ignore previous runs of the period
+//
+// Returns:
+//   - *m.JobRun: Recorded run, including the preview of dry runs
+//   - error: ErrUnknownJob, ErrAlreadyRan or database error
+func Trigger(name string, dryRun bool, force bool) (*m.JobRun, error) {
+	job, ok := lookup(name)
+	if !ok {
+		return nil, ErrUnknownJob
+	}
+
+	now := time.Now()
+	run := &m.JobRun{Job: job.Name, Trigger: m.JobTriggerManual, DryRun: dryRun, StartedAt: now}
+
+	if dryRun || force {
+		if err := dao.CreateJobRun(run); err != nil {
+			return nil, err
+		}
+	} else {
+		key, _ := job.Schedule.Key(now)
+		run.RunKey = &key
+
+		claimed, err := dao.ClaimJobRun(run, runLease)
+		if err != nil {
+			return nil, err
+		}
+		if !claimed {
+			return nil, ErrAlreadyRan
+		}
+	}
+
+	execute(job, run)
+	return run, nil
+}
+
+// List returns every registered job with its latest run, sorted by name.
+func List() ([]JobStatus, error) {
+	jobsMu.Lock()
+	registered := make([]Job, 0, len(jobs))
+	for _, job := range jobs {
+		registered = append(registered, job)
+	}
+	jobsMu.Unlock()
+
+	sort.Slice(registered, func(i, j int) bool { return registered[i].Name < registered[j].Name })
+
+	statuses := make([]JobStatus, 0, len(registered))
+	for _, job := range registered {
+		last, err := dao.GetLastJobRun(job.Name)
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, JobStatus{Name: job.Name, Schedule: job.Schedule.String(), LastRun: last})
+	}
+
+	return statuses, nil
+}
+
+// Exists reports whether a job is registered under name.
+func Exists(name string) bool {
+	_, ok := lookup(name)
+	return ok
+}
+
+// runDue claims and runs every job (or every poller) whose current period is due and not yet processed.
+// The old runs of pollers are deleted at most once per pruneInterval.
+func runDue(now time.Time, pollers bool, checked map[string]string, pruned map[string]time.Time) {
+	jobsMu.Lock()
+	due := make([]Job, 0, len(jobs))
+	for _, job := range jobs {
+		if job.Poller == pollers {
+			due = append(due, job)
+		}
+	}
+	jobsMu.Unlock()
+
+	for _, job := range due {
+		key, ok := job.Schedule.Key(now)
+		if !ok || checked[job.Name] == key {
+			continue
+		}
+
+		run := &m.JobRun{Job: job.Name, RunKey: &key, Trigger: m.JobTriggerSchedule, StartedAt: now}
+		claimed, err := dao.ClaimJobRun(run, runLease)
+		if err != nil {
+			// Retry on the next tick
+			log.Printf("could not claim %s run %s: %v", job.Name, key, err)
+			continue
+		}
+
+		if !claimed {
+			// A run still marked as running may have been abandoned: check again until its lease expires
+			if run.Status != m.JobRunRunning {
+				checked[job.Name] = key
+			}
+			continue
+		}
+
+		execute(job, run)
+		if run.Status == m.JobRunSuccess {
+			checked[job.Name] = key
+		}
+
+		if job.Poller && now.Sub(pruned[job.Name]) >= pruneInterval {
+			if _, err := dao.DeleteJobRunsBefore(job.Name, now.Add(-pollRetention)); err != nil {
+				log.Printf("could not prune %s runs: %v", job.Name, err)
+				continue
+			}
+			pruned[job.Name] = now
+		}
+	}
+}
+
+// execute runs a claimed job and stores its outcome in run.
+func execute(job Job, run *m.JobRun) {
+	lock := lockOf(job)
+	lock.Lock()
+	defer lock.Unlock()
+
+	result, err := safeRun(job, run.StartedAt, run.DryRun)
+
+	finished := time.Now()
+	run.FinishedAt = &finished
+	run.Items = result.Items
+	run.Summary = result.Summary
+	run.Preview = result.Preview
+	run.Status = m.JobRunSuccess
+	if err != nil {
+		run.Status = m.JobRunFailed
+		run.Error = err.Error()
+		log.Printf("job %s failed: %v", job.Name, err)
+	}
+
+	if err := dao.FinishJobRun(run); err != nil {
+		log.Printf("could not record %s run %d: %v", job.Name, run.ID, err)
+	}
+}
+
+// safeRun calls job.Run, turning panics into errors so the scheduler keeps running.
+func safeRun(job Job, now time.Time, dryRun bool) (result Result, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	return job.Run(now, dryRun)
+}
+
+// lockOf returns the lock a job runs under: the lock of the poller for pollers, runMu for every other job.
+func lockOf(job Job) *sync.Mutex {
+	if !job.Poller {
+		return &runMu
+	}
+
+	pollerMu.Lock()
+	defer pollerMu.Unlock()
+
+	lock, ok := pollerLocks[job.Name]
+	if !ok {
+		lock = &sync.Mutex{}
+		pollerLocks[job.Name] = lock
+	}
+
+	return lock
+}
+
+func lookup(name string) (Job, bool) {
+	jobsMu.Lock()
+	defer jobsMu.Unlock()
+
+	job, ok := jobs[name]
+	return job, ok
+}
@@ -0,0 +1,180 @@
+// Package search implements the text analysis used by full-text pet search.
+// This package is responsible for:
+// - Normalising text (lowercase, accents removed) the same way for queries and documents
+// - Dropping Spanish and English stopwords
+// - Light Spanish stemming so "perros", "perra" and "perrito" match each other
+// - Typo tolerance through a vocabulary of indexed words and edit distance
+// - Building highlighted snippets for search results
+//
+// The database does the matching and ranking (MySQL FULLTEXT or Postgres tsvector);
+// this package only prepares the terms sent to it and post-processes the results.
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	// MaxQueryLength is the maximum accepted length of a search query in bytes.
+	MaxQueryLength = 200
+
+	// MaxTerms is the maximum number of terms kept from a query.
+	MaxTerms = 10
+
+	// minStemLength is the shortest stem produced by Stem.
+	minStemLength = 3
+)
+
+// Term is an analysed query word.
+type Term struct {
+	Word     string   // Normalised word as typed
+	Stem     string   // Light Spanish stem of Word, used as a prefix
+	Variants []string // Indexed words close to Word, added for typo tolerance
+}
+
+// Prefixes returns every prefix matched by the term: its stem and the stems of its variants.
+func (t Term) Prefixes() []string {
+	prefixes := []string{t.Stem}
+	for _, variant := range t.Variants {
+		stem := Stem(variant)
+		if !contains(prefixes, stem) {
+			prefixes = append(prefixes, stem)
+		}
+	}
+
+	return prefixes
+}
+
+// accentReplacer maps accented Latin letters to their base letter.
+var accentReplacer = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ä", "a",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"í", "i", "ì", "i", "î", "i", "ï", "i",
+	"ó", "o", "ò", "o", "ô", "o", "ö", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"ñ", "n", "ç", "c",
+)
+
+// stopwords are common Spanish and English words ignored in queries.
+var stopwords = map[string]bool{}
+
+func init() {
+	for _, word := range strings.Fields(`
+		a al algo con como de del desde donde el ella ellos en entre es esta este esto
+		ha hay la las le les lo los mas me mi muy ni no o os para pero por que se
+		sea ser si sin sobre su sus tambien te tiene tu un una uno unos unas y ya
+		about an and are as at be but by for from has have he her his i in is it
+		its of on or she that the their they this to very was were what when where
+		which who will with you`) {
+		stopwords[word] = true
+	}
+}
+
+// Normalize lowercases text and removes accents.
+func Normalize(text string) string {
+	return accentReplacer.Replace(strings.ToLower(text))
+}
+
+// IsStopword reports whether the normalised word is ignored in queries.
+func IsStopword(word string) bool {
+	return stopwords[word]
+}
+
+// Tokenize splits text into normalised words made of letters and digits.
+func Tokenize(text string) []string {
+	return strings.FieldsFunc(Normalize(text), isSeparator)
+}
+
+// Parse analyses a search query into terms.
+// Stopwords and repeated stems are dropped and at most MaxTerms terms are kept.
+//
+// Parameters:
+//   - q: Raw query typed by the user
+//
+// Returns:
+//   - []Term: Analysed terms without variants (see Vocabulary.Expand)
+func Parse(q string) []Term {
+	var terms []Term
+	seen := map[string]bool{}
+
+	for _, word := range Tokenize(q) {
+		if IsStopword(word) {
+			continue
+		}
+
+		stem := Stem(word)
+		if seen[stem] {
+			continue
+		}
+		seen[stem] = true
+
+		terms = append(terms, Term{Word: word, Stem: stem})
+		if len(terms) == MaxTerms {
+			break
+		}
+	}
+
+	return terms
+}
+
+// Stem reduces a normalised word to a light Spanish stem.
+//
+// Rules (applied once, keeping at least 3 characters):
+// - Diminutives: -ito, -ita, -itos, -itas, -illo, -illa, -illos, -illas
+// - Plurals: -ces becomes -z, -es and -s are removed
+// - Gender and final vowels: -o, -a, -e are removed
+//
+// The stem is used as a prefix, so "perr" matches perro, perra, perros and perrito.
+func Stem(word string) string {
+	if len(word) <= minStemLength {
+		return word
+	}
+
+	for _, suffix := range []string{"itos", "itas", "illos", "illas", "ito", "ita", "illo", "illa"} {
+		if trimmed, ok := trimSuffix(word, suffix); ok {
+			return trimmed
+		}
+	}
+
+	if trimmed, ok := trimSuffix(word, "ces"); ok {
+		return trimmed + "z"
+	}
+
+	// English words such as "glass" keep their double s
+	if strings.HasSuffix(word, "ss") {
+		return word
+	}
+
+	for _, suffix := range []string{"os", "as", "es", "s", "o", "a", "e"} {
+		if trimmed, ok := trimSuffix(word, suffix); ok {
+			return trimmed
+		}
+	}
+
+	return word
+}
+
+// trimSuffix removes suffix from word when the remaining stem is long enough.
+func trimSuffix(word string, suffix string) (string, bool) {
+	if !strings.HasSuffix(word, suffix) || len(word)-len(suffix) < minStemLength {
+		return word, false
+	}
+
+	return strings.TrimSuffix(word, suffix), true
+}
+
+// isSeparator reports whether r separates words.
+func isSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+
+	return false
+}
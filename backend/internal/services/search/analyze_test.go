@@ -0,0 +1,126 @@
+package search
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestStem(t *testing.T) {
+	tests := []struct {
+		word string
+		want string
+	}{
+		{word: "perro", want: "perr"},
+		{word: "perra", want: "perr"},
+		{word: "perros", want: "perr"},
+		{word: "perrito", want: "perr"},
+		{word: "gatitas", want: "gat"},
+		{word: "cachorrillo", want: "cachorr"},
+		{word: "nueces", want: "nuez"},
+		{word: "leones", want: "leon"},
+		{word: "glass", want: "glass"},
+		{word: "dogs", want: "dog"},
+		{word: "gato", want: "gat"},
+		{word: "oso", want: "oso"},
+		{word: "osa", want: "osa"},
+		{word: "pit", want: "pit"},
+		{word: "bonito", want: "bon"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			if got := Stem(tt.word); got != tt.want {
+				t.Errorf("Stem(%q) = %q, want %q", tt.word, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{name: "accents and case", query: "Pastor ALEMÁN", want: []string{"pastor", "aleman"}},
+		{name: "stopwords dropped", query: "un perro para la familia", want: []string{"perro", "familia"}},
+		{name: "repeated stems dropped", query: "perro perra perritos", want: []string{"perro"}},
+		{name: "punctuation separates words", query: "border-collie, (negro)", want: []string{"border", "collie", "negro"}},
+		{name: "only stopwords", query: "el de la", want: nil},
+		{name: "empty", query: "", want: nil},
+		{name: "sql and markup are just words", query: "' OR 1=1 -- <b>", want: []string{"1", "b"}},
+		{name: "at most MaxTerms", query: strings.Repeat("uno dos tres cuatro cinco seis siete ocho nueve diez once doce ", 1), want: []string{"dos", "tres", "cuatro", "cinco", "seis", "siete", "ocho", "nueve", "diez", "once"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, term := range Parse(tt.query) {
+				got = append(got, term.Word)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Parse(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "perro", b: "perro", want: 0},
+		{a: "pero", b: "perro", want: 1},
+		{a: "perrro", b: "perro", want: 1},
+		{a: "pwrro", b: "perro", want: 1},
+		{a: "prero", b: "perro", want: 1},
+		{a: "labardor", b: "labrador", want: 1},
+		{a: "labrdaor", b: "labrador", want: 1},
+		{a: "gato", b: "perro", want: 4},
+		{a: "", b: "gato", want: 4},
+		{a: "pequeño", b: "pequeno", want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			if got := Distance(tt.a, tt.b); got != tt.want {
+				t.Errorf("Distance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+			if got := Distance(tt.b, tt.a); got != tt.want {
+				t.Errorf("Distance(%q, %q) = %d, want %d", tt.b, tt.a, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpand(t *testing.T) {
+	vocabulary := NewVocabulary("Luna es una perra labrador muy cariñosa", "Toby, pastor alemán")
+
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{name: "indexed word unchanged", query: "labrador", want: nil},
+		{name: "indexed prefix unchanged", query: "perritos", want: nil},
+		{name: "one typo", query: "labardor", want: []string{"labrador"}},
+		{name: "two typos in a long word", query: "carinoza", want: []string{"carinosa"}},
+		{name: "short words are not corrected", query: "lna", want: nil},
+		{name: "too many typos", query: "xyzdor", want: nil},
+		{name: "accents ignored", query: "alemán", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			terms := vocabulary.Expand(Parse(tt.query))
+			if len(terms) != 1 {
+				t.Fatalf("Expand(%q) returned %d terms, want 1", tt.query, len(terms))
+			}
+			if !slices.Equal(terms[0].Variants, tt.want) {
+				t.Errorf("Expand(%q) variants = %v, want %v", tt.query, terms[0].Variants, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package search
+
+import (
+	"html"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// HighlightStart and HighlightEnd wrap matched words in highlighted text.
+	HighlightStart = "<mark>"
+	HighlightEnd   = "</mark>"
+
+	// snippetContext is the number of words kept before the first match in a snippet.
+	snippetContext = 8
+
+	// Ellipsis marks text cut from a snippet.
+	Ellipsis = "…"
+)
+
+// span is the byte range of a word in the original text.
+type span struct {
+	start, end int
+	match      bool
+}
+
+// Highlight returns text HTML-escaped with every word matching one of the prefixes
+// wrapped in <mark></mark>. It returns an empty string when nothing matches.
+//
+// Parameters:
+//   - text: Original document text
+//   - prefixes: Normalised prefixes (see Term.Prefixes)
+//
+// Returns:
+//   - string: Escaped, highlighted text, or "" if no word matches
+func Highlight(text string, prefixes []string) string {
+	words := scan(text, prefixes)
+	if !anyMatch(words) {
+		return ""
+	}
+
+	return render(text, words, 0, len(text))
+}
+
+// Snippet returns a fragment of text of at most maxWords words starting shortly
+// before the first matching word, HTML-escaped and highlighted like Highlight.
+// It returns an empty string when nothing matches.
+//
+// Parameters:
+//   - text: Original document text
+//   - prefixes: Normalised prefixes (see Term.Prefixes)
+//   - maxWords: Maximum number of words in the fragment
+//
+// Returns:
+//   - string: Escaped, highlighted fragment with ellipses where text was cut, or ""
+func Snippet(text string, prefixes []string, maxWords int) string {
+	words := scan(text, prefixes)
+
+	first := -1
+	for i, word := range words {
+		if word.match {
+			first = i
+			break
+		}
+	}
+	if first < 0 {
+		return ""
+	}
+
+	// The context before the match never pushes the match itself out of the fragment
+	maxWords = max(maxWords, 1)
+	from := max(first-min(snippetContext, maxWords-1), 0)
+	to := min(from+maxWords, len(words))
+	from = max(to-maxWords, 0)
+
+	start, end := words[from].start, words[to-1].end
+	if from == 0 {
+		start = 0
+	}
+	if to == len(words) {
+		end = len(text)
+	}
+
+	fragment := render(text, words[from:to], start, end)
+	if start > 0 {
+		fragment = Ellipsis + fragment
+	}
+	if end < len(text) {
+		fragment += Ellipsis
+	}
+
+	return strings.TrimSpace(fragment)
+}
+
+// scan splits text into words and flags the ones matching a prefix.
+func scan(text string, prefixes []string) []span {
+	var words []span
+
+	start := -1
+	for i := 0; i <= len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		if i == len(text) || isSeparator(r) {
+			if start >= 0 {
+				words = append(words, span{start: start, end: i, match: matches(text[start:i], prefixes)})
+				start = -1
+			}
+			if i == len(text) {
+				break
+			}
+		} else if start < 0 {
+			start = i
+		}
+		i += size
+	}
+
+	return words
+}
+
+// matches reports whether the normalised word starts with one of the prefixes.
+func matches(word string, prefixes []string) bool {
+	normalized := Normalize(word)
+	for _, prefix := range prefixes {
+		if prefix != "" && strings.HasPrefix(normalized, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// render escapes text[start:end], wrapping the matching words.
+func render(text string, words []span, start int, end int) string {
+	var b strings.Builder
+
+	pos := start
+	for _, word := range words {
+		if !word.match {
+			continue
+		}
+		b.WriteString(html.EscapeString(text[pos:word.start]))
+		b.WriteString(HighlightStart)
+		b.WriteString(html.EscapeString(text[word.start:word.end]))
+		b.WriteString(HighlightEnd)
+		pos = word.end
+	}
+	b.WriteString(html.EscapeString(text[pos:end]))
+
+	return b.String()
+}
+
+func anyMatch(words []span) bool {
+	for _, word := range words {
+		if word.match {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MatchesAll reports whether every term matches at least one word of the texts,
+// using the same prefix rules as the full-text search. Used to check keyword
+// criteria outside the database (e.g. saved search alerts).
+//
+// Parameters:
+//   - terms: Analysed terms (see Parse)
+//   - texts: Document texts to look in
+//
+// Returns:
+//   - bool: true if every term is found (also true when there are no terms)
+func MatchesAll(terms []Term, texts ...string) bool {
+	var words []string
+	for _, text := range texts {
+		words = append(words, Tokenize(text)...)
+	}
+
+	for _, term := range terms {
+		found := false
+		for _, word := range words {
+			if matches(word, term.Prefixes()) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
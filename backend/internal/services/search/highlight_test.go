@@ -0,0 +1,85 @@
+package search
+
+import (
+	"strings"
+	"testing"
+)
+
+// prefixes returns the prefixes of every term of a query.
+func prefixes(q string) []string {
+	var all []string
+	for _, term := range Parse(q) {
+		all = append(all, term.Prefixes()...)
+	}
+
+	return all
+}
+
+func TestHighlight(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		query string
+		want  string
+	}{
+		{name: "stem matches variants", text: "Perra muy buena con perros", query: "perro", want: "<mark>Perra</mark> muy buena con <mark>perros</mark>"},
+		{name: "accents kept in output", text: "Pastor Alemán", query: "aleman", want: "Pastor <mark>Alemán</mark>"},
+		{name: "no match", text: "Gato tranquilo", query: "perro", want: ""},
+		{name: "html escaped", text: "<script>perro</script> & gato", query: "perro", want: "&lt;script&gt;<mark>perro</mark>&lt;/script&gt; &amp; gato"},
+		{name: "markup in the query is not a match", text: "Luna <b>", query: "<mark>", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Highlight(tt.text, prefixes(tt.query)); got != tt.want {
+				t.Errorf("Highlight(%q, %q) = %q, want %q", tt.text, tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSnippet(t *testing.T) {
+	long := strings.Repeat("palabra ", 20) + "perro " + strings.Repeat("texto ", 20)
+
+	tests := []struct {
+		name     string
+		text     string
+		maxWords int
+		want     string
+	}{
+		{name: "short text kept whole", text: "Un perro feliz.", maxWords: 10, want: "Un <mark>perro</mark> feliz."},
+		{name: "no match", text: "Un gato feliz", maxWords: 10, want: ""},
+		{
+			name:     "cut on both sides",
+			text:     long,
+			maxWords: 12,
+			want:     Ellipsis + strings.Repeat("palabra ", 8) + "<mark>perro</mark> texto texto texto" + Ellipsis,
+		},
+		{
+			name:     "match at the start",
+			text:     "perro uno dos tres cuatro",
+			maxWords: 3,
+			want:     "<mark>perro</mark> uno dos" + Ellipsis,
+		},
+		{
+			name:     "match near the end",
+			text:     "uno dos tres cuatro cinco seis perro",
+			maxWords: 3,
+			want:     Ellipsis + "cinco seis <mark>perro</mark>",
+		},
+		{
+			name:     "single word",
+			text:     "uno dos tres cuatro cinco seis perro siete",
+			maxWords: 1,
+			want:     Ellipsis + "<mark>perro</mark>" + Ellipsis,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Snippet(tt.text, prefixes("perro"), tt.maxWords); got != tt.want {
+				t.Errorf("Snippet = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
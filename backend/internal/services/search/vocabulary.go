@@ -0,0 +1,158 @@
+package search
+
+import (
+	"sort"
+)
+
+// maxVariants is the maximum number of typo corrections added to a term.
+const maxVariants = 3
+
+// Vocabulary is the set of normalised words present in the searchable documents.
+// It is used to correct typos: query words that match nothing are expanded with
+// the closest indexed words.
+type Vocabulary struct {
+	words []string // Sorted, unique, normalised words
+}
+
+// NewVocabulary builds a vocabulary from the given document texts.
+//
+// Parameters:
+//   - texts: Searchable texts (names, breeds, descriptions)
+//
+// Returns:
+//   - *Vocabulary: Vocabulary containing every word of the texts except stopwords
+func NewVocabulary(texts ...string) *Vocabulary {
+	seen := map[string]bool{}
+	for _, text := range texts {
+		for _, word := range Tokenize(text) {
+			if !IsStopword(word) {
+				seen[word] = true
+			}
+		}
+	}
+
+	words := make([]string, 0, len(seen))
+	for word := range seen {
+		words = append(words, word)
+	}
+	sort.Strings(words)
+
+	return &Vocabulary{words: words}
+}
+
+// HasPrefix reports whether any indexed word starts with prefix.
+func (v *Vocabulary) HasPrefix(prefix string) bool {
+	i := sort.SearchStrings(v.words, prefix)
+	return i < len(v.words) && len(v.words[i]) >= len(prefix) && v.words[i][:len(prefix)] == prefix
+}
+
+// Expand adds typo corrections to the terms whose stem matches no indexed word.
+// Terms that already match are returned unchanged.
+//
+// Parameters:
+//   - terms: Analysed query terms
+//
+// Returns:
+//   - []Term: Terms with Variants filled for likely typos
+func (v *Vocabulary) Expand(terms []Term) []Term {
+	expanded := make([]Term, len(terms))
+	for i, term := range terms {
+		expanded[i] = term
+		if !v.HasPrefix(term.Stem) {
+			expanded[i].Variants = v.suggest(term.Word)
+		}
+	}
+
+	return expanded
+}
+
+// suggest returns the indexed words closest to word within the allowed edit distance.
+func (v *Vocabulary) suggest(word string) []string {
+	limit := MaxEdits(word)
+	if limit == 0 {
+		return nil
+	}
+
+	type candidate struct {
+		word     string
+		distance int
+	}
+
+	var candidates []candidate
+	for _, indexed := range v.words {
+		if abs(len(indexed)-len(word)) > limit {
+			continue
+		}
+		if d := Distance(word, indexed); d <= limit {
+			candidates = append(candidates, candidate{word: indexed, distance: d})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].distance < candidates[j].distance
+	})
+
+	var variants []string
+	for _, c := range candidates {
+		variants = append(variants, c.word)
+		if len(variants) == maxVariants {
+			break
+		}
+	}
+
+	return variants
+}
+
+// MaxEdits returns the number of typos tolerated in a word:
+// none up to 3 characters, one up to 7 and two for longer words.
+func MaxEdits(word string) int {
+	switch n := len([]rune(word)); {
+	case n <= 3:
+		return 0
+	case n <= 7:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// Distance returns the optimal string alignment distance between a and b:
+// the number of insertions, deletions, substitutions and adjacent transpositions
+// needed to turn one into the other.
+func Distance(a string, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	// Three rolling rows: two rows back (for transpositions), previous and current
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				curr[j] = min(curr[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, curr = prev, curr, prev2
+	}
+
+	return prev[len(rb)]
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
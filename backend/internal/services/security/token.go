@@ -0,0 +1,73 @@
+package security
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"log"
+	"os"
+	"strings"
+	"sync"
+)
+
+// ErrInvalidToken is returned when a signed token is malformed or its signature does not match.
+var ErrInvalidToken = errors.New("token inválido")
+
+var (
+	tokenKey     []byte
+	tokenKeyOnce sync.Once
+)
+
+// SignToken returns value followed by an HMAC-SHA256 signature bound to purpose.
+// The token is URL-safe, so it can be used directly in email links.
+// A token signed for one purpose is never valid for another.
+//
+// The key is read from TOKEN_SECRET. When unset a random key is generated,
+// which invalidates every issued token on restart.
+func SignToken(purpose string, value string) string {
+	return value + "." + signature(purpose, value)
+}
+
+// VerifyToken checks a token produced by SignToken for the same purpose and
+// returns the signed value.
+func VerifyToken(purpose string, token string) (string, error) {
+	i := strings.LastIndex(token, ".")
+	if i < 0 {
+		return "", ErrInvalidToken
+	}
+
+	value, sig := token[:i], token[i+1:]
+	if !hmac.Equal([]byte(sig), []byte(signature(purpose, value))) {
+		return "", ErrInvalidToken
+	}
+
+	return value, nil
+}
+
+func signature(purpose string, value string) string {
+	mac := hmac.New(sha256.New, signingKey())
+	mac.Write([]byte(purpose))
+	mac.Write([]byte{0})
+	mac.Write([]byte(value))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func signingKey() []byte {
+	tokenKeyOnce.Do(func() {
+		if secret := os.Getenv("TOKEN_SECRET"); secret != "" {
+			tokenKey = []byte(secret)
+			return
+		}
+
+		log.Printf("TOKEN_SECRET not set, signed links will stop working after a restart")
+		tokenKey = make([]byte, 32)
+		if _, err := rand.Read(tokenKey); err != nil {
+			log.Fatalf("could not generate token key: %v", err)
+		}
+	})
+
+	return tokenKey
+}
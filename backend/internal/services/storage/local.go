@@ -0,0 +1,108 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// LocalStorage stores objects as regular files below a root directory.
+// It is intended for development and single-server deployments.
+type LocalStorage struct {
+	root string
+}
+
+// NewLocal creates a local filesystem storage rooted at dir, creating the directory if needed.
+//
+// Parameters:
+//   - dir: Root directory where objects are written
+//
+// Returns:
+//   - *LocalStorage: Initialised local storage
+//   - error: Filesystem error or nil on success
+func NewLocal(dir string) (*LocalStorage, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("error al crear directorio de almacenamiento %s: %v", dir, err)
+	}
+
+	return &LocalStorage{root: dir}, nil
+}
+
+// Put writes data to a temporary file and renames it into place so readers
+// never observe partially written objects.
+func (s *LocalStorage) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("error al crear directorio para %s: %v", key, err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("error al crear fichero temporal para %s: %v", key, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("error al escribir %s: %v", key, err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("error al escribir %s: %v", key, err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("error al guardar %s: %v", key, err)
+	}
+
+	return nil
+}
+
+// Get opens the file stored under key.
+func (s *LocalStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error al leer %s: %v", key, err)
+	}
+
+	return file, nil
+}
+
+// Delete removes the file stored under key.
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("error al eliminar %s: %v", key, err)
+	}
+
+	return nil
+}
+
+// path maps a storage key to a filesystem path below the root directory.
+func (s *LocalStorage) path(key string) (string, error) {
+	if err := validateKey(key); err != nil {
+		return "", err
+	}
+
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
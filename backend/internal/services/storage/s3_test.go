@@ -0,0 +1,299 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	testAccessKey = "AKIDEXAMPLE"
+	testSecretKey = "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"
+	testRegion    = "eu-west-1"
+	testBucket    = "pets"
+)
+
+// fakeS3 is a minimal S3 stand-in that verifies the Signature Version 4 of
+// every request from the server side before serving it from memory.
+type fakeS3 struct {
+	mu       sync.Mutex
+	objects  map[string][]byte
+	types    map[string]string
+	requests int
+}
+
+func newFakeS3(t *testing.T) (*fakeS3, *httptest.Server) {
+	fake := &fakeS3{objects: map[string][]byte{}, types: map[string]string{}}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	return fake, server
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests++
+
+	body, _ := io.ReadAll(r.Body)
+	if err := verifySignature(r, body); err != nil {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprintf(w, "<Error><Code>SignatureDoesNotMatch</Code><Message>%s</Message></Error>", err)
+		return
+	}
+
+	prefix := "/" + testBucket + "/"
+	if !strings.HasPrefix(r.URL.Path, prefix) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, "<Error><Code>NoSuchBucket</Code></Error>")
+		return
+	}
+	key := strings.TrimPrefix(r.URL.Path, prefix)
+
+	switch r.Method {
+	case http.MethodPut:
+		f.objects[key] = body
+		f.types[key] = r.Header.Get("Content-Type")
+	case http.MethodGet:
+		data, ok := f.objects[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, "<Error><Code>NoSuchKey</Code></Error>")
+			return
+		}
+		w.Write(data)
+	case http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// verifySignature rebuilds the canonical request as received by the server and checks the signature.
+func verifySignature(r *http.Request, body []byte) error {
+	auth := strings.TrimPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 ")
+	fields := map[string]string{}
+	for _, part := range strings.Split(auth, ", ") {
+		name, value, _ := strings.Cut(part, "=")
+		fields[name] = value
+	}
+
+	credential := strings.Split(fields["Credential"], "/")
+	if len(credential) != 5 || credential[0] != testAccessKey || credential[2] != testRegion || credential[3] != "s3" {
+		return fmt.Errorf("credential inválida: %s", fields["Credential"])
+	}
+
+	amzDate := r.Header.Get("X-Amz-Date")
+	signedAt, err := time.Parse("20060102T150405Z", amzDate)
+	if err != nil || time.Since(signedAt).Abs() > 15*time.Minute || credential[1] != amzDate[:8] {
+		return fmt.Errorf("fecha inválida: %s", amzDate)
+	}
+
+	sum := sha256.Sum256(body)
+	payloadHash := hex.EncodeToString(sum[:])
+	if r.Header.Get("X-Amz-Content-Sha256") != payloadHash {
+		return errors.New("hash del contenido no coincide")
+	}
+
+	signed := strings.Split(fields["SignedHeaders"], ";")
+	if !sort.StringsAreSorted(signed) {
+		return errors.New("cabeceras firmadas desordenadas")
+	}
+	var headers strings.Builder
+	for _, name := range signed {
+		value := r.Header.Get(name)
+		if name == "host" {
+			value = r.Host
+		}
+		headers.WriteString(name + ":" + value + "\n")
+	}
+	for _, required := range []string{"host", "x-amz-content-sha256", "x-amz-date"} {
+		if !strings.Contains(";"+fields["SignedHeaders"]+";", ";"+required+";") {
+			return fmt.Errorf("cabecera %s sin firmar", required)
+		}
+	}
+
+	canonical := strings.Join([]string{r.Method, canonicalURI(r.URL.Path), r.URL.RawQuery, headers.String(), fields["SignedHeaders"], payloadHash}, "\n")
+	canonicalHash := sha256.Sum256([]byte(canonical))
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		strings.Join(credential[1:], "/"),
+		hex.EncodeToString(canonicalHash[:]),
+	}, "\n")
+
+	key := []byte("AWS4" + testSecretKey)
+	for _, part := range append(credential[1:], stringToSign) {
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte(part))
+		key = mac.Sum(nil)
+	}
+
+	if !hmac.Equal([]byte(hex.EncodeToString(key)), []byte(fields["Signature"])) {
+		return errors.New("firma incorrecta")
+	}
+
+	return nil
+}
+
+// canonicalURI encodes every byte of the decoded path except the unreserved characters, as S3 does.
+func canonicalURI(path string) string {
+	var b strings.Builder
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || strings.IndexByte("-_.~/", c) >= 0 {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+
+	return b.String()
+}
+
+func newTestS3(t *testing.T, endpoint string, secret string) *S3Storage {
+	t.Helper()
+
+	store, err := NewS3(S3Config{
+		Endpoint:  endpoint,
+		Region:    testRegion,
+		Bucket:    testBucket,
+		AccessKey: testAccessKey,
+		SecretKey: secret,
+		PathStyle: true,
+	})
+	if err != nil {
+		t.Fatalf("NewS3 error = %v", err)
+	}
+
+	return store
+}
+
+func TestS3PutGetDelete(t *testing.T) {
+	fake, server := newFakeS3(t)
+	store := newTestS3(t, server.URL, testSecretKey)
+	ctx := context.Background()
+
+	keys := []struct {
+		name string
+		key  string
+	}{
+		{name: "photo key", key: "pets/1/abc/original.jpg"},
+		{name: "spaces", key: "pets/1/mi foto.jpg"},
+		{name: "non ascii", key: "pets/1/cañón.jpg"},
+		{name: "reserved characters", key: "pets/1/a+b=c&d;e,f:g@h!(1).jpg"},
+	}
+
+	for _, tt := range keys {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := store.Put(ctx, tt.key, []byte("foto"), "image/jpeg"); err != nil {
+				t.Fatalf("Put error = %v", err)
+			}
+			if got := fake.types[tt.key]; got != "image/jpeg" {
+				t.Errorf("stored content type = %q, want image/jpeg", got)
+			}
+
+			reader, err := store.Get(ctx, tt.key)
+			if err != nil {
+				t.Fatalf("Get error = %v", err)
+			}
+			data, _ := io.ReadAll(reader)
+			reader.Close()
+			if string(data) != "foto" {
+				t.Errorf("Get = %q, want foto", data)
+			}
+
+			if err := store.Delete(ctx, tt.key); err != nil {
+				t.Fatalf("Delete error = %v", err)
+			}
+			if _, err := store.Get(ctx, tt.key); !errors.Is(err, ErrNotFound) {
+				t.Errorf("Get after Delete error = %v, want ErrNotFound", err)
+			}
+		})
+	}
+}
+
+func TestS3WrongSecret(t *testing.T) {
+	_, server := newFakeS3(t)
+	store := newTestS3(t, server.URL, "otra-clave")
+	ctx := context.Background()
+
+	if err := store.Put(ctx, "pets/1/a.jpg", []byte("foto"), "image/jpeg"); err == nil || !strings.Contains(err.Error(), "(403)") {
+		t.Errorf("Put error = %v, want signature rejected", err)
+	}
+	if _, err := store.Get(ctx, "pets/1/a.jpg"); err == nil || errors.Is(err, ErrNotFound) {
+		t.Errorf("Get error = %v, want signature rejected", err)
+	}
+	if err := store.Delete(ctx, "pets/1/a.jpg"); err == nil {
+		t.Error("Delete error = nil, want signature rejected")
+	}
+}
+
+func TestS3InvalidKey(t *testing.T) {
+	fake, server := newFakeS3(t)
+	store := newTestS3(t, server.URL, testSecretKey)
+
+	if err := store.Put(context.Background(), "../other-bucket/a.jpg", []byte("foto"), "image/jpeg"); err == nil {
+		t.Error("Put error = nil, want invalid key")
+	}
+	if fake.requests != 0 {
+		t.Errorf("server received %d requests, want none", fake.requests)
+	}
+}
+
+func TestS3ObjectURL(t *testing.T) {
+	tests := []struct {
+		name      string
+		endpoint  string
+		pathStyle bool
+		key       string
+		want      string
+	}{
+		{name: "path style", endpoint: "http://localhost:9000", pathStyle: true, key: "pets/1/a.jpg", want: "http://localhost:9000/pets/pets/1/a.jpg"},
+		{name: "virtual hosted", endpoint: "https://s3.eu-west-1.amazonaws.com", key: "pets/1/a.jpg", want: "https://pets.s3.eu-west-1.amazonaws.com/pets/1/a.jpg"},
+		{name: "escaped segments", endpoint: "http://localhost:9000", pathStyle: true, key: "pets/1/mi foto+1.jpg", want: "http://localhost:9000/pets/pets/1/mi%20foto%2B1.jpg"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, err := NewS3(S3Config{Endpoint: tt.endpoint, Region: testRegion, Bucket: testBucket, AccessKey: testAccessKey, SecretKey: testSecretKey, PathStyle: tt.pathStyle})
+			if err != nil {
+				t.Fatalf("NewS3 error = %v", err)
+			}
+			if got := store.objectURL(tt.key).String(); got != tt.want {
+				t.Errorf("objectURL = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewS3Config(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  S3Config
+	}{
+		{name: "missing bucket", cfg: S3Config{Endpoint: "http://localhost:9000", AccessKey: "a", SecretKey: "b"}},
+		{name: "missing credentials", cfg: S3Config{Endpoint: "http://localhost:9000", Bucket: "pets"}},
+		{name: "endpoint without host", cfg: S3Config{Endpoint: "localhost", Bucket: "pets", AccessKey: "a", SecretKey: "b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewS3(tt.cfg); err == nil {
+				t.Error("NewS3 error = nil, want configuration error")
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+// Package storage provides a backend-agnostic object storage abstraction.
+// It is used to persist binary content such as pet photos and their thumbnails.
+//
+// Available backends:
+//   - local: Stores objects as files below a directory on the local filesystem
+//   - s3: Stores objects in any S3-compatible service (AWS S3, MinIO, etc.)
+//
+// Configuration (environment variables):
+//   - STORAGE_BACKEND: "local" (default) or "s3"
+//   - STORAGE_LOCAL_DIR: Root directory for the local backend (default "./uploads")
+//   - S3_ENDPOINT, S3_REGION, S3_BUCKET, S3_ACCESS_KEY, S3_SECRET_KEY, S3_PATH_STYLE
+package storage
+
+import (
+	"backend/internal/utils/env"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"sync"
+)
+
+// ErrNotFound is returned when the requested object does not exist in the storage backend.
+var ErrNotFound = errors.New("objeto no encontrado")
+
+// Storage is the interface implemented by every object storage backend.
+// Keys are slash-separated relative paths (e.g. "pets/1/abc/original.jpg").
+type Storage interface {
+	// Put stores data under key, replacing any existing object.
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+
+	// Get opens the object stored under key. Callers must close the returned reader.
+	// Returns ErrNotFound if the object does not exist.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// Delete removes the object stored under key. Deleting a missing object is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+var (
+	instance Storage
+	once     sync.Once
+)
+
+// Open returns the storage backend configured through environment variables,
+// ensuring that it is only initialised once.
+// If the backend cannot be initialised, it logs a fatal error and exits the program.
+func Open() Storage {
+	once.Do(func() {
+		var err error
+		instance, err = New(env.Get("STORAGE_BACKEND", "local"))
+		if err != nil {
+			log.Fatalf("failed to initialise storage: %v", err)
+		}
+	})
+
+	return instance
+}
+
+// New creates a storage backend by name using the environment configuration.
+//
+// Parameters:
+//   - backend: Backend name ("local" or "s3")
+//
+// Returns:
+//   - Storage: Initialised storage backend
+//   - error: Configuration error or nil on success
+func New(backend string) (Storage, error) {
+	switch backend {
+	case "local":
+		return NewLocal(env.Get("STORAGE_LOCAL_DIR", "./uploads"))
+	case "s3":
+		return NewS3(S3Config{
+			Endpoint:  env.Get("S3_ENDPOINT", "https://s3.amazonaws.com"),
+			Region:    env.Get("S3_REGION", "us-east-1"),
+			Bucket:    env.Get("S3_BUCKET", ""),
+			AccessKey: env.Get("S3_ACCESS_KEY", ""),
+			SecretKey: env.Get("S3_SECRET_KEY", ""),
+			PathStyle: env.GetBool("S3_PATH_STYLE", true),
+		})
+	default:
+		return nil, fmt.Errorf("backend de almacenamiento desconocido: %s", backend)
+	}
+}
+
+// validateKey rejects empty keys and keys that could escape the storage root.
+func validateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") {
+		return fmt.Errorf("clave de almacenamiento inválida: %q", key)
+	}
+
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return fmt.Errorf("clave de almacenamiento inválida: %q", key)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestValidateKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		wantErr bool
+	}{
+		{name: "photo key", key: "pets/1/abc/original.jpg"},
+		{name: "single segment", key: "photo.jpg"},
+		{name: "dots inside a name", key: "pets/1/..abc../thumb.jpg"},
+		{name: "empty", key: "", wantErr: true},
+		{name: "absolute", key: "/etc/passwd", wantErr: true},
+		{name: "parent directory", key: "../secret", wantErr: true},
+		{name: "parent directory in the middle", key: "pets/../../secret", wantErr: true},
+		{name: "trailing parent directory", key: "pets/1/..", wantErr: true},
+		{name: "current directory", key: "pets/./1", wantErr: true},
+		{name: "empty segment", key: "pets//1", wantErr: true},
+		{name: "trailing slash", key: "pets/1/", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateKey(tt.key)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateKey(%q) error = %v, want error %v", tt.key, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLocalStorage(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "uploads")
+	store, err := NewLocal(root)
+	if err != nil {
+		t.Fatalf("NewLocal error = %v", err)
+	}
+	ctx := context.Background()
+
+	if err := store.Put(ctx, "pets/1/abc/original.jpg", []byte("foto"), "image/jpeg"); err != nil {
+		t.Fatalf("Put error = %v", err)
+	}
+
+	reader, err := store.Get(ctx, "pets/1/abc/original.jpg")
+	if err != nil {
+		t.Fatalf("Get error = %v", err)
+	}
+	data, _ := io.ReadAll(reader)
+	reader.Close()
+	if string(data) != "foto" {
+		t.Errorf("Get = %q, want foto", data)
+	}
+
+	if err := store.Delete(ctx, "pets/1/abc/original.jpg"); err != nil {
+		t.Fatalf("Delete error = %v", err)
+	}
+	if _, err := store.Get(ctx, "pets/1/abc/original.jpg"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get after Delete error = %v, want ErrNotFound", err)
+	}
+	if err := store.Delete(ctx, "pets/1/abc/original.jpg"); err != nil {
+		t.Errorf("Delete of a missing object error = %v, want nil", err)
+	}
+}
+
+func TestLocalStoragePathTraversal(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewLocal(filepath.Join(dir, "uploads"))
+	if err != nil {
+		t.Fatalf("NewLocal error = %v", err)
+	}
+	ctx := context.Background()
+
+	outside := filepath.Join(dir, "secret.txt")
+	if err := os.WriteFile(outside, []byte("secreto"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, key := range []string{"../secret.txt", "pets/../../secret.txt", "/" + outside} {
+		t.Run(key, func(t *testing.T) {
+			if err := store.Put(ctx, key, []byte("sobrescrito"), "text/plain"); err == nil {
+				t.Error("Put error = nil, want invalid key")
+			}
+			if _, err := store.Get(ctx, key); err == nil || errors.Is(err, ErrNotFound) {
+				t.Errorf("Get error = %v, want invalid key", err)
+			}
+			if err := store.Delete(ctx, key); err == nil {
+				t.Error("Delete error = nil, want invalid key")
+			}
+		})
+	}
+
+	if data, err := os.ReadFile(outside); err != nil || string(data) != "secreto" {
+		t.Errorf("file outside the root = %q, %v, want it untouched", data, err)
+	}
+}
@@ -0,0 +1,120 @@
+// Package webhooks signs and sends the HTTP requests of outbound webhooks.
+//
+// Every request is a JSON POST signed like the payment provider webhooks:
+// SignatureHeader is "t=<unix time>,v1=<hex HMAC-SHA256>", computed with the
+// webhook secret over "<unix time>.<body>". Receivers recompute the HMAC, compare
+// it in constant time and reject old timestamps to limit replays.
+//
+// Redirects are not followed: only a 2xx answer from the registered URL counts
+// as delivered.
+//
+// Configuration (environment variables):
+//   - WEBHOOK_TIMEOUT: Maximum duration of a request, including reading the response (default 10s)
+package webhooks
+
+import (
+	"backend/internal/utils/env"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Request headers sent with every delivery.
+const (
+	SignatureHeader = "X-Webhook-Signature" // "t=<unix time>,v1=<hex HMAC-SHA256>"
+	EventHeader     = "X-Webhook-Event"     // Event type
+	EventIDHeader   = "X-Webhook-Event-ID"  // Event identifier, repeated on retries and replays
+	DeliveryHeader  = "X-Webhook-Delivery"  // Delivery identifier
+)
+
+// maxResponseBody is how much of the response body is kept for the delivery log.
+const maxResponseBody = 1024
+
+var client = &http.Client{
+	Timeout: env.GetDuration("WEBHOOK_TIMEOUT", 10*time.Second),
+	CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+// Request is a delivery to send.
+type Request struct {
+	URL        string // Endpoint of the webhook
+	Secret     string // Signing secret of the webhook
+	EventType  string // Event type
+	EventID    string // Event identifier
+	DeliveryID uint   // Delivery identifier
+	Body       []byte // JSON payload
+}
+
+// Response is the outcome of a delivery request.
+type Response struct {
+	StatusCode int           // HTTP status (0 without response)
+	Body       string        // Beginning of the response body
+	Duration   time.Duration // Time taken by the request
+}
+
+// Delivered reports whether the endpoint accepted the event.
+func (r Response) Delivered() bool {
+	return r.StatusCode >= 200 && r.StatusCode < 300
+}
+
+// Send signs and posts a delivery to its endpoint.
+//
+// Parameters:
+//   - ctx: Request context
+//   - req: Delivery to send
+//
+// Returns:
+//   - Response: Status, beginning of the body and duration (also filled on rejected statuses)
+//   - error: Network error, or rejected status (non-2xx); nil when delivered
+func Send(ctx context.Context, req Request) (Response, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.URL, bytes.NewReader(req.Body))
+	if err != nil {
+		return Response{}, fmt.Errorf("URL de webhook inválida: %v", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("User-Agent", "AdoptionSystem-Webhooks/1.0")
+	httpReq.Header.Set(EventHeader, req.EventType)
+	httpReq.Header.Set(EventIDHeader, req.EventID)
+	httpReq.Header.Set(DeliveryHeader, strconv.FormatUint(uint64(req.DeliveryID), 10))
+	httpReq.Header.Set(SignatureHeader, Sign(req.Secret, req.Body, time.Now()))
+
+	start := time.Now()
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return Response{Duration: time.Since(start)}, err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	// Drain a little more so the connection can be reused
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	result := Response{StatusCode: resp.StatusCode, Body: string(body), Duration: time.Since(start)}
+	if !result.Delivered() {
+		return result, fmt.Errorf("el endpoint respondió %d", resp.StatusCode)
+	}
+
+	return result, nil
+}
+
+// Sign returns the SignatureHeader value of a body signed with a secret at the given time.
+func Sign(secret string, body []byte, at time.Time) string {
+	timestamp := strconv.FormatInt(at.Unix(), 10)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "t=" + timestamp + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,159 @@
+package webhooks
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSign(t *testing.T) {
+	at := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	body := []byte(`{"type":"pet.adopted"}`)
+
+	tests := []struct {
+		name   string
+		secret string
+		body   []byte
+		at     time.Time
+		want   string
+	}{
+		{
+			name:   "known signature",
+			secret: "whsec_test",
+			body:   body,
+			at:     at,
+			want:   "t=1792324800,v1=98b28dfd65ca704d215b1897768547d4e16b59438ca60276200a6a39df22b1b8",
+		},
+		{
+			name:   "time zone does not matter",
+			secret: "whsec_test",
+			body:   body,
+			at:     at.In(time.FixedZone("CEST", 2*60*60)),
+			want:   "t=1792324800,v1=98b28dfd65ca704d215b1897768547d4e16b59438ca60276200a6a39df22b1b8",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Sign(tt.secret, tt.body, tt.at); got != tt.want {
+				t.Errorf("Sign = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	// Any change of secret, body or time changes the signature
+	base := Sign("whsec_test", body, at)
+	for name, other := range map[string]string{
+		"other secret": Sign("whsec_other", body, at),
+		"other body":   Sign("whsec_test", []byte(`{"type":"pet.created"}`), at),
+		"other time":   Sign("whsec_test", body, at.Add(time.Second)),
+	} {
+		if other[strings.Index(other, "v1="):] == base[strings.Index(base, "v1="):] {
+			t.Errorf("%s: signature unchanged", name)
+		}
+	}
+}
+
+func TestSend(t *testing.T) {
+	var received *http.Request
+	var receivedBody []byte
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		receivedBody, _ = io.ReadAll(r.Body)
+
+		switch r.URL.Path {
+		case "/ok":
+			w.WriteHeader(http.StatusNoContent)
+		case "/error":
+			w.WriteHeader(http.StatusInternalServerError)
+			io.WriteString(w, strings.Repeat("x", 5000))
+		case "/redirect":
+			http.Redirect(w, r, "/ok", http.StatusFound)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+		wantErr    bool
+	}{
+		{name: "delivered", path: "/ok", wantStatus: http.StatusNoContent},
+		{name: "rejected status", path: "/error", wantStatus: http.StatusInternalServerError, wantErr: true},
+		{name: "redirect not followed", path: "/redirect", wantStatus: http.StatusFound, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := Request{
+				URL:        server.URL + tt.path,
+				Secret:     "whsec_test",
+				EventType:  "pet.adopted",
+				EventID:    "evt_1",
+				DeliveryID: 7,
+				Body:       []byte(`{"id":"evt_1"}`),
+			}
+
+			resp, err := Send(context.Background(), req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Send error = %v, want error %v", err, tt.wantErr)
+			}
+			if resp.StatusCode != tt.wantStatus || resp.Delivered() == tt.wantErr {
+				t.Errorf("Send status = %d (delivered %v), want %d", resp.StatusCode, resp.Delivered(), tt.wantStatus)
+			}
+			if len(resp.Body) > maxResponseBody {
+				t.Errorf("response body kept %d bytes, want at most %d", len(resp.Body), maxResponseBody)
+			}
+			if received.URL.Path != tt.path {
+				t.Errorf("last request path = %s, want %s (redirect followed)", received.URL.Path, tt.path)
+			}
+
+			if got := received.Header.Get(EventHeader); got != "pet.adopted" {
+				t.Errorf("%s = %q", EventHeader, got)
+			}
+			if got := received.Header.Get(EventIDHeader); got != "evt_1" {
+				t.Errorf("%s = %q", EventIDHeader, got)
+			}
+			if got := received.Header.Get(DeliveryHeader); got != "7" {
+				t.Errorf("%s = %q", DeliveryHeader, got)
+			}
+
+			// Verify the signature the way a receiver would
+			var timestamp, signature string
+			for _, part := range strings.Split(received.Header.Get(SignatureHeader), ",") {
+				key, value, _ := strings.Cut(part, "=")
+				if key == "t" {
+					timestamp = value
+				} else if key == "v1" {
+					signature = value
+				}
+			}
+			unix, _ := strconv.ParseInt(timestamp, 10, 64)
+			if age := time.Since(time.Unix(unix, 0)); age < 0 || age > time.Minute {
+				t.Errorf("signature timestamp %s is not current", timestamp)
+			}
+			mac := hmac.New(sha256.New, []byte("whsec_test"))
+			mac.Write([]byte(timestamp + "."))
+			mac.Write(receivedBody)
+			if !hmac.Equal([]byte(signature), []byte(hex.EncodeToString(mac.Sum(nil)))) {
+				t.Error("signature does not verify against the received body")
+			}
+		})
+	}
+}
+
+func TestSendInvalidURL(t *testing.T) {
+	if _, err := Send(context.Background(), Request{URL: "://no-scheme"}); err == nil {
+		t.Error("Send error = nil, want invalid URL")
+	}
+}
@@ -0,0 +1,80 @@
+// Package env provides helpers for reading configuration from environment variables.
+// Every helper falls back to a default value when the variable is unset or malformed,
+// so the application can run locally without any configuration.
+package env
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+// Get returns the value of the environment variable key, or def if it is unset or empty.
+//
+// Parameters:
+//   - key: Name of the environment variable
+//   - def: Default value used when the variable is not set
+//
+// Returns:
+//   - string: Configured value or default
+func Get(key string, def string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+
+	return def
+}
+
+// GetInt returns the integer value of the environment variable key, or def if it
+// is unset or cannot be parsed.
+//
+// Parameters:
+//   - key: Name of the environment variable
+//   - def: Default value used when the variable is not set or invalid
+//
+// Returns:
+//   - int64: Configured value or default
+func GetInt(key string, def int64) int64 {
+	value, err := strconv.ParseInt(os.Getenv(key), 10, 64)
+	if err != nil {
+		return def
+	}
+
+	return value
+}
+
+// GetBool returns the boolean value of the environment variable key, or def if it
+// is unset or cannot be parsed.
+//
+// Parameters:
+//   - key: Name of the environment variable
+//   - def: Default value used when the variable is not set or invalid
+//
+// Returns:
+//   - bool: Configured value or default
+func GetBool(key string, def bool) bool {
+	value, err := strconv.ParseBool(os.Getenv(key))
+	if err != nil {
+		return def
+	}
+
+	return value
+}
+
+// GetDuration returns the duration value (e.g. "15m", "24h") of the environment
+// variable key, or def if it is unset or cannot be parsed.
+//
+// Parameters:
+//   - key: Name of the environment variable
+//   - def: Default value used when the variable is not set or invalid
+//
+// Returns:
+//   - time.Duration: Configured value or default
+func GetDuration(key string, def time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil {
+		return def
+	}
+
+	return value
+}
@@ -3,6 +3,7 @@ package main
 import (
 	api "backend/internal/api/routes"
 	"backend/internal/db"
+	services "backend/internal/services/backend_calls"
 	"database/sql"
 	"log"
 
@@ -14,11 +15,13 @@ import (
 
 /*
 Main entry point for the application.
-This function initializes the database connection and sets up the CORS middleware for the Echo web framework.
+This function initializes the database connection, starts the background job scheduler
+and sets up the CORS middleware for the Echo web framework.
 It also registers user routes defined in the API package and starts the Echo server on port 8080.
 */
 func main() {
 	defer setupDatabase().Close()
+	services.StartScheduler()
 	setupCORS()
 }
 
@@ -31,13 +34,34 @@ func setupCORS() {
 	api.RegisterUserRoutes(e)
 	api.RegisterPetRoutes(e)
 	api.RegisterSpeciesRoutes(e)
+	api.RegisterPetPhotoRoutes(e)
+	api.RegisterSavedSearchRoutes(e)
+	api.RegisterPetFavoriteRoutes(e)
+	api.RegisterMedicalRoutes(e)
+	api.RegisterJobRoutes(e)
+	api.RegisterLostFoundRoutes(e)
+	api.RegisterOrganizationRoutes(e)
+	api.RegisterFosterRoutes(e)
+	api.RegisterVisitRoutes(e)
+	api.RegisterAdoptionRoutes(e)
+	api.RegisterPaymentRoutes(e)
+	api.RegisterDonationRoutes(e)
+	api.RegisterVolunteerRoutes(e)
+	api.RegisterLocationRoutes(e)
+	api.RegisterIntakeRoutes(e)
+	api.RegisterConversationRoutes(e)
+	api.RegisterEventRoutes(e)
+	api.RegisterWebhookRoutes(e)
+	api.RegisterOutboxRoutes(e)
+	api.RegisterMailTemplateRoutes(e)
+	api.RegisterNotificationRoutes(e)
 
 	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			w := c.Response().Writer
 			w.Header().Set("Access-Control-Allow-Origin", "http://localhost:4200")
 			w.Header().Set("Access-Control-Allow-Methods", "GET,POST,OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Organization-ID")
 			w.Header().Set("Access-Control-Allow-Credentials", "true")
 			return next(c)
 		}
@@ -46,7 +70,7 @@ func setupCORS() {
 	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
 		AllowOrigins: []string{"http://localhost:4200"},
 		AllowMethods: []string{echo.GET, echo.POST, echo.PUT, echo.DELETE},
-		AllowHeaders: []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization},
+		AllowHeaders: []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization, "X-Organization-ID"},
 	}))
 
 	e.Start(":8080")